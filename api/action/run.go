@@ -6,6 +6,8 @@ package action
 import (
 	"time"
 
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/params"
 )
 
@@ -25,3 +27,15 @@ func (c *Client) Run(run params.RunParams) ([]params.ActionResult, error) {
 	err := c.facade.FacadeCall("Run", run, &results)
 	return results.Results, err
 }
+
+// CheckNetwork asks the units of the specified applications, or of all
+// applications when none are specified, to probe the units they are
+// related to.
+func (c *Client) CheckNetwork(args params.CheckNetworkParams) (params.CheckNetworkResults, error) {
+	var results params.CheckNetworkResults
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotSupportedf("check-network on this version of Juju")
+	}
+	err := c.facade.FacadeCall("CheckNetwork", args, &results)
+	return results, err
+}
@@ -0,0 +1,64 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/action"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type checkNetworkSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&checkNetworkSuite{})
+
+func (s *checkNetworkSuite) TestCheckNetwork(c *gc.C) {
+	args := params.CheckNetworkParams{
+		Applications: []string{"mysql"},
+		Timeout:      time.Second,
+	}
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Action")
+			c.Check(request, gc.Equals, "CheckNetwork")
+			c.Check(a, jc.DeepEquals, args)
+			*(response.(*params.CheckNetworkResults)) = params.CheckNetworkResults{
+				Results:          []params.ActionResult{{Action: &params.Action{Tag: "action-1"}}},
+				RemoteModelUUIDs: []string{"deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	results, err := action.NewClient(apiCaller).CheckNetwork(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Check(results, jc.DeepEquals, params.CheckNetworkResults{
+		Results:          []params.ActionResult{{Action: &params.Action{Tag: "action-1"}}},
+		RemoteModelUUIDs: []string{"deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+	})
+}
+
+func (s *checkNetworkSuite) TestCheckNetworkNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 2,
+	}
+	_, err := action.NewClient(apiCaller).CheckNetwork(params.CheckNetworkParams{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
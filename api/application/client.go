@@ -672,6 +672,56 @@ func (c *Client) Unexpose(application string) error {
 	return c.facade.FacadeCall("Unexpose", params, nil)
 }
 
+// ExposeEndpoints exposes the application as Expose does, but only
+// to the spaces and CIDRs of the given expose settings, keyed on
+// endpoint name. The settings are merged with any already set for
+// the application. An empty endpoint name applies to all endpoints.
+func (c *Client) ExposeEndpoints(application string, exposedEndpoints map[string]params.ExposedEndpoint) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("exposing applications to specific spaces or CIDRs on this version of Juju")
+	}
+	args := params.ApplicationExpose{
+		ApplicationName:  application,
+		ExposedEndpoints: exposedEndpoints,
+	}
+	return c.facade.FacadeCall("Expose", args, nil)
+}
+
+// UnexposeEndpoints removes the expose settings of the given endpoints
+// of the application. The application is unexposed once no settings
+// remain.
+func (c *Client) UnexposeEndpoints(application string, endpoints []string) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("unexposing application endpoints on this version of Juju")
+	}
+	args := params.ApplicationUnexpose{
+		ApplicationName:  application,
+		ExposedEndpoints: endpoints,
+	}
+	return c.facade.FacadeCall("Unexpose", args, nil)
+}
+
+// OfferConnectionsInfo returns the cross-model relations of the given
+// offer hosted in the model, or of the given remote application which
+// consumes an offer, along with the health of each relation.
+func (c *Client) OfferConnectionsInfo(entity names.Tag) (*params.OfferConnectionsInfo, error) {
+	if c.BestAPIVersion() < 10 {
+		return nil, errors.NotSupportedf("showing offer connections on this version of Juju")
+	}
+	args := params.Entities{Entities: []params.Entity{{Tag: entity.String()}}}
+	var results params.OfferConnectionsInfoResults
+	if err := c.facade.FacadeCall("OfferConnectionsInfo", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Result, nil
+}
+
 // Get returns the configuration for the named application.
 func (c *Client) Get(application string) (*params.ApplicationGetResults, error) {
 	var results params.ApplicationGetResults
@@ -9,6 +9,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/application"
 	basetesting "github.com/juju/juju/api/base/testing"
@@ -30,7 +31,7 @@ type applicationSuite struct {
 var _ = gc.Suite(&applicationSuite{})
 
 func newClient(f basetesting.APICallerFunc) *application.Client {
-	return application.NewClient(basetesting.BestVersionCaller{f, 8})
+	return application.NewClient(basetesting.BestVersionCaller{f, 10})
 }
 
 func newClientV4(f basetesting.APICallerFunc) *application.Client {
@@ -246,6 +247,115 @@ func (s *applicationSuite) TestApplicationGetCharmURL(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestExposeEndpoints(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Expose")
+		c.Assert(a, jc.DeepEquals, params.ApplicationExpose{
+			ApplicationName: "application",
+			ExposedEndpoints: map[string]params.ExposedEndpoint{
+				"": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+			},
+		})
+		return nil
+	})
+	err := client.ExposeEndpoints("application", map[string]params.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestExposeEndpointsNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		},
+		BestVersion: 8,
+	})
+	err := client.ExposeEndpoints("application", map[string]params.ExposedEndpoint{"": {}})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.UnexposeEndpoints("application", []string{"db"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestUnexposeEndpoints(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Unexpose")
+		c.Assert(a, jc.DeepEquals, params.ApplicationUnexpose{
+			ApplicationName:  "application",
+			ExposedEndpoints: []string{"db"},
+		})
+		return nil
+	})
+	err := client.UnexposeEndpoints("application", []string{"db"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestOfferConnectionsInfo(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "OfferConnectionsInfo")
+		c.Assert(a, jc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "applicationoffer-hosted-db"}}})
+		c.Assert(response, gc.FitsTypeOf, &params.OfferConnectionsInfoResults{})
+		*(response.(*params.OfferConnectionsInfoResults)) = params.OfferConnectionsInfoResults{
+			Results: []params.OfferConnectionsInfoResult{{
+				Result: &params.OfferConnectionsInfo{
+					Name: "hosted-db",
+					Connections: []params.OfferConnectionInfo{{
+						RelationId: 1,
+						Key:        "remote-wordpress:db mysql:db",
+						Status:     params.EntityStatus{Status: "joined"},
+					}},
+				},
+			}},
+		}
+		return nil
+	})
+	info, err := client.OfferConnectionsInfo(names.NewApplicationOfferTag("hosted-db"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(info, jc.DeepEquals, &params.OfferConnectionsInfo{
+		Name: "hosted-db",
+		Connections: []params.OfferConnectionInfo{{
+			RelationId: 1,
+			Key:        "remote-wordpress:db mysql:db",
+			Status:     params.EntityStatus{Status: "joined"},
+		}},
+	})
+}
+
+func (s *applicationSuite) TestOfferConnectionsInfoError(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		*(response.(*params.OfferConnectionsInfoResults)) = params.OfferConnectionsInfoResults{
+			Results: []params.OfferConnectionsInfoResult{{
+				Error: &params.Error{Code: params.CodeNotFound, Message: "remote application \"db2\" not found"},
+			}},
+		}
+		return nil
+	})
+	_, err := client.OfferConnectionsInfo(names.NewApplicationTag("db2"))
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *applicationSuite) TestOfferConnectionsInfoNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		},
+		BestVersion: 9,
+	})
+	_, err := client.OfferConnectionsInfo(names.NewApplicationTag("db2"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *applicationSuite) TestSetCharm(c *gc.C) {
 	var called bool
 	toUint64Ptr := func(v uint64) *uint64 {
@@ -4,6 +4,10 @@
 package applicationoffers
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6"
@@ -317,3 +321,104 @@ func (c *Client) DestroyOffers(force bool, offerURLs ...string) error {
 	}
 	return result.Combine()
 }
+
+// CreateShareToken creates a token which can be used to consume the
+// specified offer until it expires or is revoked. It returns the details
+// of the token, along with the encoded token to pass to the consumer.
+func (c *Client) CreateShareToken(offerURL string, expiry time.Duration) (params.OfferShareToken, string, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return params.OfferShareToken{}, "", errors.NotImplementedf("CreateShareToken() (need v3+, have v%d)", bestVer)
+	}
+	if _, err := crossmodel.ParseOfferURL(offerURL); err != nil {
+		return params.OfferShareToken{}, "", errors.Trace(err)
+	}
+	args := params.OfferShareTokenArgs{
+		Args: []params.OfferShareTokenArg{{OfferURL: offerURL, Expiry: expiry}},
+	}
+	var results params.CreateOfferShareTokenResults
+	if err := c.facade.FacadeCall("CreateOfferShareTokens", args, &results); err != nil {
+		return params.OfferShareToken{}, "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.OfferShareToken{}, "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.OfferShareToken{}, "", errors.Trace(result.Error)
+	}
+	if result.Token == nil || result.ConsumeDetails == nil {
+		return params.OfferShareToken{}, "", errors.Errorf("missing share token for offer %q", offerURL)
+	}
+	encoded, err := EncodeShareToken(*result.ConsumeDetails)
+	if err != nil {
+		return params.OfferShareToken{}, "", errors.Trace(err)
+	}
+	return *result.Token, encoded, nil
+}
+
+// ListShareTokens returns the share tokens created for the specified offer.
+func (c *Client) ListShareTokens(offerURL string) ([]params.OfferShareToken, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return nil, errors.NotImplementedf("ListShareTokens() (need v3+, have v%d)", bestVer)
+	}
+	if _, err := crossmodel.ParseOfferURL(offerURL); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.OfferShareTokensResults
+	args := params.OfferURLs{OfferURLs: []string{offerURL}}
+	if err := c.facade.FacadeCall("ListOfferShareTokens", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].Tokens, nil
+}
+
+// RevokeShareToken revokes the share token with the specified id, so that
+// it can no longer be used to access the offer.
+func (c *Client) RevokeShareToken(offerURL, id string) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return errors.NotImplementedf("RevokeShareToken() (need v3+, have v%d)", bestVer)
+	}
+	if _, err := crossmodel.ParseOfferURL(offerURL); err != nil {
+		return errors.Trace(err)
+	}
+	var results params.ErrorResults
+	args := params.RevokeOfferShareTokenArgs{
+		Args: []params.RevokeOfferShareTokenArg{{OfferURL: offerURL, Id: id}},
+	}
+	if err := c.facade.FacadeCall("RevokeOfferShareTokens", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// EncodeShareToken encodes the details needed to consume an offer into
+// a share token which can be passed to "juju consume".
+func EncodeShareToken(details params.ConsumeOfferDetails) (string, error) {
+	data, err := json.Marshal(details)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeShareToken decodes a share token created by EncodeShareToken.
+func DecodeShareToken(token string) (params.ConsumeOfferDetails, error) {
+	var details params.ConsumeOfferDetails
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return details, errors.NotValidf("share token")
+	}
+	if err := json.Unmarshal(data, &details); err != nil {
+		return details, errors.NotValidf("share token")
+	}
+	if details.Offer == nil || details.Macaroon == nil || details.ControllerInfo == nil {
+		return details, errors.NotValidf("incomplete share token")
+	}
+	return details, nil
+}
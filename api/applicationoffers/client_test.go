@@ -701,3 +701,133 @@ func (s *crossmodelMockSuite) TestDestroyOffersForce(c *gc.C) {
 
 	c.Assert(err, gc.ErrorMatches, "DestroyOffers\\(\\).* not implemented")
 }
+
+func (s *crossmodelMockSuite) TestCreateShareToken(c *gc.C) {
+	mac, err := apitesting.NewMacaroon("id")
+	c.Assert(err, jc.ErrorIsNil)
+	details := params.ConsumeOfferDetails{
+		Offer:          &params.ApplicationOfferDetails{OfferURL: "me/prod.app", OfferUUID: "app-uuid"},
+		Macaroon:       mac,
+		ControllerInfo: &params.ExternalControllerInfo{Addrs: []string{"1.2.3.4"}},
+	}
+	token := params.OfferShareToken{Id: "token-0", OfferURL: "me/prod.app", CreatedBy: "me"}
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				called = true
+				c.Assert(request, gc.Equals, "CreateOfferShareTokens")
+				c.Assert(a, jc.DeepEquals, params.OfferShareTokenArgs{
+					Args: []params.OfferShareTokenArg{{OfferURL: "me/prod.app", Expiry: time.Hour}},
+				})
+				if results, ok := result.(*params.CreateOfferShareTokenResults); ok {
+					results.Results = []params.CreateOfferShareTokenResult{{
+						Token:          &token,
+						ConsumeDetails: &details,
+					}}
+				}
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	result, encoded, err := client.CreateShareToken("me/prod.app", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, token)
+
+	decoded, err := applicationoffers.DecodeShareToken(encoded)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decoded.Offer, jc.DeepEquals, details.Offer)
+	c.Assert(decoded.ControllerInfo, jc.DeepEquals, details.ControllerInfo)
+	c.Assert(decoded.Macaroon.Id(), jc.DeepEquals, mac.Id())
+}
+
+func (s *crossmodelMockSuite) TestCreateShareTokenNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fail()
+				return nil
+			},
+		),
+		BestVersion: 2,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	_, _, err := client.CreateShareToken("me/prod.app", time.Hour)
+	c.Assert(err, gc.ErrorMatches, "CreateShareToken\\(\\).* not implemented")
+}
+
+func (s *crossmodelMockSuite) TestListShareTokens(c *gc.C) {
+	tokens := []params.OfferShareToken{{Id: "token-0", OfferURL: "me/prod.app", CreatedBy: "me"}}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Assert(request, gc.Equals, "ListOfferShareTokens")
+				c.Assert(a, jc.DeepEquals, params.OfferURLs{OfferURLs: []string{"me/prod.app"}})
+				if results, ok := result.(*params.OfferShareTokensResults); ok {
+					results.Results = []params.OfferShareTokensResult{{Tokens: tokens}}
+				}
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	result, err := client.ListShareTokens("me/prod.app")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, tokens)
+}
+
+func (s *crossmodelMockSuite) TestRevokeShareToken(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				called = true
+				c.Assert(request, gc.Equals, "RevokeOfferShareTokens")
+				c.Assert(a, jc.DeepEquals, params.RevokeOfferShareTokenArgs{
+					Args: []params.RevokeOfferShareTokenArg{{OfferURL: "me/prod.app", Id: "token-0"}},
+				})
+				if results, ok := result.(*params.ErrorResults); ok {
+					results.Results = []params.ErrorResult{{
+						Error: &params.Error{Message: "fail"},
+					}}
+				}
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	err := client.RevokeShareToken("me/prod.app", "token-0")
+	c.Assert(err, gc.ErrorMatches, "fail")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *crossmodelMockSuite) TestDecodeShareTokenInvalid(c *gc.C) {
+	_, err := applicationoffers.DecodeShareToken("not a token")
+	c.Assert(err, gc.ErrorMatches, "share token not valid")
+
+	encoded, err := applicationoffers.EncodeShareToken(params.ConsumeOfferDetails{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = applicationoffers.DecodeShareToken(encoded)
+	c.Assert(err, gc.ErrorMatches, "incomplete share token not valid")
+}
@@ -257,6 +257,22 @@ func (c *Client) ConfigSet(values map[string]interface{}) error {
 	)
 }
 
+// FindOrphans returns the cloud resources, in each cloud region
+// hosting the controller's models, that are tagged as being managed by
+// the controller but are no longer used by any model. If destroy is
+// true, the controller also destroys them.
+func (c *Client) FindOrphans(destroy bool) ([]params.CloudOrphans, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("finding orphaned resources with this version of Juju")
+	}
+	var result params.FindOrphansResults
+	err := c.facade.FacadeCall("FindOrphans", params.FindOrphansArgs{Destroy: destroy}, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Results, nil
+}
+
 // MigrationSpec holds the details required to start the migration of
 // a single model.
 type MigrationSpec struct {
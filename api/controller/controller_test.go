@@ -380,3 +380,49 @@ func (s *Suite) TestConfigSetAgainstOlderAPIVersion(c *gc.C) {
 	})
 	c.Assert(err, gc.ErrorMatches, "this controller version doesn't support updating controller config")
 }
+
+func (s *Suite) TestFindOrphans(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 6,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.FindOrphansResults)) = params.FindOrphansResults{
+				Results: []params.CloudOrphans{{
+					CloudTag: "cloud-aws",
+					Region:   "us-east-1",
+					Orphans: []params.OrphanedResource{{
+						Kind:   "instance",
+						Id:     "i-0",
+						Reason: "model no longer exists",
+					}},
+					Destroyed: true,
+				}},
+			}
+			return stub.NextErr()
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	results, err := client.FindOrphans(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.CloudOrphans{{
+		CloudTag: "cloud-aws",
+		Region:   "us-east-1",
+		Orphans: []params.OrphanedResource{{
+			Kind:   "instance",
+			Id:     "i-0",
+			Reason: "model no longer exists",
+		}},
+		Destroyed: true,
+	}})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.FindOrphans", []interface{}{params.FindOrphansArgs{Destroy: true}}},
+	})
+}
+
+func (s *Suite) TestFindOrphansNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 5}
+	client := controller.NewClient(apiCaller)
+	_, err := client.FindOrphans(false)
+	c.Assert(err, gc.ErrorMatches, "finding orphaned resources with this version of Juju not supported")
+}
@@ -6,10 +6,12 @@ package crosscontroller
 import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -72,3 +74,38 @@ func (c *Client) WatchControllerInfo() (watcher.NotifyWatcher, error) {
 	w := apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), results.Results[0])
 	return w, nil
 }
+
+// OfferRedirect returns the controller now hosting the offer with the
+// specified UUID, if the model hosting the offer has been migrated away
+// from the remote controller. A NotFound error is returned if the offer
+// hasn't moved.
+func (c *Client) OfferRedirect(offerUUID string) (*crossmodel.ControllerInfo, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("offer redirects on this version of Juju")
+	}
+	args := params.OfferRedirectArgs{OfferUUIDs: []string{offerUUID}}
+	var results params.OfferRedirectResults
+	if err := c.facade.FacadeCall("OfferRedirects", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		if params.IsCodeNotFound(err) {
+			return nil, errors.NotFoundf("redirect for offer %q", offerUUID)
+		}
+		return nil, errors.Trace(err)
+	}
+	redirect := results.Results[0].Result
+	controllerTag, err := names.ParseControllerTag(redirect.Controller.ControllerTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &crossmodel.ControllerInfo{
+		ControllerTag: controllerTag,
+		Alias:         redirect.Controller.Alias,
+		Addrs:         redirect.Controller.Addrs,
+		CACert:        redirect.Controller.CACert,
+	}, nil
+}
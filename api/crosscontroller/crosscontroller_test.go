@@ -4,12 +4,14 @@
 package crosscontroller_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/crosscontroller"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -87,3 +89,68 @@ func (s *CrossControllerSuite) TestWatchExternalControllers(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 	c.Assert(w, gc.IsNil)
 }
+
+func (s *CrossControllerSuite) TestOfferRedirect(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "CrossController")
+			c.Check(version, gc.Equals, 2)
+			c.Check(request, gc.Equals, "OfferRedirects")
+			c.Check(arg, jc.DeepEquals, params.OfferRedirectArgs{OfferUUIDs: []string{"offer-uuid"}})
+			c.Assert(result, gc.FitsTypeOf, &params.OfferRedirectResults{})
+			*(result.(*params.OfferRedirectResults)) = params.OfferRedirectResults{
+				Results: []params.OfferRedirectResult{{
+					Result: &params.OfferRedirect{
+						OfferUUID: "offer-uuid",
+						ModelTag:  coretesting.ModelTag.String(),
+						Controller: params.ExternalControllerInfo{
+							ControllerTag: coretesting.ControllerTag.String(),
+							Addrs:         []string{"1.2.3.4:17070"},
+							CACert:        "ca-cert",
+						},
+					},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := crosscontroller.NewClient(apiCaller)
+	info, err := client.OfferRedirect("offer-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, &crossmodel.ControllerInfo{
+		ControllerTag: coretesting.ControllerTag,
+		Addrs:         []string{"1.2.3.4:17070"},
+		CACert:        "ca-cert",
+	})
+}
+
+func (s *CrossControllerSuite) TestOfferRedirectNotFound(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.OfferRedirectResults)) = params.OfferRedirectResults{
+				Results: []params.OfferRedirectResult{{
+					Error: &params.Error{Code: params.CodeNotFound, Message: "not found"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := crosscontroller.NewClient(apiCaller)
+	_, err := client.OfferRedirect("offer-uuid")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CrossControllerSuite) TestOfferRedirectNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := crosscontroller.NewClient(apiCaller)
+	_, err := client.OfferRedirect("offer-uuid")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -0,0 +1,102 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnsrecords
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+// API provides access to the DNS records API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side DNS records facade.
+func NewAPI(caller base.APICaller) *API {
+	return &API{facade: base.NewFacadeCaller(caller, "DNSRecords")}
+}
+
+// Record holds the addresses that a name in the model's DNS domain
+// should resolve to.
+type Record struct {
+	// Name is the name relative to the model's domain, e.g.
+	// "wordpress" or "wordpress-0".
+	Name string
+
+	// Addresses are the IPv4 and IPv6 addresses of the name.
+	Addresses []string
+}
+
+// Records returns a Record for each exposed application in the model,
+// and for each of their units.
+func (api *API) Records() ([]Record, error) {
+	var result params.DNSRecordsResult
+	if err := api.facade.FacadeCall("Records", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	records := make([]Record, len(result.Records))
+	for i, r := range result.Records {
+		records[i] = Record{Name: r.Name, Addresses: r.Addresses}
+	}
+	return records, nil
+}
+
+// DNSConfig holds the model's DNS settings.
+type DNSConfig struct {
+	// ModelName is the name of the model, which names the domain
+	// that the records live in, <model>.<zone>.
+	ModelName string
+
+	// Zone is the fully qualified name of the DNS zone that holds
+	// the model's domain. If it is empty, no records are kept.
+	Zone string
+
+	// TTL is the time to live of the records, in seconds.
+	TTL int
+
+	// Backend names the service that keeps the records.
+	Backend string
+
+	// RFC2136Server is the address of the server that accepts
+	// dynamic updates when the rfc2136 backend is used.
+	RFC2136Server string
+
+	// TSIGKey is the "<name>:<base64 secret>" key used to sign
+	// dynamic updates, if any.
+	TSIGKey string
+}
+
+// DNSConfig returns the model's DNS settings.
+func (api *API) DNSConfig() (DNSConfig, error) {
+	var result params.DNSConfigResult
+	if err := api.facade.FacadeCall("DNSConfig", nil, &result); err != nil {
+		return DNSConfig{}, errors.Trace(err)
+	}
+	return DNSConfig{
+		ModelName:     result.ModelName,
+		Zone:          result.Zone,
+		TTL:           result.TTL,
+		Backend:       result.Backend,
+		RFC2136Server: result.RFC2136Server,
+		TSIGKey:       result.TSIGKey,
+	}, nil
+}
+
+// WatchRecords returns a NotifyWatcher which notifies when the results
+// of Records or DNSConfig may have changed.
+func (api *API) WatchRecords() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := api.facade.FacadeCall("WatchRecords", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(api.facade.RawAPICaller(), result), nil
+}
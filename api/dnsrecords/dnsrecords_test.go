@@ -0,0 +1,89 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnsrecords_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/dnsrecords"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type dnsRecordsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&dnsRecordsSuite{})
+
+func (s *dnsRecordsSuite) TestRecords(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "DNSRecords")
+		c.Check(request, gc.Equals, "Records")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.DNSRecordsResult)) = params.DNSRecordsResult{
+			Records: []params.DNSRecord{{
+				Name:      "wordpress",
+				Addresses: []string{"203.0.113.1", "2001:db8::1"},
+			}},
+		}
+		return nil
+	})
+	records, err := dnsrecords.NewAPI(caller).Records()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, jc.DeepEquals, []dnsrecords.Record{{
+		Name:      "wordpress",
+		Addresses: []string{"203.0.113.1", "2001:db8::1"},
+	}})
+}
+
+func (s *dnsRecordsSuite) TestRecordsError(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	_, err := dnsrecords.NewAPI(caller).Records()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *dnsRecordsSuite) TestDNSConfig(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "DNSRecords")
+		c.Check(request, gc.Equals, "DNSConfig")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.DNSConfigResult)) = params.DNSConfigResult{
+			ModelName:     "testmodel",
+			Zone:          "example.com.",
+			TTL:           60,
+			Backend:       "rfc2136",
+			RFC2136Server: "10.0.0.53",
+			TSIGKey:       "juju-key:c2VjcmV0",
+		}
+		return nil
+	})
+	cfg, err := dnsrecords.NewAPI(caller).DNSConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, dnsrecords.DNSConfig{
+		ModelName:     "testmodel",
+		Zone:          "example.com.",
+		TTL:           60,
+		Backend:       "rfc2136",
+		RFC2136Server: "10.0.0.53",
+		TSIGKey:       "juju-key:c2VjcmV0",
+	})
+}
+
+func (s *dnsRecordsSuite) TestWatchRecordsError(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "WatchRecords")
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	_, err := dnsrecords.NewAPI(caller).WatchRecords()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,70 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package egressfirewall implements the client-side API facade used
+// by the egressfirewall worker.
+package egressfirewall
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+// Facade provides access to the EgressFirewall API facade.
+type Facade struct {
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side EgressFirewall facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "EgressFirewall"),
+	}
+}
+
+// EgressRules returns the egress rules of the machine, and whether the
+// machine must enforce them itself.
+func (f *Facade) EgressRules(machineId string) (params.EgressRules, bool, error) {
+	args := params.Entities{Entities: []params.Entity{{
+		Tag: names.NewMachineTag(machineId).String(),
+	}}}
+	var results params.EgressRulesResults
+	err := f.caller.FacadeCall("EgressRules", args, &results)
+	if err != nil {
+		return params.EgressRules{}, false, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.EgressRules{}, false, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.EgressRules{}, false, result.Error
+	}
+	return result.Rules, result.Enforce, nil
+}
+
+// WatchEgressRules returns a NotifyWatcher that notifies when the
+// results of EgressRules for the machine may have changed.
+func (f *Facade) WatchEgressRules(machineId string) (watcher.NotifyWatcher, error) {
+	args := params.Entities{Entities: []params.Entity{{
+		Tag: names.NewMachineTag(machineId).String(),
+	}}}
+	var results params.NotifyWatchResults
+	err := f.caller.FacadeCall("WatchEgressRules", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(f.caller.RawAPICaller(), result), nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egressfirewall_test
+
+import (
+	"errors"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/egressfirewall"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) TestEgressRules(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "EgressFirewall")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		*response.(*params.EgressRulesResults) = params.EgressRulesResults{
+			Results: []params.EgressRulesResult{{
+				Rules: params.EgressRules{
+					Restricted:       true,
+					DestinationCIDRs: []string{"10.0.0.1/32"},
+				},
+				Enforce: true,
+			}},
+		}
+		return nil
+	})
+	facade := egressfirewall.NewFacade(apiCaller)
+
+	rules, enforce, err := facade.EgressRules("42")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, params.EgressRules{
+		Restricted:       true,
+		DestinationCIDRs: []string{"10.0.0.1/32"},
+	})
+	c.Assert(enforce, jc.IsTrue)
+
+	stub.CheckCalls(c, []testing.StubCall{{
+		"EgressRules", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: "machine-42"}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		return errors.New("blam")
+	})
+	facade := egressfirewall.NewFacade(apiCaller)
+
+	_, _, err := facade.EgressRules("42")
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *facadeSuite) TestInnerError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.EgressRulesResults) = params.EgressRulesResults{
+			Results: []params.EgressRulesResult{{
+				Error: &params.Error{Message: "blam"},
+			}},
+		}
+		return nil
+	})
+	facade := egressfirewall.NewFacade(apiCaller)
+
+	_, _, err := facade.EgressRules("42")
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *facadeSuite) TestWatchEgressRulesInnerError(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "EgressFirewall")
+		stub.AddCall(request, args)
+		*response.(*params.NotifyWatchResults) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "blam"},
+			}},
+		}
+		return nil
+	})
+	facade := egressfirewall.NewFacade(apiCaller)
+
+	_, err := facade.WatchEgressRules("42")
+	c.Assert(err, gc.ErrorMatches, "blam")
+	stub.CheckCalls(c, []testing.StubCall{{
+		"WatchEgressRules", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: "machine-42"}},
+		}},
+	}})
+}
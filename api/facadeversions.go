@@ -11,15 +11,15 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       2,
+	"Action":                       3,
 	"ActionPruner":                 1,
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  8,
-	"ApplicationOffers":            2,
+	"Application":                  10,
+	"ApplicationOffers":            3,
 	"ApplicationScaler":            1,
 	"Backups":                      2,
 	"Block":                        2,
@@ -34,33 +34,36 @@ var facadeVersions = map[string]int{
 	"Cleaner":                      2,
 	"Client":                       2,
 	"Cloud":                        2,
-	"Controller":                   5,
+	"Controller":                   6,
 	"CredentialManager":            1,
 	"CredentialValidator":          1,
-	"CrossController":              1,
+	"CrossController":              2,
 	"CrossModelRelations":          1,
 	"Deployer":                     1,
 	"DiskManager":                  2,
+	"DNSRecords":                   1,
+	"EgressFirewall":               1,
 	"EntityWatcher":                2,
 	"ExternalControllerUpdater":    1,
 	"FanConfigurer":                1,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   5,
+	"Firewaller":                   7,
 	"FirewallRules":                1,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
 	"ImageMetadata":                3,
-	"ImageMetadataManager":         1,
+	"ImageMetadataManager":         2,
 	"InstancePoller":               3,
 	"KeyManager":                   1,
 	"KeyUpdater":                   1,
 	"LeadershipService":            2,
 	"LifeFlag":                     1,
+	"LoadBalancer":                 1,
 	"LogForwarding":                1,
 	"Logger":                       1,
 	"MachineActions":               1,
-	"MachineManager":               5,
+	"MachineManager":               6,
 	"MachineUndertaker":            1,
 	"Machiner":                     1,
 	"MeterStatus":                  1,
@@ -75,6 +78,7 @@ var facadeVersions = map[string]int{
 	"ModelConfig":                  2,
 	"ModelManager":                 4,
 	"ModelUpgrader":                1,
+	"NetworkConfigurer":            1,
 	"NotifyWatcher":                1,
 	"OfferStatusWatcher":           1,
 	"Payloads":                     1,
@@ -85,7 +89,7 @@ var facadeVersions = map[string]int{
 	"Reboot":                       2,
 	"RelationStatusWatcher":        1,
 	"RelationUnitsWatcher":         1,
-	"RemoteRelations":              1,
+	"RemoteRelations":              3,
 	"Resources":                    1,
 	"ResourcesHookContext":         1,
 	"Resumer":                      2,
@@ -97,7 +101,7 @@ var facadeVersions = map[string]int{
 	"Storage":                      4,
 	"StorageProvisioner":           4,
 	"StringsWatcher":               1,
-	"Subnets":                      2,
+	"Subnets":                      3,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
 	"Uniter":                       8,
@@ -75,3 +75,32 @@ func (s *Application) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// ExposeInfo returns whether this application is exposed and, if so,
+// the CIDRs that its open ports may be reached from. Controllers that
+// do not support expose settings expose applications to everywhere.
+func (s *Application) ExposeInfo() (bool, []string, error) {
+	if s.st.BestAPIVersion() < 6 {
+		exposed, err := s.IsExposed()
+		if err != nil || !exposed {
+			return false, nil, err
+		}
+		return true, []string{"0.0.0.0/0", "::/0"}, nil
+	}
+	var results params.ExposeInfoResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetExposeInfo", args, &results)
+	if err != nil {
+		return false, nil, err
+	}
+	if len(results.Results) != 1 {
+		return false, nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return false, nil, result.Error
+	}
+	return result.Exposed, result.IngressCIDRs, nil
+}
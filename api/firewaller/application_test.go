@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/state"
 )
 
 type applicationSuite struct {
@@ -91,3 +92,26 @@ func (s *applicationSuite) TestIsExposed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(isExposed, jc.IsFalse)
 }
+
+func (s *applicationSuite) TestExposeInfo(c *gc.C) {
+	exposed, cidrs, err := s.apiApplication.ExposeInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exposed, jc.IsFalse)
+	c.Assert(cidrs, gc.HasLen, 0)
+
+	err = s.application.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	exposed, cidrs, err = s.apiApplication.ExposeInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exposed, jc.IsTrue)
+	c.Assert(cidrs, jc.DeepEquals, []string{"0.0.0.0/0", "::/0"})
+
+	err = s.application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	exposed, cidrs, err = s.apiApplication.ExposeInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exposed, jc.IsTrue)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/24"})
+}
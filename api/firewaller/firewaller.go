@@ -125,6 +125,40 @@ func (c *Client) WatchOpenedPorts() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchSubnetSpaces returns a NotifyWatcher that notifies when the
+// model's subnets, or the spaces they are in, change.
+func (c *Client) WatchSubnetSpaces() (watcher.NotifyWatcher, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("watching subnet spaces")
+	}
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchSubnetSpaces", nil, &result); err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// WatchEgressRules returns a NotifyWatcher that notifies when the
+// egress rules of the model's machines may have changed.
+func (c *Client) WatchEgressRules() (watcher.NotifyWatcher, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("watching egress rules")
+	}
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchEgressRules", nil, &result); err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // Relation provides access to methods of a state.Relation through the
 // facade.
 func (c *Client) Relation(tag names.RelationTag) (*Relation, error) {
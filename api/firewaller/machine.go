@@ -6,6 +6,7 @@ package firewaller
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	apiwatcher "github.com/juju/juju/api/watcher"
@@ -163,3 +164,27 @@ func (m *Machine) IsManual() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// EgressRules returns the rules restricting the outbound traffic of the
+// machine, according to the model's egress-policy.
+func (m *Machine) EgressRules() (params.EgressRules, error) {
+	if m.st.BestAPIVersion() < 7 {
+		return params.EgressRules{}, errors.NotSupportedf("egress rules")
+	}
+	var results params.EgressRulesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: m.tag.String()}},
+	}
+	err := m.st.facade.FacadeCall("GetMachineEgressRules", args, &results)
+	if err != nil {
+		return params.EgressRules{}, err
+	}
+	if len(results.Results) != 1 {
+		return params.EgressRules{}, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.EgressRules{}, result.Error
+	}
+	return result.Rules, nil
+}
@@ -4,6 +4,7 @@
 package firewaller_test
 
 import (
+	"github.com/juju/collections/set"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -152,3 +153,19 @@ func (s *machineSuite) TestIsManual(c *gc.C) {
 	c.Assert(answer, jc.IsTrue)
 
 }
+
+func (s *machineSuite) TestEgressRules(c *gc.C) {
+	rules, err := s.apiMachine.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, params.EgressRules{})
+
+	err = s.Model.UpdateModelConfig(map[string]interface{}{
+		"egress-policy":        "relations",
+		"egress-allowed-cidrs": "192.168.10.0/24",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = s.apiMachine.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules.Restricted, jc.IsTrue)
+	c.Assert(set.NewStrings(rules.DestinationCIDRs...).Contains("192.168.10.0/24"), jc.IsTrue)
+}
@@ -83,3 +83,45 @@ func (c *Client) Delete(imageId string) error {
 	}
 	return nil
 }
+
+// ListPolicies returns the model's image policies.
+func (c *Client) ListPolicies() ([]params.ImagePolicy, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("image policies on this controller")
+	}
+	var out params.ImagePolicies
+	if err := c.facade.FacadeCall("ListPolicies", nil, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Policies, nil
+}
+
+// SetPolicy sets the model's image policy for the policy's series and
+// region, replacing any policy already set for them.
+func (c *Client) SetPolicy(policy params.ImagePolicy) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("image policies on this controller")
+	}
+	in := params.ImagePolicies{Policies: []params.ImagePolicy{policy}}
+	var out params.ErrorResults
+	if err := c.facade.FacadeCall("SetPolicies", in, &out); err != nil {
+		return errors.Trace(err)
+	}
+	return out.OneError()
+}
+
+// RemovePolicy removes the model's image policy for the series and
+// region. An empty region identifies the policy for all regions.
+func (c *Client) RemovePolicy(series, region string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("image policies on this controller")
+	}
+	in := params.ImagePolicyKeys{
+		Keys: []params.ImagePolicyKey{{Series: series, Region: region}},
+	}
+	var out params.ErrorResults
+	if err := c.facade.FacadeCall("RemovePolicies", in, &out); err != nil {
+		return errors.Trace(err)
+	}
+	return out.OneError()
+}
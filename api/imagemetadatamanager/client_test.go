@@ -312,3 +312,84 @@ func (s *imagemetadataSuite) TestDeleteFacadeCallError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, msg)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *imagemetadataSuite) TestListPolicies(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ImageMetadataManager")
+			c.Check(request, gc.Equals, "ListPolicies")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.ImagePolicies)) = params.ImagePolicies{
+				Policies: []params.ImagePolicy{{Series: "bionic", Stream: "daily"}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := imagemetadatamanager.NewClient(apiCaller)
+	policies, err := client.ListPolicies()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policies, jc.DeepEquals, []params.ImagePolicy{{Series: "bionic", Stream: "daily"}})
+}
+
+func (s *imagemetadataSuite) TestSetPolicy(c *gc.C) {
+	policy := params.ImagePolicy{
+		Series:  "bionic",
+		Region:  "us-east-1",
+		ImageId: "ami-1234",
+		Arch:    "amd64",
+	}
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(request, gc.Equals, "SetPolicies")
+			c.Check(a, jc.DeepEquals, params.ImagePolicies{Policies: []params.ImagePolicy{policy}})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := imagemetadatamanager.NewClient(apiCaller)
+	err := client.SetPolicy(policy)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *imagemetadataSuite) TestRemovePolicy(c *gc.C) {
+	called := false
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			called = true
+			c.Check(request, gc.Equals, "RemovePolicies")
+			c.Check(a, jc.DeepEquals, params.ImagePolicyKeys{
+				Keys: []params.ImagePolicyKey{{Series: "bionic", Region: "us-east-1"}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := imagemetadatamanager.NewClient(apiCaller)
+	err := client.RemovePolicy("bionic", "us-east-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *imagemetadataSuite) TestPoliciesNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := imagemetadatamanager.NewClient(apiCaller)
+	_, err := client.ListPolicies()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.SetPolicy(params.ImagePolicy{Series: "bionic"})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.RemovePolicy("bionic", "")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
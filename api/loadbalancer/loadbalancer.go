@@ -0,0 +1,81 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package loadbalancer
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+// API provides access to the load balancer API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side load balancer facade.
+func NewAPI(caller base.APICaller) *API {
+	return &API{facade: base.NewFacadeCaller(caller, "LoadBalancer")}
+}
+
+// Target describes the traffic that an exposed application's load
+// balancer should forward.
+type Target struct {
+	// ApplicationName is the name of the exposed application.
+	ApplicationName string
+
+	// Ports are the port ranges opened by the application's units.
+	Ports []network.PortRange
+
+	// InstanceIds are the instances hosting the application's
+	// healthy units.
+	InstanceIds []instance.Id
+}
+
+// ApplicationTargets returns a Target for each exposed application in
+// the model.
+func (api *API) ApplicationTargets() ([]Target, error) {
+	var result params.LoadBalancerTargetsResult
+	if err := api.facade.FacadeCall("ApplicationTargets", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	targets := make([]Target, len(result.Targets))
+	for i, t := range result.Targets {
+		tag, err := names.ParseApplicationTag(t.ApplicationTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		target := Target{ApplicationName: tag.Id()}
+		for _, pr := range t.Ports {
+			target.Ports = append(target.Ports, pr.NetworkPortRange())
+		}
+		for _, id := range t.InstanceIds {
+			target.InstanceIds = append(target.InstanceIds, instance.Id(id))
+		}
+		targets[i] = target
+	}
+	return targets, nil
+}
+
+// SetApplicationAddresses records the provider id and addresses of the
+// load balancer in front of the application. An empty provider id
+// records that the application has no load balancer.
+func (api *API) SetApplicationAddresses(applicationName, providerId string, addresses []network.Address) error {
+	args := params.SetLoadBalancerAddressesArgs{
+		Args: []params.SetLoadBalancerAddresses{{
+			ApplicationTag: names.NewApplicationTag(applicationName).String(),
+			ProviderId:     providerId,
+			Addresses:      params.FromNetworkAddresses(addresses...),
+		}},
+	}
+	var results params.ErrorResults
+	if err := api.facade.FacadeCall("SetApplicationAddresses", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
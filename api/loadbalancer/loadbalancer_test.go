@@ -0,0 +1,75 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package loadbalancer_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/loadbalancer"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type loadBalancerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&loadBalancerSuite{})
+
+func (s *loadBalancerSuite) TestApplicationTargets(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "LoadBalancer")
+		c.Check(request, gc.Equals, "ApplicationTargets")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.LoadBalancerTargetsResult)) = params.LoadBalancerTargetsResult{
+			Targets: []params.LoadBalancerTarget{{
+				ApplicationTag: "application-wordpress",
+				Ports:          []params.PortRange{{FromPort: 80, ToPort: 80, Protocol: "tcp"}},
+				InstanceIds:    []string{"inst-0", "inst-1"},
+			}},
+		}
+		return nil
+	})
+	targets, err := loadbalancer.NewAPI(caller).ApplicationTargets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(targets, jc.DeepEquals, []loadbalancer.Target{{
+		ApplicationName: "wordpress",
+		Ports:           []network.PortRange{network.MustParsePortRange("80/tcp")},
+		InstanceIds:     []instance.Id{"inst-0", "inst-1"},
+	}})
+}
+
+func (s *loadBalancerSuite) TestApplicationTargetsError(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	_, err := loadbalancer.NewAPI(caller).ApplicationTargets()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *loadBalancerSuite) TestSetApplicationAddresses(c *gc.C) {
+	addrs := []network.Address{network.NewScopedAddress("203.0.113.10", network.ScopePublic)}
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "LoadBalancer")
+		c.Check(request, gc.Equals, "SetApplicationAddresses")
+		c.Check(arg, jc.DeepEquals, params.SetLoadBalancerAddressesArgs{
+			Args: []params.SetLoadBalancerAddresses{{
+				ApplicationTag: "application-wordpress",
+				ProviderId:     "lb-wordpress",
+				Addresses:      params.FromNetworkAddresses(addrs...),
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "nope"}}},
+		}
+		return nil
+	})
+	err := loadbalancer.NewAPI(caller).SetApplicationAddresses("wordpress", "lb-wordpress", addrs)
+	c.Assert(err, gc.ErrorMatches, "nope")
+}
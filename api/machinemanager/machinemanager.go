@@ -182,3 +182,19 @@ func (client *Client) UpgradeSeriesComplete(machineName string) error {
 
 	return nil
 }
+
+// CloudQuota returns the resource quota and estimated instance costs
+// for the cloud region in which the current model is deployed.
+func (client *Client) CloudQuota() (params.CloudQuotaResult, error) {
+	if client.BestAPIVersion() < 6 {
+		return params.CloudQuotaResult{}, errors.NotSupportedf("cloud quota")
+	}
+	var result params.CloudQuotaResult
+	if err := client.facade.FacadeCall("CloudQuota", nil, &result); err != nil {
+		return params.CloudQuotaResult{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return params.CloudQuotaResult{}, result.Error
+	}
+	return result, nil
+}
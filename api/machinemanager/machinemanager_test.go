@@ -210,3 +210,36 @@ func (s *MachinemanagerSuite) TestDestroyMachinesWithParams(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
+
+func (s *MachinemanagerSuite) TestCloudQuota(c *gc.C) {
+	expected := params.CloudQuotaResult{
+		Cloud:  "aws",
+		Region: "us-east-1",
+		Quota:  []params.CloudQuotaLimit{{Resource: "instances", Limit: 20, Used: 3}},
+	}
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "CloudQuota")
+			c.Assert(a, gc.IsNil)
+			c.Assert(response, gc.FitsTypeOf, &params.CloudQuotaResult{})
+			*(response.(*params.CloudQuotaResult)) = expected
+			return nil
+		},
+		BestVersion: 6,
+	})
+	result, err := client.CloudQuota()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *MachinemanagerSuite) TestCloudQuotaNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 5,
+	})
+	_, err := client.CloudQuota()
+	c.Assert(err, gc.ErrorMatches, "cloud quota not supported")
+}
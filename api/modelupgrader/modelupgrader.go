@@ -105,3 +105,28 @@ func (c *Client) SetModelStatus(tag names.ModelTag, status status.Status, info s
 	}
 	return result.OneError()
 }
+
+// ModelStatus returns the status of a model.
+func (c *Client) ModelStatus(tag names.ModelTag) (status.StatusInfo, error) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	var results params.StatusResults
+	err := c.facade.FacadeCall("ModelStatus", &args, &results)
+	if err != nil {
+		return status.StatusInfo{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return status.StatusInfo{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return status.StatusInfo{}, result.Error
+	}
+	return status.StatusInfo{
+		Status:  status.Status(result.Status),
+		Message: result.Info,
+		Data:    result.Data,
+		Since:   result.Since,
+	}, nil
+}
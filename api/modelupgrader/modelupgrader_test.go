@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/modelupgrader"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -213,3 +214,44 @@ func (s *ModelUpgraderSuite) TestSetModelStatusArityMismatch(c *gc.C) {
 	err := client.SetModelStatus(modelTag, "foo", "bar", nil)
 	c.Assert(err, gc.ErrorMatches, "expected 1 result, got 2")
 }
+
+func (s *ModelUpgraderSuite) TestModelStatus(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ModelUpgrader")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ModelStatus")
+		c.Check(arg, jc.DeepEquals, &params.Entities{
+			Entities: []params.Entity{{Tag: modelTag.String()}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StatusResults{})
+		*(result.(*params.StatusResults)) = params.StatusResults{
+			Results: []params.StatusResult{{
+				Status: "error",
+				Info:   "cloud credential is not valid",
+			}},
+		}
+		return nil
+	})
+
+	client := modelupgrader.NewClient(apiCaller)
+	info, err := client.ModelStatus(modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Error,
+		Message: "cloud credential is not valid",
+	})
+}
+
+func (s *ModelUpgraderSuite) TestModelStatusError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.StatusResults)) = params.StatusResults{
+			Results: []params.StatusResult{{Error: &params.Error{Message: "foo"}}},
+		}
+		return nil
+	})
+
+	client := modelupgrader.NewClient(apiCaller)
+	_, err := client.ModelStatus(modelTag)
+	c.Assert(err, gc.ErrorMatches, "foo")
+}
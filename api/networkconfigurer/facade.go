@@ -0,0 +1,68 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package networkconfigurer implements the client-side API facade used
+// by the networkconfigurer worker.
+package networkconfigurer
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+// Facade provides access to the NetworkConfigurer API facade.
+type Facade struct {
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side NetworkConfigurer facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "NetworkConfigurer"),
+	}
+}
+
+// NetworkDefinitions returns the bonds, VLANs and routes which should be
+// configured on the machine. The returned error satisfies
+// params.IsCodeNotSupported if the machine agent does not configure the
+// machine's network.
+func (f *Facade) NetworkDefinitions(machine names.MachineTag) (params.NetworkDefinitionsResult, error) {
+	args := params.Entities{Entities: []params.Entity{{Tag: machine.String()}}}
+	var results params.NetworkDefinitionsResults
+	err := f.caller.FacadeCall("NetworkDefinitions", args, &results)
+	if err != nil {
+		return params.NetworkDefinitionsResult{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.NetworkDefinitionsResult{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.NetworkDefinitionsResult{}, result.Error
+	}
+	return result, nil
+}
+
+// WatchNetworkDefinitions returns a NotifyWatcher which notifies when
+// the machine's network definitions may have changed.
+func (f *Facade) WatchNetworkDefinitions(machine names.MachineTag) (watcher.NotifyWatcher, error) {
+	args := params.Entities{Entities: []params.Entity{{Tag: machine.String()}}}
+	var results params.NotifyWatchResults
+	err := f.caller.FacadeCall("WatchNetworkDefinitions", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(f.caller.RawAPICaller(), result), nil
+}
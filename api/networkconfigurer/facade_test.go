@@ -0,0 +1,126 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package networkconfigurer_test
+
+import (
+	"errors"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/networkconfigurer"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) TestNetworkDefinitions(c *gc.C) {
+	expected := params.NetworkDefinitionsResult{
+		Bonds: []params.NetworkBond{{
+			Name:        "bond0",
+			DeviceNames: []string{"eth0", "eth1"},
+			Mode:        "active-backup",
+		}},
+		VLANs: []params.NetworkVLAN{{
+			Name:       "bond0.42",
+			ParentName: "bond0",
+			VLANTag:    42,
+		}},
+	}
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "NetworkConfigurer")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		*response.(*params.NetworkDefinitionsResults) = params.NetworkDefinitionsResults{
+			Results: []params.NetworkDefinitionsResult{expected},
+		}
+		return nil
+	})
+	facade := networkconfigurer.NewFacade(apiCaller)
+
+	result, err := facade.NetworkDefinitions(names.NewMachineTag("42"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+
+	stub.CheckCalls(c, []testing.StubCall{{
+		"NetworkDefinitions", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: "machine-42"}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestNetworkDefinitionsCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		return errors.New("blam")
+	})
+	facade := networkconfigurer.NewFacade(apiCaller)
+
+	_, err := facade.NetworkDefinitions(names.NewMachineTag("42"))
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *facadeSuite) TestNetworkDefinitionsNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.NetworkDefinitionsResults) = params.NetworkDefinitionsResults{
+			Results: []params.NetworkDefinitionsResult{{
+				Error: &params.Error{Code: params.CodeNotSupported, Message: "blam"},
+			}},
+		}
+		return nil
+	})
+	facade := networkconfigurer.NewFacade(apiCaller)
+
+	_, err := facade.NetworkDefinitions(names.NewMachineTag("42"))
+	c.Assert(err, gc.ErrorMatches, "blam")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotSupported)
+}
+
+func (s *facadeSuite) TestWatchNetworkDefinitionsError(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		stub.AddCall(request, args)
+		*response.(*params.NotifyWatchResults) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "blam"},
+			}},
+		}
+		return nil
+	})
+	facade := networkconfigurer.NewFacade(apiCaller)
+
+	w, err := facade.WatchNetworkDefinitions(names.NewMachineTag("42"))
+	c.Assert(err, gc.ErrorMatches, "blam")
+	c.Assert(w, gc.IsNil)
+
+	stub.CheckCalls(c, []testing.StubCall{{
+		"WatchNetworkDefinitions", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: "machine-42"}},
+		}},
+	}})
+}
@@ -293,6 +293,7 @@ func (st *State) prepareOrGetContainerInterfaceInfo(
 			InterfaceName:       cfg.InterfaceName,
 			ParentInterfaceName: cfg.ParentInterfaceName,
 			InterfaceType:       network.InterfaceType(cfg.InterfaceType),
+			NICType:             network.NICType(cfg.NICType),
 			Disabled:            cfg.Disabled,
 			NoAutoStart:         cfg.NoAutoStart,
 			ConfigType:          network.InterfaceConfigType(cfg.ConfigType),
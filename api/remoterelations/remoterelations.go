@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/api/base"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 )
@@ -283,6 +284,55 @@ func (c *Client) ControllerAPIInfoForModel(modelUUID string) (*api.Info, error)
 	}, nil
 }
 
+// SetRemoteRelationHealth records the outcome of an attempt to exchange
+// settings with the offering model for the specified relation, or for all
+// the relations of the specified remote application. A nil exchangeErr
+// means the exchange succeeded.
+func (c *Client) SetRemoteRelationHealth(entity names.Tag, exchangeErr error, controllerReachable bool) error {
+	if bestVer := c.facade.BestAPIVersion(); bestVer < 2 {
+		return errors.NotImplementedf("SetRemoteRelationHealth() (need v2+, have v%d)", bestVer)
+	}
+	arg := params.RemoteRelationHealthArg{
+		Tag:                 entity.String(),
+		ControllerReachable: controllerReachable,
+	}
+	if exchangeErr != nil {
+		arg.Error = exchangeErr.Error()
+	}
+	args := params.RemoteRelationHealthArgs{Args: []params.RemoteRelationHealthArg{arg}}
+	var results params.ErrorResults
+	err := c.facade.FacadeCall("SetRemoteRelationsHealth", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// UpdateControllerForModel records that the specified offering model is
+// now hosted by the specified controller, after the model was migrated.
+func (c *Client) UpdateControllerForModel(controller crossmodel.ControllerInfo, modelUUID string) error {
+	if bestVer := c.facade.BestAPIVersion(); bestVer < 3 {
+		return errors.NotImplementedf("UpdateControllerForModel() (need v3+, have v%d)", bestVer)
+	}
+	args := params.UpdateControllersForModelsParams{
+		Changes: []params.UpdateControllerForModel{{
+			ModelTag: names.NewModelTag(modelUUID).String(),
+			Info: params.ExternalControllerInfo{
+				ControllerTag: controller.ControllerTag.String(),
+				Alias:         controller.Alias,
+				Addrs:         controller.Addrs,
+				CACert:        controller.CACert,
+			},
+		}},
+	}
+	var results params.ErrorResults
+	err := c.facade.FacadeCall("UpdateControllersForModels", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // SetRemoteApplicationStatus sets the status for the specified remote application.
 func (c *Client) SetRemoteApplicationStatus(applicationName string, status status.Status, message string) error {
 	args := params.SetStatus{Entities: []params.EntityStatusArgs{
@@ -4,6 +4,7 @@
 package remoterelations_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -12,6 +13,7 @@ import (
 	"github.com/juju/juju/api/remoterelations"
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -463,3 +465,96 @@ func (s *remoteRelationsSuite) TestSetRemoteApplicationStatus(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "FAIL")
 	c.Check(callCount, gc.Equals, 1)
 }
+
+func (s *remoteRelationsSuite) TestSetRemoteRelationHealth(c *gc.C) {
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "RemoteRelations")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "SetRemoteRelationsHealth")
+			c.Assert(arg, gc.DeepEquals, params.RemoteRelationHealthArgs{
+				Args: []params.RemoteRelationHealthArg{{
+					Tag:   names.NewApplicationTag("mysql").String(),
+					Error: "connection refused",
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "FAIL"},
+				}},
+			}
+			callCount++
+			return nil
+		}),
+		BestVersion: 2,
+	}
+	client := remoterelations.NewClient(apiCaller)
+	err := client.SetRemoteRelationHealth(names.NewApplicationTag("mysql"), errors.New("connection refused"), false)
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *remoteRelationsSuite) TestSetRemoteRelationHealthNotSupported(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	client := remoterelations.NewClient(apiCaller)
+	err := client.SetRemoteRelationHealth(names.NewRelationTag("mysql:db wordpress:db"), nil, true)
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *remoteRelationsSuite) TestUpdateControllerForModel(c *gc.C) {
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "RemoteRelations")
+			c.Check(version, gc.Equals, 3)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "UpdateControllersForModels")
+			c.Assert(arg, gc.DeepEquals, params.UpdateControllersForModelsParams{
+				Changes: []params.UpdateControllerForModel{{
+					ModelTag: coretesting.ModelTag.String(),
+					Info: params.ExternalControllerInfo{
+						ControllerTag: coretesting.ControllerTag.String(),
+						Addrs:         []string{"10.0.0.1:17070"},
+						CACert:        coretesting.CACert,
+					},
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "FAIL"},
+				}},
+			}
+			callCount++
+			return nil
+		}),
+		BestVersion: 3,
+	}
+	client := remoterelations.NewClient(apiCaller)
+	err := client.UpdateControllerForModel(crossmodel.ControllerInfo{
+		ControllerTag: coretesting.ControllerTag,
+		Addrs:         []string{"10.0.0.1:17070"},
+		CACert:        coretesting.CACert,
+	}, coretesting.ModelTag.Id())
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *remoteRelationsSuite) TestUpdateControllerForModelNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		}),
+		BestVersion: 2,
+	}
+	client := remoterelations.NewClient(apiCaller)
+	err := client.UpdateControllerForModel(crossmodel.ControllerInfo{}, coretesting.ModelTag.Id())
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+}
@@ -91,3 +91,30 @@ func (api *API) ListSubnets(spaceTag *names.SpaceTag, zone string) ([]params.Sub
 	}
 	return response.Results, nil
 }
+
+// SetSubnetRoutes replaces the static routes of an existing subnet.
+// Passing no routes clears them.
+func (api *API) SetSubnetRoutes(subnet names.SubnetTag, routes []network.Route) error {
+	if api.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("setting subnet routes")
+	}
+	args := params.SetSubnetsRoutesParams{
+		Subnets: []params.SetSubnetRoutesParams{{
+			SubnetTag: subnet.String(),
+			Routes:    make([]params.NetworkRoute, len(routes)),
+		}},
+	}
+	for i, route := range routes {
+		args.Subnets[0].Routes[i] = params.NetworkRoute{
+			DestinationCIDR: route.DestinationCIDR,
+			GatewayIP:       route.GatewayIP,
+			Metric:          route.Metric,
+		}
+	}
+	var response params.ErrorResults
+	err := api.facade.FacadeCall("SetSubnetsRoutes", args, &response)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return response.OneError()
+}
@@ -214,3 +214,42 @@ func (s *SubnetsSuite) TestListSubnetsFails(c *gc.C) {
 	var expectedResults []params.Subnet
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
+
+func (s *SubnetsSuite) TestSetSubnetRoutes(c *gc.C) {
+	args := apitesting.APICall{
+		Facade: "Subnets",
+		Method: "SetSubnetsRoutes",
+		Args: params.SetSubnetsRoutesParams{
+			Subnets: []params.SetSubnetRoutesParams{{
+				SubnetTag: "subnet-10.0.0.0/24",
+				Routes: []params.NetworkRoute{{
+					DestinationCIDR: "10.1.0.0/16",
+					GatewayIP:       "10.0.0.1",
+					Metric:          5,
+				}},
+			}},
+		},
+		Results: params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		},
+	}
+	s.prepareAPICall(c, args)
+	api := subnets.NewAPI(apitesting.BestVersionCaller{
+		APICallerFunc: s.apiCaller.APICallerFunc,
+		BestVersion:   3,
+	})
+	err := api.SetSubnetRoutes(names.NewSubnetTag("10.0.0.0/24"), []network.Route{{
+		DestinationCIDR: "10.1.0.0/16",
+		GatewayIP:       "10.0.0.1",
+		Metric:          5,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.apiCaller.CallCount, gc.Equals, 1)
+}
+
+func (s *SubnetsSuite) TestSetSubnetRoutesNotSupported(c *gc.C) {
+	s.prepareAPICall(c, apitesting.APICall{})
+	err := s.api.SetSubnetRoutes(names.NewSubnetTag("10.0.0.0/24"), nil)
+	c.Assert(err, gc.ErrorMatches, "setting subnet routes not supported")
+	c.Assert(s.apiCaller.CallCount, gc.Equals, 0)
+}
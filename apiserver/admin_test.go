@@ -35,6 +35,7 @@ import (
 	"github.com/juju/juju/constraints"
 	corecontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/migration"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
@@ -717,7 +718,7 @@ func (s *loginSuite) TestAnonymousControllerLogin(c *gc.C) {
 	c.Assert(result.UserInfo, gc.IsNil)
 	c.Assert(result.ControllerTag, gc.Equals, s.State.ControllerTag().String())
 	c.Assert(result.Facades, jc.DeepEquals, []params.FacadeVersions{
-		{Name: "CrossController", Versions: []int{1}},
+		{Name: "CrossController", Versions: []int{1, 2}},
 		{Name: "NotifyWatcher", Versions: []int{1}},
 	})
 }
@@ -1529,6 +1530,38 @@ func (s *migrationSuite) TestExportingModel(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "model migration in progress")
 }
 
+func (s *migrationSuite) TestMigratedAwayModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	modelTag := names.NewModelTag(st.ModelUUID())
+	mig, err := st.CreateMigration(state.MigrationSpec{
+		InitiatedBy: s.AdminUserTag(c),
+		TargetInfo: migration.TargetInfo{
+			ControllerTag: names.NewControllerTag(utils.MustNewUUID().String()),
+			Addrs:         []string{"1.2.3.4:5555"},
+			CACert:        coretesting.CACert,
+			AuthTag:       s.AdminUserTag(c),
+			Password:      "password",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for _, phase := range []migration.Phase{
+		migration.IMPORT, migration.VALIDATION, migration.SUCCESS,
+		migration.LOGTRANSFER, migration.REAP,
+	} {
+		c.Assert(mig.SetPhase(phase), jc.ErrorIsNil)
+	}
+	c.Assert(st.RemoveExportingModelDocs(), jc.ErrorIsNil)
+
+	// Consumers of the model's offers rely on this to know that they
+	// should look for where the model has moved to.
+	info := s.APIInfo(c)
+	info.ModelTag = modelTag
+	_, err = api.Open(info, api.DialOpts{})
+	c.Assert(err, gc.NotNil)
+	c.Check(params.ErrCode(err), gc.Equals, params.CodeModelNotFound)
+}
+
 type loginV3Suite struct {
 	baseLoginSuite
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/credentialvalidator"
 	"github.com/juju/juju/apiserver/facades/agent/deployer"
 	"github.com/juju/juju/apiserver/facades/agent/diskmanager"
+	"github.com/juju/juju/apiserver/facades/agent/egressfirewall"
 	"github.com/juju/juju/apiserver/facades/agent/fanconfigurer"
 	"github.com/juju/juju/apiserver/facades/agent/hostkeyreporter"
 	"github.com/juju/juju/apiserver/facades/agent/keyupdater"
@@ -29,6 +30,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/metricsadder"
 	"github.com/juju/juju/apiserver/facades/agent/migrationflag"
 	"github.com/juju/juju/apiserver/facades/agent/migrationminion"
+	"github.com/juju/juju/apiserver/facades/agent/networkconfigurer"
 	"github.com/juju/juju/apiserver/facades/agent/payloadshookcontext"
 	"github.com/juju/juju/apiserver/facades/agent/provisioner"
 	"github.com/juju/juju/apiserver/facades/agent/proxyupdater"
@@ -78,11 +80,13 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/cleaner"
 	"github.com/juju/juju/apiserver/facades/controller/crosscontroller"
 	"github.com/juju/juju/apiserver/facades/controller/crossmodelrelations"
+	"github.com/juju/juju/apiserver/facades/controller/dnsrecords"
 	"github.com/juju/juju/apiserver/facades/controller/externalcontrollerupdater"
 	"github.com/juju/juju/apiserver/facades/controller/firewaller"
 	"github.com/juju/juju/apiserver/facades/controller/imagemetadata"
 	"github.com/juju/juju/apiserver/facades/controller/instancepoller"
 	"github.com/juju/juju/apiserver/facades/controller/lifeflag"
+	"github.com/juju/juju/apiserver/facades/controller/loadbalancer"
 	"github.com/juju/juju/apiserver/facades/controller/logfwd"
 	"github.com/juju/juju/apiserver/facades/controller/machineundertaker"
 	"github.com/juju/juju/apiserver/facades/controller/metricsmanager"
@@ -126,7 +130,8 @@ func AllFacades() *facade.Registry {
 		}
 	}
 
-	reg("Action", 2, action.NewActionAPI)
+	reg("Action", 2, action.NewActionAPIV2)
+	reg("Action", 3, action.NewActionAPI) // adds CheckNetwork
 	reg("ActionPruner", 1, actionpruner.NewAPI)
 	reg("Agent", 2, agent.NewAgentAPIV2)
 	reg("AgentTools", 1, agenttools.NewFacade)
@@ -143,9 +148,12 @@ func AllFacades() *facade.Registry {
 	reg("Application", 6, application.NewFacadeV6)
 	reg("Application", 7, application.NewFacadeV7)
 	reg("Application", 8, application.NewFacadeV8)
+	reg("Application", 9, application.NewFacadeV9)   // adds expose settings to Expose and Unexpose
+	reg("Application", 10, application.NewFacadeV10) // adds OfferConnectionsInfo
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2)
+	reg("ApplicationOffers", 3, applicationoffers.NewOffersAPIV3) // adds share tokens
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
 	reg("Backups", 1, backups.NewFacade)
 	reg("Backups", 2, backups.NewFacadeV2)
@@ -171,18 +179,24 @@ func AllFacades() *facade.Registry {
 	reg("Controller", 3, controller.NewControllerAPIv3)
 	reg("Controller", 4, controller.NewControllerAPIv4)
 	reg("Controller", 5, controller.NewControllerAPIv5)
+	reg("Controller", 6, controller.NewControllerAPIv6)
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPI)
 	reg("CrossController", 1, crosscontroller.NewStateCrossControllerAPI)
+	reg("CrossController", 2, crosscontroller.NewStateCrossControllerAPIV2) // adds OfferRedirects
 	reg("CredentialManager", 1, credentialmanager.NewCredentialManagerAPI)
 	reg("CredentialValidator", 1, credentialvalidator.NewCredentialValidatorAPI)
 	reg("ExternalControllerUpdater", 1, externalcontrollerupdater.NewStateAPI)
 
 	reg("Deployer", 1, deployer.NewDeployerAPI)
 	reg("DiskManager", 2, diskmanager.NewDiskManagerAPI)
+	reg("DNSRecords", 1, dnsrecords.NewFacade)
+	reg("EgressFirewall", 1, egressfirewall.NewFacade)
 	reg("FanConfigurer", 1, fanconfigurer.NewFanConfigurerAPI)
 	reg("Firewaller", 3, firewaller.NewStateFirewallerAPIV3)
 	reg("Firewaller", 4, firewaller.NewStateFirewallerAPIV4)
 	reg("Firewaller", 5, firewaller.NewStateFirewallerAPIV5)
+	reg("Firewaller", 6, firewaller.NewStateFirewallerAPIV6) // adds GetExposeInfo, WatchSubnetSpaces
+	reg("Firewaller", 7, firewaller.NewStateFirewallerAPIV7) // adds GetMachineEgressRules, WatchEgressRules
 	reg("FirewallRules", 1, firewallrules.NewFacade)
 	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
@@ -190,7 +204,8 @@ func AllFacades() *facade.Registry {
 	reg("ImageMetadata", 3, imagemetadata.NewAPI)
 
 	if featureflag.Enabled(feature.ImageMetadata) {
-		reg("ImageMetadataManager", 1, imagemetadatamanager.NewAPI)
+		reg("ImageMetadataManager", 1, imagemetadatamanager.NewAPIv1)
+		reg("ImageMetadataManager", 2, imagemetadatamanager.NewAPI)
 	}
 
 	reg("InstancePoller", 3, instancepoller.NewFacade)
@@ -198,6 +213,7 @@ func AllFacades() *facade.Registry {
 	reg("KeyUpdater", 1, keyupdater.NewKeyUpdaterAPI)
 	reg("LeadershipService", 2, leadership.NewLeadershipServiceFacade)
 	reg("LifeFlag", 1, lifeflag.NewExternalFacade)
+	reg("LoadBalancer", 1, loadbalancer.NewFacade)
 	reg("Logger", 1, loggerapi.NewLoggerAPI)
 	reg("LogForwarding", 1, logfwd.NewFacade)
 	reg("MachineActions", 1, machineactions.NewExternalFacade)
@@ -206,6 +222,7 @@ func AllFacades() *facade.Registry {
 	reg("MachineManager", 3, machinemanager.NewFacade)   // Version 3 adds DestroyMachine and ForceDestroyMachine.
 	reg("MachineManager", 4, machinemanager.NewFacadeV4) // Version 4 adds DestroyMachineWithParams.
 	reg("MachineManager", 5, machinemanager.NewFacadeV5) // Version 5 adds UpgradeSeriesPrepare.
+	reg("MachineManager", 6, machinemanager.NewFacadeV6) // Version 6 adds CloudQuota.
 
 	reg("MachineUndertaker", 1, machineundertaker.NewFacade)
 	reg("Machiner", 1, machine.NewMachinerAPI)
@@ -227,6 +244,8 @@ func AllFacades() *facade.Registry {
 	reg("ModelManager", 4, modelmanager.NewFacadeV4)
 	reg("ModelUpgrader", 1, modelupgrader.NewStateFacade)
 
+	reg("NetworkConfigurer", 1, networkconfigurer.NewFacade)
+
 	reg("Payloads", 1, payloads.NewFacade)
 	regHookContext(
 		"PayloadsHookContext", 1,
@@ -244,6 +263,8 @@ func AllFacades() *facade.Registry {
 	reg("ProxyUpdater", 2, proxyupdater.NewFacadeV2)
 	reg("Reboot", 2, reboot.NewRebootAPI)
 	reg("RemoteRelations", 1, remoterelations.NewStateRemoteRelationsAPI)
+	reg("RemoteRelations", 2, remoterelations.NewStateRemoteRelationsAPIV2) // adds SetRemoteRelationsHealth
+	reg("RemoteRelations", 3, remoterelations.NewStateRemoteRelationsAPIV3) // adds UpdateControllersForModels
 
 	reg("Resources", 1, resources.NewPublicFacade)
 	reg("ResourcesHookContext", 1, resourceshookcontext.NewStateFacade)
@@ -265,7 +286,8 @@ func AllFacades() *facade.Registry {
 
 	reg("StorageProvisioner", 3, storageprovisioner.NewFacadeV3)
 	reg("StorageProvisioner", 4, storageprovisioner.NewFacadeV4)
-	reg("Subnets", 2, subnets.NewAPI)
+	reg("Subnets", 2, subnets.NewAPIV2)
+	reg("Subnets", 3, subnets.NewAPI)
 	reg("Undertaker", 1, undertaker.NewUndertakerAPI)
 	reg("UnitAssigner", 1, unitassigner.New)
 
@@ -26,6 +26,7 @@ const (
 	offeruuidKey   = "offer-uuid"
 	sourcemodelKey = "source-model-uuid"
 	relationKey    = "relation-key"
+	shareTokenKey  = "share-token"
 
 	offerPermissionCaveat = "has-offer-permission"
 
@@ -73,6 +74,11 @@ func (a *AuthContext) WithClock(clock clock.Clock) *AuthContext {
 	return &ctxtCopy
 }
 
+// Clock returns the clock used by the authentication context.
+func (a *AuthContext) Clock() clock.Clock {
+	return a.clock
+}
+
 // WithDischargeURL create an auth context based on this context and used
 // to perform third party discharges at the specified URL.
 func (a *AuthContext) WithDischargeURL(offerAccessEndpoint string) *AuthContext {
@@ -223,22 +229,98 @@ func (a *AuthContext) CreateConsumeOfferMacaroon(offer *params.ApplicationOfferD
 
 // CreateRemoteRelationMacaroon creates a macaroon that authorises access to the specified relation.
 func (a *AuthContext) CreateRemoteRelationMacaroon(sourceModelUUID, offerUUID string, username string, rel names.Tag) (*macaroon.Macaroon, error) {
+	return a.createRemoteRelationMacaroon(sourceModelUUID, offerUUID, username, rel)
+}
+
+// CreateShareTokenRelationMacaroon creates a macaroon that authorises access
+// to the specified relation for as long as the share token used to
+// establish the relation remains valid.
+func (a *AuthContext) CreateShareTokenRelationMacaroon(sourceModelUUID, offerUUID, username, shareTokenID string, rel names.Tag) (*macaroon.Macaroon, error) {
+	return a.createRemoteRelationMacaroon(sourceModelUUID, offerUUID, username, rel,
+		checkers.DeclaredCaveat(shareTokenKey, shareTokenID))
+}
+
+func (a *AuthContext) createRemoteRelationMacaroon(
+	sourceModelUUID, offerUUID, username string, rel names.Tag, extraCaveats ...checkers.Caveat,
+) (*macaroon.Macaroon, error) {
 	expiryTime := a.clock.Now().Add(localOfferPermissionExpiryTime)
 	bakery, err := a.localOfferBakeryService.ExpireStorageAfter(localOfferPermissionExpiryTime)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
-	offerMacaroon, err := bakery.NewMacaroon(
+	caveats := []checkers.Caveat{
+		checkers.TimeBeforeCaveat(expiryTime),
+		checkers.DeclaredCaveat(sourcemodelKey, sourceModelUUID),
+		checkers.DeclaredCaveat(offeruuidKey, offerUUID),
+		checkers.DeclaredCaveat(usernameKey, username),
+		checkers.DeclaredCaveat(relationKey, rel.Id()),
+	}
+	offerMacaroon, err := bakery.NewMacaroon(append(caveats, extraCaveats...))
+
+	return offerMacaroon, err
+}
+
+// CreateShareTokenMacaroon creates a macaroon that authorises access to the
+// specified offer until the share token with the specified id expires or
+// is revoked.
+func (a *AuthContext) CreateShareTokenMacaroon(
+	offer *params.ApplicationOfferDetails, username, shareTokenID string, expires time.Time,
+) (*macaroon.Macaroon, error) {
+	sourceModelTag, err := names.ParseModelTag(offer.SourceModelTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	lifetime := expires.Sub(a.clock.Now())
+	if lifetime <= 0 {
+		return nil, errors.NotValidf("share token expiry %v", expires)
+	}
+	bakery, err := a.localOfferBakeryService.ExpireStorageAfter(lifetime)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return bakery.NewMacaroon(
 		[]checkers.Caveat{
-			checkers.TimeBeforeCaveat(expiryTime),
-			checkers.DeclaredCaveat(sourcemodelKey, sourceModelUUID),
-			checkers.DeclaredCaveat(offeruuidKey, offerUUID),
+			checkers.TimeBeforeCaveat(expires),
+			checkers.DeclaredCaveat(sourcemodelKey, sourceModelTag.Id()),
+			checkers.DeclaredCaveat(offeruuidKey, offer.OfferUUID),
 			checkers.DeclaredCaveat(usernameKey, username),
-			checkers.DeclaredCaveat(relationKey, rel.Id()),
+			checkers.DeclaredCaveat(shareTokenKey, shareTokenID),
 		})
+}
 
-	return offerMacaroon, err
+// ShareTokenID returns the id of the share token declared in the
+// specified macaroon attributes, or "" if there is none.
+func ShareTokenID(attrs map[string]string) string {
+	return attrs[shareTokenKey]
+}
+
+// checkShareToken returns an error if the share token with the specified id
+// does not grant access to the offer, because it has been revoked or has
+// expired.
+func (a *AuthContext) checkShareToken(modelUUID, offerUUID, shareTokenID string) error {
+	st, releaser, err := a.pool.Get(modelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer releaser()
+	token, err := st.OfferShareToken(shareTokenID)
+	if errors.IsNotFound(err) {
+		logger.Debugf("share token %q has been revoked", shareTokenID)
+		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if token.OfferUUID() != offerUUID {
+		logger.Debugf("share token %q is not valid for offer %q", shareTokenID, offerUUID)
+		return common.ErrPerm
+	}
+	if !a.clock.Now().Before(token.Expires()) {
+		logger.Debugf("share token %q expired at %v", shareTokenID, token.Expires())
+		return common.ErrPerm
+	}
+	return nil
 }
 
 type offerPermissionCheck struct {
@@ -292,6 +374,15 @@ func (a *authenticator) checkMacaroons(mac macaroon.Slice, requiredValues map[st
 		return nil, common.ErrPerm
 	}
 	relation := declared[relationKey]
+	shareTokenID := declared[shareTokenKey]
+	if shareTokenID != "" {
+		// Access granted by a share token lasts only as long as the
+		// token remains valid, whatever the macaroons say.
+		if err := a.ctxt.checkShareToken(a.sourceModelUUID, a.offerUUID, shareTokenID); err != nil {
+			logger.Debugf("share token check failed: %v", err)
+			return nil, common.ErrPerm
+		}
+	}
 	attrs, err := a.bakery.CheckAny([]macaroon.Slice{mac}, requiredValues, checkers.TimeBefore)
 	if err == nil {
 		logger.Debugf("macaroon check ok, attr: %v", attrs)
@@ -317,7 +408,7 @@ func (a *authenticator) checkMacaroons(mac macaroon.Slice, requiredValues map[st
 	for k := range requiredValues {
 		keys = append(keys, k)
 	}
-	m, err := bakery.NewMacaroon([]checkers.Caveat{
+	caveats := []checkers.Caveat{
 		checkers.NeedDeclaredCaveat(
 			checkers.Caveat{
 				Location:  a.offerAccessEndpoint,
@@ -326,7 +417,13 @@ func (a *authenticator) checkMacaroons(mac macaroon.Slice, requiredValues map[st
 			keys...,
 		),
 		checkers.TimeBeforeCaveat(a.clock.Now().Add(localOfferPermissionExpiryTime)),
-	})
+	}
+	if shareTokenID != "" {
+		// Keep the discharged macaroon tied to the share token
+		// so that revoking the token also revokes access.
+		caveats = append(caveats, checkers.DeclaredCaveat(shareTokenKey, shareTokenID))
+	}
+	m, err := bakery.NewMacaroon(caveats)
 
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot create macaroon")
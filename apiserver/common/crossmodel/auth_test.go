@@ -436,3 +436,163 @@ func (s *authSuite) TestCheckRelationMacaroonsDischargeRequired(c *gc.C) {
 	c.Assert(cav, gc.HasLen, 2)
 	c.Assert(cav[0].Location, gc.Equals, "http://thirdparty")
 }
+
+func (s *authSuite) TestCheckRelationMacaroonsFromOtherController(c *gc.C) {
+	// The relation macaroon was minted by the controller which hosted
+	// the offering model before it was migrated, so its root key is not
+	// known here. The macaroon is re-minted once the consumer discharges
+	// the third party caveat addressed to this controller.
+	key, err := bakery.GenerateKey()
+	c.Assert(err, jc.ErrorIsNil)
+	otherService, err := bakery.NewService(bakery.NewServiceParams{
+		Key: key,
+		Locator: bakery.PublicKeyLocatorMap{
+			"http://thirdparty": &key.Public,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	otherBakery := &mockBakeryService{otherService}
+	otherContext, err := crossmodel.NewAuthContext(s.mockStatePool, otherBakery, otherBakery)
+	c.Assert(err, jc.ErrorIsNil)
+	relationTag := names.NewRelationTag("mediawiki:db mysql:server")
+	mac, err := otherContext.CreateRemoteRelationMacaroon(
+		coretesting.ModelTag.Id(), "mysql-uuid", "mary", relationTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	authContext, err := crossmodel.NewAuthContext(s.mockStatePool, s.bakery, s.bakery)
+	c.Assert(err, jc.ErrorIsNil)
+	authContext = authContext.WithDischargeURL("http://thirdparty")
+	err = authContext.Authenticator(
+		coretesting.ModelTag.Id(), "mysql-uuid").CheckRelationMacaroons(
+		relationTag,
+		macaroon.Slice{mac},
+	)
+	dischargeErr, ok := err.(*common.DischargeRequiredError)
+	c.Assert(ok, jc.IsTrue)
+	cav := dischargeErr.Macaroon.Caveats()
+	c.Assert(cav, gc.HasLen, 2)
+	c.Assert(cav[0].Location, gc.Equals, "http://thirdparty")
+}
+
+func (s *authSuite) addShareToken(expires time.Time) {
+	s.mockStatePool.st[coretesting.ModelTag.Id()] = &mockState{
+		tag: coretesting.ModelTag,
+		shareTokens: map[string]*mockShareToken{
+			"token-id": {id: "token-id", offerUUID: "mysql-uuid", expires: expires},
+		},
+	}
+}
+
+func (s *authSuite) TestCreateShareTokenMacaroon(c *gc.C) {
+	authContext, err := crossmodel.NewAuthContext(s.mockStatePool, s.bakery, s.bakery)
+	c.Assert(err, jc.ErrorIsNil)
+	offer := &params.ApplicationOfferDetails{
+		SourceModelTag: coretesting.ModelTag.String(),
+		OfferUUID:      "mysql-uuid",
+	}
+	mac, err := authContext.CreateShareTokenMacaroon(offer, "mary", "token-id", time.Now().Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	cav := mac.Caveats()
+	c.Assert(cav, gc.HasLen, 5)
+	c.Assert(bytes.HasPrefix(cav[0].Id, []byte("time-before")), jc.IsTrue)
+	c.Assert(cav[1].Id, jc.DeepEquals, []byte("declared source-model-uuid "+coretesting.ModelTag.Id()))
+	c.Assert(cav[2].Id, jc.DeepEquals, []byte("declared offer-uuid mysql-uuid"))
+	c.Assert(cav[3].Id, jc.DeepEquals, []byte("declared username mary"))
+	c.Assert(cav[4].Id, jc.DeepEquals, []byte("declared share-token token-id"))
+}
+
+func (s *authSuite) TestCreateShareTokenMacaroonExpired(c *gc.C) {
+	authContext, err := crossmodel.NewAuthContext(s.mockStatePool, s.bakery, s.bakery)
+	c.Assert(err, jc.ErrorIsNil)
+	offer := &params.ApplicationOfferDetails{
+		SourceModelTag: coretesting.ModelTag.String(),
+		OfferUUID:      "mysql-uuid",
+	}
+	_, err = authContext.CreateShareTokenMacaroon(offer, "mary", "token-id", time.Now().Add(-time.Hour))
+	c.Assert(err, gc.ErrorMatches, "share token expiry .* not valid")
+}
+
+func (s *authSuite) TestCheckOfferMacaroonsShareToken(c *gc.C) {
+	s.addShareToken(time.Now().Add(time.Hour))
+	authContext, err := crossmodel.NewAuthContext(s.mockStatePool, s.bakery, s.bakery)
+	c.Assert(err, jc.ErrorIsNil)
+	offer := &params.ApplicationOfferDetails{
+		SourceModelTag: coretesting.ModelTag.String(),
+		OfferUUID:      "mysql-uuid",
+	}
+	mac, err := authContext.CreateShareTokenMacaroon(offer, "mary", "token-id", time.Now().Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+
+	attr, err := authContext.Authenticator(
+		coretesting.ModelTag.Id(), "mysql-uuid").CheckOfferMacaroons(
+		"mysql-uuid",
+		macaroon.Slice{mac},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(crossmodel.ShareTokenID(attr), gc.Equals, "token-id")
+}
+
+func (s *authSuite) TestCheckOfferMacaroonsShareTokenRevoked(c *gc.C) {
+	s.mockStatePool.st[coretesting.ModelTag.Id()] = &mockState{tag: coretesting.ModelTag}
+	authContext, err := crossmodel.NewAuthContext(s.mockStatePool, s.bakery, s.bakery)
+	c.Assert(err, jc.ErrorIsNil)
+	offer := &params.ApplicationOfferDetails{
+		SourceModelTag: coretesting.ModelTag.String(),
+		OfferUUID:      "mysql-uuid",
+	}
+	mac, err := authContext.CreateShareTokenMacaroon(offer, "mary", "token-id", time.Now().Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = authContext.Authenticator(
+		coretesting.ModelTag.Id(), "mysql-uuid").CheckOfferMacaroons(
+		"mysql-uuid",
+		macaroon.Slice{mac},
+	)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *authSuite) TestCheckRelationMacaroonsShareTokenExpired(c *gc.C) {
+	now := time.Now()
+	s.addShareToken(now.Add(time.Minute))
+	authContext, err := crossmodel.NewAuthContext(s.mockStatePool, s.bakery, s.bakery)
+	c.Assert(err, jc.ErrorIsNil)
+	relationTag := names.NewRelationTag("mediawiki:db mysql:server")
+	mac, err := authContext.CreateShareTokenRelationMacaroon(
+		coretesting.ModelTag.Id(), "mysql-uuid", "mary", "token-id", relationTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	auth := authContext.Authenticator(coretesting.ModelTag.Id(), "mysql-uuid")
+	err = auth.CheckRelationMacaroons(relationTag, macaroon.Slice{mac})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Once the share token expires, the relation macaroon no longer
+	// grants access even though the macaroon itself is still valid.
+	authContext = authContext.WithClock(testclock.NewClock(now.Add(2 * time.Minute)))
+	auth = authContext.Authenticator(coretesting.ModelTag.Id(), "mysql-uuid")
+	err = auth.CheckRelationMacaroons(relationTag, macaroon.Slice{mac})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *authSuite) TestCheckRelationMacaroonsShareTokenDischargeRequired(c *gc.C) {
+	s.addShareToken(time.Now().Add(time.Hour))
+	authContext, err := crossmodel.NewAuthContext(s.mockStatePool, s.bakery, s.bakery)
+	c.Assert(err, jc.ErrorIsNil)
+	authContext = authContext.WithClock(testclock.NewClock(time.Now().Add(-10 * time.Minute)))
+	authContext = authContext.WithDischargeURL("http://thirdparty")
+	relationTag := names.NewRelationTag("mediawiki:db mysql:server")
+	mac, err := authContext.CreateShareTokenRelationMacaroon(
+		coretesting.ModelTag.Id(), "mysql-uuid", "mary", "token-id", relationTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = authContext.Authenticator(
+		coretesting.ModelTag.Id(), "mysql-uuid").CheckRelationMacaroons(
+		relationTag,
+		macaroon.Slice{mac},
+	)
+	dischargeErr, ok := err.(*common.DischargeRequiredError)
+	c.Assert(ok, jc.IsTrue)
+	cav := dischargeErr.Macaroon.Caveats()
+	c.Assert(cav, gc.HasLen, 3)
+	c.Assert(cav[0].Location, gc.Equals, "http://thirdparty")
+	c.Assert(cav[2].Id, jc.DeepEquals, []byte("declared share-token token-id"))
+}
@@ -6,6 +6,7 @@ package crossmodel
 import (
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -144,6 +145,21 @@ func PublishRelationChange(backend Backend, relationTag names.Tag, change params
 	return nil
 }
 
+// UpdateRelationHealth records the outcome of an attempt to exchange
+// settings for the specified cross-model relation. An empty exchangeErr
+// means the exchange succeeded.
+func UpdateRelationHealth(backend Backend, relationTag names.Tag, exchangeErr string, controllerReachable bool) error {
+	rel, err := backend.KeyRelation(relationTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(rel.UpdateRemoteHealth(state.RemoteRelationHealthUpdate{
+		When:                time.Now(),
+		Error:               exchangeErr,
+		ControllerReachable: controllerReachable,
+	}))
+}
+
 // WatchRelationUnits returns a watcher for changes to the units on the specified relation.
 func WatchRelationUnits(backend Backend, tag names.RelationTag) (state.RelationUnitsWatcher, error) {
 	relation, err := backend.KeyRelation(tag.Id())
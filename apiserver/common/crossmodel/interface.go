@@ -4,6 +4,8 @@
 package crossmodel
 
 import (
+	"time"
+
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v2-unstable"
@@ -75,6 +77,9 @@ type Backend interface {
 	// ApplicationOfferForUUID returns the application offer for the UUID.
 	ApplicationOfferForUUID(offerUUID string) (*crossmodel.ApplicationOffer, error)
 
+	// OfferShareToken returns the offer share token with the specified id.
+	OfferShareToken(id string) (OfferShareToken, error)
+
 	// WatchStatus returns a watcher that notifies of changes to the status
 	// of the offer.
 	WatchOfferStatus(offerUUID string) (state.NotifyWatcher, error)
@@ -129,6 +134,10 @@ type Relation interface {
 
 	// SetSuspended sets the suspended status of the relation.
 	SetSuspended(bool, string) error
+
+	// UpdateRemoteHealth records the outcome of an attempt to exchange
+	// settings with the other side of the relation.
+	UpdateRemoteHealth(state.RemoteRelationHealthUpdate) error
 }
 
 // RelationUnit provides access to the settings of a single unit in a relation,
@@ -233,3 +242,16 @@ type RemoteApplication interface {
 	// SetStatus sets the status of the remote application.
 	SetStatus(info status.StatusInfo) error
 }
+
+// OfferShareToken represents a time-limited token granting consume
+// access to an offer.
+type OfferShareToken interface {
+	// Id returns the id of the token.
+	Id() string
+
+	// OfferUUID returns the UUID of the offer the token grants access to.
+	OfferUUID() string
+
+	// Expires returns the time after which the token is no longer valid.
+	Expires() time.Time
+}
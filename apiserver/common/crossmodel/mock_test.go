@@ -41,6 +41,7 @@ type mockState struct {
 	crossmodel.Backend
 	tag         names.ModelTag
 	permissions map[string]permission.Access
+	shareTokens map[string]*mockShareToken
 }
 
 func (m *mockState) ApplicationOfferForUUID(offerUUID string) (*jujucrossmodel.ApplicationOffer, error) {
@@ -70,3 +71,29 @@ func (m *mockState) ControllerTag() names.ControllerTag {
 func (m *mockState) ModelTag() names.ModelTag {
 	return m.tag
 }
+
+func (m *mockState) OfferShareToken(id string) (crossmodel.OfferShareToken, error) {
+	token, ok := m.shareTokens[id]
+	if !ok {
+		return nil, errors.NotFoundf("offer share token %q", id)
+	}
+	return token, nil
+}
+
+type mockShareToken struct {
+	id        string
+	offerUUID string
+	expires   time.Time
+}
+
+func (t *mockShareToken) Id() string {
+	return t.id
+}
+
+func (t *mockShareToken) OfferUUID() string {
+	return t.offerUUID
+}
+
+func (t *mockShareToken) Expires() time.Time {
+	return t.expires
+}
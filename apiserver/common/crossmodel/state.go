@@ -65,6 +65,15 @@ func (st stateShim) KeyRelation(key string) (Relation, error) {
 	return relationShim{r, st.State}, nil
 }
 
+// OfferShareToken returns the offer share token with the specified id.
+func (st stateShim) OfferShareToken(id string) (OfferShareToken, error) {
+	token, err := st.State.OfferShareToken(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return token, nil
+}
+
 // ControllerTag returns the tag of the controller in which we are operating.
 // This is a temporary transitional step. Eventually code using
 // crossmodel.Backend will only need to be passed a state.Model.
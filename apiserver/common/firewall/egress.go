@@ -0,0 +1,122 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+// EgressState provides the subset of global state required to work
+// out the egress rules of the model's machines.
+type EgressState interface {
+	ModelConfig() (*config.Config, error)
+	APIHostPortsForAgents() ([][]network.HostPort, error)
+	EgressMachine(id string) (EgressMachine, error)
+
+	// WatchEgressRules returns a watcher that notifies when the
+	// egress rules of any of the model's machines may have changed.
+	WatchEgressRules() state.NotifyWatcher
+}
+
+// EgressMachine provides the subset of a machine's state required to
+// work out its egress rules.
+type EgressMachine interface {
+	IsManager() bool
+	Addresses() []network.Address
+	RelatedAddresses() ([]network.Address, error)
+}
+
+// EgressStateShim returns an EgressState for the given model.
+func EgressStateShim(st *state.State, m *state.Model) EgressState {
+	return stateShim{st, m}
+}
+
+func (st stateShim) EgressMachine(id string) (EgressMachine, error) {
+	return st.State.Machine(id)
+}
+
+// WatchEgressRules implements EgressState. The rules follow the
+// model's config, the controllers' addresses, and the addresses of
+// related machines.
+func (st stateShim) WatchEgressRules() state.NotifyWatcher {
+	return common.NewMultiNotifyWatcher(
+		st.Model.WatchForModelConfigChanges(),
+		st.State.WatchAPIHostPortsForAgents(),
+		st.State.WatchRelatedAddresses(),
+	)
+}
+
+// MachineEgressRules returns the egress rules of the machine with the
+// given id, according to the model's egress-policy. When the policy
+// restricts outbound traffic, a machine may reach the controllers,
+// itself, the machines of the applications related to its units, and
+// the model's egress-allowed-cidrs. Controller machines are never
+// restricted.
+func MachineEgressRules(st EgressState, machineId string) (params.EgressRules, error) {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return params.EgressRules{}, errors.Trace(err)
+	}
+	if cfg.EgressPolicy() != config.EgressPolicyRelations {
+		return params.EgressRules{}, nil
+	}
+	machine, err := st.EgressMachine(machineId)
+	if err != nil {
+		return params.EgressRules{}, errors.Trace(err)
+	}
+	if machine.IsManager() {
+		return params.EgressRules{}, nil
+	}
+
+	cidrs := set.NewStrings(cfg.EgressAllowedCIDRs()...)
+	addCIDRs := func(addresses []network.Address) {
+		for _, addr := range addresses {
+			if cidr := addressCIDR(addr); cidr != "" {
+				cidrs.Add(cidr)
+			}
+		}
+	}
+	hostPorts, err := st.APIHostPortsForAgents()
+	if err != nil {
+		return params.EgressRules{}, errors.Trace(err)
+	}
+	for _, hps := range hostPorts {
+		for _, hp := range hps {
+			addCIDRs([]network.Address{hp.Address})
+		}
+	}
+	addCIDRs(machine.Addresses())
+	related, err := machine.RelatedAddresses()
+	if err != nil {
+		return params.EgressRules{}, errors.Trace(err)
+	}
+	addCIDRs(related)
+	return params.EgressRules{
+		Restricted:       true,
+		DestinationCIDRs: cidrs.SortedValues(),
+	}, nil
+}
+
+// addressCIDR returns the single-address CIDR of an IP address. Machine
+// local addresses and host names have no CIDR, and an empty string is
+// returned for them.
+func addressCIDR(addr network.Address) string {
+	if addr.Scope == network.ScopeMachineLocal {
+		return ""
+	}
+	switch addr.Type {
+	case network.IPv4Address:
+		return addr.Value + "/32"
+	case network.IPv6Address:
+		return addr.Value + "/128"
+	}
+	return ""
+}
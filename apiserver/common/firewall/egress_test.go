@@ -0,0 +1,121 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common/firewall"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&EgressSuite{})
+
+type EgressSuite struct {
+	coretesting.BaseSuite
+
+	st *mockEgressState
+}
+
+func (s *EgressSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.st = &mockEgressState{
+		attrs: coretesting.FakeConfig().Merge(coretesting.Attrs{
+			"egress-policy":        "relations",
+			"egress-allowed-cidrs": "192.168.10.0/24",
+		}),
+		hostPorts: [][]network.HostPort{
+			network.NewHostPorts(17070, "10.0.0.10", "localhost", "127.0.0.1"),
+			network.NewHostPorts(17070, "2001:db8::10"),
+		},
+		machines: map[string]*mockEgressMachine{
+			"0": {manager: true},
+			"1": {
+				addresses: network.NewAddresses("10.0.0.1", "::1"),
+				related:   network.NewAddresses("10.0.0.2", "10.0.0.3"),
+			},
+		},
+	}
+}
+
+func (s *EgressSuite) TestMachineEgressRules(c *gc.C) {
+	rules, err := firewall.MachineEgressRules(s.st, "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, params.EgressRules{
+		Restricted: true,
+		DestinationCIDRs: []string{
+			"10.0.0.1/32", "10.0.0.10/32", "10.0.0.2/32", "10.0.0.3/32",
+			"192.168.10.0/24", "2001:db8::10/128",
+		},
+	})
+}
+
+func (s *EgressSuite) TestMachineEgressRulesOpenPolicy(c *gc.C) {
+	s.st.attrs["egress-policy"] = "open"
+	rules, err := firewall.MachineEgressRules(s.st, "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, params.EgressRules{})
+}
+
+func (s *EgressSuite) TestMachineEgressRulesController(c *gc.C) {
+	rules, err := firewall.MachineEgressRules(s.st, "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, params.EgressRules{})
+}
+
+func (s *EgressSuite) TestMachineEgressRulesNotFound(c *gc.C) {
+	_, err := firewall.MachineEgressRules(s.st, "42")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+type mockEgressState struct {
+	attrs     coretesting.Attrs
+	hostPorts [][]network.HostPort
+	machines  map[string]*mockEgressMachine
+}
+
+func (st *mockEgressState) ModelConfig() (*config.Config, error) {
+	return config.New(config.UseDefaults, st.attrs)
+}
+
+func (st *mockEgressState) APIHostPortsForAgents() ([][]network.HostPort, error) {
+	return st.hostPorts, nil
+}
+
+func (st *mockEgressState) EgressMachine(id string) (firewall.EgressMachine, error) {
+	m, ok := st.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %q", id)
+	}
+	return m, nil
+}
+
+func (st *mockEgressState) WatchEgressRules() state.NotifyWatcher {
+	return apiservertesting.NewFakeNotifyWatcher()
+}
+
+type mockEgressMachine struct {
+	manager   bool
+	addresses []network.Address
+	related   []network.Address
+}
+
+func (m *mockEgressMachine) IsManager() bool {
+	return m.manager
+}
+
+func (m *mockEgressMachine) Addresses() []network.Address {
+	return m.addresses
+}
+
+func (m *mockEgressMachine) RelatedAddresses() ([]network.Address, error) {
+	return m.related, nil
+}
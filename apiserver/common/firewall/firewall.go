@@ -118,16 +118,16 @@ func localApplication(st State, relationTag names.RelationTag) (*localEndpointIn
 }
 
 // TODO(wallyworld) - this is unused until we query subnets again
-func includeAsEgressSubnet(cidr string) (bool, error) {
+func includeAsEgressSubnet(cidr string, supportsIPv6 bool) (bool, error) {
 	ip, _, err := net.ParseCIDR(cidr)
 	if err != nil {
 		return false, errors.Trace(err)
 	}
-	if ip.IsLoopback() || ip.IsMulticast() {
+	if ip.IsLoopback() || ip.IsMulticast() || ip.IsLinkLocalUnicast() {
 		return false, nil
 	}
-	// TODO(wallyworld) - We only support IPv4 addresses as not all providers support IPv6.
-	if ip.To4() == nil {
+	// Not all providers support IPv6 ingress rules.
+	if ip.To4() == nil && !supportsIPv6 {
 		return false, nil
 	}
 	return true, nil
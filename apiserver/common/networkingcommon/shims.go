@@ -58,6 +58,10 @@ func (s *subnetShim) SpaceName() string {
 	return s.subnet.SpaceName()
 }
 
+func (s *subnetShim) Routes() []network.Route {
+	return s.subnet.Routes()
+}
+
 // spaceShim forwards and adapts state.Space methods to BackingSpace.
 type spaceShim struct {
 	space *state.Space
@@ -133,6 +137,7 @@ func (s *stateShim) AddSubnet(info BackingSubnetInfo) (BackingSubnet, error) {
 		ProviderNetworkId: info.ProviderNetworkId,
 		AvailabilityZone:  firstZone,
 		SpaceName:         info.SpaceName,
+		Routes:            info.Routes,
 	})
 	return nil, err // Drop the first result, as it's unused.
 }
@@ -149,6 +154,14 @@ func (s *stateShim) AllSubnets() ([]BackingSubnet, error) {
 	return subnets, nil
 }
 
+func (s *stateShim) SetSubnetRoutes(cidr string, routes []network.Route) error {
+	subnet, err := s.st.Subnet(cidr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return subnet.SetRoutes(routes)
+}
+
 func (s *stateShim) AvailabilityZones() ([]providercommon.AvailabilityZone, error) {
 	// TODO(dimitern): Fix this to get them from state when available!
 	return nil, nil
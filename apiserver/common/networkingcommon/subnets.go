@@ -355,6 +355,7 @@ func addOneSubnet(ctx context.ProviderCallContext, api NetworkBacking, args para
 		VLANTag:           subnetInfo.VLANTag,
 		AvailabilityZones: zones,
 		SpaceName:         spaceTag.Id(),
+		Routes:            RoutesFromNetworkRoutes(args.Routes),
 	}
 	if _, err := api.AddSubnet(backingInfo); err != nil {
 		return errors.Trace(err)
@@ -382,6 +383,31 @@ func AddSubnets(ctx context.ProviderCallContext, api NetworkBacking, args params
 	return results, nil
 }
 
+func setOneSubnetRoutes(api NetworkBacking, args params.SetSubnetRoutesParams) error {
+	tag, err := names.ParseSubnetTag(args.SubnetTag)
+	if err != nil {
+		return errors.Annotate(err, "given SubnetTag is invalid")
+	}
+	routes := RoutesFromNetworkRoutes(args.Routes)
+	if err := api.SetSubnetRoutes(tag.Id(), routes); err != nil {
+		return errors.Annotatef(err, "setting routes of subnet %q", tag.Id())
+	}
+	return nil
+}
+
+// SetSubnetsRoutes replaces the static routes of existing subnets.
+func SetSubnetsRoutes(api NetworkBacking, args params.SetSubnetsRoutesParams) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Subnets)),
+	}
+	for i, arg := range args.Subnets {
+		if err := setOneSubnetRoutes(api, arg); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return results, nil
+}
+
 // ListSubnets lists all the available subnets or only those matching
 // all given optional filters.
 func ListSubnets(api NetworkBacking, args params.SubnetsFilters) (results params.ListSubnetsResults, err error) {
@@ -432,6 +458,7 @@ func ListSubnets(api NetworkBacking, args params.SubnetsFilters) (results params
 			SpaceTag:          spaceTag,
 			Zones:             subnet.AvailabilityZones(),
 			Status:            subnet.Status(),
+			Routes:            NetworkRoutesFromRoutes(subnet.Routes()),
 		}
 		results.Results = append(results.Results, result)
 	}
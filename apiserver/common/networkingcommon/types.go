@@ -32,6 +32,7 @@ type BackingSubnet interface {
 	Status() string
 	SpaceName() string
 	Life() params.Life
+	Routes() []network.Route
 }
 
 // BackingSubnetInfo describes a single subnet to be added in the
@@ -78,6 +79,10 @@ type BackingSubnetInfo struct {
 
 	// Live holds the life of the subnet
 	Life params.Life
+
+	// Routes holds the static routes which machines with addresses in
+	// the subnet should configure.
+	Routes []network.Route
 }
 
 // BackingSpace defines the methods supported by a Space entity stored
@@ -119,6 +124,10 @@ type NetworkBacking interface {
 	// AllSubnets returns all backing subnets.
 	AllSubnets() ([]BackingSubnet, error)
 
+	// SetSubnetRoutes replaces the static routes of the subnet with
+	// the given CIDR.
+	SetSubnetRoutes(cidr string, routes []network.Route) error
+
 	// ModelTag returns the tag of the model this state is associated to.
 	ModelTag() names.ModelTag
 
@@ -148,6 +157,40 @@ func BackingSubnetToParamsSubnet(subnet BackingSubnet) params.Subnet {
 	}
 }
 
+// NetworkRoutesFromRoutes converts a slice of network.Route into the
+// equivalent params.NetworkRoute slice.
+func NetworkRoutesFromRoutes(routes []network.Route) []params.NetworkRoute {
+	if len(routes) == 0 {
+		return nil
+	}
+	result := make([]params.NetworkRoute, len(routes))
+	for i, route := range routes {
+		result[i] = params.NetworkRoute{
+			DestinationCIDR: route.DestinationCIDR,
+			GatewayIP:       route.GatewayIP,
+			Metric:          route.Metric,
+		}
+	}
+	return result
+}
+
+// RoutesFromNetworkRoutes converts a slice of params.NetworkRoute into
+// the equivalent network.Route slice.
+func RoutesFromNetworkRoutes(routes []params.NetworkRoute) []network.Route {
+	if len(routes) == 0 {
+		return nil
+	}
+	result := make([]network.Route, len(routes))
+	for i, route := range routes {
+		result[i] = network.Route{
+			DestinationCIDR: route.DestinationCIDR,
+			GatewayIP:       route.GatewayIP,
+			Metric:          route.Metric,
+		}
+	}
+	return result
+}
+
 // NetworkConfigFromInterfaceInfo converts a slice of network.InterfaceInfo into
 // the equivalent params.NetworkConfig slice.
 func NetworkConfigFromInterfaceInfo(interfaceInfos []network.InterfaceInfo) []params.NetworkConfig {
@@ -179,6 +222,7 @@ func NetworkConfigFromInterfaceInfo(interfaceInfos []network.InterfaceInfo) []pa
 			InterfaceName:       v.InterfaceName,
 			ParentInterfaceName: v.ParentInterfaceName,
 			InterfaceType:       string(v.InterfaceType),
+			NICType:             string(v.NICType),
 			Disabled:            v.Disabled,
 			NoAutoStart:         v.NoAutoStart,
 			ConfigType:          string(v.ConfigType),
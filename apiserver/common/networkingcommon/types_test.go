@@ -881,6 +881,24 @@ func (s *TypesSuite) TestNetworkConfigsToStateArgs(c *gc.C) {
 	c.Check(devicesAddrs, jc.DeepEquals, expectedLinkLayerDeviceAdressesWithFinalNetworkConfig)
 }
 
+func (s *TypesSuite) TestRoutesConversion(c *gc.C) {
+	routes := []network.Route{{
+		DestinationCIDR: "10.10.0.0/16",
+		GatewayIP:       "192.168.0.254",
+		Metric:          100,
+	}}
+	paramsRoutes := networkingcommon.NetworkRoutesFromRoutes(routes)
+	c.Check(paramsRoutes, jc.DeepEquals, []params.NetworkRoute{{
+		DestinationCIDR: "10.10.0.0/16",
+		GatewayIP:       "192.168.0.254",
+		Metric:          100,
+	}})
+	c.Check(networkingcommon.RoutesFromNetworkRoutes(paramsRoutes), jc.DeepEquals, routes)
+
+	c.Check(networkingcommon.NetworkRoutesFromRoutes(nil), gc.IsNil)
+	c.Check(networkingcommon.RoutesFromNetworkRoutes(nil), gc.IsNil)
+}
+
 func (s *TypesSuite) TestMergeProviderAndObservedNetworkConfigsBothNil(c *gc.C) {
 	result := networkingcommon.MergeProviderAndObservedNetworkConfigs(nil, nil)
 	c.Check(result, gc.IsNil)
@@ -0,0 +1,32 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+// CheckQuota checks that the resources described by req fit within the
+// cloud's remaining quota, if env reports one. An error satisfying
+// environs.IsQuotaExceeded is returned if they do not. Failing to
+// determine the quota is not an error: a warning is logged, and the
+// request is left for the cloud to accept or reject when provisioning.
+func CheckQuota(env environs.Environ, ctx context.ProviderCallContext, req environs.QuotaRequest) error {
+	if req.Instances == 0 && req.Cores == 0 && req.Volumes == 0 {
+		return nil
+	}
+	reporter, ok := environs.SupportsQuota(env)
+	if !ok {
+		return nil
+	}
+	quota, err := reporter.Quota(ctx)
+	if err != nil {
+		logger.Warningf("cannot check cloud quota: %v", err)
+		return nil
+	}
+	return errors.Trace(quota.Check(req))
+}
@@ -0,0 +1,81 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+type quotaSuite struct {
+	jtesting.IsolationSuite
+}
+
+var _ = gc.Suite(&quotaSuite{})
+
+type quotaEnviron struct {
+	environs.Environ
+	jtesting.Stub
+
+	quota environs.Quota
+}
+
+func (e *quotaEnviron) Quota(ctx context.ProviderCallContext) (environs.Quota, error) {
+	e.MethodCall(e, "Quota", ctx)
+	return e.quota, e.NextErr()
+}
+
+func (e *quotaEnviron) InstanceCosts(ctx context.ProviderCallContext) ([]environs.InstanceCost, error) {
+	e.MethodCall(e, "InstanceCosts", ctx)
+	return nil, e.NextErr()
+}
+
+type noQuotaEnviron struct {
+	environs.Environ
+}
+
+func (s *quotaSuite) TestCheckQuotaWithinQuota(c *gc.C) {
+	env := &quotaEnviron{quota: environs.Quota{
+		Instances: &environs.QuotaLimit{Limit: 5, Used: 3},
+	}}
+	ctx := context.NewCloudCallContext()
+	err := common.CheckQuota(env, ctx, environs.QuotaRequest{Instances: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	env.CheckCall(c, 0, "Quota", ctx)
+}
+
+func (s *quotaSuite) TestCheckQuotaExceeded(c *gc.C) {
+	env := &quotaEnviron{quota: environs.Quota{
+		Instances: &environs.QuotaLimit{Limit: 5, Used: 4},
+	}}
+	err := common.CheckQuota(env, context.NewCloudCallContext(), environs.QuotaRequest{Instances: 2})
+	c.Assert(err, gc.ErrorMatches, `cloud quota exceeded: instances \(2 required, 1 of 5 remaining\)`)
+	c.Assert(err, jc.Satisfies, environs.IsQuotaExceeded)
+}
+
+func (s *quotaSuite) TestCheckQuotaError(c *gc.C) {
+	env := &quotaEnviron{}
+	env.SetErrors(errors.New("boom"))
+	err := common.CheckQuota(env, context.NewCloudCallContext(), environs.QuotaRequest{Instances: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	env.CheckCallNames(c, "Quota")
+}
+
+func (s *quotaSuite) TestCheckQuotaEmptyRequest(c *gc.C) {
+	env := &quotaEnviron{}
+	err := common.CheckQuota(env, context.NewCloudCallContext(), environs.QuotaRequest{})
+	c.Assert(err, jc.ErrorIsNil)
+	env.CheckNoCalls(c)
+}
+
+func (s *quotaSuite) TestCheckQuotaNotSupported(c *gc.C) {
+	err := common.CheckQuota(noQuotaEnviron{}, context.NewCloudCallContext(), environs.QuotaRequest{Instances: 2})
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,202 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package egressfirewall implements the API facade used by the
+// egressfirewall worker, which enforces the model's egress-policy on
+// machines whose outbound traffic the cloud can't restrict.
+package egressfirewall
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/firewall"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Facade implements the API required by the egressfirewall worker.
+type Facade struct {
+	backend      Backend
+	resources    facade.Resources
+	newEnviron   environs.NewEnvironFunc
+	callContext  context.ProviderCallContext
+	getCanAccess common.GetAuthFunc
+}
+
+// NewFacade is used for API registration.
+func NewFacade(ctx facade.Context) (*Facade, error) {
+	st := ctx.State()
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return New(
+		&backend{stateenvirons.EnvironConfigGetter{st, m}},
+		ctx.Resources(),
+		environs.New,
+		state.CallContext(st),
+		ctx.Auth(),
+	)
+}
+
+// New returns a new API facade for the egressfirewall worker.
+func New(
+	backend Backend,
+	resources facade.Resources,
+	newEnviron environs.NewEnvironFunc,
+	callContext context.ProviderCallContext,
+	authorizer facade.Authorizer,
+) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:     backend,
+		resources:   resources,
+		newEnviron:  newEnviron,
+		callContext: callContext,
+		getCanAccess: func() (common.AuthFunc, error) {
+			return authorizer.AuthOwner, nil
+		},
+	}, nil
+}
+
+// EgressRules returns the egress rules of each of the given machines,
+// and whether the machine must enforce them itself. That's the case
+// for containers and manually provisioned machines, and for all
+// machines of clouds that can't restrict outbound traffic.
+func (f *Facade) EgressRules(args params.Entities) (params.EgressRulesResults, error) {
+	results := params.EgressRulesResults{
+		Results: make([]params.EgressRulesResult, len(args.Entities)),
+	}
+	canAccess, err := f.getCanAccess()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	var cloudEnforces *bool
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil || !canAccess(tag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		rules, err := firewall.MachineEgressRules(f.backend, tag.Id())
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Rules = rules
+		if !rules.Restricted {
+			continue
+		}
+		machine, err := f.backend.Machine(tag.Id())
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		manual, err := machine.IsManual()
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if machine.IsContainer() || manual {
+			results.Results[i].Enforce = true
+			continue
+		}
+		if cloudEnforces == nil {
+			supported, err := f.cloudSupportsEgressFirewall()
+			if err != nil {
+				return results, errors.Trace(err)
+			}
+			cloudEnforces = &supported
+		}
+		results.Results[i].Enforce = !*cloudEnforces
+	}
+	return results, nil
+}
+
+// WatchEgressRules returns a NotifyWatcher for each of the given
+// machines, which notifies when the results of EgressRules may have
+// changed.
+func (f *Facade) WatchEgressRules(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := f.getCanAccess()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil || !canAccess(tag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		watch := f.backend.WatchEgressRules()
+		// Consume the initial event. Technically, API
+		// calls to Watch 'transmit' the initial event
+		// in the Watch response. But NotifyWatchers
+		// have no state to transmit.
+		if _, ok := <-watch.Changes(); ok {
+			results.Results[i].NotifyWatcherId = f.resources.Register(watch)
+		} else {
+			results.Results[i].Error = common.ServerError(watcher.EnsureErr(watch))
+		}
+	}
+	return results, nil
+}
+
+func (f *Facade) cloudSupportsEgressFirewall() (bool, error) {
+	env, err := environs.GetEnviron(f.backend, f.newEnviron)
+	if err != nil {
+		return false, errors.Annotate(err, "opening environment")
+	}
+	ef, ok := environs.SupportsEgressFirewall(env)
+	if !ok {
+		return false, nil
+	}
+	supported, err := ef.SupportsEgressRules(f.callContext)
+	return supported, errors.Trace(err)
+}
+
+// Backend defines the State API used by the egressfirewall facade.
+type Backend interface {
+	firewall.EgressState
+	CloudSpec() (environs.CloudSpec, error)
+	Machine(id string) (Machine, error)
+}
+
+// Machine specifies the methods on state.Machine of interest to the
+// egressfirewall facade.
+type Machine interface {
+	IsContainer() bool
+	IsManual() (bool, error)
+}
+
+type backend struct {
+	stateenvirons.EnvironConfigGetter
+}
+
+// EgressMachine implements firewall.EgressState.
+func (b *backend) EgressMachine(id string) (firewall.EgressMachine, error) {
+	return b.State.Machine(id)
+}
+
+// Machine implements Backend.
+func (b *backend) Machine(id string) (Machine, error) {
+	return b.State.Machine(id)
+}
+
+var _ Machine = (*state.Machine)(nil)
+
+// WatchEgressRules implements firewall.EgressState.
+func (b *backend) WatchEgressRules() state.NotifyWatcher {
+	return firewall.EgressStateShim(b.State, b.Model).WatchEgressRules()
+}
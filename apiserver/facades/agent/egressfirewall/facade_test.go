@@ -0,0 +1,242 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egressfirewall_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/firewall"
+	"github.com/juju/juju/apiserver/facades/agent/egressfirewall"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.BaseSuite
+
+	backend    *mockBackend
+	resources  *common.Resources
+	authorizer *apiservertesting.FakeAuthorizer
+	environ    environs.Environ
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		attrs: testing.FakeConfig().Merge(testing.Attrs{
+			"egress-policy":        "relations",
+			"egress-allowed-cidrs": "192.168.10.0/24",
+		}),
+		machines: map[string]*mockMachine{
+			"0":       {},
+			"0/lxd/0": {container: true},
+			"1":       {manual: true},
+		},
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	}
+	s.environ = &mockEnviron{}
+}
+
+func (s *facadeSuite) newFacade(c *gc.C) *egressfirewall.Facade {
+	facade, err := egressfirewall.New(
+		s.backend,
+		s.resources,
+		func(environs.OpenParams) (environs.Environ, error) {
+			return s.environ, nil
+		},
+		context.NewCloudCallContext(),
+		s.authorizer,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return facade
+}
+
+func (s *facadeSuite) egressRules(c *gc.C, machineId string) params.EgressRulesResult {
+	s.authorizer.Tag = names.NewMachineTag(machineId)
+	results, err := s.newFacade(c).EgressRules(params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag(machineId).String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	return results.Results[0]
+}
+
+var restrictedRules = params.EgressRules{
+	Restricted:       true,
+	DestinationCIDRs: []string{"192.168.10.0/24"},
+}
+
+func (s *facadeSuite) TestNewFacadeRequiresMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := egressfirewall.New(s.backend, s.resources, nil, context.NewCloudCallContext(), s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *facadeSuite) TestEgressRulesPermission(c *gc.C) {
+	results, err := s.newFacade(c).EgressRules(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-1"},
+			{Tag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.EgressRulesResults{
+		Results: []params.EgressRulesResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *facadeSuite) TestEgressRulesOpenPolicy(c *gc.C) {
+	s.backend.attrs["egress-policy"] = "open"
+	result := s.egressRules(c, "0/lxd/0")
+	c.Assert(result, jc.DeepEquals, params.EgressRulesResult{})
+}
+
+func (s *facadeSuite) TestEgressRulesCloudEnforces(c *gc.C) {
+	s.environ = &mockEgressEnviron{supported: true}
+	result := s.egressRules(c, "0")
+	c.Assert(result, jc.DeepEquals, params.EgressRulesResult{Rules: restrictedRules})
+}
+
+func (s *facadeSuite) TestEgressRulesCloudUnsupported(c *gc.C) {
+	result := s.egressRules(c, "0")
+	c.Assert(result, jc.DeepEquals, params.EgressRulesResult{Rules: restrictedRules, Enforce: true})
+}
+
+func (s *facadeSuite) TestEgressRulesCloudDeclines(c *gc.C) {
+	s.environ = &mockEgressEnviron{}
+	result := s.egressRules(c, "0")
+	c.Assert(result, jc.DeepEquals, params.EgressRulesResult{Rules: restrictedRules, Enforce: true})
+}
+
+func (s *facadeSuite) TestEgressRulesContainer(c *gc.C) {
+	s.environ = &mockEgressEnviron{supported: true}
+	result := s.egressRules(c, "0/lxd/0")
+	c.Assert(result, jc.DeepEquals, params.EgressRulesResult{Rules: restrictedRules, Enforce: true})
+}
+
+func (s *facadeSuite) TestEgressRulesManual(c *gc.C) {
+	s.environ = &mockEgressEnviron{supported: true}
+	result := s.egressRules(c, "1")
+	c.Assert(result, jc.DeepEquals, params.EgressRulesResult{Rules: restrictedRules, Enforce: true})
+}
+
+func (s *facadeSuite) TestWatchEgressRules(c *gc.C) {
+	results, err := s.newFacade(c).WatchEgressRules(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(s.resources.Get("1"), gc.NotNil)
+}
+
+type mockBackend struct {
+	attrs    testing.Attrs
+	machines map[string]*mockMachine
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	return config.New(config.UseDefaults, b.attrs)
+}
+
+func (b *mockBackend) CloudSpec() (environs.CloudSpec, error) {
+	return environs.CloudSpec{Type: "dummy"}, nil
+}
+
+func (b *mockBackend) APIHostPortsForAgents() ([][]network.HostPort, error) {
+	return nil, nil
+}
+
+func (b *mockBackend) EgressMachine(id string) (firewall.EgressMachine, error) {
+	return b.machine(id)
+}
+
+func (b *mockBackend) WatchEgressRules() state.NotifyWatcher {
+	return apiservertesting.NewFakeNotifyWatcher()
+}
+
+func (b *mockBackend) Machine(id string) (egressfirewall.Machine, error) {
+	return b.machine(id)
+}
+
+func (b *mockBackend) machine(id string) (*mockMachine, error) {
+	m, ok := b.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %q", id)
+	}
+	return m, nil
+}
+
+type mockMachine struct {
+	container bool
+	manual    bool
+}
+
+func (m *mockMachine) IsManager() bool {
+	return false
+}
+
+func (m *mockMachine) Addresses() []network.Address {
+	return nil
+}
+
+func (m *mockMachine) RelatedAddresses() ([]network.Address, error) {
+	return nil, nil
+}
+
+func (m *mockMachine) IsContainer() bool {
+	return m.container
+}
+
+func (m *mockMachine) IsManual() (bool, error) {
+	return m.manual, nil
+}
+
+type mockEnviron struct {
+	environs.Environ
+}
+
+type mockEgressEnviron struct {
+	mockEnviron
+	supported bool
+}
+
+func (e *mockEgressEnviron) SupportsEgressRules(context.ProviderCallContext) (bool, error) {
+	return e.supported, nil
+}
+
+func (*mockEgressEnviron) SetEgressRules(context.ProviderCallContext, instance.Id, string, environs.EgressRules) error {
+	return nil
+}
+
+func (*mockEgressEnviron) EgressRules(context.ProviderCallContext, instance.Id, string) (environs.EgressRules, error) {
+	return environs.EgressRules{}, nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package networkconfigurer implements the API facade used by the
+// networkconfigurer worker.
+package networkconfigurer
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend defines the State API used by the networkconfigurer facade.
+type Backend interface {
+	Machine(id string) (Machine, error)
+	WatchSubnetSpaces() state.NotifyWatcher
+}
+
+// Machine defines the machine methods used by the networkconfigurer
+// facade.
+type Machine interface {
+	Id() string
+	IsManual() (bool, error)
+	NetworkDefinitions() (state.NetworkDefinitions, error)
+	WatchBonds() state.NotifyWatcher
+}
+
+// Facade implements the API required by the networkconfigurer worker.
+type Facade struct {
+	backend      Backend
+	resources    facade.Resources
+	getCanAccess common.GetAuthFunc
+}
+
+// New returns a new API facade for the networkconfigurer worker.
+func New(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
+	// Only machine agents have access to the networkconfigurer service.
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:   backend,
+		resources: resources,
+		getCanAccess: func() (common.AuthFunc, error) {
+			return authorizer.AuthOwner, nil
+		},
+	}, nil
+}
+
+// NetworkDefinitions returns the bonds, VLANs and routes which should
+// be configured on each of the given machines.
+func (facade *Facade) NetworkDefinitions(args params.Entities) (params.NetworkDefinitionsResults, error) {
+	results := params.NetworkDefinitionsResults{
+		Results: make([]params.NetworkDefinitionsResult, len(args.Entities)),
+	}
+	canAccess, err := facade.getCanAccess()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Entities {
+		machine, err := facade.machine(canAccess, arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		defs, err := machine.NetworkDefinitions()
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = networkDefinitionsResult(defs)
+	}
+	return results, nil
+}
+
+// WatchNetworkDefinitions returns a NotifyWatcher for each of the given
+// machines, which notifies when the machine's bonds, or any subnet, and
+// so potentially its network definitions, change.
+func (facade *Facade) WatchNetworkDefinitions(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := facade.getCanAccess()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Entities {
+		machine, err := facade.machine(canAccess, arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		watch := common.NewMultiNotifyWatcher(machine.WatchBonds(), facade.backend.WatchSubnetSpaces())
+		// Consume the initial event. Technically, API
+		// calls to Watch 'transmit' the initial event
+		// in the Watch response. But NotifyWatchers
+		// have no state to transmit.
+		if _, ok := <-watch.Changes(); ok {
+			results.Results[i].NotifyWatcherId = facade.resources.Register(watch)
+		} else {
+			results.Results[i].Error = common.ServerError(watcher.EnsureErr(watch))
+		}
+	}
+	return results, nil
+}
+
+// machine returns the machine with the given tag, if it may be accessed
+// and its network is configured by the machine agent. Only manually
+// provisioned machines and LXD containers are: on other machines the
+// provider sets up the network.
+func (facade *Facade) machine(canAccess common.AuthFunc, tagString string) (Machine, error) {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil || !canAccess(tag) {
+		return nil, common.ErrPerm
+	}
+	machine, err := facade.backend.Machine(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if state.ContainerTypeFromId(machine.Id()) == instance.LXD {
+		return machine, nil
+	}
+	manual, err := machine.IsManual()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !manual {
+		return nil, errors.NotSupportedf("network configuration of machine %q", machine.Id())
+	}
+	return machine, nil
+}
+
+func networkDefinitionsResult(defs state.NetworkDefinitions) params.NetworkDefinitionsResult {
+	var result params.NetworkDefinitionsResult
+	for _, bond := range defs.Bonds {
+		result.Bonds = append(result.Bonds, params.NetworkBond{
+			Name:               bond.Name,
+			DeviceNames:        bond.DeviceNames,
+			Mode:               bond.Mode,
+			LACPRate:           bond.LACPRate,
+			MIIMonitorInterval: bond.MIIMonitorInterval,
+			TransmitHashPolicy: bond.TransmitHashPolicy,
+		})
+	}
+	for _, vlan := range defs.VLANs {
+		result.VLANs = append(result.VLANs, params.NetworkVLAN{
+			Name:             vlan.Name,
+			ParentName:       vlan.ParentName,
+			ParentMACAddress: vlan.ParentMACAddress,
+			VLANTag:          vlan.VLANTag,
+		})
+	}
+	for _, device := range defs.Routes {
+		routes := make([]params.NetworkRoute, len(device.Routes))
+		for i, route := range device.Routes {
+			routes[i] = params.NetworkRoute{
+				DestinationCIDR: route.DestinationCIDR,
+				GatewayIP:       route.GatewayIP,
+				Metric:          route.Metric,
+			}
+		}
+		result.Routes = append(result.Routes, params.NetworkDeviceRoutes{
+			DeviceName: device.DeviceName,
+			MACAddress: device.MACAddress,
+			Routes:     routes,
+		})
+	}
+	return result
+}
@@ -0,0 +1,254 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package networkconfigurer_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/networkconfigurer"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.BaseSuite
+	backend    *mockBackend
+	resources  *common.Resources
+	authorizer *apiservertesting.FakeAuthorizer
+	facade     *networkconfigurer.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		machines: map[string]*mockMachine{
+			"1":       {id: "1", manual: true},
+			"2":       {id: "2"},
+			"2/lxd/0": {id: "2/lxd/0"},
+			"2/kvm/0": {id: "2/kvm/0"},
+			"3":       {id: "3", manual: true},
+		},
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("1")}
+	facade, err := networkconfigurer.New(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestNewRequiresMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	_, err := networkconfigurer.New(s.backend, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *facadeSuite) TestNetworkDefinitions(c *gc.C) {
+	s.backend.machines["1"].defs = state.NetworkDefinitions{
+		Bonds: []state.BondInfo{{
+			Name:               "bond0",
+			DeviceNames:        []string{"eth0", "eth1"},
+			Mode:               "802.3ad",
+			LACPRate:           "fast",
+			MIIMonitorInterval: 100,
+			TransmitHashPolicy: "layer2",
+		}},
+		VLANs: []state.VLANInfo{{
+			Name:             "bond0.42",
+			ParentName:       "bond0",
+			ParentMACAddress: "aa:bb:cc:dd:ee:f0",
+			VLANTag:          42,
+		}},
+		Routes: []state.DeviceRoutes{{
+			DeviceName: "bond0.42",
+			Routes: []network.Route{{
+				DestinationCIDR: "10.100.0.0/16",
+				GatewayIP:       "10.0.42.254",
+				Metric:          10,
+			}},
+		}},
+	}
+
+	result, err := s.facade.NetworkDefinitions(params.Entities{Entities: []params.Entity{
+		{Tag: "machine-1"},
+		{Tag: "machine-3"},
+		{Tag: "unit-mysql-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NetworkDefinitionsResults{
+		Results: []params.NetworkDefinitionsResult{{
+			Bonds: []params.NetworkBond{{
+				Name:               "bond0",
+				DeviceNames:        []string{"eth0", "eth1"},
+				Mode:               "802.3ad",
+				LACPRate:           "fast",
+				MIIMonitorInterval: 100,
+				TransmitHashPolicy: "layer2",
+			}},
+			VLANs: []params.NetworkVLAN{{
+				Name:             "bond0.42",
+				ParentName:       "bond0",
+				ParentMACAddress: "aa:bb:cc:dd:ee:f0",
+				VLANTag:          42,
+			}},
+			Routes: []params.NetworkDeviceRoutes{{
+				DeviceName: "bond0.42",
+				Routes: []params.NetworkRoute{{
+					DestinationCIDR: "10.100.0.0/16",
+					GatewayIP:       "10.0.42.254",
+					Metric:          10,
+				}},
+			}},
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Machine", []interface{}{"1"}},
+		{"IsManual", nil},
+		{"NetworkDefinitions", nil},
+	})
+}
+
+func (s *facadeSuite) TestNetworkDefinitionsContainers(c *gc.C) {
+	for _, test := range []struct {
+		id    string
+		error *params.Error
+	}{{
+		id: "2/lxd/0",
+	}, {
+		id: "2/kvm/0",
+		error: &params.Error{
+			Code:    params.CodeNotSupported,
+			Message: `network configuration of machine "2/kvm/0" not supported`,
+		},
+	}, {
+		id: "2",
+		error: &params.Error{
+			Code:    params.CodeNotSupported,
+			Message: `network configuration of machine "2" not supported`,
+		},
+	}} {
+		c.Logf("machine %q", test.id)
+		s.authorizer.Tag = names.NewMachineTag(test.id)
+		facade, err := networkconfigurer.New(s.backend, s.resources, s.authorizer)
+		c.Assert(err, jc.ErrorIsNil)
+
+		result, err := facade.NetworkDefinitions(params.Entities{Entities: []params.Entity{
+			{Tag: names.NewMachineTag(test.id).String()},
+		}})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result.Results, gc.HasLen, 1)
+		c.Check(result.Results[0].Error, jc.DeepEquals, test.error)
+	}
+}
+
+func (s *facadeSuite) TestNetworkDefinitionsError(c *gc.C) {
+	s.backend.stub.SetErrors(nil, nil, errors.New("boom"))
+
+	result, err := s.facade.NetworkDefinitions(params.Entities{Entities: []params.Entity{
+		{Tag: "machine-1"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.NetworkDefinitionsResult{{
+		Error: &params.Error{Message: "boom"},
+	}})
+}
+
+func (s *facadeSuite) TestWatchNetworkDefinitions(c *gc.C) {
+	result, err := s.facade.WatchNetworkDefinitions(params.Entities{Entities: []params.Entity{
+		{Tag: "machine-1"},
+		{Tag: "machine-3"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{{
+			NotifyWatcherId: "1",
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}},
+	})
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Machine", []interface{}{"1"}},
+		{"IsManual", nil},
+		{"WatchBonds", nil},
+		{"WatchSubnetSpaces", nil},
+	})
+}
+
+func (s *facadeSuite) TestWatchNetworkDefinitionsNotSupported(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("2")
+	facade, err := networkconfigurer.New(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.WatchNetworkDefinitions(params.Entities{Entities: []params.Entity{
+		{Tag: "machine-2"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, jc.Satisfies, params.IsCodeNotSupported)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+}
+
+type mockBackend struct {
+	stub     jujutesting.Stub
+	machines map[string]*mockMachine
+}
+
+func (b *mockBackend) Machine(id string) (networkconfigurer.Machine, error) {
+	b.stub.AddCall("Machine", id)
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	m, ok := b.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %q", id)
+	}
+	m.stub = &b.stub
+	return m, nil
+}
+
+func (b *mockBackend) WatchSubnetSpaces() state.NotifyWatcher {
+	b.stub.AddCall("WatchSubnetSpaces")
+	return apiservertesting.NewFakeNotifyWatcher()
+}
+
+type mockMachine struct {
+	stub   *jujutesting.Stub
+	id     string
+	manual bool
+	defs   state.NetworkDefinitions
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) IsManual() (bool, error) {
+	m.stub.AddCall("IsManual")
+	return m.manual, m.stub.NextErr()
+}
+
+func (m *mockMachine) NetworkDefinitions() (state.NetworkDefinitions, error) {
+	m.stub.AddCall("NetworkDefinitions")
+	return m.defs, m.stub.NextErr()
+}
+
+func (m *mockMachine) WatchBonds() state.NotifyWatcher {
+	m.stub.AddCall("WatchBonds")
+	return apiservertesting.NewFakeNotifyWatcher()
+}
@@ -0,0 +1,34 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package networkconfigurer
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// NewFacade wraps New to express the supplied *state.State as a Backend.
+func NewFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	facade, err := New(backendShim{st}, res, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return facade, nil
+}
+
+// backendShim adapts *state.State to the Backend interface.
+type backendShim struct {
+	*state.State
+}
+
+// Machine is part of the Backend interface.
+func (b backendShim) Machine(id string) (Machine, error) {
+	machine, err := b.State.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machine, nil
+}
@@ -14,6 +14,7 @@ import (
 	imagetesting "github.com/juju/juju/environs/imagemetadata/testing"
 	sstesting "github.com/juju/juju/environs/simplestreams/testing"
 	"github.com/juju/juju/juju/keys"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/cloudimagemetadata"
 )
 
@@ -119,6 +120,109 @@ func (s *ImageMetadataSuite) TestMetadataFromState(c *gc.C) {
 	s.assertImageMetadataResults(c, result, expected...)
 }
 
+func (s *ImageMetadataSuite) TestMetadataPinnedByPolicy(c *gc.C) {
+	useTestImageData(c, testImagesData)
+	defer useTestImageData(c, nil)
+	err := s.State.SetImagePolicy(state.ImagePolicy{
+		Series:  "quantal",
+		ImageId: "ami-pinned",
+		Arch:    "amd64",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := provisioner.NewProvisionerAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.ProvisioningInfo(s.getTestMachinesTags(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := make([][]params.CloudImageMetadata, len(s.machines))
+	for i := range s.machines {
+		expected[i] = []params.CloudImageMetadata{{
+			ImageId: "ami-pinned",
+			Version: "12.10",
+			Series:  "quantal",
+			Arch:    "amd64",
+			Stream:  "daily",
+			Source:  "image policy",
+		}}
+	}
+	s.assertImageMetadataResults(c, result, expected...)
+}
+
+func (s *ImageMetadataSuite) TestMetadataPreferredByPolicy(c *gc.C) {
+	expected := s.expectedDataSoureImageMetadata()
+	metadata := s.convertCloudImageMetadata(expected[0])
+	metadata[1].VirtType = "hvm"
+	err := s.State.CloudImageMetadataStorage.SaveMetadata(metadata)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetImagePolicy(state.ImagePolicy{
+		Series:     "quantal",
+		PreferTags: map[string]string{state.ImageTagVirtType: "hvm"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := provisioner.NewProvisionerAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.ProvisioningInfo(s.getTestMachinesTags(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i := range expected {
+		expected[i] = expected[i][1:]
+		expected[i][0].VirtType = "hvm"
+	}
+	s.assertImageMetadataResults(c, result, expected...)
+}
+
+func (s *ImageMetadataSuite) TestMetadataStreamFromPolicy(c *gc.C) {
+	expected := s.expectedDataSoureImageMetadata()
+	metadata := s.convertCloudImageMetadata(expected[0])
+	metadata[0].Stream = "released"
+	err := s.State.CloudImageMetadataStorage.SaveMetadata(metadata)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The model's image-stream is "daily"; the policy selects
+	// images from "released" instead.
+	err = s.State.SetImagePolicy(state.ImagePolicy{Series: "quantal", Stream: "released"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := provisioner.NewProvisionerAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.ProvisioningInfo(s.getTestMachinesTags(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i := range expected {
+		expected[i] = expected[i][:1]
+		expected[i][0].Stream = "released"
+	}
+	s.assertImageMetadataResults(c, result, expected...)
+}
+
+func (s *ImageMetadataSuite) TestMetadataSignedRequiredByPolicy(c *gc.C) {
+	useTestImageData(c, testImagesData)
+	defer useTestImageData(c, nil)
+	expected := s.expectedDataSoureImageMetadata()
+
+	// Metadata in state is ignored, since it may not have been signed.
+	custom := s.convertCloudImageMetadata(expected[0])
+	for i := range custom {
+		custom[i].ImageId = "ami-custom"
+		custom[i].Source = "custom"
+	}
+	err := s.State.CloudImageMetadataStorage.SaveMetadata(custom)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetImagePolicy(state.ImagePolicy{
+		Series:        "quantal",
+		RequireSigned: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := provisioner.NewProvisionerAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.ProvisioningInfo(s.getTestMachinesTags(c))
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertImageMetadataResults(c, result, expected...)
+}
+
 func (s *ImageMetadataSuite) getTestMachinesTags(c *gc.C) params.Entities {
 
 	testMachines := make([]params.Entity, len(s.machines))
@@ -921,6 +921,7 @@ func (ctx *prepareOrGetContext) ProcessOneContainer(env environs.Environ, callCo
 			Disabled:            !device.IsUp(),
 			MTU:                 int(device.MTU()),
 			ParentInterfaceName: parentDevice.Name(),
+			NICType:             bridgePolicy.NICType(),
 		}
 
 		if len(parentAddrs) > 0 {
@@ -938,6 +939,7 @@ func (ctx *prepareOrGetContext) ProcessOneContainer(env environs.Environ, callCo
 				info.CIDR = parentDeviceSubnet.CIDR()
 				info.ProviderSubnetId = parentDeviceSubnet.ProviderId()
 				info.VLANTag = parentDeviceSubnet.VLANTag()
+				info.Routes = parentDeviceSubnet.Routes()
 				info.IsDefaultGateway = firstAddress.IsDefaultGateway()
 			} else {
 				info.ConfigType = network.ConfigDHCP
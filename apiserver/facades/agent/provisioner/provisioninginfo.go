@@ -60,6 +60,12 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// Affinity and anti-affinity are resolved into the zones constraint
+	// here, so the provisioner only has to deal with zones.
+	cons, err = state.ResolveZoneConstraints(p.st, cons)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot resolve availability zones")
+	}
 
 	volumes, volumeAttachments, err := p.machineVolumeParams(m, env)
 	if err != nil {
@@ -391,11 +397,32 @@ func (p *ProvisionerAPI) availableImageMetadata(m *state.Machine, env environs.E
 		return nil, errors.Annotate(err, "could not construct image constraint")
 	}
 
+	policy, err := p.st.ImagePolicy(m.Series(), imageConstraint.Region)
+	if errors.IsNotFound(err) {
+		policy = state.ImagePolicy{}
+	} else if err != nil {
+		return nil, errors.Annotate(err, "getting image policy")
+	}
+	if policy.ImageId != "" {
+		if pinned, ok := pinnedImageMetadata(policy, imageConstraint); ok {
+			logger.Debugf("using image %q pinned by image policy", policy.ImageId)
+			return []params.CloudImageMetadata{pinned}, nil
+		}
+		logger.Warningf(
+			"ignoring image %q pinned by image policy: architecture %q not in %v",
+			policy.ImageId, policy.Arch, imageConstraint.Arches,
+		)
+	}
+	if policy.Stream != "" {
+		imageConstraint.Stream = policy.Stream
+	}
+
 	// Look for image metadata in state.
-	data, err := p.findImageMetadata(imageConstraint, env)
+	data, err := p.findImageMetadata(imageConstraint, env, policy.RequireSigned)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	data = preferredImageMetadata(data, policy.PreferTags)
 	sort.Sort(metadataList(data))
 	logger.Debugf("available image metadata for provisioning: %v", data)
 	return data, nil
@@ -432,10 +459,70 @@ func (p *ProvisionerAPI) constructImageConstraint(m *state.Machine, env environs
 	return imagemetadata.NewImageConstraint(lookup), nil
 }
 
+// pinnedImageMetadata returns the metadata for the image pinned by the
+// policy, unless the machine needs a different architecture.
+func pinnedImageMetadata(policy state.ImagePolicy, constraint *imagemetadata.ImageConstraint) (params.CloudImageMetadata, bool) {
+	if len(constraint.Arches) > 0 && !set.NewStrings(constraint.Arches...).Contains(policy.Arch) {
+		return params.CloudImageMetadata{}, false
+	}
+	version, _ := series.SeriesVersion(policy.Series)
+	return params.CloudImageMetadata{
+		ImageId: policy.ImageId,
+		Stream:  constraint.Stream,
+		Region:  constraint.Region,
+		Version: version,
+		Series:  policy.Series,
+		Arch:    policy.Arch,
+		Source:  "image policy",
+	}, true
+}
+
+// preferredImageMetadata returns the image metadata that have all of
+// the preferred attributes, or all of the metadata if none do.
+func preferredImageMetadata(all []params.CloudImageMetadata, prefer map[string]string) []params.CloudImageMetadata {
+	if len(prefer) == 0 {
+		return all
+	}
+	var preferred []params.CloudImageMetadata
+	for _, m := range all {
+		attrs := map[string]string{
+			state.ImageTagVirtType:        m.VirtType,
+			state.ImageTagRootStorageType: m.RootStorageType,
+			state.ImageTagSource:          m.Source,
+		}
+		matches := true
+		for tag, value := range prefer {
+			if attrs[tag] != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			preferred = append(preferred, m)
+		}
+	}
+	if len(preferred) == 0 {
+		logger.Debugf("no image metadata matches preferred %v", prefer)
+		return all
+	}
+	return preferred
+}
+
 // findImageMetadata returns all image metadata or an error fetching them.
 // It looks for image metadata in state.
 // If none are found, we fall back on original image search in simple streams.
-func (p *ProvisionerAPI) findImageMetadata(imageConstraint *imagemetadata.ImageConstraint, env environs.Environ) ([]params.CloudImageMetadata, error) {
+// If signedOnly is true, image metadata in state is not used, since
+// it does not record whether it was signed.
+func (p *ProvisionerAPI) findImageMetadata(imageConstraint *imagemetadata.ImageConstraint, env environs.Environ, signedOnly bool) ([]params.CloudImageMetadata, error) {
+	if signedOnly {
+		dsMetadata, err := p.imageMetadataFromDataSources(env, imageConstraint, true)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		logger.Debugf("got from data sources %d signed metadata", len(dsMetadata))
+		return dsMetadata, nil
+	}
+
 	// Look for image metadata in state.
 	stateMetadata, err := p.imageMetadataFromState(imageConstraint)
 	if err != nil && !errors.IsNotFound(err) {
@@ -453,7 +540,7 @@ func (p *ProvisionerAPI) findImageMetadata(imageConstraint *imagemetadata.ImageC
 	// Currently, an image metadata worker picks up this metadata periodically (daily),
 	// and stores it in state. So potentially, this collection could be different
 	// to what is in state.
-	dsMetadata, err := p.imageMetadataFromDataSources(env, imageConstraint)
+	dsMetadata, err := p.imageMetadataFromDataSources(env, imageConstraint, false)
 	if err != nil {
 		if !errors.IsNotFound(err) {
 			return nil, errors.Trace(err)
@@ -478,33 +565,34 @@ func (p *ProvisionerAPI) imageMetadataFromState(constraint *imagemetadata.ImageC
 		return nil, errors.Trace(err)
 	}
 
-	toParams := func(m cloudimagemetadata.Metadata) params.CloudImageMetadata {
-		return params.CloudImageMetadata{
-			ImageId:         m.ImageId,
-			Stream:          m.Stream,
-			Region:          m.Region,
-			Version:         m.Version,
-			Series:          m.Series,
-			Arch:            m.Arch,
-			VirtType:        m.VirtType,
-			RootStorageType: m.RootStorageType,
-			RootStorageSize: m.RootStorageSize,
-			Source:          m.Source,
-			Priority:        m.Priority,
-		}
-	}
-
 	var all []params.CloudImageMetadata
 	for _, ms := range stored {
 		for _, m := range ms {
-			all = append(all, toParams(m))
+			all = append(all, metadataToParams(m))
 		}
 	}
 	return all, nil
 }
 
+func metadataToParams(m cloudimagemetadata.Metadata) params.CloudImageMetadata {
+	return params.CloudImageMetadata{
+		ImageId:         m.ImageId,
+		Stream:          m.Stream,
+		Region:          m.Region,
+		Version:         m.Version,
+		Series:          m.Series,
+		Arch:            m.Arch,
+		VirtType:        m.VirtType,
+		RootStorageType: m.RootStorageType,
+		RootStorageSize: m.RootStorageSize,
+		Source:          m.Source,
+		Priority:        m.Priority,
+	}
+}
+
 // imageMetadataFromDataSources finds image metadata that match specified criteria in existing data sources.
-func (p *ProvisionerAPI) imageMetadataFromDataSources(env environs.Environ, constraint *imagemetadata.ImageConstraint) ([]params.CloudImageMetadata, error) {
+// If signedOnly is true, data sources whose metadata is not signed are skipped.
+func (p *ProvisionerAPI) imageMetadataFromDataSources(env environs.Environ, constraint *imagemetadata.ImageConstraint, signedOnly bool) ([]params.CloudImageMetadata, error) {
 	sources, err := environs.ImageMetadataSources(env)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -547,6 +635,10 @@ func (p *ProvisionerAPI) imageMetadataFromDataSources(env environs.Environ, cons
 			logger.Warningf("encountered %v while getting published images metadata from %v", err, source.Description())
 			continue
 		}
+		if signedOnly && !info.Signed {
+			logger.Debugf("skipping unsigned image metadata from %v", source.Description())
+			continue
+		}
 		for _, m := range found {
 			mSeries, err := series.VersionSeries(m.Version)
 			if err != nil {
@@ -563,11 +655,20 @@ func (p *ProvisionerAPI) imageMetadataFromDataSources(env environs.Environ, cons
 		}
 	}
 
-	// Since we've fallen through to data sources search and have saved all needed images into controller,
-	// let's try to get them from controller to avoid duplication of conversion logic here.
-	all, err := p.imageMetadataFromState(constraint)
-	if err != nil {
-		return nil, errors.Annotate(err, "could not read metadata from controller after saving it there from data sources")
+	var all []params.CloudImageMetadata
+	if signedOnly {
+		// The controller may also hold unsigned metadata that matches,
+		// so use only what was found here.
+		for _, m := range metadataState {
+			all = append(all, metadataToParams(m))
+		}
+	} else {
+		// Since we've fallen through to data sources search and have saved all needed images into controller,
+		// let's try to get them from controller to avoid duplication of conversion logic here.
+		all, err = p.imageMetadataFromState(constraint)
+		if err != nil {
+			return nil, errors.Annotate(err, "could not read metadata from controller after saving it there from data sources")
+		}
 	}
 
 	if len(all) == 0 {
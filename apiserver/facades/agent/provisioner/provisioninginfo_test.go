@@ -13,6 +13,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
@@ -279,6 +280,40 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithUnsuitableSpacesConstra
 	c.Assert(result, jc.DeepEquals, expected)
 }
 
+func (s *withoutControllerSuite) TestProvisioningInfoWithAffinityConstraints(c *gc.C) {
+	zone := "zone1"
+	err := s.machines[0].SetProvisioned("i-0", "fake-nonce", &instance.HardwareCharacteristics{AvailabilityZone: &zone})
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	unit, err := mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machines[0])
+	c.Assert(err, jc.ErrorIsNil)
+
+	templates := []state.MachineTemplate{{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.MustParse("anti-affinity=mysql"),
+	}, {
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.MustParse("zones=zone2 affinity=mysql"),
+	}}
+	machines, err := s.State.AddMachines(templates...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: machines[0].Tag().String()},
+		{Tag: machines[1].Tag().String()},
+	}}
+	result, err := s.provisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[0].Result.Constraints.String(), gc.Equals, "zones=^zone1 anti-affinity=mysql")
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `cannot resolve availability zones: zones \["zone2"\] with affinity for \["mysql"\] in zones \["zone1"\] not valid`)
+}
+
 func (s *withoutControllerSuite) TestStorageProviderFallbackToType(c *gc.C) {
 	template := state.MachineTemplate{
 		Series:    "quantal",
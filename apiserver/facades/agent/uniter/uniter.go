@@ -2117,10 +2117,10 @@ func (u *UniterAPI) NetworkInfo(args params.NetworkInfoParams) (params.NetworkIn
 		}
 
 		// If there is no egress subnet explicitly defined for a given binding,
-		// default to the first ingress address. This matches the behaviour when
-		// there's a relation in place.
+		// default to the first ingress address of each family. This matches
+		// the behaviour when there's a relation in place.
 		if len(info.EgressSubnets) == 0 && len(info.IngressAddresses) > 0 {
-			info.EgressSubnets, err = network.FormatAsCIDR([]string{info.IngressAddresses[0]})
+			info.EgressSubnets, err = network.FormatAsCIDR(network.FirstAddressPerFamily(info.IngressAddresses))
 			if err != nil {
 				return result, errors.Trace(err)
 			}
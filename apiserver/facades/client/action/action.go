@@ -23,6 +23,20 @@ type ActionAPI struct {
 	check      *common.BlockChecker
 }
 
+// APIv2 provides the Action API facade for version 2.
+type APIv2 struct {
+	*ActionAPI
+}
+
+// NewActionAPIV2 returns an initialized ActionAPI for version 2.
+func NewActionAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv2, error) {
+	api, err := NewActionAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv2{api}, nil
+}
+
 // NewActionAPI returns an initialized ActionAPI
 func NewActionAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPI, error) {
 	if !authorizer.AuthClient() {
@@ -404,3 +418,6 @@ func runningActions(ar state.ActionReceiver) ([]params.ActionResult, error) {
 func completedActions(ar state.ActionReceiver) ([]params.ActionResult, error) {
 	return common.ConvertActions(ar, ar.CompletedActions)
 }
+
+// CheckNetwork isn't on the v2 API.
+func (*APIv2) CheckNetwork(_, _ struct{}) {}
@@ -0,0 +1,215 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.action")
+
+// CheckNetwork queues a juju-check-network action on each local unit in
+// scope of the relations of the specified applications, or of all the
+// relations in the model when no applications are specified. Each action
+// asks the unit to probe the ingress address and opened ports of every
+// unit on the other side of the relation, including the units of remote
+// applications in cross-model relations. The models of those remote
+// applications are returned, so that the check can be run there too.
+func (a *ActionAPI) CheckNetwork(args params.CheckNetworkParams) (results params.CheckNetworkResults, err error) {
+	if err := a.checkCanAdmin(); err != nil {
+		return results, err
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+
+	for _, name := range args.Applications {
+		if !names.IsValidApplication(name) {
+			return results, errors.Errorf("invalid application name %q", name)
+		}
+	}
+	wanted := set.NewStrings(args.Applications...)
+
+	relations, err := a.state.AllRelations()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	actionParams := params.Actions{Actions: []params.Action{}}
+	remoteModels := set.NewStrings()
+	for _, rel := range relations {
+		if !wanted.IsEmpty() && !relationInvolves(rel, wanted) {
+			continue
+		}
+		if isContainerScoped(rel) {
+			// The units of a container scoped relation share a
+			// machine, so there is no network between them to check.
+			continue
+		}
+		relRemoteModels, err := a.relationRemoteModels(rel)
+		if err != nil {
+			return results, errors.Annotatef(err, "checking relation %q", rel)
+		}
+		if args.RemoteModelUUID != "" && !relRemoteModels.Contains(args.RemoteModelUUID) {
+			continue
+		}
+		relActions, err := a.checkRelationNetworkActions(rel, args)
+		if err != nil {
+			return results, errors.Annotatef(err, "checking relation %q", rel)
+		}
+		actionParams.Actions = append(actionParams.Actions, relActions...)
+		remoteModels = remoteModels.Union(relRemoteModels)
+	}
+	actionResults, err := queueActions(a, actionParams)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Results = actionResults.Results
+	results.RemoteModelUUIDs = remoteModels.SortedValues()
+	return results, nil
+}
+
+// relationRemoteModels returns the models of the remote applications in
+// the relation.
+func (a *ActionAPI) relationRemoteModels(rel *state.Relation) (set.Strings, error) {
+	result := set.NewStrings()
+	for _, ep := range rel.Endpoints() {
+		remoteApp, err := a.state.RemoteApplication(ep.ApplicationName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.Add(remoteApp.SourceModel().Id())
+	}
+	return result, nil
+}
+
+func relationInvolves(rel *state.Relation, applications set.Strings) bool {
+	for _, ep := range rel.Endpoints() {
+		if applications.Contains(ep.ApplicationName) {
+			return true
+		}
+	}
+	return false
+}
+
+func isContainerScoped(rel *state.Relation) bool {
+	for _, ep := range rel.Endpoints() {
+		if ep.Scope == charm.ScopeContainer {
+			return true
+		}
+	}
+	return false
+}
+
+// checkRelationNetworkActions returns the juju-check-network actions to
+// queue on the local units in scope of the given relation.
+func (a *ActionAPI) checkRelationNetworkActions(rel *state.Relation, args params.CheckNetworkParams) ([]params.Action, error) {
+	var result []params.Action
+	for _, ep := range rel.Endpoints() {
+		unitNames, err := rel.UnitNamesInScope(ep.ApplicationName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		related, err := rel.RelatedEndpoints(ep.ApplicationName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unitName := range unitNames {
+			unit, err := a.state.Unit(unitName)
+			if errors.IsNotFound(err) {
+				// Units of remote applications are probed, but
+				// cannot be asked to probe.
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			relUnit, err := rel.Unit(unit)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			var targets []interface{}
+			for _, relatedEp := range related {
+				relatedTargets, err := a.probeTargets(rel, relUnit, relatedEp.ApplicationName, unitName)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				targets = append(targets, relatedTargets...)
+			}
+			if len(targets) == 0 {
+				continue
+			}
+			result = append(result, params.Action{
+				Receiver: unit.Tag().String(),
+				Name:     actions.JujuCheckNetworkActionName,
+				Parameters: map[string]interface{}{
+					"relation": rel.String(),
+					"targets":  targets,
+					"timeout":  args.Timeout.Nanoseconds(),
+				},
+			})
+		}
+	}
+	return result, nil
+}
+
+// probeTargets returns the units of the given application that are in
+// scope of the relation, along with the ingress address and opened ports
+// the probing unit should check for each of them.
+func (a *ActionAPI) probeTargets(rel *state.Relation, relUnit *state.RelationUnit, applicationName, probingUnit string) ([]interface{}, error) {
+	unitNames, err := rel.UnitNamesInScope(applicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var targets []interface{}
+	for _, unitName := range unitNames {
+		if unitName == probingUnit {
+			continue
+		}
+		settings, err := relUnit.ReadSettings(unitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		address, _ := settings["ingress-address"].(string)
+		if address == "" {
+			address, _ = settings["private-address"].(string)
+		}
+		ports := []interface{}{}
+		unit, err := a.state.Unit(unitName)
+		if err == nil {
+			if address == "" {
+				if addr, err := unit.PrivateAddress(); err == nil {
+					address = addr.Value
+				}
+			}
+			opened, err := unit.OpenedPorts()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, portRange := range opened {
+				ports = append(ports, portRange.String())
+			}
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if address == "" {
+			logger.Debugf("no ingress address known for unit %q in relation %q", unitName, rel)
+			continue
+		}
+		targets = append(targets, map[string]interface{}{
+			"unit":    unitName,
+			"address": address,
+			"ports":   ports,
+		})
+	}
+	return targets, nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	commontesting "github.com/juju/juju/apiserver/common/testing"
+	"github.com/juju/juju/apiserver/facades/client/action"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+)
+
+type checkNetworkSuite struct {
+	jujutesting.JujuConnSuite
+	commontesting.BlockHelper
+
+	client *action.ActionAPI
+}
+
+var _ = gc.Suite(&checkNetworkSuite{})
+
+func (s *checkNetworkSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.BlockHelper = commontesting.NewBlockHelper(s.APIState)
+	s.AddCleanup(func(*gc.C) { s.BlockHelper.Close() })
+
+	var err error
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	s.client, err = action.NewActionAPI(s.State, nil, auth)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *checkNetworkSuite) enterScope(c *gc.C, rel *state.Relation, app *state.Application, ingress string) *state.Unit {
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"ingress-address": ingress})
+	c.Assert(err, jc.ErrorIsNil)
+	return unit
+}
+
+func (s *checkNetworkSuite) setUpRelation(c *gc.C) *state.Relation {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.enterScope(c, rel, wordpress, "10.0.0.1")
+	mysql0 := s.enterScope(c, rel, mysql, "10.0.0.2")
+	err = mysql0.OpenPort("tcp", 3306)
+	c.Assert(err, jc.ErrorIsNil)
+	return rel
+}
+
+func (s *checkNetworkSuite) TestCheckNetwork(c *gc.C) {
+	rel := s.setUpRelation(c)
+	// An unrelated application is not checked.
+	s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+
+	expectedArgs := params.Actions{
+		Actions: []params.Action{{
+			Receiver: "unit-wordpress-0",
+			Name:     "juju-check-network",
+			Parameters: map[string]interface{}{
+				"relation": rel.String(),
+				"timeout":  int64(5 * time.Second),
+				"targets": []interface{}{map[string]interface{}{
+					"unit":    "mysql/0",
+					"address": "10.0.0.2",
+					"ports":   []interface{}{"3306/tcp"},
+				}},
+			},
+		}, {
+			Receiver: "unit-mysql-0",
+			Name:     "juju-check-network",
+			Parameters: map[string]interface{}{
+				"relation": rel.String(),
+				"timeout":  int64(5 * time.Second),
+				"targets": []interface{}{map[string]interface{}{
+					"unit":    "wordpress/0",
+					"address": "10.0.0.1",
+					"ports":   []interface{}{},
+				}},
+			},
+		}},
+	}
+	called := false
+	s.PatchValue(action.QueueActions, func(client *action.ActionAPI, args params.Actions) (params.ActionResults, error) {
+		called = true
+		c.Check(args.Actions, jc.SameContents, expectedArgs.Actions)
+		return params.ActionResults{}, nil
+	})
+
+	_, err := s.client.CheckNetwork(params.CheckNetworkParams{
+		Applications: []string{"mysql"},
+		Timeout:      5 * time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *checkNetworkSuite) TestCheckNetworkOtherApplication(c *gc.C) {
+	s.setUpRelation(c)
+	s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+
+	s.PatchValue(action.QueueActions, func(client *action.ActionAPI, args params.Actions) (params.ActionResults, error) {
+		c.Check(args.Actions, gc.HasLen, 0)
+		return params.ActionResults{}, nil
+	})
+
+	_, err := s.client.CheckNetwork(params.CheckNetworkParams{
+		Applications: []string{"dummy"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *checkNetworkSuite) TestCheckNetworkQueuesActions(c *gc.C) {
+	s.setUpRelation(c)
+
+	results, err := s.client.CheckNetwork(params.CheckNetworkParams{Timeout: time.Second})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	for _, result := range results.Results {
+		c.Check(result.Error, gc.IsNil)
+		c.Check(result.Action.Name, gc.Equals, "juju-check-network")
+	}
+}
+
+func (s *checkNetworkSuite) TestCheckNetworkRemoteModels(c *gc.C) {
+	s.setUpRelation(c)
+	remoteModel := names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	_, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "remote-db",
+		URL:         "prod.mysql",
+		SourceModel: remoteModel,
+		Endpoints: []charm.Relation{{
+			Interface: "mysql",
+			Name:      "server",
+			Role:      charm.RoleProvider,
+			Scope:     charm.ScopeGlobal,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	blog := s.AddTestingApplication(c, "blog", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("blog", "remote-db")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	s.enterScope(c, rel, blog, "10.0.0.3")
+	remoteUnit, err := rel.RemoteUnit("remote-db/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = remoteUnit.EnterScope(map[string]interface{}{"ingress-address": "192.168.1.1"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var receivers []string
+	s.PatchValue(action.QueueActions, func(client *action.ActionAPI, args params.Actions) (params.ActionResults, error) {
+		receivers = nil
+		for _, a := range args.Actions {
+			receivers = append(receivers, a.Receiver)
+		}
+		return params.ActionResults{}, nil
+	})
+
+	results, err := s.client.CheckNetwork(params.CheckNetworkParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.RemoteModelUUIDs, jc.DeepEquals, []string{remoteModel.Id()})
+	c.Check(receivers, jc.SameContents, []string{"unit-wordpress-0", "unit-mysql-0", "unit-blog-0"})
+
+	// Only the relations with the remote model are checked from there.
+	_, err = s.client.CheckNetwork(params.CheckNetworkParams{RemoteModelUUID: remoteModel.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(receivers, jc.DeepEquals, []string{"unit-blog-0"})
+}
+
+func (s *checkNetworkSuite) TestCheckNetworkInvalidApplication(c *gc.C) {
+	_, err := s.client.CheckNetwork(params.CheckNetworkParams{
+		Applications: []string{"not/valid"},
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid application name "not/valid"`)
+}
+
+func (s *checkNetworkSuite) TestBlockCheckNetwork(c *gc.C) {
+	s.BlockAllChanges(c, "TestBlockCheckNetwork")
+	_, err := s.client.CheckNetwork(params.CheckNetworkParams{})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue, gc.Commentf("error: %#v", err))
+}
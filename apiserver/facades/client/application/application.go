@@ -31,6 +31,7 @@ import (
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
@@ -62,6 +63,16 @@ type APIv7 struct {
 
 // APIv8 provides the Application API facade for version 8.
 type APIv8 struct {
+	*APIv9
+}
+
+// APIv9 provides the Application API facade for version 9.
+type APIv9 struct {
+	*APIv10
+}
+
+// APIv10 provides the Application API facade for version 10.
+type APIv10 struct {
 	*APIBase
 }
 
@@ -86,7 +97,8 @@ type APIBase struct {
 	stateCharm func(Charm) *state.Charm
 
 	deployApplicationFunc func(ApplicationDeployer, DeployApplicationParams) (Application, error)
-	getEnviron            stateenvirons.NewEnvironFunc
+	newEnviron            func() (environs.Environ, error)
+	callContext           context.ProviderCallContext
 }
 
 // NewFacadeV4 provides the signature required for facade registration
@@ -130,13 +142,33 @@ func NewFacadeV7(ctx facade.Context) (*APIv7, error) {
 }
 
 func NewFacadeV8(ctx facade.Context) (*APIv8, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV9(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv8{api}, nil
 }
 
+// NewFacadeV9 provides the signature required for facade registration
+// for version 9.
+func NewFacadeV9(ctx facade.Context) (*APIv9, error) {
+	api, err := NewFacadeV10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv9{api}, nil
+}
+
+// NewFacadeV10 provides the signature required for facade registration
+// for version 10.
+func NewFacadeV10(ctx facade.Context) (*APIv10, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv10{api}, nil
+}
+
 func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 	model, err := ctx.State().Model()
 	if err != nil {
@@ -148,7 +180,7 @@ func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 	}
 	blockChecker := common.NewBlockChecker(ctx.State())
 	stateCharm := CharmToStateCharm
-	return NewAPIBase(
+	api, err := NewAPIBase(
 		&stateShim{ctx.State()},
 		storageAccess,
 		ctx.Auth(),
@@ -158,6 +190,15 @@ func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 		stateCharm,
 		DeployApplication,
 	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	configGetter := stateenvirons.EnvironConfigGetter{ctx.State(), model}
+	api.newEnviron = func() (environs.Environ, error) {
+		return environs.GetEnviron(configGetter, environs.New)
+	}
+	api.callContext = state.CallContext(ctx.State())
+	return api, nil
 }
 
 // NewAPIBase returns a new application API facade.
@@ -297,7 +338,10 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 		return result, errors.Trace(err)
 	}
 	for i, arg := range args.Applications {
-		err := deployApplication(api.backend, api.modelType, api.stateCharm, arg, api.deployApplicationFunc)
+		err := api.checkQuota(arg.NumUnits, arg.Placement, arg.Constraints)
+		if err == nil {
+			err = deployApplication(api.backend, api.modelType, api.stateCharm, arg, api.deployApplicationFunc)
+		}
 		result.Results[i].Error = common.ServerError(err)
 
 		if err != nil && len(arg.Resources) != 0 {
@@ -901,7 +945,9 @@ func (api *APIBase) CharmRelations(p params.ApplicationCharmRelations) (params.A
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If expose settings
+// are given, they are merged with those already set, and the ports
+// are only opened to the spaces and CIDRs they name.
 func (api *APIBase) Expose(args params.ApplicationExpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return errors.Trace(err)
@@ -924,11 +970,25 @@ func (api *APIBase) Expose(args params.ApplicationExpose) error {
 					"juju config %s %s=<value>", caas.JujuExternalHostNameKey, args.ApplicationName, caas.JujuExternalHostNameKey)
 		}
 	}
-	return app.SetExposed()
+	if len(args.ExposedEndpoints) == 0 {
+		return app.SetExposed()
+	}
+	if api.modelType == state.ModelTypeCAAS {
+		return errors.NotSupportedf("exposing a CAAS application to specific spaces or CIDRs")
+	}
+	settings := make(map[string]state.ExposedEndpoint, len(args.ExposedEndpoints))
+	for endpoint, exposed := range args.ExposedEndpoints {
+		settings[endpoint] = state.ExposedEndpoint{
+			ExposeToSpaces: exposed.ExposeToSpaces,
+			ExposeToCIDRs:  exposed.ExposeToCIDRs,
+		}
+	}
+	return errors.Trace(app.MergeExposeSettings(settings))
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If endpoints are given,
+// only their expose settings are removed.
 func (api *APIBase) Unexpose(args params.ApplicationUnexpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
@@ -940,6 +1000,9 @@ func (api *APIBase) Unexpose(args params.ApplicationUnexpose) error {
 	if err != nil {
 		return err
 	}
+	if len(args.ExposedEndpoints) > 0 {
+		return errors.Trace(app.UnsetExposeSettings(args.ExposedEndpoints))
+	}
 	return app.ClearExposed()
 }
 
@@ -966,6 +1029,9 @@ func (api *APIBase) AddUnits(args params.AddApplicationUnits) (params.AddApplica
 	if err := api.check.ChangeAllowed(); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
+	if err := api.checkAddUnitsQuota(args); err != nil {
+		return params.AddApplicationUnitsResults{}, errors.Trace(err)
+	}
 	units, err := addApplicationUnits(api.backend, api.modelType, args)
 	if err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
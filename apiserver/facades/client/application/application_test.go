@@ -47,7 +47,7 @@ type applicationSuite struct {
 	apiservertesting.CharmStoreSuite
 	commontesting.BlockHelper
 
-	applicationAPI *application.APIv8
+	applicationAPI *application.APIv10
 	application    *state.Application
 	authorizer     *apiservertesting.FakeAuthorizer
 }
@@ -84,7 +84,7 @@ func (s *applicationSuite) TearDownTest(c *gc.C) {
 	s.JujuConnSuite.TearDownTest(c)
 }
 
-func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv8 {
+func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv10 {
 	resources := common.NewResources()
 	resources.RegisterNamed("dataDir", common.StringResource(c.MkDir()))
 	storageAccess, err := application.GetStorageState(s.State)
@@ -103,7 +103,7 @@ func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv8 {
 		application.DeployApplication,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	return &application.APIv8{api}
+	return &application.APIv10{api}
 }
 
 func (s *applicationSuite) TestGetConfig(c *gc.C) {
@@ -2090,7 +2090,7 @@ func (s *applicationSuite) TestApplicationExpose(c *gc.C) {
 	c.Assert(apps[1].IsExposed(), jc.IsTrue)
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -2142,7 +2142,7 @@ var applicationExposeTests = []struct {
 func (s *applicationSuite) assertApplicationExpose(c *gc.C) {
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -2157,7 +2157,7 @@ func (s *applicationSuite) assertApplicationExpose(c *gc.C) {
 func (s *applicationSuite) assertApplicationExposeBlocked(c *gc.C, msg string) {
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -2215,7 +2215,7 @@ func (s *applicationSuite) TestApplicationUnexpose(c *gc.C) {
 			app.SetExposed()
 		}
 		c.Assert(app.IsExposed(), gc.Equals, t.initial)
-		err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{t.application})
+		err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{ApplicationName: t.application})
 		if t.err == "" {
 			c.Assert(err, jc.ErrorIsNil)
 			app.Refresh()
@@ -2228,6 +2228,44 @@ func (s *applicationSuite) TestApplicationUnexpose(c *gc.C) {
 	}
 }
 
+func (s *applicationSuite) TestApplicationExposeSettings(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "wordpress",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"url": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsTrue)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+
+	err = s.applicationAPI.Unexpose(params.ApplicationUnexpose{
+		ApplicationName:  "wordpress",
+		ExposedEndpoints: []string{"url"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsFalse)
+	c.Assert(app.ExposedEndpoints(), gc.HasLen, 0)
+}
+
+func (s *applicationSuite) TestApplicationExposeSettingsInvalid(c *gc.C) {
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "wordpress",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"url": {ExposeToCIDRs: []string{"not-a-cidr"}},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "wordpress": .*`)
+}
+
 func (s *applicationSuite) setupApplicationUnexpose(c *gc.C) *state.Application {
 	charm := s.AddTestingCharm(c, "dummy")
 	app := s.AddTestingApplication(c, "dummy-application", charm)
@@ -2237,7 +2275,7 @@ func (s *applicationSuite) setupApplicationUnexpose(c *gc.C) *state.Application
 }
 
 func (s *applicationSuite) assertApplicationUnexpose(c *gc.C, app *state.Application) {
-	err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{"dummy-application"})
+	err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{ApplicationName: "dummy-application"})
 	c.Assert(err, jc.ErrorIsNil)
 	app.Refresh()
 	c.Assert(app.IsExposed(), gc.Equals, false)
@@ -2246,7 +2284,7 @@ func (s *applicationSuite) assertApplicationUnexpose(c *gc.C, app *state.Applica
 }
 
 func (s *applicationSuite) assertApplicationUnexposeBlocked(c *gc.C, app *state.Application, msg string) {
-	err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{"dummy-application"})
+	err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{ApplicationName: "dummy-application"})
 	s.AssertBlocked(c, err, msg)
 	err = app.Destroy()
 	c.Assert(err, jc.ErrorIsNil)
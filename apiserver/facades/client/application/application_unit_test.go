@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -18,6 +20,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/caas"
 	k8s "github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/constraints"
 	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
@@ -39,7 +42,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv8
+	api          *application.APIv10
 }
 
 var _ = gc.Suite(&ApplicationSuite{})
@@ -61,7 +64,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv8{api}
+	s.api = &application.APIv10{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -511,6 +514,41 @@ func (s *ApplicationSuite) TestAddUnits(c *gc.C) {
 	app.addedUnit.CheckCall(c, 0, "AssignWithPolicy", state.AssignCleanEmpty)
 }
 
+func (s *ApplicationSuite) TestAddUnitsExceedsQuota(c *gc.C) {
+	cores := uint64(2)
+	app := s.backend.applications["postgresql"]
+	app.constraints = constraints.Value{CpuCores: &cores}
+	application.SetEnviron(s.api, &mockQuotaEnviron{quota: environs.Quota{
+		Instances: &environs.QuotaLimit{Limit: 10, Used: 5},
+		Cores:     &environs.QuotaLimit{Limit: 20, Used: 17},
+	}})
+	_, err := s.api.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        3,
+		// The unit placed on an existing machine needs no new instance.
+		Placement: []*instance.Placement{{Scope: instance.MachineScope, Directive: "0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cloud quota exceeded: cores \(4 required, 3 of 20 remaining\)`)
+	c.Assert(err, jc.Satisfies, environs.IsQuotaExceeded)
+	app.CheckCallNames(c, "Constraints")
+}
+
+func (s *ApplicationSuite) TestDeployExceedsQuota(c *gc.C) {
+	application.SetEnviron(s.api, &mockQuotaEnviron{quota: environs.Quota{
+		Instances: &environs.QuotaLimit{Limit: 10, Used: 9},
+	}})
+	results, err := s.api.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        2,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `cloud quota exceeded: instances \(2 required, 1 of 10 remaining\)`)
+}
+
 func (s *ApplicationSuite) TestAddUnitsCAASModel(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	_, err := s.api.AddUnits(params.AddApplicationUnits{
@@ -1224,3 +1262,133 @@ func (s *ApplicationSuite) TestCAASExposeWithHostname(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	app.CheckCallNames(c, "ApplicationConfig", "SetExposed")
 }
+
+func (s *ApplicationSuite) TestExposeWithSettings(c *gc.C) {
+	err := s.api.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"":   {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+			"db": {ExposeToSpaces: []string{"public"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 0, "MergeExposeSettings", map[string]state.ExposedEndpoint{
+		"":   {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"db": {ExposeToSpaces: []string{"public"}},
+	})
+}
+
+func (s *ApplicationSuite) TestCAASExposeWithSettings(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{"juju-external-hostname": "exthost"}
+	err := s.api.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	app.CheckCallNames(c, "ApplicationConfig")
+}
+
+func (s *ApplicationSuite) TestUnexposeEndpoints(c *gc.C) {
+	err := s.api.Unexpose(params.ApplicationUnexpose{
+		ApplicationName:  "postgresql",
+		ExposedEndpoints: []string{"db"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 0, "UnsetExposeSettings", []string{"db"})
+}
+
+func (s *ApplicationSuite) TestOfferConnectionsInfoConsumer(c *gc.C) {
+	exchanged := time.Date(2018, 5, 1, 10, 0, 0, 0, time.UTC)
+	failed := exchanged.Add(time.Minute)
+	s.relation.id = 123
+	s.relation.key = "wordpress:db hosted-db2:db"
+	s.relation.status = status.Joined
+	s.relation.health = &state.RemoteRelationHealth{
+		LastExchange:  exchanged,
+		LastError:     "connection refused",
+		LastErrorTime: failed,
+		Updated:       failed,
+	}
+	s.backend.remoteApplications["hosted-db2"] = &mockRemoteApplication{
+		name:      "hosted-db2",
+		offerURL:  "othermodel.hosted-db2",
+		relations: []application.Relation{&s.relation},
+	}
+
+	results, err := s.api.OfferConnectionsInfo(params.Entities{Entities: []params.Entity{
+		{Tag: "application-hosted-db2"},
+		{Tag: "unit-hosted-db2-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result, jc.DeepEquals, &params.OfferConnectionsInfo{
+		Name:     "hosted-db2",
+		OfferURL: "othermodel.hosted-db2",
+		Consumer: true,
+		Connections: []params.OfferConnectionInfo{{
+			RelationId: 123,
+			Key:        "wordpress:db hosted-db2:db",
+			Status:     params.EntityStatus{Status: status.Joined},
+			Health: &params.RemoteRelationHealth{
+				LastExchange:  &exchanged,
+				LastError:     "connection refused",
+				LastErrorTime: &failed,
+				Updated:       failed,
+			},
+		}},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `tag "unit-hosted-db2-0" not valid`)
+}
+
+func (s *ApplicationSuite) TestOfferConnectionsInfoOfferer(c *gc.C) {
+	s.relation.id = 123
+	s.relation.key = "remote-wordpress:db postgresql:db"
+	s.relation.status = status.Joined
+	s.backend.offers = map[string]*crossmodel.ApplicationOffer{
+		"hosted-db": {OfferUUID: "offer-uuid", OfferName: "hosted-db"},
+	}
+	s.backend.offerConnectionsByOffer = map[string][]application.OfferConnection{
+		"offer-uuid": {
+			&mockOfferConnection{
+				relationId:      123,
+				relationKey:     "remote-wordpress:db postgresql:db",
+				username:        "fred",
+				sourceModelUUID: coretesting.ModelTag.Id(),
+			},
+			&mockOfferConnection{
+				relationId:  456,
+				relationKey: "remote-gone:db postgresql:db",
+			},
+		},
+	}
+
+	results, err := s.api.OfferConnectionsInfo(params.Entities{Entities: []params.Entity{
+		{Tag: "applicationoffer-hosted-db"},
+		{Tag: "applicationoffer-missing"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result, jc.DeepEquals, &params.OfferConnectionsInfo{
+		Name: "hosted-db",
+		Connections: []params.OfferConnectionInfo{{
+			RelationId:     123,
+			Key:            "remote-wordpress:db postgresql:db",
+			SourceModelTag: coretesting.ModelTag.String(),
+			Username:       "fred",
+			Status:         params.EntityStatus{Status: status.Joined},
+		}},
+	})
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	s.backend.CheckCallNames(c,
+		"ApplicationOffer", "OfferConnections", "KeyRelation", "KeyRelation",
+		"ApplicationOffer",
+	)
+}
@@ -43,6 +43,9 @@ type Backend interface {
 	Resources() (Resources, error)
 	OfferConnectionForRelation(string) (OfferConnection, error)
 	SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error)
+	ApplicationOffer(name string) (*crossmodel.ApplicationOffer, error)
+	OfferConnections(offerUUID string) ([]OfferConnection, error)
+	KeyRelation(key string) (Relation, error)
 }
 
 // BlockChecker defines the block-checking functionality required by
@@ -70,12 +73,14 @@ type Application interface {
 	DestroyOperation() *state.DestroyApplicationOperation
 	Endpoints() ([]state.Endpoint, error)
 	IsPrincipal() bool
+	MergeExposeSettings(map[string]state.ExposedEndpoint) error
 	Series() string
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
 	SetExposed() error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
+	UnsetExposeSettings([]string) error
 	UpdateApplicationSeries(string, bool) error
 	UpdateCharmConfig(charm.Settings) error
 	ApplicationConfig() (application.ConfigAttributes, error)
@@ -104,12 +109,16 @@ type Machine interface {
 // the same names.
 type Relation interface {
 	status.StatusSetter
+	status.StatusGetter
+	Id() int
+	String() string
 	Tag() names.Tag
 	Destroy() error
 	Endpoint(string) (state.Endpoint, error)
 	SetSuspended(bool, string) error
 	Suspended() bool
 	SuspendedReason() string
+	RemoteHealth() (state.RemoteRelationHealth, error)
 }
 
 // Unit defines a subset of the functionality provided by the
@@ -235,11 +244,25 @@ type remoteApplicationShim struct {
 type RemoteApplication interface {
 	Name() string
 	SourceModel() names.ModelTag
+	URL() (string, bool)
 	Endpoints() ([]state.Endpoint, error)
 	AddEndpoints(eps []charm.Relation) error
 	Bindings() map[string]string
 	Spaces() []state.RemoteSpace
 	Destroy() error
+	Relations() ([]Relation, error)
+}
+
+func (a *remoteApplicationShim) Relations() ([]Relation, error) {
+	rels, err := a.RemoteApplication.Relations()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Relation, len(rels))
+	for i, r := range rels {
+		result[i] = stateRelationShim{r}
+	}
+	return result, nil
 }
 
 func (s stateShim) RemoteApplication(name string) (RemoteApplication, error) {
@@ -321,12 +344,41 @@ func (s stateShim) Resources() (Resources, error) {
 	return s.State.Resources()
 }
 
-type OfferConnection interface{}
+type OfferConnection interface {
+	RelationId() int
+	RelationKey() string
+	UserName() string
+	SourceModelUUID() string
+}
 
 func (s stateShim) OfferConnectionForRelation(key string) (OfferConnection, error) {
 	return s.State.OfferConnectionForRelation(key)
 }
 
+func (s stateShim) OfferConnections(offerUUID string) ([]OfferConnection, error) {
+	conns, err := s.State.OfferConnections(offerUUID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]OfferConnection, len(conns))
+	for i, oc := range conns {
+		result[i] = oc
+	}
+	return result, nil
+}
+
+func (s stateShim) ApplicationOffer(name string) (*crossmodel.ApplicationOffer, error) {
+	return state.NewApplicationOffers(s.State).ApplicationOffer(name)
+}
+
+func (s stateShim) KeyRelation(key string) (Relation, error) {
+	r, err := s.State.KeyRelation(key)
+	if err != nil {
+		return nil, err
+	}
+	return stateRelationShim{r}, nil
+}
+
 type stateApplicationShim struct {
 	*state.Application
 	st *state.State
@@ -0,0 +1,143 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// OfferConnectionsInfo isn't on the V9 API.
+func (u *APIv9) OfferConnectionsInfo(_, _ struct{}) {}
+
+// OfferConnectionsInfo returns the cross-model relations of the specified
+// offers hosted in the model, or of the specified remote applications
+// consuming offers, along with how well settings are being exchanged over
+// each relation.
+func (api *APIBase) OfferConnectionsInfo(args params.Entities) (params.OfferConnectionsInfoResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.OfferConnectionsInfoResults{}, errors.Trace(err)
+	}
+	results := make([]params.OfferConnectionsInfoResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		info, err := api.offerConnectionsInfo(entity.Tag)
+		results[i].Result = info
+		results[i].Error = common.ServerError(err)
+	}
+	return params.OfferConnectionsInfoResults{Results: results}, nil
+}
+
+func (api *APIBase) offerConnectionsInfo(tagString string) (*params.OfferConnectionsInfo, error) {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch tag := tag.(type) {
+	case names.ApplicationTag:
+		return api.consumerConnectionsInfo(tag.Id())
+	case names.ApplicationOfferTag:
+		return api.offererConnectionsInfo(tag.Id())
+	}
+	return nil, errors.NotValidf("tag %q", tagString)
+}
+
+// consumerConnectionsInfo returns the relations of a remote application
+// which consumes an offer.
+func (api *APIBase) consumerConnectionsInfo(name string) (*params.OfferConnectionsInfo, error) {
+	app, err := api.backend.RemoteApplication(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info := &params.OfferConnectionsInfo{
+		Name:     app.Name(),
+		Consumer: true,
+	}
+	info.OfferURL, _ = app.URL()
+	rels, err := app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rel := range rels {
+		conn, err := offerConnectionInfo(rel)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info.Connections = append(info.Connections, conn)
+	}
+	return info, nil
+}
+
+// offererConnectionsInfo returns the relations established by consumers
+// of an offer hosted in the model.
+func (api *APIBase) offererConnectionsInfo(offerName string) (*params.OfferConnectionsInfo, error) {
+	offer, err := api.backend.ApplicationOffer(offerName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	conns, err := api.backend.OfferConnections(offer.OfferUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info := &params.OfferConnectionsInfo{
+		Name: offer.OfferName,
+	}
+	for _, oc := range conns {
+		rel, err := api.backend.KeyRelation(oc.RelationKey())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		conn, err := offerConnectionInfo(rel)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		conn.SourceModelTag = names.NewModelTag(oc.SourceModelUUID()).String()
+		conn.Username = oc.UserName()
+		info.Connections = append(info.Connections, conn)
+	}
+	return info, nil
+}
+
+func offerConnectionInfo(rel Relation) (params.OfferConnectionInfo, error) {
+	statusInfo, err := rel.Status()
+	if err != nil {
+		return params.OfferConnectionInfo{}, errors.Trace(err)
+	}
+	conn := params.OfferConnectionInfo{
+		RelationId: rel.Id(),
+		Key:        rel.String(),
+		Status:     common.EntityStatusFromState(statusInfo),
+	}
+	health, err := rel.RemoteHealth()
+	if errors.IsNotFound(err) {
+		// No settings have been exchanged yet.
+		return conn, nil
+	} else if err != nil {
+		return params.OfferConnectionInfo{}, errors.Trace(err)
+	}
+	conn.Health = remoteRelationHealthParams(health)
+	return conn, nil
+}
+
+func remoteRelationHealthParams(health state.RemoteRelationHealth) *params.RemoteRelationHealth {
+	result := &params.RemoteRelationHealth{
+		ControllerReachable: health.ControllerReachable,
+		LastError:           health.LastError,
+		Updated:             health.Updated,
+	}
+	if !health.LastExchange.IsZero() {
+		lastExchange := health.LastExchange
+		result.LastExchange = &lastExchange
+	}
+	if !health.LastErrorTime.IsZero() {
+		lastErrorTime := health.LastErrorTime
+		result.LastErrorTime = &lastErrorTime
+	}
+	return result
+}
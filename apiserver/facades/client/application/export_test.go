@@ -3,7 +3,11 @@
 
 package application
 
-import "github.com/juju/juju/state"
+import (
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/state"
+)
 
 var (
 	ParseSettingsCompatible = parseSettingsCompatible
@@ -15,6 +19,13 @@ func GetState(st *state.State) Backend {
 	return stateShim{st}
 }
 
-func SetModelType(api *APIv8, modelType state.ModelType) {
+func SetModelType(api *APIv10, modelType state.ModelType) {
 	api.modelType = modelType
 }
+
+func SetEnviron(api *APIv10, env environs.Environ) {
+	api.newEnviron = func() (environs.Environ, error) {
+		return env, nil
+	}
+	api.callContext = context.NewCloudCallContext()
+}
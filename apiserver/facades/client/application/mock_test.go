@@ -19,10 +19,12 @@ import (
 
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/constraints"
 	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -42,6 +44,20 @@ func (e *mockEnviron) ProviderSpaceInfo(space *network.SpaceInfo) (*environs.Pro
 	return e.spaceInfo, e.stub.NextErr()
 }
 
+type mockQuotaEnviron struct {
+	environs.Environ
+
+	quota environs.Quota
+}
+
+func (e *mockQuotaEnviron) Quota(context.ProviderCallContext) (environs.Quota, error) {
+	return e.quota, nil
+}
+
+func (e *mockQuotaEnviron) InstanceCosts(context.ProviderCallContext) ([]environs.InstanceCost, error) {
+	return nil, nil
+}
+
 type mockNoNetworkEnviron struct {
 	environs.Environ
 }
@@ -78,6 +94,7 @@ type mockApplication struct {
 	units       []*mockUnit
 	addedUnit   mockUnit
 	config      coreapplication.ConfigAttributes
+	constraints constraints.Value
 }
 
 func (m *mockApplication) Name() string {
@@ -138,6 +155,11 @@ func (a *mockApplication) Scale(scale int) error {
 	return nil
 }
 
+func (a *mockApplication) Constraints() (constraints.Value, error) {
+	a.MethodCall(a, "Constraints")
+	return a.constraints, a.NextErr()
+}
+
 func (a *mockApplication) IsPrincipal() bool {
 	a.MethodCall(a, "IsPrincipal")
 	a.PopNoErr()
@@ -180,6 +202,16 @@ func (a *mockApplication) SetExposed() error {
 	return a.NextErr()
 }
 
+func (a *mockApplication) MergeExposeSettings(settings map[string]state.ExposedEndpoint) error {
+	a.MethodCall(a, "MergeExposeSettings", settings)
+	return a.NextErr()
+}
+
+func (a *mockApplication) UnsetExposeSettings(endpoints []string) error {
+	a.MethodCall(a, "UnsetExposeSettings", endpoints)
+	return a.NextErr()
+}
+
 type mockRemoteApplication struct {
 	jtesting.Stub
 	name           string
@@ -190,6 +222,7 @@ type mockRemoteApplication struct {
 	offerUUID      string
 	offerURL       string
 	mac            *macaroon.Macaroon
+	relations      []application.Relation
 }
 
 func (m *mockRemoteApplication) Name() string {
@@ -212,6 +245,15 @@ func (m *mockRemoteApplication) Spaces() []state.RemoteSpace {
 	return m.spaces
 }
 
+func (m *mockRemoteApplication) URL() (string, bool) {
+	return m.offerURL, m.offerURL != ""
+}
+
+func (m *mockRemoteApplication) Relations() ([]application.Relation, error) {
+	m.MethodCall(m, "Relations")
+	return m.relations, m.NextErr()
+}
+
 func (m *mockRemoteApplication) AddEndpoints(eps []charm.Relation) error {
 	for _, ep := range eps {
 		m.endpoints = append(m.endpoints, state.Endpoint{
@@ -243,6 +285,8 @@ type mockBackend struct {
 	endpoints                  *[]state.Endpoint
 	relations                  map[int]*mockRelation
 	offerConnections           map[string]application.OfferConnection
+	offers                     map[string]*crossmodel.ApplicationOffer
+	offerConnectionsByOffer    map[string][]application.OfferConnection
 	unitStorageAttachments     map[string][]state.StorageAttachment
 	storageInstances           map[string]*mockStorage
 	storageInstanceFilesystems map[string]*mockFilesystem
@@ -344,6 +388,58 @@ func (m *mockBackend) Relation(id int) (application.Relation, error) {
 
 type mockOfferConnection struct {
 	application.OfferConnection
+	relationId      int
+	relationKey     string
+	username        string
+	sourceModelUUID string
+}
+
+func (m *mockOfferConnection) RelationId() int {
+	return m.relationId
+}
+
+func (m *mockOfferConnection) RelationKey() string {
+	return m.relationKey
+}
+
+func (m *mockOfferConnection) UserName() string {
+	return m.username
+}
+
+func (m *mockOfferConnection) SourceModelUUID() string {
+	return m.sourceModelUUID
+}
+
+func (m *mockBackend) ApplicationOffer(name string) (*crossmodel.ApplicationOffer, error) {
+	m.MethodCall(m, "ApplicationOffer", name)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	if offer, ok := m.offers[name]; ok {
+		return offer, nil
+	}
+	return nil, errors.NotFoundf("application offer %q", name)
+}
+
+func (m *mockBackend) OfferConnections(offerUUID string) ([]application.OfferConnection, error) {
+	m.MethodCall(m, "OfferConnections", offerUUID)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.offerConnectionsByOffer[offerUUID], nil
+}
+
+func (m *mockBackend) KeyRelation(key string) (application.Relation, error) {
+	m.MethodCall(m, "KeyRelation", key)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	for _, rel := range m.relations {
+		if rel.key == key {
+			return rel, nil
+		}
+	}
+	return nil, errors.NotFoundf("relation %q", key)
 }
 
 func (m *mockBackend) OfferConnectionForRelation(key string) (application.OfferConnection, error) {
@@ -504,12 +600,39 @@ type mockRelation struct {
 	message         string
 	suspended       bool
 	suspendedReason string
+	id              int
+	key             string
+	health          *state.RemoteRelationHealth
 }
 
 func (r *mockRelation) Tag() names.Tag {
 	return r.tag
 }
 
+func (r *mockRelation) Id() int {
+	return r.id
+}
+
+func (r *mockRelation) String() string {
+	return r.key
+}
+
+func (r *mockRelation) Status() (status.StatusInfo, error) {
+	r.MethodCall(r, "Status")
+	return status.StatusInfo{Status: r.status, Message: r.message}, r.NextErr()
+}
+
+func (r *mockRelation) RemoteHealth() (state.RemoteRelationHealth, error) {
+	r.MethodCall(r, "RemoteHealth")
+	if err := r.NextErr(); err != nil {
+		return state.RemoteRelationHealth{}, err
+	}
+	if r.health == nil {
+		return state.RemoteRelationHealth{}, errors.NotFoundf("remote health of relation %q", r.key)
+	}
+	return *r.health, nil
+}
+
 func (r *mockRelation) SetStatus(status status.StatusInfo) error {
 	r.MethodCall(r, "SetStatus")
 	r.status = status.Status
@@ -0,0 +1,75 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+// checkQuota refuses to add units if the new instances they require
+// would exceed the cloud's remaining quota.
+func (api *APIBase) checkQuota(numUnits int, placement []*instance.Placement, cons constraints.Value) error {
+	if api.modelType != state.ModelTypeIAAS || api.newEnviron == nil {
+		return nil
+	}
+	req := unitQuotaRequest(numUnits, placement, cons)
+	if req.Instances == 0 {
+		return nil
+	}
+	env, err := api.newEnviron()
+	if err != nil {
+		logger.Warningf("cannot check cloud quota: %v", err)
+		return nil
+	}
+	return errors.Trace(common.CheckQuota(env, api.callContext, req))
+}
+
+// checkAddUnitsQuota refuses to add the units described by args if
+// the new instances they require would exceed the cloud's remaining
+// quota.
+func (api *APIBase) checkAddUnitsQuota(args params.AddApplicationUnits) error {
+	if api.modelType != state.ModelTypeIAAS || api.newEnviron == nil {
+		return nil
+	}
+	application, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cons, err := application.Constraints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(api.checkQuota(args.NumUnits, args.Placement, cons))
+}
+
+// unitQuotaRequest returns the cloud resources required to add the
+// given number of units with the given placement and constraints.
+// Units placed on existing machines, or in containers on existing
+// machines, require no new instances.
+func unitQuotaRequest(numUnits int, placement []*instance.Placement, cons constraints.Value) environs.QuotaRequest {
+	var req environs.QuotaRequest
+	for i := 0; i < numUnits; i++ {
+		if i < len(placement) && placement[i] != nil {
+			p := placement[i]
+			if p.Scope == instance.MachineScope {
+				continue
+			}
+			if _, err := instance.ParseContainerType(p.Scope); err == nil && p.Directive != "" {
+				continue
+			}
+		}
+		req.Instances++
+		if cons.CpuCores != nil {
+			req.Cores += int(*cons.CpuCores)
+		}
+	}
+	return req
+}
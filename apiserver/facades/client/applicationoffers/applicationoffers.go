@@ -40,6 +40,11 @@ type OffersAPIV2 struct {
 	*OffersAPI
 }
 
+// OffersAPIV3 implements the cross model interface V3.
+type OffersAPIV3 struct {
+	*OffersAPIV2
+}
+
 // createAPI returns a new application offers OffersAPI facade.
 func createOffersAPI(
 	getApplicationOffers func(interface{}) jujucrossmodel.ApplicationOffers,
@@ -122,6 +127,15 @@ func NewOffersAPIV2(ctx facade.Context) (*OffersAPIV2, error) {
 	return &OffersAPIV2{OffersAPI: apiV1}, nil
 }
 
+// NewOffersAPIV3 returns a new application offers OffersAPIV3 facade.
+func NewOffersAPIV3(ctx facade.Context) (*OffersAPIV3, error) {
+	apiV2, err := NewOffersAPIV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &OffersAPIV3{OffersAPIV2: apiV2}, nil
+}
+
 // Offer makes application endpoints available for consumption at a specified URL.
 func (api *OffersAPI) Offer(all params.AddApplicationOffers) (params.ErrorResults, error) {
 	result := make([]params.ErrorResult, len(all.Offers))
@@ -337,6 +337,7 @@ type mockState struct {
 	connections       []applicationoffers.OfferConnection
 	accessPerms       map[offerAccess]permission.Access
 	relationNetworks  state.RelationNetworks
+	shareTokens       []*mockShareToken
 }
 
 func (m *mockState) GetAddressAndCertGetter() common.AddressAndCertGetter {
@@ -491,6 +492,75 @@ func (m *mockState) GetOfferUsers(offerUUID string) (map[string]permission.Acces
 	return result, nil
 }
 
+func (m *mockState) AddOfferShareToken(args state.AddOfferShareTokenParams) (applicationoffers.OfferShareToken, error) {
+	token := &mockShareToken{
+		id:        fmt.Sprintf("token-%d", len(m.shareTokens)),
+		offerUUID: args.OfferUUID,
+		createdBy: args.CreatedBy,
+		created:   time.Now(),
+		expires:   args.Expires,
+	}
+	m.shareTokens = append(m.shareTokens, token)
+	return token, nil
+}
+
+func (m *mockState) OfferShareToken(id string) (crossmodel.OfferShareToken, error) {
+	for _, token := range m.shareTokens {
+		if token.id == id {
+			return token, nil
+		}
+	}
+	return nil, errors.NotFoundf("offer share token %q", id)
+}
+
+func (m *mockState) OfferShareTokens(offerUUID string) ([]applicationoffers.OfferShareToken, error) {
+	var result []applicationoffers.OfferShareToken
+	for _, token := range m.shareTokens {
+		if token.offerUUID == offerUUID {
+			result = append(result, token)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockState) RemoveOfferShareToken(id string) error {
+	for i, token := range m.shareTokens {
+		if token.id == id {
+			m.shareTokens = append(m.shareTokens[:i], m.shareTokens[i+1:]...)
+			return nil
+		}
+	}
+	return errors.NotFoundf("offer share token %q", id)
+}
+
+type mockShareToken struct {
+	id        string
+	offerUUID string
+	createdBy string
+	created   time.Time
+	expires   time.Time
+}
+
+func (t *mockShareToken) Id() string {
+	return t.id
+}
+
+func (t *mockShareToken) OfferUUID() string {
+	return t.offerUUID
+}
+
+func (t *mockShareToken) CreatedBy() string {
+	return t.createdBy
+}
+
+func (t *mockShareToken) Created() time.Time {
+	return t.created
+}
+
+func (t *mockShareToken) Expires() time.Time {
+	return t.expires
+}
+
 type mockStatePool struct {
 	st map[string]applicationoffers.Backend
 }
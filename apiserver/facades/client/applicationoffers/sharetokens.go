@@ -0,0 +1,224 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationoffers
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	jujucrossmodel "github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/state"
+)
+
+// CreateOfferShareTokens creates a time-limited token for each of the
+// specified offers. The token can be used to consume the offer, without
+// being granted access to it, until it expires or is revoked.
+func (api *OffersAPIV3) CreateOfferShareTokens(args params.OfferShareTokenArgs) (params.CreateOfferShareTokenResults, error) {
+	results := make([]params.CreateOfferShareTokenResult, len(args.Args))
+	offerURLs := make([]string, len(args.Args))
+	for i, arg := range args.Args {
+		offerURLs[i] = arg.OfferURL
+	}
+	models, err := api.getModelsFromOffers(offerURLs...)
+	if err != nil {
+		return params.CreateOfferShareTokenResults{}, errors.Trace(err)
+	}
+
+	for i, arg := range args.Args {
+		if models[i].err != nil {
+			results[i].Error = common.ServerError(models[i].err)
+			continue
+		}
+		token, details, err := api.createOfferShareToken(models[i].model, arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Token = token
+		results[i].ConsumeDetails = details
+	}
+	return params.CreateOfferShareTokenResults{Results: results}, nil
+}
+
+func (api *OffersAPIV3) createOfferShareToken(
+	model Model, arg params.OfferShareTokenArg,
+) (*params.OfferShareToken, *params.ConsumeOfferDetails, error) {
+	if arg.Expiry <= 0 {
+		return nil, nil, errors.NotValidf("share token expiry %v", arg.Expiry)
+	}
+	backend, releaser, err := api.StatePool.Get(model.UUID())
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	defer releaser()
+
+	url, offer, err := api.adminOffer(backend, arg.OfferURL)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	offers, err := api.ApplicationOffers(params.OfferURLs{OfferURLs: []string{url.String()}})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if offers.Results[0].Error != nil {
+		return nil, nil, offers.Results[0].Error
+	}
+	addrs, caCert, err := api.getControllerInfo()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	apiUser := api.Authorizer.GetAuthTag().Id()
+	token, err := backend.AddOfferShareToken(state.AddOfferShareTokenParams{
+		OfferUUID: offer.OfferUUID,
+		CreatedBy: apiUser,
+		Expires:   api.authContext.Clock().Now().Add(arg.Expiry),
+	})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	// The consumer is not granted access to the offer, so don't tell
+	// them who has been.
+	offerDetails := offers.Results[0].Result.ApplicationOfferDetails
+	offerDetails.Users = nil
+	mac, err := api.authContext.CreateShareTokenMacaroon(&offerDetails, apiUser, token.Id(), token.Expires())
+	if err != nil {
+		if err := backend.RemoveOfferShareToken(token.Id()); err != nil {
+			logger.Warningf("cannot remove unused share token %q: %v", token.Id(), err)
+		}
+		return nil, nil, errors.Trace(err)
+	}
+	details := &params.ConsumeOfferDetails{
+		Offer:    &offerDetails,
+		Macaroon: mac,
+		ControllerInfo: &params.ExternalControllerInfo{
+			ControllerTag: api.ControllerModel.ControllerTag().String(),
+			Addrs:         addrs,
+			CACert:        caCert,
+		},
+	}
+	return shareTokenParams(url, token), details, nil
+}
+
+// ListOfferShareTokens returns the share tokens which have been created
+// for each of the specified offers.
+func (api *OffersAPIV3) ListOfferShareTokens(args params.OfferURLs) (params.OfferShareTokensResults, error) {
+	results := make([]params.OfferShareTokensResult, len(args.OfferURLs))
+	models, err := api.getModelsFromOffers(args.OfferURLs...)
+	if err != nil {
+		return params.OfferShareTokensResults{}, errors.Trace(err)
+	}
+
+	for i, offerURL := range args.OfferURLs {
+		if models[i].err != nil {
+			results[i].Error = common.ServerError(models[i].err)
+			continue
+		}
+		tokens, err := api.listOfferShareTokens(models[i].model, offerURL)
+		results[i].Tokens = tokens
+		results[i].Error = common.ServerError(err)
+	}
+	return params.OfferShareTokensResults{Results: results}, nil
+}
+
+func (api *OffersAPIV3) listOfferShareTokens(model Model, offerURL string) ([]params.OfferShareToken, error) {
+	backend, releaser, err := api.StatePool.Get(model.UUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer releaser()
+
+	url, offer, err := api.adminOffer(backend, offerURL)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tokens, err := backend.OfferShareTokens(offer.OfferUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.OfferShareToken, len(tokens))
+	for i, token := range tokens {
+		result[i] = *shareTokenParams(url, token)
+	}
+	return result, nil
+}
+
+// RevokeOfferShareTokens revokes the specified share tokens. Relations
+// established using a revoked token can no longer be used.
+func (api *OffersAPIV3) RevokeOfferShareTokens(args params.RevokeOfferShareTokenArgs) (params.ErrorResults, error) {
+	results := make([]params.ErrorResult, len(args.Args))
+	offerURLs := make([]string, len(args.Args))
+	for i, arg := range args.Args {
+		offerURLs[i] = arg.OfferURL
+	}
+	models, err := api.getModelsFromOffers(offerURLs...)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	for i, arg := range args.Args {
+		if models[i].err != nil {
+			results[i].Error = common.ServerError(models[i].err)
+			continue
+		}
+		err := api.revokeOfferShareToken(models[i].model, arg)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (api *OffersAPIV3) revokeOfferShareToken(model Model, arg params.RevokeOfferShareTokenArg) error {
+	backend, releaser, err := api.StatePool.Get(model.UUID())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer releaser()
+
+	_, offer, err := api.adminOffer(backend, arg.OfferURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	token, err := backend.OfferShareToken(arg.Id)
+	if errors.IsNotFound(err) || (err == nil && token.OfferUUID() != offer.OfferUUID) {
+		return errors.NotFoundf("share token %q for offer %q", arg.Id, arg.OfferURL)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return backend.RemoveOfferShareToken(arg.Id)
+}
+
+// adminOffer returns the parsed offer URL and the offer it refers to,
+// provided the user is an admin of the model hosting the offer.
+func (api *OffersAPIV3) adminOffer(backend Backend, offerURL string) (*jujucrossmodel.OfferURL, *jujucrossmodel.ApplicationOffer, error) {
+	url, err := jujucrossmodel.ParseOfferURL(offerURL)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if url.HasEndpoint() {
+		return nil, nil, errors.Errorf("remote application %q shouldn't include endpoint", url)
+	}
+	if url.User == "" {
+		url.User = api.Authorizer.GetAuthTag().Id()
+	}
+	if err := api.checkAdmin(backend); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	offer, err := backend.ApplicationOffer(url.ApplicationName)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return url, offer, nil
+}
+
+func shareTokenParams(url *jujucrossmodel.OfferURL, token OfferShareToken) *params.OfferShareToken {
+	return &params.OfferShareToken{
+		Id:        token.Id(),
+		OfferURL:  url.String(),
+		CreatedBy: token.CreatedBy(),
+		Created:   token.Created(),
+		Expires:   token.Expires(),
+	}
+}
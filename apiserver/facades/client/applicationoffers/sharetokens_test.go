@@ -0,0 +1,188 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationoffers_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/applicationoffers"
+	"github.com/juju/juju/apiserver/params"
+	jujucrossmodel "github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/testing"
+)
+
+func (s *consumeSuite) apiV3() *applicationoffers.OffersAPIV3 {
+	return &applicationoffers.OffersAPIV3{OffersAPIV2: s.api}
+}
+
+func (s *consumeSuite) createShareToken(c *gc.C) params.OfferShareToken {
+	results, err := s.apiV3().CreateOfferShareTokens(params.OfferShareTokenArgs{
+		Args: []params.OfferShareTokenArg{{OfferURL: "fred/prod.hosted-mysql", Expiry: time.Hour}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	return *results.Results[0].Token
+}
+
+func (s *consumeSuite) TestCreateOfferShareTokens(c *gc.C) {
+	s.setupOffer()
+	s.authorizer.Tag = names.NewUserTag("admin")
+
+	before := time.Now()
+	results, err := s.apiV3().CreateOfferShareTokens(params.OfferShareTokenArgs{
+		Args: []params.OfferShareTokenArg{
+			{OfferURL: "fred/prod.hosted-mysql", Expiry: time.Hour},
+			{OfferURL: "fred/prod.unknown", Expiry: time.Hour},
+			{OfferURL: "fred/prod.hosted-mysql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `application offer "unknown" not found`, Code: "not found",
+	})
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `share token expiry 0s not valid`)
+
+	token := results.Results[0].Token
+	c.Assert(token.Id, gc.Equals, "token-0")
+	c.Assert(token.OfferURL, gc.Equals, "fred/prod.hosted-mysql")
+	c.Assert(token.CreatedBy, gc.Equals, "admin")
+	c.Assert(token.Expires.After(before.Add(time.Hour)), jc.IsTrue)
+
+	details := results.Results[0].ConsumeDetails
+	c.Assert(details.Offer.OfferUUID, gc.Equals, "hosted-mysql-uuid")
+	c.Assert(details.Offer.Users, gc.HasLen, 0)
+	c.Assert(details.ControllerInfo, jc.DeepEquals, &params.ExternalControllerInfo{
+		ControllerTag: testing.ControllerTag.String(),
+		Addrs:         []string{"192.168.1.1:17070"},
+		CACert:        testing.CACert,
+	})
+	cav := s.bakery.caveats[string(details.Macaroon.Id())]
+	c.Check(cav, gc.HasLen, 5)
+	c.Check(strings.HasPrefix(cav[0].Condition, "time-before "), jc.IsTrue)
+	c.Check(cav[1].Condition, gc.Equals, "declared source-model-uuid deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	c.Check(cav[2].Condition, gc.Equals, "declared offer-uuid hosted-mysql-uuid")
+	c.Check(cav[3].Condition, gc.Equals, "declared username admin")
+	c.Check(cav[4].Condition, gc.Equals, "declared share-token token-0")
+}
+
+func (s *consumeSuite) TestCreateOfferShareTokensUsesClock(c *gc.C) {
+	s.setupOffer()
+	s.authorizer.Tag = names.NewUserTag("admin")
+
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	resources := common.NewResources()
+	resources.RegisterNamed("dataDir", common.StringResource(c.MkDir()))
+	apiV1, err := applicationoffers.CreateOffersAPI(
+		func(st interface{}) jujucrossmodel.ApplicationOffers {
+			return &mockApplicationOffers{st: st.(*mockState)}
+		},
+		func(string) (environs.Environ, error) { return s.env, nil },
+		getFakeControllerInfo,
+		s.mockState, s.mockStatePool, s.authorizer, resources,
+		s.authContext.WithClock(testclock.NewClock(now)),
+		context.NewCloudCallContext(),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	api := &applicationoffers.OffersAPIV3{OffersAPIV2: &applicationoffers.OffersAPIV2{OffersAPI: apiV1}}
+
+	results, err := api.CreateOfferShareTokens(params.OfferShareTokenArgs{
+		Args: []params.OfferShareTokenArg{{OfferURL: "fred/prod.hosted-mysql", Expiry: time.Hour}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Token.Expires, gc.Equals, now.Add(time.Hour))
+}
+
+func (s *consumeSuite) TestCreateOfferShareTokensPermission(c *gc.C) {
+	s.setupOffer()
+	s.authorizer.Tag = names.NewUserTag("mary")
+
+	results, err := s.apiV3().CreateOfferShareTokens(params.OfferShareTokenArgs{
+		Args: []params.OfferShareTokenArg{{OfferURL: "fred/prod.hosted-mysql", Expiry: time.Hour}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, common.ErrPerm.Error())
+	st := s.mockStatePool.st[testing.ModelTag.Id()].(*mockState)
+	c.Assert(st.shareTokens, gc.HasLen, 0)
+}
+
+func (s *consumeSuite) TestListOfferShareTokens(c *gc.C) {
+	s.setupOffer()
+	s.authorizer.Tag = names.NewUserTag("admin")
+	token := s.createShareToken(c)
+
+	results, err := s.apiV3().ListOfferShareTokens(params.OfferURLs{
+		OfferURLs: []string{"fred/prod.hosted-mysql", "fred/prod.unknown"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Tokens, jc.DeepEquals, []params.OfferShareToken{token})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application offer "unknown" not found`)
+}
+
+func (s *consumeSuite) TestListOfferShareTokensPermission(c *gc.C) {
+	s.setupOffer()
+	s.authorizer.Tag = names.NewUserTag("mary")
+
+	results, err := s.apiV3().ListOfferShareTokens(params.OfferURLs{
+		OfferURLs: []string{"fred/prod.hosted-mysql"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, common.ErrPerm.Error())
+}
+
+func (s *consumeSuite) TestRevokeOfferShareTokens(c *gc.C) {
+	s.setupOffer()
+	s.authorizer.Tag = names.NewUserTag("admin")
+	token := s.createShareToken(c)
+
+	results, err := s.apiV3().RevokeOfferShareTokens(params.RevokeOfferShareTokenArgs{
+		Args: []params.RevokeOfferShareTokenArg{
+			{OfferURL: "fred/prod.hosted-mysql", Id: token.Id},
+			{OfferURL: "fred/prod.hosted-mysql", Id: "missing"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{
+			Message: `share token "missing" for offer "fred/prod.hosted-mysql" not found`,
+			Code:    "not found",
+		}},
+	})
+	st := s.mockStatePool.st[testing.ModelTag.Id()].(*mockState)
+	c.Assert(st.shareTokens, gc.HasLen, 0)
+}
+
+func (s *consumeSuite) TestRevokeOfferShareTokensPermission(c *gc.C) {
+	s.setupOffer()
+	s.authorizer.Tag = names.NewUserTag("admin")
+	token := s.createShareToken(c)
+
+	s.authorizer.Tag = names.NewUserTag("mary")
+	results, err := s.apiV3().RevokeOfferShareTokens(params.RevokeOfferShareTokenArgs{
+		Args: []params.RevokeOfferShareTokenArg{{OfferURL: "fred/prod.hosted-mysql", Id: token.Id}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, common.ErrPerm.Error())
+	st := s.mockStatePool.st[testing.ModelTag.Id()].(*mockState)
+	c.Assert(st.shareTokens, gc.HasLen, 1)
+}
@@ -4,6 +4,8 @@
 package applicationoffers
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
@@ -66,6 +68,10 @@ type Backend interface {
 	UpdateOfferAccess(offer names.ApplicationOfferTag, user names.UserTag, access permission.Access) error
 	RemoveOfferAccess(offer names.ApplicationOfferTag, user names.UserTag) error
 	GetOfferUsers(offerUUID string) (map[string]permission.Access, error)
+
+	AddOfferShareToken(args state.AddOfferShareTokenParams) (OfferShareToken, error)
+	OfferShareTokens(offerUUID string) ([]OfferShareToken, error)
+	RemoveOfferShareToken(id string) error
 }
 
 var GetStateAccess = func(st *state.State) Backend {
@@ -96,6 +102,30 @@ func (s stateShim) GetOfferUsers(offerUUID string) (map[string]permission.Access
 	return s.st.GetOfferUsers(offerUUID)
 }
 
+func (s stateShim) AddOfferShareToken(args state.AddOfferShareTokenParams) (OfferShareToken, error) {
+	token, err := s.st.AddOfferShareToken(args)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (s stateShim) OfferShareTokens(offerUUID string) ([]OfferShareToken, error) {
+	tokens, err := s.st.OfferShareTokens(offerUUID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]OfferShareToken, len(tokens))
+	for i, token := range tokens {
+		result[i] = token
+	}
+	return result, nil
+}
+
+func (s stateShim) RemoveOfferShareToken(id string) error {
+	return s.st.RemoveOfferShareToken(id)
+}
+
 func (s *stateShim) Space(name string) (Space, error) {
 	sp, err := s.st.Space(name)
 	return &spaceShim{sp}, err
@@ -212,3 +242,11 @@ func (s *stateShim) User(tag names.UserTag) (User, error) {
 type User interface {
 	DisplayName() string
 }
+
+type OfferShareToken interface {
+	Id() string
+	OfferUUID() string
+	CreatedBy() string
+	Created() time.Time
+	Expires() time.Time
+}
@@ -184,6 +184,7 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		return noStatus, errors.Annotate(err, "cannot obtain current model config")
 	}
 	context.providerType = cfg.Type()
+	context.exposeLoadBalancers = cfg.ExposeLoadBalancers()
 
 	if context.model, err = c.api.stateAccessor.Model(); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch model")
@@ -441,6 +442,10 @@ type statusContext struct {
 	status       *state.ModelStatus
 	presence     common.ModelPresenceContext
 
+	// exposeLoadBalancers records whether exposed IAAS applications
+	// are put behind a cloud load balancer.
+	exposeLoadBalancers bool
+
 	// machines: top-level machine id -> list of machines nested in
 	// this machine.
 	machines map[string][]*state.Machine
@@ -976,6 +981,16 @@ func (context *statusContext) processApplication(application *state.Application)
 		CharmVersion: applicationCharm.Version(),
 	}
 
+	if exposedEndpoints := application.ExposedEndpoints(); len(exposedEndpoints) > 0 {
+		processedStatus.ExposedEndpoints = make(map[string]params.ExposedEndpoint, len(exposedEndpoints))
+		for endpoint, exposed := range exposedEndpoints {
+			processedStatus.ExposedEndpoints[endpoint] = params.ExposedEndpoint{
+				ExposeToSpaces: exposed.ExposeToSpaces,
+				ExposeToCIDRs:  exposed.ExposeToCIDRs,
+			}
+		}
+	}
+
 	if latestCharm, ok := context.allAppsUnitsCharmBindings.latestCharms[*applicationCharm.URL().WithRevision(-1)]; ok && latestCharm != nil {
 		if latestCharm.Revision() > applicationCharm.URL().Revision {
 			processedStatus.CanUpgradeTo = latestCharm.String()
@@ -1053,6 +1068,11 @@ func (context *statusContext) processApplication(application *state.Application)
 			// Container zero is the primary.
 			processedStatus.WorkloadVersion = fmt.Sprintf("%v", spec.Containers[0].Image)
 		}
+	}
+
+	// CAAS applications have a cloud service, as do IAAS applications
+	// that are exposed through a load balancer.
+	if context.model.Type() == state.ModelTypeCAAS || context.hasLoadBalancer(application) {
 		serviceInfo, err := application.ServiceInfo()
 		if err == nil {
 			processedStatus.ProviderId = serviceInfo.ProviderId()
@@ -1069,6 +1089,12 @@ func (context *statusContext) processApplication(application *state.Application)
 	return processedStatus
 }
 
+// hasLoadBalancer reports whether the IAAS application may be exposed
+// through a cloud load balancer, and so have a cloud service recorded.
+func (context *statusContext) hasLoadBalancer(application *state.Application) bool {
+	return context.exposeLoadBalancers && application.IsExposed()
+}
+
 func (context *statusContext) processRemoteApplications() map[string]params.RemoteApplicationStatus {
 	applicationsMap := make(map[string]params.RemoteApplicationStatus)
 	for _, app := range context.consumerRemoteApplications {
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing/factory"
 )
@@ -328,6 +329,44 @@ func (s *statusUnitTestSuite) TestWorkloadVersionOkWithUnset(c *gc.C) {
 	checkUnitVersion(c, appStatus, unit, "")
 }
 
+func (s *statusUnitTestSuite) TestLoadBalancerAddress(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"expose-load-balancers": true}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	application := s.Factory.MakeApplication(c, nil)
+	err = application.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.UpdateCloudService("lb-id", []network.Address{
+		network.NewScopedAddress("203.0.113.10", network.ScopePublic),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	appStatus, found := status.Applications[application.Name()]
+	c.Assert(found, jc.IsTrue)
+	c.Check(appStatus.ProviderId, gc.Equals, "lb-id")
+	c.Check(appStatus.PublicAddress, gc.Equals, "203.0.113.10")
+}
+
+func (s *statusUnitTestSuite) TestLoadBalancerAddressIgnoredWhenNotExposed(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"expose-load-balancers": true}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	application := s.Factory.MakeApplication(c, nil)
+	err = application.UpdateCloudService("lb-id", []network.Address{
+		network.NewScopedAddress("203.0.113.10", network.ScopePublic),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	appStatus, found := status.Applications[application.Name()]
+	c.Assert(found, jc.IsTrue)
+	c.Check(appStatus.ProviderId, gc.Equals, "")
+	c.Check(appStatus.PublicAddress, gc.Equals, "")
+}
+
 func (s *statusUnitTestSuite) TestMigrationInProgress(c *gc.C) {
 
 	// Create a host model because controller models can't be migrated.
@@ -6,6 +6,7 @@ package controller
 import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/state"
 )
 
@@ -18,3 +19,7 @@ func SetPrecheckResult(p patcher, err error) {
 		return err
 	})
 }
+
+func SetNewEnviron(p patcher, f func(*state.State) (environs.Environ, error)) {
+	p.PatchValue(&newEnviron, f)
+}
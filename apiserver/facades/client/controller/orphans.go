@@ -0,0 +1,333 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// newEnviron is used to open the environ of each model checked by
+// FindOrphans. It is a variable so that it can be replaced in tests.
+var newEnviron = stateenvirons.GetNewEnvironFunc(environs.New)
+
+// cloudRegion identifies a cloud region, and the credential used to
+// access it. Resources in a region are only visible with credentials
+// for the same account, so each credential is checked separately.
+type cloudRegion struct {
+	cloud      string
+	region     string
+	credential string
+}
+
+// regionOrphans holds the environ used to check a cloud region for
+// orphaned resources, and the results of the check. If the environ
+// could not be opened, env is nil and the result holds the error.
+type regionOrphans struct {
+	cloud   string
+	env     environs.Environ
+	result  params.CloudOrphans
+	orphans []orphan
+}
+
+// modelEntities records the machines and storage instances of a model,
+// which are the entities that own the model's tagged cloud resources.
+type modelEntities struct {
+	// machines maps the ids of the model's machines to the ids
+	// of their instances. Unprovisioned machines map to "".
+	machines map[string]instance.Id
+
+	// storage holds the ids of the model's storage instances.
+	storage set.Strings
+}
+
+// orphan describes a tagged cloud resource that is no longer used,
+// and why.
+type orphan struct {
+	resource environs.TaggedResource
+	reason   string
+}
+
+// FindOrphans finds the cloud resources tagged as being managed by the
+// controller, but which are no longer used by any of its models, and
+// optionally destroys them. Each cloud region hosting a model is
+// checked once for each credential used to access it; resources
+// in regions no longer hosting a model are not found.
+func (c *ControllerAPI) FindOrphans(args params.FindOrphansArgs) (params.FindOrphansResults, error) {
+	result := params.FindOrphansResults{}
+	if err := c.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	regions, err := c.modelRegions()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	// The cloud resources are listed before the models are read, so
+	// that resources created while we are looking are seen as used.
+	ctx := context.NewCloudCallContext()
+	controllerUUID := c.state.ControllerUUID()
+	resources := make([][]environs.TaggedResource, len(regions))
+	for i, r := range regions {
+		if r.result.Error != nil {
+			continue
+		}
+		lister, ok := environs.SupportsTaggedResources(r.env)
+		if !ok {
+			r.result.Error = common.ServerError(errors.NotSupportedf(
+				"finding orphaned resources in %q cloud", r.cloud,
+			))
+			continue
+		}
+		resources[i], err = lister.ControllerResources(ctx, controllerUUID)
+		if err != nil {
+			r.result.Error = common.ServerError(err)
+		}
+	}
+
+	models, err := c.modelEntities()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	for i, r := range regions {
+		if r.result.Error != nil {
+			result.Results = append(result.Results, r.result)
+			continue
+		}
+		r.orphans = orphanedResources(resources[i], models)
+		for _, o := range r.orphans {
+			r.result.Orphans = append(r.result.Orphans, params.OrphanedResource{
+				Kind:      string(o.resource.Kind),
+				Id:        o.resource.Id,
+				ModelUUID: o.resource.ModelUUID,
+				Reason:    o.reason,
+			})
+		}
+		if args.Destroy && len(r.orphans) > 0 {
+			toDestroy := make([]environs.TaggedResource, len(r.orphans))
+			for j, o := range r.orphans {
+				toDestroy[j] = o.resource
+			}
+			lister, _ := environs.SupportsTaggedResources(r.env)
+			if err := lister.DestroyResources(ctx, toDestroy); err != nil {
+				r.result.Error = common.ServerError(errors.Annotate(err, "destroying orphaned resources"))
+			} else {
+				r.result.Destroyed = true
+			}
+		}
+		result.Results = append(result.Results, r.result)
+	}
+	return result, nil
+}
+
+// modelRegions returns an environ for each distinct cloud region and
+// credential used by the controller's IAAS models. A region whose
+// environ cannot be opened for any of its models is returned with
+// the error, so that the other regions are still checked.
+func (c *ControllerAPI) modelRegions() ([]*regionOrphans, error) {
+	modelUUIDs, err := c.state.AllModelUUIDs()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var regions []*regionOrphans
+	seen := make(map[cloudRegion]*regionOrphans)
+	for _, modelUUID := range modelUUIDs {
+		r, key, err := c.modelRegion(modelUUID)
+		if errors.IsNotFound(err) {
+			// This model could have been removed.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if r == nil {
+			continue
+		}
+		if existing, ok := seen[key]; ok {
+			if existing.env == nil && r.env != nil {
+				// Another model in the region has
+				// an environ we can use instead.
+				*existing = *r
+			}
+			continue
+		}
+		seen[key] = r
+		regions = append(regions, r)
+	}
+	return regions, nil
+}
+
+// modelRegion returns an environ for the cloud region hosting the
+// model with the given UUID, or nil if it is not an IAAS model. If
+// the environ cannot be opened, the error is recorded in the result.
+func (c *ControllerAPI) modelRegion(modelUUID string) (*regionOrphans, cloudRegion, error) {
+	st, err := c.statePool.Get(modelUUID)
+	if err != nil {
+		return nil, cloudRegion{}, errors.Trace(err)
+	}
+	defer st.Release()
+	model, err := st.Model()
+	if err != nil {
+		return nil, cloudRegion{}, errors.Trace(err)
+	}
+	if model.Type() != state.ModelTypeIAAS {
+		return nil, cloudRegion{}, nil
+	}
+	key := cloudRegion{cloud: model.Cloud(), region: model.CloudRegion()}
+	if credentialTag, ok := model.CloudCredential(); ok {
+		key.credential = credentialTag.Id()
+	}
+	r := &regionOrphans{
+		cloud: key.cloud,
+		result: params.CloudOrphans{
+			CloudTag: names.NewCloudTag(key.cloud).String(),
+			Region:   key.region,
+		},
+	}
+	env, err := newEnviron(st.State)
+	if err != nil {
+		r.result.Error = common.ServerError(errors.Annotatef(err, "opening environ for model %q", model.Name()))
+		return r, key, nil
+	}
+	r.env = env
+	return r, key, nil
+}
+
+// modelEntities returns the entities of each of the controller's
+// models, keyed on model UUID.
+func (c *ControllerAPI) modelEntities() (map[string]modelEntities, error) {
+	modelUUIDs, err := c.state.AllModelUUIDs()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	models := make(map[string]modelEntities)
+	for _, modelUUID := range modelUUIDs {
+		entities, err := c.oneModelEntities(modelUUID)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "reading model %q", modelUUID)
+		}
+		models[modelUUID] = entities
+	}
+	return models, nil
+}
+
+func (c *ControllerAPI) oneModelEntities(modelUUID string) (modelEntities, error) {
+	st, err := c.statePool.Get(modelUUID)
+	if err != nil {
+		return modelEntities{}, errors.Trace(err)
+	}
+	defer st.Release()
+
+	entities := modelEntities{
+		machines: make(map[string]instance.Id),
+		storage:  set.NewStrings(),
+	}
+	machines, err := st.AllMachines()
+	if err != nil {
+		return modelEntities{}, errors.Trace(err)
+	}
+	for _, m := range machines {
+		instId, err := m.InstanceId()
+		if err != nil && !errors.IsNotProvisioned(err) {
+			return modelEntities{}, errors.Trace(err)
+		}
+		entities.machines[m.Id()] = instId
+	}
+
+	sb, err := state.NewStorageBackend(st.State)
+	if err != nil {
+		return modelEntities{}, errors.Trace(err)
+	}
+	storage, err := sb.AllStorageInstances()
+	if err != nil {
+		return modelEntities{}, errors.Trace(err)
+	}
+	for _, s := range storage {
+		entities.storage.Add(s.StorageTag().Id())
+	}
+	return entities, nil
+}
+
+// orphanedResources returns those of the given resources that are not
+// used by any of the given models. Resources without a model UUID are
+// never considered orphaned, since we cannot tell who owns them.
+func orphanedResources(resources []environs.TaggedResource, models map[string]modelEntities) []orphan {
+	var orphans []orphan
+	for _, r := range resources {
+		if reason := orphanReason(r, models); reason != "" {
+			orphans = append(orphans, orphan{resource: r, reason: reason})
+		}
+	}
+	return orphans
+}
+
+// orphanReason returns the reason that the resource is orphaned, or
+// the empty string if it is still in use.
+func orphanReason(r environs.TaggedResource, models map[string]modelEntities) string {
+	if r.ModelUUID == "" {
+		return ""
+	}
+	model, ok := models[r.ModelUUID]
+	if !ok {
+		return "model no longer exists"
+	}
+	switch r.Kind {
+	case environs.InstanceResource:
+		machineId, ok := instanceMachineId(r.Tags[tags.JujuMachine])
+		if !ok {
+			return ""
+		}
+		instId, ok := model.machines[machineId]
+		if !ok {
+			return fmt.Sprintf("machine %s no longer exists", machineId)
+		}
+		if instId != "" && string(instId) != r.Id {
+			return fmt.Sprintf("machine %s is using instance %s", machineId, instId)
+		}
+	case environs.SecurityGroupResource:
+		if r.MachineId == "" {
+			return ""
+		}
+		if _, ok := model.machines[r.MachineId]; !ok {
+			return fmt.Sprintf("machine %s no longer exists", r.MachineId)
+		}
+	case environs.VolumeResource:
+		storageId := r.Tags[tags.JujuStorageInstance]
+		if storageId != "" && !model.storage.Contains(storageId) {
+			return fmt.Sprintf("storage %s no longer exists", storageId)
+		}
+	}
+	return ""
+}
+
+// instanceMachineId returns the id of the machine recorded in the
+// value of an instance's juju-machine-id tag, which has the form
+// "<model-name>-machine-<id>".
+func instanceMachineId(value string) (string, bool) {
+	i := strings.LastIndex(value, "-machine-")
+	if i < 0 {
+		return "", false
+	}
+	tag, err := names.ParseMachineTag(value[i+1:])
+	if err != nil {
+		return "", false
+	}
+	return tag.Id(), true
+}
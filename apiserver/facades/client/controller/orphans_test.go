@@ -0,0 +1,188 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/facades/client/controller"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type mockTaggedEnviron struct {
+	environs.Environ
+	resources []environs.TaggedResource
+	destroyed []environs.TaggedResource
+}
+
+func (e *mockTaggedEnviron) ControllerResources(ctx context.ProviderCallContext, controllerUUID string) ([]environs.TaggedResource, error) {
+	return e.resources, nil
+}
+
+func (e *mockTaggedEnviron) DestroyResources(ctx context.ProviderCallContext, resources []environs.TaggedResource) error {
+	e.destroyed = append(e.destroyed, resources...)
+	return nil
+}
+
+func (s *controllerSuite) setUpOrphans(c *gc.C) *mockTaggedEnviron {
+	s.Factory.MakeMachine(c, &factory.MachineParams{InstanceId: "inst-0"})
+	modelUUID := s.State.ModelUUID()
+	env := &mockTaggedEnviron{
+		resources: []environs.TaggedResource{{
+			Kind:      environs.InstanceResource,
+			Id:        "inst-0",
+			ModelUUID: modelUUID,
+			Tags:      map[string]string{"juju-machine-id": "controller-machine-0"},
+		}, {
+			Kind:      environs.InstanceResource,
+			Id:        "inst-1",
+			ModelUUID: modelUUID,
+			Tags:      map[string]string{"juju-machine-id": "controller-machine-0"},
+		}, {
+			Kind:      environs.InstanceResource,
+			Id:        "inst-2",
+			ModelUUID: modelUUID,
+			Tags:      map[string]string{"juju-machine-id": "controller-machine-42"},
+		}, {
+			Kind:      environs.InstanceResource,
+			Id:        "inst-3",
+			ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		}, {
+			Kind: environs.InstanceResource,
+			Id:   "inst-4",
+		}, {
+			Kind:      environs.VolumeResource,
+			Id:        "vol-0",
+			ModelUUID: modelUUID,
+			Tags:      map[string]string{"juju-storage-instance": "data/0"},
+		}, {
+			Kind:      environs.VolumeResource,
+			Id:        "vol-1",
+			ModelUUID: modelUUID,
+		}, {
+			Kind:      environs.SecurityGroupResource,
+			Id:        "sg-0",
+			ModelUUID: modelUUID,
+		}, {
+			Kind:      environs.SecurityGroupResource,
+			Id:        "sg-1",
+			ModelUUID: modelUUID,
+			MachineId: "0",
+		}, {
+			Kind:      environs.SecurityGroupResource,
+			Id:        "sg-2",
+			ModelUUID: modelUUID,
+			MachineId: "42",
+		}},
+	}
+	controller.SetNewEnviron(s, func(*state.State) (environs.Environ, error) {
+		return env, nil
+	})
+	return env
+}
+
+func (s *controllerSuite) TestFindOrphans(c *gc.C) {
+	env := s.setUpOrphans(c)
+	results, err := s.controller.FindOrphans(params.FindOrphansArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	modelUUID := s.State.ModelUUID()
+	c.Assert(results, jc.DeepEquals, params.FindOrphansResults{
+		Results: []params.CloudOrphans{{
+			CloudTag: "cloud-dummy",
+			Region:   "dummy-region",
+			Orphans: []params.OrphanedResource{{
+				Kind:      "instance",
+				Id:        "inst-1",
+				ModelUUID: modelUUID,
+				Reason:    "machine 0 is using instance inst-0",
+			}, {
+				Kind:      "instance",
+				Id:        "inst-2",
+				ModelUUID: modelUUID,
+				Reason:    "machine 42 no longer exists",
+			}, {
+				Kind:      "instance",
+				Id:        "inst-3",
+				ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				Reason:    "model no longer exists",
+			}, {
+				Kind:      "volume",
+				Id:        "vol-0",
+				ModelUUID: modelUUID,
+				Reason:    "storage data/0 no longer exists",
+			}, {
+				Kind:      "security-group",
+				Id:        "sg-2",
+				ModelUUID: modelUUID,
+				Reason:    "machine 42 no longer exists",
+			}},
+		}},
+	})
+	c.Assert(env.destroyed, gc.HasLen, 0)
+}
+
+func (s *controllerSuite) TestFindOrphansDestroy(c *gc.C) {
+	env := s.setUpOrphans(c)
+	results, err := s.controller.FindOrphans(params.FindOrphansArgs{Destroy: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Destroyed, jc.IsTrue)
+
+	var destroyed []string
+	for _, r := range env.destroyed {
+		destroyed = append(destroyed, r.Id)
+	}
+	c.Assert(destroyed, jc.DeepEquals, []string{"inst-1", "inst-2", "inst-3", "vol-0", "sg-2"})
+}
+
+func (s *controllerSuite) TestFindOrphansNotSupported(c *gc.C) {
+	controller.SetNewEnviron(s, func(*state.State) (environs.Environ, error) {
+		return struct{ environs.Environ }{}, nil
+	})
+	results, err := s.controller.FindOrphans(params.FindOrphansArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `finding orphaned resources in "dummy" cloud not supported`)
+}
+
+func (s *controllerSuite) TestFindOrphansEnvironError(c *gc.C) {
+	controller.SetNewEnviron(s, func(*state.State) (environs.Environ, error) {
+		return nil, errors.New("bad credential")
+	})
+	results, err := s.controller.FindOrphans(params.FindOrphansArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].CloudTag, gc.Equals, "cloud-dummy")
+	c.Assert(results.Results[0].Region, gc.Equals, "dummy-region")
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `opening environ for model "controller": bad credential`)
+}
+
+func (s *controllerSuite) TestFindOrphansRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.NewControllerAPIv6(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = endpoint.FindOrphans(params.FindOrphansArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
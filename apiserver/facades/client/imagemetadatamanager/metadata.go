@@ -22,6 +22,12 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.imagemetadatamanager")
 
+// APIv1 provides the image metadata manager facade version 1,
+// which does not manage image policies.
+type APIv1 struct {
+	*API
+}
+
 // API is the concrete implementation of the api end point
 // for loud image metadata manipulations.
 type API struct {
@@ -65,6 +71,19 @@ func NewAPI(
 	return createAPI(getState(st), newEnviron, resources, authorizer)
 }
 
+// NewAPIv1 returns a version 1 cloud image metadata API facade.
+func NewAPIv1(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv1, error) {
+	api, err := NewAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv1{api}, nil
+}
+
 // List returns all found cloud image metadata that satisfy
 // given filter.
 // Returned list contains metadata ordered by priority.
@@ -117,6 +136,73 @@ func (api *API) Delete(images params.MetadataImageIds) (params.ErrorResults, err
 	return params.ErrorResults{Results: all}, nil
 }
 
+// ListPolicies returns the model's image policies.
+func (api *API) ListPolicies() (params.ImagePolicies, error) {
+	policies, err := api.metadata.AllImagePolicies()
+	if err != nil {
+		return params.ImagePolicies{}, common.ServerError(err)
+	}
+	result := params.ImagePolicies{
+		Policies: make([]params.ImagePolicy, len(policies)),
+	}
+	for i, policy := range policies {
+		result.Policies[i] = params.ImagePolicy{
+			Series:        policy.Series,
+			Region:        policy.Region,
+			ImageId:       policy.ImageId,
+			Arch:          policy.Arch,
+			Stream:        policy.Stream,
+			RequireSigned: policy.RequireSigned,
+			PreferTags:    policy.PreferTags,
+		}
+	}
+	return result, nil
+}
+
+// SetPolicies sets the given image policies, replacing any existing
+// policies for the same series and region.
+// It supports bulk calls.
+func (api *API) SetPolicies(args params.ImagePolicies) (params.ErrorResults, error) {
+	all := make([]params.ErrorResult, len(args.Policies))
+	for i, policy := range args.Policies {
+		err := api.metadata.SetImagePolicy(state.ImagePolicy{
+			Series:        policy.Series,
+			Region:        policy.Region,
+			ImageId:       policy.ImageId,
+			Arch:          policy.Arch,
+			Stream:        policy.Stream,
+			RequireSigned: policy.RequireSigned,
+			PreferTags:    policy.PreferTags,
+		})
+		all[i] = params.ErrorResult{common.ServerError(err)}
+	}
+	return params.ErrorResults{Results: all}, nil
+}
+
+// RemovePolicies removes the image policies for the given series and
+// regions.
+// It supports bulk calls.
+func (api *API) RemovePolicies(args params.ImagePolicyKeys) (params.ErrorResults, error) {
+	all := make([]params.ErrorResult, len(args.Keys))
+	for i, key := range args.Keys {
+		err := api.metadata.RemoveImagePolicy(key.Series, key.Region)
+		all[i] = params.ErrorResult{common.ServerError(err)}
+	}
+	return params.ErrorResults{Results: all}, nil
+}
+
+// Mask the image policy methods from the V1 API. The API reflection
+// code skips 2-argument methods.
+
+// ListPolicies isn't on the V1 API.
+func (*APIv1) ListPolicies(_, _ struct{}) {}
+
+// SetPolicies isn't on the V1 API.
+func (*APIv1) SetPolicies(_, _ struct{}) {}
+
+// RemovePolicies isn't on the V1 API.
+func (*APIv1) RemovePolicies(_, _ struct{}) {}
+
 func parseMetadataToParams(p cloudimagemetadata.Metadata) params.CloudImageMetadata {
 	result := params.CloudImageMetadata{
 		ImageId:         p.ImageId,
@@ -19,6 +19,7 @@ import (
 	imagetesting "github.com/juju/juju/environs/imagemetadata/testing"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/cloudimagemetadata"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -66,6 +67,10 @@ const (
 	deleteMetadata = "deleteMetadata"
 	modelConfig    = "modelConfig"
 	controllerTag  = "controllerTag"
+
+	allImagePolicies  = "allImagePolicies"
+	setImagePolicy    = "setImagePolicy"
+	removeImagePolicy = "removeImagePolicy"
 )
 
 func (s *baseImageMetadataSuite) constructState(cfg *config.Config) *mockState {
@@ -86,6 +91,15 @@ func (s *baseImageMetadataSuite) constructState(cfg *config.Config) *mockState {
 		controllerTag: func() names.ControllerTag {
 			return names.NewControllerTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
 		},
+		allImagePolicies: func() ([]state.ImagePolicy, error) {
+			return nil, nil
+		},
+		setImagePolicy: func(state.ImagePolicy) error {
+			return nil
+		},
+		removeImagePolicy: func(series, region string) error {
+			return nil
+		},
 	}
 }
 
@@ -97,6 +111,10 @@ type mockState struct {
 	deleteMetadata func(imageId string) error
 	modelConfig    func() (*config.Config, error)
 	controllerTag  func() names.ControllerTag
+
+	allImagePolicies  func() ([]state.ImagePolicy, error)
+	setImagePolicy    func(state.ImagePolicy) error
+	removeImagePolicy func(series, region string) error
 }
 
 func (st *mockState) FindMetadata(f cloudimagemetadata.MetadataFilter) (map[string][]cloudimagemetadata.Metadata, error) {
@@ -124,6 +142,21 @@ func (st *mockState) ControllerTag() names.ControllerTag {
 	return st.controllerTag()
 }
 
+func (st *mockState) AllImagePolicies() ([]state.ImagePolicy, error) {
+	st.Stub.MethodCall(st, allImagePolicies)
+	return st.allImagePolicies()
+}
+
+func (st *mockState) SetImagePolicy(policy state.ImagePolicy) error {
+	st.Stub.MethodCall(st, setImagePolicy, policy)
+	return st.setImagePolicy(policy)
+}
+
+func (st *mockState) RemoveImagePolicy(series, region string) error {
+	st.Stub.MethodCall(st, removeImagePolicy, series, region)
+	return st.removeImagePolicy(series, region)
+}
+
 func testConfig(c *gc.C) *config.Config {
 	cfg, err := config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
 		"type": "mock",
@@ -0,0 +1,112 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package imagemetadatamanager_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type policiesSuite struct {
+	baseImageMetadataSuite
+}
+
+var _ = gc.Suite(&policiesSuite{})
+
+func (s *policiesSuite) TestListPolicies(c *gc.C) {
+	s.state.allImagePolicies = func() ([]state.ImagePolicy, error) {
+		return []state.ImagePolicy{{
+			Series:  "bionic",
+			Region:  "us-east-1",
+			ImageId: "ami-1234",
+			Arch:    "amd64",
+		}, {
+			Series:        "xenial",
+			Stream:        "daily",
+			RequireSigned: true,
+			PreferTags:    map[string]string{state.ImageTagVirtType: "hvm"},
+		}}, nil
+	}
+
+	result, err := s.api.ListPolicies()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ImagePolicies{
+		Policies: []params.ImagePolicy{{
+			Series:  "bionic",
+			Region:  "us-east-1",
+			ImageId: "ami-1234",
+			Arch:    "amd64",
+		}, {
+			Series:        "xenial",
+			Stream:        "daily",
+			RequireSigned: true,
+			PreferTags:    map[string]string{"virt-type": "hvm"},
+		}},
+	})
+	s.assertCalls(c, controllerTag, allImagePolicies)
+}
+
+func (s *policiesSuite) TestListPoliciesError(c *gc.C) {
+	s.state.allImagePolicies = func() ([]state.ImagePolicy, error) {
+		return nil, errors.New("boom")
+	}
+
+	_, err := s.api.ListPolicies()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *policiesSuite) TestSetPolicies(c *gc.C) {
+	s.state.setImagePolicy = func(policy state.ImagePolicy) error {
+		if policy.Series == "" {
+			return errors.NotValidf("image policy without a series")
+		}
+		return nil
+	}
+
+	result, err := s.api.SetPolicies(params.ImagePolicies{
+		Policies: []params.ImagePolicy{
+			{Series: "bionic", Stream: "daily", PreferTags: map[string]string{"source": "custom"}},
+			{Stream: "daily"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, "image policy without a series not valid")
+
+	s.assertCalls(c, controllerTag, setImagePolicy, setImagePolicy)
+	s.state.Stub.CheckCall(c, 1, setImagePolicy, state.ImagePolicy{
+		Series:     "bionic",
+		Stream:     "daily",
+		PreferTags: map[string]string{"source": "custom"},
+	})
+}
+
+func (s *policiesSuite) TestRemovePolicies(c *gc.C) {
+	s.state.removeImagePolicy = func(series, region string) error {
+		if region != "" {
+			return errors.NotFoundf("image policy %q", series+"#"+region)
+		}
+		return nil
+	}
+
+	result, err := s.api.RemovePolicies(params.ImagePolicyKeys{
+		Keys: []params.ImagePolicyKey{
+			{Series: "bionic"},
+			{Series: "bionic", Region: "us-east-1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `image policy "bionic#us-east-1" not found`)
+	c.Check(result.Results[1].Error.Code, gc.Equals, params.CodeNotFound)
+
+	s.assertCalls(c, controllerTag, removeImagePolicy, removeImagePolicy)
+	s.state.Stub.CheckCall(c, 2, removeImagePolicy, "bionic", "us-east-1")
+}
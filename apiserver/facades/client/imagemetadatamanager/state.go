@@ -18,6 +18,9 @@ type metadataAccess interface {
 	DeleteMetadata(imageId string) error
 	ModelConfig() (*config.Config, error)
 	ControllerTag() names.ControllerTag
+	AllImagePolicies() ([]state.ImagePolicy, error)
+	SetImagePolicy(state.ImagePolicy) error
+	RemoveImagePolicy(series, region string) error
 }
 
 type Model interface {
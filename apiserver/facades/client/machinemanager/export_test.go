@@ -5,3 +5,7 @@ package machinemanager
 
 var InstanceTypes = instanceTypes
 var IsSeriesLessThan = isSeriesLessThan
+
+func PatchGetEnviron(mm *MachineManagerAPI, getEnviron environGetFunc) {
+	mm.getEnviron = getEnviron
+}
@@ -25,23 +25,10 @@ func instanceTypes(mm *MachineManagerAPI,
 	getEnviron environGetFunc,
 	cons params.ModelInstanceTypesConstraints,
 ) (params.InstanceTypesResults, error) {
-	model, err := mm.st.Model()
+	env, err := mm.environ(getEnviron)
 	if err != nil {
 		return params.InstanceTypesResults{}, errors.Trace(err)
 	}
-
-	cloudSpec := func() (environs.CloudSpec, error) {
-		cloudName := model.Cloud()
-		regionName := model.CloudRegion()
-		credentialTag, _ := model.CloudCredential()
-		return stateenvirons.CloudSpec(mm.st, cloudName, regionName, credentialTag)
-	}
-	backend := common.EnvironConfigGetterFuncs{
-		CloudSpecFunc:   cloudSpec,
-		ModelConfigFunc: model.Config,
-	}
-
-	env, err := getEnviron(backend, environs.New)
 	result := make([]params.InstanceTypesResult, len(cons.Constraints))
 	// TODO(perrito666) Cache the results to avoid excessive querying of the cloud.
 	for i, c := range cons.Constraints {
@@ -63,3 +50,88 @@ func instanceTypes(mm *MachineManagerAPI,
 
 	return params.InstanceTypesResults{Results: result}, nil
 }
+
+// CloudQuota returns the resource quota and estimated instance costs
+// for the cloud region in which the current model is deployed.
+func (mm *MachineManagerAPIV6) CloudQuota() (params.CloudQuotaResult, error) {
+	return cloudQuota(mm.MachineManagerAPI)
+}
+
+// CloudQuota isn't on the v5 API.
+func (*MachineManagerAPIV5) CloudQuota(_, _ struct{}) {}
+
+func cloudQuota(mm *MachineManagerAPI) (params.CloudQuotaResult, error) {
+	model, err := mm.st.Model()
+	if err != nil {
+		return params.CloudQuotaResult{}, errors.Trace(err)
+	}
+	result := params.CloudQuotaResult{
+		Cloud:  model.Cloud(),
+		Region: model.CloudRegion(),
+	}
+	env, err := mm.environ(mm.getEnviron)
+	if err != nil {
+		return params.CloudQuotaResult{}, errors.Trace(err)
+	}
+	reporter, ok := environs.SupportsQuota(env)
+	if !ok {
+		result.Error = common.ServerError(errors.NotSupportedf("cloud quota reporting"))
+		return result, nil
+	}
+	quota, err := reporter.Quota(mm.callContext)
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result, nil
+	}
+	for _, limit := range []struct {
+		resource string
+		limit    *environs.QuotaLimit
+	}{
+		{"instances", quota.Instances},
+		{"cores", quota.Cores},
+		{"volumes", quota.Volumes},
+		{"ip-addresses", quota.IPAddresses},
+	} {
+		if limit.limit == nil {
+			continue
+		}
+		result.Quota = append(result.Quota, params.CloudQuotaLimit{
+			Resource: limit.resource,
+			Limit:    limit.limit.Limit,
+			Used:     limit.limit.Used,
+		})
+	}
+	costs, err := reporter.InstanceCosts(mm.callContext)
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result, nil
+	}
+	for _, cost := range costs {
+		result.InstanceCosts = append(result.InstanceCosts, params.InstanceCost{
+			InstanceType: cost.InstanceType,
+			HourlyCost:   cost.HourlyCost,
+			Currency:     cost.Currency,
+		})
+	}
+	return result, nil
+}
+
+// environ returns the Environ for the model, using getEnviron.
+func (mm *MachineManagerAPI) environ(getEnviron environGetFunc) (environs.Environ, error) {
+	model, err := mm.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	cloudSpec := func() (environs.CloudSpec, error) {
+		cloudName := model.Cloud()
+		regionName := model.CloudRegion()
+		credentialTag, _ := model.CloudCredential()
+		return stateenvirons.CloudSpec(mm.st, cloudName, regionName, credentialTag)
+	}
+	backend := common.EnvironConfigGetterFuncs{
+		CloudSpecFunc:   cloudSpec,
+		ModelConfigFunc: model.Config,
+	}
+	return getEnviron(backend, environs.New)
+}
@@ -164,3 +164,48 @@ func (*mockModel) Cloud() string {
 func (*mockModel) CloudRegion() string {
 	return "a-region"
 }
+
+func (p *instanceTypesSuite) TestCloudQuota(c *gc.C) {
+	backend := &mockBackend{}
+	authorizer := testing.FakeAuthorizer{Tag: names.NewUserTag("admin")}
+	api, err := machinemanager.NewMachineManagerAPI(backend, backend, &mockPool{}, authorizer, backend.ModelTag(), context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+	env := &mockQuotaEnviron{
+		quota: environs.Quota{
+			Instances: &environs.QuotaLimit{Limit: 20, Used: 3},
+		},
+		costs: []environs.InstanceCost{
+			{InstanceType: "m5.large", HourlyCost: 0.096, Currency: "USD"},
+		},
+	}
+	machinemanager.PatchGetEnviron(api, func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	})
+
+	result, err := (&machinemanager.MachineManagerAPIV6{MachineManagerAPI: api}).CloudQuota()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CloudQuotaResult{
+		Cloud:  "a-cloud",
+		Region: "a-region",
+		Quota: []params.CloudQuotaLimit{
+			{Resource: "instances", Limit: 20, Used: 3},
+		},
+		InstanceCosts: []params.InstanceCost{
+			{InstanceType: "m5.large", HourlyCost: 0.096, Currency: "USD"},
+		},
+	})
+}
+
+func (p *instanceTypesSuite) TestCloudQuotaNotSupported(c *gc.C) {
+	backend := &mockBackend{}
+	authorizer := testing.FakeAuthorizer{Tag: names.NewUserTag("admin")}
+	api, err := machinemanager.NewMachineManagerAPI(backend, backend, &mockPool{}, authorizer, backend.ModelTag(), context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+	machinemanager.PatchGetEnviron(api, func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return &mockEnviron{}, nil
+	})
+
+	result, err := (&machinemanager.MachineManagerAPIV6{MachineManagerAPI: api}).CloudQuota()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "cloud quota reporting not supported")
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/instance"
@@ -34,6 +35,7 @@ type MachineManagerAPI struct {
 
 	modelTag    names.ModelTag
 	callContext context.ProviderCallContext
+	getEnviron  environGetFunc
 }
 
 // NewFacade create a new server-side MachineManager API facade. This
@@ -60,6 +62,11 @@ type MachineManagerAPIV4 struct {
 
 // Version 5 of Machine Manger API. Adds CreateUpgradeSeriesLock.
 type MachineManagerAPIV5 struct {
+	*MachineManagerAPIV6
+}
+
+// Version 6 of Machine Manager API. Adds CloudQuota.
+type MachineManagerAPIV6 struct {
 	*MachineManagerAPI
 }
 
@@ -74,11 +81,20 @@ func NewFacadeV4(ctx facade.Context) (*MachineManagerAPIV4, error) {
 
 // NewFacadeV5 creates a new server-side MachineManager API facade.
 func NewFacadeV5(ctx facade.Context) (*MachineManagerAPIV5, error) {
+	machineManagerAPIV6, err := NewFacadeV6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV5{machineManagerAPIV6}, nil
+}
+
+// NewFacadeV6 creates a new server-side MachineManager API facade.
+func NewFacadeV6(ctx facade.Context) (*MachineManagerAPIV6, error) {
 	machineManagerAPI, err := NewFacade(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &MachineManagerAPIV5{machineManagerAPI}, nil
+	return &MachineManagerAPIV6{machineManagerAPI}, nil
 }
 
 // NewMachineManagerAPI creates a new server-side MachineManager API facade.
@@ -101,6 +117,7 @@ func NewMachineManagerAPI(
 		check:         common.NewBlockChecker(backend),
 		modelTag:      modelTag,
 		callContext:   callCtx,
+		getEnviron:    environs.GetEnviron,
 	}, nil
 }
 
@@ -126,6 +143,9 @@ func (mm *MachineManagerAPI) AddMachines(args params.AddMachines) (params.AddMac
 	if err := mm.check.ChangeAllowed(); err != nil {
 		return results, errors.Trace(err)
 	}
+	if err := mm.checkQuota(args.MachineParams); err != nil {
+		return results, errors.Trace(err)
+	}
 	for i, p := range args.MachineParams {
 		m, err := mm.addOneMachine(p)
 		results.Machines[i].Error = common.ServerError(err)
@@ -136,6 +156,46 @@ func (mm *MachineManagerAPI) AddMachines(args params.AddMachines) (params.AddMac
 	return results, nil
 }
 
+// checkQuota refuses to add the machines if the new instances they
+// require would exceed the cloud's remaining quota.
+func (mm *MachineManagerAPI) checkQuota(machineParams []params.AddMachineParams) error {
+	req := machineQuotaRequest(machineParams)
+	if req.Instances == 0 {
+		return nil
+	}
+	env, err := mm.environ(mm.getEnviron)
+	if err != nil {
+		logger.Warningf("cannot check cloud quota: %v", err)
+		return nil
+	}
+	return errors.Trace(common.CheckQuota(env, mm.callContext, req))
+}
+
+// machineQuotaRequest returns the cloud resources required to add
+// machines with the given parameters. Machines with an existing
+// instance, and containers on existing machines, require none.
+func machineQuotaRequest(machineParams []params.AddMachineParams) environs.QuotaRequest {
+	var req environs.QuotaRequest
+	for _, p := range machineParams {
+		if p.InstanceId != "" || p.ParentId != "" {
+			continue
+		}
+		if p.Placement != nil && p.Placement.Directive != "" {
+			if _, err := instance.ParseContainerType(p.Placement.Scope); err == nil {
+				continue
+			}
+		}
+		req.Instances++
+		if p.Constraints.CpuCores != nil {
+			req.Cores += int(*p.Constraints.CpuCores)
+		}
+		for _, disk := range p.Disks {
+			req.Volumes += int(disk.Count)
+		}
+	}
+	return req
+}
+
 func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Machine, error) {
 	if p.ParentId != "" && p.ContainerType == "" {
 		return nil, fmt.Errorf("parent machine specified without container type")
@@ -18,6 +18,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -128,6 +130,47 @@ func (s *MachineManagerSuite) TestAddMachinesStateError(c *gc.C) {
 	c.Assert(s.st.calls, gc.Equals, 1)
 }
 
+func (s *MachineManagerSuite) patchQuota(quota environs.Quota) {
+	env := &mockQuotaEnviron{quota: quota}
+	machinemanager.PatchGetEnviron(s.api, func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	})
+}
+
+func (s *MachineManagerSuite) TestAddMachinesWithinQuota(c *gc.C) {
+	s.patchQuota(environs.Quota{
+		Instances: &environs.QuotaLimit{Limit: 10, Used: 8},
+	})
+	results, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{
+			{Series: "trusty"},
+			{Series: "trusty"},
+			// A machine with an existing instance needs no new one.
+			{Series: "trusty", InstanceId: "i-manual", Nonce: "nonce"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Machines, gc.HasLen, 3)
+	c.Assert(s.st.calls, gc.Equals, 3)
+}
+
+func (s *MachineManagerSuite) TestAddMachinesExceedsQuota(c *gc.C) {
+	cores := uint64(4)
+	s.patchQuota(environs.Quota{
+		Instances: &environs.QuotaLimit{Limit: 10, Used: 8},
+		Cores:     &environs.QuotaLimit{Limit: 16, Used: 12},
+	})
+	_, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{
+			{Series: "trusty", Constraints: constraints.Value{CpuCores: &cores}},
+			{Series: "trusty", Constraints: constraints.Value{CpuCores: &cores}},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cloud quota exceeded: cores \(8 required, 4 of 16 remaining\)`)
+	c.Assert(err, jc.Satisfies, environs.IsQuotaExceeded)
+	c.Assert(s.st.calls, gc.Equals, 0)
+}
+
 func (s *MachineManagerSuite) TestDestroyMachine(c *gc.C) {
 	s.st.machines["0"] = &mockMachine{}
 	results, err := s.api.DestroyMachine(params.Entities{
@@ -611,6 +654,21 @@ func (st *mockState) UnitStorageAttachments(tag names.UnitTag) ([]state.StorageA
 	return nil, nil
 }
 
+type mockQuotaEnviron struct {
+	environs.Environ
+
+	quota environs.Quota
+	costs []environs.InstanceCost
+}
+
+func (e *mockQuotaEnviron) Quota(context.ProviderCallContext) (environs.Quota, error) {
+	return e.quota, nil
+}
+
+func (e *mockQuotaEnviron) InstanceCosts(context.ProviderCallContext) ([]environs.InstanceCost, error) {
+	return e.costs, nil
+}
+
 type mockBlock struct {
 	state.Block
 	t state.BlockType
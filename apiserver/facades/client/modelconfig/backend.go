@@ -4,11 +4,14 @@
 package modelconfig
 
 import (
+	"github.com/juju/errors"
 	names "gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
 )
 
 // Backend contains the state.State methods used in this package,
@@ -22,6 +25,9 @@ type Backend interface {
 	Sequences() (map[string]int, error)
 	SetSLA(level, owner string, credentials []byte) error
 	SLALevel() (string, error)
+	SupportsContainerAddresses() (bool, error)
+	SupportsLoadBalancers() (bool, error)
+	SetDNSTSIGKey(key string) error
 }
 
 type stateShim struct {
@@ -37,6 +43,31 @@ func (st stateShim) ModelConfigValues() (config.ConfigValues, error) {
 	return st.model.ModelConfigValues()
 }
 
+func (st stateShim) SetDNSTSIGKey(key string) error {
+	return st.model.SetDNSTSIGKey(key)
+}
+
+// SupportsContainerAddresses returns whether the model's provider can
+// allocate static addresses for containers.
+func (st stateShim) SupportsContainerAddresses() (bool, error) {
+	env, err := environs.GetEnviron(stateenvirons.EnvironConfigGetter{st.State, st.model}, environs.New)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return environs.SupportsContainerAddresses(state.CallContext(st.State), env), nil
+}
+
+// SupportsLoadBalancers returns whether the model's provider can
+// manage load balancers for exposed applications.
+func (st stateShim) SupportsLoadBalancers() (bool, error) {
+	env, err := environs.GetEnviron(stateenvirons.EnvironConfigGetter{st.State, st.model}, environs.New)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	_, ok := environs.SupportsLoadBalancers(env)
+	return ok, nil
+}
+
 func (st stateShim) ModelTag() names.ModelTag {
 	m, err := st.State.Model()
 	if err != nil {
@@ -4,12 +4,16 @@
 package modelconfig
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/dns/rfc2136"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/permission"
 )
@@ -182,9 +186,67 @@ func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
 		return nil
 	}
 
+	// Containers attached with ipvlan cannot use DHCP, so they need
+	// static addresses allocated by the provider.
+	checkContainerNetworkingMethod := func(updateAttrs map[string]interface{}, removeAttrs []string, oldConfig *config.Config) error {
+		if updateAttrs[config.ContainerNetworkingMethod] != "ipvlan" {
+			return nil
+		}
+		supported, err := c.backend.SupportsContainerAddresses()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !supported {
+			return errors.New("container-networking-method ipvlan requires a provider that allocates container addresses")
+		}
+		return nil
+	}
+
+	// Load balancers are only implemented by some providers, so
+	// don't let users think exposed applications are behind one
+	// when they aren't.
+	checkExposeLoadBalancers := func(updateAttrs map[string]interface{}, removeAttrs []string, oldConfig *config.Config) error {
+		v, ok := updateAttrs[config.ExposeLoadBalancersKey]
+		if !ok {
+			return nil
+		}
+		if enabled, _ := strconv.ParseBool(fmt.Sprint(v)); !enabled {
+			return nil
+		}
+		supported, err := c.backend.SupportsLoadBalancers()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !supported {
+			return errors.Errorf("%s requires a provider that supports load balancers", config.ExposeLoadBalancersKey)
+		}
+		return nil
+	}
+
 	// Replace any deprecated attributes with their new values.
 	attrs := config.ProcessDeprecatedAttributes(args.Config)
-	return c.backend.UpdateModelConfig(attrs, nil, checkAgentVersion, checkLogTrace)
+
+	// The DNS TSIG key is a secret, so it is stored on the model
+	// rather than in the model config, which agents can read.
+	tsigKey, setTSIGKey := attrs[config.DNSRFC2136TSIGKeyKey]
+	if setTSIGKey {
+		delete(attrs, config.DNSRFC2136TSIGKeyKey)
+		key, ok := tsigKey.(string)
+		if !ok {
+			return errors.NotValidf("%s of type %T", config.DNSRFC2136TSIGKeyKey, tsigKey)
+		}
+		if key != "" {
+			if _, _, err := rfc2136.ParseTSIGKey(key); err != nil {
+				return errors.Annotatef(err, "setting %s", config.DNSRFC2136TSIGKeyKey)
+			}
+		}
+	}
+	err := c.backend.UpdateModelConfig(attrs, nil,
+		checkAgentVersion, checkLogTrace, checkContainerNetworkingMethod, checkExposeLoadBalancers)
+	if err != nil || !setTSIGKey {
+		return err
+	}
+	return errors.Trace(c.backend.SetDNSTSIGKey(tsigKey.(string)))
 }
 
 // ModelUnset implements the server-side part of the
@@ -196,7 +258,19 @@ func (c *ModelConfigAPI) ModelUnset(args params.ModelUnset) error {
 	if err := c.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
-	return c.backend.UpdateModelConfig(nil, args.Keys)
+	var keys []string
+	unsetTSIGKey := false
+	for _, key := range args.Keys {
+		if key == config.DNSRFC2136TSIGKeyKey {
+			unsetTSIGKey = true
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if err := c.backend.UpdateModelConfig(nil, keys); err != nil || !unsetTSIGKey {
+		return err
+	}
+	return errors.Trace(c.backend.SetDNSTSIGKey(""))
 }
 
 // SetSLALevel sets the sla level on the model.
@@ -126,6 +126,40 @@ func (s *modelconfigSuite) TestModelSetCannotChangeAgentVersion(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) TestModelSetIPVLANNeedsContainerAddresses(c *gc.C) {
+	args := params.ModelSet{
+		map[string]interface{}{"container-networking-method": "ipvlan"},
+	}
+	err := s.api.ModelSet(args)
+	c.Assert(err, gc.ErrorMatches, "container-networking-method ipvlan requires a provider that allocates container addresses")
+	s.assertConfigValueMissing(c, "container-networking-method")
+
+	s.backend.containerAddresses = true
+	err = s.api.ModelSet(args)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertConfigValue(c, "container-networking-method", "ipvlan")
+}
+
+func (s *modelconfigSuite) TestModelSetExposeLoadBalancersNeedsSupport(c *gc.C) {
+	args := params.ModelSet{
+		map[string]interface{}{"expose-load-balancers": true},
+	}
+	err := s.api.ModelSet(args)
+	c.Assert(err, gc.ErrorMatches, "expose-load-balancers requires a provider that supports load balancers")
+	s.assertConfigValueMissing(c, "expose-load-balancers")
+
+	// Turning them off is always allowed.
+	err = s.api.ModelSet(params.ModelSet{
+		map[string]interface{}{"expose-load-balancers": "false"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.backend.loadBalancers = true
+	err = s.api.ModelSet(args)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertConfigValue(c, "expose-load-balancers", true)
+}
+
 func (s *modelconfigSuite) TestAdminCanSetLogTrace(c *gc.C) {
 	args := params.ModelSet{
 		map[string]interface{}{"logging-config": "<root>=DEBUG;somepackage=TRACE"},
@@ -202,6 +236,35 @@ func (s *modelconfigSuite) TestModelUnsetMissing(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) TestModelSetDNSTSIGKey(c *gc.C) {
+	args := params.ModelSet{
+		map[string]interface{}{
+			"dns-rfc2136-tsig-key": "juju-key:c2VjcmV0",
+			"dns-zone":             "example.com",
+		},
+	}
+	err := s.api.ModelSet(args)
+	c.Assert(err, jc.ErrorIsNil)
+	// The key is stored apart from the model config.
+	c.Assert(s.backend.tsigKey, gc.Equals, "juju-key:c2VjcmV0")
+	s.assertConfigValueMissing(c, "dns-rfc2136-tsig-key")
+	s.assertConfigValue(c, "dns-zone", "example.com")
+
+	err = s.api.ModelUnset(params.ModelUnset{[]string{"dns-rfc2136-tsig-key"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backend.tsigKey, gc.Equals, "")
+	s.assertConfigValue(c, "dns-zone", "example.com")
+}
+
+func (s *modelconfigSuite) TestModelSetInvalidDNSTSIGKey(c *gc.C) {
+	args := params.ModelSet{
+		map[string]interface{}{"dns-rfc2136-tsig-key": "juju-key:!!"},
+	}
+	err := s.api.ModelSet(args)
+	c.Assert(err, gc.ErrorMatches, "setting dns-rfc2136-tsig-key: invalid TSIG key secret: .*")
+	c.Assert(s.backend.tsigKey, gc.Equals, "")
+}
+
 func (s *modelconfigSuite) TestSetSupportCredentals(c *gc.C) {
 	err := s.api.SetSLALevel(params.ModelSLA{params.ModelSLAInfo{"level", "bob"}, []byte("foobar")})
 	c.Assert(err, jc.ErrorIsNil)
@@ -212,6 +275,10 @@ type mockBackend struct {
 	old *config.Config
 	b   state.BlockType
 	msg string
+
+	containerAddresses bool
+	loadBalancers      bool
+	tsigKey            string
 }
 
 func (m *mockBackend) ModelConfigValues() (config.ConfigValues, error) {
@@ -261,6 +328,19 @@ func (m *mockBackend) SLALevel() (string, error) {
 	return "mock-level", nil
 }
 
+func (m *mockBackend) SupportsContainerAddresses() (bool, error) {
+	return m.containerAddresses, nil
+}
+
+func (m *mockBackend) SupportsLoadBalancers() (bool, error) {
+	return m.loadBalancers, nil
+}
+
+func (m *mockBackend) SetDNSTSIGKey(key string) error {
+	m.tsigKey = key
+	return nil
+}
+
 type mockBlock struct {
 	state.Block
 	t state.BlockType
@@ -32,6 +32,17 @@ type SubnetsAPI interface {
 	// ListSubnets returns the matching subnets after applying
 	// optional filters.
 	ListSubnets(args params.SubnetsFilters) (params.ListSubnetsResults, error)
+
+	// SetSubnetsRoutes replaces the static routes of existing subnets.
+	SetSubnetsRoutes(args params.SetSubnetsRoutesParams) (params.ErrorResults, error)
+}
+
+// SubnetsAPIV2 is missing the SetSubnetsRoutes method.
+type SubnetsAPIV2 interface {
+	AllZones() (params.ZoneResults, error)
+	AllSpaces() (params.SpaceResults, error)
+	AddSubnets(args params.AddSubnetsParams) (params.ErrorResults, error)
+	ListSubnets(args params.SubnetsFilters) (params.ListSubnetsResults, error)
 }
 
 // subnetsAPI implements the SubnetsAPI interface.
@@ -52,6 +63,11 @@ func NewAPI(st *state.State, res facade.Resources, auth facade.Authorizer) (Subn
 	return newAPIWithBacking(stateshim, state.CallContext(st), res, auth)
 }
 
+// NewAPIV2 is a wrapper that creates a V2 subnets API.
+func NewAPIV2(st *state.State, res facade.Resources, auth facade.Authorizer) (SubnetsAPIV2, error) {
+	return NewAPI(st, res, auth)
+}
+
 func (api *subnetsAPI) checkCanRead() error {
 	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.backing.ModelTag())
 	if err != nil {
@@ -137,3 +153,11 @@ func (api *subnetsAPI) ListSubnets(args params.SubnetsFilters) (results params.L
 
 	return networkingcommon.ListSubnets(api.backing, args)
 }
+
+// SetSubnetsRoutes is defined on the API interface.
+func (api *subnetsAPI) SetSubnetsRoutes(args params.SetSubnetsRoutesParams) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	return networkingcommon.SetSubnetsRoutes(api.backing, args)
+}
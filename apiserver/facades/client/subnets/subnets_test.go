@@ -862,3 +862,54 @@ func (s *SubnetsSuite) TestListSubnetsAllSubnetError(c *gc.C) {
 	_, err := s.facade.ListSubnets(params.SubnetsFilters{})
 	c.Assert(err, gc.ErrorMatches, "no subnets for you")
 }
+
+func (s *SubnetsSuite) TestSetSubnetsRoutes(c *gc.C) {
+	args := params.SetSubnetsRoutesParams{Subnets: []params.SetSubnetRoutesParams{{
+		SubnetTag: "subnet-10.10.0.0/24",
+		Routes: []params.NetworkRoute{{
+			DestinationCIDR: "10.20.0.0/16",
+			GatewayIP:       "10.10.0.1",
+			Metric:          10,
+		}},
+	}, {
+		SubnetTag: "subnet-10.99.0.0/24",
+	}, {
+		SubnetTag: "invalid",
+	}}}
+	results, err := s.facade.SetSubnetsRoutes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `setting routes of subnet "10.99.0.0/24": subnet "10.99.0.0/24" not found`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `given SubnetTag is invalid: "invalid" is not a valid tag`)
+
+	routes := []network.Route{{
+		DestinationCIDR: "10.20.0.0/16",
+		GatewayIP:       "10.10.0.1",
+		Metric:          10,
+	}}
+	subnet := apiservertesting.BackingInstance.Subnets[0]
+	c.Assert(subnet.CIDR(), gc.Equals, "10.10.0.0/24")
+	c.Assert(subnet.Routes(), jc.DeepEquals, routes)
+
+	apiservertesting.CheckMethodCalls(c, apiservertesting.SharedStub,
+		apiservertesting.BackingCall("SetSubnetRoutes", "10.10.0.0/24", routes),
+		apiservertesting.BackingCall("SetSubnetRoutes", "10.99.0.0/24", []network.Route(nil)),
+	)
+}
+
+func (s *SubnetsSuite) TestSetSubnetsRoutesReadOnly(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	facade, err := subnets.NewAPIWithBacking(
+		apiservertesting.BackingInstance,
+		s.callContext,
+		s.resources, s.authorizer,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.SetSubnetsRoutes(params.SetSubnetsRoutesParams{
+		Subnets: []params.SetSubnetRoutesParams{{SubnetTag: "subnet-10.10.0.0/24"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	apiservertesting.CheckMethodCalls(c, apiservertesting.SharedStub)
+}
@@ -4,6 +4,7 @@
 package caasunitprovisioner
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/juju/clock"
@@ -488,6 +489,10 @@ func (a *Facade) updateStatus(params params.ApplicationUnitParams) (
 	unitStatus *status.StatusInfo,
 	_ error,
 ) {
+	// The substrate may tell us why a pod is not running,
+	// eg FailedScheduling or CrashLoopBackOff, in which case
+	// the reason is surfaced in the workload status as well.
+	_, hasReason := params.Data[caas.StatusReasonKey]
 	switch status.Status(params.Status) {
 	case status.Unknown:
 		// The container runtime can spam us with unimportant
@@ -503,6 +508,11 @@ func (a *Facade) updateStatus(params params.ApplicationUnitParams) (
 			Status:  status.Waiting,
 			Message: status.MessageWaitForContainer,
 		}
+		if hasReason {
+			agentStatus.Data = params.Data
+			unitStatus.Message = fmt.Sprintf("%s: %s", status.MessageWaitForContainer, params.Info)
+			unitStatus.Data = params.Data
+		}
 	case status.Running:
 		// A pod has finished starting so the workload is now active.
 		agentStatus = &status.StatusInfo{
@@ -512,12 +522,29 @@ func (a *Facade) updateStatus(params params.ApplicationUnitParams) (
 			Status:  status.Active,
 			Message: params.Info,
 		}
+	case status.Stopped:
+		// A pod run to completion, eg by a job, has finished its work.
+		agentStatus = &status.StatusInfo{
+			Status: status.Idle,
+		}
+		unitStatus = &status.StatusInfo{
+			Status:  status.Terminated,
+			Message: "workload completed",
+		}
 	case status.Error:
 		agentStatus = &status.StatusInfo{
 			Status:  status.Error,
 			Message: params.Info,
 			Data:    params.Data,
 		}
+		if hasReason {
+			// The workload can't run until someone intervenes.
+			unitStatus = &status.StatusInfo{
+				Status:  status.Blocked,
+				Message: params.Info,
+				Data:    params.Data,
+			}
+		}
 	}
 	return agentStatus, unitStatus, nil
 }
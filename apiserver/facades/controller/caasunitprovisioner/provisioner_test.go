@@ -339,6 +339,84 @@ func (s *CAASProvisionerSuite) TestUpdateApplicationsUnits(c *gc.C) {
 	})
 }
 
+func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsWithReason(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", containerInfo: &mockContainerInfo{providerId: "uuid"}, life: state.Alive},
+		&mockUnit{name: "gitlab/1", containerInfo: &mockContainerInfo{providerId: "another-uuid"}, life: state.Alive},
+	}
+
+	scheduling := map[string]interface{}{"reason": "FailedScheduling"}
+	crashing := map[string]interface{}{"reason": "CrashLoopBackOff"}
+	units := []params.ApplicationUnitParams{
+		{ProviderId: "uuid", Address: "address", Ports: []string{"port"},
+			Status: "allocating", Info: "FailedScheduling: 0/3 nodes are available", Data: scheduling},
+		{ProviderId: "another-uuid", Address: "another-address", Ports: []string{"another-port"},
+			Status: "error", Info: "CrashLoopBackOff: back-off restarting failed container", Data: crashing},
+	}
+	args := params.UpdateApplicationUnitArgs{
+		Args: []params.UpdateApplicationUnits{
+			{ApplicationTag: "application-gitlab", Units: units},
+		},
+	}
+	results, err := s.facade.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	s.st.application.units[0].(*mockUnit).CheckCallNames(c, "Life", "UpdateOperation")
+	s.st.application.units[0].(*mockUnit).CheckCall(c, 1, "UpdateOperation", state.UnitUpdateProperties{
+		ProviderId: strPtr("uuid"),
+		Address:    strPtr("address"), Ports: &[]string{"port"},
+		UnitStatus: &status.StatusInfo{
+			Status:  status.Waiting,
+			Message: "waiting for container: FailedScheduling: 0/3 nodes are available",
+			Data:    scheduling,
+		},
+		AgentStatus: &status.StatusInfo{
+			Status:  status.Allocating,
+			Message: "FailedScheduling: 0/3 nodes are available",
+			Data:    scheduling,
+		},
+	})
+	s.st.application.units[1].(*mockUnit).CheckCallNames(c, "Life", "UpdateOperation")
+	s.st.application.units[1].(*mockUnit).CheckCall(c, 1, "UpdateOperation", state.UnitUpdateProperties{
+		ProviderId: strPtr("another-uuid"),
+		Address:    strPtr("another-address"), Ports: &[]string{"another-port"},
+		UnitStatus: &status.StatusInfo{
+			Status:  status.Blocked,
+			Message: "CrashLoopBackOff: back-off restarting failed container",
+			Data:    crashing,
+		},
+		AgentStatus: &status.StatusInfo{
+			Status:  status.Error,
+			Message: "CrashLoopBackOff: back-off restarting failed container",
+			Data:    crashing,
+		},
+	})
+}
+
+func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsCompleted(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", containerInfo: &mockContainerInfo{providerId: "uuid"}, life: state.Alive},
+	}
+	units := []params.ApplicationUnitParams{
+		{ProviderId: "uuid", Address: "address", Ports: []string{"port"}, Status: "stopped"},
+	}
+	args := params.UpdateApplicationUnitArgs{
+		Args: []params.UpdateApplicationUnits{
+			{ApplicationTag: "application-gitlab", Units: units},
+		},
+	}
+	results, err := s.facade.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	s.st.application.units[0].(*mockUnit).CheckCallNames(c, "Life", "UpdateOperation")
+	s.st.application.units[0].(*mockUnit).CheckCall(c, 1, "UpdateOperation", state.UnitUpdateProperties{
+		ProviderId: strPtr("uuid"),
+		Address:    strPtr("address"), Ports: &[]string{"port"},
+		UnitStatus:  &status.StatusInfo{Status: status.Terminated, Message: "workload completed"},
+		AgentStatus: &status.StatusInfo{Status: status.Idle},
+	})
+}
+
 func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsNotAlive(c *gc.C) {
 	s.st.application.units = []caasunitprovisioner.Unit{
 		&mockUnit{name: "gitlab/0", life: state.Alive},
@@ -4,7 +4,9 @@
 package crosscontroller
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
@@ -17,6 +19,7 @@ var logger = loggo.GetLogger("juju.apiserver.crosscontroller")
 
 type localControllerInfoFunc func() ([]string, string, error)
 type watchLocalControllerInfoFunc func() state.NotifyWatcher
+type offerRedirectFunc func(offerUUID string) (*state.OfferRedirect, error)
 
 // CrossControllerAPI provides access to the CrossModelRelations API facade.
 type CrossControllerAPI struct {
@@ -36,6 +39,31 @@ func NewStateCrossControllerAPI(ctx facade.Context) (*CrossControllerAPI, error)
 	)
 }
 
+// CrossControllerAPIV2 provides access to version 2 of the CrossController
+// API facade, which adds finding where offers have moved to.
+type CrossControllerAPIV2 struct {
+	*CrossControllerAPI
+	offerRedirect offerRedirectFunc
+}
+
+// NewStateCrossControllerAPIV2 creates a new server-side CrossControllerAPIV2
+// facade backed by global state.
+func NewStateCrossControllerAPIV2(ctx facade.Context) (*CrossControllerAPIV2, error) {
+	api, err := NewStateCrossControllerAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewCrossControllerAPIV2(api, ctx.State().OfferRedirect)
+}
+
+// NewCrossControllerAPIV2 returns a new server-side CrossControllerAPIV2 facade.
+func NewCrossControllerAPIV2(api *CrossControllerAPI, offerRedirect offerRedirectFunc) (*CrossControllerAPIV2, error) {
+	return &CrossControllerAPIV2{
+		CrossControllerAPI: api,
+		offerRedirect:      offerRedirect,
+	}, nil
+}
+
 // NewCrossControllerAPI returns a new server-side CrossControllerAPI facade.
 func NewCrossControllerAPI(
 	resources facade.Resources,
@@ -78,3 +106,31 @@ func (api *CrossControllerAPI) ControllerInfo() (params.ControllerAPIInfoResults
 	results.Results[0].CACert = caCert
 	return results, nil
 }
+
+// OfferRedirects returns the controllers now hosting the specified offers,
+// for offers whose models have been migrated away from this controller.
+// Knowing the UUID of an offer is sufficient to find where it has moved
+// to, as is the case for finding this controller's own API info.
+func (api *CrossControllerAPIV2) OfferRedirects(args params.OfferRedirectArgs) (params.OfferRedirectResults, error) {
+	results := params.OfferRedirectResults{
+		Results: make([]params.OfferRedirectResult, len(args.OfferUUIDs)),
+	}
+	for i, offerUUID := range args.OfferUUIDs {
+		redirect, err := api.offerRedirect(offerUUID)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = &params.OfferRedirect{
+			OfferUUID: redirect.OfferUUID,
+			ModelTag:  names.NewModelTag(redirect.ModelUUID).String(),
+			Controller: params.ExternalControllerInfo{
+				ControllerTag: redirect.Controller.ControllerTag.String(),
+				Alias:         redirect.Controller.Alias,
+				Addrs:         redirect.Controller.Addrs,
+				CACert:        redirect.Controller.CACert,
+			},
+		}
+	}
+	return results, nil
+}
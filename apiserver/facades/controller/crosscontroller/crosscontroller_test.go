@@ -6,12 +6,14 @@ package crosscontroller_test
 import (
 	"errors"
 
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facades/controller/crosscontroller"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -25,7 +27,9 @@ type CrossControllerSuite struct {
 	watcher                  *mockNotifyWatcher
 	localControllerInfo      func() ([]string, string, error)
 	watchLocalControllerInfo func() state.NotifyWatcher
+	offerRedirect            func(string) (*state.OfferRedirect, error)
 	api                      *crosscontroller.CrossControllerAPI
+	apiV2                    *crosscontroller.CrossControllerAPIV2
 }
 
 func (s *CrossControllerSuite) SetUpTest(c *gc.C) {
@@ -45,6 +49,13 @@ func (s *CrossControllerSuite) SetUpTest(c *gc.C) {
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = api
+	s.offerRedirect = func(offerUUID string) (*state.OfferRedirect, error) {
+		return nil, jujuerrors.NotFoundf("redirect for offer %q", offerUUID)
+	}
+	s.apiV2, err = crosscontroller.NewCrossControllerAPIV2(api, func(offerUUID string) (*state.OfferRedirect, error) {
+		return s.offerRedirect(offerUUID)
+	})
+	c.Assert(err, jc.ErrorIsNil)
 	s.watcher = newMockNotifyWatcher()
 	s.AddCleanup(func(*gc.C) { s.watcher.Stop() })
 }
@@ -98,3 +109,43 @@ func (s *CrossControllerSuite) TestWatchControllerInfoError(c *gc.C) {
 	})
 	c.Assert(s.resources.Get("1"), gc.IsNil)
 }
+
+func (s *CrossControllerSuite) TestOfferRedirects(c *gc.C) {
+	s.offerRedirect = func(offerUUID string) (*state.OfferRedirect, error) {
+		if offerUUID != "moved-uuid" {
+			return nil, jujuerrors.NotFoundf("redirect for offer %q", offerUUID)
+		}
+		return &state.OfferRedirect{
+			OfferUUID: "moved-uuid",
+			OfferName: "hosted-mysql",
+			ModelUUID: coretesting.ModelTag.Id(),
+			Controller: crossmodel.ControllerInfo{
+				ControllerTag: coretesting.ControllerTag,
+				Addrs:         []string{"1.2.3.4:17070"},
+				CACert:        "ca-cert",
+			},
+		}, nil
+	}
+	results, err := s.apiV2.OfferRedirects(params.OfferRedirectArgs{
+		OfferUUIDs: []string{"moved-uuid", "other-uuid"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.OfferRedirectResults{
+		Results: []params.OfferRedirectResult{{
+			Result: &params.OfferRedirect{
+				OfferUUID: "moved-uuid",
+				ModelTag:  coretesting.ModelTag.String(),
+				Controller: params.ExternalControllerInfo{
+					ControllerTag: coretesting.ControllerTag.String(),
+					Addrs:         []string{"1.2.3.4:17070"},
+					CACert:        "ca-cert",
+				},
+			},
+		}, {
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `redirect for offer "other-uuid" not found`,
+			},
+		}},
+	})
+}
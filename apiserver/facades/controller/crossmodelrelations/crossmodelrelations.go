@@ -127,20 +127,40 @@ func (api *CrossModelRelationsAPI) PublishRelationChanges(
 		}
 		logger.Debugf("relation tag for token %+v is %v", change.RelationToken, relationTag)
 		if err := api.checkMacaroonsForRelation(relationTag, change.Macaroons); err != nil {
+			api.updateRelationHealth(relationTag, err)
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
 		if err := commoncrossmodel.PublishRelationChange(api.st, relationTag, change); err != nil {
+			api.updateRelationHealth(relationTag, err)
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
 		if change.Life != params.Alive {
 			delete(api.relationToOffer, relationTag.Id())
+		} else {
+			api.updateRelationHealth(relationTag, nil)
 		}
 	}
 	return results, nil
 }
 
+// updateRelationHealth records the outcome of publishing a change from
+// the consuming model. The health is informational only, so failing to
+// record it is logged rather than returned.
+func (api *CrossModelRelationsAPI) updateRelationHealth(relationTag names.Tag, publishErr error) {
+	var exchangeErr string
+	if publishErr != nil {
+		exchangeErr = publishErr.Error()
+	}
+	// The consuming controller is reachable, since it has just
+	// published the change to us.
+	err := commoncrossmodel.UpdateRelationHealth(api.st, relationTag, exchangeErr, true)
+	if err != nil && !errors.IsNotFound(err) {
+		logger.Warningf("cannot record health of relation %v: %v", relationTag.Id(), err)
+	}
+}
+
 // RegisterRemoteRelationArgs sets up the model to participate
 // in the specified relations. This operation is idempotent.
 func (api *CrossModelRelationsAPI) RegisterRemoteRelations(
@@ -280,9 +300,16 @@ func (api *CrossModelRelationsAPI) registerRemoteRelation(relation params.Regist
 	}
 	logger.Debugf("local application %v from model %v exported with token %v ", localApplicationName, api.st.ModelUUID(), token)
 
-	// Mint a new macaroon attenuated to the actual relation.
-	relationMacaroon, err := api.authCtxt.CreateRemoteRelationMacaroon(
-		api.st.ModelUUID(), relation.OfferUUID, username, localRel.Tag())
+	// Mint a new macaroon attenuated to the actual relation. If access
+	// was granted by a share token, the relation remains tied to it.
+	var relationMacaroon *macaroon.Macaroon
+	if shareTokenID := commoncrossmodel.ShareTokenID(attr); shareTokenID != "" {
+		relationMacaroon, err = api.authCtxt.CreateShareTokenRelationMacaroon(
+			api.st.ModelUUID(), relation.OfferUUID, username, shareTokenID, localRel.Tag())
+	} else {
+		relationMacaroon, err = api.authCtxt.CreateRemoteRelationMacaroon(
+			api.st.ModelUUID(), relation.OfferUUID, username, localRel.Tag())
+	}
 	if err != nil {
 		return nil, errors.Annotate(err, "creating relation macaroon")
 	}
@@ -6,6 +6,7 @@ package crossmodelrelations_test
 import (
 	"bytes"
 	"regexp"
+	"time"
 
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -145,9 +146,17 @@ func (s *crossmodelRelationsSuite) assertPublishRelationsChanges(c *gc.C, life p
 		} else {
 			c.Assert(rel.message, gc.Equals, suspendedReason)
 		}
+		expected = append(expected, testing.StubCall{
+			"KeyRelation", []interface{}{"db2:db django:db"},
+		})
+		c.Assert(rel.health, gc.HasLen, 1)
+		c.Assert(rel.health[0].When.IsZero(), jc.IsFalse)
+		c.Assert(rel.health[0].Error, gc.Equals, "")
+		c.Assert(rel.health[0].ControllerReachable, jc.IsTrue)
 	} else {
 		c.Assert(rel.status, gc.Equals, status.Status(""))
 		c.Assert(rel.message, gc.Equals, "")
+		c.Assert(rel.health, gc.HasLen, 0)
 		expected = append(expected, testing.StubCall{
 			"RemoteApplication", []interface{}{"db2"},
 		})
@@ -174,6 +183,41 @@ func (s *crossmodelRelationsSuite) TestPublishRelationsChangesDyingWhileSuspende
 	s.assertPublishRelationsChanges(c, params.Dying, "")
 }
 
+func (s *crossmodelRelationsSuite) TestPublishRelationsChangesRecordsError(c *gc.C) {
+	rel := newMockRelation(1)
+	s.st.relations["db2:db django:db"] = rel
+	s.st.offerConnectionsByKey["db2:db django:db"] = &mockOfferConnection{
+		offerUUID:       "hosted-db2-uuid",
+		sourcemodelUUID: "source-model-uuid",
+		relationKey:     "db2:db django:db",
+		relationId:      1,
+	}
+	s.st.remoteEntities[names.NewRelationTag("db2:db django:db")] = "token-db2:db django:db"
+	// The macaroon is for a different relation.
+	mac, err := s.bakery.NewMacaroon(
+		[]checkers.Caveat{
+			checkers.DeclaredCaveat("source-model-uuid", s.st.ModelUUID()),
+			checkers.DeclaredCaveat("relation-key", "db2:db mysql:db"),
+			checkers.DeclaredCaveat("username", "mary"),
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.PublishRelationChanges(params.RemoteRelationsChanges{
+		Changes: []params.RemoteRelationChangeEvent{{
+			Life:             params.Alive,
+			ApplicationToken: "token-db2",
+			RelationToken:    "token-db2:db django:db",
+			Macaroons:        macaroon.Slice{mac},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.NotNil)
+	c.Assert(rel.health, gc.HasLen, 1)
+	c.Assert(rel.health[0].Error, gc.Equals, results.Results[0].Error.Error())
+	c.Assert(rel.health[0].ControllerReachable, jc.IsTrue)
+}
+
 func (s *crossmodelRelationsSuite) assertRegisterRemoteRelations(c *gc.C) {
 	app := &mockApplication{}
 	app.eps = []state.Endpoint{{
@@ -261,6 +305,76 @@ func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsIdempotent(c *gc.C
 	s.assertRegisterRemoteRelations(c)
 }
 
+func (s *crossmodelRelationsSuite) registerRemoteRelationWithShareToken(c *gc.C) params.RegisterRemoteRelationResult {
+	app := &mockApplication{}
+	app.eps = []state.Endpoint{{
+		ApplicationName: "offeredapp",
+		Relation:        charm.Relation{Name: "local"},
+	}}
+	s.st.applications["offeredapp"] = app
+	s.st.offers = map[string]*crossmodel.ApplicationOffer{
+		"offer-uuid": {
+			OfferUUID:       "offer-uuid",
+			OfferName:       "offered",
+			ApplicationName: "offeredapp",
+		}}
+	mac, err := s.bakery.NewMacaroon(
+		[]checkers.Caveat{
+			checkers.DeclaredCaveat("source-model-uuid", s.st.ModelUUID()),
+			checkers.DeclaredCaveat("offer-uuid", "offer-uuid"),
+			checkers.DeclaredCaveat("username", "mary"),
+			checkers.DeclaredCaveat("share-token", "token-id"),
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.api.RegisterRemoteRelations(params.RegisterRemoteRelationArgs{
+		Relations: []params.RegisterRemoteRelationArg{{
+			ApplicationToken:  "app-token",
+			SourceModelTag:    coretesting.ModelTag.String(),
+			RelationToken:     "rel-token",
+			RemoteEndpoint:    params.RemoteEndpoint{Name: "remote"},
+			OfferUUID:         "offer-uuid",
+			LocalEndpointName: "local",
+			Macaroons:         macaroon.Slice{mac},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	return results.Results[0]
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsShareToken(c *gc.C) {
+	s.st.shareTokens["token-id"] = &mockShareToken{
+		id:        "token-id",
+		offerUUID: "offer-uuid",
+		expires:   time.Now().Add(time.Hour),
+	}
+	result := s.registerRemoteRelationWithShareToken(c)
+	c.Assert(result.Error, gc.IsNil)
+	declared := checkers.InferDeclared(macaroon.Slice{result.Result.Macaroon})
+	c.Assert(declared, jc.DeepEquals, checkers.Declared{
+		"source-model-uuid": "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"relation-key":      "offeredapp:local remote-apptoken:remote",
+		"username":          "mary",
+		"offer-uuid":        "offer-uuid",
+		"share-token":       "token-id",
+	})
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsRevokedShareToken(c *gc.C) {
+	result := s.registerRemoteRelationWithShareToken(c)
+	c.Assert(result.Error, gc.ErrorMatches, "permission denied")
+	c.Assert(s.st.offerConnections, gc.HasLen, 0)
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsExpiredShareToken(c *gc.C) {
+	s.st.shareTokens["token-id"] = &mockShareToken{
+		id:        "token-id",
+		offerUUID: "offer-uuid",
+		expires:   time.Now().Add(-time.Minute),
+	}
+	result := s.registerRemoteRelationWithShareToken(c)
+	c.Assert(result.Error, gc.ErrorMatches, "permission denied")
+}
+
 func (s *crossmodelRelationsSuite) TestRelationUnitSettings(c *gc.C) {
 	djangoRelationUnit := newMockRelationUnit()
 	djangoRelationUnit.settings["key"] = "value"
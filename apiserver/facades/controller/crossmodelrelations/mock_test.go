@@ -50,6 +50,7 @@ type mockState struct {
 	remoteEntities        map[names.Tag]string
 	firewallRules         map[state.WellKnownServiceType]*state.FirewallRule
 	ingressNetworks       map[string][]string
+	shareTokens           map[string]*mockShareToken
 }
 
 func newMockState() *mockState {
@@ -63,9 +64,37 @@ func newMockState() *mockState {
 		offerConnectionsByKey: make(map[string]*mockOfferConnection),
 		firewallRules:         make(map[state.WellKnownServiceType]*state.FirewallRule),
 		ingressNetworks:       make(map[string][]string),
+		shareTokens:           make(map[string]*mockShareToken),
 	}
 }
 
+func (st *mockState) OfferShareToken(id string) (commoncrossmodel.OfferShareToken, error) {
+	st.MethodCall(st, "OfferShareToken", id)
+	token, ok := st.shareTokens[id]
+	if !ok {
+		return nil, errors.NotFoundf("offer share token %q", id)
+	}
+	return token, nil
+}
+
+type mockShareToken struct {
+	id        string
+	offerUUID string
+	expires   time.Time
+}
+
+func (t *mockShareToken) Id() string {
+	return t.id
+}
+
+func (t *mockShareToken) OfferUUID() string {
+	return t.offerUUID
+}
+
+func (t *mockShareToken) Expires() time.Time {
+	return t.expires
+}
+
 func (st *mockState) ApplicationOfferForUUID(offerUUID string) (*crossmodel.ApplicationOffer, error) {
 	offer, ok := st.offers[offerUUID]
 	if !ok {
@@ -312,6 +341,7 @@ type mockRelation struct {
 	status          status.Status
 	message         string
 	units           map[string]commoncrossmodel.RelationUnit
+	health          []state.RemoteRelationHealthUpdate
 }
 
 func newMockRelation(id int) *mockRelation {
@@ -364,6 +394,12 @@ func (r *mockRelation) SuspendedReason() string {
 	return r.suspendedReason
 }
 
+func (r *mockRelation) UpdateRemoteHealth(update state.RemoteRelationHealthUpdate) error {
+	r.MethodCall(r, "UpdateRemoteHealth", update)
+	r.health = append(r.health, update)
+	return r.NextErr()
+}
+
 func (r *mockRelation) RemoteUnit(unitId string) (commoncrossmodel.RelationUnit, error) {
 	r.MethodCall(r, "RemoteUnit", unitId)
 	if err := r.NextErr(); err != nil {
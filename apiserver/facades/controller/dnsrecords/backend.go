@@ -0,0 +1,130 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnsrecords
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the methods the DNS records facade needs from
+// state.State.
+type Backend interface {
+	// AllApplications returns all of the model's applications.
+	AllApplications() ([]Application, error)
+
+	// Machine returns the machine with the given id, so that we can
+	// find the addresses of the units it hosts.
+	Machine(id string) (Machine, error)
+
+	// ModelConfig returns the model's current config.
+	ModelConfig() (*config.Config, error)
+
+	// DNSTSIGKey returns the TSIG key used to update the model's
+	// records on an RFC 2136 server.
+	DNSTSIGKey() (string, error)
+
+	// WatchRecords returns a watcher that notifies when the model's
+	// records or DNS settings may have changed.
+	WatchRecords() (state.NotifyWatcher, error)
+}
+
+// Application defines the methods we need from state.Application.
+type Application interface {
+	Name() string
+	Life() state.Life
+	IsExposed() bool
+	AllUnits() ([]Unit, error)
+	ServiceInfo() (state.CloudService, error)
+}
+
+// Unit defines the methods we need from state.Unit.
+type Unit interface {
+	Name() string
+	Life() state.Life
+	AssignedMachineId() (string, error)
+	PublicAddress() (network.Address, error)
+}
+
+// Machine defines the methods we need from state.Machine.
+type Machine interface {
+	Addresses() []network.Address
+}
+
+type backendShim struct {
+	st *state.State
+}
+
+// AllApplications implements Backend.
+func (b *backendShim) AllApplications() ([]Application, error) {
+	apps, err := b.st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(apps))
+	for i, app := range apps {
+		result[i] = &applicationShim{app}
+	}
+	return result, nil
+}
+
+// Machine implements Backend.
+func (b *backendShim) Machine(id string) (Machine, error) {
+	return b.st.Machine(id)
+}
+
+// ModelConfig implements Backend.
+func (b *backendShim) ModelConfig() (*config.Config, error) {
+	model, err := b.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return model.ModelConfig()
+}
+
+// DNSTSIGKey implements Backend.
+func (b *backendShim) DNSTSIGKey() (string, error) {
+	model, err := b.st.Model()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return model.DNSTSIGKey(), nil
+}
+
+// WatchRecords implements Backend. Records follow the addresses of
+// machines, units and load balancers, and the exposed flag of
+// applications; the DNS settings follow the model config and, for the
+// TSIG key, the model itself.
+func (b *backendShim) WatchRecords() (state.NotifyWatcher, error) {
+	model, err := b.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewMultiNotifyWatcher(
+		b.st.WatchExposedAddresses(),
+		model.WatchForModelConfigChanges(),
+		model.Watch(),
+	), nil
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+// AllUnits implements Application.
+func (a *applicationShim) AllUnits() ([]Unit, error) {
+	units, err := a.Application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Unit, len(units))
+	for i, unit := range units {
+		result[i] = unit
+	}
+	return result, nil
+}
@@ -0,0 +1,185 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package dnsrecords provides the API used by the DNS records worker
+// to find out which names the model's exposed applications and their
+// units should have, and the addresses those names should resolve to.
+package dnsrecords
+
+import (
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// API implements the API facade used by the DNS records worker.
+type API struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// NewAPI returns a new DNS records API facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, errors.Trace(common.ErrPerm)
+	}
+	return &API{backend: backend, resources: resources}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(&backendShim{st}, res, auth)
+}
+
+// Records returns a record for each alive exposed application, and
+// for each of its alive units that has an address. A unit's record is
+// named after the unit, with the slash replaced by a hyphen, which
+// cannot clash with an application name. An application resolves to
+// the addresses of its load balancer if it has one, and otherwise to
+// the addresses of all of its units.
+func (api *API) Records() (params.DNSRecordsResult, error) {
+	apps, err := api.backend.AllApplications()
+	if err != nil {
+		return params.DNSRecordsResult{}, errors.Trace(err)
+	}
+	records := []params.DNSRecord{}
+	for _, app := range apps {
+		if app.Life() != state.Alive || !app.IsExposed() {
+			continue
+		}
+		appRecords, err := api.applicationRecords(app)
+		if err != nil {
+			return params.DNSRecordsResult{}, errors.Annotatef(err, "application %q", app.Name())
+		}
+		records = append(records, appRecords...)
+	}
+	return params.DNSRecordsResult{Records: records}, nil
+}
+
+// DNSConfig returns the model's DNS settings, including the TSIG key
+// which is not part of the model config.
+func (api *API) DNSConfig() (params.DNSConfigResult, error) {
+	cfg, err := api.backend.ModelConfig()
+	if err != nil {
+		return params.DNSConfigResult{}, errors.Trace(err)
+	}
+	key, err := api.backend.DNSTSIGKey()
+	if err != nil {
+		return params.DNSConfigResult{}, errors.Trace(err)
+	}
+	return params.DNSConfigResult{
+		ModelName:     cfg.Name(),
+		Zone:          cfg.DNSZone(),
+		TTL:           cfg.DNSRecordTTL(),
+		Backend:       cfg.DNSBackend(),
+		RFC2136Server: cfg.DNSRFC2136Server(),
+		TSIGKey:       key,
+	}, nil
+}
+
+// WatchRecords returns a NotifyWatcher which notifies when the results
+// of Records or DNSConfig may have changed.
+func (api *API) WatchRecords() (params.NotifyWatchResult, error) {
+	result := params.NotifyWatchResult{}
+	watch, err := api.backend.WatchRecords()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	// Consume the initial event. Technically, API
+	// calls to Watch 'transmit' the initial event
+	// in the Watch response. But NotifyWatchers
+	// have no state to transmit.
+	if _, ok := <-watch.Changes(); ok {
+		result.NotifyWatcherId = api.resources.Register(watch)
+	} else {
+		return result, watcher.EnsureErr(watch)
+	}
+	return result, nil
+}
+
+func (api *API) applicationRecords(app Application) ([]params.DNSRecord, error) {
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var unitRecords []params.DNSRecord
+	appAddresses := set.NewStrings()
+	for _, unit := range units {
+		if unit.Life() != state.Alive {
+			continue
+		}
+		addresses, err := api.unitAddresses(unit)
+		if err != nil {
+			return nil, errors.Annotatef(err, "unit %q", unit.Name())
+		}
+		if len(addresses) == 0 {
+			continue
+		}
+		unitRecords = append(unitRecords, params.DNSRecord{
+			Name:      strings.Replace(unit.Name(), "/", "-", 1),
+			Addresses: addresses,
+		})
+		appAddresses = appAddresses.Union(set.NewStrings(addresses...))
+	}
+
+	info, err := app.ServiceInfo()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if err == nil && len(info.Addresses()) > 0 {
+		appAddresses = set.NewStrings()
+		for _, addr := range info.Addresses() {
+			appAddresses.Add(addr.Value)
+		}
+	}
+
+	var records []params.DNSRecord
+	if !appAddresses.IsEmpty() {
+		records = append(records, params.DNSRecord{
+			Name:      app.Name(),
+			Addresses: appAddresses.SortedValues(),
+		})
+	}
+	return append(records, unitRecords...), nil
+}
+
+// unitAddresses returns the public addresses of the machine hosting
+// the unit, both IPv4 and IPv6. If the machine has none, the unit's
+// preferred public address is used, so that units in clouds without
+// public addresses can still be resolved.
+func (api *API) unitAddresses(unit Unit) ([]string, error) {
+	machineId, err := unit.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machine, err := api.backend.Machine(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	addresses := set.NewStrings()
+	for _, addr := range machine.Addresses() {
+		if addr.Scope == network.ScopePublic {
+			addresses.Add(addr.Value)
+		}
+	}
+	if addresses.IsEmpty() {
+		addr, err := unit.PublicAddress()
+		if network.IsNoAddressError(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		addresses.Add(addr.Value)
+	}
+	return addresses.SortedValues(), nil
+}
@@ -0,0 +1,296 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnsrecords_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/dnsrecords"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type dnsRecordsSuite struct {
+	testing.IsolationSuite
+
+	backend   *mockBackend
+	resources *common.Resources
+	api       *dnsrecords.API
+}
+
+var _ = gc.Suite(&dnsRecordsSuite{})
+
+func (s *dnsRecordsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		machines: map[string]*mockMachine{
+			"0": {addresses: []network.Address{
+				network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+				network.NewScopedAddress("203.0.113.1", network.ScopePublic),
+				network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+			}},
+			"1": {addresses: []network.Address{
+				network.NewScopedAddress("203.0.113.2", network.ScopePublic),
+			}},
+			"2": {addresses: []network.Address{
+				network.NewScopedAddress("10.0.0.3", network.ScopeCloudLocal),
+			}},
+		},
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	api, err := dnsrecords.NewAPI(s.backend, s.resources, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *dnsRecordsSuite) TestRequiresController(c *gc.C) {
+	_, err := dnsrecords.NewAPI(s.backend, nil, apiservertesting.FakeAuthorizer{Controller: false})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *dnsRecordsSuite) TestRecords(c *gc.C) {
+	s.backend.apps = []*mockApplication{{
+		name:    "wordpress",
+		exposed: true,
+		units: []*mockUnit{
+			{name: "wordpress/0", machineId: "0"},
+			{name: "wordpress/1", machineId: "1"},
+			{name: "wordpress/2", machineId: "2", publicAddress: "10.0.0.3"},
+			{name: "wordpress/3"},
+			{name: "wordpress/4", machineId: "1", life: state.Dying},
+		},
+	}, {
+		name: "mysql",
+		units: []*mockUnit{
+			{name: "mysql/0", machineId: "0"},
+		},
+	}, {
+		name:    "dying",
+		exposed: true,
+		life:    state.Dying,
+	}}
+
+	result, err := s.api.Records()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.DNSRecordsResult{
+		Records: []params.DNSRecord{{
+			Name:      "wordpress",
+			Addresses: []string{"10.0.0.3", "2001:db8::1", "203.0.113.1", "203.0.113.2"},
+		}, {
+			Name:      "wordpress-0",
+			Addresses: []string{"2001:db8::1", "203.0.113.1"},
+		}, {
+			Name:      "wordpress-1",
+			Addresses: []string{"203.0.113.2"},
+		}, {
+			Name:      "wordpress-2",
+			Addresses: []string{"10.0.0.3"},
+		}},
+	})
+}
+
+func (s *dnsRecordsSuite) TestRecordsLoadBalancer(c *gc.C) {
+	s.backend.apps = []*mockApplication{{
+		name:    "wordpress",
+		exposed: true,
+		units: []*mockUnit{
+			{name: "wordpress/0", machineId: "1"},
+		},
+		serviceAddresses: []network.Address{
+			network.NewScopedAddress("198.51.100.10", network.ScopePublic),
+		},
+	}}
+
+	result, err := s.api.Records()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.DNSRecordsResult{
+		Records: []params.DNSRecord{{
+			Name:      "wordpress",
+			Addresses: []string{"198.51.100.10"},
+		}, {
+			Name:      "wordpress-0",
+			Addresses: []string{"203.0.113.2"},
+		}},
+	})
+}
+
+func (s *dnsRecordsSuite) TestRecordsNoAddresses(c *gc.C) {
+	s.backend.apps = []*mockApplication{{
+		name:    "wordpress",
+		exposed: true,
+		units:   []*mockUnit{{name: "wordpress/0", machineId: "2"}},
+	}}
+
+	result, err := s.api.Records()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Records, gc.HasLen, 0)
+}
+
+func (s *dnsRecordsSuite) TestRecordsError(c *gc.C) {
+	s.backend.apps = []*mockApplication{{name: "wordpress", exposed: true}}
+	s.backend.apps[0].SetErrors(errors.New("boom"))
+	_, err := s.api.Records()
+	c.Assert(err, gc.ErrorMatches, `application "wordpress": boom`)
+}
+
+func (s *dnsRecordsSuite) TestDNSConfig(c *gc.C) {
+	s.backend.config = coretesting.CustomModelConfig(c, coretesting.Attrs{
+		config.DNSZoneKey:          "example.com",
+		config.DNSBackendKey:       config.DNSBackendRFC2136,
+		config.DNSRFC2136ServerKey: "10.0.0.53",
+		config.DNSRecordTTLKey:     60,
+	})
+	s.backend.tsigKey = "juju-key:c2VjcmV0"
+
+	result, err := s.api.DNSConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.DNSConfigResult{
+		ModelName:     "testmodel",
+		Zone:          "example.com.",
+		TTL:           60,
+		Backend:       config.DNSBackendRFC2136,
+		RFC2136Server: "10.0.0.53",
+		TSIGKey:       "juju-key:c2VjcmV0",
+	})
+}
+
+func (s *dnsRecordsSuite) TestWatchRecords(c *gc.C) {
+	result, err := s.api.WatchRecords()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Assert(s.resources.Get("1"), gc.NotNil)
+}
+
+type mockBackend struct {
+	apps     []*mockApplication
+	machines map[string]*mockMachine
+	config   *config.Config
+	tsigKey  string
+}
+
+func (b *mockBackend) AllApplications() ([]dnsrecords.Application, error) {
+	result := make([]dnsrecords.Application, len(b.apps))
+	for i, app := range b.apps {
+		result[i] = app
+	}
+	return result, nil
+}
+
+func (b *mockBackend) Machine(id string) (dnsrecords.Machine, error) {
+	m, ok := b.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %q", id)
+	}
+	return m, nil
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	return b.config, nil
+}
+
+func (b *mockBackend) DNSTSIGKey() (string, error) {
+	return b.tsigKey, nil
+}
+
+func (b *mockBackend) WatchRecords() (state.NotifyWatcher, error) {
+	return apiservertesting.NewFakeNotifyWatcher(), nil
+}
+
+type mockApplication struct {
+	testing.Stub
+	name             string
+	life             state.Life
+	exposed          bool
+	units            []*mockUnit
+	serviceAddresses []network.Address
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) Life() state.Life {
+	return a.life
+}
+
+func (a *mockApplication) IsExposed() bool {
+	return a.exposed
+}
+
+func (a *mockApplication) AllUnits() ([]dnsrecords.Unit, error) {
+	a.MethodCall(a, "AllUnits")
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	result := make([]dnsrecords.Unit, len(a.units))
+	for i, unit := range a.units {
+		result[i] = unit
+	}
+	return result, nil
+}
+
+func (a *mockApplication) ServiceInfo() (state.CloudService, error) {
+	if a.serviceAddresses == nil {
+		return nil, errors.NotFoundf("cloud service for application %v", a.name)
+	}
+	return &mockCloudService{addresses: a.serviceAddresses}, nil
+}
+
+type mockCloudService struct {
+	addresses []network.Address
+}
+
+func (s *mockCloudService) ProviderId() string {
+	return "lb"
+}
+
+func (s *mockCloudService) Addresses() []network.Address {
+	return s.addresses
+}
+
+type mockUnit struct {
+	name          string
+	life          state.Life
+	machineId     string
+	publicAddress string
+}
+
+func (u *mockUnit) Name() string {
+	return u.name
+}
+
+func (u *mockUnit) Life() state.Life {
+	return u.life
+}
+
+func (u *mockUnit) AssignedMachineId() (string, error) {
+	if u.machineId == "" {
+		return "", errors.NotAssignedf("unit %q", u.name)
+	}
+	return u.machineId, nil
+}
+
+func (u *mockUnit) PublicAddress() (network.Address, error) {
+	if u.publicAddress == "" {
+		return network.Address{}, network.NoAddressError("public")
+	}
+	return network.NewScopedAddress(u.publicAddress, network.ScopeCloudLocal), nil
+}
+
+type mockMachine struct {
+	addresses []network.Address
+}
+
+func (m *mockMachine) Addresses() []network.Address {
+	return m.addresses
+}
@@ -4,6 +4,7 @@
 package firewaller
 
 import (
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -21,6 +22,10 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.firewaller")
 
+// allIngressCIDRs are the ingress CIDRs of an application exposed to
+// everywhere, over both IPv4 and IPv6.
+var allIngressCIDRs = []string{"0.0.0.0/0", "::/0"}
+
 // FirewallerAPIV3 provides access to the Firewaller v3 API facade.
 type FirewallerAPIV3 struct {
 	*common.LifeGetter
@@ -51,6 +56,16 @@ type FirewallerAPIV5 struct {
 	*FirewallerAPIV4
 }
 
+// FirewallerAPIV6 provides access to the Firewaller v6 API facade.
+type FirewallerAPIV6 struct {
+	*FirewallerAPIV5
+}
+
+// FirewallerAPIV7 provides access to the Firewaller v7 API facade.
+type FirewallerAPIV7 struct {
+	*FirewallerAPIV6
+}
+
 // NewStateFirewallerAPIV3 creates a new server-side FirewallerAPIV3 facade.
 func NewStateFirewallerAPIV3(context facade.Context) (*FirewallerAPIV3, error) {
 	st := context.State()
@@ -64,7 +79,7 @@ func NewStateFirewallerAPIV3(context facade.Context) (*FirewallerAPIV3, error) {
 		cloudspec.MakeCloudSpecGetterForModel(st),
 		common.AuthFuncForTag(m.ModelTag()),
 	)
-	return NewFirewallerAPI(stateShim{st: st, State: firewall.StateShim(st, m)}, context.Resources(), context.Auth(), cloudSpecAPI)
+	return NewFirewallerAPI(stateShim{st: st, m: m, State: firewall.StateShim(st, m)}, context.Resources(), context.Auth(), cloudSpecAPI)
 }
 
 // NewStateFirewallerAPIV4 creates a new server-side FirewallerAPIV4 facade.
@@ -90,6 +105,28 @@ func NewStateFirewallerAPIV5(context facade.Context) (*FirewallerAPIV5, error) {
 	}, nil
 }
 
+// NewStateFirewallerAPIV6 creates a new server-side FirewallerAPIV6 facade.
+func NewStateFirewallerAPIV6(context facade.Context) (*FirewallerAPIV6, error) {
+	facadev5, err := NewStateFirewallerAPIV5(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV6{
+		FirewallerAPIV5: facadev5,
+	}, nil
+}
+
+// NewStateFirewallerAPIV7 creates a new server-side FirewallerAPIV7 facade.
+func NewStateFirewallerAPIV7(context facade.Context) (*FirewallerAPIV7, error) {
+	facadev6, err := NewStateFirewallerAPIV6(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV7{
+		FirewallerAPIV6: facadev6,
+	}, nil
+}
+
 // NewFirewallerAPI creates a new server-side FirewallerAPIV3 facade.
 func NewFirewallerAPI(
 	st State,
@@ -532,3 +569,110 @@ func (f *FirewallerAPIV5) AreManuallyProvisioned(args params.Entities) (params.B
 	}
 	return result, nil
 }
+
+// GetExposeInfo returns, for each given application, whether it is
+// exposed and the CIDRs its open ports may be reached from. Spaces
+// named in the application's expose settings are resolved to the
+// CIDRs of their subnets.
+func (f *FirewallerAPIV6) GetExposeInfo(args params.Entities) (params.ExposeInfoResults, error) {
+	result := params.ExposeInfoResults{
+		Results: make([]params.ExposeInfoResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.ExposeInfoResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err == nil && application.IsExposed() {
+			result.Results[i].Exposed = true
+			result.Results[i].IngressCIDRs, err = f.exposedIngressCIDRs(application.ExposedEndpoints())
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// exposedIngressCIDRs returns the sorted CIDRs allowed to reach an
+// exposed application with the given expose settings. Ports are not
+// associated with endpoints, so the sources of all endpoints apply
+// to all of the application's open ports.
+func (f *FirewallerAPIV6) exposedIngressCIDRs(exposedEndpoints map[string]state.ExposedEndpoint) ([]string, error) {
+	if len(exposedEndpoints) == 0 {
+		return allIngressCIDRs, nil
+	}
+	cidrs := set.NewStrings()
+	for _, exposed := range exposedEndpoints {
+		if exposed.AllowsAll() {
+			return allIngressCIDRs, nil
+		}
+		cidrs = cidrs.Union(set.NewStrings(exposed.ExposeToCIDRs...))
+		for _, spaceName := range exposed.ExposeToSpaces {
+			spaceCIDRs, err := f.st.SpaceSubnetCIDRs(spaceName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			cidrs = cidrs.Union(set.NewStrings(spaceCIDRs...))
+		}
+	}
+	return cidrs.SortedValues(), nil
+}
+
+// WatchSubnetSpaces returns a NotifyWatcher that notifies when the
+// model's subnets, or the spaces they are in, change. The CIDRs that
+// an application exposed to spaces may be reached from change with
+// them.
+func (f *FirewallerAPIV6) WatchSubnetSpaces() (params.NotifyWatchResult, error) {
+	watch := f.st.WatchSubnetSpaces()
+	// Consume the initial event.
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: f.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// GetMachineEgressRules returns the egress rules of each given machine,
+// according to the model's egress-policy.
+func (f *FirewallerAPIV7) GetMachineEgressRules(args params.Entities) (params.EgressRulesResults, error) {
+	result := params.EgressRulesResults{
+		Results: make([]params.EgressRulesResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessMachine()
+	if err != nil {
+		return params.EgressRulesResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		result.Results[i].Rules, err = firewall.MachineEgressRules(f.st, tag.Id())
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// WatchEgressRules returns a NotifyWatcher that notifies when the
+// egress rules returned by GetMachineEgressRules may have changed.
+func (f *FirewallerAPIV7) WatchEgressRules() (params.NotifyWatchResult, error) {
+	watch := f.st.WatchEgressRules()
+	// Consume the initial event.
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: f.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{}, watcher.EnsureErr(watch)
+}
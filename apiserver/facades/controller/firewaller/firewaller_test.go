@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/firewaller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
@@ -270,3 +271,158 @@ func (s *firewallerSuite) TestAreManuallyProvisioned(c *gc.C) {
 		},
 	})
 }
+
+func (s *firewallerSuite) TestGetExposeInfo(c *gc.C) {
+	_, err := s.State.AddSpace("public", "", []string{"10.20.30.0/24"}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {
+			ExposeToSpaces: []string{"public"},
+			ExposeToCIDRs:  []string{"192.168.0.0/16"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	mysql, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	apiv6 := &firewaller.FirewallerAPIV6{
+		&firewaller.FirewallerAPIV5{
+			&firewaller.FirewallerAPIV4{
+				FirewallerAPIV3:     s.firewaller,
+				ControllerConfigAPI: common.NewControllerConfig(newMockState(coretesting.ModelTag.Id())),
+			}}}
+
+	result, err := apiv6.GetExposeInfo(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+		{Tag: mysql.Tag().String()},
+		{Tag: s.units[0].Tag().String()},
+		{Tag: "application-bar"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ExposeInfoResults{
+		Results: []params.ExposeInfoResult{
+			{Exposed: true, IngressCIDRs: []string{"10.20.30.0/24", "192.168.0.0/16"}},
+			{Exposed: true, IngressCIDRs: []string{"0.0.0.0/0", "::/0"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+		},
+	})
+
+	err = mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = apiv6.GetExposeInfo(params.Entities{Entities: []params.Entity{
+		{Tag: mysql.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.ExposeInfoResult{{}})
+}
+
+func (s *firewallerSuite) TestWatchSubnetSpaces(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	apiv6 := &firewaller.FirewallerAPIV6{
+		&firewaller.FirewallerAPIV5{
+			&firewaller.FirewallerAPIV4{
+				FirewallerAPIV3:     s.firewaller,
+				ControllerConfigAPI: common.NewControllerConfig(newMockState(coretesting.ModelTag.Id())),
+			}}}
+	result, err := apiv6.WatchSubnetSpaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+
+	// Verify the resource was registered and stop when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event, and that
+	// moving a subnet to a space is notified.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	_, err = s.State.AddSpace("public", "", []string{"10.20.30.0/24"}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *firewallerSuite) TestGetMachineEgressRules(c *gc.C) {
+	err := s.State.SetAPIHostPorts([][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.10"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machines[0].SetProviderAddresses(network.NewAddress("10.0.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	apiv7 := &firewaller.FirewallerAPIV7{
+		&firewaller.FirewallerAPIV6{
+			&firewaller.FirewallerAPIV5{
+				&firewaller.FirewallerAPIV4{
+					FirewallerAPIV3:     s.firewaller,
+					ControllerConfigAPI: common.NewControllerConfig(newMockState(coretesting.ModelTag.Id())),
+				}}}}
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+		{Tag: s.application.Tag().String()},
+		{Tag: "machine-42"},
+	}}
+
+	// The default egress policy leaves egress unrestricted.
+	result, err := apiv7.GetMachineEgressRules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0], jc.DeepEquals, params.EgressRulesResult{})
+
+	err = s.Model.UpdateModelConfig(map[string]interface{}{
+		"egress-policy":        "relations",
+		"egress-allowed-cidrs": "192.168.0.0/16",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err = apiv7.GetMachineEgressRules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.EgressRulesResults{
+		Results: []params.EgressRulesResult{
+			{Rules: params.EgressRules{
+				Restricted:       true,
+				DestinationCIDRs: []string{"10.0.0.1/32", "10.0.0.10/32", "192.168.0.0/16"},
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError("machine 42")},
+		},
+	})
+}
+
+func (s *firewallerSuite) TestWatchEgressRules(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	apiv7 := &firewaller.FirewallerAPIV7{
+		&firewaller.FirewallerAPIV6{
+			&firewaller.FirewallerAPIV5{
+				&firewaller.FirewallerAPIV4{
+					FirewallerAPIV3:     s.firewaller,
+					ControllerConfigAPI: common.NewControllerConfig(newMockState(coretesting.ModelTag.Id())),
+				}}}}
+	result, err := apiv7.WatchEgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+
+	// Verify the resource was registered and stop when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event, and that
+	// changes to the egress-policy and to machine addresses are
+	// notified.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = s.Model.UpdateModelConfig(map[string]interface{}{"egress-policy": "relations"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.machines[0].SetProviderAddresses(network.NewAddress("10.0.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
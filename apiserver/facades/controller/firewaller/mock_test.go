@@ -112,6 +112,36 @@ func (st *mockState) FindEntity(tag names.Tag) (state.Entity, error) {
 	return nil, errors.NotImplementedf("FindEntity")
 }
 
+func (st *mockState) SpaceSubnetCIDRs(spaceName string) ([]string, error) {
+	st.MethodCall(st, "SpaceSubnetCIDRs", spaceName)
+	// TODO - implement when remaining firewaller tests become unit tests
+	return nil, errors.NotImplementedf("SpaceSubnetCIDRs")
+}
+
+func (st *mockState) WatchSubnetSpaces() state.NotifyWatcher {
+	st.MethodCall(st, "WatchSubnetSpaces")
+	// TODO - implement when remaining firewaller tests become unit tests
+	return nil
+}
+
+func (st *mockState) APIHostPortsForAgents() ([][]network.HostPort, error) {
+	st.MethodCall(st, "APIHostPortsForAgents")
+	// TODO - implement when remaining firewaller tests become unit tests
+	return nil, errors.NotImplementedf("APIHostPortsForAgents")
+}
+
+func (st *mockState) EgressMachine(id string) (firewall.EgressMachine, error) {
+	st.MethodCall(st, "EgressMachine", id)
+	// TODO - implement when remaining firewaller tests become unit tests
+	return nil, errors.NotImplementedf("EgressMachine")
+}
+
+func (st *mockState) WatchEgressRules() state.NotifyWatcher {
+	st.MethodCall(st, "WatchEgressRules")
+	// TODO - implement when remaining firewaller tests become unit tests
+	return nil
+}
+
 func (st *mockState) FirewallRule(service state.WellKnownServiceType) (*state.FirewallRule, error) {
 	r, ok := st.firewallRules[service]
 	if !ok {
@@ -4,10 +4,12 @@
 package firewaller
 
 import (
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v2-unstable"
 
 	"github.com/juju/juju/apiserver/common/firewall"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
 
@@ -25,16 +27,27 @@ type State interface {
 	FindEntity(tag names.Tag) (state.Entity, error)
 
 	FirewallRule(service state.WellKnownServiceType) (*state.FirewallRule, error)
+
+	SpaceSubnetCIDRs(spaceName string) ([]string, error)
+
+	WatchSubnetSpaces() state.NotifyWatcher
+
+	APIHostPortsForAgents() ([][]network.HostPort, error)
+
+	EgressMachine(id string) (firewall.EgressMachine, error)
+
+	WatchEgressRules() state.NotifyWatcher
 }
 
 // TODO(wallyworld) - for tests, remove when remaining firewaller tests become unit tests.
 func StateShim(st *state.State, m *state.Model) stateShim {
-	return stateShim{st: st, State: firewall.StateShim(st, m)}
+	return stateShim{st: st, m: m, State: firewall.StateShim(st, m)}
 }
 
 type stateShim struct {
 	firewall.State
 	st *state.State
+	m  *state.Model
 }
 
 func (st stateShim) ModelUUID() string {
@@ -58,3 +71,35 @@ func (s stateShim) FirewallRule(service state.WellKnownServiceType) (*state.Fire
 	api := state.NewFirewallRules(s.st)
 	return api.Rule(service)
 }
+
+func (st stateShim) SpaceSubnetCIDRs(spaceName string) ([]string, error) {
+	space, err := st.st.Space(spaceName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	subnets, err := space.Subnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cidrs := make([]string, len(subnets))
+	for i, subnet := range subnets {
+		cidrs[i] = subnet.CIDR()
+	}
+	return cidrs, nil
+}
+
+func (st stateShim) WatchSubnetSpaces() state.NotifyWatcher {
+	return st.st.WatchSubnetSpaces()
+}
+
+func (st stateShim) APIHostPortsForAgents() ([][]network.HostPort, error) {
+	return st.st.APIHostPortsForAgents()
+}
+
+func (st stateShim) EgressMachine(id string) (firewall.EgressMachine, error) {
+	return st.st.Machine(id)
+}
+
+func (st stateShim) WatchEgressRules() state.NotifyWatcher {
+	return firewall.EgressStateShim(st.st, st.m).WatchEgressRules()
+}
@@ -213,6 +213,11 @@ func (a *InstancePollerAPI) SetInstanceStatus(args params.SetStatus) (params.Err
 				Data:    arg.Data,
 				Since:   &now,
 			}
+			if s.Status == status.Interrupted {
+				err = a.setInterrupted(machine, s)
+				result.Results[i].Error = common.ServerError(err)
+				continue
+			}
 			err = machine.SetInstanceStatus(s)
 			if status.Status(arg.Status) == status.ProvisioningError {
 				s.Status = status.Error
@@ -226,6 +231,45 @@ func (a *InstancePollerAPI) SetInstanceStatus(args params.SetStatus) (params.Err
 	return result, nil
 }
 
+// setInterrupted records that the machine's instance has been
+// interrupted by the cloud, stopping the machine and, if the model is
+// configured to do so, replacing it. The instance status is only set
+// to interrupted once the machine has been stopped and replaced, so
+// that a failure is retried when the interruption is next reported.
+// Controller machines cannot be replaced, so for them only the
+// instance status is set.
+func (a *InstancePollerAPI) setInterrupted(machine StateMachine, s status.StatusInfo) error {
+	previous, err := machine.InstanceStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if previous.Status == status.Interrupted {
+		return errors.Trace(machine.SetInstanceStatus(s))
+	}
+	if machine.IsManager() {
+		return errors.Trace(machine.SetInstanceStatus(s))
+	}
+	machineStatus := s
+	machineStatus.Status = status.Stopped
+	machineStatus.Message = "instance interrupted"
+	if s.Message != "" {
+		machineStatus.Message += ": " + s.Message
+	}
+	if err := machine.SetStatus(machineStatus); err != nil {
+		return errors.Trace(err)
+	}
+	cfg, err := a.st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.ReplaceInterruptedMachines() {
+		if err := machine.Replace(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(machine.SetInstanceStatus(s))
+}
+
 // AreManuallyProvisioned returns whether each given entity is
 // manually provisioned or not. Only machine tags are accepted.
 func (a *InstancePollerAPI) AreManuallyProvisioned(args params.Entities) (params.BoolResults, error) {
@@ -657,6 +657,127 @@ func (s *InstancePollerSuite) TestSetInstanceStatusFailure(c *gc.C) {
 	s.st.CheckFindEntityCall(c, 3, "3")
 }
 
+func (s *InstancePollerSuite) TestSetInstanceStatusInterrupted(c *gc.C) {
+	s.st.SetConfig(c, coretesting.ModelConfig(c))
+	s.st.SetMachineInfo(c, machineInfo{id: "1", instanceStatus: statusInfo("running")})
+
+	result, err := s.api.SetInstanceStatus(params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "machine-1", Status: "interrupted", Info: "preempted"},
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}}})
+
+	now := s.clock.Now()
+	s.st.CheckCallNames(c, "FindEntity", "InstanceStatus", "IsManager", "SetStatus", "ModelConfig", "SetInstanceStatus")
+	s.st.CheckCall(c, 3, "SetStatus", status.StatusInfo{
+		Status: status.Stopped, Message: "instance interrupted: preempted", Since: &now,
+	})
+	s.st.CheckCall(c, 5, "SetInstanceStatus", status.StatusInfo{
+		Status: status.Interrupted, Message: "preempted", Since: &now,
+	})
+
+	// Reporting the interruption again does not stop the machine again.
+	s.st.ResetCalls()
+	result, err = s.api.SetInstanceStatus(params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "machine-1", Status: "interrupted", Info: "preempted"},
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}}})
+	s.st.CheckCallNames(c, "FindEntity", "InstanceStatus", "SetInstanceStatus")
+}
+
+func (s *InstancePollerSuite) TestSetInstanceStatusInterruptedReplace(c *gc.C) {
+	modelConfig, err := coretesting.ModelConfig(c).Apply(map[string]interface{}{
+		"replace-interrupted-machines": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.SetConfig(c, modelConfig)
+	s.st.SetMachineInfo(c, machineInfo{id: "1", instanceStatus: statusInfo("running")})
+
+	result, err := s.api.SetInstanceStatus(params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "machine-1", Status: "interrupted"},
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}}})
+
+	now := s.clock.Now()
+	s.st.CheckCallNames(c, "FindEntity", "InstanceStatus", "IsManager", "SetStatus", "ModelConfig", "Replace", "SetInstanceStatus")
+	s.st.CheckCall(c, 3, "SetStatus", status.StatusInfo{
+		Status: status.Stopped, Message: "instance interrupted", Since: &now,
+	})
+}
+
+func (s *InstancePollerSuite) TestSetInstanceStatusInterruptedReplaceFailure(c *gc.C) {
+	modelConfig, err := coretesting.ModelConfig(c).Apply(map[string]interface{}{
+		"replace-interrupted-machines": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.SetConfig(c, modelConfig)
+	s.st.SetMachineInfo(c, machineInfo{id: "1", instanceStatus: statusInfo("running")})
+	s.st.SetErrors(
+		nil,                // FindEntity("1")
+		nil,                // InstanceStatus()
+		nil,                // IsManager()
+		nil,                // SetStatus()
+		nil,                // ModelConfig()
+		errors.New("boom"), // Replace()
+	)
+
+	result, err := s.api.SetInstanceStatus(params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "machine-1", Status: "interrupted"},
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{Error: apiservertesting.ServerError("boom")},
+	}})
+	s.st.CheckCallNames(c, "FindEntity", "InstanceStatus", "IsManager", "SetStatus", "ModelConfig", "Replace")
+
+	// The interruption is not recorded, so the replacement
+	// is retried when it is next reported.
+	s.st.ResetCalls()
+	result, err = s.api.SetInstanceStatus(params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "machine-1", Status: "interrupted"},
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}}})
+	s.st.CheckCallNames(c, "FindEntity", "InstanceStatus", "IsManager", "SetStatus", "ModelConfig", "Replace", "SetInstanceStatus")
+}
+
+func (s *InstancePollerSuite) TestSetInstanceStatusInterruptedController(c *gc.C) {
+	modelConfig, err := coretesting.ModelConfig(c).Apply(map[string]interface{}{
+		"replace-interrupted-machines": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.SetConfig(c, modelConfig)
+	s.st.SetMachineInfo(c, machineInfo{id: "1", instanceStatus: statusInfo("running"), isManager: true})
+
+	result, err := s.api.SetInstanceStatus(params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "machine-1", Status: "interrupted", Info: "preempted"},
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}}})
+
+	// Controller machines can't be replaced, so the machine
+	// is neither stopped nor replaced.
+	now := s.clock.Now()
+	s.st.CheckCallNames(c, "FindEntity", "InstanceStatus", "IsManager", "SetInstanceStatus")
+	s.st.CheckCall(c, 3, "SetInstanceStatus", status.StatusInfo{
+		Status: status.Interrupted, Message: "preempted", Since: &now,
+	})
+}
+
 func (s *InstancePollerSuite) TestAreManuallyProvisionedSuccess(c *gc.C) {
 	s.st.SetMachineInfo(c, machineInfo{id: "1", isManual: true})
 	s.st.SetMachineInfo(c, machineInfo{id: "2", isManual: false})
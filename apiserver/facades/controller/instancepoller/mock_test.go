@@ -212,6 +212,7 @@ type machineInfo struct {
 	providerAddresses []network.Address
 	life              state.Life
 	isManual          bool
+	isManager         bool
 }
 
 type mockMachine struct {
@@ -317,6 +318,38 @@ func (m *mockMachine) Status() (status.StatusInfo, error) {
 	return m.status, m.NextErr()
 }
 
+// SetStatus implements StateMachine.
+func (m *mockMachine) SetStatus(machineStatus status.StatusInfo) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "SetStatus", machineStatus)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.status = machineStatus
+	return nil
+}
+
+// IsManager implements StateMachine.
+func (m *mockMachine) IsManager() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "IsManager")
+	m.NextErr() // consume the unused error
+	return m.isManager
+}
+
+// Replace implements StateMachine.
+func (m *mockMachine) Replace() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "Replace")
+	return m.NextErr()
+}
+
 type mockBaseWatcher struct {
 	err error
 
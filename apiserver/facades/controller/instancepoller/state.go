@@ -26,6 +26,8 @@ type StateMachine interface {
 	Life() state.Life
 	Status() (status.StatusInfo, error)
 	IsManual() (bool, error)
+	IsManager() bool
+	Replace() error
 }
 
 type StateInterface interface {
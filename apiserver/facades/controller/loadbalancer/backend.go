@@ -0,0 +1,99 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package loadbalancer
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+// Backend defines the methods the load balancer facade needs from
+// state.State.
+type Backend interface {
+	// AllApplications returns all of the model's applications.
+	AllApplications() ([]Application, error)
+
+	// Application returns the application with the given name.
+	Application(name string) (Application, error)
+
+	// Machine returns the machine with the given id, so that we can
+	// find the instance its units run on.
+	Machine(id string) (Machine, error)
+}
+
+// Application defines the methods we need from state.Application.
+type Application interface {
+	Name() string
+	Life() state.Life
+	IsExposed() bool
+	AllUnits() ([]Unit, error)
+	UpdateCloudService(providerId string, addresses []network.Address) error
+}
+
+// Unit defines the methods we need from state.Unit.
+type Unit interface {
+	Name() string
+	Life() state.Life
+	AssignedMachineId() (string, error)
+	OpenedPorts() ([]network.PortRange, error)
+	Status() (status.StatusInfo, error)
+	AgentStatus() (status.StatusInfo, error)
+}
+
+// Machine defines the methods we need from state.Machine.
+type Machine interface {
+	InstanceId() (instance.Id, error)
+}
+
+type backendShim struct {
+	st *state.State
+}
+
+// AllApplications implements Backend.
+func (b *backendShim) AllApplications() ([]Application, error) {
+	apps, err := b.st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(apps))
+	for i, app := range apps {
+		result[i] = &applicationShim{app}
+	}
+	return result, nil
+}
+
+// Application implements Backend.
+func (b *backendShim) Application(name string) (Application, error) {
+	app, err := b.st.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &applicationShim{app}, nil
+}
+
+// Machine implements Backend.
+func (b *backendShim) Machine(id string) (Machine, error) {
+	return b.st.Machine(id)
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+// AllUnits implements Application.
+func (a *applicationShim) AllUnits() ([]Unit, error) {
+	units, err := a.Application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Unit, len(units))
+	for i, unit := range units {
+		result[i] = unit
+	}
+	return result, nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package loadbalancer provides the API used by the load balancer
+// worker to find out which applications should be put behind a cloud
+// load balancer, and to record the load balancers' addresses.
+package loadbalancer
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+// API implements the API facade used by the load balancer worker.
+type API struct {
+	backend Backend
+}
+
+// NewAPI returns a new load balancer API facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, errors.Trace(common.ErrPerm)
+	}
+	return &API{backend: backend}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(&backendShim{st}, res, auth)
+}
+
+// ApplicationTargets returns, for each alive exposed application, the
+// ports opened by its units and the instances of its healthy units.
+// Units in containers are not reachable by a cloud load balancer, and
+// so are left out.
+func (api *API) ApplicationTargets() (params.LoadBalancerTargetsResult, error) {
+	apps, err := api.backend.AllApplications()
+	if err != nil {
+		return params.LoadBalancerTargetsResult{}, errors.Trace(err)
+	}
+	targets := []params.LoadBalancerTarget{}
+	for _, app := range apps {
+		if app.Life() != state.Alive || !app.IsExposed() {
+			continue
+		}
+		target, err := api.applicationTarget(app)
+		if err != nil {
+			return params.LoadBalancerTargetsResult{}, errors.Annotatef(err, "application %q", app.Name())
+		}
+		targets = append(targets, target)
+	}
+	return params.LoadBalancerTargetsResult{Targets: targets}, nil
+}
+
+func (api *API) applicationTarget(app Application) (params.LoadBalancerTarget, error) {
+	target := params.LoadBalancerTarget{
+		ApplicationTag: names.NewApplicationTag(app.Name()).String(),
+		Ports:          []params.PortRange{},
+		InstanceIds:    []string{},
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return target, errors.Trace(err)
+	}
+	ports := make(map[network.PortRange]bool)
+	instances := make(map[string]bool)
+	for _, unit := range units {
+		if unit.Life() != state.Alive {
+			continue
+		}
+		unitPorts, err := unit.OpenedPorts()
+		if err != nil {
+			return target, errors.Annotatef(err, "unit %q", unit.Name())
+		}
+		for _, pr := range unitPorts {
+			ports[pr] = true
+		}
+		instId, err := api.healthyUnitInstance(unit)
+		if err != nil {
+			return target, errors.Annotatef(err, "unit %q", unit.Name())
+		}
+		if instId != "" {
+			instances[instId] = true
+		}
+	}
+	portRanges := make([]network.PortRange, 0, len(ports))
+	for pr := range ports {
+		portRanges = append(portRanges, pr)
+	}
+	network.SortPortRanges(portRanges)
+	for _, pr := range portRanges {
+		target.Ports = append(target.Ports, params.FromNetworkPortRange(pr))
+	}
+	for instId := range instances {
+		target.InstanceIds = append(target.InstanceIds, instId)
+	}
+	sort.Strings(target.InstanceIds)
+	return target, nil
+}
+
+// healthyUnitInstance returns the instance id of the machine hosting
+// the unit, if the unit is healthy and its machine is a provisioned
+// cloud instance; otherwise it returns "".
+func (api *API) healthyUnitInstance(unit Unit) (string, error) {
+	agentStatus, err := unit.AgentStatus()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if agentStatus.Status == status.Error {
+		return "", nil
+	}
+	workloadStatus, err := unit.Status()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if workloadStatus.Status != status.Active && workloadStatus.Status != status.Unknown {
+		return "", nil
+	}
+	machineId, err := unit.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	if names.IsContainerMachine(machineId) {
+		return "", nil
+	}
+	machine, err := api.backend.Machine(machineId)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	instId, err := machine.InstanceId()
+	if errors.IsNotProvisioned(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(instId), nil
+}
+
+// SetApplicationAddresses records the provider id and addresses of the
+// load balancers in front of the given applications. The addresses are
+// reported as the applications' public addresses in status.
+func (api *API) SetApplicationAddresses(args params.SetLoadBalancerAddressesArgs) (params.ErrorResults, error) {
+	results := make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		results[i].Error = common.ServerError(api.setApplicationAddresses(arg))
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (api *API) setApplicationAddresses(arg params.SetLoadBalancerAddresses) error {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return app.UpdateCloudService(arg.ProviderId, params.NetworkAddresses(arg.Addresses...))
+}
@@ -0,0 +1,234 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package loadbalancer_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/controller/loadbalancer"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type loadBalancerSuite struct {
+	testing.IsolationSuite
+
+	backend *mockBackend
+	api     *loadbalancer.API
+}
+
+var _ = gc.Suite(&loadBalancerSuite{})
+
+func (s *loadBalancerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		machines: map[string]*mockMachine{
+			"0": {instanceId: "inst-0"},
+			"1": {instanceId: "inst-1"},
+			"2": {instanceId: "inst-2"},
+			"3": {},
+		},
+	}
+	api, err := loadbalancer.NewAPI(s.backend, nil, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *loadBalancerSuite) TestRequiresController(c *gc.C) {
+	_, err := loadbalancer.NewAPI(s.backend, nil, apiservertesting.FakeAuthorizer{Controller: false})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *loadBalancerSuite) TestApplicationTargets(c *gc.C) {
+	s.backend.apps = []*mockApplication{{
+		name:    "wordpress",
+		exposed: true,
+		units: []*mockUnit{
+			newMockUnit("wordpress/0", "0", status.Active, network.MustParsePortRange("80/tcp")),
+			newMockUnit("wordpress/1", "1", status.Unknown, network.MustParsePortRange("443/tcp")),
+			newMockUnit("wordpress/2", "2", status.Blocked, network.MustParsePortRange("80/tcp")),
+			newMockUnit("wordpress/3", "3", status.Active),
+			newMockUnit("wordpress/4", "0/lxd/0", status.Active),
+		},
+	}, {
+		name: "mysql",
+		units: []*mockUnit{
+			newMockUnit("mysql/0", "0", status.Active, network.MustParsePortRange("3306/tcp")),
+		},
+	}, {
+		name:    "dying",
+		exposed: true,
+		life:    state.Dying,
+	}}
+	s.backend.apps[0].units[1].agentStatus = status.Error
+
+	result, err := s.api.ApplicationTargets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.LoadBalancerTargetsResult{
+		Targets: []params.LoadBalancerTarget{{
+			ApplicationTag: "application-wordpress",
+			Ports: []params.PortRange{
+				{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+				{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+			},
+			InstanceIds: []string{"inst-0"},
+		}},
+	})
+}
+
+func (s *loadBalancerSuite) TestApplicationTargetsError(c *gc.C) {
+	s.backend.apps = []*mockApplication{{name: "wordpress", exposed: true}}
+	s.backend.apps[0].SetErrors(errors.New("boom"))
+	_, err := s.api.ApplicationTargets()
+	c.Assert(err, gc.ErrorMatches, `application "wordpress": boom`)
+}
+
+func (s *loadBalancerSuite) TestSetApplicationAddresses(c *gc.C) {
+	s.backend.apps = []*mockApplication{{name: "wordpress"}}
+	addrs := []network.Address{network.NewScopedAddress("203.0.113.10", network.ScopePublic)}
+	result, err := s.api.SetApplicationAddresses(params.SetLoadBalancerAddressesArgs{
+		Args: []params.SetLoadBalancerAddresses{{
+			ApplicationTag: "application-wordpress",
+			ProviderId:     "lb-wordpress",
+			Addresses:      params.FromNetworkAddresses(addrs...),
+		}, {
+			ApplicationTag: "application-mysql",
+		}, {
+			ApplicationTag: "unit-mysql-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `application "mysql" not found`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"unit-mysql-0" is not a valid application tag`)
+	s.backend.apps[0].CheckCall(c, 0, "UpdateCloudService", "lb-wordpress", addrs)
+}
+
+type mockBackend struct {
+	apps     []*mockApplication
+	machines map[string]*mockMachine
+}
+
+func (b *mockBackend) AllApplications() ([]loadbalancer.Application, error) {
+	result := make([]loadbalancer.Application, len(b.apps))
+	for i, app := range b.apps {
+		result[i] = app
+	}
+	return result, nil
+}
+
+func (b *mockBackend) Application(name string) (loadbalancer.Application, error) {
+	for _, app := range b.apps {
+		if app.name == name {
+			return app, nil
+		}
+	}
+	return nil, errors.NotFoundf("application %q", name)
+}
+
+func (b *mockBackend) Machine(id string) (loadbalancer.Machine, error) {
+	m, ok := b.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %q", id)
+	}
+	return m, nil
+}
+
+type mockApplication struct {
+	testing.Stub
+	name    string
+	life    state.Life
+	exposed bool
+	units   []*mockUnit
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) Life() state.Life {
+	return a.life
+}
+
+func (a *mockApplication) IsExposed() bool {
+	return a.exposed
+}
+
+func (a *mockApplication) AllUnits() ([]loadbalancer.Unit, error) {
+	a.MethodCall(a, "AllUnits")
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	result := make([]loadbalancer.Unit, len(a.units))
+	for i, unit := range a.units {
+		result[i] = unit
+	}
+	return result, nil
+}
+
+func (a *mockApplication) UpdateCloudService(providerId string, addresses []network.Address) error {
+	a.MethodCall(a, "UpdateCloudService", providerId, addresses)
+	return a.NextErr()
+}
+
+type mockUnit struct {
+	name           string
+	machineId      string
+	ports          []network.PortRange
+	workloadStatus status.Status
+	agentStatus    status.Status
+}
+
+func newMockUnit(name, machineId string, workloadStatus status.Status, ports ...network.PortRange) *mockUnit {
+	return &mockUnit{
+		name:           name,
+		machineId:      machineId,
+		ports:          ports,
+		workloadStatus: workloadStatus,
+		agentStatus:    status.Idle,
+	}
+}
+
+func (u *mockUnit) Name() string {
+	return u.name
+}
+
+func (u *mockUnit) Life() state.Life {
+	return state.Alive
+}
+
+func (u *mockUnit) AssignedMachineId() (string, error) {
+	return u.machineId, nil
+}
+
+func (u *mockUnit) OpenedPorts() ([]network.PortRange, error) {
+	return u.ports, nil
+}
+
+func (u *mockUnit) Status() (status.StatusInfo, error) {
+	return status.StatusInfo{Status: u.workloadStatus}, nil
+}
+
+func (u *mockUnit) AgentStatus() (status.StatusInfo, error) {
+	return status.StatusInfo{Status: u.agentStatus}, nil
+}
+
+type mockMachine struct {
+	instanceId instance.Id
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instanceId == "" {
+		return "", errors.NotProvisionedf("machine")
+	}
+	return m.instanceId, nil
+}
@@ -22,6 +22,7 @@ type Facade struct {
 	providers     ProviderRegistry
 	entityWatcher EntityWatcher
 	statusSetter  StatusSetter
+	statusGetter  StatusGetter
 }
 
 // EntityWatcher is an interface that provides a means of watching
@@ -42,6 +43,12 @@ type StatusSetter interface {
 	SetStatus(params.SetStatus) (params.ErrorResults, error)
 }
 
+// StatusGetter is an interface that provides a means of getting
+// the status of entities.
+type StatusGetter interface {
+	Status(params.Entities) (params.StatusResults, error)
+}
+
 // NewStateFacade provides the signature required for facade registration.
 func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	pool := NewPool(ctx.StatePool())
@@ -55,7 +62,11 @@ func NewStateFacade(ctx facade.Context) (*Facade, error) {
 		ctx.State(),
 		common.AuthFuncForTagKind(names.ModelTagKind),
 	)
-	return NewFacade(ctx.State(), pool, registry, watcher, statusSetter, ctx.Auth())
+	statusGetter := common.NewStatusGetter(
+		ctx.State(),
+		common.AuthFuncForTagKind(names.ModelTagKind),
+	)
+	return NewFacade(ctx.State(), pool, registry, watcher, statusSetter, statusGetter, ctx.Auth())
 }
 
 // NewFacade returns a new Facade using the given Backend and Authorizer.
@@ -65,6 +76,7 @@ func NewFacade(
 	providers ProviderRegistry,
 	entityWatcher EntityWatcher,
 	statusSetter StatusSetter,
+	statusGetter StatusGetter,
 	auth facade.Authorizer,
 ) (*Facade, error) {
 	if !auth.AuthController() {
@@ -76,6 +88,7 @@ func NewFacade(
 		providers:     providers,
 		entityWatcher: entityWatcher,
 		statusSetter:  statusSetter,
+		statusGetter:  statusGetter,
 	}, nil
 }
 
@@ -189,3 +202,8 @@ func (f *Facade) WatchModelEnvironVersion(args params.Entities) (params.NotifyWa
 func (f *Facade) SetModelStatus(args params.SetStatus) (params.ErrorResults, error) {
 	return f.statusSetter.SetStatus(args)
 }
+
+// ModelStatus returns the status of each given model.
+func (f *Facade) ModelStatus(args params.Entities) (params.StatusResults, error) {
+	return f.statusGetter.Status(args)
+}
@@ -30,6 +30,7 @@ type ModelUpgraderSuite struct {
 	providers    mockProviderRegistry
 	watcher      mockWatcher
 	statusSetter mockStatusSetter
+	statusGetter mockStatusGetter
 	authorizer   apiservertesting.FakeAuthorizer
 }
 
@@ -60,22 +61,23 @@ func (s *ModelUpgraderSuite) SetUpTest(c *gc.C) {
 	}
 	s.watcher = mockWatcher{}
 	s.statusSetter = mockStatusSetter{}
+	s.statusGetter = mockStatusGetter{}
 }
 
 func (s *ModelUpgraderSuite) TestAuthController(c *gc.C) {
-	_, err := modelupgrader.NewFacade(&s.backend, &s.pool, &s.providers, &s.watcher, &s.statusSetter, &s.authorizer)
+	_, err := modelupgrader.NewFacade(&s.backend, &s.pool, &s.providers, &s.watcher, &s.statusSetter, &s.statusGetter, &s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
 func (s *ModelUpgraderSuite) TestAuthNonController(c *gc.C) {
 	s.authorizer.Controller = false
 	s.authorizer.Tag = names.NewUserTag("admin")
-	_, err := modelupgrader.NewFacade(&s.backend, &s.pool, &s.providers, &s.watcher, &s.statusSetter, &s.authorizer)
+	_, err := modelupgrader.NewFacade(&s.backend, &s.pool, &s.providers, &s.watcher, &s.statusSetter, &s.statusGetter, &s.authorizer)
 	c.Assert(err, gc.Equals, common.ErrPerm)
 }
 
 func (s *ModelUpgraderSuite) TestModelEnvironVersion(c *gc.C) {
-	facade, err := modelupgrader.NewFacade(&s.backend, &s.pool, &s.providers, &s.watcher, &s.statusSetter, &s.authorizer)
+	facade, err := modelupgrader.NewFacade(&s.backend, &s.pool, &s.providers, &s.watcher, &s.statusSetter, &s.statusGetter, &s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 	results, err := facade.ModelEnvironVersion(params.Entities{
 		Entities: []params.Entity{
@@ -104,7 +106,7 @@ func (s *ModelUpgraderSuite) TestModelEnvironVersion(c *gc.C) {
 
 func (s *ModelUpgraderSuite) TestModelTargetEnvironVersion(c *gc.C) {
 	s.providers.SetErrors(nil, errors.New("blargh"))
-	facade, err := modelupgrader.NewFacade(&s.backend, &s.pool, &s.providers, &s.watcher, &s.statusSetter, &s.authorizer)
+	facade, err := modelupgrader.NewFacade(&s.backend, &s.pool, &s.providers, &s.watcher, &s.statusSetter, &s.statusGetter, &s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 	results, err := facade.ModelTargetEnvironVersion(params.Entities{
 		Entities: []params.Entity{
@@ -141,7 +143,7 @@ func (s *ModelUpgraderSuite) TestModelTargetEnvironVersion(c *gc.C) {
 }
 
 func (s *ModelUpgraderSuite) TestSetModelEnvironVersion(c *gc.C) {
-	facade, err := modelupgrader.NewFacade(&s.backend, &s.pool, &s.providers, &s.watcher, &s.statusSetter, &s.authorizer)
+	facade, err := modelupgrader.NewFacade(&s.backend, &s.pool, &s.providers, &s.watcher, &s.statusSetter, &s.statusGetter, &s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 	results, err := facade.SetModelEnvironVersion(params.SetModelEnvironVersions{
 		Models: []params.SetModelEnvironVersion{
@@ -181,7 +183,7 @@ func (s *ModelUpgraderSuite) TestSetModelStatus(c *gc.C) {
 		},
 	}
 
-	facade, err := modelupgrader.NewFacade(&s.backend, &s.pool, &s.providers, &s.watcher, &s.statusSetter, &s.authorizer)
+	facade, err := modelupgrader.NewFacade(&s.backend, &s.pool, &s.providers, &s.watcher, &s.statusSetter, &s.statusGetter, &s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 	results, err := facade.SetModelStatus(args)
 	c.Assert(err, jc.ErrorIsNil)
@@ -193,6 +195,29 @@ func (s *ModelUpgraderSuite) TestSetModelStatus(c *gc.C) {
 	})
 }
 
+func (s *ModelUpgraderSuite) TestModelStatus(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: modelTag1.String()}},
+	}
+	s.statusGetter.results = params.StatusResults{
+		Results: []params.StatusResult{{
+			Status: "error",
+			Info:   "cloud credential is not valid",
+		}},
+	}
+
+	facade, err := modelupgrader.NewFacade(&s.backend, &s.pool, &s.providers, &s.watcher, &s.statusSetter, &s.statusGetter, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := facade.ModelStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, s.statusGetter.results)
+	s.backend.CheckNoCalls(c)
+	s.statusSetter.CheckNoCalls(c)
+	s.statusGetter.CheckCalls(c, []testing.StubCall{
+		{"Status", []interface{}{args}},
+	})
+}
+
 type mockBackend struct {
 	testing.Stub
 	clouds map[string]cloud.Cloud
@@ -282,3 +307,13 @@ func (m *mockStatusSetter) SetStatus(args params.SetStatus) (params.ErrorResults
 	m.MethodCall(m, "SetStatus", args)
 	return m.results, m.NextErr()
 }
+
+type mockStatusGetter struct {
+	testing.Stub
+	results params.StatusResults
+}
+
+func (m *mockStatusGetter) Status(args params.Entities) (params.StatusResults, error) {
+	m.MethodCall(m, "Status", args)
+	return m.results, m.NextErr()
+}
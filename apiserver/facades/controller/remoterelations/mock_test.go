@@ -198,6 +198,28 @@ func (st *mockState) WatchRemoteApplicationRelations(applicationName string) (st
 	return w, nil
 }
 
+func (st *mockState) RemoteApplicationRelationKeys(applicationName string) ([]string, error) {
+	st.MethodCall(st, "RemoteApplicationRelationKeys", applicationName)
+	if _, ok := st.remoteApplications[applicationName]; !ok {
+		return nil, errors.NotFoundf("remote application %q", applicationName)
+	}
+	var keys []string
+	for key, rel := range st.relations {
+		for _, ep := range rel.endpoints {
+			if ep.ApplicationName == applicationName {
+				keys = append(keys, key)
+				break
+			}
+		}
+	}
+	return keys, nil
+}
+
+func (st *mockState) UpdateControllerForModel(controller crossmodel.ControllerInfo, modelUUID string) error {
+	st.MethodCall(st, "UpdateControllerForModel", controller, modelUUID)
+	return st.NextErr()
+}
+
 func (st *mockState) WatchRemoteRelations() state.StringsWatcher {
 	st.MethodCall(st, "WatchRemoteRelations")
 	return st.remoteRelationsWatcher
@@ -227,6 +249,7 @@ type mockRelation struct {
 	remoteUnits           map[string]common.RelationUnit
 	endpoints             []state.Endpoint
 	endpointUnitsWatchers map[string]*mockRelationUnitsWatcher
+	health                []state.RemoteRelationHealthUpdate
 }
 
 func newMockRelation(id int) *mockRelation {
@@ -283,6 +306,12 @@ func (r *mockRelation) RemoteUnit(unitId string) (common.RelationUnit, error) {
 	return u, nil
 }
 
+func (r *mockRelation) UpdateRemoteHealth(update state.RemoteRelationHealthUpdate) error {
+	r.MethodCall(r, "UpdateRemoteHealth", update)
+	r.health = append(r.health, update)
+	return r.NextErr()
+}
+
 func (r *mockRelation) Endpoints() []state.Endpoint {
 	r.MethodCall(r, "Endpoints")
 	return r.endpoints
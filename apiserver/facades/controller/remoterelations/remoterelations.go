@@ -11,6 +11,7 @@ import (
 	commoncrossmodel "github.com/juju/juju/apiserver/common/crossmodel"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state/watcher"
 )
@@ -23,6 +24,19 @@ type RemoteRelationsAPI struct {
 	authorizer facade.Authorizer
 }
 
+// RemoteRelationsAPIV2 provides access to version 2 of the RemoteRelations
+// API facade, which adds recording the health of cross-model relations.
+type RemoteRelationsAPIV2 struct {
+	*RemoteRelationsAPI
+}
+
+// RemoteRelationsAPIV3 provides access to version 3 of the RemoteRelations
+// API facade, which adds updating the controllers hosting offering models
+// after those models have been migrated.
+type RemoteRelationsAPIV3 struct {
+	*RemoteRelationsAPIV2
+}
+
 // NewStateRemoteRelationsAPI creates a new server-side RemoteRelationsAPI facade
 // backed by global state.
 func NewStateRemoteRelationsAPI(ctx facade.Context) (*RemoteRelationsAPI, error) {
@@ -34,6 +48,26 @@ func NewStateRemoteRelationsAPI(ctx facade.Context) (*RemoteRelationsAPI, error)
 
 }
 
+// NewStateRemoteRelationsAPIV2 creates a new server-side RemoteRelationsAPIV2
+// facade backed by global state.
+func NewStateRemoteRelationsAPIV2(ctx facade.Context) (*RemoteRelationsAPIV2, error) {
+	api, err := NewStateRemoteRelationsAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &RemoteRelationsAPIV2{api}, nil
+}
+
+// NewStateRemoteRelationsAPIV3 creates a new server-side RemoteRelationsAPIV3
+// facade backed by global state.
+func NewStateRemoteRelationsAPIV3(ctx facade.Context) (*RemoteRelationsAPIV3, error) {
+	api, err := NewStateRemoteRelationsAPIV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &RemoteRelationsAPIV3{api}, nil
+}
+
 // NewRemoteRelationsAPI returns a new server-side RemoteRelationsAPI facade.
 func NewRemoteRelationsAPI(
 	st RemoteRelationsState,
@@ -419,3 +453,76 @@ func (f *RemoteRelationsAPI) SetRemoteApplicationsStatus(args params.SetStatus)
 	}
 	return result, nil
 }
+
+// SetRemoteRelationsHealth records the outcome of attempts to exchange
+// settings for cross-model relations with the offering model. An outcome
+// for a remote application applies to all of its relations.
+func (api *RemoteRelationsAPIV2) SetRemoteRelationsHealth(args params.RemoteRelationHealthArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.setRemoteRelationHealth(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *RemoteRelationsAPIV2) setRemoteRelationHealth(arg params.RemoteRelationHealthArg) error {
+	tag, err := names.ParseTag(arg.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var relationTags []names.Tag
+	switch tag := tag.(type) {
+	case names.RelationTag:
+		relationTags = []names.Tag{tag}
+	case names.ApplicationTag:
+		keys, err := api.st.RemoteApplicationRelationKeys(tag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, key := range keys {
+			relationTags = append(relationTags, names.NewRelationTag(key))
+		}
+	default:
+		return errors.NotValidf("tag %q", arg.Tag)
+	}
+	for _, relationTag := range relationTags {
+		err := commoncrossmodel.UpdateRelationHealth(api.st, relationTag, arg.Error, arg.ControllerReachable)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// UpdateControllersForModels records the controllers now hosting the
+// specified offering models, after those models have been migrated.
+func (api *RemoteRelationsAPIV3) UpdateControllersForModels(args params.UpdateControllersForModelsParams) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Changes)),
+	}
+	for i, change := range args.Changes {
+		err := api.updateControllerForModel(change)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *RemoteRelationsAPIV3) updateControllerForModel(change params.UpdateControllerForModel) error {
+	modelTag, err := names.ParseModelTag(change.ModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerTag, err := names.ParseControllerTag(change.Info.ControllerTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return api.st.UpdateControllerForModel(crossmodel.ControllerInfo{
+		ControllerTag: controllerTag,
+		Alias:         change.Info.Alias,
+		Addrs:         change.Info.Addrs,
+		CACert:        change.Info.CACert,
+	}, modelTag.Id())
+}
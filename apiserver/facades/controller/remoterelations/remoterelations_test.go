@@ -426,3 +426,79 @@ func (s *remoteRelationsSuite) TestSetRemoteApplicationsStatus(c *gc.C) {
 	c.Assert(remoteApp.status, gc.Equals, status.Blocked)
 	c.Assert(remoteApp.message, gc.Equals, "a message")
 }
+
+func (s *remoteRelationsSuite) TestSetRemoteRelationsHealth(c *gc.C) {
+	s.st.remoteApplications["db2"] = newMockRemoteApplication("db2", "url")
+	db2Rel := newMockRelation(1)
+	db2Rel.endpoints = []state.Endpoint{{ApplicationName: "db2"}, {ApplicationName: "django"}}
+	s.st.relations["db2:db django:db"] = db2Rel
+	otherRel := newMockRelation(2)
+	otherRel.endpoints = []state.Endpoint{{ApplicationName: "mysql"}, {ApplicationName: "wordpress"}}
+	s.st.relations["mysql:db wordpress:db"] = otherRel
+
+	api := &remoterelations.RemoteRelationsAPIV2{RemoteRelationsAPI: s.api}
+	result, err := api.SetRemoteRelationsHealth(params.RemoteRelationHealthArgs{
+		Args: []params.RemoteRelationHealthArg{{
+			Tag:                 names.NewRelationTag("mysql:db wordpress:db").String(),
+			ControllerReachable: true,
+		}, {
+			Tag:   names.NewApplicationTag("db2").String(),
+			Error: "connection refused",
+		}, {
+			Tag: names.NewApplicationTag("unknown").String(),
+		}, {
+			Tag: names.NewUnitTag("db2/0").String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `remote application "unknown" not found`)
+	c.Assert(result.Results[3].Error, gc.ErrorMatches, `tag "unit-db2-0" not valid`)
+
+	c.Assert(otherRel.health, gc.HasLen, 1)
+	c.Assert(otherRel.health[0].Error, gc.Equals, "")
+	c.Assert(otherRel.health[0].ControllerReachable, jc.IsTrue)
+	c.Assert(db2Rel.health, gc.HasLen, 1)
+	c.Assert(db2Rel.health[0].Error, gc.Equals, "connection refused")
+	c.Assert(db2Rel.health[0].ControllerReachable, jc.IsFalse)
+}
+
+func (s *remoteRelationsSuite) TestUpdateControllersForModels(c *gc.C) {
+	api := &remoterelations.RemoteRelationsAPIV3{
+		RemoteRelationsAPIV2: &remoterelations.RemoteRelationsAPIV2{RemoteRelationsAPI: s.api},
+	}
+	result, err := api.UpdateControllersForModels(params.UpdateControllersForModelsParams{
+		Changes: []params.UpdateControllerForModel{{
+			ModelTag: coretesting.ModelTag.String(),
+			Info: params.ExternalControllerInfo{
+				ControllerTag: coretesting.ControllerTag.String(),
+				Alias:         "target",
+				Addrs:         []string{"10.0.0.1:17070"},
+				CACert:        coretesting.CACert,
+			},
+		}, {
+			ModelTag: "bad-tag",
+		}, {
+			ModelTag: coretesting.ModelTag.String(),
+			Info:     params.ExternalControllerInfo{ControllerTag: "bad-tag"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `"bad-tag" is not a valid tag`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"bad-tag" is not a valid tag`)
+	s.st.CheckCalls(c, []testing.StubCall{
+		{"UpdateControllerForModel", []interface{}{
+			crossmodel.ControllerInfo{
+				ControllerTag: coretesting.ControllerTag,
+				Alias:         "target",
+				Addrs:         []string{"10.0.0.1:17070"},
+				CACert:        coretesting.CACert,
+			},
+			coretesting.ModelTag.Id(),
+		}},
+	})
+}
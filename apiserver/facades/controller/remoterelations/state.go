@@ -9,6 +9,7 @@ import (
 	"gopkg.in/macaroon.v2-unstable"
 
 	common "github.com/juju/juju/apiserver/common/crossmodel"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/state"
 )
 
@@ -38,6 +39,14 @@ type RemoteRelationsState interface {
 
 	// SaveMacaroon saves the given macaroon for the specified entity.
 	SaveMacaroon(entity names.Tag, mac *macaroon.Macaroon) error
+
+	// RemoteApplicationRelationKeys returns the keys of the relations
+	// involving the specified remote application.
+	RemoteApplicationRelationKeys(applicationName string) ([]string, error)
+
+	// UpdateControllerForModel records that the specified model is now
+	// hosted by the specified external controller.
+	UpdateControllerForModel(controller crossmodel.ControllerInfo, modelUUID string) error
 }
 
 // TODO - CAAS(ericclaudejones): This should contain state alone, model will be
@@ -77,3 +86,29 @@ func (st stateShim) WatchRemoteApplicationRelations(applicationName string) (sta
 	}
 	return a.WatchRelations(), nil
 }
+
+func (st stateShim) RemoteApplicationRelationKeys(applicationName string) ([]string, error) {
+	a, err := st.st.RemoteApplication(applicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relations, err := a.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	keys := make([]string, len(relations))
+	for i, relation := range relations {
+		keys[i] = relation.String()
+	}
+	return keys, nil
+}
+
+func (st stateShim) UpdateControllerForModel(controller crossmodel.ControllerInfo, modelUUID string) error {
+	if controller.ControllerTag == st.st.ControllerTag() {
+		// The model has moved to this controller, where it is
+		// found before any external controller is consulted.
+		return nil
+	}
+	ec := state.NewExternalControllers(st.st)
+	return ec.SaveAndMoveModels(controller, modelUUID)
+}
@@ -104,3 +104,34 @@ const (
 	GrantControllerAccess  ControllerAction = "grant"
 	RevokeControllerAccess ControllerAction = "revoke"
 )
+
+// FindOrphansArgs holds the arguments for Controller.FindOrphans.
+type FindOrphansArgs struct {
+	// Destroy is true if the orphaned resources should be
+	// destroyed, rather than just reported.
+	Destroy bool `json:"destroy"`
+}
+
+// OrphanedResource describes a cloud resource tagged as managed by the
+// controller, but which is no longer used by any model.
+type OrphanedResource struct {
+	Kind      string `json:"kind"`
+	Id        string `json:"id"`
+	ModelUUID string `json:"model-uuid,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+// CloudOrphans holds the orphaned resources found in a cloud region,
+// or an error if the region could not be checked.
+type CloudOrphans struct {
+	CloudTag  string             `json:"cloud-tag"`
+	Region    string             `json:"region,omitempty"`
+	Orphans   []OrphanedResource `json:"orphans,omitempty"`
+	Destroyed bool               `json:"destroyed"`
+	Error     *Error             `json:"error,omitempty"`
+}
+
+// FindOrphansResults holds the results of Controller.FindOrphans.
+type FindOrphansResults struct {
+	Results []CloudOrphans `json:"results"`
+}
@@ -4,6 +4,8 @@
 package params
 
 import (
+	"time"
+
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/macaroon.v2-unstable"
 )
@@ -570,6 +572,64 @@ type ConsumeOfferDetailsResults struct {
 	Results []ConsumeOfferDetailsResult `json:"results,omitempty"`
 }
 
+// OfferShareTokenArg holds the parameters for creating a share token
+// for an offer.
+type OfferShareTokenArg struct {
+	OfferURL string        `json:"offer-url"`
+	Expiry   time.Duration `json:"expiry"`
+}
+
+// OfferShareTokenArgs holds the parameters for creating share tokens
+// for a set of offers.
+type OfferShareTokenArgs struct {
+	Args []OfferShareTokenArg `json:"args"`
+}
+
+// OfferShareToken holds the details of an offer share token.
+type OfferShareToken struct {
+	Id        string    `json:"id"`
+	OfferURL  string    `json:"offer-url"`
+	CreatedBy string    `json:"created-by"`
+	Created   time.Time `json:"created"`
+	Expires   time.Time `json:"expires"`
+}
+
+// CreateOfferShareTokenResult holds a newly created share token and the
+// details needed to consume the offer with it, or an error.
+type CreateOfferShareTokenResult struct {
+	Token          *OfferShareToken     `json:"token,omitempty"`
+	ConsumeDetails *ConsumeOfferDetails `json:"consume-details,omitempty"`
+	Error          *Error               `json:"error,omitempty"`
+}
+
+// CreateOfferShareTokenResults holds the results of a
+// CreateOfferShareTokens call.
+type CreateOfferShareTokenResults struct {
+	Results []CreateOfferShareTokenResult `json:"results"`
+}
+
+// OfferShareTokensResult holds the share tokens for an offer, or an error.
+type OfferShareTokensResult struct {
+	Tokens []OfferShareToken `json:"tokens,omitempty"`
+	Error  *Error            `json:"error,omitempty"`
+}
+
+// OfferShareTokensResults holds the results of a ListOfferShareTokens call.
+type OfferShareTokensResults struct {
+	Results []OfferShareTokensResult `json:"results"`
+}
+
+// RevokeOfferShareTokenArg identifies a share token to revoke.
+type RevokeOfferShareTokenArg struct {
+	OfferURL string `json:"offer-url"`
+	Id       string `json:"id"`
+}
+
+// RevokeOfferShareTokenArgs holds the share tokens to revoke.
+type RevokeOfferShareTokenArgs struct {
+	Args []RevokeOfferShareTokenArg `json:"args"`
+}
+
 // RemoteEntities identifies multiple remote entities.
 type RemoteEntities struct {
 	Tokens []string `json:"tokens"`
@@ -627,3 +687,99 @@ type ExternalControllerInfo struct {
 	Addrs         []string `json:"addrs"`
 	CACert        string   `json:"ca-cert"`
 }
+
+// RemoteRelationHealthArgs holds the outcomes of attempts to exchange
+// settings for cross-model relations.
+type RemoteRelationHealthArgs struct {
+	Args []RemoteRelationHealthArg `json:"args"`
+}
+
+// RemoteRelationHealthArg holds the outcome of an attempt to exchange
+// settings for a cross-model relation. If the tag is that of a remote
+// application, the outcome applies to all of its relations.
+type RemoteRelationHealthArg struct {
+	Tag                 string `json:"tag"`
+	Error               string `json:"error,omitempty"`
+	ControllerReachable bool   `json:"controller-reachable"`
+}
+
+// RemoteRelationHealth describes how well settings are being exchanged
+// for a cross-model relation.
+type RemoteRelationHealth struct {
+	ControllerReachable bool       `json:"controller-reachable"`
+	LastExchange        *time.Time `json:"last-exchange,omitempty"`
+	LastError           string     `json:"last-error,omitempty"`
+	LastErrorTime       *time.Time `json:"last-error-time,omitempty"`
+	Updated             time.Time  `json:"updated"`
+}
+
+// OfferConnectionInfo describes a cross-model relation, as seen from
+// either the offering or the consuming model.
+type OfferConnectionInfo struct {
+	RelationId     int                   `json:"relation-id"`
+	Key            string                `json:"key"`
+	SourceModelTag string                `json:"source-model-tag,omitempty"`
+	Username       string                `json:"username,omitempty"`
+	Status         EntityStatus          `json:"status"`
+	Health         *RemoteRelationHealth `json:"health,omitempty"`
+}
+
+// OfferConnectionsInfo holds the cross-model relations of an offer
+// hosted in a model, or of a remote application consuming an offer.
+type OfferConnectionsInfo struct {
+	Name        string                `json:"name"`
+	OfferURL    string                `json:"offer-url,omitempty"`
+	Consumer    bool                  `json:"consumer"`
+	Connections []OfferConnectionInfo `json:"connections"`
+}
+
+// OfferConnectionsInfoResult holds the cross-model relations of an
+// offer or remote application, or an error.
+type OfferConnectionsInfoResult struct {
+	Result *OfferConnectionsInfo `json:"result,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// OfferConnectionsInfoResults holds the results of a call to
+// OfferConnectionsInfo.
+type OfferConnectionsInfoResults struct {
+	Results []OfferConnectionsInfoResult `json:"results"`
+}
+
+// OfferRedirectArgs holds the UUIDs of offers for which to find
+// where they have moved to.
+type OfferRedirectArgs struct {
+	OfferUUIDs []string `json:"offer-uuids"`
+}
+
+// OfferRedirect describes the controller now hosting an offer, after
+// the model hosting the offer was migrated to it.
+type OfferRedirect struct {
+	OfferUUID  string                 `json:"offer-uuid"`
+	ModelTag   string                 `json:"model-tag"`
+	Controller ExternalControllerInfo `json:"controller"`
+}
+
+// OfferRedirectResult holds where an offer has moved to, or an error.
+type OfferRedirectResult struct {
+	Result *OfferRedirect `json:"result,omitempty"`
+	Error  *Error         `json:"error,omitempty"`
+}
+
+// OfferRedirectResults holds the results of finding where offers have
+// moved to.
+type OfferRedirectResults struct {
+	Results []OfferRedirectResult `json:"results"`
+}
+
+// UpdateControllersForModelsParams holds the new controllers hosting
+// a set of models.
+type UpdateControllersForModelsParams struct {
+	Changes []UpdateControllerForModel `json:"changes"`
+}
+
+// UpdateControllerForModel holds the new controller hosting a model.
+type UpdateControllerForModel struct {
+	ModelTag string                 `json:"model-tag"`
+	Info     ExternalControllerInfo `json:"info"`
+}
@@ -0,0 +1,31 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// DNSRecordsResult holds the address records that should exist for
+// the model's exposed applications and their units, returned by
+// DNSRecords.Records.
+type DNSRecordsResult struct {
+	Records []DNSRecord `json:"records"`
+}
+
+// DNSRecord describes the addresses that a name in the model's DNS
+// domain should resolve to. Name is relative to the domain, e.g.
+// "wordpress" or "wordpress-0".
+type DNSRecord struct {
+	Name      string   `json:"name"`
+	Addresses []string `json:"addresses"`
+}
+
+// DNSConfigResult holds the model's DNS settings, returned by
+// DNSRecords.DNSConfig. The TSIG key is kept with the model rather
+// than in its config, so it is only handed to the controller.
+type DNSConfigResult struct {
+	ModelName     string `json:"model-name"`
+	Zone          string `json:"zone,omitempty"`
+	TTL           int    `json:"ttl"`
+	Backend       string `json:"backend"`
+	RFC2136Server string `json:"rfc2136-server,omitempty"`
+	TSIGKey       string `json:"tsig-key,omitempty"`
+}
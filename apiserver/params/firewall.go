@@ -27,6 +27,48 @@ type FirewallRule struct {
 	WhitelistCIDRS []string `json:"whitelist-cidrs,omitempty"`
 }
 
+// ExposeInfoResults holds the results of a GetExposeInfo call.
+type ExposeInfoResults struct {
+	Results []ExposeInfoResult `json:"results"`
+}
+
+// ExposeInfoResult describes whether an application is exposed and,
+// if so, the CIDRs its open ports may be reached from.
+type ExposeInfoResult struct {
+	Exposed      bool     `json:"exposed"`
+	IngressCIDRs []string `json:"ingress-cidrs,omitempty"`
+	Error        *Error   `json:"error,omitempty"`
+}
+
+// EgressRules describes the outbound traffic that a machine may send.
+type EgressRules struct {
+	// Restricted is true if outbound traffic is only allowed to the
+	// destination CIDRs.
+	Restricted bool `json:"restricted"`
+
+	// DestinationCIDRs are the sorted CIDRs that outbound traffic is
+	// allowed to, when restricted.
+	DestinationCIDRs []string `json:"destination-cidrs,omitempty"`
+}
+
+// EgressRulesResults holds the results of a call to get the egress
+// rules of machines.
+type EgressRulesResults struct {
+	Results []EgressRulesResult `json:"results"`
+}
+
+// EgressRulesResult holds the egress rules of a machine.
+type EgressRulesResult struct {
+	Rules EgressRules `json:"rules"`
+
+	// Enforce is true if the machine must enforce the rules itself,
+	// because the cloud cannot do so. It is only set by the
+	// EgressFirewall facade.
+	Enforce bool `json:"enforce,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
 // KnownServiceArgs holds the parameters for retrieving firewall rules.
 type KnownServiceArgs struct {
 	// KnownServices are the well known services for a firewall rule.
@@ -87,3 +87,47 @@ type CloudImageMetadataList struct {
 type MetadataImageIds struct {
 	Ids []string `json:"image-ids"`
 }
+
+// ImagePolicy holds a model's policy for choosing the images of
+// machines of a series, in one region or, if Region is empty, in all
+// regions.
+type ImagePolicy struct {
+	// Series is the OS series the policy applies to.
+	Series string `json:"series"`
+
+	// Region is the cloud region the policy applies to.
+	Region string `json:"region,omitempty"`
+
+	// ImageId is the image that is always used, if set.
+	ImageId string `json:"image-id,omitempty"`
+
+	// Arch is the architecture of the pinned image.
+	Arch string `json:"arch,omitempty"`
+
+	// Stream is the image stream images are taken from, if set.
+	Stream string `json:"stream,omitempty"`
+
+	// RequireSigned is true if only images from signed metadata
+	// may be used.
+	RequireSigned bool `json:"require-signed,omitempty"`
+
+	// PreferTags holds the image attributes that are preferred,
+	// keyed by "virt-type", "root-storage-type" or "source".
+	PreferTags map[string]string `json:"prefer-tags,omitempty"`
+}
+
+// ImagePolicies holds a list of image policies.
+type ImagePolicies struct {
+	Policies []ImagePolicy `json:"policies"`
+}
+
+// ImagePolicyKey identifies an image policy.
+type ImagePolicyKey struct {
+	Series string `json:"series"`
+	Region string `json:"region,omitempty"`
+}
+
+// ImagePolicyKeys holds a list of image policy keys.
+type ImagePolicyKeys struct {
+	Keys []ImagePolicyKey `json:"keys"`
+}
@@ -72,3 +72,28 @@ type InstanceType struct {
 	Deprecated   bool     `json:"deprecated,omitempty"`
 	Cost         int      `json:"cost,omitempty"`
 }
+
+// CloudQuotaResult holds the resource quota and estimated instance
+// costs of a model's cloud region.
+type CloudQuotaResult struct {
+	Cloud         string            `json:"cloud"`
+	Region        string            `json:"region,omitempty"`
+	Quota         []CloudQuotaLimit `json:"quota,omitempty"`
+	InstanceCosts []InstanceCost    `json:"instance-costs,omitempty"`
+	Error         *Error            `json:"error,omitempty"`
+}
+
+// CloudQuotaLimit holds the limit and usage of a quota-limited cloud
+// resource, such as "instances" or "cores".
+type CloudQuotaLimit struct {
+	Resource string `json:"resource"`
+	Limit    int    `json:"limit"`
+	Used     int    `json:"used"`
+}
+
+// InstanceCost holds the estimated hourly cost of an instance type.
+type InstanceCost struct {
+	InstanceType string  `json:"instance-type"`
+	HourlyCost   float64 `json:"hourly-cost"`
+	Currency     string  `json:"currency"`
+}
@@ -650,6 +650,33 @@ type RunParams struct {
 	Units        []string      `json:"units,omitempty"`
 }
 
+// CheckNetworkParams is used to have the units of related applications
+// probe each other's network connectivity.
+type CheckNetworkParams struct {
+	// Applications limits the check to the relations of these
+	// applications. All relations in the model are checked when it is
+	// empty.
+	Applications []string `json:"applications,omitempty"`
+
+	// RemoteModelUUID limits the check to the relations with remote
+	// applications from this model, when it is set.
+	RemoteModelUUID string `json:"remote-model-uuid,omitempty"`
+
+	// Timeout is how long each probe waits to connect.
+	Timeout time.Duration `json:"timeout"`
+}
+
+// CheckNetworkResults holds the actions queued to check the network
+// connectivity between related units.
+type CheckNetworkResults struct {
+	Results []ActionResult `json:"results,omitempty"`
+
+	// RemoteModelUUIDs holds the models of the remote applications in
+	// the checked relations. Their units are probed, but the check has
+	// to be run in those models for them to probe back.
+	RemoteModelUUIDs []string `json:"remote-model-uuids,omitempty"`
+}
+
 // RunResult contains the result from an individual run call on a machine.
 // UnitId is populated if the command was run inside the unit context.
 type RunResult struct {
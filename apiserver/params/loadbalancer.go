@@ -0,0 +1,40 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// LoadBalancerTargetsResult holds the applications that should be
+// behind a load balancer, returned by LoadBalancer.ApplicationTargets.
+type LoadBalancerTargetsResult struct {
+	// Targets holds one entry per exposed application.
+	Targets []LoadBalancerTarget `json:"targets"`
+}
+
+// LoadBalancerTarget describes the traffic an application's load
+// balancer should forward.
+type LoadBalancerTarget struct {
+	// ApplicationTag is the tag of the exposed application.
+	ApplicationTag string `json:"application-tag"`
+
+	// Ports are the port ranges opened by any of the application's
+	// units.
+	Ports []PortRange `json:"ports"`
+
+	// InstanceIds are the instances of the machines that host the
+	// application's healthy units.
+	InstanceIds []string `json:"instance-ids"`
+}
+
+// SetLoadBalancerAddressesArgs holds the arguments for
+// LoadBalancer.SetApplicationAddresses.
+type SetLoadBalancerAddressesArgs struct {
+	Args []SetLoadBalancerAddresses `json:"args"`
+}
+
+// SetLoadBalancerAddresses records the load balancer in front of an
+// application. An empty ProviderId records that there is none.
+type SetLoadBalancerAddresses struct {
+	ApplicationTag string    `json:"application-tag"`
+	ProviderId     string    `json:"provider-id"`
+	Addresses      []Address `json:"addresses"`
+}
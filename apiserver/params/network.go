@@ -50,6 +50,10 @@ type Subnet struct {
 	// Status returns the status of the subnet, whether it is in use, not
 	// in use or terminating.
 	Status string `json:"status,omitempty"`
+
+	// Routes holds the static routes which machines with addresses in
+	// the subnet should configure.
+	Routes []NetworkRoute `json:"routes,omitempty"`
 }
 
 // NetworkRoute describes a special route that should be added for a given
@@ -118,6 +122,10 @@ type NetworkConfig struct {
 	// InterfaceType is the type of the interface.
 	InterfaceType string `json:"interface-type"`
 
+	// NICType defines how a container interface is attached to its
+	// parent device. An empty value means it is bridged.
+	NICType string `json:"nic-type,omitempty"`
+
 	// Disabled is true when the interface needs to be disabled on the
 	// machine, e.g. not to configure it at all or stop it if running.
 	Disabled bool `json:"disabled"`
@@ -599,6 +607,24 @@ type AddSubnetParams struct {
 	SpaceTag          string   `json:"space-tag"`
 	VLANTag           int      `json:"vlan-tag,omitempty"`
 	Zones             []string `json:"zones,omitempty"`
+
+	// Routes holds the static routes which machines with addresses in
+	// the subnet should configure.
+	Routes []NetworkRoute `json:"routes,omitempty"`
+}
+
+// SetSubnetsRoutesParams holds the arguments of SetSubnetsRoutes API
+// call.
+type SetSubnetsRoutesParams struct {
+	Subnets []SetSubnetRoutesParams `json:"subnets"`
+}
+
+// SetSubnetRoutesParams holds the tag of an existing subnet and the
+// static routes which replace any routes it already has. An empty
+// Routes clears them.
+type SetSubnetRoutesParams struct {
+	SubnetTag string         `json:"subnet-tag"`
+	Routes    []NetworkRoute `json:"routes"`
 }
 
 // CreateSubnetsParams holds the arguments of CreateSubnets API call.
@@ -750,3 +776,46 @@ type FanConfigEntry struct {
 type FanConfigResult struct {
 	Fans []FanConfigEntry `json:"fans"`
 }
+
+// NetworkBond describes a bond which should be created on a machine by
+// aggregating some of its ethernet devices.
+type NetworkBond struct {
+	Name               string   `json:"name"`
+	DeviceNames        []string `json:"device-names"`
+	Mode               string   `json:"mode,omitempty"`
+	LACPRate           string   `json:"lacp-rate,omitempty"`
+	MIIMonitorInterval int      `json:"mii-monitor-interval,omitempty"`
+	TransmitHashPolicy string   `json:"transmit-hash-policy,omitempty"`
+}
+
+// NetworkVLAN describes a VLAN device which should be created on top of
+// an existing device of a machine.
+type NetworkVLAN struct {
+	Name             string `json:"name"`
+	ParentName       string `json:"parent-name"`
+	ParentMACAddress string `json:"parent-mac-address,omitempty"`
+	VLANTag          int    `json:"vlan-tag"`
+}
+
+// NetworkDeviceRoutes holds the static routes which should be configured
+// on a single device of a machine.
+type NetworkDeviceRoutes struct {
+	DeviceName string         `json:"device-name"`
+	MACAddress string         `json:"mac-address,omitempty"`
+	Routes     []NetworkRoute `json:"routes"`
+}
+
+// NetworkDefinitionsResult holds the bonds, VLANs and routes which the
+// machine agent should configure on a machine, or an error.
+type NetworkDefinitionsResult struct {
+	Bonds  []NetworkBond         `json:"bonds,omitempty"`
+	VLANs  []NetworkVLAN         `json:"vlans,omitempty"`
+	Routes []NetworkDeviceRoutes `json:"routes,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// NetworkDefinitionsResults holds the results of a NetworkDefinitions
+// API call.
+type NetworkDefinitionsResults struct {
+	Results []NetworkDefinitionsResult `json:"results"`
+}
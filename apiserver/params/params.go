@@ -390,6 +390,20 @@ type ApplicationSetCharm struct {
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposedEndpoints, if set, restricts the sources the application
+	// is exposed to, keyed on endpoint name. The empty endpoint name
+	// applies to all endpoints of the application. This field is only
+	// understood by Application facade version 9 and greater.
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+}
+
+// ExposedEndpoint holds the sources that an exposed endpoint of an
+// application is reachable from. An endpoint with no spaces or CIDRs
+// is reachable from everywhere.
+type ExposedEndpoint struct {
+	ExposeToSpaces []string `json:"expose-to-spaces,omitempty"`
+	ExposeToCIDRs  []string `json:"expose-to-cidrs,omitempty"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -455,6 +469,12 @@ type ApplicationCharmRelationsResults struct {
 // ApplicationUnexpose holds parameters for the application Unexpose call.
 type ApplicationUnexpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposedEndpoints, if set, names the endpoints whose expose
+	// settings are removed; the application remains exposed while
+	// other settings remain. This field is only understood by
+	// Application facade version 9 and greater.
+	ExposedEndpoints []string `json:"exposed-endpoints,omitempty"`
 }
 
 // ApplicationMetricCredential holds parameters for the SetApplicationCredentials call.
@@ -132,6 +132,10 @@ type ApplicationStatus struct {
 	CharmVersion     string                 `json:"charm-verion"`
 	EndpointBindings map[string]string      `json:"endpoint-bindings"`
 
+	// ExposedEndpoints holds the expose settings of an exposed
+	// application, keyed on endpoint name.
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+
 	// The following are for CAAS models.
 	ProviderId    string `json:"provider-id,omitempty"`
 	PublicAddress string `json:"public-address"`
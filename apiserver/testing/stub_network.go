@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/juju/collections/set"
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
@@ -333,6 +334,10 @@ func (f *FakeSubnet) Life() params.Life {
 	return f.Info.Life
 }
 
+func (f *FakeSubnet) Routes() []network.Route {
+	return f.Info.Routes
+}
+
 // ResetStub resets all recorded calls and errors of the given stub.
 func ResetStub(stub *testing.Stub) {
 	*stub = testing.Stub{}
@@ -517,6 +522,23 @@ func (sb *StubBacking) AddSubnet(subnetInfo networkingcommon.BackingSubnetInfo)
 	return fs, nil
 }
 
+func (sb *StubBacking) SetSubnetRoutes(cidr string, routes []network.Route) error {
+	sb.MethodCall(sb, "SetSubnetRoutes", cidr, routes)
+	if err := sb.NextErr(); err != nil {
+		return err
+	}
+	for _, subnet := range sb.Subnets {
+		if subnet.CIDR() != cidr {
+			continue
+		}
+		if fs, ok := subnet.(*FakeSubnet); ok {
+			fs.Info.Routes = routes
+		}
+		return nil
+	}
+	return errors.NotFoundf("subnet %q", cidr)
+}
+
 func (sb *StubBacking) AddSpace(name string, providerId network.Id, subnets []string, public bool) error {
 	sb.MethodCall(sb, "AddSpace", name, providerId, subnets, public)
 	if err := sb.NextErr(); err != nil {
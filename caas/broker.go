@@ -137,6 +137,49 @@ type Broker interface {
 	storage.ProviderRegistry
 }
 
+// OperatorUpgrader is implemented by brokers which can upgrade an
+// operator in place, swapping its image without recreating the pod or
+// touching the application's workload, and which can roll back the
+// most recent such upgrade.
+type OperatorUpgrader interface {
+	// Operator returns the operator for the specified application,
+	// or an error satisfying errors.IsNotFound if there is none.
+	Operator(appName string) (*Operator, error)
+
+	// UpgradeOperator updates the operator for the specified application
+	// to run the image and version in the config, recording the image
+	// and version being replaced so that the upgrade can be rolled back.
+	UpgradeOperator(appName string, config *OperatorConfig) error
+
+	// RollbackOperator restores the image and version which the operator
+	// for the specified application was running before its last upgrade,
+	// recording the version rolled back from as the failed version.
+	RollbackOperator(appName string) error
+}
+
+// Operator represents information about the operator
+// running the charm for an application.
+type Operator struct {
+	// Image is the docker image run by the operator.
+	Image string
+
+	// Version is the Juju version of the operator.
+	Version version.Number
+
+	// PreviousImage and PreviousVersion record what the operator ran
+	// before its last upgrade. They are empty if there is nothing to
+	// roll back to.
+	PreviousImage   string
+	PreviousVersion version.Number
+
+	// FailedVersion is the version of the last upgrade which failed
+	// and was rolled back. It is empty if no upgrade has failed.
+	FailedVersion version.Number
+
+	// Status is the status of the operator.
+	Status status.StatusInfo
+}
+
 // Service represents information about the status of a caas service entity.
 type Service struct {
 	Id        string
@@ -164,6 +207,11 @@ type VolumeInfo struct {
 	Status     status.StatusInfo
 }
 
+// StatusReasonKey is the status data key under which the substrate's
+// reason for a unit or filesystem status, such as FailedScheduling or
+// CrashLoopBackOff, is recorded.
+const StatusReasonKey = "reason"
+
 // Unit represents information about the status of a "pod".
 type Unit struct {
 	Id             string
@@ -55,10 +55,58 @@ type ContainerSpec struct {
 	ProviderContainer `yaml:"-"`
 }
 
+// WorkloadKind defines the kind of controller used
+// to run the pods of an application.
+type WorkloadKind string
+
+const (
+	// WorkloadDefault leaves the choice of controller to the
+	// substrate, eg a deployment, or a stateful set if the
+	// application requires storage.
+	WorkloadDefault WorkloadKind = ""
+
+	// WorkloadDeployment runs the requested number of
+	// interchangeable pods.
+	WorkloadDeployment WorkloadKind = "deployment"
+
+	// WorkloadStatefulSet runs the requested number of pods,
+	// each with a stable identity and storage.
+	WorkloadStatefulSet WorkloadKind = "statefulset"
+
+	// WorkloadDaemonSet runs one pod on each eligible node;
+	// the number of units follows the number of nodes.
+	WorkloadDaemonSet WorkloadKind = "daemonset"
+
+	// WorkloadJob runs the requested number of pods
+	// to completion.
+	WorkloadJob WorkloadKind = "job"
+
+	// WorkloadCronJob runs the requested number of pods
+	// to completion, repeatedly on a schedule.
+	WorkloadCronJob WorkloadKind = "cronjob"
+)
+
+// Validate returns an error if the workload kind is not valid.
+func (k WorkloadKind) Validate() error {
+	switch k {
+	case WorkloadDefault, WorkloadDeployment, WorkloadStatefulSet, WorkloadDaemonSet, WorkloadJob, WorkloadCronJob:
+		return nil
+	}
+	return errors.NotValidf("workload kind %q", k)
+}
+
+// SupportsStorage returns true if pods of this kind of
+// workload may have storage attached.
+func (k WorkloadKind) SupportsStorage() bool {
+	return k == WorkloadDefault || k == WorkloadStatefulSet
+}
+
 // PodSpec defines the data values used to configure
 // a pod on the CAAS substrate.
 type PodSpec struct {
 	Containers                []ContainerSpec            `yaml:"-"`
+	WorkloadKind              WorkloadKind               `yaml:"workloadKind,omitempty"`
+	Schedule                  string                     `yaml:"schedule,omitempty"`
 	OmitServiceFrontend       bool                       `yaml:"omitServiceFrontend"`
 	CustomResourceDefinitions []CustomResourceDefinition `yaml:"customResourceDefinition,omitempty"`
 }
@@ -96,6 +144,15 @@ func (crd *CustomResourceDefinition) Validate() error {
 
 // Validate returns an error if the spec is not valid.
 func (spec *PodSpec) Validate() error {
+	if err := spec.WorkloadKind.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.WorkloadKind == WorkloadCronJob && spec.Schedule == "" {
+		return errors.New("schedule is missing for cronjob workload")
+	}
+	if spec.WorkloadKind != WorkloadCronJob && spec.Schedule != "" {
+		return errors.Errorf("schedule is not supported for %q workloads", spec.WorkloadKind)
+	}
 	for _, c := range spec.Containers {
 		if err := c.Validate(); err != nil {
 			return errors.Trace(err)
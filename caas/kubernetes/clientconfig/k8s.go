@@ -5,6 +5,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -16,6 +17,36 @@ import (
 
 var logger = loggo.GetLogger("juju.caas.kubernetes.clientconfig")
 
+// now returns the current time, used to check token expiry.
+var now = time.Now
+
+// ResolveCredential returns the credential with a current token,
+// running the exec credential plugin or refreshing the OpenID Connect
+// id token it records as necessary. The resolved credential holds the
+// token rather than the plugin, so it can be stored by the controller.
+// Credentials which are not token based are returned unchanged. Only
+// credentials which are about to be used should be resolved, since
+// doing so may run commands named in the kubeconfig.
+func ResolveCredential(cred cloud.Credential) (cloud.Credential, error) {
+	attrs, err := resolveExecCredential(cred.Attributes())
+	if err != nil {
+		return cloud.Credential{}, errors.Trace(err)
+	}
+	attrs, err = RefreshCredentialAttrs(attrs, now())
+	if err != nil {
+		return cloud.Credential{}, errors.Trace(err)
+	}
+	resolved := cloud.NewCredential(cred.AuthType(), attrs)
+	resolved.Label = cred.Label
+	return resolved, nil
+}
+
+// NeedsPlugin reports whether the credential records an exec credential
+// plugin, and so must be resolved with ResolveCredential before use.
+func NeedsPlugin(cred cloud.Credential) bool {
+	return cred.Attributes()[credAttrExecCommand] != ""
+}
+
 // NewK8sClientConfig returns a new Kubernetes client, reading the config from the specified reader.
 func NewK8sClientConfig(reader io.Reader) (*ClientConfig, error) {
 	if reader == nil {
@@ -123,6 +154,51 @@ func credentialsFromConfig(config *clientcmdapi.Config) (map[string]cloud.Creden
 			user.ClientKeyData = keyData
 		}
 
+		// Credential plugins and OpenID Connect refreshes are not run
+		// here, since the config may hold users unrelated to the
+		// clusters being imported. Only what's needed to obtain a token
+		// later is recorded; see ResolveCredential.
+		var tokenBased bool
+		if user.Exec != nil {
+			execAttrs, err := execCredentialAttrs(user.Exec)
+			if err != nil {
+				logger.Warningf("cannot read credential plugin for AuthInfo '%s': %v", name, err)
+				continue
+			}
+			for k, v := range execAttrs {
+				attrs[k] = v
+			}
+			tokenBased = true
+		} else if user.AuthProvider != nil {
+			if user.AuthProvider.Name != oidcAuthProvider {
+				logger.Warningf("unsupported auth-provider %q for AuthInfo '%s': skipping", user.AuthProvider.Name, name)
+				continue
+			}
+			providerConfig := user.AuthProvider.Config
+			if idToken := providerConfig[oidcIDToken]; idToken != "" {
+				expiry, err := TokenExpiry(idToken)
+				if err != nil {
+					logger.Warningf("cannot read OIDC id token for AuthInfo '%s': %v", name, err)
+					continue
+				}
+				user.Token = idToken
+				if !expiry.IsZero() {
+					attrs[credAttrExpiry] = expiry.Format(time.RFC3339)
+				}
+			}
+			// Record what's needed so that the id token can be
+			// refreshed once it expires.
+			if providerConfig[oidcRefreshToken] != "" {
+				attrs[credAttrRefreshToken] = providerConfig[oidcRefreshToken]
+				attrs[credAttrIssuerURL] = providerConfig[oidcIssuerURL]
+				attrs[credAttrClientID] = providerConfig[oidcClientID]
+				if secret := providerConfig[oidcClientSecret]; secret != "" {
+					attrs[credAttrClientSecret] = secret
+				}
+			}
+			tokenBased = true
+		}
+
 		if len(user.ClientCertificateData) > 0 {
 			attrs["ClientCertificateData"] = string(user.ClientCertificateData)
 			hasCert = true
@@ -132,7 +208,12 @@ func credentialsFromConfig(config *clientcmdapi.Config) (map[string]cloud.Creden
 		}
 
 		var authType cloud.AuthType
-		if user.Token != "" {
+		if tokenBased {
+			if user.Token != "" {
+				attrs[credAttrToken] = user.Token
+			}
+			authType = cloud.OAuth2AuthType
+		} else if user.Token != "" {
 			if user.Username != "" || user.Password != "" {
 				logger.Warningf("invalid AuthInfo: '%s' has both Token and User/Pass: skipping", name)
 				continue
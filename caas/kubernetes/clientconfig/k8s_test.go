@@ -4,10 +4,16 @@
 package clientconfig_test
 
 import (
+	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"runtime"
+	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"k8s.io/client-go/tools/clientcmd"
@@ -276,3 +282,204 @@ func (s *k8sConfigSuite) TestGetSingleConfigReadsFilePaths(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertSingleConfig(c, f)
 }
+
+var pluginConfigYAML = `
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://1.1.1.1:8888
+  name: the-cluster
+contexts:
+- context:
+    cluster: the-cluster
+    user: exec-user
+  name: exec-context
+- context:
+    cluster: the-cluster
+    user: oidc-user
+  name: oidc-context
+current-context: exec-context
+preferences: {}
+users:
+- name: exec-user
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1alpha1
+      command: get-token
+      args: ["--cluster", "the-cluster"]
+      env:
+      - name: REGION
+        value: north
+- name: oidc-user
+  user:
+    auth-provider:
+      name: oidc
+      config:
+        id-token: header.eyJleHAiOjQxMDI0NDQ4MDB9.signature
+        refresh-token: refresh
+        idp-issuer-url: https://issuer.example.com
+        client-id: juju
+`
+
+func (s *k8sConfigSuite) TestGetPluginConfig(c *gc.C) {
+	s.PatchValue(clientconfig.RunExecPlugin, func(command string, args []string, env []string) ([]byte, error) {
+		c.Errorf("credential plugin %q run while reading config", command)
+		return nil, errors.New("unexpected")
+	})
+	f, err := s.writeTempKubeConfig(c, "pluginConfig", pluginConfigYAML)
+	defer f.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := clientconfig.NewK8sClientConfig(f)
+	c.Assert(err, jc.ErrorIsNil)
+
+	execCred := cloud.NewCredential(
+		cloud.OAuth2AuthType,
+		map[string]string{
+			"ExecCommand":    "get-token",
+			"ExecArgs":       `["--cluster","the-cluster"]`,
+			"ExecEnv":        `[{"name":"REGION","value":"north"}]`,
+			"ExecAPIVersion": "client.authentication.k8s.io/v1alpha1",
+		})
+	execCred.Label = `kubernetes credential "exec-user"`
+	oidcCred := cloud.NewCredential(
+		cloud.OAuth2AuthType,
+		map[string]string{
+			"Token":        "header.eyJleHAiOjQxMDI0NDQ4MDB9.signature",
+			"Expiry":       "2100-01-01T00:00:00Z",
+			"RefreshToken": "refresh",
+			"IssuerURL":    "https://issuer.example.com",
+			"ClientID":     "juju",
+		})
+	oidcCred.Label = `kubernetes credential "oidc-user"`
+	c.Assert(cfg.Credentials, jc.DeepEquals, map[string]cloud.Credential{
+		"exec-user": execCred,
+		"oidc-user": oidcCred,
+	})
+}
+
+func (s *k8sConfigSuite) TestResolveCredentialRunsPlugin(c *gc.C) {
+	s.PatchValue(clientconfig.RunExecPlugin, func(command string, args []string, env []string) ([]byte, error) {
+		c.Check(command, gc.Equals, "get-token")
+		c.Check(args, jc.DeepEquals, []string{"--cluster", "the-cluster"})
+		c.Check(env, jc.DeepEquals, []string{
+			`KUBERNETES_EXEC_INFO={"apiVersion":"client.authentication.k8s.io/v1alpha1","kind":"ExecCredential","spec":{"interactive":false}}`,
+			"REGION=north",
+		})
+		return []byte(`{
+  "kind": "ExecCredential",
+  "apiVersion": "client.authentication.k8s.io/v1alpha1",
+  "status": {"token": "exectoken", "expirationTimestamp": "2100-01-01T00:00:00Z"}
+}`), nil
+	})
+	f, err := s.writeTempKubeConfig(c, "pluginConfig", pluginConfigYAML)
+	defer f.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := clientconfig.NewK8sClientConfig(f)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cred, err := clientconfig.ResolveCredential(cfg.Credentials["exec-user"])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cred.AuthType(), gc.Equals, cloud.OAuth2AuthType)
+	c.Assert(cred.Label, gc.Equals, `kubernetes credential "exec-user"`)
+	// Only the token is kept, not the plugin.
+	c.Assert(cred.Attributes(), jc.DeepEquals, map[string]string{
+		"Token":  "exectoken",
+		"Expiry": "2100-01-01T00:00:00Z",
+	})
+	c.Assert(clientconfig.NeedsPlugin(cfg.Credentials["exec-user"]), jc.IsTrue)
+	c.Assert(clientconfig.NeedsPlugin(cred), jc.IsFalse)
+}
+
+func (s *k8sConfigSuite) TestResolveCredentialPluginFails(c *gc.C) {
+	s.PatchValue(clientconfig.RunExecPlugin, func(string, []string, []string) ([]byte, error) {
+		return nil, errors.New("boom")
+	})
+	f, err := s.writeTempKubeConfig(c, "pluginConfig", pluginConfigYAML)
+	defer f.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := clientconfig.NewK8sClientConfig(f)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = clientconfig.ResolveCredential(cfg.Credentials["exec-user"])
+	c.Assert(err, gc.ErrorMatches, `running credential plugin "get-token": boom`)
+}
+
+func (s *k8sConfigSuite) TestRunExecPluginStderr(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("credential plugin test uses a shell")
+	}
+	runExecPlugin := *clientconfig.RunExecPlugin
+	_, err := runExecPlugin("/bin/sh", []string{"-c", "echo bad credentials >&2; exit 1"}, nil)
+	c.Assert(err, gc.ErrorMatches, "exit status 1: bad credentials")
+}
+
+func (s *k8sConfigSuite) TestRefreshCredentialAttrsDoesNotRunPlugin(c *gc.C) {
+	s.PatchValue(clientconfig.RunExecPlugin, func(command string, args []string, env []string) ([]byte, error) {
+		c.Errorf("credential plugin %q run while refreshing", command)
+		return nil, errors.New("unexpected")
+	})
+	_, err := clientconfig.RefreshCredentialAttrs(map[string]string{
+		"Token":       "token",
+		"Expiry":      "2017-12-31T23:00:00Z",
+		"ExecCommand": "get-token",
+	}, time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	c.Assert(err, gc.ErrorMatches, "token expired at 2017-12-31T23:00:00Z")
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (s *k8sConfigSuite) TestResolveCredentialUnexpiredToken(c *gc.C) {
+	s.PatchValue(clientconfig.Now, func() time.Time {
+		return time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	})
+	cred := cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{
+		"Token":        "token",
+		"Expiry":       "2100-01-01T00:00:00Z",
+		"RefreshToken": "refresh",
+	})
+	resolved, err := clientconfig.ResolveCredential(cred)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resolved, jc.DeepEquals, cred)
+}
+
+func (s *k8sConfigSuite) TestRefreshCredentialAttrsExpired(c *gc.C) {
+	_, err := clientconfig.RefreshCredentialAttrs(map[string]string{
+		"Token":  "token",
+		"Expiry": "2017-12-31T23:00:00Z",
+	}, time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	c.Assert(err, gc.ErrorMatches, "token expired at 2017-12-31T23:00:00Z")
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (s *k8sConfigSuite) TestOIDCTokenExpiredWithoutRefreshToken(c *gc.C) {
+	_, _, err := clientconfig.OIDCToken(map[string]string{
+		"id-token": "header.eyJleHAiOjQxMDI0NDQ4MDB9.signature",
+	}, time.Date(2101, 1, 1, 0, 0, 0, 0, time.UTC))
+	c.Assert(err, gc.ErrorMatches, "OIDC id token has expired and no refresh token is available")
+}
+
+func (s *k8sConfigSuite) TestOIDCTokenRefresh(c *gc.C) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			fmt.Fprintf(w, `{"token_endpoint": %q}`, server.URL+"/token")
+		case "/token":
+			c.Check(r.FormValue("grant_type"), gc.Equals, "refresh_token")
+			c.Check(r.FormValue("refresh_token"), gc.Equals, "refresh")
+			c.Check(r.FormValue("client_id"), gc.Equals, "juju")
+			fmt.Fprint(w, `{"id_token": "new.eyJleHAiOjQxMDI0NDQ4MDB9.token"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	token, expiry, err := clientconfig.OIDCToken(map[string]string{
+		"refresh-token":  "refresh",
+		"idp-issuer-url": server.URL,
+		"client-id":      "juju",
+	}, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token, gc.Equals, "new.eyJleHAiOjQxMDI0NDQ4MDB9.token")
+	c.Assert(expiry.Equal(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)), jc.IsTrue)
+}
@@ -0,0 +1,373 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package clientconfig
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// oidcAuthProvider is the name of the kubeconfig auth-provider
+	// which authenticates with an OpenID Connect identity provider.
+	oidcAuthProvider = "oidc"
+
+	oidcIDToken      = "id-token"
+	oidcRefreshToken = "refresh-token"
+	oidcClientID     = "client-id"
+	oidcClientSecret = "client-secret"
+	oidcIssuerURL    = "idp-issuer-url"
+)
+
+const (
+	// These credential attributes record how a token was obtained,
+	// so that it can be renewed once it expires.
+	credAttrToken        = "Token"
+	credAttrExpiry       = "Expiry"
+	credAttrRefreshToken = "RefreshToken"
+	credAttrIssuerURL    = "IssuerURL"
+	credAttrClientID     = "ClientID"
+	credAttrClientSecret = "ClientSecret"
+
+	// These credential attributes record the exec credential plugin
+	// of a kubeconfig user. They are only held by the client, and are
+	// replaced by the token the plugin hands back when the credential
+	// is resolved, so plugins are never run by the controller.
+	credAttrExecCommand    = "ExecCommand"
+	credAttrExecArgs       = "ExecArgs"
+	credAttrExecEnv        = "ExecEnv"
+	credAttrExecAPIVersion = "ExecAPIVersion"
+
+	// execInfoEnvVar is the environment variable through which the
+	// ExecCredential request is passed to credential plugins.
+	execInfoEnvVar = "KUBERNETES_EXEC_INFO"
+
+	defaultExecAPIVersion = "client.authentication.k8s.io/v1alpha1"
+)
+
+// execCredential is the subset of the client.authentication.k8s.io
+// ExecCredential object exchanged with exec credential plugins.
+type execCredential struct {
+	APIVersion string                `json:"apiVersion,omitempty"`
+	Kind       string                `json:"kind"`
+	Spec       *execCredentialSpec   `json:"spec,omitempty"`
+	Status     *execCredentialStatus `json:"status,omitempty"`
+}
+
+type execCredentialSpec struct {
+	Interactive bool `json:"interactive"`
+}
+
+type execCredentialStatus struct {
+	ExpirationTimestamp   *time.Time `json:"expirationTimestamp,omitempty"`
+	Token                 string     `json:"token,omitempty"`
+	ClientCertificateData string     `json:"clientCertificateData,omitempty"`
+	ClientKeyData         string     `json:"clientKeyData,omitempty"`
+}
+
+type execEnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// runExecPlugin runs the credential plugin command with the specified
+// arguments and additional environment, returning its stdout. Anything
+// the plugin writes to stderr is included in the error if it fails.
+var runExecPlugin = func(command string, args []string, env []string) ([]byte, error) {
+	cmd := exec.Command(command, args...)
+	cmd.Env = append(os.Environ(), env...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.Errorf("%v: %s", err, msg)
+		}
+		return nil, errors.Trace(err)
+	}
+	return out, nil
+}
+
+// httpClient is used to talk to OpenID Connect identity providers.
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// execCredentialAttrs returns the credential attributes which record
+// the exec credential plugin configuration of a kubeconfig user. The
+// plugin itself is not run.
+func execCredentialAttrs(config *clientcmdapi.ExecConfig) (map[string]string, error) {
+	args, err := json.Marshal(config.Args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	env := make([]execEnvVar, len(config.Env))
+	for i, e := range config.Env {
+		env[i] = execEnvVar{Name: e.Name, Value: e.Value}
+	}
+	envData, err := json.Marshal(env)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attrs := map[string]string{
+		credAttrExecCommand: config.Command,
+		credAttrExecArgs:    string(args),
+		credAttrExecEnv:     string(envData),
+	}
+	if config.APIVersion != "" {
+		attrs[credAttrExecAPIVersion] = config.APIVersion
+	}
+	return attrs, nil
+}
+
+// credentialFromExec runs the exec credential plugin recorded in the
+// credential attributes and returns the credential it hands back.
+func credentialFromExec(attrs map[string]string) (*execCredentialStatus, error) {
+	command := attrs[credAttrExecCommand]
+	var args []string
+	if argsData := attrs[credAttrExecArgs]; argsData != "" {
+		if err := json.Unmarshal([]byte(argsData), &args); err != nil {
+			return nil, errors.Annotatef(err, "decoding arguments of credential plugin %q", command)
+		}
+	}
+	var envVars []execEnvVar
+	if envData := attrs[credAttrExecEnv]; envData != "" {
+		if err := json.Unmarshal([]byte(envData), &envVars); err != nil {
+			return nil, errors.Annotatef(err, "decoding environment of credential plugin %q", command)
+		}
+	}
+	apiVersion := attrs[credAttrExecAPIVersion]
+	if apiVersion == "" {
+		apiVersion = defaultExecAPIVersion
+	}
+	execInfo, err := json.Marshal(execCredential{
+		APIVersion: apiVersion,
+		Kind:       "ExecCredential",
+		Spec:       &execCredentialSpec{Interactive: false},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	env := []string{execInfoEnvVar + "=" + string(execInfo)}
+	for _, e := range envVars {
+		env = append(env, e.Name+"="+e.Value)
+	}
+
+	out, err := runExecPlugin(command, args, env)
+	if err != nil {
+		return nil, errors.Annotatef(err, "running credential plugin %q", command)
+	}
+	var cred execCredential
+	if err := json.Unmarshal(out, &cred); err != nil {
+		return nil, errors.Annotatef(err, "decoding output of credential plugin %q", command)
+	}
+	if cred.Kind != "ExecCredential" {
+		return nil, errors.NotValidf("credential plugin %q returned kind %q", command, cred.Kind)
+	}
+	if cred.Status == nil || (cred.Status.Token == "" && cred.Status.ClientCertificateData == "") {
+		return nil, errors.NotValidf("credential plugin %q returned no credential", command)
+	}
+	return cred.Status, nil
+}
+
+// resolveExecCredential returns the attributes of a credential which
+// records an exec credential plugin, with the plugin replaced by the
+// token it hands back. The plugin is run on every call, since the
+// attributes read from a kubeconfig hold no token. Credentials which
+// record no plugin are returned as is.
+func resolveExecCredential(attrs map[string]string) (map[string]string, error) {
+	if attrs[credAttrExecCommand] == "" {
+		return attrs, nil
+	}
+	execCred, err := credentialFromExec(attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resolved := make(map[string]string, len(attrs))
+	for k, v := range attrs {
+		switch k {
+		case credAttrExecCommand, credAttrExecArgs, credAttrExecEnv, credAttrExecAPIVersion, credAttrExpiry:
+		default:
+			resolved[k] = v
+		}
+	}
+	resolved[credAttrToken] = execCred.Token
+	if execCred.ClientCertificateData != "" {
+		resolved["ClientCertificateData"] = execCred.ClientCertificateData
+		resolved["ClientKeyData"] = execCred.ClientKeyData
+	}
+	if execCred.ExpirationTimestamp != nil {
+		resolved[credAttrExpiry] = execCred.ExpirationTimestamp.UTC().Format(time.RFC3339)
+	}
+	return resolved, nil
+}
+
+// RefreshCredentialAttrs returns the attributes of a token based
+// credential with a current token. An OpenID Connect id token is
+// renewed from the identity provider using the refresh token. The
+// supplied attributes are not modified; credentials which need no
+// refresh are returned as is. An error satisfying errors.IsUnauthorized
+// is returned if the token has expired and cannot be renewed; this is
+// always the case for tokens obtained from exec credential plugins,
+// which are only run by the client.
+func RefreshCredentialAttrs(attrs map[string]string, now time.Time) (map[string]string, error) {
+	token := attrs[credAttrToken]
+	if token == "" && attrs[credAttrRefreshToken] == "" {
+		return attrs, nil
+	}
+	if token != "" {
+		expired, err := tokenExpired(attrs[credAttrExpiry], now)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !expired {
+			return attrs, nil
+		}
+	}
+	if attrs[credAttrRefreshToken] == "" {
+		return nil, errors.Unauthorizedf("token expired at %s", attrs[credAttrExpiry])
+	}
+
+	token, expiry, err := OIDCToken(map[string]string{
+		oidcRefreshToken: attrs[credAttrRefreshToken],
+		oidcIssuerURL:    attrs[credAttrIssuerURL],
+		oidcClientID:     attrs[credAttrClientID],
+		oidcClientSecret: attrs[credAttrClientSecret],
+	}, now)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	refreshed := make(map[string]string, len(attrs))
+	for k, v := range attrs {
+		refreshed[k] = v
+	}
+	delete(refreshed, credAttrExpiry)
+	refreshed[credAttrToken] = token
+	if !expiry.IsZero() {
+		refreshed[credAttrExpiry] = expiry.Format(time.RFC3339)
+	}
+	return refreshed, nil
+}
+
+// tokenExpired reports whether a token with the specified RFC3339
+// expiry has expired. Tokens with no expiry never expire.
+func tokenExpired(expiry string, now time.Time) (bool, error) {
+	if expiry == "" {
+		return false, nil
+	}
+	expiryTime, err := time.Parse(time.RFC3339, expiry)
+	if err != nil {
+		return false, errors.NotValidf("credential expiry %q", expiry)
+	}
+	return !expiryTime.After(now), nil
+}
+
+// OIDCToken returns a valid ID token and its expiry for the given OpenID
+// Connect auth-provider configuration. If the ID token in the config has
+// expired and a refresh token is available, a new ID token is obtained
+// from the identity provider.
+func OIDCToken(config map[string]string, now time.Time) (string, time.Time, error) {
+	idToken := config[oidcIDToken]
+	if idToken != "" {
+		expiry, err := TokenExpiry(idToken)
+		if err != nil {
+			return "", time.Time{}, errors.Trace(err)
+		}
+		if expiry.IsZero() || expiry.After(now) {
+			return idToken, expiry, nil
+		}
+	}
+	if config[oidcRefreshToken] == "" || config[oidcIssuerURL] == "" {
+		return "", time.Time{}, errors.Errorf("OIDC id token has expired and no refresh token is available")
+	}
+	idToken, err := refreshOIDCToken(config)
+	if err != nil {
+		return "", time.Time{}, errors.Annotate(err, "refreshing OIDC id token")
+	}
+	expiry, err := TokenExpiry(idToken)
+	if err != nil {
+		return "", time.Time{}, errors.Trace(err)
+	}
+	return idToken, expiry, nil
+}
+
+func refreshOIDCToken(config map[string]string) (string, error) {
+	issuer := strings.TrimSuffix(config[oidcIssuerURL], "/")
+	resp, err := httpClient.Get(issuer + "/.well-known/openid-configuration")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("fetching OIDC discovery document: %s", resp.Status)
+	}
+	var discovery struct {
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return "", errors.Annotate(err, "decoding OIDC discovery document")
+	}
+	if discovery.TokenEndpoint == "" {
+		return "", errors.NotFoundf("OIDC token endpoint for %q", issuer)
+	}
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {config[oidcRefreshToken]},
+		"client_id":     {config[oidcClientID]},
+	}
+	if secret := config[oidcClientSecret]; secret != "" {
+		form.Set("client_secret", secret)
+	}
+	tokenResp, err := httpClient.PostForm(discovery.TokenEndpoint, form)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer tokenResp.Body.Close()
+	if tokenResp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("OIDC token refresh: %s", tokenResp.Status)
+	}
+	var token struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.NewDecoder(tokenResp.Body).Decode(&token); err != nil {
+		return "", errors.Annotate(err, "decoding OIDC token response")
+	}
+	if token.IDToken == "" {
+		return "", errors.New("OIDC token response did not contain an id token")
+	}
+	return token.IDToken, nil
+}
+
+// TokenExpiry returns the expiry time recorded in the "exp" claim of a
+// JSON web token. A zero time is returned if the token is not a JWT or
+// carries no expiry.
+func TokenExpiry(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, nil
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, errors.Annotate(err, "decoding token payload")
+	}
+	var claims struct {
+		Expiry int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, errors.Annotate(err, "decoding token claims")
+	}
+	if claims.Expiry == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(claims.Expiry, 0).UTC(), nil
+}
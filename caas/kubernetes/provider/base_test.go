@@ -7,6 +7,9 @@ import (
 	"github.com/golang/mock/gomock"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	apps "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,6 +36,9 @@ type BaseSuite struct {
 	mockExtensions             *mocks.MockExtensionsV1beta1Interface
 	mockDeployments            *mocks.MockDeploymentInterface
 	mockStatefulSets           *mocks.MockStatefulSetInterface
+	mockDaemonSets             *mocks.MockDaemonSetInterface
+	mockJobs                   *mocks.MockJobInterface
+	mockCronJobs               *mocks.MockCronJobInterface
 	mockPods                   *mocks.MockPodInterface
 	mockServices               *mocks.MockServiceInterface
 	mockConfigMaps             *mocks.MockConfigMapInterface
@@ -101,6 +107,17 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	s.mockApps.EXPECT().Deployments(testNamespace).AnyTimes().Return(s.mockDeployments)
 	s.mockExtensions.EXPECT().Ingresses(testNamespace).AnyTimes().Return(s.mockIngressInterface)
 
+	s.mockDaemonSets = mocks.NewMockDaemonSetInterface(ctrl)
+	s.mockApps.EXPECT().DaemonSets(testNamespace).AnyTimes().Return(s.mockDaemonSets)
+	mockBatchV1 := mocks.NewMockBatchV1Interface(ctrl)
+	s.mockJobs = mocks.NewMockJobInterface(ctrl)
+	s.k8sClient.EXPECT().BatchV1().AnyTimes().Return(mockBatchV1)
+	mockBatchV1.EXPECT().Jobs(testNamespace).AnyTimes().Return(s.mockJobs)
+	mockBatchV1beta1 := mocks.NewMockBatchV1beta1Interface(ctrl)
+	s.mockCronJobs = mocks.NewMockCronJobInterface(ctrl)
+	s.k8sClient.EXPECT().BatchV1beta1().AnyTimes().Return(mockBatchV1beta1)
+	mockBatchV1beta1.EXPECT().CronJobs(testNamespace).AnyTimes().Return(s.mockCronJobs)
+
 	s.mockStorage = mocks.NewMockStorageV1Interface(ctrl)
 	s.mockStorageClass = mocks.NewMockStorageClassInterface(ctrl)
 	s.k8sClient.EXPECT().StorageV1().AnyTimes().Return(s.mockStorage)
@@ -139,3 +156,32 @@ func (s *BaseSuite) k8sNotFoundError() *k8serrors.StatusError {
 func (s *BaseSuite) deleteOptions(policy v1.DeletionPropagation) *v1.DeleteOptions {
 	return &v1.DeleteOptions{PropagationPolicy: &policy}
 }
+
+// workloadLookups returns the calls expected when the broker looks up
+// which kinds of workload run the pods of the "test" application. Only
+// those of the specified kinds are found.
+func (s *BaseSuite) workloadLookups(existing ...caas.WorkloadKind) []*gomock.Call {
+	found := make(map[caas.WorkloadKind]bool)
+	for _, kind := range existing {
+		found[kind] = true
+	}
+	lookup := func(kind caas.WorkloadKind, call *gomock.Call, obj interface{}) *gomock.Call {
+		if found[kind] {
+			return call.Return(obj, nil)
+		}
+		return call.Return(nil, s.k8sNotFoundError())
+	}
+	opts := v1.GetOptions{IncludeUninitialized: true}
+	return []*gomock.Call{
+		lookup(caas.WorkloadStatefulSet,
+			s.mockStatefulSets.EXPECT().Get("juju-test", opts).Times(1), &apps.StatefulSet{}),
+		lookup(caas.WorkloadDeployment,
+			s.mockDeployments.EXPECT().Get("juju-test", opts).Times(1), &apps.Deployment{}),
+		lookup(caas.WorkloadDaemonSet,
+			s.mockDaemonSets.EXPECT().Get("juju-test", opts).Times(1), &apps.DaemonSet{}),
+		lookup(caas.WorkloadJob,
+			s.mockJobs.EXPECT().Get("juju-test", opts).Times(1), &batchv1.Job{}),
+		lookup(caas.WorkloadCronJob,
+			s.mockCronJobs.EXPECT().Get("juju-test", opts).Times(1), &batchv1beta1.CronJob{}),
+	}
+}
@@ -4,16 +4,31 @@
 package provider
 
 import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
 	"github.com/juju/errors"
-	"github.com/juju/juju/caas/kubernetes/clientconfig"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/juju/juju/caas/kubernetes/clientconfig"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
 )
 
 const (
 	CredAttrUsername = "username"
 	CredAttrPassword = "password"
+
+	CredAttrToken        = "Token"
+	CredAttrExpiry       = "Expiry"
+	CredAttrRefreshToken = "RefreshToken"
+	CredAttrIssuerURL    = "IssuerURL"
+	CredAttrClientID     = "ClientID"
+	CredAttrClientSecret = "ClientSecret"
 )
 
 type environProviderCredentials struct{}
@@ -31,6 +46,42 @@ func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.C
 				},
 			},
 		},
+		cloud.OAuth2AuthType: {
+			{
+				CredAttrToken, cloud.CredentialAttr{
+					Description: "The bearer token to authenticate with.",
+					Hidden:      true,
+					Optional:    true,
+				},
+			}, {
+				CredAttrExpiry, cloud.CredentialAttr{
+					Description: "The time at which the token expires, in RFC3339 format.",
+					Optional:    true,
+				},
+			}, {
+				CredAttrRefreshToken, cloud.CredentialAttr{
+					Description: "The OpenID Connect refresh token used to renew an expired token.",
+					Hidden:      true,
+					Optional:    true,
+				},
+			}, {
+				CredAttrIssuerURL, cloud.CredentialAttr{
+					Description: "The OpenID Connect identity provider URL.",
+					Optional:    true,
+				},
+			}, {
+				CredAttrClientID, cloud.CredentialAttr{
+					Description: "The OpenID Connect client ID.",
+					Optional:    true,
+				},
+			}, {
+				CredAttrClientSecret, cloud.CredentialAttr{
+					Description: "The OpenID Connect client secret.",
+					Hidden:      true,
+					Optional:    true,
+				},
+			},
+		},
 	}
 }
 
@@ -49,10 +100,23 @@ func (environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, e
 		return nil, errors.NotFoundf("k8s cluster definitions")
 	}
 
+	// Credentials using exec plugins hold the plugin rather than a
+	// token. They are left out, rather than running every plugin in
+	// the config; "juju add-k8s" resolves the one it needs.
+	credentials := make(map[string]cloud.Credential)
+	for name, cred := range caasConfig.Credentials {
+		if clientconfig.NeedsPlugin(cred) {
+			logger.Debugf("skipping credential %q which uses a credential plugin", name)
+			continue
+		}
+		credentials[name] = cred
+	}
 	defaultContext := caasConfig.Contexts[caasConfig.CurrentContext]
 	result := &cloud.CloudCredential{
-		AuthCredentials:   caasConfig.Credentials,
-		DefaultCredential: defaultContext.CredentialName,
+		AuthCredentials: credentials,
+	}
+	if _, ok := credentials[defaultContext.CredentialName]; ok {
+		result.DefaultCredential = defaultContext.CredentialName
 	}
 	return result, nil
 }
@@ -61,3 +125,100 @@ func (environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, e
 func (environProviderCredentials) FinalizeCredential(_ environs.FinalizeCredentialContext, args environs.FinalizeCredentialParams) (*cloud.Credential, error) {
 	return &args.Credential, nil
 }
+
+// refreshCredentialAttrs returns the credential attributes with a
+// current bearer token. If the token has expired it is renewed by
+// refreshing the OpenID Connect id token recorded in the credential;
+// if it cannot be renewed an error satisfying common.IsCredentialNotValid
+// is returned. Tokens obtained from credential plugins are never renewed
+// here, since plugins are only run by the client.
+func refreshCredentialAttrs(credentialAttrs map[string]string, now time.Time) (map[string]string, error) {
+	attrs, err := clientconfig.RefreshCredentialAttrs(credentialAttrs, now)
+	if errors.IsUnauthorized(err) {
+		return nil, common.NewCredentialNotValid(err.Error())
+	} else if err != nil {
+		return nil, common.CredentialNotValidf(err, "token expired and could not be refreshed")
+	}
+	return attrs, nil
+}
+
+// bearerToken returns the current token for a credential, renewing it
+// as described for refreshCredentialAttrs.
+func bearerToken(credentialAttrs map[string]string, now time.Time) (string, error) {
+	attrs, err := refreshCredentialAttrs(credentialAttrs, now)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return attrs[CredAttrToken], nil
+}
+
+// tokenSource hands out the bearer token for a credential, renewing it
+// whenever it expires. Brokers are long lived, and OpenID Connect id
+// tokens often expire after minutes.
+type tokenSource struct {
+	clock clock.Clock
+
+	mu    sync.Mutex
+	attrs map[string]string
+}
+
+func (s *tokenSource) token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	attrs, err := refreshCredentialAttrs(s.attrs, s.clock.Now())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	s.attrs = attrs
+	return attrs[CredAttrToken], nil
+}
+
+// bearerTokenTransport sets the Authorization header of each request
+// to the current token handed out by its token source.
+type bearerTokenTransport struct {
+	source *tokenSource
+	next   http.RoundTripper
+}
+
+// RoundTrip is part of the http.RoundTripper interface.
+func (t *bearerTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source.token()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// RoundTrippers must not modify the request they are given.
+	authReq := new(http.Request)
+	*authReq = *req
+	authReq.Header = make(http.Header, len(req.Header)+1)
+	for k, v := range req.Header {
+		authReq.Header[k] = v
+	}
+	authReq.Header.Set("Authorization", "Bearer "+token)
+	return t.next.RoundTrip(authReq)
+}
+
+// maybeConvertCredentialError examines the error received from the
+// Kubernetes API. Unauthorized errors are wrapped in
+// common.CredentialNotValid and the model credential is invalidated,
+// so that expired or revoked credentials are flagged rather than
+// surfacing as opaque 401s.
+func maybeConvertCredentialError(err error, ctx context.ProviderCallContext) error {
+	if err == nil || !k8serrors.IsUnauthorized(errors.Cause(err)) {
+		return err
+	}
+	converted := common.CredentialNotValidf(err, "kubernetes API rejected credential")
+	if ctx != nil {
+		if callbackErr := ctx.InvalidateCredential(converted.Error()); callbackErr != nil {
+			logger.Infof("callback to invalidate model credential failed with %v", callbackErr)
+		}
+	}
+	return converted
+}
+
+// convertCredentialError is deferred by broker methods which call the
+// Kubernetes API, so that a credential rejected by any of those calls
+// is reported as common.CredentialNotValid. These methods are not given
+// a call context, so the model credential is not invalidated here.
+func convertCredentialError(err *error) {
+	*err = maybeConvertCredentialError(*err, nil)
+}
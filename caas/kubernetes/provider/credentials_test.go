@@ -4,16 +4,24 @@
 package provider_test
 
 import (
+	"net/http"
 	"path/filepath"
+	"time"
 
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
 	envtesting "github.com/juju/juju/environs/testing"
+	"github.com/juju/juju/provider/common"
 )
 
 type credentialsSuite struct {
@@ -32,7 +40,7 @@ func (s *credentialsSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *credentialsSuite) TestCredentialSchemas(c *gc.C) {
-	envtesting.AssertProviderAuthTypes(c, s.provider, "userpass")
+	envtesting.AssertProviderAuthTypes(c, s.provider, "userpass", "oauth2")
 }
 
 func (s *credentialsSuite) TestCredentialsValid(c *gc.C) {
@@ -42,8 +50,16 @@ func (s *credentialsSuite) TestCredentialsValid(c *gc.C) {
 	})
 }
 
+func (s *credentialsSuite) TestOAuth2CredentialsValid(c *gc.C) {
+	envtesting.AssertProviderCredentialsValid(c, s.provider, "oauth2", map[string]string{
+		"Token":  "token",
+		"Expiry": "2100-01-01T00:00:00Z",
+	})
+}
+
 func (s *credentialsSuite) TestHiddenAttributes(c *gc.C) {
 	envtesting.AssertProviderCredentialsAttributesHidden(c, s.provider, "userpass", "password")
+	envtesting.AssertProviderCredentialsAttributesHidden(c, s.provider, "oauth2", "Token", "RefreshToken", "ClientSecret")
 }
 
 var singleConfigYAML = `
@@ -87,3 +103,117 @@ func (s *credentialsSuite) TestDetectCredentials(c *gc.C) {
 	expected.Label = `kubernetes credential "the-user"`
 	c.Assert(creds.AuthCredentials["the-user"], jc.DeepEquals, expected)
 }
+
+var pluginConfigYAML = `
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://1.1.1.1:8888
+  name: the-cluster
+contexts:
+- context:
+    cluster: the-cluster
+    user: exec-user
+  name: the-context
+current-context: the-context
+preferences: {}
+users:
+- name: exec-user
+  user:
+    exec:
+      command: get-token
+- name: the-user
+  user:
+    token: token
+`
+
+func (s *credentialsSuite) TestDetectCredentialsSkipsPlugins(c *gc.C) {
+	kubeConfig := filepath.Join(utils.Home(), "config")
+	s.PatchEnvironment("KUBECONFIG", kubeConfig)
+	s.Home.AddFiles(c, testing.TestFile{
+		Name: "config",
+		Data: pluginConfigYAML,
+	})
+	creds, err := s.provider.DetectCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(creds.DefaultCredential, gc.Equals, "")
+	c.Assert(creds.AuthCredentials, gc.HasLen, 1)
+	c.Assert(creds.AuthCredentials["the-user"].Attributes(), jc.DeepEquals, map[string]string{
+		"Token": "token",
+	})
+}
+
+func (s *credentialsSuite) TestBearerToken(c *gc.C) {
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	token, err := provider.BearerToken(map[string]string{"Token": "token"}, now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token, gc.Equals, "token")
+
+	token, err = provider.BearerToken(map[string]string{
+		"Token":  "token",
+		"Expiry": "2018-01-01T01:00:00Z",
+	}, now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token, gc.Equals, "token")
+}
+
+func (s *credentialsSuite) TestBearerTokenExpired(c *gc.C) {
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := provider.BearerToken(map[string]string{
+		"Token":  "token",
+		"Expiry": "2017-12-31T23:00:00Z",
+	}, now)
+	c.Assert(err, gc.ErrorMatches, "credential not valid: token expired at 2017-12-31T23:00:00Z")
+	c.Assert(common.IsCredentialNotValid(err), jc.IsTrue)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func (s *credentialsSuite) TestBearerTokenTransport(c *gc.C) {
+	clock := testclock.NewClock(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	var authorization string
+	transport := provider.NewBearerTokenTransport(map[string]string{
+		"Token":  "token",
+		"Expiry": "2018-01-01T01:00:00Z",
+	}, clock, roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		authorization = req.Header.Get("Authorization")
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}))
+
+	req, err := http.NewRequest("GET", "https://1.1.1.1:8888/api", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = transport.RoundTrip(req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(authorization, gc.Equals, "Bearer token")
+	c.Assert(req.Header.Get("Authorization"), gc.Equals, "")
+
+	// Once the token expires, it can't be used and there's no means
+	// of renewing it.
+	clock.Advance(2 * time.Hour)
+	_, err = transport.RoundTrip(req)
+	c.Assert(err, gc.ErrorMatches, "credential not valid: token expired at 2018-01-01T01:00:00Z")
+	c.Assert(common.IsCredentialNotValid(err), jc.IsTrue)
+}
+
+func (s *credentialsSuite) TestMaybeConvertCredentialError(c *gc.C) {
+	var invalidated string
+	ctx := &context.CloudCallContext{
+		InvalidateCredentialFunc: func(reason string) error {
+			invalidated = reason
+			return nil
+		},
+	}
+	err := provider.MaybeConvertCredentialError(errors.New("boom"), ctx)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(invalidated, gc.Equals, "")
+
+	err = provider.MaybeConvertCredentialError(
+		errors.Trace(k8serrors.NewUnauthorized("token expired")), ctx)
+	c.Assert(common.IsCredentialNotValid(err), jc.IsTrue)
+	c.Assert(invalidated, gc.Matches, "kubernetes API rejected credential: token expired")
+}
@@ -4,10 +4,15 @@
 package provider
 
 import (
+	"net/http"
+	"time"
+
+	"github.com/juju/clock"
 	core "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/storage"
 )
 
@@ -18,14 +23,37 @@ var (
 	ExtractRegistryURL     = extractRegistryURL
 	CreateDockerConfigJSON = createDockerConfigJSON
 	NewStorageConfig       = newStorageConfig
+	BearerToken            = bearerToken
+	JobSpecHash            = jobSpecHash
+
+	MaybeConvertCredentialError = maybeConvertCredentialError
 )
 
+func NewBearerTokenTransport(attrs map[string]string, clock clock.Clock, next http.RoundTripper) http.RoundTripper {
+	return &bearerTokenTransport{
+		source: &tokenSource{clock: clock, attrs: attrs},
+		next:   next,
+	}
+}
+
+func IsApplicationEvent(client kubernetes.Interface, namespace, appName string, evt *core.Event) bool {
+	return (&kubernetesClient{Interface: client, namespace: namespace}).isApplicationEvent(appName, evt)
+}
+
+func PodStatusInfo(pod core.Pod, events []core.Event, now time.Time) status.StatusInfo {
+	return (&kubernetesClient{}).podStatusInfo(pod, events, now)
+}
+
+func PVCStatusInfo(pvc core.PersistentVolumeClaim, events []core.Event, now time.Time) status.StatusInfo {
+	return (&kubernetesClient{}).pvcStatusInfo(pvc, events, now)
+}
+
 func PodSpec(u *unitSpec) core.PodSpec {
 	return u.Pod
 }
 
 func NewProvider() caas.ContainerEnvironProvider {
-	return kubernetesEnvironProvider{}
+	return providerInstance
 }
 
 func StorageProvider(k8sClient kubernetes.Interface, namespace string) storage.Provider {
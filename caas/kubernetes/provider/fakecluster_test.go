@@ -0,0 +1,189 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	k8stesting "github.com/juju/juju/caas/kubernetes/provider/testing"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/testing"
+)
+
+// fakeClusterSuite runs the broker against an in-process fake cluster,
+// checking the objects it creates are accepted by the API server and
+// the resulting units are reported back.
+type fakeClusterSuite struct {
+	testing.BaseSuite
+
+	cluster *k8stesting.FakeCluster
+	broker  caas.Broker
+}
+
+var _ = gc.Suite(&fakeClusterSuite{})
+
+func (s *fakeClusterSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.cluster = k8stesting.NewFakeCluster()
+	var err error
+	s.broker, err = s.cluster.NewBroker(testNamespace)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+var gitlabPodSpec = &caas.PodSpec{
+	Containers: []caas.ContainerSpec{{
+		Name:  "gitlab",
+		Image: "gitlab/latest",
+		Ports: []caas.ContainerPort{{ContainerPort: 80, Protocol: "TCP"}},
+		Config: map[string]string{
+			"restricted": "yes",
+		},
+	}},
+}
+
+func (s *fakeClusterSuite) TestEnsureServiceCreatesUnits(c *gc.C) {
+	params := &caas.ServiceParams{PodSpec: gitlabPodSpec}
+	err := s.broker.EnsureService("gitlab", params, 2, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	deployment, err := s.cluster.Clientset.AppsV1().Deployments(testNamespace).Get("juju-gitlab", v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*deployment.Spec.Replicas, gc.Equals, int32(2))
+	_, err = s.cluster.Clientset.CoreV1().Services(testNamespace).Get("juju-gitlab", v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := s.broker.Units("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+	for _, u := range units {
+		c.Check(u.Id, gc.Not(gc.Equals), "")
+		c.Check(u.Address, gc.Not(gc.Equals), "")
+		c.Check(u.Ports, jc.DeepEquals, []string{"80/TCP"})
+		c.Check(u.Status.Status, gc.Equals, status.Running)
+	}
+}
+
+func (s *fakeClusterSuite) TestEnsureServiceScales(c *gc.C) {
+	params := &caas.ServiceParams{PodSpec: gitlabPodSpec}
+	err := s.broker.EnsureService("gitlab", params, 3, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.broker.EnsureService("gitlab", params, 1, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := s.broker.Units("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+}
+
+func (s *fakeClusterSuite) TestEnsureServiceRejectsInvalidSpec(c *gc.C) {
+	podSpec := *gitlabPodSpec
+	podSpec.Containers = []caas.ContainerSpec{{
+		Name:  "Git_Lab",
+		Image: "gitlab/latest",
+		Ports: []caas.ContainerPort{{ContainerPort: 80, Protocol: "TCP"}},
+	}}
+	params := &caas.ServiceParams{PodSpec: &podSpec}
+	err := s.broker.EnsureService("gitlab", params, 1, nil)
+	c.Assert(err, gc.ErrorMatches, `creating or updating DeploymentController: .*spec.template.spec.containers\[0\].name: Invalid value: "Git_Lab".*`)
+
+	units, err := s.broker.Units("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 0)
+}
+
+func (s *fakeClusterSuite) TestEnsureServiceDaemonSet(c *gc.C) {
+	podSpec := *gitlabPodSpec
+	podSpec.WorkloadKind = caas.WorkloadDaemonSet
+	params := &caas.ServiceParams{PodSpec: &podSpec}
+	err := s.broker.EnsureService("gitlab", params, 3, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// There are no registered nodes, so the cluster has a single node.
+	units, err := s.broker.Units("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+}
+
+func (s *fakeClusterSuite) TestEnsureServiceJobCompleted(c *gc.C) {
+	s.cluster.SetPodPhase(core.PodSucceeded)
+	podSpec := *gitlabPodSpec
+	podSpec.WorkloadKind = caas.WorkloadJob
+	params := &caas.ServiceParams{PodSpec: &podSpec}
+	err := s.broker.EnsureService("gitlab", params, 2, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := s.broker.Units("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+	for _, u := range units {
+		c.Check(u.Status.Status, gc.Equals, status.Stopped)
+	}
+}
+
+func (s *fakeClusterSuite) TestUnitsReportsEvents(c *gc.C) {
+	s.cluster.SetPodPhase(core.PodPending)
+	params := &caas.ServiceParams{PodSpec: gitlabPodSpec}
+	err := s.broker.EnsureService("gitlab", params, 1, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	pods, err := s.cluster.Pods(testNamespace)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pods, gc.HasLen, 1)
+	err = s.cluster.AddEvent(testNamespace, pods[0].Name, core.EventTypeWarning, "FailedScheduling", "0/1 nodes are available")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.cluster.AddEvent(testNamespace, "juju-other-0", core.EventTypeWarning, "FailedMount", "unrelated")
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := s.broker.Units("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].Status.Status, gc.Equals, status.Allocating)
+	c.Assert(units[0].Status.Message, gc.Matches, ".*0/1 nodes are available.*")
+}
+
+func (s *fakeClusterSuite) TestDeleteServiceRemovesUnits(c *gc.C) {
+	params := &caas.ServiceParams{PodSpec: gitlabPodSpec}
+	err := s.broker.EnsureService("gitlab", params, 2, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.broker.DeleteService("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := s.broker.Units("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 0)
+	_, err = s.broker.Service("gitlab")
+	c.Assert(err, gc.NotNil)
+}
+
+func (s *fakeClusterSuite) TestWatchUnits(c *gc.C) {
+	w, err := s.broker.WatchUnits("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		w.Kill()
+		c.Assert(w.Wait(), jc.ErrorIsNil)
+	}()
+
+	assertChange := func() {
+		select {
+		case _, ok := <-w.Changes():
+			c.Assert(ok, jc.IsTrue)
+		case <-time.After(testing.LongWait):
+			c.Fatalf("timed out waiting for unit change")
+		}
+	}
+	// Initial event.
+	assertChange()
+
+	params := &caas.ServiceParams{PodSpec: gitlabPodSpec}
+	err = s.broker.EnsureService("gitlab", params, 1, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	assertChange()
+}
@@ -5,7 +5,11 @@ package provider
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
@@ -16,8 +20,11 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/retry"
+	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	core "k8s.io/api/core/v1"
 	"k8s.io/api/extensions/v1beta1"
 	k8sstorage "k8s.io/api/storage/v1"
@@ -26,9 +33,9 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -53,11 +60,23 @@ const (
 	labelVersion     = "juju-version"
 	labelApplication = "juju-application"
 
+	operatorContainerName    = "juju-operator"
 	operatorStorageClassName = "juju-operator-storage"
 	// TODO(caas) - make this configurable using application config
 	operatorStorageSize = "10Mi"
 
 	gpuAffinityNodeSelectorKey = "gpu"
+
+	// These annotations on an operator pod record what it ran before
+	// its last in place upgrade, and the version of the last upgrade
+	// which was rolled back.
+	annotationPreviousImage   = "juju.io/previous-operator-image"
+	annotationPreviousVersion = "juju.io/previous-operator-version"
+	annotationFailedVersion   = "juju.io/failed-operator-version"
+
+	// annotationJobSpecHash records the hash of the spec a job was
+	// created with, so that changes to the spec can be detected.
+	annotationJobSpecHash = "juju.io/job-spec-hash"
 )
 
 var defaultPropagationPolicy = v1.DeletePropagationForeground
@@ -71,10 +90,14 @@ type kubernetesClient struct {
 	namespace string
 }
 
+var _ caas.OperatorUpgrader = (*kubernetesClient)(nil)
+
 // To regenerate the mocks for the kubernetes Client used by this broker,
 // run "go generate" from the package directory.
 //go:generate mockgen -package mocks -destination mocks/k8sclient_mock.go k8s.io/client-go/kubernetes Interface
-//go:generate mockgen -package mocks -destination mocks/appv1_mock.go k8s.io/client-go/kubernetes/typed/apps/v1 AppsV1Interface,DeploymentInterface,StatefulSetInterface
+//go:generate mockgen -package mocks -destination mocks/appv1_mock.go k8s.io/client-go/kubernetes/typed/apps/v1 AppsV1Interface,DaemonSetInterface,DeploymentInterface,StatefulSetInterface
+//go:generate mockgen -package mocks -destination mocks/batchv1_mock.go k8s.io/client-go/kubernetes/typed/batch/v1 BatchV1Interface,JobInterface
+//go:generate mockgen -package mocks -destination mocks/batchv1beta1_mock.go k8s.io/client-go/kubernetes/typed/batch/v1beta1 BatchV1beta1Interface,CronJobInterface
 //go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface
 //go:generate mockgen -package mocks -destination mocks/extenstionsv1_mock.go k8s.io/client-go/kubernetes/typed/extensions/v1beta1 ExtensionsV1beta1Interface,IngressInterface
 //go:generate mockgen -package mocks -destination mocks/storagev1_mock.go k8s.io/client-go/kubernetes/typed/storage/v1 StorageV1Interface,StorageClassInterface
@@ -109,8 +132,11 @@ func newK8sConfig(cloudSpec environs.CloudSpec) (*rest.Config, error) {
 		CAData = append(CAData, cacert...)
 	}
 
-	credentialAttrs := cloudSpec.Credential.Attributes()
-	return &rest.Config{
+	credentialAttrs, err := refreshCredentialAttrs(cloudSpec.Credential.Attributes(), time.Now())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	config := &rest.Config{
 		Host:     cloudSpec.Endpoint,
 		Username: credentialAttrs[CredAttrUsername],
 		Password: credentialAttrs[CredAttrPassword],
@@ -119,7 +145,14 @@ func newK8sConfig(cloudSpec environs.CloudSpec) (*rest.Config, error) {
 			KeyData:  []byte(credentialAttrs["ClientKeyData"]),
 			CAData:   CAData,
 		},
-	}, nil
+	}
+	if credentialAttrs[CredAttrToken] != "" {
+		source := &tokenSource{clock: clock.WallClock, attrs: credentialAttrs}
+		config.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+			return &bearerTokenTransport{source: source, next: rt}
+		}
+	}
+	return config, nil
 }
 
 // Provider is part of the Broker interface.
@@ -128,12 +161,13 @@ func (*kubernetesClient) Provider() caas.ContainerEnvironProvider {
 }
 
 // Destroy is part of the Broker interface.
-func (k *kubernetesClient) Destroy(context.ProviderCallContext) error {
-	return k.deleteNamespace()
+func (k *kubernetesClient) Destroy(ctx context.ProviderCallContext) error {
+	return maybeConvertCredentialError(k.deleteNamespace(), ctx)
 }
 
 // EnsureNamespace ensures this broker's namespace is created.
-func (k *kubernetesClient) EnsureNamespace() error {
+func (k *kubernetesClient) EnsureNamespace() (err error) {
+	defer convertCredentialError(&err)
 	ns := &core.Namespace{ObjectMeta: v1.ObjectMeta{Name: k.namespace}}
 	namespaces := k.CoreV1().Namespaces()
 	_, err := namespaces.Update(ns)
@@ -161,7 +195,8 @@ func (k *kubernetesClient) deleteNamespace() error {
 }
 
 // EnsureSecret ensures a secret exists for use with retrieving images from private registries
-func (k *kubernetesClient) EnsureSecret(imageSecretName, appName string, imageDetails *caas.ImageDetails) error {
+func (k *kubernetesClient) EnsureSecret(imageSecretName, appName string, imageDetails *caas.ImageDetails) (err error) {
+	defer convertCredentialError(&err)
 	if imageDetails.Password == "" {
 		return errors.New("attempting to create a secret with no password")
 	}
@@ -203,7 +238,8 @@ func (k *kubernetesClient) deleteSecret(appName, containerName string) error {
 
 // EnsureOperator creates or updates an operator pod with the given application
 // name, agent path, and operator config.
-func (k *kubernetesClient) EnsureOperator(appName, agentPath string, config *caas.OperatorConfig) error {
+func (k *kubernetesClient) EnsureOperator(appName, agentPath string, config *caas.OperatorConfig) (err error) {
+	defer convertCredentialError(&err)
 	logger.Debugf("creating/updating %s operator", appName)
 
 	// TODO(caas) - this is a stop gap until we implement a CAAS model manager worker
@@ -433,6 +469,7 @@ func (k *kubernetesClient) ensureStorageClass(cfg *storageConfig) error {
 
 // DeleteOperator deletes the specified operator.
 func (k *kubernetesClient) DeleteOperator(appName string) (err error) {
+	defer convertCredentialError(&err)
 	logger.Debugf("deleting %s operator", appName)
 
 	// First delete any persistent volume claim.
@@ -461,7 +498,8 @@ func (k *kubernetesClient) DeleteOperator(appName string) (err error) {
 }
 
 // Service returns the service for the specified application.
-func (k *kubernetesClient) Service(appName string) (*caas.Service, error) {
+func (k *kubernetesClient) Service(appName string) (_ *caas.Service, err error) {
+	defer convertCredentialError(&err)
 	services := k.CoreV1().Services(k.namespace)
 	servicesList, err := services.List(v1.ListOptions{
 		LabelSelector: applicationSelector(appName),
@@ -502,14 +540,21 @@ func (k *kubernetesClient) Service(appName string) (*caas.Service, error) {
 
 // DeleteService deletes the specified service.
 func (k *kubernetesClient) DeleteService(appName string) (err error) {
+	defer convertCredentialError(&err)
 	logger.Debugf("deleting application %s", appName)
 
 	if err := k.deleteService(appName); err != nil {
 		return errors.Trace(err)
 	}
-	if err := k.deleteStatefulSet(appName); err != nil {
+	existing, err := k.existingWorkloadKinds(appName)
+	if err != nil {
 		return errors.Trace(err)
 	}
+	if existing[caas.WorkloadStatefulSet] {
+		if err := k.deleteStatefulSet(appName); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	pods := k.CoreV1().Pods(k.namespace)
 	podsList, err := pods.List(v1.ListOptions{
 		LabelSelector: applicationSelector(appName),
@@ -522,11 +567,20 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 			return errors.Trace(err)
 		}
 	}
-	return errors.Trace(k.deleteDeployment(appName))
+	for _, kind := range workloadKinds {
+		if kind == caas.WorkloadStatefulSet || !existing[kind] {
+			continue
+		}
+		if err := k.deleteWorkload(appName, kind); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
 }
 
 // EnsureCustomResourceDefinition creates or updates a custom resource definition resource.
-func (k *kubernetesClient) EnsureCustomResourceDefinition(appName string, podSpec *caas.PodSpec) error {
+func (k *kubernetesClient) EnsureCustomResourceDefinition(appName string, podSpec *caas.PodSpec) (err error) {
+	defer convertCredentialError(&err)
 	for _, t := range podSpec.CustomResourceDefinitions {
 		crd, err := k.ensureCustomResourceDefinitionTemplate(&t)
 		if err != nil {
@@ -576,6 +630,7 @@ func (k *kubernetesClient) ensureCustomResourceDefinitionTemplate(t *caas.Custom
 func (k *kubernetesClient) EnsureService(
 	appName string, params *caas.ServiceParams, numUnits int, config application.ConfigAttributes,
 ) (err error) {
+	defer convertCredentialError(&err)
 	logger.Debugf("creating/updating application %s", appName)
 
 	if numUnits < 0 {
@@ -590,7 +645,22 @@ func (k *kubernetesClient) EnsureService(
 	if params.PodSpec.OmitServiceFrontend && len(params.Filesystems) == 0 {
 		return errors.Errorf("kubernetes service is required when using storage")
 	}
+	workloadKind := params.PodSpec.WorkloadKind
+	if len(params.Filesystems) > 0 && !workloadKind.SupportsStorage() {
+		return errors.NotSupportedf("storage for %q workloads", workloadKind)
+	}
 
+	existing, err := k.existingWorkloadKinds(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if workloadKind == caas.WorkloadDefault {
+		// Defensively keep using a stateful set if one is already used.
+		workloadKind = caas.WorkloadDeployment
+		if len(params.Filesystems) > 0 || existing[caas.WorkloadStatefulSet] {
+			workloadKind = caas.WorkloadStatefulSet
+		}
+	}
 	var cleanups []func()
 	defer func() {
 		if err == nil {
@@ -622,34 +692,49 @@ func (k *kubernetesClient) EnsureService(
 		cleanups = append(cleanups, func() { k.deleteSecret(appName, c.Name) })
 	}
 
-	// Add a deployment controller or stateful set configured to create the specified number of units/pods.
-	// Defensively check to see if a stateful set is already used.
-	useStatefulSet := len(params.Filesystems) > 0
-	if !useStatefulSet {
-		statefulsets := k.AppsV1().StatefulSets(k.namespace)
-		_, err := statefulsets.Get(deploymentName(appName), v1.GetOptions{IncludeUninitialized: true})
-		if err != nil && !k8serrors.IsNotFound(err) {
-			return errors.Trace(err)
+	numPods := int32(numUnits)
+	switch workloadKind {
+	case caas.WorkloadDaemonSet:
+		// A daemon set runs a pod on each node, so the number of units is ignored.
+		if err := k.configureDaemonSet(appName, unitSpec, params.PodSpec.Containers); err != nil {
+			return errors.Annotate(err, "creating or updating DaemonSet")
 		}
-		useStatefulSet = err == nil
-		if useStatefulSet {
-			logger.Debugf("no updated filesystems but already using stateful set for %v", appName)
+		cleanups = append(cleanups, func() { k.deleteDaemonSet(appName) })
+	case caas.WorkloadJob:
+		if err := k.configureJob(appName, unitSpec, params.PodSpec.Containers, &numPods); err != nil {
+			return errors.Annotate(err, "creating or updating Job")
 		}
-	}
-
-	numPods := int32(numUnits)
-	if useStatefulSet {
+		cleanups = append(cleanups, func() { k.deleteJob(appName) })
+	case caas.WorkloadCronJob:
+		if err := k.configureCronJob(appName, params.PodSpec.Schedule, unitSpec, params.PodSpec.Containers, &numPods); err != nil {
+			return errors.Annotate(err, "creating or updating CronJob")
+		}
+		cleanups = append(cleanups, func() { k.deleteCronJob(appName) })
+	case caas.WorkloadStatefulSet:
 		if err := k.configureStatefulSet(appName, unitSpec, params.PodSpec.Containers, &numPods, params.Filesystems); err != nil {
 			return errors.Annotate(err, "creating or updating StatefulSet")
 		}
-		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
-	} else {
+		cleanups = append(cleanups, func() { k.deleteStatefulSet(appName) })
+	default:
 		if err := k.configureDeployment(appName, unitSpec, params.PodSpec.Containers, &numPods); err != nil {
 			return errors.Annotate(err, "creating or updating DeploymentController")
 		}
 		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
 	}
 
+	// Only one kind of workload may run the application's pods, so
+	// once the new workload exists remove any left behind when the
+	// workload kind is changed.
+	for _, kind := range workloadKinds {
+		if kind == workloadKind || !existing[kind] {
+			continue
+		}
+		logger.Infof("removing %s for %s, now run by a %s", kind, appName, workloadKind)
+		if err := k.deleteWorkload(appName, kind); err != nil {
+			return errors.Annotatef(err, "removing %s for %s", kind, appName)
+		}
+	}
+
 	var ports []core.ContainerPort
 	for _, c := range unitSpec.Pod.Containers {
 		for _, p := range c.Ports {
@@ -667,30 +752,103 @@ func (k *kubernetesClient) EnsureService(
 	return nil
 }
 
+// workloadKinds are the kinds of workload which may run the pods of
+// an application, in the order they're checked for and removed.
+var workloadKinds = []caas.WorkloadKind{
+	caas.WorkloadStatefulSet,
+	caas.WorkloadDeployment,
+	caas.WorkloadDaemonSet,
+	caas.WorkloadJob,
+	caas.WorkloadCronJob,
+}
+
+// existingWorkloadKinds returns the kinds of workload which currently
+// run the pods of the specified application.
+func (k *kubernetesClient) existingWorkloadKinds(appName string) (map[caas.WorkloadKind]bool, error) {
+	name := deploymentName(appName)
+	opts := v1.GetOptions{IncludeUninitialized: true}
+	existing := make(map[caas.WorkloadKind]bool)
+	for _, kind := range workloadKinds {
+		var err error
+		switch kind {
+		case caas.WorkloadStatefulSet:
+			_, err = k.AppsV1().StatefulSets(k.namespace).Get(name, opts)
+		case caas.WorkloadDeployment:
+			_, err = k.AppsV1().Deployments(k.namespace).Get(name, opts)
+		case caas.WorkloadDaemonSet:
+			_, err = k.AppsV1().DaemonSets(k.namespace).Get(name, opts)
+		case caas.WorkloadJob:
+			_, err = k.BatchV1().Jobs(k.namespace).Get(name, opts)
+		case caas.WorkloadCronJob:
+			_, err = k.BatchV1beta1().CronJobs(k.namespace).Get(name, opts)
+		}
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting %s for %s", kind, appName)
+		}
+		existing[kind] = true
+	}
+	return existing, nil
+}
+
+// deleteWorkload deletes the workload of the specified kind which
+// runs the pods of the application.
+func (k *kubernetesClient) deleteWorkload(appName string, kind caas.WorkloadKind) error {
+	switch kind {
+	case caas.WorkloadStatefulSet:
+		return k.deleteStatefulSet(appName)
+	case caas.WorkloadDeployment:
+		return k.deleteDeployment(appName)
+	case caas.WorkloadDaemonSet:
+		return k.deleteDaemonSet(appName)
+	case caas.WorkloadJob:
+		return k.deleteJob(appName)
+	case caas.WorkloadCronJob:
+		return k.deleteCronJob(appName)
+	}
+	return errors.NotValidf("workload kind %q", kind)
+}
+
 func (k *kubernetesClient) deleteAllPods(appName string) error {
-	zero := int32(0)
-	statefulsets := k.AppsV1().StatefulSets(k.namespace)
-	statefulSet, err := statefulsets.Get(deploymentName(appName), v1.GetOptions{IncludeUninitialized: true})
-	if err != nil && !k8serrors.IsNotFound(err) {
+	existing, err := k.existingWorkloadKinds(appName)
+	if err != nil {
 		return errors.Trace(err)
 	}
-	if err == nil {
+	zero := int32(0)
+	if existing[caas.WorkloadStatefulSet] {
+		statefulsets := k.AppsV1().StatefulSets(k.namespace)
+		statefulSet, err := statefulsets.Get(deploymentName(appName), v1.GetOptions{IncludeUninitialized: true})
+		if err != nil {
+			return errors.Trace(err)
+		}
 		statefulSet.Spec.Replicas = &zero
 		_, err = statefulsets.Update(statefulSet)
 		return errors.Trace(err)
 	}
-
-	deployments := k.AppsV1().Deployments(k.namespace)
-	deployment, err := deployments.Get(deploymentName(appName), v1.GetOptions{IncludeUninitialized: true})
-	if k8serrors.IsNotFound(err) {
-		return nil
-	}
-	if err != nil {
+	if existing[caas.WorkloadDeployment] {
+		deployments := k.AppsV1().Deployments(k.namespace)
+		deployment, err := deployments.Get(deploymentName(appName), v1.GetOptions{IncludeUninitialized: true})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		deployment.Spec.Replicas = &zero
+		_, err = deployments.Update(deployment)
 		return errors.Trace(err)
 	}
-	deployment.Spec.Replicas = &zero
-	_, err = deployments.Update(deployment)
-	return errors.Trace(err)
+
+	// Daemon sets can't be scaled, and jobs can only be scaled
+	// by parallelism, so remove them instead.
+	for _, kind := range []caas.WorkloadKind{caas.WorkloadDaemonSet, caas.WorkloadJob, caas.WorkloadCronJob} {
+		if !existing[kind] {
+			continue
+		}
+		if err := k.deleteWorkload(appName, kind); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
 }
 
 func (k *kubernetesClient) configureStorage(
@@ -925,6 +1083,218 @@ func (k *kubernetesClient) deleteStatefulSet(appName string) error {
 	return errors.Trace(err)
 }
 
+func (k *kubernetesClient) configureDaemonSet(appName string, unitSpec *unitSpec, containers []caas.ContainerSpec) error {
+	logger.Debugf("creating/updating daemon set for %s", appName)
+
+	cfgName := func(fileSetName string) string {
+		return applicationConfigMapName(appName, fileSetName)
+	}
+	podSpec := unitSpec.Pod
+	if err := k.configurePodFiles(&podSpec, containers, cfgName); err != nil {
+		return errors.Trace(err)
+	}
+	return k.ensureDaemonSet(daemonSetSpec(appName, podSpec))
+}
+
+// daemonSetSpec returns the daemon set used to run one
+// unit of the application on each node.
+func daemonSetSpec(appName string, podSpec core.PodSpec) *apps.DaemonSet {
+	return &apps.DaemonSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   deploymentName(appName),
+			Labels: map[string]string{labelApplication: appName}},
+		Spec: apps.DaemonSetSpec{
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{labelApplication: appName},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: resourceNamePrefix(appName),
+					Labels:       map[string]string{labelApplication: appName},
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
+
+func (k *kubernetesClient) ensureDaemonSet(spec *apps.DaemonSet) error {
+	daemonSets := k.AppsV1().DaemonSets(k.namespace)
+	_, err := daemonSets.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = daemonSets.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteDaemonSet(appName string) error {
+	daemonSets := k.AppsV1().DaemonSets(k.namespace)
+	err := daemonSets.Delete(deploymentName(appName), &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) configureJob(appName string, unitSpec *unitSpec, containers []caas.ContainerSpec, parallelism *int32) error {
+	logger.Debugf("creating/updating job for %s", appName)
+
+	cfgName := func(fileSetName string) string {
+		return applicationConfigMapName(appName, fileSetName)
+	}
+	podSpec := unitSpec.Pod
+	if err := k.configurePodFiles(&podSpec, containers, cfgName); err != nil {
+		return errors.Trace(err)
+	}
+	return k.ensureJob(jobSpec(appName, podSpec, parallelism))
+}
+
+// jobSpec returns the job used to run the specified
+// number of units of the application to completion.
+func jobSpec(appName string, podSpec core.PodSpec, parallelism *int32) *batch.Job {
+	// Job pods may not be restarted "Always".
+	if podSpec.RestartPolicy == "" || podSpec.RestartPolicy == core.RestartPolicyAlways {
+		podSpec.RestartPolicy = core.RestartPolicyOnFailure
+	}
+	return &batch.Job{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   deploymentName(appName),
+			Labels: map[string]string{labelApplication: appName}},
+		Spec: batch.JobSpec{
+			Parallelism: parallelism,
+			Completions: parallelism,
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: resourceNamePrefix(appName),
+					Labels:       map[string]string{labelApplication: appName},
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
+
+// ensureJob creates the job, or replaces it if its spec has changed.
+// The pod template and completions of a job are immutable, so a job
+// whose spec has changed is deleted and created again, which runs the
+// application's units to completion once more.
+func (k *kubernetesClient) ensureJob(spec *batch.Job) error {
+	hash, err := jobSpecHash(spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Annotations == nil {
+		spec.Annotations = make(map[string]string)
+	}
+	spec.Annotations[annotationJobSpecHash] = hash
+
+	jobs := k.BatchV1().Jobs(k.namespace)
+	_, err = jobs.Create(spec)
+	if !k8serrors.IsAlreadyExists(err) {
+		return errors.Trace(err)
+	}
+	existing, err := jobs.Get(spec.Name, v1.GetOptions{IncludeUninitialized: true})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if existing.Annotations[annotationJobSpecHash] == hash {
+		return nil
+	}
+
+	logger.Infof("replacing job %s, its spec has changed", spec.Name)
+	// The job is removed at once and its pods in the background,
+	// so that the new job can be created straight away.
+	backgroundPropagationPolicy := v1.DeletePropagationBackground
+	err = jobs.Delete(spec.Name, &v1.DeleteOptions{
+		PropagationPolicy: &backgroundPropagationPolicy,
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Annotatef(err, "deleting job %s", spec.Name)
+	}
+	_, err = jobs.Create(spec)
+	return errors.Annotatef(err, "creating job %s", spec.Name)
+}
+
+// jobSpecHash returns a hash of the spec of the job.
+func jobSpecHash(job *batch.Job) (string, error) {
+	data, err := json.Marshal(job.Spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (k *kubernetesClient) deleteJob(appName string) error {
+	jobs := k.BatchV1().Jobs(k.namespace)
+	err := jobs.Delete(deploymentName(appName), &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) configureCronJob(
+	appName, schedule string, unitSpec *unitSpec, containers []caas.ContainerSpec, parallelism *int32,
+) error {
+	logger.Debugf("creating/updating cron job for %s", appName)
+
+	cfgName := func(fileSetName string) string {
+		return applicationConfigMapName(appName, fileSetName)
+	}
+	podSpec := unitSpec.Pod
+	if err := k.configurePodFiles(&podSpec, containers, cfgName); err != nil {
+		return errors.Trace(err)
+	}
+	return k.ensureCronJob(cronJobSpec(appName, schedule, podSpec, parallelism))
+}
+
+// cronJobSpec returns the cron job used to run the specified
+// number of units of the application to completion on a schedule.
+func cronJobSpec(appName, schedule string, podSpec core.PodSpec, parallelism *int32) *batchv1beta1.CronJob {
+	job := jobSpec(appName, podSpec, parallelism)
+	return &batchv1beta1.CronJob{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   deploymentName(appName),
+			Labels: map[string]string{labelApplication: appName}},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule: schedule,
+			// Units are not expected to run more than once at a time.
+			ConcurrencyPolicy: batchv1beta1.ForbidConcurrent,
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					Labels: map[string]string{labelApplication: appName},
+				},
+				Spec: job.Spec,
+			},
+		},
+	}
+}
+
+func (k *kubernetesClient) ensureCronJob(spec *batchv1beta1.CronJob) error {
+	cronJobs := k.BatchV1beta1().CronJobs(k.namespace)
+	_, err := cronJobs.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = cronJobs.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteCronJob(appName string) error {
+	cronJobs := k.BatchV1beta1().CronJobs(k.namespace)
+	err := cronJobs.Delete(deploymentName(appName), &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
 func (k *kubernetesClient) deleteVolumeClaims(p *core.Pod) error {
 	volumesByName := make(map[string]core.Volume)
 	for _, pv := range p.Spec.Volumes {
@@ -1025,7 +1395,8 @@ func (k *kubernetesClient) deleteService(appName string) error {
 }
 
 // ExposeService sets up external access to the specified application.
-func (k *kubernetesClient) ExposeService(appName string, config application.ConfigAttributes) error {
+func (k *kubernetesClient) ExposeService(appName string, config application.ConfigAttributes) (err error) {
+	defer convertCredentialError(&err)
 	logger.Debugf("creating/updating ingress resource for %s", appName)
 
 	host := config.GetString(caas.JujuExternalHostNameKey, "")
@@ -1080,7 +1451,8 @@ func (k *kubernetesClient) ExposeService(appName string, config application.Conf
 }
 
 // UnexposeService removes external access to the specified service.
-func (k *kubernetesClient) UnexposeService(appName string) error {
+func (k *kubernetesClient) UnexposeService(appName string) (err error) {
+	defer convertCredentialError(&err)
 	logger.Debugf("deleting ingress resource for %s", appName)
 	return k.deleteIngress(appName)
 }
@@ -1114,17 +1486,35 @@ func applicationSelector(appName string) string {
 }
 
 // WatchUnits returns a watcher which notifies when there
-// are changes to units of the specified application.
-func (k *kubernetesClient) WatchUnits(appName string) (watcher.NotifyWatcher, error) {
+// are changes to units of the specified application, or
+// events are recorded against their pods or volume claims.
+func (k *kubernetesClient) WatchUnits(appName string) (_ watcher.NotifyWatcher, err error) {
+	defer convertCredentialError(&err)
 	pods := k.CoreV1().Pods(k.namespace)
-	w, err := pods.Watch(v1.ListOptions{
+	podWatcher, err := pods.Watch(v1.ListOptions{
 		LabelSelector: applicationSelector(appName),
 		Watch:         true,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return newKubernetesWatcher(w, appName)
+	events := k.CoreV1().Events(k.namespace)
+	eventWatcher, err := events.Watch(v1.ListOptions{
+		Watch: true,
+	})
+	if err != nil {
+		podWatcher.Stop()
+		return nil, errors.Trace(err)
+	}
+	appEvents := watch.Filter(eventWatcher, func(in watch.Event) (watch.Event, bool) {
+		evt, ok := in.Object.(*core.Event)
+		if !ok {
+			// Pass through errors etc.
+			return in, true
+		}
+		return in, k.isApplicationEvent(appName, evt)
+	})
+	return newKubernetesWatcher(newMultiWatch(podWatcher, appEvents), appName)
 }
 
 // jujuPVNameRegexp matches how Juju labels persistent volumes.
@@ -1133,7 +1523,8 @@ var jujuPVNameRegexp = regexp.MustCompile(`^juju-(?P<storageName>\D+)-\d+$`)
 
 // Units returns all units and any associated filesystems of the specified application.
 // Filesystems are mounted via volumes bound to the unit.
-func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
+func (k *kubernetesClient) Units(appName string) (_ []caas.Unit, err error) {
+	defer convertCredentialError(&err)
 	pods := k.CoreV1().Pods(k.namespace)
 	podsList, err := pods.List(v1.ListOptions{
 		LabelSelector: applicationSelector(appName),
@@ -1151,47 +1542,18 @@ func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
 				ports = append(ports, fmt.Sprintf("%v/%v", p.ContainerPort, p.Protocol))
 			}
 		}
-		terminated := p.DeletionTimestamp != nil
-		unitStatus := k.jujuStatus(p.Status.Phase, terminated)
-		statusMessage := p.Status.Message
-		since := now
-		if statusMessage == "" {
-			for _, cond := range p.Status.Conditions {
-				statusMessage = cond.Message
-				since = cond.LastProbeTime.Time
-				if cond.Type == core.PodScheduled && cond.Reason == core.PodReasonUnschedulable {
-					unitStatus = status.Allocating
-					break
-				}
-			}
-		}
-
-		if statusMessage == "" {
-			// If there are any events for this pod we can use the
-			// most recent to set the status.
-			events := k.CoreV1().Events(k.namespace)
-			eventList, err := events.List(v1.ListOptions{
-				IncludeUninitialized: true,
-				FieldSelector:        fields.OneTermEqualSelector("involvedObject.name", p.Name).String(),
-			})
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-			// Take the most recent event.
-			if count := len(eventList.Items); count > 0 {
-				statusMessage = eventList.Items[count-1].Message
-			}
+		events, err := k.eventsFor(p.Name)
+		if err != nil {
+			return nil, errors.Trace(err)
 		}
+		terminated := p.DeletionTimestamp != nil
+		unitStatus := k.podStatusInfo(p, events, now)
 		unitInfo := caas.Unit{
 			Id:      string(p.UID),
 			Address: p.Status.PodIP,
 			Ports:   ports,
 			Dying:   terminated,
-			Status: status.StatusInfo{
-				Status:  unitStatus,
-				Message: statusMessage,
-				Since:   &since,
-			},
+			Status:  unitStatus,
 		}
 
 		volumesByName := make(map[string]core.Volume)
@@ -1238,28 +1600,11 @@ func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
 				return nil, errors.Trace(err)
 			}
 
-			statusMessage := ""
-			since = now
-			if len(pvc.Status.Conditions) > 0 {
-				statusMessage = pvc.Status.Conditions[0].Message
-				since = pvc.Status.Conditions[0].LastProbeTime.Time
-			}
-			if statusMessage == "" {
-				// If there are any events for this pvc we can use the
-				// most recent to set the status.
-				events := k.CoreV1().Events(k.namespace)
-				eventList, err := events.List(v1.ListOptions{
-					IncludeUninitialized: true,
-					FieldSelector:        fields.OneTermEqualSelector("involvedObject.name", pvc.Name).String(),
-				})
-				if err != nil {
-					return nil, errors.Trace(err)
-				}
-				// Take the most recent event.
-				if count := len(eventList.Items); count > 0 {
-					statusMessage = eventList.Items[count-1].Message
-				}
+			pvcEvents, err := k.eventsFor(pvc.Name)
+			if err != nil {
+				return nil, errors.Trace(err)
 			}
+			fsStatus := k.pvcStatusInfo(*pvc, pvcEvents, now)
 
 			unitInfo.FilesystemInfo = append(unitInfo.FilesystemInfo, caas.FilesystemInfo{
 				StorageName:  storageName,
@@ -1267,11 +1612,7 @@ func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
 				FilesystemId: pvc.Name,
 				MountPoint:   volMount.MountPath,
 				ReadOnly:     volMount.ReadOnly,
-				Status: status.StatusInfo{
-					Status:  k.jujuFilesystemStatus(pvc.Status.Phase),
-					Message: statusMessage,
-					Since:   &since,
-				},
+				Status:       fsStatus,
 				Volume: caas.VolumeInfo{
 					VolumeId:   pv.Name,
 					Size:       uint64(pv.Size()),
@@ -1279,7 +1620,7 @@ func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
 					Status: status.StatusInfo{
 						Status:  k.jujuVolumeStatus(pv.Status.Phase),
 						Message: pv.Status.Message,
-						Since:   &since,
+						Since:   fsStatus.Since,
 					},
 				},
 			})
@@ -1296,6 +1637,8 @@ func (k *kubernetesClient) jujuStatus(podPhase core.PodPhase, terminated bool) s
 	switch podPhase {
 	case core.PodRunning:
 		return status.Running
+	case core.PodSucceeded:
+		return status.Stopped
 	case core.PodFailed:
 		return status.Error
 	case core.PodPending:
@@ -1375,7 +1718,7 @@ func (k *kubernetesClient) maybeUpdatePodImage(selector, jujuVersion, image stri
 	// If the pod is for the same Juju version, we know the pod spec
 	// will not have changed so can just update the image.
 	// TODO(caas) - we should compare the old and new pod specs
-	if pod.Labels[jujuVersion] != jujuVersion {
+	if pod.Labels[labelVersion] != jujuVersion {
 		return errors.New("version mismatch")
 	}
 	pod.Spec.Containers[0].Image = image
@@ -1383,6 +1726,156 @@ func (k *kubernetesClient) maybeUpdatePodImage(selector, jujuVersion, image stri
 	return errors.Trace(err)
 }
 
+// getOperatorPod returns the pod running the operator for the specified application.
+func (k *kubernetesClient) getOperatorPod(appName string) (*core.Pod, error) {
+	pods := k.CoreV1().Pods(k.namespace)
+	podList, err := pods.List(v1.ListOptions{
+		LabelSelector: operatorSelector(appName),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(podList.Items) == 0 {
+		return nil, errors.NotFoundf("operator for %q", appName)
+	}
+	return &podList.Items[0], nil
+}
+
+// Operator is part of the caas.OperatorUpgrader interface.
+func (k *kubernetesClient) Operator(appName string) (_ *caas.Operator, err error) {
+	defer convertCredentialError(&err)
+	pod, err := k.getOperatorPod(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &caas.Operator{
+		Image:         pod.Spec.Containers[0].Image,
+		PreviousImage: pod.Annotations[annotationPreviousImage],
+		Status:        k.operatorStatusInfo(*pod, time.Now()),
+	}
+	if result.Version, err = version.Parse(pod.Labels[labelVersion]); err != nil {
+		return nil, errors.Annotatef(err, "parsing version of operator for %q", appName)
+	}
+	if prev := pod.Annotations[annotationPreviousVersion]; prev != "" {
+		if result.PreviousVersion, err = version.Parse(prev); err != nil {
+			return nil, errors.Annotatef(err, "parsing previous version of operator for %q", appName)
+		}
+	}
+	if failed := pod.Annotations[annotationFailedVersion]; failed != "" {
+		if result.FailedVersion, err = version.Parse(failed); err != nil {
+			return nil, errors.Annotatef(err, "parsing failed version of operator for %q", appName)
+		}
+	}
+	return result, nil
+}
+
+// operatorStatusInfo returns the status of an operator pod. The operator
+// is only considered running once the pod is ready and its container is
+// running the image in the pod spec; after an in place upgrade the
+// container keeps reporting the old image until it has been restarted.
+func (k *kubernetesClient) operatorStatusInfo(pod core.Pod, now time.Time) status.StatusInfo {
+	info := k.podStatusInfo(pod, nil, now)
+	if info.Status != status.Running {
+		return info
+	}
+	if !podReady(pod) {
+		info.Status = status.Allocating
+		info.Message = "waiting for operator to become ready"
+		return info
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name != operatorContainerName {
+			continue
+		}
+		if cs.State.Running == nil || !cs.Ready {
+			info.Status = status.Allocating
+			info.Message = "waiting for operator container"
+		} else if !sameImage(cs.Image, pod.Spec.Containers[0].Image) {
+			info.Status = status.Allocating
+			info.Message = "waiting for operator container to restart"
+		}
+	}
+	return info
+}
+
+// podReady reports whether the pod's Ready condition is true.
+func podReady(pod core.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == core.PodReady {
+			return cond.Status == core.ConditionTrue
+		}
+	}
+	return false
+}
+
+// sameImage reports whether the image reported in a container's status
+// refers to the specified image. Some container runtimes report images
+// pulled from the default registry by their fully qualified name.
+func sameImage(reported, image string) bool {
+	normalise := func(s string) string {
+		s = strings.TrimPrefix(s, "docker.io/")
+		return strings.TrimPrefix(s, "library/")
+	}
+	return normalise(reported) == normalise(image)
+}
+
+// UpgradeOperator is part of the caas.OperatorUpgrader interface.
+// The operator's container is restarted with the new image but the pod,
+// and so any storage it uses, is kept, as are the application's units.
+func (k *kubernetesClient) UpgradeOperator(appName string, config *caas.OperatorConfig) (err error) {
+	defer convertCredentialError(&err)
+	pod, err := k.getOperatorPod(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(config.AgentConf) > 0 {
+		if err := k.ensureConfigMap(operatorConfigMap(appName, config)); err != nil {
+			return errors.Annotate(err, "updating operator ConfigMap")
+		}
+	}
+	container := &pod.Spec.Containers[0]
+	newVersion := config.Version.String()
+	if container.Image == config.OperatorImagePath && pod.Labels[labelVersion] == newVersion {
+		return nil
+	}
+	logger.Debugf("upgrading %s operator from %q to %q", appName, container.Image, config.OperatorImagePath)
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[annotationPreviousImage] = container.Image
+	pod.Annotations[annotationPreviousVersion] = pod.Labels[labelVersion]
+	delete(pod.Annotations, annotationFailedVersion)
+	pod.Labels[labelVersion] = newVersion
+	container.Image = config.OperatorImagePath
+
+	_, err = k.CoreV1().Pods(k.namespace).Update(pod)
+	return errors.Trace(err)
+}
+
+// RollbackOperator is part of the caas.OperatorUpgrader interface.
+func (k *kubernetesClient) RollbackOperator(appName string) (err error) {
+	defer convertCredentialError(&err)
+	pod, err := k.getOperatorPod(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	previousImage := pod.Annotations[annotationPreviousImage]
+	if previousImage == "" {
+		return errors.NotFoundf("previous image for %q operator", appName)
+	}
+	logger.Debugf("rolling back %s operator to %q", appName, previousImage)
+	// Record the version which failed so that the upgrade
+	// isn't retried until there's a different version to go to.
+	pod.Annotations[annotationFailedVersion] = pod.Labels[labelVersion]
+	pod.Spec.Containers[0].Image = previousImage
+	pod.Labels[labelVersion] = pod.Annotations[annotationPreviousVersion]
+	delete(pod.Annotations, annotationPreviousImage)
+	delete(pod.Annotations, annotationPreviousVersion)
+
+	_, err = k.CoreV1().Pods(k.namespace).Update(pod)
+	return errors.Trace(err)
+}
+
 func (k *kubernetesClient) ensurePod(pod *core.Pod) error {
 	// Kubernetes doesn't support updating a pod except under specific
 	// circumstances so we need to delete and create.
@@ -1447,7 +1940,7 @@ func operatorPod(appName, agentPath, operatorImagePath, version string) *core.Po
 		},
 		Spec: core.PodSpec{
 			Containers: []core.Container{{
-				Name:            "juju-operator",
+				Name:            operatorContainerName,
 				ImagePullPolicy: core.PullIfNotPresent,
 				Image:           operatorImagePath,
 				Env: []core.EnvVar{
@@ -5,21 +5,28 @@ package provider_test
 
 import (
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	apps "k8s.io/api/apps/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	core "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/devices"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
 )
@@ -165,17 +172,21 @@ func (s *K8sBrokerSuite) TestDeleteService(c *gc.C) {
 	ctrl := s.setupBroker(c)
 	defer ctrl.Finish()
 
-	// Delete operations below return a not found to ensure it's treated as a no-op.
-	gomock.InOrder(
+	// Only the kinds of workload which exist are deleted, and
+	// not found errors are treated as a no-op.
+	calls := []*gomock.Call{
 		s.mockServices.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
 			Return(s.k8sNotFoundError()),
+	}
+	calls = append(calls, s.workloadLookups(caas.WorkloadStatefulSet, caas.WorkloadDaemonSet)...)
+	gomock.InOrder(append(calls,
 		s.mockStatefulSets.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
-			Return(s.k8sNotFoundError()),
+			Return(nil),
 		s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).
 			Return(&core.PodList{Items: []core.Pod{}}, nil),
-		s.mockDeployments.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+		s.mockDaemonSets.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
 			Return(s.k8sNotFoundError()),
-	)
+	)...)
 
 	err := s.broker.DeleteService("test")
 	c.Assert(err, jc.ErrorIsNil)
@@ -190,14 +201,12 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoUnits(c *gc.C) {
 	zero := int32(0)
 	emptyDc := dc
 	emptyDc.Spec.Replicas = &zero
-	gomock.InOrder(
-		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
-			Return(nil, s.k8sNotFoundError()),
+	gomock.InOrder(append(s.workloadLookups(caas.WorkloadDeployment),
 		s.mockDeployments.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(dc, nil),
 		s.mockDeployments.EXPECT().Update(emptyDc).Times(1).
 			Return(nil, nil),
-	)
+	)...)
 
 	params := &caas.ServiceParams{}
 	err := s.broker.EnsureService("test", params, 0, nil)
@@ -247,9 +256,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 		},
 	}
 
-	gomock.InOrder(
-		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
-			Return(nil, s.k8sNotFoundError()),
+	gomock.InOrder(append(s.workloadLookups(),
 		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
@@ -260,7 +267,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Create(serviceArg).Times(1).
 			Return(nil, nil),
-	)
+	)...)
 
 	params := &caas.ServiceParams{
 		PodSpec: basicPodspec,
@@ -273,6 +280,285 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *K8sBrokerSuite) TestEnsureServiceDaemonSet(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpec := *basicPodspec
+	podSpec.WorkloadKind = caas.WorkloadDaemonSet
+	podSpec.OmitServiceFrontend = true
+	unitSpec, err := provider.MakeUnitSpec("app-name", &podSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	daemonSetArg := &appsv1.DaemonSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "test"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-application-test-",
+					Labels:       map[string]string{"juju-application": "test"},
+				},
+				Spec: provider.PodSpec(unitSpec),
+			},
+		},
+	}
+	gomock.InOrder(append(s.workloadLookups(),
+		s.mockDaemonSets.EXPECT().Update(daemonSetArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDaemonSets.EXPECT().Create(daemonSetArg).Times(1).
+			Return(nil, nil),
+	)...)
+
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+	}
+	err = s.broker.EnsureService("test", params, 2, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) jobArg(c *gc.C, podSpec *caas.PodSpec, numUnits int32) *batchv1.Job {
+	unitSpec, err := provider.MakeUnitSpec("app-name", podSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	jobPodSpec := provider.PodSpec(unitSpec)
+	jobPodSpec.RestartPolicy = core.RestartPolicyOnFailure
+
+	job := &batchv1.Job{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: batchv1.JobSpec{
+			Parallelism: &numUnits,
+			Completions: &numUnits,
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-application-test-",
+					Labels:       map[string]string{"juju-application": "test"},
+				},
+				Spec: jobPodSpec,
+			},
+		},
+	}
+	hash, err := provider.JobSpecHash(job)
+	c.Assert(err, jc.ErrorIsNil)
+	job.Annotations = map[string]string{"juju.io/job-spec-hash": hash}
+	return job
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceJob(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpec := *basicPodspec
+	podSpec.WorkloadKind = caas.WorkloadJob
+	podSpec.OmitServiceFrontend = true
+	jobArg := s.jobArg(c, &podSpec, 2)
+	gomock.InOrder(append(s.workloadLookups(),
+		s.mockJobs.EXPECT().Create(jobArg).Times(1).
+			Return(nil, nil),
+	)...)
+
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+	}
+	err := s.broker.EnsureService("test", params, 2, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceJobUnchanged(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpec := *basicPodspec
+	podSpec.WorkloadKind = caas.WorkloadJob
+	podSpec.OmitServiceFrontend = true
+	jobArg := s.jobArg(c, &podSpec, 2)
+	gomock.InOrder(append(s.workloadLookups(caas.WorkloadJob),
+		s.mockJobs.EXPECT().Create(jobArg).Times(1).
+			Return(nil, k8serrors.NewAlreadyExists(schema.GroupResource{}, "juju-test")),
+		s.mockJobs.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(jobArg, nil),
+	)...)
+
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+	}
+	err := s.broker.EnsureService("test", params, 2, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceJobChanged(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpec := *basicPodspec
+	podSpec.WorkloadKind = caas.WorkloadJob
+	podSpec.OmitServiceFrontend = true
+	jobArg := s.jobArg(c, &podSpec, 2)
+	// The job was created to run a single unit.
+	existing := s.jobArg(c, &podSpec, 1)
+	gomock.InOrder(append(s.workloadLookups(caas.WorkloadJob),
+		s.mockJobs.EXPECT().Create(jobArg).Times(1).
+			Return(nil, k8serrors.NewAlreadyExists(schema.GroupResource{}, "juju-test")),
+		s.mockJobs.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(existing, nil),
+		s.mockJobs.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationBackground)).Times(1).
+			Return(nil),
+		s.mockJobs.EXPECT().Create(jobArg).Times(1).
+			Return(nil, nil),
+	)...)
+
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+	}
+	err := s.broker.EnsureService("test", params, 2, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceCronJob(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpec := *basicPodspec
+	podSpec.WorkloadKind = caas.WorkloadCronJob
+	podSpec.Schedule = "*/5 * * * *"
+	podSpec.OmitServiceFrontend = true
+	unitSpec, err := provider.MakeUnitSpec("app-name", &podSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	jobPodSpec := provider.PodSpec(unitSpec)
+	jobPodSpec.RestartPolicy = core.RestartPolicyOnFailure
+
+	numUnits := int32(2)
+	cronJobArg := &batchv1beta1.CronJob{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule:          "*/5 * * * *",
+			ConcurrencyPolicy: batchv1beta1.ForbidConcurrent,
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					Labels: map[string]string{"juju-application": "test"},
+				},
+				Spec: batchv1.JobSpec{
+					Parallelism: &numUnits,
+					Completions: &numUnits,
+					Template: core.PodTemplateSpec{
+						ObjectMeta: v1.ObjectMeta{
+							GenerateName: "juju-application-test-",
+							Labels:       map[string]string{"juju-application": "test"},
+						},
+						Spec: jobPodSpec,
+					},
+				},
+			},
+		},
+	}
+	gomock.InOrder(append(s.workloadLookups(),
+		s.mockCronJobs.EXPECT().Update(cronJobArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockCronJobs.EXPECT().Create(cronJobArg).Times(1).
+			Return(nil, nil),
+	)...)
+
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+	}
+	err = s.broker.EnsureService("test", params, 2, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceChangesWorkloadKind(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpec := *basicPodspec
+	podSpec.WorkloadKind = caas.WorkloadDaemonSet
+	podSpec.OmitServiceFrontend = true
+	unitSpec, err := provider.MakeUnitSpec("app-name", &podSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	daemonSetArg := &appsv1.DaemonSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "test"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-application-test-",
+					Labels:       map[string]string{"juju-application": "test"},
+				},
+				Spec: provider.PodSpec(unitSpec),
+			},
+		},
+	}
+	// The application was previously run by a deployment,
+	// which is removed once the daemon set runs it.
+	gomock.InOrder(append(s.workloadLookups(caas.WorkloadDeployment),
+		s.mockDaemonSets.EXPECT().Update(daemonSetArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDaemonSets.EXPECT().Create(daemonSetArg).Times(1).
+			Return(nil, nil),
+		s.mockDeployments.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+	)...)
+
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+	}
+	err = s.broker.EnsureService("test", params, 2, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceChangesWorkloadKindCreateFails(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpec := *basicPodspec
+	podSpec.WorkloadKind = caas.WorkloadJob
+	podSpec.OmitServiceFrontend = true
+	jobArg := s.jobArg(c, &podSpec, 2)
+	// The deployment which previously ran the application
+	// is kept when the job can't be created.
+	gomock.InOrder(append(s.workloadLookups(caas.WorkloadDeployment),
+		s.mockJobs.EXPECT().Create(jobArg).Times(1).
+			Return(nil, errors.New("boom")),
+		s.mockJobs.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+	)...)
+
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+	}
+	err := s.broker.EnsureService("test", params, 2, nil)
+	c.Assert(err, gc.ErrorMatches, "creating or updating Job: boom")
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceDaemonSetWithStorage(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpec := *basicPodspec
+	podSpec.WorkloadKind = caas.WorkloadDaemonSet
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+		Filesystems: []storage.KubernetesFilesystemParams{{
+			StorageName: "database",
+			Size:        100,
+			Provider:    "kubernetes",
+		}},
+	}
+	err := s.broker.EnsureService("test", params, 2, nil)
+	c.Assert(err, gc.ErrorMatches, `storage for "daemonset" workloads not supported`)
+}
+
 func (s *K8sBrokerSuite) TestEnsureCustomResourceDefinitionCreate(c *gc.C) {
 	ctrl := s.setupBroker(c)
 	defer ctrl.Finish()
@@ -543,7 +829,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 		},
 	}
 
-	gomock.InOrder(
+	gomock.InOrder(append(s.workloadLookups(),
 		s.mockPersistentVolumeClaims.EXPECT().Get("juju-database-0", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
@@ -558,7 +844,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Create(serviceArg).Times(1).
 			Return(nil, nil),
-	)
+	)...)
 
 	params := &caas.ServiceParams{
 		PodSpec: basicPodspec,
@@ -633,9 +919,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithDevices(c *gc.C) {
 		},
 	}
 
-	gomock.InOrder(
-		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
-			Return(nil, s.k8sNotFoundError()),
+	gomock.InOrder(append(s.workloadLookups(),
 		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
@@ -646,7 +930,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithDevices(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Create(serviceArg).Times(1).
 			Return(nil, nil),
-	)
+	)...)
 
 	params := &caas.ServiceParams{
 		PodSpec: basicPodspec,
@@ -739,7 +1023,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 		},
 	}
 
-	gomock.InOrder(
+	gomock.InOrder(append(s.workloadLookups(),
 		s.mockPersistentVolumeClaims.EXPECT().Get("juju-database-0", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
@@ -754,7 +1038,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Create(serviceArg).Times(1).
 			Return(nil, nil),
-	)
+	)...)
 
 	params := &caas.ServiceParams{
 		PodSpec: basicPodspec,
@@ -781,3 +1065,174 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func operatorPodWithStatus(runningImage string, ready bool, annotations map[string]string) *core.Pod {
+	pod := provider.OperatorPod("test", "/var/lib/juju", "jujusolutions/caas-jujud-operator:2.99.0", "2.99.0")
+	pod.Annotations = annotations
+	readyStatus := core.ConditionFalse
+	if ready {
+		readyStatus = core.ConditionTrue
+	}
+	pod.Status = core.PodStatus{
+		Phase: core.PodRunning,
+		Conditions: []core.PodCondition{{
+			Type:   core.PodReady,
+			Status: readyStatus,
+		}},
+		ContainerStatuses: []core.ContainerStatus{{
+			Name:  "juju-operator",
+			Ready: ready,
+			Image: runningImage,
+			State: core.ContainerState{
+				Running: &core.ContainerStateRunning{StartedAt: v1.Now()},
+			},
+		}},
+	}
+	return pod
+}
+
+func (s *K8sBrokerSuite) TestOperator(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	pod := operatorPodWithStatus("docker.io/jujusolutions/caas-jujud-operator:2.99.0", true, map[string]string{
+		"juju.io/previous-operator-image":   "jujusolutions/caas-jujud-operator:2.98.0",
+		"juju.io/previous-operator-version": "2.98.0",
+		"juju.io/failed-operator-version":   "2.99.1",
+	})
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-operator==test"}).Times(1).
+		Return(&core.PodList{Items: []core.Pod{*pod}}, nil)
+
+	operator, err := s.broker.(caas.OperatorUpgrader).Operator("test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operator.Image, gc.Equals, "jujusolutions/caas-jujud-operator:2.99.0")
+	c.Assert(operator.Version, gc.Equals, version.MustParse("2.99.0"))
+	c.Assert(operator.PreviousImage, gc.Equals, "jujusolutions/caas-jujud-operator:2.98.0")
+	c.Assert(operator.PreviousVersion, gc.Equals, version.MustParse("2.98.0"))
+	c.Assert(operator.FailedVersion, gc.Equals, version.MustParse("2.99.1"))
+	c.Assert(operator.Status.Status, gc.Equals, status.Running)
+}
+
+func (s *K8sBrokerSuite) TestOperatorWaitingForRestart(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	// The pod is still ready, but the container
+	// is running the image from before the upgrade.
+	pod := operatorPodWithStatus("jujusolutions/caas-jujud-operator:2.98.0", true, nil)
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-operator==test"}).Times(1).
+		Return(&core.PodList{Items: []core.Pod{*pod}}, nil)
+
+	operator, err := s.broker.(caas.OperatorUpgrader).Operator("test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operator.Status.Status, gc.Equals, status.Allocating)
+	c.Assert(operator.Status.Message, gc.Equals, "waiting for operator container to restart")
+}
+
+func (s *K8sBrokerSuite) TestOperatorNotReady(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	pod := operatorPodWithStatus("jujusolutions/caas-jujud-operator:2.99.0", false, nil)
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-operator==test"}).Times(1).
+		Return(&core.PodList{Items: []core.Pod{*pod}}, nil)
+
+	operator, err := s.broker.(caas.OperatorUpgrader).Operator("test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operator.Status.Status, gc.Equals, status.Allocating)
+	c.Assert(operator.Status.Message, gc.Equals, "waiting for operator to become ready")
+}
+
+func (s *K8sBrokerSuite) TestOperatorNotFound(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-operator==test"}).Times(1).
+		Return(&core.PodList{}, nil)
+
+	_, err := s.broker.(caas.OperatorUpgrader).Operator("test")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *K8sBrokerSuite) TestUpgradeOperator(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	pod := operatorPodWithStatus("jujusolutions/caas-jujud-operator:2.99.0", true, nil)
+	var updated *core.Pod
+	gomock.InOrder(
+		s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-operator==test"}).Times(1).
+			Return(&core.PodList{Items: []core.Pod{*pod}}, nil),
+		s.mockPods.EXPECT().Update(gomock.Any()).Times(1).
+			Do(func(p *core.Pod) { updated = p }).
+			Return(nil, nil),
+	)
+
+	err := s.broker.(caas.OperatorUpgrader).UpgradeOperator("test", &caas.OperatorConfig{
+		OperatorImagePath: "jujusolutions/caas-jujud-operator:3.0.0",
+		Version:           version.MustParse("3.0.0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated, gc.NotNil)
+	c.Assert(updated.Name, gc.Equals, "juju-operator-test")
+	c.Assert(updated.Spec.Containers[0].Image, gc.Equals, "jujusolutions/caas-jujud-operator:3.0.0")
+	c.Assert(updated.Labels["juju-version"], gc.Equals, "3.0.0")
+	c.Assert(updated.Annotations["juju.io/previous-operator-image"], gc.Equals, "jujusolutions/caas-jujud-operator:2.99.0")
+	c.Assert(updated.Annotations["juju.io/previous-operator-version"], gc.Equals, "2.99.0")
+	_, ok := updated.Annotations["juju.io/failed-operator-version"]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *K8sBrokerSuite) TestUpgradeOperatorSameVersion(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	pod := operatorPodWithStatus("jujusolutions/caas-jujud-operator:2.99.0", true, nil)
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-operator==test"}).Times(1).
+		Return(&core.PodList{Items: []core.Pod{*pod}}, nil)
+
+	err := s.broker.(caas.OperatorUpgrader).UpgradeOperator("test", &caas.OperatorConfig{
+		OperatorImagePath: "jujusolutions/caas-jujud-operator:2.99.0",
+		Version:           version.MustParse("2.99.0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestRollbackOperator(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	pod := operatorPodWithStatus("jujusolutions/caas-jujud-operator:2.99.0", true, map[string]string{
+		"juju.io/previous-operator-image":   "jujusolutions/caas-jujud-operator:2.98.0",
+		"juju.io/previous-operator-version": "2.98.0",
+	})
+	var updated *core.Pod
+	gomock.InOrder(
+		s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-operator==test"}).Times(1).
+			Return(&core.PodList{Items: []core.Pod{*pod}}, nil),
+		s.mockPods.EXPECT().Update(gomock.Any()).Times(1).
+			Do(func(p *core.Pod) { updated = p }).
+			Return(nil, nil),
+	)
+
+	err := s.broker.(caas.OperatorUpgrader).RollbackOperator("test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated, gc.NotNil)
+	c.Assert(updated.Spec.Containers[0].Image, gc.Equals, "jujusolutions/caas-jujud-operator:2.98.0")
+	c.Assert(updated.Labels["juju-version"], gc.Equals, "2.98.0")
+	c.Assert(updated.Annotations["juju.io/failed-operator-version"], gc.Equals, "2.99.0")
+	_, ok := updated.Annotations["juju.io/previous-operator-image"]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *K8sBrokerSuite) TestRollbackOperatorNothingToRollBack(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	pod := operatorPodWithStatus("jujusolutions/caas-jujud-operator:2.99.0", true, nil)
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-operator==test"}).Times(1).
+		Return(&core.PodList{Items: []core.Pod{*pod}}, nil)
+
+	err := s.broker.(caas.OperatorUpgrader).RollbackOperator("test")
+	c.Assert(err, gc.ErrorMatches, `previous image for "test" operator not found`)
+}
@@ -0,0 +1,208 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/status"
+)
+
+// containerErrorReasons are the reasons a container may be waiting
+// which need attention before the workload can start.
+var containerErrorReasons = set.NewStrings(
+	"CrashLoopBackOff",
+	"ErrImagePull",
+	"ImagePullBackOff",
+	"InvalidImageName",
+	"CreateContainerConfigError",
+	"CreateContainerError",
+	"RunContainerError",
+)
+
+// containerTerminatedReasons are the reasons a container may have
+// last terminated which are worth reporting while it is restarted.
+var containerTerminatedReasons = set.NewStrings(
+	"OOMKilled",
+	"Error",
+	"ContainerCannotRun",
+	"DeadlineExceeded",
+)
+
+// eventsFor returns the events recorded against the named object,
+// most recent first.
+func (k *kubernetesClient) eventsFor(objectName string) ([]core.Event, error) {
+	events := k.CoreV1().Events(k.namespace)
+	eventList, err := events.List(v1.ListOptions{
+		IncludeUninitialized: true,
+		FieldSelector:        fields.OneTermEqualSelector("involvedObject.name", objectName).String(),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := eventList.Items
+	sort.SliceStable(result, func(i, j int) bool {
+		return eventTime(result[i]).After(eventTime(result[j]))
+	})
+	return result, nil
+}
+
+func eventTime(evt core.Event) time.Time {
+	if !evt.LastTimestamp.IsZero() {
+		return evt.LastTimestamp.Time
+	}
+	return evt.FirstTimestamp.Time
+}
+
+// reasonMessage formats a kubernetes reason and message for display.
+func reasonMessage(reason, message string) string {
+	message = strings.TrimSpace(message)
+	if reason == "" {
+		return message
+	}
+	if message == "" {
+		return reason
+	}
+	return fmt.Sprintf("%s: %s", reason, message)
+}
+
+// podStatusInfo derives the Juju status of a unit from its pod,
+// using the container states and any events recorded against
+// the pod (most recent first) to report why a pod is not running.
+func (k *kubernetesClient) podStatusInfo(pod core.Pod, events []core.Event, now time.Time) status.StatusInfo {
+	terminated := pod.DeletionTimestamp != nil
+	info := status.StatusInfo{
+		Status:  k.jujuStatus(pod.Status.Phase, terminated),
+		Message: pod.Status.Message,
+		Since:   &now,
+	}
+	if terminated {
+		return info
+	}
+	setReason := func(reason, message string) {
+		info.Message = reasonMessage(reason, message)
+		info.Data = map[string]interface{}{caas.StatusReasonKey: reason}
+	}
+
+	// Containers which cannot start take precedence over the pod
+	// phase, as the pod may otherwise report itself as running.
+	for _, cs := range pod.Status.ContainerStatuses {
+		if waiting := cs.State.Waiting; waiting != nil && containerErrorReasons.Contains(waiting.Reason) {
+			info.Status = status.Error
+			message := waiting.Message
+			if last := cs.LastTerminationState.Terminated; last != nil && containerTerminatedReasons.Contains(last.Reason) {
+				message = fmt.Sprintf("%s (last exit: %s)", message, last.Reason)
+			}
+			setReason(waiting.Reason, message)
+			return info
+		}
+		if terminated := cs.State.Terminated; terminated != nil && containerTerminatedReasons.Contains(terminated.Reason) {
+			info.Status = status.Error
+			setReason(terminated.Reason, terminated.Message)
+			return info
+		}
+	}
+
+	if pod.Status.Reason != "" {
+		setReason(pod.Status.Reason, pod.Status.Message)
+	}
+	if info.Message == "" {
+		for _, cond := range pod.Status.Conditions {
+			info.Message = cond.Message
+			since := cond.LastProbeTime.Time
+			info.Since = &since
+			if cond.Type == core.PodScheduled && cond.Reason == core.PodReasonUnschedulable {
+				info.Status = status.Allocating
+				setReason(cond.Reason, cond.Message)
+				break
+			}
+		}
+	}
+	if info.Status != status.Running {
+		// Warning events, such as FailedScheduling or FailedMount,
+		// explain why a pod is stuck.
+		for _, evt := range events {
+			if evt.Type == core.EventTypeWarning {
+				setReason(evt.Reason, evt.Message)
+				return info
+			}
+		}
+	}
+	if info.Message == "" && len(events) > 0 {
+		// If there's nothing better to report, use the most recent event.
+		info.Message = events[0].Message
+	}
+	return info
+}
+
+// pvcStatusInfo derives the Juju status of a filesystem from its
+// persistent volume claim and any events recorded against it.
+func (k *kubernetesClient) pvcStatusInfo(pvc core.PersistentVolumeClaim, events []core.Event, now time.Time) status.StatusInfo {
+	info := status.StatusInfo{
+		Status: k.jujuFilesystemStatus(pvc.Status.Phase),
+		Since:  &now,
+	}
+	if len(pvc.Status.Conditions) > 0 {
+		info.Message = pvc.Status.Conditions[0].Message
+		since := pvc.Status.Conditions[0].LastProbeTime.Time
+		info.Since = &since
+	}
+	for _, evt := range events {
+		if evt.Type == core.EventTypeWarning {
+			info.Message = reasonMessage(evt.Reason, evt.Message)
+			info.Data = map[string]interface{}{caas.StatusReasonKey: evt.Reason}
+			return info
+		}
+	}
+	if info.Message == "" && len(events) > 0 {
+		info.Message = events[0].Message
+	}
+	return info
+}
+
+// isApplicationEvent returns true if the event concerns a pod or
+// persistent volume claim labelled as belonging to the specified
+// application. Object names can't be relied on, since the names of
+// one application's resources may be prefixes of another's.
+func (k *kubernetesClient) isApplicationEvent(appName string, evt *core.Event) bool {
+	obj := evt.InvolvedObject
+	var (
+		meta v1.ObjectMeta
+		err  error
+	)
+	switch obj.Kind {
+	case "Pod":
+		var pod *core.Pod
+		pod, err = k.CoreV1().Pods(k.namespace).Get(obj.Name, v1.GetOptions{})
+		if err == nil {
+			meta = pod.ObjectMeta
+		}
+	case "PersistentVolumeClaim":
+		var pvc *core.PersistentVolumeClaim
+		pvc, err = k.CoreV1().PersistentVolumeClaims(k.namespace).Get(obj.Name, v1.GetOptions{})
+		if err == nil {
+			meta = pvc.ObjectMeta
+		}
+	default:
+		return false
+	}
+	if k8serrors.IsNotFound(err) {
+		return false
+	} else if err != nil {
+		logger.Warningf("cannot get %s %q for event: %v", obj.Kind, obj.Name, err)
+		return false
+	}
+	return meta.Labels[labelApplication] == appName
+}
@@ -0,0 +1,166 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/status"
+)
+
+type statusSuite struct {
+	testing.IsolationSuite
+	now time.Time
+}
+
+var _ = gc.Suite(&statusSuite{})
+
+func (s *statusSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.now = time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *statusSuite) TestPodRunning(c *gc.C) {
+	pod := core.Pod{Status: core.PodStatus{Phase: core.PodRunning}}
+	events := []core.Event{
+		{Type: core.EventTypeNormal, Reason: "Started", Message: "Started container"},
+	}
+	info := provider.PodStatusInfo(pod, events, s.now)
+	c.Assert(info, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Running,
+		Message: "Started container",
+		Since:   &s.now,
+	})
+}
+
+func (s *statusSuite) TestPodFailedScheduling(c *gc.C) {
+	pod := core.Pod{Status: core.PodStatus{Phase: core.PodPending}}
+	events := []core.Event{
+		{Type: core.EventTypeNormal, Reason: "Scheduled", Message: "ignored"},
+		{Type: core.EventTypeWarning, Reason: "FailedScheduling", Message: "0/3 nodes are available: 3 Insufficient cpu."},
+	}
+	info := provider.PodStatusInfo(pod, events, s.now)
+	c.Assert(info, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Allocating,
+		Message: "FailedScheduling: 0/3 nodes are available: 3 Insufficient cpu.",
+		Data:    map[string]interface{}{"reason": "FailedScheduling"},
+		Since:   &s.now,
+	})
+}
+
+func (s *statusSuite) TestPodCrashLoopBackOff(c *gc.C) {
+	pod := core.Pod{Status: core.PodStatus{
+		Phase: core.PodRunning,
+		ContainerStatuses: []core.ContainerStatus{{
+			State: core.ContainerState{
+				Waiting: &core.ContainerStateWaiting{
+					Reason:  "CrashLoopBackOff",
+					Message: "Back-off restarting failed container",
+				},
+			},
+			LastTerminationState: core.ContainerState{
+				Terminated: &core.ContainerStateTerminated{Reason: "OOMKilled"},
+			},
+		}},
+	}}
+	info := provider.PodStatusInfo(pod, nil, s.now)
+	c.Assert(info, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Error,
+		Message: "CrashLoopBackOff: Back-off restarting failed container (last exit: OOMKilled)",
+		Data:    map[string]interface{}{"reason": "CrashLoopBackOff"},
+		Since:   &s.now,
+	})
+}
+
+func (s *statusSuite) TestPodImagePullBackOff(c *gc.C) {
+	pod := core.Pod{Status: core.PodStatus{
+		Phase: core.PodPending,
+		ContainerStatuses: []core.ContainerStatus{{
+			State: core.ContainerState{
+				Waiting: &core.ContainerStateWaiting{
+					Reason:  "ImagePullBackOff",
+					Message: `Back-off pulling image "gitlab/gitlab-ce"`,
+				},
+			},
+		}},
+	}}
+	info := provider.PodStatusInfo(pod, nil, s.now)
+	c.Assert(info.Status, gc.Equals, status.Error)
+	c.Assert(info.Message, gc.Equals, `ImagePullBackOff: Back-off pulling image "gitlab/gitlab-ce"`)
+}
+
+func (s *statusSuite) TestPodTerminated(c *gc.C) {
+	deleted := v1.NewTime(s.now)
+	pod := core.Pod{
+		ObjectMeta: v1.ObjectMeta{DeletionTimestamp: &deleted},
+		Status:     core.PodStatus{Phase: core.PodRunning},
+	}
+	events := []core.Event{
+		{Type: core.EventTypeWarning, Reason: "FailedMount", Message: "ignored"},
+	}
+	info := provider.PodStatusInfo(pod, events, s.now)
+	c.Assert(info.Status, gc.Equals, status.Terminated)
+	c.Assert(info.Data, gc.IsNil)
+}
+
+func (s *statusSuite) TestPVCWarningEvent(c *gc.C) {
+	pvc := core.PersistentVolumeClaim{
+		Status: core.PersistentVolumeClaimStatus{Phase: core.ClaimPending},
+	}
+	events := []core.Event{
+		{Type: core.EventTypeWarning, Reason: "ProvisioningFailed", Message: "storageclass not found"},
+	}
+	info := provider.PVCStatusInfo(pvc, events, s.now)
+	c.Assert(info, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Pending,
+		Message: "ProvisioningFailed: storageclass not found",
+		Data:    map[string]interface{}{"reason": "ProvisioningFailed"},
+		Since:   &s.now,
+	})
+}
+
+func (s *statusSuite) TestIsApplicationEvent(c *gc.C) {
+	labelled := func(name, app string) v1.ObjectMeta {
+		return v1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels:    map[string]string{"juju-application": app},
+		}
+	}
+	client := k8sfake.NewSimpleClientset(
+		&core.Pod{ObjectMeta: labelled("juju-gitlab-7d8f9c-abcde", "gitlab")},
+		&core.Pod{ObjectMeta: labelled("juju-gitlab-0", "gitlab")},
+		&core.Pod{ObjectMeta: labelled("juju-gitlab-db-0", "gitlab-db")},
+		&core.Pod{ObjectMeta: labelled("juju-mariadb-0", "mariadb")},
+		&core.PersistentVolumeClaim{ObjectMeta: labelled("juju-database-0-juju-gitlab-0", "gitlab")},
+		&core.PersistentVolumeClaim{ObjectMeta: labelled("juju-database-0-juju-gitlab-db-0", "gitlab-db")},
+		&core.PersistentVolumeClaim{ObjectMeta: labelled("juju-database-0-juju-mariadb-0", "mariadb")},
+	)
+	for i, t := range []struct {
+		kind, name string
+		expected   bool
+	}{
+		{"Pod", "juju-gitlab-7d8f9c-abcde", true},
+		{"Pod", "juju-gitlab-0", true},
+		{"Pod", "juju-gitlab-db-0", false},
+		{"Pod", "juju-mariadb-0", false},
+		{"Pod", "juju-gitlab-1", false},
+		{"PersistentVolumeClaim", "juju-database-0-juju-gitlab-0", true},
+		{"PersistentVolumeClaim", "juju-database-0-juju-gitlab-db-0", false},
+		{"PersistentVolumeClaim", "juju-database-0-juju-mariadb-0", false},
+		{"Node", "juju-gitlab-0", false},
+	} {
+		c.Logf("test %d: %s %s", i, t.kind, t.name)
+		evt := &core.Event{InvolvedObject: core.ObjectReference{Kind: t.kind, Name: t.name}}
+		c.Check(provider.IsApplicationEvent(client, "test", "gitlab", evt), gc.Equals, t.expected)
+	}
+}
@@ -143,3 +143,80 @@ foo: bar
 			},
 		}}})
 }
+
+func (s *ContainersSuite) TestParseWorkloadKind(c *gc.C) {
+	specStr := `
+workloadKind: daemonset
+containers:
+  - name: log-shipper
+    image: fluentd/latest
+`[1:]
+
+	spec, err := provider.NewProvider().ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, jc.DeepEquals, &caas.PodSpec{
+		WorkloadKind: caas.WorkloadDaemonSet,
+		Containers: []caas.ContainerSpec{{
+			Name:  "log-shipper",
+			Image: "fluentd/latest",
+		}},
+	})
+}
+
+func (s *ContainersSuite) TestParseInvalidWorkloadKind(c *gc.C) {
+	specStr := `
+workloadKind: replicaset
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:]
+
+	_, err := provider.NewProvider().ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `workload kind "replicaset" not valid`)
+}
+
+func (s *ContainersSuite) TestParseCronJobWorkloadKind(c *gc.C) {
+	specStr := `
+workloadKind: cronjob
+schedule: "0 * * * *"
+containers:
+  - name: backup
+    image: backup/latest
+`[1:]
+
+	spec, err := provider.NewProvider().ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, jc.DeepEquals, &caas.PodSpec{
+		WorkloadKind: caas.WorkloadCronJob,
+		Schedule:     "0 * * * *",
+		Containers: []caas.ContainerSpec{{
+			Name:  "backup",
+			Image: "backup/latest",
+		}},
+	})
+}
+
+func (s *ContainersSuite) TestParseCronJobWithoutSchedule(c *gc.C) {
+	specStr := `
+workloadKind: cronjob
+containers:
+  - name: backup
+    image: backup/latest
+`[1:]
+
+	_, err := provider.NewProvider().ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `schedule is missing for cronjob workload`)
+}
+
+func (s *ContainersSuite) TestParseScheduleWithoutCronJob(c *gc.C) {
+	specStr := `
+workloadKind: job
+schedule: "0 * * * *"
+containers:
+  - name: backup
+    image: backup/latest
+`[1:]
+
+	_, err := provider.NewProvider().ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `schedule is not supported for "job" workloads`)
+}
@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -95,3 +96,65 @@ func (w *kubernetesWatcher) Kill() {
 func (w *kubernetesWatcher) Wait() error {
 	return w.catacomb.Wait()
 }
+
+// multiWatch merges the results of several kubernetes watchers
+// into a single watch.Interface.
+type multiWatch struct {
+	result   chan watch.Event
+	stopped  chan struct{}
+	stopOnce sync.Once
+	sources  []watch.Interface
+}
+
+func newMultiWatch(sources ...watch.Interface) *multiWatch {
+	w := &multiWatch{
+		result:  make(chan watch.Event),
+		stopped: make(chan struct{}),
+		sources: sources,
+	}
+	var wg sync.WaitGroup
+	for _, source := range sources {
+		wg.Add(1)
+		go func(source watch.Interface) {
+			defer wg.Done()
+			// If any source closes, stop them all so the
+			// consumer sees the result channel close.
+			defer w.Stop()
+			for {
+				select {
+				case <-w.stopped:
+					return
+				case evt, ok := <-source.ResultChan():
+					if !ok {
+						return
+					}
+					select {
+					case w.result <- evt:
+					case <-w.stopped:
+						return
+					}
+				}
+			}
+		}(source)
+	}
+	go func() {
+		wg.Wait()
+		close(w.result)
+	}()
+	return w
+}
+
+// Stop is part of the watch.Interface.
+func (w *multiWatch) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopped)
+		for _, source := range w.sources {
+			source.Stop()
+		}
+	})
+}
+
+// ResultChan is part of the watch.Interface.
+func (w *multiWatch) ResultChan() <-chan watch.Event {
+	return w.result
+}
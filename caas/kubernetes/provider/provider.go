@@ -21,10 +21,18 @@ import (
 
 type kubernetesEnvironProvider struct {
 	environProviderCredentials
+	newClient NewK8sClientFunc
 }
 
 var _ environs.EnvironProvider = (*kubernetesEnvironProvider)(nil)
-var providerInstance = kubernetesEnvironProvider{}
+var providerInstance = kubernetesEnvironProvider{newClient: newK8sClient}
+
+// NewEnvironProvider returns a kubernetes environ provider which uses the
+// specified function to create the k8s clients for the brokers it opens.
+// This is used to run the provider against an in-process fake cluster.
+func NewEnvironProvider(newClient NewK8sClientFunc) caas.ContainerEnvironProvider {
+	return kubernetesEnvironProvider{newClient: newClient}
+}
 
 // Version is part of the EnvironProvider interface.
 func (kubernetesEnvironProvider) Version() int {
@@ -45,12 +53,12 @@ func newK8sClient(c *rest.Config) (kubernetes.Interface, apiextensionsclientset.
 }
 
 // Open is part of the ContainerEnvironProvider interface.
-func (kubernetesEnvironProvider) Open(args environs.OpenParams) (caas.Broker, error) {
+func (p kubernetesEnvironProvider) Open(args environs.OpenParams) (caas.Broker, error) {
 	logger.Debugf("opening model %q.", args.Config.Name())
 	if err := validateCloudSpec(args.Cloud); err != nil {
 		return nil, errors.Annotate(err, "validating cloud spec")
 	}
-	broker, err := NewK8sBroker(args.Cloud, args.Config.Name(), newK8sClient)
+	broker, err := NewK8sBroker(args.Cloud, args.Config.Name(), p.newClient)
 	if err != nil {
 		return nil, err
 	}
@@ -114,7 +122,9 @@ func validateCloudSpec(spec environs.CloudSpec) error {
 	if spec.Credential == nil {
 		return errors.NotValidf("missing credential")
 	}
-	if authType := spec.Credential.AuthType(); authType != cloud.UserPassAuthType {
+	switch authType := spec.Credential.AuthType(); authType {
+	case cloud.UserPassAuthType, cloud.OAuth2AuthType:
+	default:
 		return errors.NotSupportedf("%q auth-type", authType)
 	}
 	return nil
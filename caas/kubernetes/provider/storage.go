@@ -179,7 +179,7 @@ func (v *volumeSource) ListVolumes(ctx context.ProviderCallContext) ([]string, e
 	pVolumes := v.client.CoreV1().PersistentVolumes()
 	vols, err := pVolumes.List(v1.ListOptions{})
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, maybeConvertCredentialError(errors.Trace(err), ctx)
 	}
 	volumeIds := make([]string, 0, len(vols.Items))
 	for _, v := range vols.Items {
@@ -195,7 +195,7 @@ func (v *volumeSource) DescribeVolumes(ctx context.ProviderCallContext, volIds [
 		// TODO(caas) - filter on volumes for the current model
 	})
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, maybeConvertCredentialError(errors.Trace(err), ctx)
 	}
 
 	byId := make(map[string]core.PersistentVolume)
@@ -223,9 +223,10 @@ func (v *volumeSource) DestroyVolumes(ctx context.ProviderCallContext, volIds []
 	logger.Debugf("destroy k8s volumes: %v", volIds)
 	pVolumes := v.client.CoreV1().PersistentVolumes()
 	return foreachVolume(volIds, func(volumeId string) error {
-		return pVolumes.Delete(volumeId, &v1.DeleteOptions{
+		err := pVolumes.Delete(volumeId, &v1.DeleteOptions{
 			PropagationPolicy: &defaultPropagationPolicy,
 		})
+		return maybeConvertCredentialError(err, ctx)
 	}), nil
 }
 
@@ -0,0 +1,467 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/juju/errors"
+	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+const providerType = "kubernetes"
+
+var (
+	podsResource     = core.SchemeGroupVersion.WithResource("pods")
+	podKind          = core.SchemeGroupVersion.WithKind("Pod")
+	nodesResource    = core.SchemeGroupVersion.WithResource("nodes")
+	nodeKind         = core.SchemeGroupVersion.WithKind("Node")
+	eventsResource   = core.SchemeGroupVersion.WithResource("events")
+	eventKind        = core.SchemeGroupVersion.WithKind("Event")
+	claimsResource   = core.SchemeGroupVersion.WithResource("persistentvolumeclaims")
+	volumesResource  = core.SchemeGroupVersion.WithResource("persistentvolumes")
+	defaultPodPhase  = core.PodRunning
+	defaultClaimSize = resource.MustParse("1Gi")
+)
+
+// FakeCluster is an in-process stand-in for a Kubernetes cluster, backed
+// by the client-go fake clientsets. Objects written through a broker
+// connected to the cluster are validated the way the API server would
+// validate them, and the deployment, stateful set, daemon set and job
+// controllers are simulated so that pods (and volume claims) exist for
+// the workloads the broker creates.
+type FakeCluster struct {
+	// Clientset holds the objects in the cluster and records
+	// all actions made against it.
+	Clientset *fake.Clientset
+
+	// ExtensionsClientset holds custom resource definitions.
+	ExtensionsClientset *apiextensionsfake.Clientset
+
+	mu       sync.Mutex
+	podPhase core.PodPhase
+	serial   int
+}
+
+// NewFakeCluster returns a new fake cluster holding the specified objects.
+func NewFakeCluster(objects ...runtime.Object) *FakeCluster {
+	f := &FakeCluster{
+		Clientset:           fake.NewSimpleClientset(objects...),
+		ExtensionsClientset: apiextensionsfake.NewSimpleClientset(),
+		podPhase:            defaultPodPhase,
+	}
+	f.Clientset.PrependReactor("create", "*", f.validateAndReconcile)
+	f.Clientset.PrependReactor("update", "*", f.validateAndReconcile)
+	f.Clientset.PrependReactor("delete", "*", f.deleteDependents)
+	f.Clientset.PrependReactor("list", "events", f.listEvents)
+	return f
+}
+
+// NewClient is a provider.NewK8sClientFunc which returns
+// clients connected to the fake cluster.
+func (f *FakeCluster) NewClient(*rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, error) {
+	return f.Clientset, f.ExtensionsClientset, nil
+}
+
+// CloudSpec returns a cloud spec suitable for opening
+// a broker connected to the fake cluster.
+func CloudSpec() environs.CloudSpec {
+	cred := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		provider.CredAttrUsername: "admin",
+		provider.CredAttrPassword: "secret",
+	})
+	return environs.CloudSpec{
+		Type:       providerType,
+		Name:       "fake-k8s",
+		Endpoint:   "https://fake-k8s.invalid",
+		Credential: &cred,
+	}
+}
+
+// NewBroker returns a broker connected to the fake cluster,
+// managing resources in the specified namespace.
+func (f *FakeCluster) NewBroker(namespace string) (caas.Broker, error) {
+	return provider.NewK8sBroker(CloudSpec(), namespace, f.NewClient)
+}
+
+// RegisterProvider replaces the registered kubernetes provider with
+// one whose brokers are connected to the fake cluster, so that code
+// opening brokers through the provider registry uses the fake. The
+// returned function restores the original provider.
+func (f *FakeCluster) RegisterProvider() (restore func()) {
+	registry := environs.GlobalProviderRegistry()
+	original, err := registry.Provider(providerType)
+	if err != nil {
+		panic(err)
+	}
+	registry.UnregisterProvider(providerType)
+	unregister := caas.RegisterContainerProvider(providerType, provider.NewEnvironProvider(f.NewClient))
+	return func() {
+		unregister()
+		caas.RegisterContainerProvider(providerType, original.(caas.ContainerEnvironProvider))
+	}
+}
+
+// SetPodPhase sets the phase of pods subsequently
+// started by the simulated workload controllers.
+func (f *FakeCluster) SetPodPhase(phase core.PodPhase) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.podPhase = phase
+}
+
+// AddEvent records an event about the named object in the namespace.
+func (f *FakeCluster) AddEvent(namespace, objectName, eventType, reason, message string) error {
+	f.mu.Lock()
+	name := f.nextName(objectName)
+	f.mu.Unlock()
+	now := v1.Now()
+	event := &core.Event{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		InvolvedObject: core.ObjectReference{
+			Namespace: namespace,
+			Name:      objectName,
+		},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        message,
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+	}
+	return f.Clientset.Tracker().Create(eventsResource, event, namespace)
+}
+
+// Pods returns the pods in the namespace.
+func (f *FakeCluster) Pods(namespace string) ([]core.Pod, error) {
+	list, err := f.Clientset.Tracker().List(podsResource, podKind, namespace)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return list.(*core.PodList).Items, nil
+}
+
+// nextName returns a unique name derived from prefix.
+// It must be called with f.mu held.
+func (f *FakeCluster) nextName(prefix string) string {
+	f.serial++
+	return fmt.Sprintf("%s.%d", prefix, f.serial)
+}
+
+func (f *FakeCluster) validateAndReconcile(action k8stesting.Action) (bool, runtime.Object, error) {
+	if action.GetSubresource() != "" {
+		return false, nil, nil
+	}
+	objAction, ok := action.(interface {
+		GetObject() runtime.Object
+	})
+	if !ok {
+		return false, nil, nil
+	}
+	obj := objAction.GetObject()
+	if errs := validateObject(obj); len(errs) > 0 {
+		accessor, _ := meta.Accessor(obj)
+		return true, nil, k8serrors.NewInvalid(groupKind(obj), accessor.GetName(), errs)
+	}
+
+	handled, result, err := k8stesting.ObjectReaction(f.Clientset.Tracker())(action)
+	if err != nil || result == nil {
+		return handled, result, err
+	}
+	if err := f.reconcile(action.GetNamespace(), result); err != nil {
+		return true, nil, k8serrors.NewInternalError(err)
+	}
+	return handled, result, err
+}
+
+func groupKind(obj runtime.Object) schema.GroupKind {
+	kinds, _, err := scheme.Scheme.ObjectKinds(obj)
+	if err != nil || len(kinds) == 0 {
+		return schema.GroupKind{}
+	}
+	return kinds[0].GroupKind()
+}
+
+// reconcile does the job of the controller which
+// manages the pods for the specified workload.
+func (f *FakeCluster) reconcile(namespace string, obj runtime.Object) error {
+	switch o := obj.(type) {
+	case *apps.Deployment:
+		return f.syncPods(namespace, &o.ObjectMeta, "Deployment", replicas(o.Spec.Replicas), o.Spec.Template, nil)
+	case *apps.StatefulSet:
+		return f.syncPods(namespace, &o.ObjectMeta, "StatefulSet", replicas(o.Spec.Replicas), o.Spec.Template, o.Spec.VolumeClaimTemplates)
+	case *apps.DaemonSet:
+		nodes, err := f.Clientset.Tracker().List(nodesResource, nodeKind, "")
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// A cluster without any registered nodes is treated as a single node cluster.
+		numNodes := len(nodes.(*core.NodeList).Items)
+		if numNodes == 0 {
+			numNodes = 1
+		}
+		return f.syncPods(namespace, &o.ObjectMeta, "DaemonSet", numNodes, o.Spec.Template, nil)
+	case *batch.Job:
+		return f.syncPods(namespace, &o.ObjectMeta, "Job", replicas(o.Spec.Parallelism), o.Spec.Template, nil)
+	}
+	return nil
+}
+
+func replicas(n *int32) int {
+	if n == nil {
+		return 1
+	}
+	return int(*n)
+}
+
+// syncPods ensures the expected number of pods, built from the template,
+// exist for the owner. Pods whose spec no longer matches the template
+// are replaced, as they would be by a rolling update.
+func (f *FakeCluster) syncPods(
+	namespace string, owner *v1.ObjectMeta, kind string, count int,
+	template core.PodTemplateSpec, claimTemplates []core.PersistentVolumeClaim,
+) error {
+	tracker := f.Clientset.Tracker()
+	existing, err := f.ownedPods(namespace, kind, owner.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var keep []core.Pod
+	for _, pod := range existing {
+		if len(keep) < count && reflect.DeepEqual(pod.Spec.Containers, template.Spec.Containers) {
+			keep = append(keep, pod)
+			continue
+		}
+		if err := tracker.Delete(podsResource, namespace, pod.Name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	used := make(map[string]bool)
+	for _, pod := range keep {
+		used[pod.Name] = true
+	}
+
+	f.mu.Lock()
+	phase := f.podPhase
+	f.mu.Unlock()
+	for ordinal := 0; len(keep) < count; ordinal++ {
+		name := fmt.Sprintf("%s-%d", owner.Name, ordinal)
+		if used[name] {
+			continue
+		}
+		pod := f.newPod(namespace, name, owner, kind, template, phase)
+		for _, claimTemplate := range claimTemplates {
+			claimName, err := f.ensureClaim(namespace, name, claimTemplate)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			pod.Spec.Volumes = append(pod.Spec.Volumes, core.Volume{
+				Name: claimTemplate.Name,
+				VolumeSource: core.VolumeSource{
+					PersistentVolumeClaim: &core.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+				},
+			})
+		}
+		if err := tracker.Create(podsResource, pod, namespace); err != nil {
+			return errors.Trace(err)
+		}
+		keep = append(keep, *pod)
+	}
+	return nil
+}
+
+func (f *FakeCluster) newPod(
+	namespace, name string, owner *v1.ObjectMeta, kind string, template core.PodTemplateSpec, phase core.PodPhase,
+) *core.Pod {
+	f.mu.Lock()
+	f.serial++
+	serial := f.serial
+	f.mu.Unlock()
+
+	pod := &core.Pod{
+		ObjectMeta: *template.ObjectMeta.DeepCopy(),
+		Spec:       *template.Spec.DeepCopy(),
+		Status: core.PodStatus{
+			Phase: phase,
+			PodIP: fmt.Sprintf("10.1.%d.%d", serial/250, serial%250+1),
+		},
+	}
+	pod.Name = name
+	pod.Namespace = namespace
+	pod.UID = types.UID(fmt.Sprintf("%s-uid-%d", name, serial))
+	pod.OwnerReferences = []v1.OwnerReference{{
+		Kind: kind,
+		Name: owner.Name,
+		UID:  owner.UID,
+	}}
+	return pod
+}
+
+// ensureClaim creates a bound volume claim, and the persistent volume backing
+// it, for the specified pod and claim template, if they do not already exist.
+func (f *FakeCluster) ensureClaim(namespace, podName string, template core.PersistentVolumeClaim) (string, error) {
+	tracker := f.Clientset.Tracker()
+	claimName := fmt.Sprintf("%s-%s", template.Name, podName)
+	_, err := tracker.Get(claimsResource, namespace, claimName)
+	if err == nil {
+		return claimName, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	}
+
+	size, ok := template.Spec.Resources.Requests[core.ResourceStorage]
+	if !ok {
+		size = defaultClaimSize
+	}
+	volumeName := "pvc-" + claimName
+	claim := template.DeepCopy()
+	claim.Name = claimName
+	claim.Namespace = namespace
+	claim.Spec.VolumeName = volumeName
+	claim.Status = core.PersistentVolumeClaimStatus{
+		Phase:    core.ClaimBound,
+		Capacity: core.ResourceList{core.ResourceStorage: size},
+	}
+	volume := &core.PersistentVolume{
+		ObjectMeta: v1.ObjectMeta{Name: volumeName},
+		Spec: core.PersistentVolumeSpec{
+			Capacity:    core.ResourceList{core.ResourceStorage: size},
+			AccessModes: claim.Spec.AccessModes,
+			ClaimRef: &core.ObjectReference{
+				Kind:      "PersistentVolumeClaim",
+				Namespace: namespace,
+				Name:      claimName,
+			},
+			PersistentVolumeReclaimPolicy: core.PersistentVolumeReclaimDelete,
+		},
+		Status: core.PersistentVolumeStatus{Phase: core.VolumeBound},
+	}
+	if err := tracker.Create(volumesResource, volume, ""); err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := tracker.Create(claimsResource, claim, namespace); err != nil {
+		return "", errors.Trace(err)
+	}
+	return claimName, nil
+}
+
+func (f *FakeCluster) ownedPods(namespace, kind, ownerName string) ([]core.Pod, error) {
+	pods, err := f.Pods(namespace)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []core.Pod
+	for _, pod := range pods {
+		for _, ref := range pod.OwnerReferences {
+			if ref.Kind == kind && ref.Name == ownerName {
+				result = append(result, pod)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+var workloadKinds = map[string]string{
+	"deployments":  "Deployment",
+	"statefulsets": "StatefulSet",
+	"daemonsets":   "DaemonSet",
+	"jobs":         "Job",
+}
+
+// deleteDependents simulates the garbage collector, removing the
+// pods owned by a workload when the workload is deleted.
+func (f *FakeCluster) deleteDependents(action k8stesting.Action) (bool, runtime.Object, error) {
+	kind, ok := workloadKinds[action.GetResource().Resource]
+	if !ok {
+		return false, nil, nil
+	}
+	deleteAction, ok := action.(k8stesting.DeleteAction)
+	if !ok {
+		return false, nil, nil
+	}
+	handled, result, err := k8stesting.ObjectReaction(f.Clientset.Tracker())(action)
+	if err != nil {
+		return handled, result, err
+	}
+	pods, err := f.ownedPods(action.GetNamespace(), kind, deleteAction.GetName())
+	if err != nil {
+		return true, nil, k8serrors.NewInternalError(err)
+	}
+	for _, pod := range pods {
+		if err := f.Clientset.Tracker().Delete(podsResource, pod.Namespace, pod.Name); err != nil {
+			return true, nil, k8serrors.NewInternalError(err)
+		}
+	}
+	return handled, result, nil
+}
+
+// listEvents applies the field selector used to list the events about a
+// given object, which the generic fake list reaction ignores.
+func (f *FakeCluster) listEvents(action k8stesting.Action) (bool, runtime.Object, error) {
+	listAction, ok := action.(k8stesting.ListAction)
+	if !ok {
+		return false, nil, nil
+	}
+	selector := listAction.GetListRestrictions().Fields
+	if selector == nil || selector.Empty() {
+		return false, nil, nil
+	}
+	obj, err := f.Clientset.Tracker().List(eventsResource, eventKind, action.GetNamespace())
+	if err != nil {
+		return true, nil, err
+	}
+	all := obj.(*core.EventList)
+	result := &core.EventList{ListMeta: all.ListMeta}
+	for _, evt := range all.Items {
+		if selector.Matches(eventFields(evt)) {
+			result.Items = append(result.Items, evt)
+		}
+	}
+	return true, result, nil
+}
+
+func eventFields(evt core.Event) fields.Set {
+	return fields.Set{
+		"metadata.name":                  evt.Name,
+		"metadata.namespace":             evt.Namespace,
+		"involvedObject.kind":            evt.InvolvedObject.Kind,
+		"involvedObject.namespace":       evt.InvolvedObject.Namespace,
+		"involvedObject.name":            evt.InvolvedObject.Name,
+		"involvedObject.uid":             string(evt.InvolvedObject.UID),
+		"involvedObject.apiVersion":      evt.InvolvedObject.APIVersion,
+		"involvedObject.resourceVersion": evt.InvolvedObject.ResourceVersion,
+		"involvedObject.fieldPath":       evt.InvolvedObject.FieldPath,
+		"reason":                         evt.Reason,
+		"source":                         evt.Source.Component,
+		"type":                           evt.Type,
+	}
+}
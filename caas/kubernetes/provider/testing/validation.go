@@ -0,0 +1,277 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"strings"
+
+	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// validateObject performs the subset of the API server's validation
+// relevant to the objects created by the kubernetes broker.
+func validateObject(obj runtime.Object) field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	switch o := obj.(type) {
+	case *core.Namespace:
+		errs = append(errs, validateObjectMeta(o.ObjectMeta, validation.IsDNS1123Label)...)
+	case *core.Service:
+		errs = append(errs, validateObjectMeta(o.ObjectMeta, validation.IsDNS1035Label)...)
+		errs = append(errs, validateServiceSpec(o.Spec, specPath)...)
+	case *core.Pod:
+		errs = append(errs, validateObjectMeta(o.ObjectMeta, validation.IsDNS1123Subdomain)...)
+		errs = append(errs, validatePodSpec(o.Spec, nil, specPath)...)
+	case *core.ConfigMap:
+		errs = append(errs, validateObjectMeta(o.ObjectMeta, validation.IsDNS1123Subdomain)...)
+		for key := range o.Data {
+			errs = append(errs, validateStrings(field.NewPath("data").Key(key), key, validation.IsConfigMapKey)...)
+		}
+	case *core.Secret:
+		errs = append(errs, validateObjectMeta(o.ObjectMeta, validation.IsDNS1123Subdomain)...)
+		for key := range o.Data {
+			errs = append(errs, validateStrings(field.NewPath("data").Key(key), key, validation.IsConfigMapKey)...)
+		}
+	case *core.PersistentVolumeClaim:
+		errs = append(errs, validateObjectMeta(o.ObjectMeta, validation.IsDNS1123Subdomain)...)
+		if len(o.Spec.AccessModes) == 0 {
+			errs = append(errs, field.Required(specPath.Child("accessModes"), ""))
+		}
+	case *apps.Deployment:
+		errs = append(errs, validateObjectMeta(o.ObjectMeta, validation.IsDNS1123Subdomain)...)
+		errs = append(errs, validateWorkload(o.Spec.Selector, o.Spec.Template, nil, specPath)...)
+	case *apps.StatefulSet:
+		errs = append(errs, validateObjectMeta(o.ObjectMeta, validation.IsDNS1123Subdomain)...)
+		var claims []string
+		for _, claim := range o.Spec.VolumeClaimTemplates {
+			claims = append(claims, claim.Name)
+		}
+		errs = append(errs, validateWorkload(o.Spec.Selector, o.Spec.Template, claims, specPath)...)
+	case *apps.DaemonSet:
+		errs = append(errs, validateObjectMeta(o.ObjectMeta, validation.IsDNS1123Subdomain)...)
+		errs = append(errs, validateWorkload(o.Spec.Selector, o.Spec.Template, nil, specPath)...)
+	case *batch.Job:
+		errs = append(errs, validateObjectMeta(o.ObjectMeta, validation.IsDNS1123Subdomain)...)
+		// Jobs manage their own pod selector unless told otherwise.
+		selector := o.Spec.Selector
+		if selector == nil {
+			selector = &v1.LabelSelector{MatchLabels: o.Spec.Template.Labels}
+		}
+		errs = append(errs, validateWorkload(selector, o.Spec.Template, nil, specPath)...)
+		policyPath := specPath.Child("template", "spec", "restartPolicy")
+		switch policy := o.Spec.Template.Spec.RestartPolicy; policy {
+		case core.RestartPolicyOnFailure, core.RestartPolicyNever:
+		default:
+			errs = append(errs, field.NotSupported(policyPath, policy,
+				[]string{string(core.RestartPolicyOnFailure), string(core.RestartPolicyNever)}))
+		}
+	default:
+		if accessor, err := meta.Accessor(obj); err == nil {
+			errs = append(errs, validateName(accessor.GetName(), accessor.GetGenerateName(), validation.IsDNS1123Subdomain)...)
+		}
+	}
+	return errs
+}
+
+func validateStrings(path *field.Path, value string, validate func(string) []string) field.ErrorList {
+	var errs field.ErrorList
+	for _, msg := range validate(value) {
+		errs = append(errs, field.Invalid(path, value, msg))
+	}
+	return errs
+}
+
+func validateName(name, generateName string, validateName func(string) []string) field.ErrorList {
+	path := field.NewPath("metadata", "name")
+	if name == "" {
+		if generateName == "" {
+			return field.ErrorList{field.Required(path, "name or generateName is required")}
+		}
+		return nil
+	}
+	return validateStrings(path, name, validateName)
+}
+
+func validateObjectMeta(m v1.ObjectMeta, validateNameFunc func(string) []string) field.ErrorList {
+	errs := validateName(m.Name, m.GenerateName, validateNameFunc)
+	errs = append(errs, validateLabels(m.Labels, field.NewPath("metadata", "labels"))...)
+	annotationsPath := field.NewPath("metadata", "annotations")
+	for key := range m.Annotations {
+		errs = append(errs, validateStrings(annotationsPath, strings.ToLower(key), validation.IsQualifiedName)...)
+	}
+	return errs
+}
+
+func validateLabels(l map[string]string, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for key, value := range l {
+		errs = append(errs, validateStrings(path, key, validation.IsQualifiedName)...)
+		errs = append(errs, validateStrings(path, value, validation.IsValidLabelValue)...)
+	}
+	return errs
+}
+
+// validateWorkload checks the selector and pod template of a workload
+// controller. The selector must be set and select the pods created
+// from the template.
+func validateWorkload(
+	selector *v1.LabelSelector, template core.PodTemplateSpec, claimNames []string, path *field.Path,
+) field.ErrorList {
+	var errs field.ErrorList
+	templatePath := path.Child("template")
+	errs = append(errs, validateLabels(template.Labels, templatePath.Child("metadata", "labels"))...)
+	if selector == nil {
+		errs = append(errs, field.Required(path.Child("selector"), ""))
+	} else {
+		s, err := v1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			errs = append(errs, field.Invalid(path.Child("selector"), selector, err.Error()))
+		} else if s.Empty() {
+			errs = append(errs, field.Invalid(path.Child("selector"), selector, "empty selector is invalid for deployment"))
+		} else if !s.Matches(labels.Set(template.Labels)) {
+			errs = append(errs, field.Invalid(templatePath.Child("metadata", "labels"), template.Labels,
+				"`selector` does not match template `labels`"))
+		}
+	}
+	errs = append(errs, validatePodSpec(template.Spec, claimNames, templatePath.Child("spec"))...)
+	return errs
+}
+
+var supportedProtocols = []string{string(core.ProtocolTCP), string(core.ProtocolUDP), string(core.ProtocolSCTP)}
+
+func validateProtocol(protocol core.Protocol, path *field.Path) field.ErrorList {
+	switch protocol {
+	case "", core.ProtocolTCP, core.ProtocolUDP, core.ProtocolSCTP:
+		return nil
+	}
+	return field.ErrorList{field.NotSupported(path, protocol, supportedProtocols)}
+}
+
+// validatePodSpec checks the containers and volumes of a pod spec.
+// Volume mounts may refer to volumes in the spec or to any of the
+// specified volume claim templates.
+func validatePodSpec(spec core.PodSpec, claimNames []string, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	volumes := make(map[string]bool)
+	for _, name := range claimNames {
+		volumes[name] = true
+	}
+	for i, vol := range spec.Volumes {
+		volPath := path.Child("volumes").Index(i).Child("name")
+		errs = append(errs, validateStrings(volPath, vol.Name, validation.IsDNS1123Label)...)
+		if volumes[vol.Name] {
+			errs = append(errs, field.Duplicate(volPath, vol.Name))
+		}
+		volumes[vol.Name] = true
+	}
+
+	allContainers := append(append([]core.Container(nil), spec.InitContainers...), spec.Containers...)
+	if len(spec.Containers) == 0 {
+		errs = append(errs, field.Required(path.Child("containers"), ""))
+	}
+	names := make(map[string]bool)
+	for i, c := range allContainers {
+		containerPath := path.Child("containers").Index(i)
+		if i < len(spec.InitContainers) {
+			containerPath = path.Child("initContainers").Index(i)
+		}
+		namePath := containerPath.Child("name")
+		if c.Name == "" {
+			errs = append(errs, field.Required(namePath, ""))
+		} else {
+			errs = append(errs, validateStrings(namePath, c.Name, validation.IsDNS1123Label)...)
+		}
+		if names[c.Name] {
+			errs = append(errs, field.Duplicate(namePath, c.Name))
+		}
+		names[c.Name] = true
+		if strings.TrimSpace(c.Image) == "" {
+			errs = append(errs, field.Required(containerPath.Child("image"), ""))
+		}
+		for j, port := range c.Ports {
+			portPath := containerPath.Child("ports").Index(j)
+			for _, msg := range validation.IsValidPortNum(int(port.ContainerPort)) {
+				errs = append(errs, field.Invalid(portPath.Child("containerPort"), port.ContainerPort, msg))
+			}
+			if port.Name != "" {
+				errs = append(errs, validateStrings(portPath.Child("name"), port.Name, validation.IsValidPortName)...)
+			}
+			errs = append(errs, validateProtocol(port.Protocol, portPath.Child("protocol"))...)
+		}
+		for j, env := range c.Env {
+			errs = append(errs, validateStrings(containerPath.Child("env").Index(j).Child("name"), env.Name, validation.IsEnvVarName)...)
+		}
+		for j, mount := range c.VolumeMounts {
+			mountPath := containerPath.Child("volumeMounts").Index(j)
+			if !volumes[mount.Name] {
+				errs = append(errs, field.NotFound(mountPath.Child("name"), mount.Name))
+			}
+			if mount.MountPath == "" {
+				errs = append(errs, field.Required(mountPath.Child("mountPath"), ""))
+			}
+		}
+	}
+
+	switch spec.RestartPolicy {
+	case "", core.RestartPolicyAlways, core.RestartPolicyOnFailure, core.RestartPolicyNever:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("restartPolicy"), spec.RestartPolicy, []string{
+			string(core.RestartPolicyAlways), string(core.RestartPolicyOnFailure), string(core.RestartPolicyNever),
+		}))
+	}
+	return errs
+}
+
+// validateServiceSpec checks the type and ports of a service.
+func validateServiceSpec(spec core.ServiceSpec, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	switch spec.Type {
+	case "", core.ServiceTypeClusterIP, core.ServiceTypeNodePort, core.ServiceTypeLoadBalancer:
+	case core.ServiceTypeExternalName:
+		if spec.ExternalName == "" {
+			errs = append(errs, field.Required(path.Child("externalName"), ""))
+		}
+	default:
+		errs = append(errs, field.NotSupported(path.Child("type"), spec.Type, []string{
+			string(core.ServiceTypeClusterIP), string(core.ServiceTypeNodePort),
+			string(core.ServiceTypeLoadBalancer), string(core.ServiceTypeExternalName),
+		}))
+	}
+	if spec.Type != core.ServiceTypeExternalName && len(spec.Ports) == 0 {
+		errs = append(errs, field.Required(path.Child("ports"), ""))
+	}
+	portNames := make(map[string]bool)
+	for i, port := range spec.Ports {
+		portPath := path.Child("ports").Index(i)
+		// Names are only optional for single port services.
+		if len(spec.Ports) > 1 && port.Name == "" {
+			errs = append(errs, field.Required(portPath.Child("name"), ""))
+		} else if port.Name != "" {
+			errs = append(errs, validateStrings(portPath.Child("name"), port.Name, validation.IsDNS1123Label)...)
+			if portNames[port.Name] {
+				errs = append(errs, field.Duplicate(portPath.Child("name"), port.Name))
+			}
+			portNames[port.Name] = true
+		}
+		for _, msg := range validation.IsValidPortNum(int(port.Port)) {
+			errs = append(errs, field.Invalid(portPath.Child("port"), port.Port, msg))
+		}
+		if port.TargetPort.IntVal != 0 {
+			for _, msg := range validation.IsValidPortNum(int(port.TargetPort.IntVal)) {
+				errs = append(errs, field.Invalid(portPath.Child("targetPort"), port.TargetPort, msg))
+			}
+		}
+		errs = append(errs, validateProtocol(port.Protocol, portPath.Child("protocol"))...)
+	}
+	return errs
+}
@@ -25,10 +25,16 @@ The remote offer is identified by providing a path to the offer:
     [<model owner>/]<model name>.<application name>
         for an application in another model in this controller (if owner isn't specified it's assumed to be the logged-in user)
 
+Alternatively, a share token created using "juju offer --share" can be
+provided instead of the path to the offer. The token grants access to the
+offer until it expires or is revoked, without needing an account on the
+offering controller.
+
 Examples:
     $ juju consume othermodel.mysql
     $ juju consume owner/othermodel.mysql
     $ juju consume anothercontroller:owner/othermodel.mysql
+    $ juju consume <share token> mysql
 
 See also:
     add-relation
@@ -54,7 +60,7 @@ type consumeCommand struct {
 func (c *consumeCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "consume",
-		Args:    "<remote offer path> [<local application name>]",
+		Args:    "<remote offer path>|<share token> [<local application name>]",
 		Purpose: usageConsumeSummary,
 		Doc:     usageConsumeDetails,
 	}
@@ -110,39 +116,48 @@ func (c *consumeCommand) getSourceAPI(url *crossmodel.OfferURL) (applicationCons
 // Run adds the requested remote offer to the model. Implements
 // cmd.Command.
 func (c *consumeCommand) Run(ctx *cmd.Context) error {
-	accountDetails, err := c.CurrentAccountDetails()
-	if err != nil {
-		return errors.Trace(err)
-	}
+	var consumeDetails params.ConsumeOfferDetails
 	url, err := crossmodel.ParseOfferURL(c.remoteApplication)
 	if err != nil {
-		return errors.Trace(err)
-	}
-	if url.HasEndpoint() {
-		return errors.Errorf("remote offer %q shouldn't include endpoint", c.remoteApplication)
-	}
-	if url.User == "" {
-		url.User = accountDetails.User
-		c.remoteApplication = url.Path()
-	}
-	sourceClient, err := c.getSourceAPI(url)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	defer sourceClient.Close()
+		// A share token carries everything needed to consume the
+		// offer, so there's no need to ask the offering controller.
+		var tokenErr error
+		consumeDetails, tokenErr = applicationoffers.DecodeShareToken(c.remoteApplication)
+		if tokenErr != nil {
+			return errors.Trace(err)
+		}
+		c.remoteApplication = consumeDetails.Offer.OfferURL
+	} else {
+		accountDetails, err := c.CurrentAccountDetails()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if url.HasEndpoint() {
+			return errors.Errorf("remote offer %q shouldn't include endpoint", c.remoteApplication)
+		}
+		if url.User == "" {
+			url.User = accountDetails.User
+			c.remoteApplication = url.Path()
+		}
+		sourceClient, err := c.getSourceAPI(url)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer sourceClient.Close()
 
-	consumeDetails, err := sourceClient.GetConsumeDetails(url.AsLocal().String())
-	if err != nil {
-		return errors.Trace(err)
-	}
-	// Parse the offer details URL and add the source controller so
-	// things like status can show the original source of the offer.
-	offerURL, err := crossmodel.ParseOfferURL(consumeDetails.Offer.OfferURL)
-	if err != nil {
-		return errors.Trace(err)
+		consumeDetails, err = sourceClient.GetConsumeDetails(url.AsLocal().String())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// Parse the offer details URL and add the source controller so
+		// things like status can show the original source of the offer.
+		offerURL, err := crossmodel.ParseOfferURL(consumeDetails.Offer.OfferURL)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		offerURL.Source = url.Source
+		consumeDetails.Offer.OfferURL = offerURL.String()
 	}
-	offerURL.Source = url.Source
-	consumeDetails.Offer.OfferURL = offerURL.String()
 
 	targetClient, err := c.getTargetAPI()
 	if err != nil {
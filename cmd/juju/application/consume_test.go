@@ -11,6 +11,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api/applicationoffers"
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/application"
@@ -126,6 +127,37 @@ func (s *ConsumeSuite) TestSuccessModelDotApplicationWithAlias(c *gc.C) {
 	s.assertSuccessModelDotApplication(c, "alias")
 }
 
+func (s *ConsumeSuite) TestSuccessShareToken(c *gc.C) {
+	s.mockAPI.localName = "mary-weep"
+	mac, err := apitesting.NewMacaroon("id")
+	c.Assert(err, jc.ErrorIsNil)
+	offer := params.ApplicationOfferDetails{OfferName: "an offer", OfferURL: "fred/booster.uke"}
+	token, err := applicationoffers.EncodeShareToken(params.ConsumeOfferDetails{
+		Offer:    &offer,
+		Macaroon: mac,
+		ControllerInfo: &params.ExternalControllerInfo{
+			ControllerTag: coretesting.ControllerTag.String(),
+			Addrs:         []string{"192.168.1:1234"},
+			CACert:        coretesting.CACert,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := s.runConsume(c, token, "uke")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "Consume", "Close")
+	arg := s.mockAPI.Calls()[0].Args[0].(crossmodel.ConsumeApplicationArgs)
+	c.Assert(arg.Offer, jc.DeepEquals, offer)
+	c.Assert(arg.ApplicationAlias, gc.Equals, "uke")
+	c.Assert(arg.Macaroon.Id(), jc.DeepEquals, mac.Id())
+	c.Assert(arg.ControllerInfo, jc.DeepEquals, &crossmodel.ControllerInfo{
+		ControllerTag: coretesting.ControllerTag,
+		Addrs:         []string{"192.168.1:1234"},
+		CACert:        coretesting.CACert,
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Added fred/booster.uke as mary-weep\n")
+}
+
 type mockConsumeAPI struct {
 	*testing.Stub
 
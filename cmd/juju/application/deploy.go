@@ -542,16 +542,31 @@ Examples:
 
     juju deploy haproxy -n 2 --constraints spaces=dmz,^cms,^database
     (deploy 2 units to machines that are in the 'dmz' space but not of
-	the 'cmd' or the 'database' spaces)
+    the 'cmd' or the 'database' spaces)
 
-	juju deploy mycharm --device bitcoinminer=1,nvidia.com/gpu
-	(deploy mycharm requires any Nvidia GPU without needing to further specify any tags)
+    juju deploy mysql -n 3 --constraints zones=us-east-1a,us-east-1b
+    (deploy 3 units to machines in either of the given AZs)
 
-	juju deploy mycharm --device bitcoinminer=nvidia.com/gpu
-	(deploy mycharm requires any Nvidia GPU. No count is specified, it is assumed to be 1)
+    juju deploy wordpress -n 2 --constraints anti-affinity=mysql
+    (provider-dependent; deploy 2 units to AZs with no mysql units,
+    spread across those AZs)
 
-	juju deploy mycharm --device bitcoinminer=1,nvidia.com/gpu,gpu=nvidia-tesla-p100;attr2=attr2
-	(deploy mycharm requires 1*nvidia.com/gpu with attributes: gpu=nvidia-tesla-p10 && attr2=attr2)
+    juju deploy memcached --constraints affinity=wordpress
+    (provider-dependent; deploy to an AZ that wordpress units are in)
+
+    juju deploy hadoop-slave -n 10 --constraints "spot=true max-price=0.05"
+    (provider-dependent; deploy to spot or preemptible instances, paying at
+    most $0.05 an hour where the cloud supports it, which the cloud may
+    reclaim; see the replace-interrupted-machines model config)
+
+    juju deploy mycharm --device bitcoinminer=1,nvidia.com/gpu
+    (deploy mycharm requires any Nvidia GPU without needing to further specify any tags)
+
+    juju deploy mycharm --device bitcoinminer=nvidia.com/gpu
+    (deploy mycharm requires any Nvidia GPU. No count is specified, it is assumed to be 1)
+
+    juju deploy mycharm --device bitcoinminer=1,nvidia.com/gpu,gpu=nvidia-tesla-p100;attr2=attr2
+    (deploy mycharm requires 1*nvidia.com/gpu with attributes: gpu=nvidia-tesla-p10 && attr2=attr2)
 
 See also:
     add-unit
@@ -4,10 +4,14 @@
 package application
 
 import (
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -19,8 +23,32 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+If the expose-load-balancers model config is set, and the cloud supports
+it (currently only GCE does), the application is also put behind a
+cloud load balancer. The load balancer forwards the application's open
+ports to those of its units that are healthy, and its address is shown
+in status as the application's address.
+
+By default the application is reachable from everywhere. The sources
+allowed to reach it may be restricted to a comma-separated list of
+CIDRs with --to-cidrs, or to the subnets of a comma-separated list of
+spaces with --to-spaces. Without --endpoints, the restriction applies
+to all of the application's endpoints; with --endpoints, it applies
+only to the named endpoints. Expose settings are merged with those
+already set for the application; use "juju unexpose --endpoints" to
+remove them. Exposing the application without --endpoints, --to-cidrs
+or --to-spaces removes any expose settings, making it reachable from
+everywhere again. "juju status" shows the expose settings of each
+application.
+
+Note that the ports opened by units are not tied to endpoints, so the
+sources allowed for any of the exposed endpoints may reach all of the
+application's open ports.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --to-cidrs 10.0.0.0/24,192.168.1.0/24
+    juju expose mysql --endpoints db --to-spaces internal
 
 See also: 
     unexpose`[1:]
@@ -34,6 +62,9 @@ func NewExposeCommand() modelcmd.ModelCommand {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	Endpoints       string
+	ToSpaces        string
+	ToCIDRs         string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,6 +76,13 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.Endpoints, "endpoints", "", "Comma-separated list of endpoints to expose")
+	f.StringVar(&c.ToSpaces, "to-spaces", "", "Comma-separated list of spaces allowed to reach the application")
+	f.StringVar(&c.ToCIDRs, "to-cidrs", "", "Comma-separated list of CIDRs allowed to reach the application")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
@@ -56,7 +94,9 @@ func (c *exposeCommand) Init(args []string) error {
 type applicationExposeAPI interface {
 	Close() error
 	Expose(applicationName string) error
+	ExposeEndpoints(applicationName string, exposedEndpoints map[string]params.ExposedEndpoint) error
 	Unexpose(applicationName string) error
+	UnexposeEndpoints(applicationName string, endpoints []string) error
 }
 
 func (c *exposeCommand) getAPI() (applicationExposeAPI, error) {
@@ -75,5 +115,42 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	exposedEndpoints := c.exposedEndpoints()
+	if len(exposedEndpoints) == 0 {
+		return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	}
+	err = client.ExposeEndpoints(c.ApplicationName, exposedEndpoints)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+// exposedEndpoints returns the expose settings given on the command
+// line, or nil if the application is to be exposed to everywhere.
+func (c *exposeCommand) exposedEndpoints() map[string]params.ExposedEndpoint {
+	endpoints := splitCommaList(c.Endpoints)
+	exposed := params.ExposedEndpoint{
+		ExposeToSpaces: splitCommaList(c.ToSpaces),
+		ExposeToCIDRs:  splitCommaList(c.ToCIDRs),
+	}
+	if len(endpoints) == 0 {
+		if len(exposed.ExposeToSpaces) == 0 && len(exposed.ExposeToCIDRs) == 0 {
+			return nil
+		}
+		endpoints = []string{""}
+	}
+	result := make(map[string]params.ExposedEndpoint, len(endpoints))
+	for _, endpoint := range endpoints {
+		result[endpoint] = exposed
+	}
+	return result
+}
+
+// splitCommaList returns the non-empty items of a comma-separated list.
+func splitCommaList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
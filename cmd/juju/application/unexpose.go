@@ -6,6 +6,7 @@ package application
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/cmd/juju/block"
@@ -20,8 +21,13 @@ Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to deny public access to the application.
 An application is unexposed by default when it gets created.
 
+With --endpoints, only the expose settings of the named endpoints are
+removed, and the application remains exposed while any other settings
+remain.
+
 Examples:
     juju unexpose wordpress
+    juju unexpose mysql --endpoints db
 
 See also: 
     expose`[1:]
@@ -35,6 +41,7 @@ func NewUnexposeCommand() modelcmd.ModelCommand {
 type unexposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	Endpoints       string
 }
 
 func (c *unexposeCommand) Info() *cmd.Info {
@@ -46,6 +53,11 @@ func (c *unexposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *unexposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.Endpoints, "endpoints", "", "Comma-separated list of endpoints to remove expose settings from")
+}
+
 func (c *unexposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
@@ -70,5 +82,9 @@ func (c *unexposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
+	if endpoints := splitCommaList(c.Endpoints); len(endpoints) > 0 {
+		err = client.UnexposeEndpoints(c.ApplicationName, endpoints)
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
 	return block.ProcessBlockedError(client.Unexpose(c.ApplicationName), block.BlockChange)
 }
@@ -12,6 +12,7 @@ import (
 
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/testcharms"
 	"github.com/juju/juju/testing"
 )
@@ -65,6 +66,29 @@ func (s *UnexposeSuite) TestUnexpose(c *gc.C) {
 	})
 }
 
+func (s *UnexposeSuite) TestUnexposeEndpoints(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "multi-series")
+	err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runExpose(c, "some-application-name", "--to-cidrs", "10.0.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	err = runExpose(c, "some-application-name", "--endpoints", "multi-directory")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runUnexpose(c, "some-application-name", "--endpoints", "multi-directory")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-application-name", true)
+	app, err := s.State.Application("some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+
+	err = runUnexpose(c, "some-application-name", "--endpoints", "multi-directory")
+	c.Assert(err, gc.ErrorMatches, `.*not found`)
+}
+
 func (s *UnexposeSuite) TestBlockUnexpose(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "multi-series")
 	err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
@@ -9,6 +9,8 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -50,12 +52,22 @@ Creates a user-defined cloud and populate the selected controller with the k8s
 cloud details. Speficify non default kubeconfig file location using $KUBECONFIG
 environment variable or pipe in file content from stdin. The config file
 can contain definitions for different k8s clusters, use --cluster-name to pick
-which one to use.
+which one to use. Several clusters may be imported at once by passing a
+comma separated list to --cluster-name, or by using --all-clusters; each
+cluster is then added as a cloud named <k8s name>-<cluster name>.
+
+Credentials using exec plugins or the OpenID Connect auth-provider are
+supported. The plugin is run, or the OIDC token refreshed, at import time.
+Only the token a plugin hands back is stored with the controller, so once
+it expires the credential must be imported again. OIDC refresh tokens are
+stored with the credential so that the controller can renew expired tokens.
 
 Examples:
     juju add-k8s myk8scloud
     KUBECONFIG=path-to-kubuconfig-file juju add-k8s myk8scloud --cluster-name=my_cluster_name
     kubectl config view --raw | juju add-k8s myk8scloud --cluster-name=my_cluster_name
+    juju add-k8s myk8scloud --cluster-name=cluster1,cluster2
+    juju add-k8s myk8scloud --all-clusters
 
 See also:
     remove-k8s
@@ -71,9 +83,13 @@ type AddCAASCommand struct {
 	// caasType is the type of CAAS being added
 	caasType string
 
-	// clusterName is the name of the cluster (k8s) or credential to import
+	// clusterName is the name of the cluster (k8s) or credential to import.
+	// Several comma separated cluster names may be specified.
 	clusterName string
 
+	// allClusters is true if every cluster in the config should be imported.
+	allClusters bool
+
 	cloudMetadataStore    CloudMetadataStore
 	fileCredentialStore   jujuclient.CredentialStore
 	apiFunc               func() (AddCloudAPI, error)
@@ -112,7 +128,8 @@ func (c *AddCAASCommand) Info() *cmd.Info {
 // SetFlags initializes the flags supported by the command.
 func (c *AddCAASCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
-	f.StringVar(&c.clusterName, "cluster-name", "", "Specify the k8s cluster to import")
+	f.StringVar(&c.clusterName, "cluster-name", "", "Specify the k8s cluster(s) to import, comma separated")
+	f.BoolVar(&c.allClusters, "all-clusters", false, "Import all k8s clusters in the config")
 }
 
 // Init populates the command with the args from the command line.
@@ -120,6 +137,9 @@ func (c *AddCAASCommand) Init(args []string) (err error) {
 	if len(args) == 0 {
 		return errors.Errorf("missing k8s name.")
 	}
+	if c.allClusters && c.clusterName != "" {
+		return errors.New("cannot specify both --cluster-name and --all-clusters")
+	}
 	c.caasType = "kubernetes"
 	c.caasName = args[0]
 	return cmd.CheckEmpty(args[1:])
@@ -169,24 +189,100 @@ func (c *AddCAASCommand) Run(ctxt *cmd.Context) error {
 		return errors.Errorf("No k8s cluster definitions found in config")
 	}
 
-	var context clientconfig.Context
-	clusterName := c.clusterName
-	if clusterName != "" {
+	contexts, err := c.selectContexts(caasConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	cloudClient, err := c.apiFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer cloudClient.Close()
+
+	for _, context := range contexts {
+		cloudName := c.caasName
+		if len(contexts) > 1 {
+			cloudName = fmt.Sprintf("%s-%s", c.caasName, context.CloudName)
+			if err := c.verifyName(cloudName); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if err := c.addContext(cloudClient, cloudName, caasConfig, context); err != nil {
+			return errors.Annotatef(err, "adding k8s cluster %q", context.CloudName)
+		}
+		if len(contexts) > 1 {
+			ctxt.Infof("k8s cluster %q added as cloud %q", context.CloudName, cloudName)
+		}
+	}
+	return nil
+}
+
+// selectContexts returns the contexts to import from the config, as
+// selected by the --cluster-name and --all-clusters options.
+func (c *AddCAASCommand) selectContexts(caasConfig *clientconfig.ClientConfig) ([]clientconfig.Context, error) {
+	if c.allClusters {
+		// A cluster may be referenced by more than one context, so
+		// import each cluster once, using the first context by name.
+		contextNames := make([]string, 0, len(caasConfig.Contexts))
+		for name := range caasConfig.Contexts {
+			contextNames = append(contextNames, name)
+		}
+		sort.Strings(contextNames)
+		seen := make(map[string]bool)
+		var contexts []clientconfig.Context
+		for _, name := range contextNames {
+			context := caasConfig.Contexts[name]
+			if seen[context.CloudName] {
+				continue
+			}
+			seen[context.CloudName] = true
+			contexts = append(contexts, context)
+		}
+		return contexts, nil
+	}
+
+	if c.clusterName == "" {
+		context, _ := caasConfig.Contexts[caasConfig.CurrentContext]
+		logger.Debugf("No cluster name specified, so use current context %q", caasConfig.CurrentContext)
+		if (clientconfig.Context{}) == context {
+			return nil, errors.NotFoundf("clusterName %q", c.clusterName)
+		}
+		return []clientconfig.Context{context}, nil
+	}
+
+	var contexts []clientconfig.Context
+	for _, clusterName := range strings.Split(c.clusterName, ",") {
+		clusterName = strings.TrimSpace(clusterName)
+		var context clientconfig.Context
 		for _, c := range caasConfig.Contexts {
 			if clusterName == c.CloudName {
 				context = c
 				break
 			}
 		}
-	} else {
-		context, _ = caasConfig.Contexts[caasConfig.CurrentContext]
-		logger.Debugf("No cluster name specified, so use current context %q", caasConfig.CurrentContext)
+		if (clientconfig.Context{}) == context {
+			return nil, errors.NotFoundf("clusterName %q", clusterName)
+		}
+		contexts = append(contexts, context)
 	}
+	return contexts, nil
+}
 
-	if (clientconfig.Context{}) == context {
-		return errors.NotFoundf("clusterName %q", clusterName)
+// addContext adds the cluster and credential referenced by the context
+// to the local cloud metadata and credential stores, and to the controller.
+func (c *AddCAASCommand) addContext(
+	cloudClient AddCloudAPI,
+	cloudName string,
+	caasConfig *clientconfig.ClientConfig,
+	context clientconfig.Context,
+) error {
+	// Only the credential of the selected context is resolved, since
+	// doing so may run the credential plugin named in the config.
+	credential, err := clientconfig.ResolveCredential(caasConfig.Credentials[context.CredentialName])
+	if err != nil {
+		return errors.Annotatef(err, "resolving credential %q", context.CredentialName)
 	}
-	credential := caasConfig.Credentials[context.CredentialName]
 	currentCloud := caasConfig.Clouds[context.CloudName]
 
 	cloudCAData, ok := currentCloud.Attributes["CAData"].(string)
@@ -195,7 +291,7 @@ func (c *AddCAASCommand) Run(ctxt *cmd.Context) error {
 	}
 
 	newCloud := cloud.Cloud{
-		Name:           c.caasName,
+		Name:           cloudName,
 		Type:           c.caasType,
 		Endpoint:       currentCloud.Endpoint,
 		AuthTypes:      []cloud.AuthType{credential.AuthType()},
@@ -206,21 +302,15 @@ func (c *AddCAASCommand) Run(ctxt *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
-	cloudClient, err := c.apiFunc()
-	if err != nil {
-		return errors.Trace(err)
-	}
-	defer cloudClient.Close()
-
 	if err := addCloudToController(cloudClient, newCloud); err != nil {
 		return errors.Trace(err)
 	}
 
-	if err := c.addCredentialToLocal(c.caasName, credential, context.CredentialName); err != nil {
+	if err := c.addCredentialToLocal(cloudName, credential, context.CredentialName); err != nil {
 		return errors.Trace(err)
 	}
 
-	if err := c.addCredentialToController(cloudClient, credential, context.CredentialName); err != nil {
+	if err := c.addCredentialToController(cloudClient, cloudName, credential, context.CredentialName); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -290,14 +380,14 @@ func (c *AddCAASCommand) addCredentialToLocal(cloudName string, newCredential cl
 	return nil
 }
 
-func (c *AddCAASCommand) addCredentialToController(apiClient AddCloudAPI, newCredential cloud.Credential, credentialName string) error {
+func (c *AddCAASCommand) addCredentialToController(apiClient AddCloudAPI, cloudName string, newCredential cloud.Credential, credentialName string) error {
 	currentAccountDetails, err := c.CurrentAccountDetails()
 	if err != nil {
 		return errors.Trace(err)
 	}
 
 	cloudCredTag := names.NewCloudCredentialTag(fmt.Sprintf("%s/%s/%s",
-		c.caasName, currentAccountDetails.User, credentialName))
+		cloudName, currentAccountDetails.User, credentialName))
 
 	if err := apiClient.AddCredential(cloudCredTag.String(), newCredential); err != nil {
 		return errors.Trace(err)
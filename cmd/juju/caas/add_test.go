@@ -435,3 +435,37 @@ func (s *addCAASSuite) TestCorrectSelectContext(c *gc.C) {
 		},
 	)
 }
+
+func (s *addCAASSuite) TestAllClustersAndClusterNameConflict(c *gc.C) {
+	cmd := s.makeCommand(c, true, false, true)
+	_, err := s.runCommand(c, nil, cmd, "myk8s", "--cluster-name", "mrcloud2", "--all-clusters")
+	c.Assert(err, gc.ErrorMatches, `cannot specify both --cluster-name and --all-clusters`)
+}
+
+func (s *addCAASSuite) assertClustersAdded(c *gc.C, args ...string) {
+	cmd := s.makeCommand(c, true, false, true)
+	ctx, err := s.runCommand(c, nil, cmd, args...)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"k8s cluster \"mrcloud1\" added as cloud \"myk8s-mrcloud1\"\n"+
+		"k8s cluster \"mrcloud2\" added as cloud \"myk8s-mrcloud2\"\n")
+
+	var written map[string]cloud.Cloud
+	for _, call := range s.store.Calls() {
+		if call.FuncName == "WritePersonalCloudMetadata" {
+			written = call.Args[0].(map[string]cloud.Cloud)
+		}
+	}
+	c.Assert(written["myk8s-mrcloud1"].Endpoint, gc.Equals, "fakeendpoint1")
+	c.Assert(written["myk8s-mrcloud1"].CACertificates, jc.DeepEquals, []string{"fakecadata1"})
+	c.Assert(written["myk8s-mrcloud2"].Endpoint, gc.Equals, "fakeendpoint2")
+	c.Assert(written["myk8s-mrcloud2"].CACertificates, jc.DeepEquals, []string{"fakecadata2"})
+}
+
+func (s *addCAASSuite) TestSelectMultipleClusters(c *gc.C) {
+	s.assertClustersAdded(c, "myk8s", "--cluster-name", "mrcloud1,mrcloud2")
+}
+
+func (s *addCAASSuite) TestAllClusters(c *gc.C) {
+	s.assertClustersAdded(c, "myk8s", "--all-clusters")
+}
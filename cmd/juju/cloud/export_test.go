@@ -92,3 +92,10 @@ func NewShowCredentialCommandForTest(api CredentialContentAPI) cmd.Command {
 	}}
 	return modelcmd.WrapBase(cmd)
 }
+
+func NewShowCloudCommandForTest(api CloudQuotaAPI) cmd.Command {
+	cmd := &showCloudCommand{newQuotaAPIFunc: func() (CloudQuotaAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.WrapBase(cmd)
+}
@@ -11,17 +11,25 @@ import (
 	"github.com/juju/gnuflag"
 	"gopkg.in/yaml.v2"
 
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
 )
 
 type showCloudCommand struct {
-	cmd.CommandBase
-	out cmd.Output
+	modelcmd.CommandBase
+	store jujuclient.ClientStore
+	out   cmd.Output
+
+	newQuotaAPIFunc func() (CloudQuotaAPI, error)
 
 	CloudName string
 
 	includeConfig bool
+	showQuota     bool
 }
 
 var showCloudDoc = `
@@ -32,10 +40,16 @@ options.
 If ‘--include-config’ is used, additional configuration (key, type, and
 description) specific to the cloud are displayed if available.
 
+If ‘--quota’ is used, the remaining resource quota and the estimated
+hourly cost of each instance type are displayed for the cloud region
+and credential of the current model, if the cloud reports them. The
+current model must be on the specified cloud.
+
 Examples:
 
     juju show-cloud google
     juju show-cloud azure-china --output ~/azure_cloud_details.txt
+    juju show-cloud aws --quota
 
 See also:
     clouds
@@ -44,7 +58,11 @@ See also:
 
 // NewShowCloudCommand returns a command to list cloud information.
 func NewShowCloudCommand() cmd.Command {
-	return &showCloudCommand{}
+	c := &showCloudCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+	c.newQuotaAPIFunc = c.newCloudQuotaAPI
+	return modelcmd.WrapBase(c)
 }
 
 func (c *showCloudCommand) SetFlags(f *gnuflag.FlagSet) {
@@ -54,6 +72,7 @@ func (c *showCloudCommand) SetFlags(f *gnuflag.FlagSet) {
 		"yaml": cmd.FormatYaml,
 	})
 	f.BoolVar(&c.includeConfig, "include-config", false, "Print available config option details specific to the specified cloud")
+	f.BoolVar(&c.showQuota, "quota", false, "Print the remaining quota and instance costs for the current model's region of the specified cloud")
 }
 
 func (c *showCloudCommand) Init(args []string) error {
@@ -84,6 +103,12 @@ func (c *showCloudCommand) Run(ctxt *cmd.Context) error {
 	if !ok {
 		return errors.NotFoundf("cloud %q", c.CloudName)
 	}
+	var quota *quotaDetails
+	if c.showQuota {
+		if quota, err = c.getQuota(); err != nil {
+			return err
+		}
+	}
 	if err = c.out.Write(ctxt, cloud); err != nil {
 		return err
 	}
@@ -91,12 +116,100 @@ func (c *showCloudCommand) Run(ctxt *cmd.Context) error {
 		config := getCloudConfigDetails(cloud.CloudType)
 		if len(config) > 0 {
 			fmt.Fprintln(ctxt.Stdout, fmt.Sprintf("\nThe available config options specific to %s clouds are:", cloud.CloudType))
-			return c.out.Write(ctxt, config)
+			if err := c.out.Write(ctxt, config); err != nil {
+				return err
+			}
 		}
 	}
+	if quota != nil {
+		fmt.Fprintln(ctxt.Stdout, fmt.Sprintf("\nThe quota for region %s of %s is:", quota.Region, c.CloudName))
+		return c.out.Write(ctxt, quota)
+	}
 	return nil
 }
 
+// CloudQuotaAPI provides the cloud quota of the current model.
+type CloudQuotaAPI interface {
+	CloudQuota() (params.CloudQuotaResult, error)
+	Close() error
+}
+
+func (c *showCloudCommand) newCloudQuotaAPI() (CloudQuotaAPI, error) {
+	controllerName, err := c.store.CurrentController()
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.New("there is no active controller")
+		}
+		return nil, errors.Trace(err)
+	}
+	modelName, err := c.store.CurrentModel(controllerName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.New("there is no current model")
+		}
+		return nil, errors.Trace(err)
+	}
+	root, err := c.NewAPIRoot(c.store, controllerName, modelName)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+type quotaLimitDetails struct {
+	Limit     int `yaml:"limit" json:"limit"`
+	Used      int `yaml:"used" json:"used"`
+	Remaining int `yaml:"remaining" json:"remaining"`
+}
+
+type quotaDetails struct {
+	Region        string                       `yaml:"-" json:"region,omitempty"`
+	Limits        map[string]quotaLimitDetails `yaml:"limits,omitempty" json:"limits,omitempty"`
+	Currency      string                       `yaml:"currency,omitempty" json:"currency,omitempty"`
+	InstanceCosts map[string]float64           `yaml:"hourly-instance-costs,omitempty" json:"hourly-instance-costs,omitempty"`
+}
+
+// getQuota returns the quota of the current model's cloud region,
+// which must be in the cloud being shown.
+func (c *showCloudCommand) getQuota() (*quotaDetails, error) {
+	api, err := c.newQuotaAPIFunc()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer api.Close()
+
+	result, err := api.CloudQuota()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting cloud quota")
+	}
+	if result.Cloud != c.CloudName {
+		return nil, errors.Errorf("the current model is on cloud %q, not %q", result.Cloud, c.CloudName)
+	}
+	details := &quotaDetails{Region: result.Region}
+	for _, limit := range result.Quota {
+		if details.Limits == nil {
+			details.Limits = make(map[string]quotaLimitDetails)
+		}
+		remaining := limit.Limit - limit.Used
+		if remaining < 0 {
+			remaining = 0
+		}
+		details.Limits[limit.Resource] = quotaLimitDetails{
+			Limit:     limit.Limit,
+			Used:      limit.Used,
+			Remaining: remaining,
+		}
+	}
+	for _, cost := range result.InstanceCosts {
+		if details.InstanceCosts == nil {
+			details.InstanceCosts = make(map[string]float64)
+		}
+		details.InstanceCosts[cost.InstanceType] = cost.HourlyCost
+		details.Currency = cost.Currency
+	}
+	return details, nil
+}
+
 type regionDetails struct {
 	Name             string `yaml:"-" json:"-"`
 	Endpoint         string `yaml:"endpoint,omitempty" json:"endpoint,omitempty"`
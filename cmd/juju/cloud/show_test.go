@@ -8,9 +8,11 @@ import (
 	"strings"
 
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/cloud"
 	"github.com/juju/juju/juju/osenv"
 	_ "github.com/juju/juju/provider/all"
@@ -241,3 +243,68 @@ func (s *showSuite) TestShowWithCACertificate(c *gc.C) {
 	out := cmdtesting.Stdout(ctx)
 	c.Assert(out, gc.Equals, resultWithCert)
 }
+
+type fakeCloudQuotaAPI struct {
+	result params.CloudQuotaResult
+	err    error
+}
+
+func (api *fakeCloudQuotaAPI) CloudQuota() (params.CloudQuotaResult, error) {
+	return api.result, api.err
+}
+
+func (*fakeCloudQuotaAPI) Close() error {
+	return nil
+}
+
+func (s *showSuite) TestShowQuota(c *gc.C) {
+	api := &fakeCloudQuotaAPI{result: params.CloudQuotaResult{
+		Cloud:  "aws-china",
+		Region: "cn-north-1",
+		Quota: []params.CloudQuotaLimit{
+			{Resource: "instances", Limit: 20, Used: 3},
+		},
+		InstanceCosts: []params.InstanceCost{
+			{InstanceType: "m4.large", HourlyCost: 0.1, Currency: "USD"},
+			{InstanceType: "t2.micro", HourlyCost: 0.012, Currency: "USD"},
+		},
+	}}
+	ctx, err := cmdtesting.RunCommand(c, cloud.NewShowCloudCommandForTest(api), "aws-china", "--quota")
+	c.Assert(err, jc.ErrorIsNil)
+	out := cmdtesting.Stdout(ctx)
+	c.Assert(out, gc.Equals, `
+defined: public
+type: ec2
+description: Amazon China
+auth-types: [access-key]
+regions:
+  cn-north-1:
+    endpoint: https://ec2.cn-north-1.amazonaws.com.cn
+
+The quota for region cn-north-1 of aws-china is:
+limits:
+  instances:
+    limit: 20
+    used: 3
+    remaining: 17
+currency: USD
+hourly-instance-costs:
+  m4.large: 0.1
+  t2.micro: 0.012
+`[1:])
+}
+
+func (s *showSuite) TestShowQuotaWrongCloud(c *gc.C) {
+	api := &fakeCloudQuotaAPI{result: params.CloudQuotaResult{
+		Cloud:  "aws",
+		Region: "us-east-1",
+	}}
+	_, err := cmdtesting.RunCommand(c, cloud.NewShowCloudCommandForTest(api), "aws-china", "--quota")
+	c.Assert(err, gc.ErrorMatches, `the current model is on cloud "aws", not "aws-china"`)
+}
+
+func (s *showSuite) TestShowQuotaNotSupported(c *gc.C) {
+	api := &fakeCloudQuotaAPI{err: errors.NotSupportedf("cloud quota reporting")}
+	_, err := cmdtesting.RunCommand(c, cloud.NewShowCloudCommandForTest(api), "aws-china", "--quota")
+	c.Assert(err, gc.ErrorMatches, "getting cloud quota: cloud quota reporting not supported")
+}
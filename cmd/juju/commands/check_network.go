@@ -0,0 +1,423 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/ansiterm"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	actionapi "github.com/juju/juju/api/action"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/jujuclient"
+)
+
+func newDefaultCheckNetworkCommand(store jujuclient.ClientStore) cmd.Command {
+	return newCheckNetworkCommand(store, time.After)
+}
+
+func newCheckNetworkCommand(store jujuclient.ClientStore, timeAfter func(time.Duration) <-chan time.Time) cmd.Command {
+	cmd := modelcmd.Wrap(&checkNetworkCommand{
+		timeAfter: timeAfter,
+	})
+	cmd.SetClientStore(store)
+	return cmd
+}
+
+// checkNetworkCommand asks the units on both sides of each relation to
+// probe each other, and reports which of them can reach one another.
+type checkNetworkCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.IAASOnlyCommand
+	out          cmd.Output
+	applications []string
+	probeTimeout time.Duration
+	timeout      time.Duration
+	timeAfter    func(time.Duration) <-chan time.Time
+}
+
+const checkNetworkDoc = `
+Check the network connectivity between related units. Only admin users of a
+model are able to use this command.
+
+Each unit in scope of a relation is asked to probe the ingress address and
+opened ports of every unit on the other side of that relation. Only TCP is
+probed, and only the first port of each opened range. When a unit has no
+opened ports, its host is probed on port 22 instead; a "refused" result then
+means the host answered but nothing is listening on that port.
+
+The units of remote applications on the far side of a cross-model relation
+are asked to probe back when their model is known to this client. Otherwise
+they are only probed.
+
+If applications are specified, only the relations of those applications are
+checked, otherwise all the relations in the model are checked.
+
+Since juju check-network creates actions, you can query for the status of
+the checks by calling "juju show-action-status --name juju-check-network".
+
+Examples:
+
+    juju check-network
+    juju check-network mysql wordpress
+    juju check-network --probe-timeout 10s mysql
+`
+
+func (c *checkNetworkCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "check-network",
+		Args:    "[<application name> ...]",
+		Purpose: "Check the network connectivity between related units.",
+		Doc:     checkNetworkDoc,
+	}
+}
+
+func (c *checkNetworkCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatNetworkChecksTabular,
+	})
+	f.DurationVar(&c.probeTimeout, "probe-timeout", 5*time.Second, "How long each probe waits for a connection")
+	f.DurationVar(&c.timeout, "timeout", 5*time.Minute, "How long to wait for the units to report their results")
+}
+
+func (c *checkNetworkCommand) Init(args []string) error {
+	var nameErrors []string
+	for _, application := range args {
+		if !names.IsValidApplication(application) {
+			nameErrors = append(nameErrors, fmt.Sprintf("  %q is not a valid application name", application))
+		}
+	}
+	if len(nameErrors) > 0 {
+		return errors.Errorf("The following applications are not valid:\n%s",
+			strings.Join(nameErrors, "\n"))
+	}
+	if c.probeTimeout <= 0 {
+		return errors.Errorf("--probe-timeout must be positive")
+	}
+	c.applications = args
+	return nil
+}
+
+// networkCheck holds the result of one unit probing one address and port
+// of a related unit.
+type networkCheck struct {
+	Relation string `yaml:"relation" json:"relation"`
+	From     string `yaml:"from" json:"from"`
+	To       string `yaml:"to,omitempty" json:"to,omitempty"`
+	Address  string `yaml:"address,omitempty" json:"address,omitempty"`
+	Probe    string `yaml:"probe,omitempty" json:"probe,omitempty"`
+	Result   string `yaml:"result" json:"result"`
+}
+
+func (c *checkNetworkCommand) Run(ctx *cmd.Context) error {
+	client, err := getCheckNetworkAPIClient(c)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results, err := client.CheckNetwork(params.CheckNetworkParams{
+		Applications: c.applications,
+		Timeout:      c.probeTimeout,
+	})
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	modelChecks := []*modelNetworkCheck{{
+		client:  client,
+		queries: networkCheckQueries(ctx, results.Results),
+	}}
+
+	// The units of remote applications are asked to probe back by
+	// running the check in their models, restricted to the relations
+	// with this one.
+	if len(results.RemoteModelUUIDs) > 0 {
+		_, details, err := c.ModelDetails()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, modelUUID := range results.RemoteModelUUIDs {
+			remoteClient, err := getCheckNetworkRemoteAPIClient(c, modelUUID)
+			if err != nil {
+				fmt.Fprintf(ctx.GetStderr(), "units of model %s will not probe back: %v\n", modelUUID, err)
+				continue
+			}
+			defer remoteClient.Close()
+			remoteResults, err := remoteClient.CheckNetwork(params.CheckNetworkParams{
+				RemoteModelUUID: details.ModelUUID,
+				Timeout:         c.probeTimeout,
+			})
+			if err != nil {
+				fmt.Fprintf(ctx.GetStderr(), "units of model %s will not probe back: %v\n", modelUUID, err)
+				continue
+			}
+			modelChecks = append(modelChecks, &modelNetworkCheck{
+				client:  remoteClient,
+				queries: networkCheckQueries(ctx, remoteResults.Results),
+			})
+		}
+	}
+
+	pending := func() int {
+		n := 0
+		for _, modelCheck := range modelChecks {
+			n += len(modelCheck.queries)
+		}
+		return n
+	}
+	if pending() == 0 {
+		ctx.Infof("No related units to check.")
+		return nil
+	}
+
+	timeout := c.timeAfter(c.timeout)
+	var checks []networkCheck
+	for pending() > 0 {
+		for _, modelCheck := range modelChecks {
+			if len(modelCheck.queries) == 0 {
+				continue
+			}
+			actionResults, err := modelCheck.client.Actions(entities(modelCheck.queries))
+			if err != nil {
+				return errors.Trace(err)
+			}
+
+			var newActionsToQuery []actionQuery
+			for i, result := range actionResults.Results {
+				if result.Error == nil {
+					switch result.Status {
+					case params.ActionRunning, params.ActionPending:
+						newActionsToQuery = append(newActionsToQuery, modelCheck.queries[i])
+						continue
+					}
+				}
+				checks = append(checks, convertNetworkCheckResult(result, modelCheck.queries[i])...)
+			}
+			modelCheck.queries = newActionsToQuery
+		}
+
+		if pending() > 0 {
+			var timedOut bool
+			select {
+			case <-timeout:
+				timedOut = true
+			case <-c.timeAfter(1 * time.Second):
+			}
+			if timedOut {
+				break
+			}
+		}
+	}
+
+	sort.Sort(networkChecksByUnit(checks))
+	if len(checks) > 0 {
+		if err := c.out.Write(ctx, checks); err != nil {
+			return err
+		}
+	}
+
+	if n := pending(); n > 0 {
+		suffix := ""
+		if n > 1 {
+			suffix = "s"
+		}
+		var receivers []string
+		for _, modelCheck := range modelChecks {
+			for _, actionToQuery := range modelCheck.queries {
+				receivers = append(receivers, names.ReadableString(actionToQuery.receiver.tag))
+			}
+		}
+		return errors.Errorf(
+			"timed out waiting for result%s from: %s",
+			suffix, strings.Join(receivers, ", "),
+		)
+	}
+	return nil
+}
+
+// modelNetworkCheck holds the juju-check-network actions queued in one
+// model that have yet to complete, along with the client to query them.
+type modelNetworkCheck struct {
+	client  CheckNetworkClient
+	queries []actionQuery
+}
+
+// networkCheckQueries returns the actions to query for the results of
+// the juju-check-network actions that were queued.
+func networkCheckQueries(ctx *cmd.Context, results []params.ActionResult) []actionQuery {
+	var actionsToQuery []actionQuery
+	for _, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.GetStderr(), "couldn't queue one action: %v\n", result.Error)
+			continue
+		}
+		actionTag, err := names.ParseActionTag(result.Action.Tag)
+		if err != nil {
+			fmt.Fprintf(ctx.GetStderr(), "got invalid action tag %v for receiver %v\n", result.Action.Tag, result.Action.Receiver)
+			continue
+		}
+		receiverTag, err := names.ActionReceiverFromTag(result.Action.Receiver)
+		if err != nil {
+			fmt.Fprintf(ctx.GetStderr(), "got invalid action receiver tag %v for action %v\n", result.Action.Receiver, result.Action.Tag)
+			continue
+		}
+		actionsToQuery = append(actionsToQuery, actionQuery{
+			actionTag: actionTag,
+			receiver: actionReceiver{
+				receiverType: "UnitId",
+				tag:          receiverTag,
+			}})
+	}
+	return actionsToQuery
+}
+
+// convertNetworkCheckResult returns a network check for each probe
+// reported in the results of a completed juju-check-network action.
+func convertNetworkCheckResult(result params.ActionResult, query actionQuery) []networkCheck {
+	from := query.receiver.tag.Id()
+	if result.Error != nil {
+		return []networkCheck{{From: from, Result: "error: " + result.Error.Error()}}
+	}
+	var relation string
+	if result.Action != nil {
+		relation, _ = result.Action.Parameters["relation"].(string)
+	}
+	if result.Status != params.ActionCompleted {
+		message := result.Status
+		if result.Message != "" {
+			message += ": " + result.Message
+		}
+		return []networkCheck{{Relation: relation, From: from, Result: message}}
+	}
+
+	var checks []networkCheck
+	probes, _ := result.Output["probes"].(map[string]interface{})
+	for unit, p := range probes {
+		unitProbes, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		address, _ := unitProbes["address"].(string)
+		for probe, r := range unitProbes {
+			if probe == "address" {
+				continue
+			}
+			value, _ := r.(string)
+			checks = append(checks, networkCheck{
+				Relation: relation,
+				From:     from,
+				To:       unit,
+				Address:  address,
+				Probe:    probe,
+				Result:   value,
+			})
+		}
+	}
+	return checks
+}
+
+type networkChecksByUnit []networkCheck
+
+func (s networkChecksByUnit) Len() int      { return len(s) }
+func (s networkChecksByUnit) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s networkChecksByUnit) Less(i, j int) bool {
+	a, b := s[i], s[j]
+	if a.Relation != b.Relation {
+		return a.Relation < b.Relation
+	}
+	if a.From != b.From {
+		return a.From < b.From
+	}
+	if a.To != b.To {
+		return a.To < b.To
+	}
+	return a.Probe < b.Probe
+}
+
+// formatNetworkChecksTabular writes a matrix of the probes made between
+// related units, highlighting those that failed.
+func formatNetworkChecksTabular(writer io.Writer, value interface{}) error {
+	checks, ok := value.([]networkCheck)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", checks, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Relation", "From", "To", "Address", "Probe", "Result")
+	for _, check := range checks {
+		w.Print(check.Relation, check.From, check.To, check.Address, check.Probe)
+		var highlight *ansiterm.Context
+		switch {
+		case check.Result == "reachable":
+			highlight = output.GoodHighlight
+		case !strings.HasPrefix(check.Result, "not probed"):
+			highlight = output.ErrorHighlight
+		}
+		w.PrintColor(highlight, check.Result)
+		w.Println()
+	}
+	tw.Flush()
+	return nil
+}
+
+// CheckNetworkClient exposes the capabilities required by the
+// check-network command.
+type CheckNetworkClient interface {
+	action.APIClient
+	CheckNetwork(params.CheckNetworkParams) (params.CheckNetworkResults, error)
+}
+
+// In order to be able to easily mock out the API side for testing,
+// the API client is retrieved using a function.
+var getCheckNetworkAPIClient = func(c *checkNetworkCommand) (CheckNetworkClient, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return actionapi.NewClient(root), nil
+}
+
+// getCheckNetworkRemoteAPIClient returns a client for the model with the
+// given UUID, which must be known to the client store.
+var getCheckNetworkRemoteAPIClient = func(c *checkNetworkCommand, modelUUID string) (CheckNetworkClient, error) {
+	store := c.ClientStore()
+	controllers, err := store.AllControllers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for controllerName := range controllers {
+		models, err := store.AllModels(controllerName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for modelName, details := range models {
+			if details.ModelUUID != modelUUID {
+				continue
+			}
+			root, err := c.CommandBase.NewAPIRoot(store, controllerName, modelName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return actionapi.NewClient(root), nil
+		}
+	}
+	return nil, errors.NotFoundf("model %s in the client store", modelUUID)
+}
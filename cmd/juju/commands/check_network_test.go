@@ -0,0 +1,267 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/model"
+	"github.com/juju/juju/testing"
+)
+
+type CheckNetworkSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api       *mockCheckNetworkAPI
+	remoteAPI *mockCheckNetworkAPI
+	store     *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&CheckNetworkSuite{})
+
+func (s *CheckNetworkSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &mockCheckNetworkAPI{}
+	s.PatchValue(&getCheckNetworkAPIClient, func(*checkNetworkCommand) (CheckNetworkClient, error) {
+		return s.api, nil
+	})
+	s.remoteAPI = &mockCheckNetworkAPI{}
+	s.PatchValue(&getCheckNetworkRemoteAPIClient, func(_ *checkNetworkCommand, modelUUID string) (CheckNetworkClient, error) {
+		if modelUUID != remoteModelUUID {
+			return nil, errors.NotFoundf("model %s in the client store", modelUUID)
+		}
+		return s.remoteAPI, nil
+	})
+	s.store = jujuclienttesting.MinimalStore()
+	s.store.Models["arthur"].Models["king/sword"] = jujuclient.ModelDetails{
+		ModelUUID: localModelUUID,
+		ModelType: model.IAAS,
+	}
+}
+
+const (
+	localModelUUID  = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	remoteModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+)
+
+func (s *CheckNetworkSuite) runCheckNetwork(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, newCheckNetworkCommand(s.store, (&mockClock{}).After), args...)
+}
+
+func (s *CheckNetworkSuite) TestInitInvalidApplication(c *gc.C) {
+	_, err := s.runCheckNetwork(c, "mysql", "not/valid")
+	c.Assert(err, gc.ErrorMatches, "The following applications are not valid:\n  \"not/valid\" is not a valid application name")
+}
+
+func (s *CheckNetworkSuite) TestInitInvalidProbeTimeout(c *gc.C) {
+	_, err := s.runCheckNetwork(c, "--probe-timeout", "0s")
+	c.Assert(err, gc.ErrorMatches, "--probe-timeout must be positive")
+}
+
+func (s *CheckNetworkSuite) TestBlocked(c *gc.C) {
+	s.api.block = true
+	_, err := s.runCheckNetwork(c)
+	testing.AssertOperationWasBlocked(c, err, ".*To enable changes.*")
+}
+
+func (s *CheckNetworkSuite) TestNoRelatedUnits(c *gc.C) {
+	ctx, err := s.runCheckNetwork(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No related units to check.\n")
+}
+
+func (s *CheckNetworkSuite) setUpResults() {
+	s.api.results = []params.ActionResult{{
+		Action: &params.Action{Tag: "action-1", Receiver: "unit-wordpress-0"},
+	}, {
+		Action: &params.Action{Tag: "action-2", Receiver: "unit-mysql-0"},
+	}}
+	s.api.actions = map[string]params.ActionResult{
+		"action-1": {
+			Action: &params.Action{
+				Tag:        "action-1",
+				Receiver:   "unit-wordpress-0",
+				Parameters: map[string]interface{}{"relation": "wordpress:db mysql:server"},
+			},
+			Status: params.ActionCompleted,
+			Output: map[string]interface{}{
+				"probes": map[string]interface{}{
+					"mysql/0": map[string]interface{}{
+						"address":  "10.0.0.2",
+						"3306/tcp": "reachable",
+					},
+				},
+			},
+		},
+		"action-2": {
+			Action: &params.Action{
+				Tag:        "action-2",
+				Receiver:   "unit-mysql-0",
+				Parameters: map[string]interface{}{"relation": "wordpress:db mysql:server"},
+			},
+			Status: params.ActionCompleted,
+			Output: map[string]interface{}{
+				"probes": map[string]interface{}{
+					"wordpress/0": map[string]interface{}{
+						"address": "10.0.0.1",
+						"host":    "unreachable: i/o timeout",
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *CheckNetworkSuite) TestCheckNetworkTabular(c *gc.C) {
+	s.setUpResults()
+	ctx, err := s.runCheckNetwork(c, "mysql", "--probe-timeout", "10s")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.args, jc.DeepEquals, params.CheckNetworkParams{
+		Applications: []string{"mysql"},
+		Timeout:      10 * time.Second,
+	})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Relation                   From         To           Address   Probe     Result\n"+
+		"wordpress:db mysql:server  mysql/0      wordpress/0  10.0.0.1  host      unreachable: i/o timeout  \n"+
+		"wordpress:db mysql:server  wordpress/0  mysql/0      10.0.0.2  3306/tcp  reachable                 \n")
+}
+
+func (s *CheckNetworkSuite) TestCheckNetworkYAML(c *gc.C) {
+	s.setUpResults()
+	ctx, err := s.runCheckNetwork(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+- relation: wordpress:db mysql:server
+  from: mysql/0
+  to: wordpress/0
+  address: 10.0.0.1
+  probe: host
+  result: 'unreachable: i/o timeout'
+- relation: wordpress:db mysql:server
+  from: wordpress/0
+  to: mysql/0
+  address: 10.0.0.2
+  probe: 3306/tcp
+  result: reachable
+`[1:])
+}
+
+func (s *CheckNetworkSuite) TestCheckNetworkFailedAction(c *gc.C) {
+	s.api.results = []params.ActionResult{{
+		Action: &params.Action{Tag: "action-1", Receiver: "unit-wordpress-0"},
+	}}
+	s.api.actions = map[string]params.ActionResult{
+		"action-1": {
+			Action: &params.Action{
+				Tag:        "action-1",
+				Receiver:   "unit-wordpress-0",
+				Parameters: map[string]interface{}{"relation": "wordpress:db mysql:server"},
+			},
+			Status:  params.ActionFailed,
+			Message: "boom",
+		},
+	}
+	ctx, err := s.runCheckNetwork(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals,
+		`[{"relation":"wordpress:db mysql:server","from":"wordpress/0","result":"failed: boom"}]`+"\n")
+}
+
+func (s *CheckNetworkSuite) TestCheckNetworkRemoteUnitsProbeBack(c *gc.C) {
+	s.setUpResults()
+	s.api.remoteModelUUIDs = []string{remoteModelUUID}
+	s.remoteAPI.results = []params.ActionResult{{
+		Action: &params.Action{Tag: "action-3", Receiver: "unit-postgresql-0"},
+	}}
+	s.remoteAPI.actions = map[string]params.ActionResult{
+		"action-3": {
+			Action: &params.Action{
+				Tag:        "action-3",
+				Receiver:   "unit-postgresql-0",
+				Parameters: map[string]interface{}{"relation": "wordpress:db postgresql:db"},
+			},
+			Status: params.ActionCompleted,
+			Output: map[string]interface{}{
+				"probes": map[string]interface{}{
+					"remote-f47ac10b/0": map[string]interface{}{
+						"address": "10.0.0.1",
+						"host":    "refused",
+					},
+				},
+			},
+		},
+	}
+	ctx, err := s.runCheckNetwork(c, "--probe-timeout", "10s")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.remoteAPI.args, jc.DeepEquals, params.CheckNetworkParams{
+		RemoteModelUUID: localModelUUID,
+		Timeout:         10 * time.Second,
+	})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Relation                    From          To                 Address   Probe     Result\n"+
+		"wordpress:db mysql:server   mysql/0       wordpress/0        10.0.0.1  host      unreachable: i/o timeout  \n"+
+		"wordpress:db mysql:server   wordpress/0   mysql/0            10.0.0.2  3306/tcp  reachable                 \n"+
+		"wordpress:db postgresql:db  postgresql/0  remote-f47ac10b/0  10.0.0.1  host      refused                   \n")
+}
+
+func (s *CheckNetworkSuite) TestCheckNetworkUnknownRemoteModel(c *gc.C) {
+	s.setUpResults()
+	s.api.remoteModelUUIDs = []string{"6f2ab3c1-1d47-4a8e-9c0e-3b5a7f9d2e10"}
+	ctx, err := s.runCheckNetwork(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals,
+		"units of model 6f2ab3c1-1d47-4a8e-9c0e-3b5a7f9d2e10 will not probe back: "+
+			"model 6f2ab3c1-1d47-4a8e-9c0e-3b5a7f9d2e10 in the client store not found\n")
+	c.Check(s.remoteAPI.args, jc.DeepEquals, params.CheckNetworkParams{})
+}
+
+type mockCheckNetworkAPI struct {
+	action.APIClient
+	block   bool
+	args    params.CheckNetworkParams
+	results []params.ActionResult
+	actions map[string]params.ActionResult
+
+	remoteModelUUIDs []string
+}
+
+var _ CheckNetworkClient = (*mockCheckNetworkAPI)(nil)
+
+func (*mockCheckNetworkAPI) Close() error {
+	return nil
+}
+
+func (m *mockCheckNetworkAPI) CheckNetwork(args params.CheckNetworkParams) (params.CheckNetworkResults, error) {
+	if m.block {
+		return params.CheckNetworkResults{}, common.OperationBlockedError("the operation has been blocked")
+	}
+	m.args = args
+	return params.CheckNetworkResults{
+		Results:          m.results,
+		RemoteModelUUIDs: m.remoteModelUUIDs,
+	}, nil
+}
+
+func (m *mockCheckNetworkAPI) Actions(args params.Entities) (params.ActionResults, error) {
+	results := params.ActionResults{Results: make([]params.ActionResult, len(args.Entities))}
+	for i, entity := range args.Entities {
+		result, ok := m.actions[entity.Tag]
+		if !ok {
+			result = params.ActionResult{Error: &params.Error{Message: "action not found"}}
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
@@ -280,6 +280,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(crossmodel.NewShowOfferedEndpointCommand())
 	r.Register(crossmodel.NewListEndpointsCommand())
 	r.Register(crossmodel.NewFindEndpointsCommand())
+	r.Register(crossmodel.NewOfferTokensCommand())
+	r.Register(crossmodel.NewRevokeOfferTokenCommand())
+	r.Register(crossmodel.NewShowOfferConnectionCommand())
 	r.Register(application.NewConsumeCommand())
 	r.Register(application.NewSuspendRelationCommand())
 	r.Register(application.NewResumeRelationCommand())
@@ -301,6 +304,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Error resolution and debugging commands.
 	r.Register(newDefaultRunCommand(nil))
+	r.Register(newDefaultCheckNetworkCommand(nil))
 	r.Register(newSCPCommand(nil))
 	r.Register(newSSHCommand(nil, nil))
 	r.Register(application.NewResolvedCommand())
@@ -427,6 +431,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	// Manage subnets
 	r.Register(subnet.NewAddCommand())
 	r.Register(subnet.NewListCommand())
+	r.Register(subnet.NewSetRoutesCommand())
 	if featureflag.Enabled(feature.PostNetCLIMVP) {
 		r.Register(subnet.NewCreateCommand())
 		r.Register(subnet.NewRemoveCommand())
@@ -443,6 +448,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewConfigCommand())
+	r.Register(controller.NewFindOrphansCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -416,6 +416,7 @@ var commandNames = []string{
 	"change-user-password",
 	"charm",
 	"charm-resources",
+	"check-network",
 	"clouds",
 	"collect-metrics",
 	"config",
@@ -443,6 +444,7 @@ var commandNames = []string{
 	"export-bundle",
 	"expose",
 	"find-offers",
+	"find-orphans",
 	"firewall-rules",
 	"get-constraints",
 	"get-model-constraints",
@@ -489,6 +491,7 @@ var commandNames = []string{
 	"model-defaults",
 	"models",
 	"offer",
+	"offer-tokens",
 	"offers",
 	"payloads",
 	"plans",
@@ -518,6 +521,7 @@ var commandNames = []string{
 	"resume-relation",
 	"retry-provisioning",
 	"revoke",
+	"revoke-offer-token",
 	"run",
 	"run-action",
 	"scale-application",
@@ -530,6 +534,7 @@ var commandNames = []string{
 	"set-model-constraints",
 	"set-plan",
 	"set-series",
+	"set-subnet-routes",
 	"set-wallet",
 	"show-action-output",
 	"show-action-status",
@@ -541,6 +546,7 @@ var commandNames = []string{
 	"show-machine",
 	"show-model",
 	"show-offer",
+	"show-offer-connection",
 	"show-status",
 	"show-status-log",
 	"show-storage",
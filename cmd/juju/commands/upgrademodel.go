@@ -57,6 +57,12 @@ configured agent stream for that model. It's possible to overwrite this for
 the lifetime of this upgrade using --agent-stream
 If a failed upgrade has been resolved, '--reset-previous-upgrade' can be
 used to allow the upgrade to proceed.
+Once the controller has been upgraded, the operators of applications in
+Kubernetes models are upgraded in place, one application at a time, without
+restarting the applications' units. Progress is shown in the model status
+reported by ` + "`juju status`" + `. An operator which does not become healthy
+after its upgrade is rolled back to the image it was previously running, and
+is not upgraded to that version again.
 Backups are recommended prior to upgrading.
 
 Examples:
@@ -189,3 +189,13 @@ func NewData(api destroyControllerAPI, ctrUUID string) (ctrData, []modelData, er
 var (
 	NoModelsMessage = noModelsMessage
 )
+
+// NewFindOrphansCommandForTest returns a findOrphansCommand with the
+// API mocked out.
+func NewFindOrphansCommandForTest(api findOrphansAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &findOrphansCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
@@ -0,0 +1,205 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewFindOrphansCommand returns a command that reports, and optionally
+// destroys, cloud resources leaked by the controller's models.
+func NewFindOrphansCommand() cmd.Command {
+	return modelcmd.WrapController(&findOrphansCommand{})
+}
+
+type findOrphansCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	api     findOrphansAPI
+	destroy bool
+}
+
+type findOrphansAPI interface {
+	Close() error
+	FindOrphans(destroy bool) ([]params.CloudOrphans, error)
+}
+
+const findOrphansDoc = `
+Juju tags the instances, volumes and security groups that it creates
+with the UUIDs of the controller and model they belong to. If the
+destruction of a model or machine fails part way through, some of these
+resources may be left behind in the cloud, where they continue to cost
+money.
+
+find-orphans lists the resources in the cloud tagged with the current
+controller's UUID that are no longer used by any of its models: those
+whose model or machine no longer exists. Each cloud region and
+credential used by the controller's models is checked. Orphaned
+resources in regions no longer hosting any model are not found.
+
+With --destroy, the orphaned resources are destroyed as well as listed.
+Take care to review the output of find-orphans without --destroy first.
+
+Additional tags can be applied to the resources of a model by setting
+its "resource-tags" configuration.
+
+Examples:
+
+    juju find-orphans
+    juju find-orphans --destroy
+    juju model-config resource-tags="team=infra cost-centre=42"
+
+See also:
+    destroy-model
+    model-config
+    remove-machine
+`
+
+// Info implements Command.Info.
+func (c *findOrphansCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "find-orphans",
+		Purpose: "Lists, and optionally destroys, cloud resources leaked by the controller's models.",
+		Doc:     findOrphansDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *findOrphansCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.destroy, "destroy", false, "Destroy the orphaned resources")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatOrphansTabular,
+	})
+}
+
+func (c *findOrphansCommand) getAPI() (findOrphansAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+// CloudOrphans holds the orphaned resources found in a cloud region.
+type CloudOrphans struct {
+	Cloud     string             `yaml:"cloud" json:"cloud"`
+	Region    string             `yaml:"region,omitempty" json:"region,omitempty"`
+	Orphans   []OrphanedResource `yaml:"orphans,omitempty" json:"orphans,omitempty"`
+	Destroyed bool               `yaml:"destroyed" json:"destroyed"`
+	Error     string             `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// OrphanedResource describes a cloud resource that is no longer used
+// by any model.
+type OrphanedResource struct {
+	Kind   string `yaml:"kind" json:"kind"`
+	Id     string `yaml:"id" json:"id"`
+	Model  string `yaml:"model-uuid,omitempty" json:"model-uuid,omitempty"`
+	Reason string `yaml:"reason" json:"reason"`
+}
+
+// Run implements Command.Run.
+func (c *findOrphansCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	results, err := client.FindOrphans(c.destroy)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var found, failed bool
+	orphans := make([]CloudOrphans, len(results))
+	for i, result := range results {
+		cloudTag, err := names.ParseCloudTag(result.CloudTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		orphans[i] = CloudOrphans{
+			Cloud:     cloudTag.Id(),
+			Region:    result.Region,
+			Destroyed: result.Destroyed,
+		}
+		if result.Error != nil {
+			orphans[i].Error = result.Error.Error()
+			failed = true
+		}
+		for _, o := range result.Orphans {
+			orphans[i].Orphans = append(orphans[i].Orphans, OrphanedResource{
+				Kind:   o.Kind,
+				Id:     o.Id,
+				Model:  o.ModelUUID,
+				Reason: o.Reason,
+			})
+			found = true
+		}
+	}
+
+	if c.out.Name() != "tabular" || found {
+		if err := c.out.Write(ctx, orphans); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		ctx.Infof("No orphaned resources found.")
+	}
+	if failed {
+		if c.out.Name() == "tabular" {
+			// The errors are not included in the tabular output.
+			for _, o := range orphans {
+				if o.Error != "" {
+					ctx.Infof("ERROR checking %s: %s", cloudRegionName(o), o.Error)
+				}
+			}
+		}
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// cloudRegionName returns the name of the cloud region in which the
+// resources were found, in the form used on the command line.
+func cloudRegionName(o CloudOrphans) string {
+	if o.Region == "" {
+		return o.Cloud
+	}
+	return o.Cloud + "/" + o.Region
+}
+
+// formatOrphansTabular writes a tabular summary of the orphaned
+// resources.
+func formatOrphansTabular(writer io.Writer, value interface{}) error {
+	results, ok := value.([]CloudOrphans)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", results, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Cloud", "Kind", "Id", "Model", "Status", "Reason")
+	for _, result := range results {
+		status := "orphaned"
+		if result.Destroyed {
+			status = "destroyed"
+		}
+		for _, o := range result.Orphans {
+			w.Println(cloudRegionName(result), o.Kind, o.Id, o.Model, status, o.Reason)
+		}
+	}
+	tw.Flush()
+	return nil
+}
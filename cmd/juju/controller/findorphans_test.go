@@ -0,0 +1,125 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+)
+
+type findOrphansSuite struct {
+	baseControllerSuite
+	api   *fakeFindOrphansAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&findOrphansSuite{})
+
+func (s *findOrphansSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeFindOrphansAPI{
+		results: []params.CloudOrphans{{
+			CloudTag: "cloud-aws",
+			Region:   "us-east-1",
+			Orphans: []params.OrphanedResource{{
+				Kind:      "instance",
+				Id:        "i-0123",
+				ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				Reason:    "model no longer exists",
+			}, {
+				Kind:      "volume",
+				Id:        "vol-0123",
+				ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				Reason:    "model no longer exists",
+			}},
+		}},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *findOrphansSuite) newCommand() cmd.Command {
+	return controller.NewFindOrphansCommandForTest(s.api, s.store)
+}
+
+func (s *findOrphansSuite) TestFindOrphans(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.destroy, jc.IsFalse)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Cloud          Kind      Id        Model                                 Status    Reason\n"+
+		"aws/us-east-1  instance  i-0123    deadbeef-0bad-400d-8000-4b1d0d06f00d  orphaned  model no longer exists\n"+
+		"aws/us-east-1  volume    vol-0123  deadbeef-0bad-400d-8000-4b1d0d06f00d  orphaned  model no longer exists\n")
+}
+
+func (s *findOrphansSuite) TestFindOrphansDestroy(c *gc.C) {
+	s.api.results[0].Destroyed = true
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--destroy", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.destroy, jc.IsTrue)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- cloud: aws
+  region: us-east-1
+  orphans:
+  - kind: instance
+    id: i-0123
+    model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+    reason: model no longer exists
+  - kind: volume
+    id: vol-0123
+    model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+    reason: model no longer exists
+  destroyed: true
+`[1:])
+}
+
+func (s *findOrphansSuite) TestFindOrphansNone(c *gc.C) {
+	s.api.results = []params.CloudOrphans{{CloudTag: "cloud-aws", Region: "us-east-1"}}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No orphaned resources found.\n")
+}
+
+func (s *findOrphansSuite) TestFindOrphansRegionError(c *gc.C) {
+	s.api.results = append(s.api.results, params.CloudOrphans{
+		CloudTag: "cloud-maas",
+		Error:    &params.Error{Message: `finding orphaned resources in "maas" cloud not supported`},
+	})
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Not(gc.Equals), "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals,
+		`ERROR checking maas: finding orphaned resources in "maas" cloud not supported`+"\n")
+}
+
+func (s *findOrphansSuite) TestFindOrphansError(c *gc.C) {
+	s.api.err = common.ErrPerm
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeFindOrphansAPI struct {
+	results []params.CloudOrphans
+	err     error
+	destroy bool
+}
+
+func (f *fakeFindOrphansAPI) Close() error {
+	return nil
+}
+
+func (f *fakeFindOrphansAPI) FindOrphans(destroy bool) ([]params.CloudOrphans, error) {
+	f.destroy = destroy
+	return f.results, f.err
+}
@@ -70,3 +70,29 @@ func NewRemoveCommandForTest(store jujuclient.ClientStore, api RemoveAPI) cmd.Co
 	aCmd.SetClientStore(store)
 	return modelcmd.WrapController(aCmd)
 }
+
+func NewOfferTokensCommandForTest(store jujuclient.ClientStore, api ShareTokensAPI) cmd.Command {
+	aCmd := &offerTokensCommand{}
+	aCmd.newAPIFunc = func(controllerName string) (ShareTokensAPI, error) {
+		return api, nil
+	}
+	aCmd.SetClientStore(store)
+	return modelcmd.WrapController(aCmd)
+}
+
+func NewRevokeOfferTokenCommandForTest(store jujuclient.ClientStore, api ShareTokensAPI) cmd.Command {
+	aCmd := &revokeOfferTokenCommand{}
+	aCmd.newAPIFunc = func(controllerName string) (ShareTokensAPI, error) {
+		return api, nil
+	}
+	aCmd.SetClientStore(store)
+	return modelcmd.WrapController(aCmd)
+}
+
+func NewShowOfferConnectionCommandForTest(store jujuclient.ClientStore, api ShowConnectionAPI) cmd.Command {
+	aCmd := &showOfferConnectionCommand{newAPIFunc: func() (ShowConnectionAPI, error) {
+		return api, nil
+	}}
+	aCmd.SetClientStore(store)
+	return modelcmd.Wrap(aCmd)
+}
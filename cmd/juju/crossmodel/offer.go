@@ -4,8 +4,10 @@
 package crossmodel
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -31,10 +33,17 @@ $ juju offer mysql:db
 $ juju offer mymodel.mysql:db
 $ juju offer db2:db hosted-db2
 $ juju offer db2:db,log hosted-db2
+$ juju offer --share --share-expiry 72h mysql:db
+
+When --share is specified, a share token is printed once the offer has been
+made. The token can be passed to "juju consume" to consume the offer without
+being granted access to it, until the token expires or is revoked.
 
 See also:
     consume
     relate
+    offer-tokens
+    revoke-offer-token
 `
 )
 
@@ -65,6 +74,12 @@ type offerCommand struct {
 
 	// QualifiedModelName stores the name of the model hosting the offer.
 	QualifiedModelName string
+
+	// Share is true if a share token should be created for the offer.
+	Share bool
+
+	// ShareExpiry is how long the share token remains valid.
+	ShareExpiry time.Duration
 }
 
 // NewApplicationOffersAPI returns an application offers api for the root api endpoint
@@ -98,12 +113,17 @@ func (c *offerCommand) Init(args []string) error {
 		argCount = 2
 		c.OfferName = args[1]
 	}
+	if c.ShareExpiry <= 0 {
+		return errors.New("--share-expiry must be positive")
+	}
 	return cmd.CheckEmpty(args[argCount:])
 }
 
 // SetFlags implements Command.SetFlags.
 func (c *offerCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.Share, "share", false, "Create a share token which can be used to consume the offer")
+	f.DurationVar(&c.ShareExpiry, "share-expiry", 24*time.Hour, "How long the share token remains valid")
 }
 
 // Run implements Command.Run.
@@ -165,6 +185,16 @@ func (c *offerCommand) Run(ctx *cmd.Context) error {
 	url := jujucrossmodel.MakeURL(ownerTag.Name(), unqualifiedModelName, c.OfferName, "")
 	ep := strings.Join(c.Endpoints, ", ")
 	ctx.Infof("Application %q endpoints [%s] available at %q", c.Application, ep, url)
+	if !c.Share {
+		return nil
+	}
+
+	token, encoded, err := api.CreateShareToken(url, c.ShareExpiry)
+	if err != nil {
+		return errors.Annotate(err, "creating share token")
+	}
+	ctx.Infof("Share token %q for %q expires at %s", token.Id, url, token.Expires.Local().Format(time.RFC3339))
+	fmt.Fprintln(ctx.Stdout, encoded)
 	return nil
 }
 
@@ -172,6 +202,7 @@ func (c *offerCommand) Run(ctx *cmd.Context) error {
 type OfferAPI interface {
 	Close() error
 	Offer(modelUUID, application string, endpoints []string, offerName string, desc string) ([]params.ErrorResult, error)
+	CreateShareToken(offerURL string, expiry time.Duration) (params.OfferShareToken, string, error)
 }
 
 // applicationParse is used to split an application string
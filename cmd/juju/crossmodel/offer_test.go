@@ -4,6 +4,8 @@
 package crossmodel_test
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
@@ -107,6 +109,28 @@ func (s *offerSuite) TestOfferMultipleEndpoints(c *gc.C) {
 	s.assertOfferOutput(c, "test", "tst", "tst", []string{"db", "admin"})
 }
 
+func (s *offerSuite) TestOfferShare(c *gc.C) {
+	ctx, err := s.runOffer(c, "--share", "--share-expiry", "2h", "tst:db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.offers["tst"], jc.SameContents, []string{"db"})
+	c.Assert(s.mockAPI.shareURL, gc.Equals, "fred/test.tst")
+	c.Assert(s.mockAPI.shareExpiry, gc.Equals, 2*time.Hour)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "encoded-token\n")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Matches, `(?s).*Share token "token-0" for "fred/test.tst" expires at .*`)
+}
+
+func (s *offerSuite) TestOfferWithoutShare(c *gc.C) {
+	ctx, err := s.runOffer(c, "tst:db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.shareURL, gc.Equals, "")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+}
+
+func (s *offerSuite) TestOfferShareInvalidExpiry(c *gc.C) {
+	s.args = []string{"--share", "--share-expiry", "0s", "tst:db"}
+	s.assertOfferErrorOutput(c, "--share-expiry must be positive")
+}
+
 func (s *offerSuite) assertOfferOutput(c *gc.C, expectedModel, expectedOffer, expectedApplication string, endpoints []string) {
 	_, err := s.runOffer(c, s.args...)
 	c.Assert(err, jc.ErrorIsNil)
@@ -119,6 +143,8 @@ type mockOfferAPI struct {
 	offers           map[string][]string
 	applications     map[string]string
 	descs            map[string]string
+	shareURL         string
+	shareExpiry      time.Duration
 }
 
 func newMockOfferAPI() *mockOfferAPI {
@@ -151,3 +177,13 @@ func (s *mockOfferAPI) Offer(modelUUID, application string, endpoints []string,
 	s.descs[offerName] = desc
 	return result, nil
 }
+
+func (s *mockOfferAPI) CreateShareToken(offerURL string, expiry time.Duration) (params.OfferShareToken, string, error) {
+	s.shareURL = offerURL
+	s.shareExpiry = expiry
+	return params.OfferShareToken{
+		Id:       "token-0",
+		OfferURL: offerURL,
+		Expires:  time.Now().Add(expiry),
+	}, "encoded-token", nil
+}
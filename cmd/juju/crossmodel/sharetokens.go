@@ -0,0 +1,255 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/applicationoffers"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/crossmodel"
+)
+
+// ShareTokensAPI defines the API methods that the share token commands use.
+type ShareTokensAPI interface {
+	Close() error
+	ListShareTokens(offerURL string) ([]params.OfferShareToken, error)
+	RevokeShareToken(offerURL, id string) error
+}
+
+// shareTokenCommandBase holds what is common to the commands which
+// manage the share tokens of an offer.
+type shareTokenCommandBase struct {
+	modelcmd.ControllerCommandBase
+	newAPIFunc func(string) (ShareTokensAPI, error)
+	offerURL   string
+}
+
+// NewApplicationOffersAPI returns an application offers api.
+func (c *shareTokenCommandBase) NewApplicationOffersAPI(controllerName string) (*applicationoffers.Client, error) {
+	root, err := c.CommandBase.NewAPIRoot(c.ClientStore(), controllerName, "")
+	if err != nil {
+		return nil, err
+	}
+	return applicationoffers.NewClient(root), nil
+}
+
+// newAPI returns an api for the controller hosting the offer, after
+// resolving an offer specified by name to a URL in the current model.
+func (c *shareTokenCommandBase) newAPI() (ShareTokensAPI, error) {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	url, err := crossmodel.ParseOfferURL(c.offerURL)
+	if err != nil {
+		currentModel, err := c.ClientStore().CurrentModel(controllerName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		url, err = makeURLFromCurrentModel(c.offerURL, "", currentModel)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if url.Source != "" {
+		controllerName = url.Source
+	}
+	c.offerURL = url.AsLocal().String()
+	return c.newAPIFunc(controllerName)
+}
+
+// NewOfferTokensCommand returns a command used to list the share tokens
+// of an offer.
+func NewOfferTokensCommand() cmd.Command {
+	listCmd := &offerTokensCommand{}
+	listCmd.newAPIFunc = func(controllerName string) (ShareTokensAPI, error) {
+		return listCmd.NewApplicationOffersAPI(controllerName)
+	}
+	return modelcmd.WrapController(listCmd)
+}
+
+type offerTokensCommand struct {
+	shareTokenCommandBase
+	out cmd.Output
+}
+
+const offerTokensDoc = `
+List the share tokens which have been created for an offer using
+"juju offer --share", along with when they expire.
+
+The offer is normally specified by its URL. It's also possible to
+specify just the offer name, in which case the offer is considered
+to reside in the current model.
+
+Examples:
+
+    juju offer-tokens fred/prod.hosted-mysql
+    juju offer-tokens hosted-mysql --format yaml
+
+See also:
+    offer
+    revoke-offer-token
+`
+
+// Info implements Command.Info.
+func (c *offerTokensCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "offer-tokens",
+		Args:    "<offer-url>",
+		Purpose: "Lists the share tokens of an offer.",
+		Doc:     offerTokensDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *offerTokensCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatShareTokensTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *offerTokensCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no offer specified")
+	}
+	c.offerURL = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// shareToken holds the details of a share token for output.
+type shareToken struct {
+	Id        string `yaml:"id" json:"id"`
+	CreatedBy string `yaml:"created-by" json:"created-by"`
+	Created   string `yaml:"created" json:"created"`
+	Expires   string `yaml:"expires" json:"expires"`
+	Expired   bool   `yaml:"expired,omitempty" json:"expired,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *offerTokensCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	tokens, err := api.ListShareTokens(c.offerURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(tokens) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("Offer %q has no share tokens.", c.offerURL)
+		return nil
+	}
+	now := time.Now()
+	result := make([]shareToken, len(tokens))
+	for i, token := range tokens {
+		result[i] = shareToken{
+			Id:        token.Id,
+			CreatedBy: token.CreatedBy,
+			Created:   common.FormatTime(&token.Created, true),
+			Expires:   common.FormatTime(&token.Expires, true),
+			Expired:   !now.Before(token.Expires),
+		}
+	}
+	return c.out.Write(ctx, result)
+}
+
+func formatShareTokensTabular(writer io.Writer, value interface{}) error {
+	tokens, ok := value.([]shareToken)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", tokens, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Token", "Created by", "Created", "Expires")
+	for _, token := range tokens {
+		expires := token.Expires
+		if token.Expired {
+			expires += " (expired)"
+		}
+		w.Println(token.Id, token.CreatedBy, token.Created, expires)
+	}
+	tw.Flush()
+	return nil
+}
+
+// NewRevokeOfferTokenCommand returns a command used to revoke a share
+// token of an offer.
+func NewRevokeOfferTokenCommand() cmd.Command {
+	revokeCmd := &revokeOfferTokenCommand{}
+	revokeCmd.newAPIFunc = func(controllerName string) (ShareTokensAPI, error) {
+		return revokeCmd.NewApplicationOffersAPI(controllerName)
+	}
+	return modelcmd.WrapController(revokeCmd)
+}
+
+type revokeOfferTokenCommand struct {
+	shareTokenCommandBase
+	tokenId string
+}
+
+const revokeOfferTokenDoc = `
+Revoke a share token created for an offer using "juju offer --share".
+Once revoked, the token can no longer be used to consume the offer, and
+relations established using it stop working.
+
+The offer is normally specified by its URL. It's also possible to
+specify just the offer name, in which case the offer is considered
+to reside in the current model.
+
+Examples:
+
+    juju revoke-offer-token fred/prod.hosted-mysql 3f6b54e8-12c5-4e8e-8ad1-0c6a6c0c1b2e
+
+See also:
+    offer
+    offer-tokens
+`
+
+// Info implements Command.Info.
+func (c *revokeOfferTokenCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "revoke-offer-token",
+		Args:    "<offer-url> <token-id>",
+		Purpose: "Revokes a share token of an offer.",
+		Doc:     revokeOfferTokenDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *revokeOfferTokenCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no offer specified")
+	case 1:
+		return errors.New("no share token specified")
+	}
+	c.offerURL, c.tokenId = args[0], args[1]
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements Command.Run.
+func (c *revokeOfferTokenCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	return errors.Trace(api.RevokeShareToken(c.offerURL, c.tokenId))
+}
@@ -0,0 +1,142 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/crossmodel"
+)
+
+type shareTokensSuite struct {
+	BaseCrossModelSuite
+	mockAPI *mockShareTokensAPI
+}
+
+var _ = gc.Suite(&shareTokensSuite{})
+
+func (s *shareTokensSuite) SetUpTest(c *gc.C) {
+	s.BaseCrossModelSuite.SetUpTest(c)
+	s.mockAPI = &mockShareTokensAPI{
+		tokens: []params.OfferShareToken{{
+			Id:        "token-0",
+			OfferURL:  "fred/test.hosted-db2",
+			CreatedBy: "fred",
+			Created:   time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC),
+			Expires:   time.Date(2018, 6, 2, 12, 0, 0, 0, time.UTC),
+		}, {
+			Id:        "token-1",
+			OfferURL:  "fred/test.hosted-db2",
+			CreatedBy: "mary",
+			Created:   time.Date(2018, 6, 1, 13, 0, 0, 0, time.UTC),
+			Expires:   time.Date(2118, 6, 1, 13, 0, 0, 0, time.UTC),
+		}},
+	}
+}
+
+func (s *shareTokensSuite) runOfferTokens(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, crossmodel.NewOfferTokensCommandForTest(s.store, s.mockAPI), args...)
+}
+
+func (s *shareTokensSuite) runRevokeOfferToken(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, crossmodel.NewRevokeOfferTokenCommandForTest(s.store, s.mockAPI), args...)
+}
+
+func (s *shareTokensSuite) TestOfferTokensNoArgs(c *gc.C) {
+	_, err := s.runOfferTokens(c)
+	c.Assert(err, gc.ErrorMatches, "no offer specified")
+}
+
+func (s *shareTokensSuite) TestOfferTokensTabular(c *gc.C) {
+	ctx, err := s.runOfferTokens(c, "fred/test.hosted-db2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.offerURL, gc.Equals, "fred/test.hosted-db2")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Token    Created by  Created               Expires
+token-0  fred        2018-06-01 12:00:00Z  2018-06-02 12:00:00Z (expired)
+token-1  mary        2018-06-01 13:00:00Z  2118-06-01 13:00:00Z
+`[1:])
+}
+
+func (s *shareTokensSuite) TestOfferTokensYAML(c *gc.C) {
+	ctx, err := s.runOfferTokens(c, "hosted-db2", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.offerURL, gc.Equals, "fred/test.hosted-db2")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- id: token-0
+  created-by: fred
+  created: 2018-06-01 12:00:00Z
+  expires: 2018-06-02 12:00:00Z
+  expired: true
+- id: token-1
+  created-by: mary
+  created: 2018-06-01 13:00:00Z
+  expires: 2118-06-01 13:00:00Z
+`[1:])
+}
+
+func (s *shareTokensSuite) TestOfferTokensNone(c *gc.C) {
+	s.mockAPI.tokens = nil
+	ctx, err := s.runOfferTokens(c, "fred/test.hosted-db2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Offer \"fred/test.hosted-db2\" has no share tokens.\n")
+}
+
+func (s *shareTokensSuite) TestOfferTokensAPIError(c *gc.C) {
+	s.mockAPI.err = errors.New("fail")
+	_, err := s.runOfferTokens(c, "fred/test.hosted-db2")
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+func (s *shareTokensSuite) TestRevokeOfferTokenArgs(c *gc.C) {
+	_, err := s.runRevokeOfferToken(c)
+	c.Assert(err, gc.ErrorMatches, "no offer specified")
+	_, err = s.runRevokeOfferToken(c, "fred/test.hosted-db2")
+	c.Assert(err, gc.ErrorMatches, "no share token specified")
+	_, err = s.runRevokeOfferToken(c, "fred/test.hosted-db2", "token-0", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *shareTokensSuite) TestRevokeOfferToken(c *gc.C) {
+	_, err := s.runRevokeOfferToken(c, "hosted-db2", "token-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.offerURL, gc.Equals, "fred/test.hosted-db2")
+	c.Assert(s.mockAPI.revoked, gc.Equals, "token-0")
+}
+
+func (s *shareTokensSuite) TestRevokeOfferTokenAPIError(c *gc.C) {
+	s.mockAPI.err = errors.New("fail")
+	_, err := s.runRevokeOfferToken(c, "fred/test.hosted-db2", "token-0")
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+type mockShareTokensAPI struct {
+	tokens   []params.OfferShareToken
+	offerURL string
+	revoked  string
+	err      error
+}
+
+func (s *mockShareTokensAPI) Close() error {
+	return nil
+}
+
+func (s *mockShareTokensAPI) ListShareTokens(offerURL string) ([]params.OfferShareToken, error) {
+	s.offerURL = offerURL
+	return s.tokens, s.err
+}
+
+func (s *mockShareTokensAPI) RevokeShareToken(offerURL, id string) error {
+	s.offerURL = offerURL
+	s.revoked = id
+	return s.err
+}
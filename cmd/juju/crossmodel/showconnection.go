@@ -0,0 +1,238 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// ShowConnectionAPI defines the API methods that the show offer
+// connection command uses.
+type ShowConnectionAPI interface {
+	Close() error
+	OfferConnectionsInfo(entity names.Tag) (*params.OfferConnectionsInfo, error)
+}
+
+// NewShowOfferConnectionCommand returns a command used to show the
+// health of the cross-model relations of an offer or a SAAS application.
+func NewShowOfferConnectionCommand() cmd.Command {
+	showCmd := &showOfferConnectionCommand{}
+	showCmd.newAPIFunc = func() (ShowConnectionAPI, error) {
+		root, err := showCmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(showCmd)
+}
+
+type showOfferConnectionCommand struct {
+	modelcmd.ModelCommandBase
+	out        cmd.Output
+	newAPIFunc func() (ShowConnectionAPI, error)
+	name       string
+}
+
+const showOfferConnectionDoc = `
+Show the cross-model relations of a SAAS application consuming an offer,
+or of an offer hosted in the current model, along with how well relation
+settings are being exchanged with the other side of each relation.
+
+For each relation, the time settings were last exchanged successfully
+is shown, as well as the most recent error exchanging settings and
+whether the controller on the other side of the relation could be
+reached. A relation for which no settings have been exchanged yet has
+no exchange details.
+
+The name is first looked up as a SAAS application in the current model;
+if there is no such SAAS application, it is treated as the name of an
+offer hosted in the current model.
+
+Examples:
+
+    juju show-offer-connection mysql
+    juju show-offer-connection hosted-mysql --format yaml
+
+See also:
+    consume
+    offers
+    remove-saas
+`
+
+// Info implements Command.Info.
+func (c *showOfferConnectionCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-offer-connection",
+		Args:    "<saas-or-offer-name>",
+		Purpose: "Shows the health of the cross-model relations of a SAAS application or offer.",
+		Doc:     showOfferConnectionDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showOfferConnectionCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatOfferConnectionTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *showOfferConnectionCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no SAAS application or offer specified")
+	}
+	c.name = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// offerConnections holds the cross-model relations of a SAAS application
+// or offer for output.
+type offerConnections struct {
+	Name        string            `yaml:"name" json:"name"`
+	OfferURL    string            `yaml:"offer-url,omitempty" json:"offer-url,omitempty"`
+	Consumer    bool              `yaml:"-" json:"-"`
+	Connections []offerConnection `yaml:"connections" json:"connections"`
+}
+
+// offerConnection holds the details of a cross-model relation for output.
+type offerConnection struct {
+	RelationId          int    `yaml:"relation-id" json:"relation-id"`
+	Endpoints           string `yaml:"endpoints" json:"endpoints"`
+	Status              string `yaml:"status" json:"status"`
+	Message             string `yaml:"message,omitempty" json:"message,omitempty"`
+	SourceModel         string `yaml:"source-model-uuid,omitempty" json:"source-model-uuid,omitempty"`
+	Username            string `yaml:"username,omitempty" json:"username,omitempty"`
+	ControllerReachable *bool  `yaml:"controller-reachable,omitempty" json:"controller-reachable,omitempty"`
+	LastExchange        string `yaml:"last-exchange,omitempty" json:"last-exchange,omitempty"`
+	LastError           string `yaml:"last-error,omitempty" json:"last-error,omitempty"`
+	LastErrorTime       string `yaml:"last-error-time,omitempty" json:"last-error-time,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *showOfferConnectionCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	info, err := c.offerConnectionsInfo(api)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	result := offerConnections{
+		Name:        info.Name,
+		OfferURL:    info.OfferURL,
+		Consumer:    info.Consumer,
+		Connections: make([]offerConnection, len(info.Connections)),
+	}
+	for i, conn := range info.Connections {
+		out := offerConnection{
+			RelationId: conn.RelationId,
+			Endpoints:  conn.Key,
+			Status:     string(conn.Status.Status),
+			Message:    conn.Status.Info,
+			Username:   conn.Username,
+		}
+		if conn.SourceModelTag != "" {
+			modelTag, err := names.ParseModelTag(conn.SourceModelTag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			out.SourceModel = modelTag.Id()
+		}
+		if health := conn.Health; health != nil {
+			reachable := health.ControllerReachable
+			out.ControllerReachable = &reachable
+			out.LastError = health.LastError
+			if health.LastExchange != nil {
+				out.LastExchange = common.FormatTime(health.LastExchange, true)
+			}
+			if health.LastErrorTime != nil {
+				out.LastErrorTime = common.FormatTime(health.LastErrorTime, true)
+			}
+		}
+		result.Connections[i] = out
+	}
+	if len(result.Connections) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("%q has no cross-model relations.", c.name)
+		return nil
+	}
+	return c.out.Write(ctx, result)
+}
+
+// offerConnectionsInfo returns the connections of the named SAAS
+// application, falling back to the offer of that name if there is no
+// such SAAS application.
+func (c *showOfferConnectionCommand) offerConnectionsInfo(api ShowConnectionAPI) (*params.OfferConnectionsInfo, error) {
+	if names.IsValidApplication(c.name) {
+		info, err := api.OfferConnectionsInfo(names.NewApplicationTag(c.name))
+		if err == nil || !params.IsCodeNotFound(err) {
+			return info, err
+		}
+	}
+	info, err := api.OfferConnectionsInfo(names.NewApplicationOfferTag(c.name))
+	if params.IsCodeNotFound(err) {
+		return nil, errors.NotFoundf("SAAS application or offer %q", c.name)
+	}
+	return info, err
+}
+
+func formatOfferConnectionTabular(writer io.Writer, value interface{}) error {
+	conns, ok := value.(offerConnections)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", conns, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	if conns.Consumer {
+		w.Println("SAAS", "Offer URL")
+		w.Println(conns.Name, conns.OfferURL)
+		w.Println()
+		w.Println("Relation", "Endpoints", "Status", "Reachable", "Last exchange", "Last error")
+	} else {
+		w.Println("Offer")
+		w.Println(conns.Name)
+		w.Println()
+		w.Println("Relation", "Endpoints", "Status", "User", "Reachable", "Last exchange", "Last error")
+	}
+	for _, conn := range conns.Connections {
+		reachable, lastExchange, lastError := "-", "-", "-"
+		if conn.ControllerReachable != nil {
+			reachable = "no"
+			if *conn.ControllerReachable {
+				reachable = "yes"
+			}
+		}
+		if conn.LastExchange != "" {
+			lastExchange = conn.LastExchange
+		}
+		if conn.LastError != "" {
+			lastError = fmt.Sprintf("%s (%s)", conn.LastError, conn.LastErrorTime)
+		}
+		if conns.Consumer {
+			w.Println(conn.RelationId, conn.Endpoints, conn.Status, reachable, lastExchange, lastError)
+		} else {
+			w.Println(conn.RelationId, conn.Endpoints, conn.Status, conn.Username, reachable, lastExchange, lastError)
+		}
+	}
+	tw.Flush()
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/crossmodel"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type showOfferConnectionSuite struct {
+	BaseCrossModelSuite
+	mockAPI *mockShowConnectionAPI
+}
+
+var _ = gc.Suite(&showOfferConnectionSuite{})
+
+func (s *showOfferConnectionSuite) SetUpTest(c *gc.C) {
+	s.BaseCrossModelSuite.SetUpTest(c)
+	exchanged := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	failed := time.Date(2018, 6, 1, 12, 5, 0, 0, time.UTC)
+	s.mockAPI = &mockShowConnectionAPI{
+		infos: map[string]*params.OfferConnectionsInfo{
+			"application-hosted-db2": {
+				Name:     "hosted-db2",
+				OfferURL: "bob/prod.hosted-db2",
+				Consumer: true,
+				Connections: []params.OfferConnectionInfo{{
+					RelationId: 1,
+					Key:        "wordpress:db hosted-db2:db",
+					Status:     params.EntityStatus{Status: "joined"},
+					Health: &params.RemoteRelationHealth{
+						LastExchange:  &exchanged,
+						LastError:     "connection refused",
+						LastErrorTime: &failed,
+						Updated:       failed,
+					},
+				}, {
+					RelationId: 2,
+					Key:        "mediawiki:db hosted-db2:db",
+					Status:     params.EntityStatus{Status: "joining"},
+				}},
+			},
+			"applicationoffer-hosted-db": {
+				Name: "hosted-db",
+				Connections: []params.OfferConnectionInfo{{
+					RelationId:     3,
+					Key:            "remote-abc:db mysql:db",
+					SourceModelTag: coretesting.ModelTag.String(),
+					Username:       "fred",
+					Status:         params.EntityStatus{Status: "joined"},
+					Health: &params.RemoteRelationHealth{
+						ControllerReachable: true,
+						LastExchange:        &exchanged,
+						Updated:             exchanged,
+					},
+				}},
+			},
+		},
+	}
+}
+
+func (s *showOfferConnectionSuite) runShowOfferConnection(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, crossmodel.NewShowOfferConnectionCommandForTest(s.store, s.mockAPI), args...)
+}
+
+func (s *showOfferConnectionSuite) TestNoArgs(c *gc.C) {
+	_, err := s.runShowOfferConnection(c)
+	c.Assert(err, gc.ErrorMatches, "no SAAS application or offer specified")
+}
+
+func (s *showOfferConnectionSuite) TestConsumerTabular(c *gc.C) {
+	ctx, err := s.runShowOfferConnection(c, "hosted-db2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.tags, jc.DeepEquals, []string{"application-hosted-db2"})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+SAAS        Offer URL
+hosted-db2  bob/prod.hosted-db2
+
+Relation  Endpoints                   Status   Reachable  Last exchange         Last error
+1         wordpress:db hosted-db2:db  joined   no         2018-06-01 12:00:00Z  connection refused (2018-06-01 12:05:00Z)
+2         mediawiki:db hosted-db2:db  joining  -          -                     -
+`[1:])
+}
+
+func (s *showOfferConnectionSuite) TestOffererTabular(c *gc.C) {
+	ctx, err := s.runShowOfferConnection(c, "hosted-db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.tags, jc.DeepEquals, []string{"application-hosted-db", "applicationoffer-hosted-db"})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Offer
+hosted-db
+
+Relation  Endpoints               Status  User  Reachable  Last exchange         Last error
+3         remote-abc:db mysql:db  joined  fred  yes        2018-06-01 12:00:00Z  -
+`[1:])
+}
+
+func (s *showOfferConnectionSuite) TestOffererYAML(c *gc.C) {
+	ctx, err := s.runShowOfferConnection(c, "hosted-db", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+name: hosted-db
+connections:
+- relation-id: 3
+  endpoints: remote-abc:db mysql:db
+  status: joined
+  source-model-uuid: `+coretesting.ModelTag.Id()+`
+  username: fred
+  controller-reachable: true
+  last-exchange: 2018-06-01 12:00:00Z
+`[1:])
+}
+
+func (s *showOfferConnectionSuite) TestNotFound(c *gc.C) {
+	_, err := s.runShowOfferConnection(c, "unknown")
+	c.Assert(err, gc.ErrorMatches, `SAAS application or offer "unknown" not found`)
+}
+
+func (s *showOfferConnectionSuite) TestNoConnections(c *gc.C) {
+	s.mockAPI.infos["application-hosted-db2"].Connections = nil
+	ctx, err := s.runShowOfferConnection(c, "hosted-db2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "\"hosted-db2\" has no cross-model relations.\n")
+}
+
+func (s *showOfferConnectionSuite) TestAPIError(c *gc.C) {
+	s.mockAPI.err = errors.New("fail")
+	_, err := s.runShowOfferConnection(c, "hosted-db2")
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+type mockShowConnectionAPI struct {
+	infos map[string]*params.OfferConnectionsInfo
+	tags  []string
+	err   error
+}
+
+func (s *mockShowConnectionAPI) Close() error {
+	return nil
+}
+
+func (s *mockShowConnectionAPI) OfferConnectionsInfo(entity names.Tag) (*params.OfferConnectionsInfo, error) {
+	s.tags = append(s.tags, entity.String())
+	if s.err != nil {
+		return nil, s.err
+	}
+	if info, ok := s.infos[entity.String()]; ok {
+		return info, nil
+	}
+	return nil, &params.Error{Code: params.CodeNotFound, Message: entity.Id() + " not found"}
+}
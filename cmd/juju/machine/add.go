@@ -4,9 +4,13 @@
 package machine
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/cmd"
@@ -15,6 +19,7 @@ import (
 	"github.com/juju/utils/winrm"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/annotations"
 	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/api/modelconfig"
 	"github.com/juju/juju/apiserver/params"
@@ -57,6 +62,18 @@ and bringing it under Juju's management; currently this requires that the
 machine be running Ubuntu, that it be accessible via SSH, and be running on
 the same network as the API server.
 
+Many machines can be provisioned manually at once by listing them in an
+inventory file passed with "--inventory". Each line of the file holds a
+single ssh:[user@]host or winrm:[user@]host placement; blank lines and
+lines starting with "#" are ignored. Up to "--parallel" machines are
+provisioned at a time, and the result for each machine is reported once
+all have finished. Since the machines are provisioned non-interactively,
+each must accept the client's SSH key and allow passwordless sudo.
+
+Machines that cannot be reached directly over SSH may be reached through
+a jump host given with "--jump-host". The same jump host is used to
+clean up the machine when it is removed.
+
 It is possible to override or augment constraints by passing provider-specific
 "placement directives" as an argument; these give the provider additional
 information about how to allocate the machine. For example, one can direct the
@@ -71,6 +88,9 @@ Examples:
    juju add-machine --constraints mem=8G (starts a machine with at least 8GB RAM)
    juju add-machine ssh:user@10.10.0.3   (manually provisions machine with ssh)
    juju add-machine winrm:user@10.10.0.3 (manually provisions machine with winrm)
+   juju add-machine --inventory hosts    (manually provisions the machines listed in hosts)
+   juju add-machine ssh:10.10.0.3 --jump-host user@bastion
+                                         (manually provisions machine with ssh through bastion)
    juju add-machine zone=us-east-1a      (start a machine in zone us-east-1a on AWS)
    juju add-machine maas2.name           (acquire machine maas2.name on MAAS)
 
@@ -102,6 +122,7 @@ type addCommand struct {
 	api               AddMachineAPI
 	modelConfigAPI    ModelConfigAPI
 	machineManagerAPI MachineManagerAPI
+	annotationsAPI    AnnotationsAPI
 	// If specified, use this series, else use the model default-series
 	Series string
 	// If specified, these constraints are merged with those already in the model.
@@ -114,8 +135,19 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+	// Inventory is the path of a file listing machines to be manually provisioned.
+	Inventory string
+	// Parallel is the number of machines from the inventory provisioned at once.
+	Parallel int
+	// JumpHost is the [user@]host through which manually provisioned
+	// machines are reached over SSH.
+	JumpHost string
 }
 
+// defaultParallel is the default number of machines from an inventory
+// that are provisioned at once.
+const defaultParallel = 5
+
 func (c *addCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "add-machine",
@@ -131,6 +163,9 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Additional machine constraints")
 	f.Var(disksFlag{&c.Disks}, "disks", "Constraints for disks to attach to the machine")
+	f.StringVar(&c.Inventory, "inventory", "", "Path to a file listing machines to provision manually")
+	f.IntVar(&c.Parallel, "parallel", defaultParallel, "The number of machines in the inventory to provision at once")
+	f.StringVar(&c.JumpHost, "jump-host", "", "The [user@]host to reach manually provisioned machines through over SSH")
 }
 
 func (c *addCommand) Init(args []string) error {
@@ -141,6 +176,9 @@ func (c *addCommand) Init(args []string) error {
 	if err != nil {
 		return err
 	}
+	if c.Inventory != "" {
+		return c.checkInventoryArgs(placement)
+	}
 	c.Placement, err = instance.ParsePlacement(placement)
 	if err == instance.ErrPlacementScopeMissing {
 		placement = "model-uuid" + ":" + placement
@@ -152,6 +190,25 @@ func (c *addCommand) Init(args []string) error {
 	if c.NumMachines > 1 && c.Placement != nil && c.Placement.Directive != "" {
 		return errors.New("cannot use -n when specifying a placement directive")
 	}
+	if c.JumpHost != "" && (c.Placement == nil || c.Placement.Scope != sshScope) {
+		return errors.New("--jump-host can only be used with ssh placements")
+	}
+	return nil
+}
+
+func (c *addCommand) checkInventoryArgs(placement string) error {
+	if placement != "" {
+		return errors.New("cannot specify a placement with --inventory")
+	}
+	if c.NumMachines != 1 {
+		return errors.New("cannot use -n with --inventory")
+	}
+	if len(c.Disks) > 0 {
+		return errors.New("cannot use --disks with --inventory")
+	}
+	if c.Parallel < 1 {
+		return errors.Errorf("--parallel must be at least 1, got %d", c.Parallel)
+	}
 	return nil
 }
 
@@ -169,6 +226,14 @@ type ModelConfigAPI interface {
 	Close() error
 }
 
+// AnnotationsAPI is used to record, and read back, the user that
+// manually provisioned machines were added as.
+type AnnotationsAPI interface {
+	Get(tags []string) ([]params.AnnotationsGetResult, error)
+	Set(annotations map[string]map[string]string) ([]params.ErrorResult, error)
+	Close() error
+}
+
 type MachineManagerAPI interface {
 	AddMachines([]params.AddMachineParams) ([]params.AddMachinesResult, error)
 	BestAPIVersion() int
@@ -211,6 +276,51 @@ func (c *addCommand) NewMachineManagerClient() (*machinemanager.Client, error) {
 	return machinemanager.NewClient(root), nil
 }
 
+func (c *addCommand) getAnnotationsAPI() (AnnotationsAPI, error) {
+	if c.annotationsAPI != nil {
+		return c.annotationsAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return annotations.NewClient(root), nil
+}
+
+// recordManualAccess records the user that the manually provisioned
+// machine with the given id was added as, and the jump host it was
+// reached through, so that remove-machine can connect to it the same
+// way to deprovision it. Nothing is recorded for those not given; the
+// default "ubuntu" user, and a direct connection, are used then.
+func (c *addCommand) recordManualAccess(machineId, user, jumpHost string) error {
+	values := make(map[string]string)
+	if user != "" {
+		values[manual.UserAnnotation] = user
+	}
+	if jumpHost != "" {
+		values[manual.JumpHostAnnotation] = jumpHost
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	client, err := c.getAnnotationsAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	results, err := client.Set(map[string]map[string]string{
+		names.NewMachineTag(machineId).String(): values,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			return result.Error
+		}
+	}
+	return nil
+}
+
 func (c *addCommand) getMachineManagerAPI() (MachineManagerAPI, error) {
 	if c.machineManagerAPI != nil {
 		return c.machineManagerAPI, nil
@@ -260,6 +370,10 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
+	if c.Inventory != "" {
+		return c.provisionInventory(client, config, ctx)
+	}
+
 	if c.Placement != nil {
 		err := c.tryManualProvision(client, config, ctx)
 		if err != errNonManualScope {
@@ -346,43 +460,187 @@ var (
 	winrmScope        = "winrm"
 )
 
-func (c *addCommand) tryManualProvision(client AddMachineAPI, config *config.Config, ctx *cmd.Context) error {
-
-	var provisionMachine manual.ProvisionMachineFunc
-	switch c.Placement.Scope {
+// manualProvisioner returns the function used to manually provision
+// machines with the given placement scope.
+func (c *addCommand) manualProvisioner(scope string) (manual.ProvisionMachineFunc, error) {
+	switch scope {
 	case sshScope:
-		provisionMachine = sshProvisioner
+		return sshProvisioner, nil
 	case winrmScope:
-		provisionMachine = c.provisionWinRM
-	default:
-		return errNonManualScope
-	}
-
-	authKeys, err := common.ReadAuthorizedKeys(ctx, "")
-	if err != nil {
-		return errors.Annotatef(err, "cannot reading authorized-keys")
+		return c.provisionWinRM, nil
 	}
+	return nil, errNonManualScope
+}
 
-	user, host := splitUserHost(c.Placement.Directive)
-	args := manual.ProvisionMachineArgs{
+// manualProvisionArgs returns the arguments for manually provisioning
+// the machine described by the given placement directive.
+func manualProvisionArgs(
+	directive string,
+	client AddMachineAPI,
+	config *config.Config,
+	authKeys string,
+) manual.ProvisionMachineArgs {
+	user, host := splitUserHost(directive)
+	return manual.ProvisionMachineArgs{
 		Host:           host,
 		User:           user,
 		Client:         client,
-		Stdin:          ctx.Stdin,
-		Stdout:         ctx.Stdout,
-		Stderr:         ctx.Stderr,
 		AuthorizedKeys: authKeys,
 		UpdateBehavior: &params.UpdateBehavior{
 			EnableOSRefreshUpdate: config.EnableOSRefreshUpdate(),
 			EnableOSUpgrade:       config.EnableOSUpgrade(),
 		},
 	}
+}
+
+func (c *addCommand) tryManualProvision(client AddMachineAPI, config *config.Config, ctx *cmd.Context) error {
+	provisionMachine, err := c.manualProvisioner(c.Placement.Scope)
+	if err != nil {
+		return err
+	}
+
+	authKeys, err := common.ReadAuthorizedKeys(ctx, "")
+	if err != nil {
+		return errors.Annotatef(err, "cannot reading authorized-keys")
+	}
+
+	args := manualProvisionArgs(c.Placement.Directive, client, config, authKeys)
+	args.JumpHost = c.JumpHost
+	args.Stdin = ctx.Stdin
+	args.Stdout = ctx.Stdout
+	args.Stderr = ctx.Stderr
 
 	machineId, err := provisionMachine(args)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	ctx.Infof("created machine %v", machineId)
+	if err := c.recordManualAccess(machineId, args.User, args.JumpHost); err != nil {
+		ctx.Warningf("cannot record access details for machine %v: %v", machineId, err)
+	}
+	return nil
+}
+
+// readInventory returns the placements listed in the inventory file at
+// the given path, one per line. Blank lines and comments are skipped.
+func readInventory(path string) ([]*instance.Placement, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+
+	var placements []*instance.Placement
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		placement, err := instance.ParsePlacement(line)
+		if err != nil || (placement.Scope != sshScope && placement.Scope != winrmScope) {
+			return nil, errors.Errorf(
+				"%s line %d: expected ssh:[user@]host or winrm:[user@]host, got %q",
+				path, lineNum, line,
+			)
+		}
+		placements = append(placements, placement)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Annotatef(err, "reading %s", path)
+	}
+	if len(placements) == 0 {
+		return nil, errors.Errorf("no machines listed in %s", path)
+	}
+	return placements, nil
+}
+
+// manualProvisionResult records the outcome of manually provisioning
+// a machine from an inventory.
+type manualProvisionResult struct {
+	user      string
+	machineId string
+	output    bytes.Buffer
+	err       error
+}
+
+// provisionInventory manually provisions each of the machines listed in
+// the inventory file, c.Parallel at a time, and reports the outcome for
+// each once they have all finished. The hosts that failed are listed,
+// along with their provisioning output, on stderr, and an error is
+// returned if there were any.
+func (c *addCommand) provisionInventory(client AddMachineAPI, config *config.Config, ctx *cmd.Context) error {
+	placements, err := readInventory(ctx.AbsPath(c.Inventory))
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	authKeys, err := common.ReadAuthorizedKeys(ctx, "")
+	if err != nil {
+		return errors.Annotatef(err, "cannot reading authorized-keys")
+	}
+
+	results := make([]manualProvisionResult, len(placements))
+	sem := make(chan struct{}, c.Parallel)
+	var wg sync.WaitGroup
+	for i, placement := range placements {
+		provisionMachine, err := c.manualProvisioner(placement.Scope)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if c.JumpHost != "" && placement.Scope != sshScope {
+			return errors.Errorf("cannot use --jump-host with %s", placement)
+		}
+		args := manualProvisionArgs(placement.Directive, client, config, authKeys)
+		args.JumpHost = c.JumpHost
+		// Provisioning must not prompt for input, and the output of
+		// each machine is kept apart so that it can be reported if
+		// provisioning fails.
+		result := &results[i]
+		result.user = args.User
+		args.Stdin = strings.NewReader("")
+		args.Stdout = &result.output
+		args.Stderr = &result.output
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			result.machineId, result.err = provisionMachine(args)
+		}()
+	}
+	wg.Wait()
+
+	var failed []string
+	for i := range results {
+		result := &results[i]
+		if result.err != nil {
+			failed = append(failed, placements[i].Directive)
+			continue
+		}
+		ctx.Infof("%s: created machine %v", placements[i], result.machineId)
+		if err := c.recordManualAccess(result.machineId, result.user, c.JumpHost); err != nil {
+			ctx.Warningf("cannot record access details for machine %v: %v", result.machineId, err)
+		}
+	}
+	// Failures are always written to stderr, even with --quiet.
+	for i := range results {
+		result := &results[i]
+		if result.err == nil {
+			continue
+		}
+		fmt.Fprintf(ctx.Stderr, "ERROR %s: %v\n", placements[i], result.err)
+		if output := strings.TrimSpace(result.output.String()); output != "" {
+			for _, line := range strings.Split(output, "\n") {
+				fmt.Fprintf(ctx.Stderr, "    %s\n", line)
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("failed to provision %d of %d machines: %s",
+			len(failed), len(placements), strings.Join(failed, ", "))
+	}
 	return nil
 }
 
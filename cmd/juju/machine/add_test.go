@@ -4,8 +4,11 @@
 package machine_test
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -27,6 +30,7 @@ type AddMachineSuite struct {
 	testing.FakeJujuXDGDataHomeSuite
 	fakeAddMachine     *fakeAddMachineAPI
 	fakeMachineManager *fakeMachineManagerAPI
+	fakeAnnotations    *fakeAnnotationsAPI
 }
 
 var _ = gc.Suite(&AddMachineSuite{})
@@ -35,6 +39,7 @@ func (s *AddMachineSuite) SetUpTest(c *gc.C) {
 	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
 	s.fakeAddMachine = &fakeAddMachineAPI{}
 	s.fakeMachineManager = &fakeMachineManagerAPI{}
+	s.fakeAnnotations = &fakeAnnotationsAPI{}
 }
 
 func (s *AddMachineSuite) TestInit(c *gc.C) {
@@ -71,10 +76,20 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 			args:      []string{"ssh:user@10.10.0.3"},
 			count:     1,
 			placement: "ssh:user@10.10.0.3",
+		}, {
+			args:      []string{"ssh:10.10.0.3", "--jump-host", "user@bastion"},
+			count:     1,
+			placement: "ssh:10.10.0.3",
 		}, {
 			args:      []string{"winrm:user@10.10.0.3"},
 			count:     1,
 			placement: "winrm:user@10.10.0.3",
+		}, {
+			args:        []string{"winrm:user@10.10.0.3", "--jump-host", "bastion"},
+			errorString: "--jump-host can only be used with ssh placements",
+		}, {
+			args:        []string{"--jump-host", "bastion"},
+			errorString: "--jump-host can only be used with ssh placements",
 		}, {
 			args:      []string{"zone=us-east-1a"},
 			count:     1,
@@ -93,7 +108,7 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 		},
 	} {
 		c.Logf("test %d", i)
-		wrappedCommand, addCmd := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager)
+		wrappedCommand, addCmd := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager, s.fakeAnnotations)
 		err := cmdtesting.InitCommand(wrappedCommand, test.args)
 		if test.errorString == "" {
 			c.Check(err, jc.ErrorIsNil)
@@ -112,7 +127,7 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 }
 
 func (s *AddMachineSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
-	add, _ := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager)
+	add, _ := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager, s.fakeAnnotations)
 	return cmdtesting.RunCommand(c, add, args...)
 }
 
@@ -145,6 +160,32 @@ func (s *AddMachineSuite) TestSSHPlacement(c *gc.C) {
 	context, err := s.run(c, "ssh:10.1.2.3")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cmdtesting.Stderr(context), gc.Equals, "created machine 42\n")
+	c.Assert(s.fakeAnnotations.annotations, gc.HasLen, 0)
+}
+
+func (s *AddMachineSuite) TestSSHPlacementJumpHost(c *gc.C) {
+	var jumpHost string
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		jumpHost = args.JumpHost
+		return "42", nil
+	})
+	_, err := s.run(c, "ssh:10.1.2.3", "--jump-host", "jump@bastion")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(jumpHost, gc.Equals, "jump@bastion")
+	c.Assert(s.fakeAnnotations.annotations, jc.DeepEquals, map[string]map[string]string{
+		"machine-42": {"juju-manual-jump-host": "jump@bastion"},
+	})
+}
+
+func (s *AddMachineSuite) TestSSHPlacementRecordsUser(c *gc.C) {
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		return "42", nil
+	})
+	_, err := s.run(c, "ssh:admin@10.1.2.3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAnnotations.annotations, jc.DeepEquals, map[string]map[string]string{
+		"machine-42": {"juju-manual-user": "admin"},
+	})
 }
 
 func (s *AddMachineSuite) TestSSHPlacementError(c *gc.C) {
@@ -156,6 +197,108 @@ func (s *AddMachineSuite) TestSSHPlacementError(c *gc.C) {
 	c.Assert(cmdtesting.Stderr(context), gc.Equals, "")
 }
 
+func (s *AddMachineSuite) writeInventory(c *gc.C, content string) string {
+	path := filepath.Join(c.MkDir(), "inventory")
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *AddMachineSuite) TestInitInventory(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		errorString string
+	}{{
+		args: []string{"--inventory", "hosts"},
+	}, {
+		args: []string{"--inventory", "hosts", "--parallel", "10"},
+	}, {
+		args: []string{"--inventory", "hosts", "--jump-host", "bastion"},
+	}, {
+		args:        []string{"--inventory", "hosts", "ssh:10.1.2.3"},
+		errorString: "cannot specify a placement with --inventory",
+	}, {
+		args:        []string{"--inventory", "hosts", "-n", "2"},
+		errorString: "cannot use -n with --inventory",
+	}, {
+		args:        []string{"--inventory", "hosts", "--disks", "2,1G"},
+		errorString: "cannot use --disks with --inventory",
+	}, {
+		args:        []string{"--inventory", "hosts", "--parallel", "0"},
+		errorString: "--parallel must be at least 1, got 0",
+	}} {
+		c.Logf("test %d", i)
+		wrappedCommand, _ := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager, s.fakeAnnotations)
+		err := cmdtesting.InitCommand(wrappedCommand, test.args)
+		if test.errorString == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errorString)
+		}
+	}
+}
+
+func (s *AddMachineSuite) TestInventory(c *gc.C) {
+	var mu sync.Mutex
+	var hosts []string
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		hosts = append(hosts, args.User+"@"+args.Host)
+		if args.Host == "10.1.2.4" {
+			args.Stderr.Write([]byte("connection refused\n"))
+			return "", errors.New("failed to initialize warp core")
+		}
+		return strings.TrimPrefix(args.Host, "10.1.2."), nil
+	})
+	path := s.writeInventory(c, `
+# web servers
+ssh:ubuntu@10.1.2.3
+ssh:ubuntu@10.1.2.4
+
+ssh:admin@10.1.2.5
+`)
+	context, err := s.run(c, "--inventory", path, "--parallel", "2")
+	c.Assert(err, gc.ErrorMatches, "failed to provision 1 of 3 machines: ubuntu@10.1.2.4")
+	c.Assert(hosts, jc.SameContents, []string{"ubuntu@10.1.2.3", "ubuntu@10.1.2.4", "admin@10.1.2.5"})
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, `
+ssh:ubuntu@10.1.2.3: created machine 3
+ssh:admin@10.1.2.5: created machine 5
+ERROR ssh:ubuntu@10.1.2.4: failed to initialize warp core
+    connection refused
+`[1:])
+	c.Assert(s.fakeAnnotations.annotations, jc.DeepEquals, map[string]map[string]string{
+		"machine-3": {"juju-manual-user": "ubuntu"},
+		"machine-5": {"juju-manual-user": "admin"},
+	})
+}
+
+func (s *AddMachineSuite) TestInventoryFailuresReportedWhenQuiet(c *gc.C) {
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		return "", errors.New("failed to initialize warp core")
+	})
+	path := s.writeInventory(c, "ssh:10.1.2.3\n")
+	context, err := s.run(c, "--quiet", "--inventory", path)
+	c.Assert(err, gc.ErrorMatches, "failed to provision 1 of 1 machines: 10.1.2.3")
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, "ERROR ssh:10.1.2.3: failed to initialize warp core\n")
+}
+
+func (s *AddMachineSuite) TestInventoryInvalidLine(c *gc.C) {
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		c.Fatalf("unexpected provisioning of %s", args.Host)
+		return "", nil
+	})
+	path := s.writeInventory(c, "ssh:10.1.2.3\nlxd:4\n")
+	_, err := s.run(c, "--inventory", path)
+	c.Assert(err, gc.ErrorMatches, `.* line 2: expected ssh:\[user@\]host or winrm:\[user@\]host, got "lxd:4"`)
+}
+
+func (s *AddMachineSuite) TestInventoryEmpty(c *gc.C) {
+	path := s.writeInventory(c, "# nothing here\n")
+	_, err := s.run(c, "--inventory", path)
+	c.Assert(err, gc.ErrorMatches, "no machines listed in .*")
+}
+
 func (s *AddMachineSuite) TestParamsPassedOn(c *gc.C) {
 	_, err := s.run(c, "--constraints", "mem=8G", "--series=special", "zone=nz")
 	c.Assert(err, jc.ErrorIsNil)
@@ -276,6 +419,42 @@ func (f *fakeAddMachineAPI) ModelGet() (map[string]interface{}, error) {
 	}), nil
 }
 
+type fakeAnnotationsAPI struct {
+	annotations map[string]map[string]string
+	err         error
+}
+
+func (f *fakeAnnotationsAPI) Close() error {
+	return nil
+}
+
+func (f *fakeAnnotationsAPI) Get(tags []string) ([]params.AnnotationsGetResult, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	results := make([]params.AnnotationsGetResult, len(tags))
+	for i, tag := range tags {
+		results[i] = params.AnnotationsGetResult{
+			EntityTag:   tag,
+			Annotations: f.annotations[tag],
+		}
+	}
+	return results, nil
+}
+
+func (f *fakeAnnotationsAPI) Set(annotations map[string]map[string]string) ([]params.ErrorResult, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.annotations == nil {
+		f.annotations = make(map[string]map[string]string)
+	}
+	for tag, values := range annotations {
+		f.annotations[tag] = values
+	}
+	return nil, nil
+}
+
 type fakeMachineManagerAPI struct {
 	apiVersion int
 	fakeAddMachineAPI
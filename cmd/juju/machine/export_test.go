@@ -13,7 +13,10 @@ import (
 )
 
 var (
-	SSHProvisioner = &sshProvisioner
+	SSHProvisioner            = &sshProvisioner
+	DeprovisionMachine        = &deprovisionMachine
+	ManualRemovalPollInterval = &manualRemovalPollInterval
+	ManualRemovalTimeout      = &manualRemovalTimeout
 )
 
 type AddCommand struct {
@@ -21,11 +24,12 @@ type AddCommand struct {
 }
 
 // NewAddCommand returns an AddCommand with the api provided as specified.
-func NewAddCommandForTest(api AddMachineAPI, mcAPI ModelConfigAPI, mmAPI MachineManagerAPI) (cmd.Command, *AddCommand) {
+func NewAddCommandForTest(api AddMachineAPI, mcAPI ModelConfigAPI, mmAPI MachineManagerAPI, annAPI AnnotationsAPI) (cmd.Command, *AddCommand) {
 	cmd := &addCommand{
 		api:               api,
 		machineManagerAPI: mmAPI,
 		modelConfigAPI:    mcAPI,
+		annotationsAPI:    annAPI,
 	}
 	cmd.SetClientStore(jujuclienttesting.MinimalStore())
 	return modelcmd.Wrap(cmd), &AddCommand{cmd}
@@ -50,10 +54,14 @@ type RemoveCommand struct {
 }
 
 // NewRemoveCommand returns an RemoveCommand with the api provided as specified.
-func NewRemoveCommandForTest(apiRoot api.Connection, machineAPI RemoveMachineAPI) (cmd.Command, *RemoveCommand) {
+func NewRemoveCommandForTest(
+	apiRoot api.Connection, machineAPI RemoveMachineAPI, statusAPI statusAPI, annAPI AnnotationsAPI,
+) (cmd.Command, *RemoveCommand) {
 	cmd := &removeCommand{
-		apiRoot:    apiRoot,
-		machineAPI: machineAPI,
+		apiRoot:        apiRoot,
+		machineAPI:     machineAPI,
+		statusAPI:      statusAPI,
+		annotationsAPI: annAPI,
 	}
 	cmd.SetClientStore(jujuclienttesting.MinimalStore())
 	return modelcmd.Wrap(cmd), &RemoveCommand{cmd}
@@ -4,16 +4,41 @@
 package machine
 
 import (
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	jujuos "github.com/juju/os"
+	"github.com/juju/os/series"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/annotations"
 	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/environs/manual/sshprovisioner"
+)
+
+// deprovisionMachine removes the Juju agents from a manually
+// provisioned machine. It is a variable so that it can be replaced
+// in tests.
+var deprovisionMachine manual.DeprovisionMachineFunc = sshprovisioner.DeprovisionMachine
+
+var (
+	// manualRemovalPollInterval is how often the model is checked
+	// while waiting for manual machines to be removed.
+	manualRemovalPollInterval = 5 * time.Second
+
+	// manualRemovalTimeout is how long to wait for manual machines
+	// to be removed before giving up on deprovisioning them.
+	manualRemovalTimeout = 10 * time.Minute
 )
 
 // NewRemoveCommand returns a command used to remove a specified machine.
@@ -24,11 +49,15 @@ func NewRemoveCommand() cmd.Command {
 // removeCommand causes an existing machine to be destroyed.
 type removeCommand struct {
 	baseMachinesCommand
-	apiRoot      api.Connection
-	machineAPI   RemoveMachineAPI
-	MachineIds   []string
-	Force        bool
-	KeepInstance bool
+	apiRoot        api.Connection
+	machineAPI     RemoveMachineAPI
+	statusAPI      statusAPI
+	annotationsAPI AnnotationsAPI
+	clock          clock.Clock
+	MachineIds     []string
+	Force          bool
+	KeepInstance   bool
+	NoWait         bool
 }
 
 const destroyMachineDoc = `
@@ -48,12 +77,23 @@ Remove machine number 5 which has no running units or containers:
 Remove machine 6 and any running units or containers:
 
     juju remove-machine 6 --force
-    
+
+A manually provisioned machine has its Juju agents, services and data
+removed over SSH, so that the host may be added to a model again. The
+user, host and jump host given to add-machine are used to connect, or
+the "ubuntu" user if no user was given. Without '--force', the command waits for the
+machine to leave the model before doing this; use '--no-wait' to return
+immediately, leaving Juju on the host to be removed by hand.
+
 Remove machine 7 from the Juju model but do not stop 
 the corresponding cloud instance:
 
     juju remove-machine 7 --keep-instance
 
+Remove manually provisioned machine 8 without waiting to deprovision it:
+
+    juju remove-machine 8 --no-wait
+
 See also:
     add-machine
 `
@@ -73,6 +113,7 @@ func (c *removeCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.Force, "force", false, "Completely remove a machine and all its dependencies")
 	f.BoolVar(&c.KeepInstance, "keep-instance", false, "Do not stop the running cloud instance")
+	f.BoolVar(&c.NoWait, "no-wait", false, "Do not wait to deprovision manually provisioned machines")
 }
 
 func (c *removeCommand) Init(args []string) error {
@@ -131,6 +172,135 @@ func (c *removeCommand) getAPIRoot() (api.Connection, error) {
 	return c.NewAPIRoot()
 }
 
+func (c *removeCommand) getAnnotationsAPI() (AnnotationsAPI, error) {
+	if c.annotationsAPI != nil {
+		return c.annotationsAPI, nil
+	}
+	root, err := c.getAPIRoot()
+	if err != nil {
+		return nil, err
+	}
+	return annotations.NewClient(root), nil
+}
+
+func (c *removeCommand) getStatusAPI() (statusAPI, error) {
+	if c.statusAPI != nil {
+		return c.statusAPI, nil
+	}
+	root, err := c.getAPIRoot()
+	if err != nil {
+		return nil, err
+	}
+	return root.Client(), nil
+}
+
+// manualMachine records the details needed to deprovision a manually
+// provisioned machine.
+type manualMachine struct {
+	user     string
+	host     string
+	jumpHost string
+	series   string
+}
+
+// manualMachines returns the manually provisioned machines among those
+// being removed, keyed on machine id. The agent on such a machine leaves
+// Juju's services and data behind on the host when it is removed, and a
+// forced removal kills it before it can do even that, so we must clean
+// up after it.
+func (c *removeCommand) manualMachines() (map[string]manualMachine, error) {
+	client, err := c.getStatusAPI()
+	if err != nil {
+		return nil, err
+	}
+	status, err := client.Status(c.MachineIds)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting machine status")
+	}
+	machines := make(map[string]manualMachine)
+	for _, id := range c.MachineIds {
+		m, ok := status.Machines[id]
+		if !ok {
+			continue
+		}
+		host := strings.TrimPrefix(string(m.InstanceId), manual.ManualInstancePrefix)
+		if host == string(m.InstanceId) || host == "" {
+			continue
+		}
+		machines[id] = manualMachine{host: host, series: m.Series}
+	}
+	if len(machines) == 0 {
+		return machines, nil
+	}
+
+	annotationsAPI, err := c.getAnnotationsAPI()
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for id := range machines {
+		tags = append(tags, names.NewMachineTag(id).String())
+	}
+	results, err := annotationsAPI.Get(tags)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting machine annotations")
+	}
+	for _, result := range results {
+		if result.Error.Error != nil {
+			return nil, errors.Annotate(result.Error.Error, "getting machine annotations")
+		}
+		tag, err := names.ParseMachineTag(result.EntityTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if m, ok := machines[tag.Id()]; ok {
+			m.user = result.Annotations[manual.UserAnnotation]
+			m.jumpHost = result.Annotations[manual.JumpHostAnnotation]
+			machines[tag.Id()] = m
+		}
+	}
+	return machines, nil
+}
+
+// waitForRemoval waits for the machines with the given ids to be
+// removed from the model, and returns the ids of those which were
+// removed before manualRemovalTimeout expired.
+func (c *removeCommand) waitForRemoval(ids []string) ([]string, error) {
+	client, err := c.getStatusAPI()
+	if err != nil {
+		return nil, err
+	}
+	clk := c.clock
+	if clk == nil {
+		clk = clock.WallClock
+	}
+	timeout := clk.After(manualRemovalTimeout)
+	var removed []string
+	for {
+		status, err := client.Status(ids)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting machine status")
+		}
+		var remaining []string
+		for _, id := range ids {
+			if _, ok := status.Machines[id]; ok {
+				remaining = append(remaining, id)
+			} else {
+				removed = append(removed, id)
+			}
+		}
+		if len(remaining) == 0 {
+			return removed, nil
+		}
+		ids = remaining
+		select {
+		case <-timeout:
+			return removed, nil
+		case <-clk.After(manualRemovalPollInterval):
+		}
+	}
+}
+
 func (c *removeCommand) getRemoveMachineAPI() (RemoveMachineAPI, error) {
 	root, err := c.getAPIRoot()
 	if err != nil {
@@ -156,6 +326,13 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	var manualMachines map[string]manualMachine
+	if !c.KeepInstance {
+		if manualMachines, err = c.manualMachines(); err != nil {
+			return err
+		}
+	}
+
 	var results []params.DestroyMachineResult
 	if c.KeepInstance {
 		results, err = client.DestroyMachinesWithParams(c.Force, c.KeepInstance, c.MachineIds...)
@@ -171,6 +348,7 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 	}
 
 	anyFailed := false
+	var toDeprovision []string
 	for i, id := range c.MachineIds {
 		result := results[i]
 		if result.Error != nil {
@@ -207,6 +385,43 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 			}
 			ctx.Infof("- will detach %s", names.ReadableString(storageTag))
 		}
+		if _, ok := manualMachines[id]; !ok {
+			continue
+		}
+		if c.Force {
+			if err := c.deprovision(ctx, id, manualMachines[id]); err != nil {
+				anyFailed = true
+				ctx.Infof("deprovisioning machine %s failed: %s", id, err)
+			}
+			continue
+		}
+		if c.NoWait {
+			ctx.Infof("- not deprovisioning %s; remove Juju from it manually", manualMachines[id].host)
+			continue
+		}
+		toDeprovision = append(toDeprovision, id)
+	}
+
+	if len(toDeprovision) > 0 {
+		// The agents on the machines must be allowed to finish their
+		// work, and remove themselves, before we clean up after them.
+		ctx.Infof("waiting for machines %s to be removed", strings.Join(toDeprovision, ", "))
+		removed, err := c.waitForRemoval(toDeprovision)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		removedSet := set.NewStrings(removed...)
+		for _, id := range toDeprovision {
+			if !removedSet.Contains(id) {
+				anyFailed = true
+				ctx.Infof("not deprovisioning machine %s: not removed after %v", id, manualRemovalTimeout)
+				continue
+			}
+			if err := c.deprovision(ctx, id, manualMachines[id]); err != nil {
+				anyFailed = true
+				ctx.Infof("deprovisioning machine %s failed: %s", id, err)
+			}
+		}
 	}
 
 	if anyFailed {
@@ -214,3 +429,20 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 	}
 	return nil
 }
+
+// deprovision removes the Juju agents and their data from the manually
+// provisioned machine with the given id.
+func (c *removeCommand) deprovision(ctx *cmd.Context, id string, m manualMachine) error {
+	if os, err := series.GetOSFromSeries(m.series); err == nil && os == jujuos.Windows {
+		ctx.Infof("- not deprovisioning Windows machine %s; remove Juju from it manually", m.host)
+		return nil
+	}
+	ctx.Infof("- deprovisioning %s", m.host)
+	return deprovisionMachine(manual.DeprovisionMachineArgs{
+		User:     m.user,
+		Host:     m.host,
+		JumpHost: m.jumpHost,
+		Series:   m.series,
+		Stderr:   ctx.Stderr,
+	})
+}
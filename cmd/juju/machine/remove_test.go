@@ -4,8 +4,11 @@
 package machine_test
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -13,12 +16,15 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/environs/manual"
 	"github.com/juju/juju/testing"
 )
 
 type RemoveMachineSuite struct {
 	testing.FakeJujuXDGDataHomeSuite
 	fake          *fakeRemoveMachineAPI
+	status        *fakeRemoveStatusAPI
+	annotations   *fakeAnnotationsAPI
 	apiConnection *mockAPIConnection
 }
 
@@ -27,13 +33,32 @@ var _ = gc.Suite(&RemoveMachineSuite{})
 func (s *RemoveMachineSuite) SetUpTest(c *gc.C) {
 	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
 	s.fake = &fakeRemoveMachineAPI{}
+	s.status = &fakeRemoveStatusAPI{
+		result: &params.FullStatus{
+			Machines: map[string]params.MachineStatus{
+				"1": {InstanceId: "i-1", Series: "bionic"},
+				"2": {InstanceId: "manual:10.0.0.2", Series: "bionic"},
+				"3": {InstanceId: "manual:10.0.0.3", Series: "win2012r2"},
+			},
+		},
+	}
+	s.annotations = &fakeAnnotationsAPI{
+		annotations: map[string]map[string]string{
+			"machine-2": {
+				"juju-manual-user":      "admin",
+				"juju-manual-jump-host": "jump@bastion",
+			},
+		},
+	}
 	s.apiConnection = &mockAPIConnection{
 		bestFacadeVersion: 4,
 	}
+	s.PatchValue(machine.ManualRemovalPollInterval, time.Millisecond)
+	s.PatchValue(machine.ManualRemovalTimeout, testing.ShortWait)
 }
 
 func (s *RemoveMachineSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
-	remove, _ := machine.NewRemoveCommandForTest(s.apiConnection, s.fake)
+	remove, _ := machine.NewRemoveCommandForTest(s.apiConnection, s.fake, s.status, s.annotations)
 	return cmdtesting.RunCommand(c, remove, args...)
 }
 
@@ -43,6 +68,7 @@ func (s *RemoveMachineSuite) TestInit(c *gc.C) {
 		machines    []string
 		force       bool
 		keep        bool
+		noWait      bool
 		errorString string
 	}{
 		{
@@ -65,6 +91,10 @@ func (s *RemoveMachineSuite) TestInit(c *gc.C) {
 			args:     []string{"--keep-instance", "1", "2"},
 			machines: []string{"1", "2"},
 			keep:     true,
+		}, {
+			args:     []string{"--no-wait", "1"},
+			machines: []string{"1"},
+			noWait:   true,
 		}, {
 			args:        []string{"lxd"},
 			errorString: `invalid machine id "lxd"`,
@@ -74,12 +104,13 @@ func (s *RemoveMachineSuite) TestInit(c *gc.C) {
 		},
 	} {
 		c.Logf("test %d", i)
-		wrappedCommand, removeCmd := machine.NewRemoveCommandForTest(s.apiConnection, s.fake)
+		wrappedCommand, removeCmd := machine.NewRemoveCommandForTest(s.apiConnection, s.fake, s.status, s.annotations)
 		err := cmdtesting.InitCommand(wrappedCommand, test.args)
 		if test.errorString == "" {
 			c.Check(err, jc.ErrorIsNil)
 			c.Check(removeCmd.Force, gc.Equals, test.force)
 			c.Check(removeCmd.KeepInstance, gc.Equals, test.keep)
+			c.Check(removeCmd.NoWait, gc.Equals, test.noWait)
 			c.Check(removeCmd.MachineIds, jc.DeepEquals, test.machines)
 		} else {
 			c.Check(err, gc.ErrorMatches, test.errorString)
@@ -136,6 +167,137 @@ func (s *RemoveMachineSuite) TestRemoveForce(c *gc.C) {
 	c.Assert(s.fake.machines, jc.DeepEquals, []string{"1", "2/lxd/1"})
 }
 
+func (s *RemoveMachineSuite) TestRemoveForceDeprovisionsManualMachines(c *gc.C) {
+	var deprovisioned []manual.DeprovisionMachineArgs
+	s.PatchValue(machine.DeprovisionMachine, func(args manual.DeprovisionMachineArgs) error {
+		deprovisioned = append(deprovisioned, args)
+		return nil
+	})
+	ctx, err := s.run(c, "--force", "1", "2", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.status.patterns, jc.DeepEquals, []string{"1", "2", "3"})
+	c.Assert(deprovisioned, gc.HasLen, 1)
+	c.Assert(deprovisioned[0].User, gc.Equals, "admin")
+	c.Assert(deprovisioned[0].Host, gc.Equals, "10.0.0.2")
+	c.Assert(deprovisioned[0].JumpHost, gc.Equals, "jump@bastion")
+	c.Assert(deprovisioned[0].Series, gc.Equals, "bionic")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing machine 1
+removing machine 2
+- deprovisioning 10.0.0.2
+removing machine 3
+- not deprovisioning Windows machine 10.0.0.3; remove Juju from it manually
+`[1:])
+}
+
+func (s *RemoveMachineSuite) TestRemoveForceDeprovisionError(c *gc.C) {
+	s.PatchValue(machine.DeprovisionMachine, func(args manual.DeprovisionMachineArgs) error {
+		return errors.New("deprovisioning failed: killing jujud")
+	})
+	ctx, err := s.run(c, "--force", "2")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing machine 2
+- deprovisioning 10.0.0.2
+deprovisioning machine 2 failed: deprovisioning failed: killing jujud
+`[1:])
+}
+
+func (s *RemoveMachineSuite) TestRemoveForceStatusError(c *gc.C) {
+	s.status.err = errors.New("boom")
+	_, err := s.run(c, "--force", "2")
+	c.Assert(err, gc.ErrorMatches, "getting machine status: boom")
+	c.Assert(s.fake.machines, gc.IsNil)
+}
+
+func (s *RemoveMachineSuite) TestRemoveDeprovisionsManualMachinesOnceRemoved(c *gc.C) {
+	var deprovisioned []manual.DeprovisionMachineArgs
+	s.PatchValue(machine.DeprovisionMachine, func(args manual.DeprovisionMachineArgs) error {
+		deprovisioned = append(deprovisioned, args)
+		return nil
+	})
+	s.status.next = []*params.FullStatus{
+		s.status.result,
+		{Machines: map[string]params.MachineStatus{
+			"2": {InstanceId: "manual:10.0.0.2", Series: "bionic"},
+		}},
+		{Machines: map[string]params.MachineStatus{}},
+	}
+	ctx, err := s.run(c, "1", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.forced, jc.IsFalse)
+	c.Assert(s.status.patterns, jc.DeepEquals, []string{"2"})
+	c.Assert(deprovisioned, gc.HasLen, 1)
+	c.Assert(deprovisioned[0].User, gc.Equals, "admin")
+	c.Assert(deprovisioned[0].Host, gc.Equals, "10.0.0.2")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing machine 1
+removing machine 2
+waiting for machines 2 to be removed
+- deprovisioning 10.0.0.2
+`[1:])
+}
+
+func (s *RemoveMachineSuite) TestRemoveDeprovisionsAsUbuntuWithoutRecordedUser(c *gc.C) {
+	var deprovisioned []manual.DeprovisionMachineArgs
+	s.PatchValue(machine.DeprovisionMachine, func(args manual.DeprovisionMachineArgs) error {
+		deprovisioned = append(deprovisioned, args)
+		return nil
+	})
+	s.annotations.annotations = nil
+	_, err := s.run(c, "--force", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deprovisioned, gc.HasLen, 1)
+	c.Assert(deprovisioned[0].User, gc.Equals, "")
+	c.Assert(deprovisioned[0].Host, gc.Equals, "10.0.0.2")
+	c.Assert(deprovisioned[0].JumpHost, gc.Equals, "")
+}
+
+func (s *RemoveMachineSuite) TestRemoveDoesNotDeprovisionMachinesNotRemoved(c *gc.C) {
+	s.PatchValue(machine.DeprovisionMachine, func(args manual.DeprovisionMachineArgs) error {
+		c.Fatalf("unexpected deprovision of %s", args.Host)
+		return nil
+	})
+	ctx, err := s.run(c, "2")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Matches, `
+removing machine 2
+waiting for machines 2 to be removed
+not deprovisioning machine 2: not removed after .*
+`[1:])
+}
+
+func (s *RemoveMachineSuite) TestRemoveNoWaitDoesNotDeprovision(c *gc.C) {
+	s.PatchValue(machine.DeprovisionMachine, func(args manual.DeprovisionMachineArgs) error {
+		c.Fatalf("unexpected deprovision of %s", args.Host)
+		return nil
+	})
+	ctx, err := s.run(c, "--no-wait", "1", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.forced, jc.IsFalse)
+	c.Assert(s.fake.machines, jc.DeepEquals, []string{"1", "2"})
+	// The status is only fetched to find the manual
+	// machines; there is no waiting for their removal.
+	c.Assert(s.status.patterns, jc.DeepEquals, []string{"1", "2"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing machine 1
+removing machine 2
+- not deprovisioning 10.0.0.2; remove Juju from it manually
+`[1:])
+}
+
+func (s *RemoveMachineSuite) TestRemoveKeepDoesNotDeprovision(c *gc.C) {
+	s.PatchValue(machine.DeprovisionMachine, func(args manual.DeprovisionMachineArgs) error {
+		c.Fatalf("unexpected deprovision of %s", args.Host)
+		return nil
+	})
+	_, err := s.run(c, "--keep-instance", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.run(c, "--force", "--keep-instance", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.status.patterns, gc.IsNil)
+}
+
 func (s *RemoveMachineSuite) TestRemoveKeep(c *gc.C) {
 	_, err := s.run(c, "--keep-instance", "1", "2")
 	c.Assert(err, jc.ErrorIsNil)
@@ -164,6 +326,25 @@ func (s *RemoveMachineSuite) TestOldFacadeRemoveKeep(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "this version of Juju doesn't support --keep-instance")
 }
 
+type fakeRemoveStatusAPI struct {
+	result   *params.FullStatus
+	next     []*params.FullStatus
+	err      error
+	patterns []string
+}
+
+func (f *fakeRemoveStatusAPI) Close() error {
+	return nil
+}
+
+func (f *fakeRemoveStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+	f.patterns = patterns
+	if len(f.next) > 0 {
+		f.result, f.next = f.next[0], f.next[1:]
+	}
+	return f.result, f.err
+}
+
 type fakeRemoveMachineAPI struct {
 	forced      bool
 	keep        bool
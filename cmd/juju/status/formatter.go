@@ -220,6 +220,15 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 		Version:          application.WorkloadVersion,
 		EndpointBindings: application.EndpointBindings,
 	}
+	if len(application.ExposedEndpoints) > 0 {
+		out.ExposedEndpoints = make(map[string]exposedEndpointStatus)
+		for endpoint, exposed := range application.ExposedEndpoints {
+			out.ExposedEndpoints[endpoint] = exposedEndpointStatus{
+				ExposeToSpaces: exposed.ExposeToSpaces,
+				ExposeToCIDRs:  exposed.ExposeToCIDRs,
+			}
+		}
+	}
 	for k, m := range application.Units {
 		out.Units[k] = sf.formatUnit(unitFormatInfo{
 			unit:            m,
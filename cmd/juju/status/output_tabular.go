@@ -146,6 +146,11 @@ func printApplications(tw *ansiterm.TabWriter, fs formattedStatus) {
 		notes := ""
 		if app.Exposed {
 			notes = "exposed"
+			// IAAS applications only have an address when they
+			// are exposed through a load balancer.
+			if fs.Model.Type != caasModelType && app.Address != "" {
+				notes += " via " + app.Address
+			}
 		}
 		w.Print(appName, version)
 		w.PrintStatus(app.StatusInfo.Current)
@@ -4769,6 +4769,64 @@ foo/1  maintenance  executing                                  (backup database)
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatTabularExposedWithLoadBalancer(c *gc.C) {
+	status := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"foo": {
+				Exposed: true,
+				Address: "203.0.113.10",
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+Model  Controller  Cloud/Region  Version
+                                 
+
+App  Version  Status  Scale  Charm  Store  Rev  OS  Charm version  Notes
+foo                       0                  0                     exposed via 203.0.113.10
+`[1:])
+}
+
+func (s *StatusSuite) TestFormatExposedEndpoints(c *gc.C) {
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			CloudTag: "cloud-dummy",
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Charm:   "cs:quantal/mysql-1",
+				Series:  "quantal",
+				Exposed: true,
+				ExposedEndpoints: map[string]params.ExposedEndpoint{
+					"":   {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+					"db": {ExposeToSpaces: []string{"internal"}},
+				},
+			},
+		},
+	}
+	formatter := NewStatusFormatter(status, true)
+	formatted, err := formatter.format()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(formatted.Applications["mysql"].ExposedEndpoints, jc.DeepEquals, map[string]exposedEndpointStatus{
+		"":   {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"db": {ExposeToSpaces: []string{"internal"}},
+	})
+
+	out, err := goyaml.Marshal(formatted.Applications["mysql"].ExposedEndpoints)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, `
+"":
+  expose-to-cidrs:
+  - 10.0.0.0/24
+db:
+  expose-to-spaces:
+  - internal
+`[1:])
+}
+
 func (s *StatusSuite) TestFormatTabularHookActionName(c *gc.C) {
 	status := formattedStatus{
 		Applications: map[string]applicationStatus{
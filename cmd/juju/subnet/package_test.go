@@ -202,6 +202,11 @@ func (sa *StubAPI) AddSubnet(cidr names.SubnetTag, id network.Id, spaceTag names
 	return sa.NextErr()
 }
 
+func (sa *StubAPI) SetSubnetRoutes(subnetCIDR names.SubnetTag, routes []network.Route) error {
+	sa.MethodCall(sa, "SetSubnetRoutes", subnetCIDR, routes)
+	return sa.NextErr()
+}
+
 func (sa *StubAPI) RemoveSubnet(subnetCIDR names.SubnetTag) error {
 	sa.MethodCall(sa, "RemoveSubnet", subnetCIDR)
 	return sa.NextErr()
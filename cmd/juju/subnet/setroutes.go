@@ -0,0 +1,151 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package subnet
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/network"
+)
+
+// NewSetRoutesCommand returns a command used to set the static routes
+// of an existing subnet.
+func NewSetRoutesCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&SetRoutesCommand{})
+}
+
+// SetRoutesCommand calls the API to replace the static routes of an
+// existing subnet.
+type SetRoutesCommand struct {
+	SubnetCommandBase
+
+	CIDR   names.SubnetTag
+	Routes []network.Route
+	Clear  bool
+}
+
+const setRoutesCommandDoc = `
+Replaces the static routes of an existing subnet. Machines with an
+address in the subnet configure these routes on the matching device.
+
+Each route is given as <destination-CIDR>,<gateway-IP>[,<metric>]. The
+gateway must be an address within the subnet and the metric defaults
+to 0. Any routes the subnet already has are replaced; use --clear,
+without any routes, to remove them all.
+
+Examples:
+
+    juju set-subnet-routes 10.0.0.0/24 10.1.0.0/16,10.0.0.1
+    juju set-subnet-routes 10.0.0.0/24 10.1.0.0/16,10.0.0.1,10 10.2.0.0/16,10.0.0.254
+    juju set-subnet-routes 10.0.0.0/24 --clear
+
+See also:
+    add-subnet
+    subnets
+`
+
+// Info is defined on the cmd.Command interface.
+func (c *SetRoutesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-subnet-routes",
+		Args:    "<CIDR> [<destination-CIDR>,<gateway-IP>[,<metric>] ...]",
+		Purpose: "Set the static routes of an existing subnet.",
+		Doc:     strings.TrimSpace(setRoutesCommandDoc),
+	}
+}
+
+// SetFlags is defined on the cmd.Command interface.
+func (c *SetRoutesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.SubnetCommandBase.SetFlags(f)
+	f.BoolVar(&c.Clear, "clear", false, "remove all routes from the subnet")
+}
+
+// Init is defined on the cmd.Command interface. It checks the
+// arguments for sanity and sets up the command to run.
+func (c *SetRoutesCommand) Init(args []string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "invalid arguments specified")
+
+	if err := c.CheckNumArgs(args, []error{errNoCIDR}); err != nil {
+		return err
+	}
+	c.CIDR, err = c.ValidateCIDR(args[0], true)
+	if err != nil {
+		return err
+	}
+
+	routes := args[1:]
+	switch {
+	case c.Clear && len(routes) > 0:
+		return errors.New("cannot specify routes with --clear")
+	case !c.Clear && len(routes) == 0:
+		return errors.New("at least one route or --clear is required")
+	}
+	for _, route := range routes {
+		parsed, err := parseRoute(route)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.Routes = append(c.Routes, parsed)
+	}
+	return nil
+}
+
+// parseRoute parses a <destination-CIDR>,<gateway-IP>[,<metric>]
+// argument. The fields are separated by commas, rather than colons,
+// so that IPv6 gateways can be given.
+func parseRoute(given string) (network.Route, error) {
+	fields := strings.Split(given, ",")
+	if len(fields) < 2 || len(fields) > 3 {
+		return network.Route{}, errors.Errorf(
+			"route %q not valid, expected <destination-CIDR>,<gateway-IP>[,<metric>]", given,
+		)
+	}
+	route := network.Route{
+		DestinationCIDR: fields[0],
+		GatewayIP:       fields[1],
+	}
+	if _, _, err := net.ParseCIDR(route.DestinationCIDR); err != nil {
+		return network.Route{}, errors.Errorf("route %q: %q is not a valid CIDR", given, route.DestinationCIDR)
+	}
+	if net.ParseIP(route.GatewayIP) == nil {
+		return network.Route{}, errors.Errorf("route %q: %q is not a valid IP address", given, route.GatewayIP)
+	}
+	if len(fields) == 3 {
+		metric, err := strconv.Atoi(fields[2])
+		if err != nil || metric < 0 {
+			return network.Route{}, errors.Errorf("route %q: %q is not a valid metric", given, fields[2])
+		}
+		route.Metric = metric
+	}
+	return route, nil
+}
+
+// Run implements Command.Run.
+func (c *SetRoutesCommand) Run(ctx *cmd.Context) error {
+	return c.RunWithAPI(ctx, func(api SubnetAPI, ctx *cmd.Context) error {
+		if err := api.SetSubnetRoutes(c.CIDR, c.Routes); err != nil {
+			if params.IsCodeUnauthorized(err) {
+				common.PermissionsMessage(ctx.Stderr, "set subnet routes")
+			}
+			return errors.Annotatef(err, "cannot set routes of subnet %q", c.CIDR.Id())
+		}
+
+		if len(c.Routes) == 0 {
+			ctx.Infof("cleared routes of subnet %q", c.CIDR.Id())
+		} else {
+			ctx.Infof("set %d route(s) on subnet %q", len(c.Routes), c.CIDR.Id())
+		}
+		return nil
+	})
+}
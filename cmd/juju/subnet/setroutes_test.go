@@ -0,0 +1,134 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package subnet_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/subnet"
+	"github.com/juju/juju/network"
+)
+
+type SetRoutesSuite struct {
+	BaseSubnetSuite
+}
+
+var _ = gc.Suite(&SetRoutesSuite{})
+
+func (s *SetRoutesSuite) SetUpTest(c *gc.C) {
+	s.BaseSubnetSuite.SetUpTest(c)
+	s.newCommand = subnet.NewSetRoutesCommand
+}
+
+func (s *SetRoutesSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		about        string
+		args         []string
+		expectCIDR   string
+		expectRoutes []network.Route
+		expectErr    string
+	}{{
+		about:     "no arguments",
+		expectErr: "invalid arguments specified: CIDR is required",
+	}, {
+		about:     "an invalid CIDR",
+		args:      s.Strings("foo", "10.1.0.0/16,10.0.0.1"),
+		expectErr: `invalid arguments specified: "foo" is not a valid CIDR`,
+	}, {
+		about:     "no routes",
+		args:      s.Strings("10.0.0.0/24"),
+		expectErr: "invalid arguments specified: at least one route or --clear is required",
+	}, {
+		about:     "routes with --clear",
+		args:      s.Strings("10.0.0.0/24", "--clear", "10.1.0.0/16,10.0.0.1"),
+		expectErr: "invalid arguments specified: cannot specify routes with --clear",
+	}, {
+		about:     "route without a gateway",
+		args:      s.Strings("10.0.0.0/24", "10.1.0.0/16"),
+		expectErr: `invalid arguments specified: route "10.1.0.0/16" not valid, expected <destination-CIDR>,<gateway-IP>\[,<metric>\]`,
+	}, {
+		about:     "route with an invalid destination",
+		args:      s.Strings("10.0.0.0/24", "10.1.0.0,10.0.0.1"),
+		expectErr: `invalid arguments specified: route "10.1.0.0,10.0.0.1": "10.1.0.0" is not a valid CIDR`,
+	}, {
+		about:     "route with an invalid gateway",
+		args:      s.Strings("10.0.0.0/24", "10.1.0.0/16,gw"),
+		expectErr: `invalid arguments specified: route "10.1.0.0/16,gw": "gw" is not a valid IP address`,
+	}, {
+		about:     "route with an invalid metric",
+		args:      s.Strings("10.0.0.0/24", "10.1.0.0/16,10.0.0.1,-1"),
+		expectErr: `invalid arguments specified: route "10.1.0.0/16,10.0.0.1,-1": "-1" is not a valid metric`,
+	}, {
+		about:      "--clear",
+		args:       s.Strings("10.0.0.0/24", "--clear"),
+		expectCIDR: "10.0.0.0/24",
+	}, {
+		about:      "IPv4 and IPv6 routes",
+		args:       s.Strings("10.0.0.0/24", "10.1.0.0/16,10.0.0.1", "2001:db8:1::/48,2001:db8::1,10"),
+		expectCIDR: "10.0.0.0/24",
+		expectRoutes: []network.Route{{
+			DestinationCIDR: "10.1.0.0/16",
+			GatewayIP:       "10.0.0.1",
+		}, {
+			DestinationCIDR: "2001:db8:1::/48",
+			GatewayIP:       "2001:db8::1",
+			Metric:          10,
+		}},
+	}} {
+		c.Logf("test #%d: %s", i, test.about)
+		command, err := s.InitCommand(c, test.args...)
+		if test.expectErr != "" {
+			c.Check(err, gc.ErrorMatches, test.expectErr)
+		} else {
+			c.Check(err, jc.ErrorIsNil)
+			command := command.(*subnet.SetRoutesCommand)
+			c.Check(command.CIDR.Id(), gc.Equals, test.expectCIDR)
+			c.Check(command.Routes, jc.DeepEquals, test.expectRoutes)
+		}
+
+		// No API calls should be recorded at this stage.
+		s.api.CheckCallNames(c)
+	}
+}
+
+func (s *SetRoutesSuite) TestRunSucceeds(c *gc.C) {
+	s.AssertRunSucceeds(c,
+		`set 1 route\(s\) on subnet "10.0.0.0/24"\n`,
+		"", // empty stdout.
+		"10.0.0.0/24", "10.1.0.0/16,10.0.0.1,5",
+	)
+
+	s.api.CheckCallNames(c, "SetSubnetRoutes", "Close")
+	s.api.CheckCall(c, 0, "SetSubnetRoutes", names.NewSubnetTag("10.0.0.0/24"), []network.Route{{
+		DestinationCIDR: "10.1.0.0/16",
+		GatewayIP:       "10.0.0.1",
+		Metric:          5,
+	}})
+}
+
+func (s *SetRoutesSuite) TestRunWithClearSucceeds(c *gc.C) {
+	s.AssertRunSucceeds(c,
+		`cleared routes of subnet "10.0.0.0/24"\n`,
+		"", // empty stdout.
+		"10.0.0.0/24", "--clear",
+	)
+
+	s.api.CheckCallNames(c, "SetSubnetRoutes", "Close")
+	s.api.CheckCall(c, 0, "SetSubnetRoutes", names.NewSubnetTag("10.0.0.0/24"), []network.Route(nil))
+}
+
+func (s *SetRoutesSuite) TestRunWhenSetSubnetRoutesFails(c *gc.C) {
+	s.api.SetErrors(errors.NotFoundf("subnet \"10.0.0.0/24\""))
+
+	err := s.AssertRunFails(c,
+		`cannot set routes of subnet "10.0.0.0/24": subnet "10.0.0.0/24" not found`,
+		"10.0.0.0/24", "10.1.0.0/16,10.0.0.1",
+	)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+
+	s.api.CheckCallNames(c, "SetSubnetRoutes", "Close")
+}
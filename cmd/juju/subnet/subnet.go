@@ -40,6 +40,10 @@ type SubnetAPI interface {
 	// CreateSubnet creates a new Juju subnet.
 	CreateSubnet(subnetCIDR names.SubnetTag, spaceTag names.SpaceTag, zones []string, isPublic bool) error
 
+	// SetSubnetRoutes replaces the static routes of an existing
+	// subnet.
+	SetSubnetRoutes(subnetCIDR names.SubnetTag, routes []network.Route) error
+
 	// RemoveSubnet marks an existing subnet as no longer used, which
 	// will cause it to get removed at some point after all its
 	// related entites are cleaned up. It will fail if the subnet is
@@ -68,6 +72,10 @@ func (m *mvpAPIShim) ListSubnets(withSpace *names.SpaceTag, withZone string) ([]
 	return m.facade.ListSubnets(withSpace, withZone)
 }
 
+func (m *mvpAPIShim) SetSubnetRoutes(subnetCIDR names.SubnetTag, routes []network.Route) error {
+	return m.facade.SetSubnetRoutes(subnetCIDR, routes)
+}
+
 var logger = loggo.GetLogger("juju.cmd.juju.subnet")
 
 // SubnetCommandBase is the base type embedded into all subnet
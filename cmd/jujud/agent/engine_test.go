@@ -36,8 +36,10 @@ var (
 		"application-scaler",     // tertiary dependency: will be inactive because migration workers will be inactive
 		"charm-revision-updater", // tertiary dependency: will be inactive because migration workers will be inactive
 		"compute-provisioner",
+		"dns-records", // tertiary dependency: will be inactive because migration workers will be inactive
 		"firewaller",
 		"instance-poller",
+		"load-balancer",           // tertiary dependency: will be inactive because migration workers will be inactive
 		"machine-undertaker",      // tertiary dependency: will be inactive because migration workers will be inactive
 		"metric-worker",           // tertiary dependency: will be inactive because migration workers will be inactive
 		"migration-fortress",      // secondary dependency: will be inactive because depends on model-upgrader
@@ -55,6 +57,7 @@ var (
 		"action-pruner",
 		"charm-revision-updater",
 		"compute-provisioner",
+		"dns-records",
 		"environ-tracker",
 		"firewaller",
 		"instance-poller",
@@ -135,12 +138,14 @@ var (
 	notMigratingMachineWorkers = []string{
 		"api-address-updater",
 		"disk-manager",
+		"egress-firewall",
 		"fan-configurer",
 		// "host-key-reporter", not stable, exits when done
 		"log-sender",
 		"logging-config-updater",
 		"machine-action-runner",
 		"machiner",
+		// "network-configurer", uninstalls unless the machine is manual or an LXD container
 		"proxy-config-updater",
 		"reboot-executor",
 		"ssh-authkeys-updater",
@@ -1013,6 +1013,8 @@ func (a *MachineAgent) startModelWorkers(modelUUID string, modelType state.Model
 		InstPollerAggregationDelay:  3 * time.Second,
 		StatusHistoryPrunerInterval: 5 * time.Minute,
 		ActionPrunerInterval:        24 * time.Hour,
+		LoadBalancerPollInterval:    time.Minute,
+		DNSRecordsRetryDelay:        time.Minute,
 		NewEnvironFunc:              newEnvirons,
 		NewContainerBrokerFunc:      newCAASBroker,
 		NewMigrationMaster:          migrationmaster.NewWorker,
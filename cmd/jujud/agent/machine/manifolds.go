@@ -50,6 +50,7 @@ import (
 	"github.com/juju/juju/worker/dblogpruner"
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/diskmanager"
+	"github.com/juju/juju/worker/egressfirewall"
 	"github.com/juju/juju/worker/externalcontrollerupdater"
 	"github.com/juju/juju/worker/fanconfigurer"
 	"github.com/juju/juju/worker/featureflag"
@@ -67,6 +68,7 @@ import (
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationminion"
 	"github.com/juju/juju/worker/modelworkermanager"
+	"github.com/juju/juju/worker/networkconfigurer"
 	"github.com/juju/juju/worker/peergrouper"
 	prworker "github.com/juju/juju/worker/presence"
 	"github.com/juju/juju/worker/proxyupdater"
@@ -677,6 +679,29 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:     hostkeyreporter.NewWorker,
 		})),
 
+		// The network configurer applies the bonds, VLANs and routes
+		// defined for a manual machine or LXD container with netplan.
+		// It uninstalls itself on machines whose network is set up
+		// by the provider.
+		networkConfigurerName: ifNotMigrating(networkconfigurer.Manifold(networkconfigurer.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			MachineLock:   config.MachineLock,
+			Clock:         config.Clock,
+			NewFacade:     networkconfigurer.NewFacade,
+			NewWorker:     networkconfigurer.NewWorker,
+		})),
+
+		// The egress firewall enforces the model's egress-policy with
+		// iptables when the cloud can't restrict the machine's
+		// outbound traffic itself.
+		egressFirewallName: ifNotMigrating(egressfirewall.Manifold(egressfirewall.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			NewFacade:     egressfirewall.NewFacade,
+			NewWorker:     egressfirewall.NewWorker,
+		})),
+
 		externalControllerUpdaterName: ifNotMigrating(ifPrimaryController(externalcontrollerupdater.Manifold(
 			externalcontrollerupdater.ManifoldConfig{
 				APICallerName:                      apiCallerName,
@@ -952,6 +977,8 @@ const (
 	toolsVersionCheckerName       = "tools-version-checker"
 	machineActionName             = "machine-action-runner"
 	hostKeyReporterName           = "host-key-reporter"
+	networkConfigurerName         = "network-configurer"
+	egressFirewallName            = "egress-firewall"
 	fanConfigurerName             = "fan-configurer"
 	externalControllerUpdaterName = "external-controller-updater"
 	globalClockUpdaterName        = "global-clock-updater"
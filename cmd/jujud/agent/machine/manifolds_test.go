@@ -61,6 +61,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"certificate-watcher",
 		"clock",
 		"disk-manager",
+		"egress-firewall",
 		"external-controller-updater",
 		"fan-configurer",
 		"global-clock-updater",
@@ -80,6 +81,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"migration-minion",
 		"migration-inactive-flag",
 		"model-worker-manager",
+		"network-configurer",
 		"peer-grouper",
 		"presence",
 		"proxy-config-updater",
@@ -349,6 +351,17 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-flag",
 		"upgrade-steps-gate"},
 
+	"egress-firewall": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate"},
+
 	"external-controller-updater": {
 		"agent",
 		"api-caller",
@@ -533,6 +546,17 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-flag",
 		"upgrade-steps-gate"},
 
+	"network-configurer": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate"},
+
 	"peer-grouper": {
 		"agent",
 		"clock",
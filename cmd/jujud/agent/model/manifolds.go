@@ -37,12 +37,14 @@ import (
 	"github.com/juju/juju/worker/cleaner"
 	"github.com/juju/juju/worker/common"
 	"github.com/juju/juju/worker/credentialvalidator"
+	"github.com/juju/juju/worker/dnsrecords"
 	"github.com/juju/juju/worker/environ"
 	"github.com/juju/juju/worker/firewaller"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/instancepoller"
 	"github.com/juju/juju/worker/lifeflag"
+	"github.com/juju/juju/worker/loadbalancer"
 	"github.com/juju/juju/worker/logforwarder"
 	"github.com/juju/juju/worker/logforwarder/sinks"
 	"github.com/juju/juju/worker/machineundertaker"
@@ -103,6 +105,16 @@ type ManifoldsConfig struct {
 	// worker is run.
 	ActionPrunerInterval time.Duration
 
+	// LoadBalancerPollInterval controls how often the load balancer
+	// worker brings the cloud's load balancers in line with the
+	// model's exposed applications.
+	LoadBalancerPollInterval time.Duration
+
+	// DNSRecordsRetryDelay controls how long the DNS records worker
+	// waits before retrying after it fails to update the model's DNS
+	// records.
+	DNSRecordsRetryDelay time.Duration
+
 	// NewEnvironFunc is a function opens a provider "environment"
 	// (typically environs.New).
 	NewEnvironFunc environs.NewEnvironFunc
@@ -379,6 +391,20 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			Delay:         config.InstPollerAggregationDelay,
 			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
 		}))),
+		loadBalancerName: ifNotMigrating(ifCredentialValid(loadbalancer.Manifold(loadbalancer.ManifoldConfig{
+			APICallerName:                apiCallerName,
+			EnvironName:                  environTrackerName,
+			ClockName:                    clockName,
+			Interval:                     config.LoadBalancerPollInterval,
+			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
+		}))),
+		dnsRecordsName: ifNotMigrating(ifCredentialValid(dnsrecords.Manifold(dnsrecords.ManifoldConfig{
+			APICallerName:                apiCallerName,
+			EnvironName:                  environTrackerName,
+			ClockName:                    clockName,
+			RetryDelay:                   config.DNSRecordsRetryDelay,
+			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
+		}))),
 		metricWorkerName: ifNotMigrating(metricworker.Manifold(metricworker.ManifoldConfig{
 			APICallerName: apiCallerName,
 		})),
@@ -442,6 +468,7 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 				AgentName:     agentName,
 				APICallerName: apiCallerName,
 				BrokerName:    caasBrokerTrackerName,
+				ClockName:     clockName,
 				NewWorker:     caasoperatorprovisioner.NewProvisionerWorker,
 			},
 		)),
@@ -581,6 +608,8 @@ const (
 	unitAssignerName         = "unit-assigner"
 	applicationScalerName    = "application-scaler"
 	instancePollerName       = "instance-poller"
+	loadBalancerName         = "load-balancer"
+	dnsRecordsName           = "dns-records"
 	charmRevisionUpdaterName = "charm-revision-updater"
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
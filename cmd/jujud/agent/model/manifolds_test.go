@@ -40,10 +40,12 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"charm-revision-updater",
 		"clock",
 		"compute-provisioner",
+		"dns-records",
 		"environ-tracker",
 		"firewaller",
 		"instance-poller",
 		"is-responsible-flag",
+		"load-balancer",
 		"log-forwarder",
 		"machine-undertaker",
 		"metric-worker",
@@ -424,6 +426,20 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"valid-credential-flag",
 	},
 
+	"dns-records": {
+		"agent",
+		"api-caller",
+		"clock",
+		"environ-tracker",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag",
+		"valid-credential-flag",
+	},
+
 	"environ-tracker": {"agent", "api-caller", "clock", "is-responsible-flag"},
 
 	"firewaller": {
@@ -456,6 +472,20 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 
 	"is-responsible-flag": {"agent", "api-caller", "clock"},
 
+	"load-balancer": {
+		"agent",
+		"api-caller",
+		"clock",
+		"environ-tracker",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag",
+		"valid-credential-flag",
+	},
+
 	"log-forwarder": {
 		"agent",
 		"api-caller",
@@ -247,7 +247,7 @@ func (c *BootstrapCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 
-	if err := c.startMongo(addrs, agentConfig); err != nil {
+	if err := c.startMongo(addrs, args.ControllerModelConfig.PreferredIPv6(), agentConfig); err != nil {
 		return errors.Annotate(err, "failed to start mongo")
 	}
 
@@ -343,7 +343,7 @@ func (c *BootstrapCommand) Run(_ *cmd.Context) error {
 	return m.SetHasVote(true)
 }
 
-func (c *BootstrapCommand) startMongo(addrs []network.Address, agentConfig agent.Config) error {
+func (c *BootstrapCommand) startMongo(addrs []network.Address, preferIPv6 bool, agentConfig agent.Config) error {
 	logger.Debugf("starting mongo")
 
 	info, ok := agentConfig.MongoInfo()
@@ -382,7 +382,7 @@ func (c *BootstrapCommand) startMongo(addrs []network.Address, agentConfig agent
 		return err
 	}
 
-	peerAddr := mongo.SelectPeerAddress(addrs)
+	peerAddr := mongo.SelectPeerAddress(addrs, preferIPv6)
 	if peerAddr == "" {
 		return fmt.Errorf("no appropriate peer address found in %q", addrs)
 	}
@@ -0,0 +1,333 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// ImagePolicyAPI defines the API methods that the image policy
+// commands use.
+type ImagePolicyAPI interface {
+	Close() error
+	ListPolicies() ([]params.ImagePolicy, error)
+	SetPolicy(params.ImagePolicy) error
+	RemovePolicy(series, region string) error
+}
+
+// imagePolicyCommandBase is embedded by the image policy commands.
+type imagePolicyCommandBase struct {
+	cloudImageMetadataCommandBase
+
+	newAPIFunc func() (ImagePolicyAPI, error)
+}
+
+func (c *imagePolicyCommandBase) newAPI() (ImagePolicyAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	return c.NewImageMetadataAPI()
+}
+
+func newListImagePoliciesCommand() cmd.Command {
+	return modelcmd.Wrap(&listImagePoliciesCommand{})
+}
+
+const listImagePoliciesDoc = `
+List the policies that choose the images of the model's machines.
+
+A policy applies to machines of one series, in one cloud region or,
+if no region is shown, in all regions without a policy of their own.
+See "juju metadata set-image-policy" for what a policy can do.
+`
+
+// listImagePoliciesCommand lists the model's image policies.
+type listImagePoliciesCommand struct {
+	imagePolicyCommandBase
+
+	out cmd.Output
+}
+
+// Info implements Command.Info.
+func (c *listImagePoliciesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list-image-policies",
+		Purpose: "lists the policies used when choosing an image to start",
+		Doc:     listImagePoliciesDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listImagePoliciesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.imagePolicyCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatImagePoliciesTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listImagePoliciesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *listImagePoliciesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPI()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	policies, err := api.ListPolicies()
+	if err != nil {
+		return err
+	}
+	if len(policies) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No image policies to display.")
+		return nil
+	}
+	info := make([]ImagePolicyInfo, len(policies))
+	for i, policy := range policies {
+		info[i] = ImagePolicyInfo{
+			Series:        policy.Series,
+			Region:        policy.Region,
+			ImageId:       policy.ImageId,
+			Arch:          policy.Arch,
+			Stream:        policy.Stream,
+			RequireSigned: policy.RequireSigned,
+			Prefer:        policy.PreferTags,
+		}
+	}
+	return c.out.Write(ctx, info)
+}
+
+// ImagePolicyInfo defines the serialization behaviour of image
+// policies.
+type ImagePolicyInfo struct {
+	Series        string            `yaml:"series" json:"series"`
+	Region        string            `yaml:"region,omitempty" json:"region,omitempty"`
+	ImageId       string            `yaml:"image-id,omitempty" json:"image-id,omitempty"`
+	Arch          string            `yaml:"arch,omitempty" json:"arch,omitempty"`
+	Stream        string            `yaml:"stream,omitempty" json:"stream,omitempty"`
+	RequireSigned bool              `yaml:"require-signed,omitempty" json:"require-signed,omitempty"`
+	Prefer        map[string]string `yaml:"prefer,omitempty" json:"prefer,omitempty"`
+}
+
+func formatImagePoliciesTabular(writer io.Writer, value interface{}) error {
+	policies, ok := value.([]ImagePolicyInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", policies, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("Series", "Region", "Image id", "Arch", "Stream", "Signed", "Prefer")
+	for _, p := range policies {
+		signed := ""
+		if p.RequireSigned {
+			signed = "required"
+		}
+		var prefer []string
+		for tag, value := range p.Prefer {
+			prefer = append(prefer, tag+"="+value)
+		}
+		sort.Strings(prefer)
+		print(p.Series, p.Region, p.ImageId, p.Arch, p.Stream, signed, strings.Join(prefer, ","))
+	}
+	tw.Flush()
+	return nil
+}
+
+func newSetImagePolicyCommand() cmd.Command {
+	return modelcmd.Wrap(&setImagePolicyCommand{})
+}
+
+const setImagePolicyDoc = `
+Set the policy that chooses the images of the model's machines of a
+series, replacing any policy already set for the series and region.
+
+Without --region, the policy applies in every region that has no
+policy of its own for the series.
+
+A policy may pin the series to a single image with --image-id, which
+also needs --arch. The image is then used without looking up image
+metadata. Otherwise a policy may:
+ - take images from another stream than the model's image-stream,
+   with --stream;
+ - use only images found in signed image metadata, with
+   --require-signed;
+ - prefer images with particular attributes, with --prefer. The
+   attributes are virt-type, root-storage-type and source. When
+   some of the images found have all of the preferred attributes,
+   only those are used.
+
+Examples:
+    juju metadata set-image-policy bionic --region us-east-1 --image-id ami-0ac019f4fcb7cb7e6 --arch amd64
+    juju metadata set-image-policy xenial --stream daily --require-signed
+    juju metadata set-image-policy bionic --prefer virt-type=hvm --prefer root-storage-type=ebs
+
+See also:
+    list-image-policies
+    remove-image-policy
+`
+
+// setImagePolicyCommand sets an image policy for the model.
+type setImagePolicyCommand struct {
+	imagePolicyCommandBase
+
+	Series        string
+	Region        string
+	ImageId       string
+	Arch          string
+	Stream        string
+	RequireSigned bool
+	Prefer        []string
+
+	preferTags map[string]string
+}
+
+// Info implements Command.Info.
+func (c *setImagePolicyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-image-policy",
+		Args:    "<series>",
+		Purpose: "sets a policy used when choosing an image to start",
+		Doc:     setImagePolicyDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setImagePolicyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.imagePolicyCommandBase.SetFlags(f)
+	f.StringVar(&c.Region, "region", "", "cloud region the policy applies to")
+	f.StringVar(&c.ImageId, "image-id", "", "image to always use")
+	f.StringVar(&c.Arch, "arch", "", "architecture of the image to always use")
+	f.StringVar(&c.Stream, "stream", "", "image stream to take images from")
+	f.BoolVar(&c.RequireSigned, "require-signed", false, "use only images from signed image metadata")
+	f.Var(cmd.NewAppendStringsValue(&c.Prefer), "prefer", "preferred image attribute, as <attribute>=<value>")
+}
+
+// Init implements Command.Init.
+func (c *setImagePolicyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("series must be supplied when setting an image policy")
+	}
+	c.Series, args = args[0], args[1:]
+	if err := cmd.CheckEmpty(args); err != nil {
+		return err
+	}
+	if c.ImageId != "" && c.Arch == "" {
+		return errors.New("--arch must be supplied with --image-id")
+	}
+	if c.ImageId != "" && (c.RequireSigned || len(c.Prefer) > 0) {
+		return errors.New("--image-id cannot be combined with --require-signed or --prefer")
+	}
+	if c.ImageId == "" && c.Arch != "" {
+		return errors.New("--arch is only valid with --image-id")
+	}
+	for _, prefer := range c.Prefer {
+		parts := strings.SplitN(prefer, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return errors.Errorf("expected <attribute>=<value>, got %q", prefer)
+		}
+		if c.preferTags == nil {
+			c.preferTags = make(map[string]string)
+		}
+		c.preferTags[parts[0]] = parts[1]
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setImagePolicyCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPI()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	return api.SetPolicy(params.ImagePolicy{
+		Series:        c.Series,
+		Region:        c.Region,
+		ImageId:       c.ImageId,
+		Arch:          c.Arch,
+		Stream:        c.Stream,
+		RequireSigned: c.RequireSigned,
+		PreferTags:    c.preferTags,
+	})
+}
+
+func newRemoveImagePolicyCommand() cmd.Command {
+	return modelcmd.Wrap(&removeImagePolicyCommand{})
+}
+
+const removeImagePolicyDoc = `
+Remove the model's image policy for a series. Without --region, the
+policy that applies to all regions is removed.
+
+Examples:
+    juju metadata remove-image-policy bionic --region us-east-1
+
+See also:
+    list-image-policies
+    set-image-policy
+`
+
+// removeImagePolicyCommand removes an image policy from the model.
+type removeImagePolicyCommand struct {
+	imagePolicyCommandBase
+
+	Series string
+	Region string
+}
+
+// Info implements Command.Info.
+func (c *removeImagePolicyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-image-policy",
+		Args:    "<series>",
+		Purpose: "removes a policy used when choosing an image to start",
+		Doc:     removeImagePolicyDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *removeImagePolicyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.imagePolicyCommandBase.SetFlags(f)
+	f.StringVar(&c.Region, "region", "", "cloud region the policy applies to")
+}
+
+// Init implements Command.Init.
+func (c *removeImagePolicyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("series must be supplied when removing an image policy")
+	}
+	c.Series = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *removeImagePolicyCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPI()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	return api.RemovePolicy(c.Series, c.Region)
+}
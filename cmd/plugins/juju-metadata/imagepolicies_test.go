@@ -0,0 +1,196 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type imagePoliciesSuite struct {
+	BaseCloudImageMetadataSuite
+
+	api *mockImagePolicyAPI
+}
+
+var _ = gc.Suite(&imagePoliciesSuite{})
+
+func (s *imagePoliciesSuite) SetUpTest(c *gc.C) {
+	s.BaseCloudImageMetadataSuite.SetUpTest(c)
+	s.api = &mockImagePolicyAPI{
+		policies: []params.ImagePolicy{{
+			Series:  "bionic",
+			Region:  "us-east-1",
+			ImageId: "ami-1234",
+			Arch:    "amd64",
+		}, {
+			Series:        "xenial",
+			Stream:        "daily",
+			RequireSigned: true,
+			PreferTags:    map[string]string{"virt-type": "hvm", "root-storage-type": "ebs"},
+		}},
+	}
+}
+
+func (s *imagePoliciesSuite) run(c *gc.C, command modelcmd.ModelCommand, args ...string) (*cmd.Context, error) {
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	newAPI := func() (ImagePolicyAPI, error) {
+		return s.api, nil
+	}
+	switch command := command.(type) {
+	case *listImagePoliciesCommand:
+		command.newAPIFunc = newAPI
+	case *setImagePolicyCommand:
+		command.newAPIFunc = newAPI
+	case *removeImagePolicyCommand:
+		command.newAPIFunc = newAPI
+	}
+	return cmdtesting.RunCommand(c, modelcmd.Wrap(command), args...)
+}
+
+func (s *imagePoliciesSuite) TestListTabular(c *gc.C) {
+	ctx, err := s.run(c, &listImagePoliciesCommand{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Series  Region     Image id  Arch   Stream  Signed    Prefer
+bionic  us-east-1  ami-1234  amd64                    
+xenial                              daily   required  root-storage-type=ebs,virt-type=hvm
+`[1:])
+	s.api.CheckCallNames(c, "ListPolicies", "Close")
+}
+
+func (s *imagePoliciesSuite) TestListYAML(c *gc.C) {
+	ctx, err := s.run(c, &listImagePoliciesCommand{}, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- series: bionic
+  region: us-east-1
+  image-id: ami-1234
+  arch: amd64
+- series: xenial
+  stream: daily
+  require-signed: true
+  prefer:
+    root-storage-type: ebs
+    virt-type: hvm
+`[1:])
+}
+
+func (s *imagePoliciesSuite) TestListNone(c *gc.C) {
+	s.api.policies = nil
+	ctx, err := s.run(c, &listImagePoliciesCommand{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No image policies to display.\n")
+}
+
+func (s *imagePoliciesSuite) TestSetPinned(c *gc.C) {
+	_, err := s.run(c, &setImagePolicyCommand{}, "bionic", "--region", "us-east-1", "--image-id", "ami-1234", "--arch", "amd64")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{"SetPolicy", []interface{}{params.ImagePolicy{
+			Series:  "bionic",
+			Region:  "us-east-1",
+			ImageId: "ami-1234",
+			Arch:    "amd64",
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *imagePoliciesSuite) TestSetPreferences(c *gc.C) {
+	_, err := s.run(c, &setImagePolicyCommand{}, "xenial", "--stream", "daily", "--require-signed", "--prefer", "virt-type=hvm", "--prefer", "source=custom")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "SetPolicy", params.ImagePolicy{
+		Series:        "xenial",
+		Stream:        "daily",
+		RequireSigned: true,
+		PreferTags:    map[string]string{"virt-type": "hvm", "source": "custom"},
+	})
+}
+
+func (s *imagePoliciesSuite) TestSetError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c, &setImagePolicyCommand{}, "xenial", "--stream", "daily")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.api.CheckCallNames(c, "SetPolicy", "Close")
+}
+
+func (s *imagePoliciesSuite) TestSetInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "series must be supplied when setting an image policy",
+	}, {
+		args: []string{"bionic", "xenial"},
+		err:  `unrecognized args: \["xenial"\]`,
+	}, {
+		args: []string{"bionic", "--image-id", "ami-1234"},
+		err:  "--arch must be supplied with --image-id",
+	}, {
+		args: []string{"bionic", "--image-id", "ami-1234", "--arch", "amd64", "--require-signed"},
+		err:  "--image-id cannot be combined with --require-signed or --prefer",
+	}, {
+		args: []string{"bionic", "--arch", "amd64"},
+		err:  "--arch is only valid with --image-id",
+	}, {
+		args: []string{"bionic", "--prefer", "virt-type"},
+		err:  `expected <attribute>=<value>, got "virt-type"`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.run(c, &setImagePolicyCommand{}, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *imagePoliciesSuite) TestRemove(c *gc.C) {
+	_, err := s.run(c, &removeImagePolicyCommand{}, "bionic", "--region", "us-east-1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{"RemovePolicy", []interface{}{"bionic", "us-east-1"}},
+		{"Close", nil},
+	})
+}
+
+func (s *imagePoliciesSuite) TestRemoveNoSeries(c *gc.C) {
+	_, err := s.run(c, &removeImagePolicyCommand{})
+	c.Assert(err, gc.ErrorMatches, "series must be supplied when removing an image policy")
+}
+
+type mockImagePolicyAPI struct {
+	gitjujutesting.Stub
+
+	policies []params.ImagePolicy
+}
+
+func (m *mockImagePolicyAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockImagePolicyAPI) ListPolicies() ([]params.ImagePolicy, error) {
+	m.MethodCall(m, "ListPolicies")
+	return m.policies, m.NextErr()
+}
+
+func (m *mockImagePolicyAPI) SetPolicy(policy params.ImagePolicy) error {
+	m.MethodCall(m, "SetPolicy", policy)
+	return m.NextErr()
+}
+
+func (m *mockImagePolicyAPI) RemovePolicy(series, region string) error {
+	m.MethodCall(m, "RemovePolicy", series, region)
+	return m.NextErr()
+}
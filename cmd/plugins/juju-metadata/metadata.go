@@ -58,6 +58,9 @@ func NewSuperCommand() cmd.Command {
 		metadatacmd.Register(newListImagesCommand())
 		metadatacmd.Register(newAddImageMetadataCommand())
 		metadatacmd.Register(newDeleteImageMetadataCommand())
+		metadatacmd.Register(newListImagePoliciesCommand())
+		metadatacmd.Register(newSetImagePolicyCommand())
+		metadatacmd.Register(newRemoveImagePolicyCommand())
 	}
 	return metadatacmd
 }
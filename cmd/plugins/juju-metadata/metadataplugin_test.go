@@ -36,7 +36,10 @@ var metadataCommandNames = []string{
 	"generate-image",
 	"generate-tools",
 	"help",
+	"list-image-policies",
 	"list-images",
+	"remove-image-policy",
+	"set-image-policy",
 	"sign",
 	"validate-agents",
 	"validate-images",
@@ -85,7 +88,10 @@ func (s *MetadataSuite) TestHelpCommands(c *gc.C) {
 
 	// Remove add/list-image for the first test because the feature is not
 	// enabled by default.
-	devFeatures := set.NewStrings("add-image", "list-images", "delete-image")
+	devFeatures := set.NewStrings(
+		"add-image", "list-images", "delete-image",
+		"list-image-policies", "set-image-policy", "remove-image-policy",
+	)
 
 	// Remove features behind dev_flag for the first test since they are not
 	// enabled.
@@ -32,6 +32,11 @@ const (
 	InstanceType = "instance-type"
 	Spaces       = "spaces"
 	VirtType     = "virt-type"
+	Zones        = "zones"
+	Affinity     = "affinity"
+	AntiAffinity = "anti-affinity"
+	Spot         = "spot"
+	MaxPrice     = "max-price"
 )
 
 // Value describes a user's requirements of the hardware on which units
@@ -85,6 +90,31 @@ type Value struct {
 	// VirtType, if not nil or empty, indicates that a machine must run the named
 	// virtual type. Only valid for clouds with multi-hypervisor support.
 	VirtType *string `json:"virt-type,omitempty" yaml:"virt-type,omitempty"`
+
+	// Zones, if not nil, holds a list of availability zones the
+	// machine may (or may not) be started in. Positive and negative
+	// values are accepted, and the difference is the latter have a
+	// "^" prefix to the name.
+	Zones *[]string `json:"zones,omitempty" yaml:"zones,omitempty"`
+
+	// Affinity, if not nil, holds a list of applications whose units
+	// the machine should share availability zones with.
+	Affinity *[]string `json:"affinity,omitempty" yaml:"affinity,omitempty"`
+
+	// AntiAffinity, if not nil, holds a list of applications whose
+	// units the machine should be kept apart from, by starting it in
+	// an availability zone none of their machines are in.
+	AntiAffinity *[]string `json:"anti-affinity,omitempty" yaml:"anti-affinity,omitempty"`
+
+	// Spot, if true, indicates that the machine may be started on a
+	// cheaper spot or preemptible instance, which the cloud can reclaim
+	// at any time. Only valid for clouds which support such instances.
+	Spot *bool `json:"spot,omitempty" yaml:"spot,omitempty"`
+
+	// MaxPrice, if not nil, holds the most that may be paid per hour,
+	// in US dollars, for a spot instance. If nil or empty, the cloud's
+	// on-demand price is used as the limit.
+	MaxPrice *string `json:"max-price,omitempty" yaml:"max-price,omitempty"`
 }
 
 var rawAliases = map[string]string{
@@ -180,6 +210,43 @@ func (v *Value) HasVirtType() bool {
 	return v.VirtType != nil && *v.VirtType != ""
 }
 
+// IncludeZones returns a list of availability zones to start a
+// machine in, if specified.
+func (v *Value) IncludeZones() []string {
+	if v.Zones == nil {
+		return nil
+	}
+	return v.extractItems(*v.Zones, true)
+}
+
+// ExcludeZones returns a list of availability zones not to start a
+// machine in, if specified. They are given in the zones constraint with
+// a "^" prefix to the name, which is stripped before returning.
+func (v *Value) ExcludeZones() []string {
+	if v.Zones == nil {
+		return nil
+	}
+	return v.extractItems(*v.Zones, false)
+}
+
+// HasZones returns whether any zone constraints were specified.
+func (v *Value) HasZones() bool {
+	return v.Zones != nil && len(*v.Zones) > 0
+}
+
+// HasAffinity returns whether any affinity or anti-affinity
+// constraints were specified.
+func (v *Value) HasAffinity() bool {
+	return (v.Affinity != nil && len(*v.Affinity) > 0) ||
+		(v.AntiAffinity != nil && len(*v.AntiAffinity) > 0)
+}
+
+// HasSpot returns true if the constraints.Value requests a spot
+// or preemptible instance.
+func (v *Value) HasSpot() bool {
+	return v.Spot != nil && *v.Spot
+}
+
 // String expresses a constraints.Value in the language in which it was specified.
 func (v Value) String() string {
 	var strs []string
@@ -223,6 +290,24 @@ func (v Value) String() string {
 	if v.VirtType != nil {
 		strs = append(strs, "virt-type="+(*v.VirtType))
 	}
+	if v.Zones != nil {
+		s := strings.Join(*v.Zones, ",")
+		strs = append(strs, "zones="+s)
+	}
+	if v.Affinity != nil {
+		s := strings.Join(*v.Affinity, ",")
+		strs = append(strs, "affinity="+s)
+	}
+	if v.AntiAffinity != nil {
+		s := strings.Join(*v.AntiAffinity, ",")
+		strs = append(strs, "anti-affinity="+s)
+	}
+	if v.Spot != nil {
+		strs = append(strs, "spot="+strconv.FormatBool(*v.Spot))
+	}
+	if v.MaxPrice != nil {
+		strs = append(strs, "max-price="+*v.MaxPrice)
+	}
 	return strings.Join(strs, " ")
 }
 
@@ -264,6 +349,27 @@ func (v Value) GoString() string {
 	if v.VirtType != nil {
 		values = append(values, fmt.Sprintf("VirtType: %q", *v.VirtType))
 	}
+	if v.Zones != nil && *v.Zones != nil {
+		values = append(values, fmt.Sprintf("Zones: %q", *v.Zones))
+	} else if v.Zones != nil {
+		values = append(values, "Zones: (*[]string)(nil)")
+	}
+	if v.Affinity != nil && *v.Affinity != nil {
+		values = append(values, fmt.Sprintf("Affinity: %q", *v.Affinity))
+	} else if v.Affinity != nil {
+		values = append(values, "Affinity: (*[]string)(nil)")
+	}
+	if v.AntiAffinity != nil && *v.AntiAffinity != nil {
+		values = append(values, fmt.Sprintf("AntiAffinity: %q", *v.AntiAffinity))
+	} else if v.AntiAffinity != nil {
+		values = append(values, "AntiAffinity: (*[]string)(nil)")
+	}
+	if v.Spot != nil {
+		values = append(values, fmt.Sprintf("Spot: %v", *v.Spot))
+	}
+	if v.MaxPrice != nil {
+		values = append(values, fmt.Sprintf("MaxPrice: %q", *v.MaxPrice))
+	}
 	return fmt.Sprintf("{%s}", strings.Join(values, ", "))
 }
 
@@ -420,6 +526,16 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setSpaces(str)
 	case VirtType:
 		err = v.setVirtType(str)
+	case Zones:
+		err = v.setZones(str)
+	case Affinity:
+		err = v.setAffinity(str)
+	case AntiAffinity:
+		err = v.setAntiAffinity(str)
+	case Spot:
+		err = v.setSpot(str)
+	case MaxPrice:
+		err = v.setMaxPrice(str)
 	default:
 		return errors.Errorf("unknown constraint %q", name)
 	}
@@ -483,6 +599,35 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			}
 		case VirtType:
 			v.VirtType = &vstr
+		case Zones:
+			v.Zones, err = parseYamlStrings("zones", val)
+		case Affinity:
+			var apps *[]string
+			apps, err = parseYamlStrings("affinity", val)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			err = validateApplications(apps)
+			if err == nil {
+				v.Affinity = apps
+			}
+		case AntiAffinity:
+			var apps *[]string
+			apps, err = parseYamlStrings("anti-affinity", val)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			err = validateApplications(apps)
+			if err == nil {
+				v.AntiAffinity = apps
+			}
+		case Spot:
+			v.Spot, err = parseBool(vstr)
+		case MaxPrice:
+			err = validatePrice(vstr)
+			if err == nil {
+				v.MaxPrice = &vstr
+			}
 		default:
 			return errors.Errorf("unknown constraint value: %v", k)
 		}
@@ -607,6 +752,91 @@ func (v *Value) setVirtType(str string) error {
 	return nil
 }
 
+func (v *Value) setZones(str string) error {
+	if v.Zones != nil {
+		return errors.Errorf("already set")
+	}
+	v.Zones = parseCommaDelimited(str)
+	return nil
+}
+
+func (v *Value) setAffinity(str string) error {
+	if v.Affinity != nil {
+		return errors.Errorf("already set")
+	}
+	apps := parseCommaDelimited(str)
+	if err := validateApplications(apps); err != nil {
+		return err
+	}
+	v.Affinity = apps
+	return nil
+}
+
+func (v *Value) setAntiAffinity(str string) error {
+	if v.AntiAffinity != nil {
+		return errors.Errorf("already set")
+	}
+	apps := parseCommaDelimited(str)
+	if err := validateApplications(apps); err != nil {
+		return err
+	}
+	v.AntiAffinity = apps
+	return nil
+}
+
+func (v *Value) setSpot(str string) (err error) {
+	if v.Spot != nil {
+		return errors.Errorf("already set")
+	}
+	v.Spot, err = parseBool(str)
+	return
+}
+
+func (v *Value) setMaxPrice(str string) error {
+	if v.MaxPrice != nil {
+		return errors.Errorf("already set")
+	}
+	if err := validatePrice(str); err != nil {
+		return err
+	}
+	v.MaxPrice = &str
+	return nil
+}
+
+func validatePrice(str string) error {
+	if str == "" {
+		return nil
+	}
+	if val, err := strconv.ParseFloat(str, 64); err != nil || val <= 0 {
+		return errors.Errorf("must be a positive number of US dollars per hour")
+	}
+	return nil
+}
+
+func validateApplications(apps *[]string) error {
+	if apps == nil {
+		return nil
+	}
+	for _, name := range *apps {
+		if !names.IsValidApplication(name) {
+			return errors.Errorf("%q is not a valid application name", name)
+		}
+	}
+	return nil
+}
+
+func parseBool(str string) (*bool, error) {
+	var value bool
+	if str != "" {
+		val, err := strconv.ParseBool(str)
+		if err != nil {
+			return nil, errors.Errorf("must be true or false")
+		}
+		value = val
+	}
+	return &value, nil
+}
+
 func parseUint64(str string) (*uint64, error) {
 	var value uint64
 	if str != "" {
@@ -284,6 +284,79 @@ var parseConstraintsTests = []struct {
 		args:    []string{"spaces="},
 	},
 
+	// zones
+	{
+		summary: "single zone",
+		args:    []string{"zones=az1"},
+	}, {
+		summary: "multiple zones",
+		args:    []string{"zones=az1,az2"},
+	}, {
+		summary: "multiple zones - positive and negative",
+		args:    []string{"zones=az1,^az2"},
+	}, {
+		summary: "no zones",
+		args:    []string{"zones="},
+	},
+
+	// affinity
+	{
+		summary: "affinity with an application",
+		args:    []string{"affinity=mysql"},
+	}, {
+		summary: "anti-affinity with applications",
+		args:    []string{"anti-affinity=mysql,postgresql"},
+	}, {
+		summary: "invalid affinity application",
+		args:    []string{"affinity=My_SQL"},
+		err:     `bad "affinity" constraint: "My_SQL" is not a valid application name`,
+	}, {
+		summary: "invalid anti-affinity application",
+		args:    []string{"anti-affinity=mysql,0"},
+		err:     `bad "anti-affinity" constraint: "0" is not a valid application name`,
+	},
+
+	// spot
+	{
+		summary: "set spot",
+		args:    []string{"spot=true"},
+	}, {
+		summary: "unset spot",
+		args:    []string{"spot=false"},
+	}, {
+		summary: "spot empty",
+		args:    []string{"spot="},
+	}, {
+		summary: "invalid spot",
+		args:    []string{"spot=maybe"},
+		err:     `bad "spot" constraint: must be true or false`,
+	}, {
+		summary: "double set spot",
+		args:    []string{"spot=true spot=true"},
+		err:     `bad "spot" constraint: already set`,
+	},
+
+	// max-price
+	{
+		summary: "set max-price",
+		args:    []string{"spot=true max-price=0.05"},
+	}, {
+		summary: "max-price empty",
+		args:    []string{"max-price="},
+	}, {
+		summary: "invalid max-price",
+		args:    []string{"max-price=cheap"},
+		err:     `bad "max-price" constraint: must be a positive number of US dollars per hour`,
+	}, {
+		summary: "negative max-price",
+		args:    []string{"max-price=-1"},
+		err:     `bad "max-price" constraint: must be a positive number of US dollars per hour`,
+	}, {
+		summary: "double set max-price",
+		args:    []string{"max-price=1 max-price=1"},
+		err:     `bad "max-price" constraint: already set`,
+	},
+
 	// instance type
 	{
 		summary: "set instance type",
@@ -422,6 +495,30 @@ func (s *ConstraintsSuite) TestIncludeExcludeAndHaveSpaces(c *gc.C) {
 	c.Check(con.HaveSpaces(), jc.IsTrue)
 }
 
+func (s *ConstraintsSuite) TestHasZonesAndAffinity(c *gc.C) {
+	con := constraints.MustParse("zones=az1,az2 anti-affinity=mysql")
+	c.Check(*con.Zones, jc.DeepEquals, []string{"az1", "az2"})
+	c.Check(con.HasZones(), jc.IsTrue)
+	c.Check(con.HasAffinity(), jc.IsTrue)
+	con = constraints.MustParse("zones=az1,^az2,^az3")
+	c.Check(con.IncludeZones(), jc.DeepEquals, []string{"az1"})
+	c.Check(con.ExcludeZones(), jc.DeepEquals, []string{"az2", "az3"})
+	con = constraints.MustParse("zones= affinity=")
+	c.Check(con.HasZones(), jc.IsFalse)
+	c.Check(con.HasAffinity(), jc.IsFalse)
+	con = constraints.MustParse("affinity=wordpress")
+	c.Check(con.HasAffinity(), jc.IsTrue)
+}
+
+func (s *ConstraintsSuite) TestHasSpot(c *gc.C) {
+	con := constraints.MustParse("spot=true")
+	c.Check(con.HasSpot(), jc.IsTrue)
+	con = constraints.MustParse("spot=false")
+	c.Check(con.HasSpot(), jc.IsFalse)
+	con = constraints.MustParse("mem=4G")
+	c.Check(con.HasSpot(), jc.IsFalse)
+}
+
 func (s *ConstraintsSuite) TestInvalidSpaces(c *gc.C) {
 	invalidNames := []string{
 		"%$pace", "^foo#2", "+", "tcp:ip",
@@ -473,6 +570,10 @@ func strp(s string) *string {
 	return &s
 }
 
+func boolp(b bool) *bool {
+	return &b
+}
+
 func ctypep(ctype string) *instance.ContainerType {
 	res := instance.ContainerType(ctype)
 	return &res
@@ -508,6 +609,14 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Spaces1", constraints.Value{Spaces: nil}},
 	{"Spaces2", constraints.Value{Spaces: &[]string{}}},
 	{"Spaces3", constraints.Value{Spaces: &[]string{"space1", "^space2"}}},
+	{"Zones1", constraints.Value{Zones: nil}},
+	{"Zones2", constraints.Value{Zones: &[]string{}}},
+	{"Zones3", constraints.Value{Zones: &[]string{"az1", "az2"}}},
+	{"Affinity1", constraints.Value{Affinity: &[]string{"mysql"}}},
+	{"AntiAffinity1", constraints.Value{AntiAffinity: &[]string{"mysql", "wordpress"}}},
+	{"Spot1", constraints.Value{Spot: boolp(true)}},
+	{"Spot2", constraints.Value{Spot: boolp(false)}},
+	{"MaxPrice1", constraints.Value{MaxPrice: strp("0.05")}},
 	{"InstanceType1", constraints.Value{InstanceType: strp("")}},
 	{"InstanceType2", constraints.Value{InstanceType: strp("foo")}},
 	{"All", constraints.Value{
@@ -520,6 +629,11 @@ var constraintsRoundtripTests = []roundTrip{
 		Tags:         &[]string{"foo", "bar"},
 		Spaces:       &[]string{"space1", "^space2"},
 		InstanceType: strp("foo"),
+		Zones:        &[]string{"az1", "az2"},
+		Affinity:     &[]string{"wordpress"},
+		AntiAffinity: &[]string{"mysql"},
+		Spot:         boolp(true),
+		MaxPrice:     strp("0.05"),
 	}},
 }
 
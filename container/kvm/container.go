@@ -152,3 +152,8 @@ func (i interfaceInfo) InterfaceName() string {
 func (i interfaceInfo) ParentInterfaceName() string {
 	return i.config.ParentInterfaceName
 }
+
+// NICType returns the embedded NICType value.
+func (i interfaceInfo) NICType() string {
+	return string(i.config.NICType)
+}
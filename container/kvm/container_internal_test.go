@@ -19,8 +19,9 @@ var _ = gc.Suite(&containerInternalSuite{})
 
 func (containerInternalSuite) TestInterfaceInfo(c *gc.C) {
 	i := interfaceInfo{config: network.InterfaceInfo{
-		MACAddress: "mac", ParentInterfaceName: "piname", InterfaceName: "iname"}}
+		MACAddress: "mac", ParentInterfaceName: "piname", InterfaceName: "iname", NICType: network.MACVLANNIC}}
 	c.Check(i.InterfaceName(), gc.Equals, "iname")
 	c.Check(i.ParentInterfaceName(), gc.Equals, "piname")
+	c.Check(i.NICType(), gc.Equals, "macvlan")
 	c.Assert(i.MACAddress(), gc.Equals, "mac")
 }
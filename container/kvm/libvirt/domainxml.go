@@ -32,6 +32,10 @@ type InterfaceInfo interface {
 	ParentInterfaceName() string
 	// InterfaceName returns the interface's device name.
 	InterfaceName() string
+	// NICType returns how the interface is attached to its parent device.
+	// It is empty when the parent is a bridge, or "macvlan" when the
+	// interface is attached directly to the parent with macvtap.
+	NICType() string
 }
 
 type domainParams interface {
@@ -122,11 +126,24 @@ func NewDomain(p domainParams) (Domain, error) {
 		}
 	}
 	for _, iface := range p.NetworkInfo() {
+		var ifaceType string
+		var source InterfaceSource
+		switch iface.NICType() {
+		case "":
+			ifaceType = "bridge"
+			source = InterfaceSource{Bridge: iface.ParentInterfaceName()}
+		case "macvlan":
+			ifaceType = "direct"
+			source = InterfaceSource{Dev: iface.ParentInterfaceName(), Mode: "bridge"}
+		default:
+			return Domain{}, errors.Errorf(
+				"unsupported nic type %q", iface.NICType())
+		}
 		d.Interface = append(d.Interface, Interface{
-			Type:   "bridge",
+			Type:   ifaceType,
 			MAC:    InterfaceMAC{Address: iface.MACAddress()},
 			Model:  Model{Type: "virtio"},
-			Source: InterfaceSource{Bridge: iface.ParentInterfaceName()},
+			Source: source,
 			Guest:  InterfaceGuest{Dev: iface.InterfaceName()},
 		})
 	}
@@ -196,6 +213,7 @@ type Domain struct {
 	XMLName       xml.Name    `xml:"domain"`
 	Type          string      `xml:"type,attr"`
 	Name          string      `xml:"name"`
+	Description   string      `xml:"description,omitempty"`
 	VCPU          uint64      `xml:"vcpu"`
 	CurrentMemory Memory      `xml:"currentMemory"`
 	Memory        Memory      `xml:"memory"`
@@ -344,10 +362,13 @@ type InterfaceMAC struct {
 	Address string `xml:"address,attr"`
 }
 
-// InterfaceSource it the host bridge to the network.
+// InterfaceSource it the host bridge to the network, or the host device
+// for a direct (macvtap) interface.
 // See: Interface
 type InterfaceSource struct {
-	Bridge string `xml:"bridge,attr"`
+	Bridge string `xml:"bridge,attr,omitempty"`
+	Dev    string `xml:"dev,attr,omitempty"`
+	Mode   string `xml:"mode,attr,omitempty"`
 }
 
 // InterfaceGuest is the guests network device.
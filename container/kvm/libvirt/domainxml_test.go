@@ -134,6 +134,36 @@ func (domainXMLSuite) TestNewDomain(c *gc.C) {
 	}
 }
 
+func (domainXMLSuite) TestNewDomainMACVLANInterface(c *gc.C) {
+	ifaces := []InterfaceInfo{
+		dummyInterface{
+			mac:     "00:00:00:00:00:00",
+			parent:  "eth1",
+			name:    "eth0",
+			nicType: "macvlan"}}
+	params := dummyParams{ifaceInfo: ifaces, memory: 1024, cpuCores: 2, hostname: "juju-someid", arch: "amd64"}
+
+	d, err := NewDomain(params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(d.Interface, gc.HasLen, 1)
+	ml, err := xml.MarshalIndent(&d.Interface[0], "", "    ")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(string(ml), gc.Equals, `
+<Interface type="direct">
+    <mac address="00:00:00:00:00:00"></mac>
+    <model type="virtio"></model>
+    <source dev="eth1" mode="bridge"></source>
+    <guest dev="eth0"></guest>
+</Interface>`[1:])
+}
+
+func (domainXMLSuite) TestNewDomainUnsupportedNICType(c *gc.C) {
+	ifaces := []InterfaceInfo{
+		dummyInterface{parent: "eth1", name: "eth0", nicType: "ipvlan"}}
+	_, err := NewDomain(dummyParams{ifaceInfo: ifaces, arch: "amd64"})
+	c.Assert(err, gc.ErrorMatches, `unsupported nic type "ipvlan"`)
+}
+
 func (domainXMLSuite) TestNewDomainError(c *gc.C) {
 	d, err := NewDomain(dummyParams{err: errors.Errorf("boom")})
 	c.Check(d, jc.DeepEquals, Domain{})
@@ -171,9 +201,10 @@ func (d dummyDisk) Driver() string { return d.driver }
 func (d dummyDisk) Source() string { return d.source }
 
 type dummyInterface struct {
-	mac, parent, name string
+	mac, parent, name, nicType string
 }
 
 func (i dummyInterface) InterfaceName() string       { return i.name }
 func (i dummyInterface) MACAddress() string          { return i.mac }
 func (i dummyInterface) ParentInterfaceName() string { return i.parent }
+func (i dummyInterface) NICType() string             { return i.nicType }
@@ -428,6 +428,88 @@ func (s *managerSuite) TestNetworkDevicesFromConfigWithParentDevice(c *gc.C) {
 	c.Check(unknown, gc.HasLen, 0)
 }
 
+func (s *managerSuite) TestNetworkDevicesFromConfigParentDeviceNICTypes(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServer(ctrl)
+
+	interfaces := []network.InterfaceInfo{{
+		ParentInterfaceName: "eth1",
+		InterfaceName:       "eth0",
+		InterfaceType:       "ethernet",
+		NICType:             network.MACVLANNIC,
+		CIDR:                "10.10.0.0/24",
+		MACAddress:          "aa:bb:cc:dd:ee:f0",
+	}, {
+		ParentInterfaceName: "eth2",
+		InterfaceName:       "eth1",
+		InterfaceType:       "ethernet",
+		NICType:             network.SRIOVNIC,
+		CIDR:                "10.20.0.0/24",
+		MACAddress:          "aa:bb:cc:dd:ee:f1",
+	}, {
+		ParentInterfaceName: "bond0",
+		InterfaceName:       "eth2",
+		InterfaceType:       "ethernet",
+		NICType:             network.IPVLANNIC,
+		CIDR:                "10.30.0.0/24",
+		Address:             network.NewAddress("10.30.0.5"),
+		MACAddress:          "aa:bb:cc:dd:ee:f2",
+	}}
+
+	expected := map[string]map[string]string{
+		"eth0": {
+			"hwaddr":  "aa:bb:cc:dd:ee:f0",
+			"name":    "eth0",
+			"nictype": "macvlan",
+			"parent":  "eth1",
+			"type":    "nic",
+		},
+		"eth1": {
+			"hwaddr":  "aa:bb:cc:dd:ee:f1",
+			"name":    "eth1",
+			"nictype": "sriov",
+			"parent":  "eth2",
+			"type":    "nic",
+		},
+		"eth2": {
+			"hwaddr":       "aa:bb:cc:dd:ee:f2",
+			"ipv4.address": "10.30.0.5",
+			"name":         "eth2",
+			"nictype":      "ipvlan",
+			"parent":       "bond0",
+			"type":         "nic",
+		},
+	}
+
+	result, unknown, err := lxd.NetworkDevicesFromConfig(s.makeManager(c, cSvr), &container.NetworkConfig{
+		Interfaces: interfaces,
+	})
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, expected)
+	c.Check(unknown, gc.HasLen, 0)
+}
+
+func (s *managerSuite) TestNetworkDevicesFromConfigIPVLANWithoutAddress(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServer(ctrl)
+
+	interfaces := []network.InterfaceInfo{{
+		ParentInterfaceName: "eth1",
+		InterfaceName:       "eth0",
+		InterfaceType:       "ethernet",
+		NICType:             network.IPVLANNIC,
+		ConfigType:          network.ConfigDHCP,
+	}}
+
+	_, _, err := lxd.NetworkDevicesFromConfig(s.makeManager(c, cSvr), &container.NetworkConfig{
+		Interfaces: interfaces,
+	})
+	c.Assert(err, gc.ErrorMatches, `ipvlan interface "eth0" requires a static address`)
+}
+
 func (s *managerSuite) TestNetworkDevicesFromConfigUnknownCIDR(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
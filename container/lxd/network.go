@@ -21,6 +21,8 @@ const (
 	nic            = "nic"
 	nicTypeBridged = "bridged"
 	nicTypeMACVLAN = "macvlan"
+	nicTypeIPVLAN  = "ipvlan"
+	nicTypeSRIOV   = "sriov"
 )
 
 // device is a type alias for profile devices.
@@ -453,18 +455,44 @@ func DevicesFromInterfaceInfo(interfaces []network.InterfaceInfo) (map[string]de
 		if v.CIDR == "" {
 			unknown = append(unknown, v.ParentInterfaceName)
 		}
-		nics[v.InterfaceName] = newNICDevice(v.InterfaceName, v.ParentInterfaceName, v.MACAddress, v.MTU)
+		nicDevice := newNICDevice(v.InterfaceName, v.ParentInterfaceName, v.MACAddress, v.MTU)
+		if err := setNICType(nicDevice, v); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		nics[v.InterfaceName] = nicDevice
 	}
 
 	return nics, unknown, nil
 }
 
-// newNICDevice creates and returns a LXD-compatible config for a network
-// device from the input arguments.
-// TODO (manadart 2018-06-21) We want to support nictype=macvlan too.
-// This will involve interrogating the parent device, via the server if it is
-// LXD managed, or via the container.NetworkConfig.DeviceType that this is
-// being generated from.
+// setNICType updates the nictype of the input device according to how the
+// interface is attached to its parent device on the host.
+// An ipvlan device cannot use DHCP, so its static address is set too.
+func setNICType(nicDevice device, iface network.InterfaceInfo) error {
+	switch iface.NICType {
+	case network.BridgedNIC:
+	case network.MACVLANNIC:
+		nicDevice["nictype"] = nicTypeMACVLAN
+	case network.SRIOVNIC:
+		nicDevice["nictype"] = nicTypeSRIOV
+	case network.IPVLANNIC:
+		if iface.Address.Value == "" {
+			return errors.Errorf("ipvlan interface %q requires a static address", iface.InterfaceName)
+		}
+		nicDevice["nictype"] = nicTypeIPVLAN
+		if iface.Address.Type == network.IPv6Address {
+			nicDevice["ipv6.address"] = iface.Address.Value
+		} else {
+			nicDevice["ipv4.address"] = iface.Address.Value
+		}
+	default:
+		return errors.NotSupportedf("nic type %q", iface.NICType)
+	}
+	return nil
+}
+
+// newNICDevice creates and returns a LXD-compatible config for a bridged
+// network device from the input arguments.
 func newNICDevice(deviceName, parentDevice, hwAddr string, mtu int) device {
 	device := map[string]string{
 		"type":    "nic",
@@ -11,6 +11,10 @@ import (
 // JujuRunActionName defines the action name used by juju-run.
 const JujuRunActionName = "juju-run"
 
+// JujuCheckNetworkActionName defines the action name used by
+// juju check-network to have a unit probe its related units.
+const JujuCheckNetworkActionName = "juju-check-network"
+
 // PredefinedActionsSpec defines a spec for each predefined action.
 var PredefinedActionsSpec = map[string]charm.ActionSpec{
 	JujuRunActionName: {
@@ -32,4 +36,48 @@ var PredefinedActionsSpec = map[string]charm.ActionSpec{
 			},
 		},
 	},
+	JujuCheckNetworkActionName: {
+		Description: "predefined juju-check-network action",
+		Params: map[string]interface{}{
+			"type":        "object",
+			"title":       JujuCheckNetworkActionName,
+			"description": "predefined juju-check-network action params",
+			"required":    []interface{}{"relation", "targets", "timeout"},
+			"properties": map[string]interface{}{
+				"relation": map[string]interface{}{
+					"type":        "string",
+					"description": "key of the relation being checked",
+				},
+				"targets": map[string]interface{}{
+					"type":        "array",
+					"description": "related units to probe",
+					"items": map[string]interface{}{
+						"type":     "object",
+						"required": []interface{}{"unit", "address"},
+						"properties": map[string]interface{}{
+							"unit": map[string]interface{}{
+								"type":        "string",
+								"description": "name of the related unit",
+							},
+							"address": map[string]interface{}{
+								"type":        "string",
+								"description": "ingress address of the related unit",
+							},
+							"ports": map[string]interface{}{
+								"type":        "array",
+								"description": "opened ports of the related unit",
+								"items": map[string]interface{}{
+									"type": "string",
+								},
+							},
+						},
+					},
+				},
+				"timeout": map[string]interface{}{
+					"type":        "number",
+					"description": "timeout for each probe",
+				},
+			},
+		},
+	},
 }
@@ -219,6 +219,10 @@ const (
 	Provisioning      Status = "allocating"
 	Running           Status = "running"
 	ProvisioningError Status = "provisioning error"
+
+	// Interrupted is used to signify that the cloud has reclaimed
+	// a spot or preemptible instance.
+	Interrupted Status = "interrupted"
 )
 
 const (
@@ -236,6 +240,7 @@ func (status Status) KnownInstanceStatus() bool {
 		ProvisioningError,
 		Allocating,
 		Running,
+		Interrupted,
 		Unknown:
 		return true
 	}
@@ -0,0 +1,9 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The dns package contains the tools needed to keep DNS records for
+// exposed applications in juju. The common code sits at the top level.
+// Backends that are not tied to a cloud (e.g. rfc2136) are provided
+// through sub-packages; cloud DNS services are provided by the
+// environs that support them.
+package dns
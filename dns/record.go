@@ -0,0 +1,115 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dns
+
+import (
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/juju/collections/set"
+)
+
+const (
+	// TypeA is the type of records holding IPv4 addresses.
+	TypeA = "A"
+
+	// TypeAAAA is the type of records holding IPv6 addresses.
+	TypeAAAA = "AAAA"
+)
+
+// Record is a DNS record set: all of the records of one type for a
+// single name.
+type Record struct {
+	// Name is the fully qualified name of the records, ending in a
+	// dot.
+	Name string
+
+	// Type is the type of the records, e.g. TypeA.
+	Type string
+
+	// TTL is the time to live of the records, in seconds.
+	TTL int
+
+	// Values holds the data of the records, e.g. IP addresses.
+	Values []string
+}
+
+// Equal reports whether the record sets have the same name, type, TTL
+// and values. The order of the values is not significant.
+func (r Record) Equal(other Record) bool {
+	if Fqdn(r.Name) != Fqdn(other.Name) || r.Type != other.Type || r.TTL != other.TTL {
+		return false
+	}
+	values := set.NewStrings(r.Values...)
+	otherValues := set.NewStrings(other.Values...)
+	return values.Size() == otherValues.Size() && values.Difference(otherValues).IsEmpty()
+}
+
+// Zone is a DNS zone in which record sets can be managed.
+type Zone interface {
+	// Records returns the A and AAAA record sets in the zone whose
+	// names are the fully qualified domain, or are within it.
+	Records(domain string) ([]Record, error)
+
+	// SetRecord creates the record set, replacing any existing record
+	// set with the same name and type.
+	SetRecord(record Record) error
+
+	// RemoveRecord removes the record set with the same name and type
+	// as the record. If there is no such record set, an error
+	// satisfying errors.IsNotFound is returned.
+	RemoveRecord(record Record) error
+}
+
+// Fqdn returns the name in lower case, with the trailing dot that marks
+// it as fully qualified.
+func Fqdn(name string) string {
+	name = strings.ToLower(name)
+	if !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+	return name
+}
+
+// InDomain reports whether the name is the domain, or is within it.
+func InDomain(name, domain string) bool {
+	name, domain = Fqdn(name), Fqdn(domain)
+	return name == domain || strings.HasSuffix(name, "."+domain)
+}
+
+// AddressRecords returns the A and AAAA record sets needed to resolve
+// the name to the addresses. Values that are not IP addresses are
+// ignored, and no record set is returned for a type without addresses.
+func AddressRecords(name string, ttl int, addresses []string) []Record {
+	v4, v6 := set.NewStrings(), set.NewStrings()
+	for _, addr := range addresses {
+		ip := net.ParseIP(addr)
+		switch {
+		case ip == nil:
+		case ip.To4() != nil:
+			v4.Add(ip.String())
+		default:
+			v6.Add(ip.String())
+		}
+	}
+	var records []Record
+	if !v4.IsEmpty() {
+		records = append(records, Record{Fqdn(name), TypeA, ttl, v4.SortedValues()})
+	}
+	if !v6.IsEmpty() {
+		records = append(records, Record{Fqdn(name), TypeAAAA, ttl, v6.SortedValues()})
+	}
+	return records
+}
+
+// SortRecords sorts the record sets by name and then type.
+func SortRecords(records []Record) {
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Name != records[j].Name {
+			return records[i].Name < records[j].Name
+		}
+		return records[i].Type < records[j].Type
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dns_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/dns"
+)
+
+type recordSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&recordSuite{})
+
+func (s *recordSuite) TestFqdn(c *gc.C) {
+	c.Check(dns.Fqdn("Example.com"), gc.Equals, "example.com.")
+	c.Check(dns.Fqdn("example.com."), gc.Equals, "example.com.")
+}
+
+func (s *recordSuite) TestInDomain(c *gc.C) {
+	c.Check(dns.InDomain("a.b.example.com.", "example.com"), jc.IsTrue)
+	c.Check(dns.InDomain("example.com", "example.com."), jc.IsTrue)
+	c.Check(dns.InDomain("badexample.com.", "example.com."), jc.IsFalse)
+	c.Check(dns.InDomain("example.org.", "example.com."), jc.IsFalse)
+}
+
+func (s *recordSuite) TestAddressRecords(c *gc.C) {
+	records := dns.AddressRecords("wordpress.example.com", 60, []string{
+		"10.0.0.2", "2001:db8::1", "10.0.0.1", "10.0.0.2", "not-an-ip",
+	})
+	c.Assert(records, jc.DeepEquals, []dns.Record{{
+		Name:   "wordpress.example.com.",
+		Type:   dns.TypeA,
+		TTL:    60,
+		Values: []string{"10.0.0.1", "10.0.0.2"},
+	}, {
+		Name:   "wordpress.example.com.",
+		Type:   dns.TypeAAAA,
+		TTL:    60,
+		Values: []string{"2001:db8::1"},
+	}})
+}
+
+func (s *recordSuite) TestAddressRecordsNone(c *gc.C) {
+	c.Assert(dns.AddressRecords("wordpress.example.com", 60, nil), gc.HasLen, 0)
+}
+
+func (s *recordSuite) TestEqual(c *gc.C) {
+	record := dns.Record{"a.example.com.", dns.TypeA, 60, []string{"10.0.0.1", "10.0.0.2"}}
+	c.Check(record.Equal(dns.Record{"A.example.com", dns.TypeA, 60, []string{"10.0.0.2", "10.0.0.1"}}), jc.IsTrue)
+	c.Check(record.Equal(dns.Record{"a.example.com.", dns.TypeAAAA, 60, record.Values}), jc.IsFalse)
+	c.Check(record.Equal(dns.Record{"a.example.com.", dns.TypeA, 300, record.Values}), jc.IsFalse)
+	c.Check(record.Equal(dns.Record{"a.example.com.", dns.TypeA, 60, []string{"10.0.0.1"}}), jc.IsFalse)
+}
+
+func (s *recordSuite) TestSortRecords(c *gc.C) {
+	records := []dns.Record{
+		{Name: "b.example.com.", Type: dns.TypeA},
+		{Name: "a.example.com.", Type: dns.TypeAAAA},
+		{Name: "a.example.com.", Type: dns.TypeA},
+	}
+	dns.SortRecords(records)
+	c.Assert(records, jc.DeepEquals, []dns.Record{
+		{Name: "a.example.com.", Type: dns.TypeA},
+		{Name: "a.example.com.", Type: dns.TypeAAAA},
+		{Name: "b.example.com.", Type: dns.TypeA},
+	})
+}
@@ -0,0 +1,250 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rfc2136
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// The subset of DNS (RFC 1035) and dynamic update (RFC 2136) constants
+// that the package needs.
+const (
+	classIN  = 1
+	classANY = 255
+
+	typeA    = 1
+	typeSOA  = 6
+	typeAAAA = 28
+	typeTSIG = 250
+	typeAXFR = 252
+
+	opcodeQuery  = 0
+	opcodeUpdate = 5
+
+	flagResponse = 1 << 15
+
+	rcodeSuccess = 0
+	rcodeNXRRSet = 8
+
+	headerLen = 12
+	maxPtrs   = 16
+)
+
+var rcodeNames = map[int]string{
+	1:  "FORMERR",
+	2:  "SERVFAIL",
+	3:  "NXDOMAIN",
+	4:  "NOTIMP",
+	5:  "REFUSED",
+	6:  "YXDOMAIN",
+	7:  "YXRRSET",
+	8:  "NXRRSET",
+	9:  "NOTAUTH",
+	10: "NOTZONE",
+}
+
+func rcodeName(rcode int) string {
+	if name, ok := rcodeNames[rcode]; ok {
+		return name
+	}
+	return fmt.Sprintf("rcode %d", rcode)
+}
+
+type question struct {
+	name   string
+	qtype  uint16
+	qclass uint16
+}
+
+type resource struct {
+	name  string
+	rtype uint16
+	class uint16
+	ttl   uint32
+	data  []byte
+}
+
+// message is a DNS message. In an update, the question section holds
+// the zone, the answer section holds the prerequisites, and the
+// authority section holds the updates.
+type message struct {
+	id          uint16
+	flags       uint16
+	questions   []question
+	answers     []resource
+	authorities []resource
+	additionals []resource
+}
+
+func newMessage(id uint16, opcode int) *message {
+	return &message{id: id, flags: uint16(opcode) << 11}
+}
+
+func (m *message) opcode() int {
+	return int(m.flags>>11) & 0xf
+}
+
+func (m *message) rcode() int {
+	return int(m.flags & 0xf)
+}
+
+// pack returns the message in wire format. Names are not compressed.
+func (m *message) pack() ([]byte, error) {
+	b := make([]byte, headerLen, 512)
+	binary.BigEndian.PutUint16(b[0:], m.id)
+	binary.BigEndian.PutUint16(b[2:], m.flags)
+	binary.BigEndian.PutUint16(b[4:], uint16(len(m.questions)))
+	binary.BigEndian.PutUint16(b[6:], uint16(len(m.answers)))
+	binary.BigEndian.PutUint16(b[8:], uint16(len(m.authorities)))
+	binary.BigEndian.PutUint16(b[10:], uint16(len(m.additionals)))
+
+	var err error
+	for _, q := range m.questions {
+		if b, err = packName(b, q.name); err != nil {
+			return nil, errors.Trace(err)
+		}
+		b = appendUint16(b, q.qtype)
+		b = appendUint16(b, q.qclass)
+	}
+	for _, section := range [][]resource{m.answers, m.authorities, m.additionals} {
+		for _, r := range section {
+			if b, err = packName(b, r.name); err != nil {
+				return nil, errors.Trace(err)
+			}
+			b = appendUint16(b, r.rtype)
+			b = appendUint16(b, r.class)
+			b = appendUint32(b, r.ttl)
+			b = appendUint16(b, uint16(len(r.data)))
+			b = append(b, r.data...)
+		}
+	}
+	return b, nil
+}
+
+// unpackMessage parses a message in wire format.
+func unpackMessage(b []byte) (*message, error) {
+	if len(b) < headerLen {
+		return nil, errors.New("message too short")
+	}
+	m := &message{
+		id:    binary.BigEndian.Uint16(b[0:]),
+		flags: binary.BigEndian.Uint16(b[2:]),
+	}
+	counts := []int{
+		int(binary.BigEndian.Uint16(b[4:])),
+		int(binary.BigEndian.Uint16(b[6:])),
+		int(binary.BigEndian.Uint16(b[8:])),
+		int(binary.BigEndian.Uint16(b[10:])),
+	}
+
+	off := headerLen
+	for i := 0; i < counts[0]; i++ {
+		name, next, err := unpackName(b, off)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if next+4 > len(b) {
+			return nil, errors.New("question truncated")
+		}
+		m.questions = append(m.questions, question{
+			name:   name,
+			qtype:  binary.BigEndian.Uint16(b[next:]),
+			qclass: binary.BigEndian.Uint16(b[next+2:]),
+		})
+		off = next + 4
+	}
+	sections := []*[]resource{&m.answers, &m.authorities, &m.additionals}
+	for i, section := range sections {
+		for j := 0; j < counts[i+1]; j++ {
+			name, next, err := unpackName(b, off)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if next+10 > len(b) {
+				return nil, errors.New("resource record truncated")
+			}
+			length := int(binary.BigEndian.Uint16(b[next+8:]))
+			if next+10+length > len(b) {
+				return nil, errors.New("resource data truncated")
+			}
+			*section = append(*section, resource{
+				name:  name,
+				rtype: binary.BigEndian.Uint16(b[next:]),
+				class: binary.BigEndian.Uint16(b[next+2:]),
+				ttl:   binary.BigEndian.Uint32(b[next+4:]),
+				data:  b[next+10 : next+10+length],
+			})
+			off = next + 10 + length
+		}
+	}
+	return m, nil
+}
+
+// packName appends the fully qualified name to b in wire format.
+func packName(b []byte, name string) ([]byte, error) {
+	name = strings.TrimSuffix(name, ".")
+	if name != "" {
+		for _, label := range strings.Split(name, ".") {
+			if len(label) == 0 || len(label) > 63 {
+				return nil, errors.NotValidf("name %q", name)
+			}
+			b = append(b, byte(len(label)))
+			b = append(b, label...)
+		}
+	}
+	return append(b, 0), nil
+}
+
+// unpackName reads the name at off in the message, following
+// compression pointers, and returns it along with the offset of the
+// data following it.
+func unpackName(msg []byte, off int) (string, int, error) {
+	var labels []string
+	end, ptrs := -1, 0
+	for {
+		if off >= len(msg) {
+			return "", 0, errors.New("name truncated")
+		}
+		length := int(msg[off])
+		switch length & 0xc0 {
+		case 0x00:
+			if length == 0 {
+				if end < 0 {
+					end = off + 1
+				}
+				return strings.Join(labels, ".") + ".", end, nil
+			}
+			if off+1+length > len(msg) {
+				return "", 0, errors.New("name truncated")
+			}
+			labels = append(labels, string(msg[off+1:off+1+length]))
+			off += 1 + length
+		case 0xc0:
+			if off+2 > len(msg) {
+				return "", 0, errors.New("name truncated")
+			}
+			if ptrs++; ptrs > maxPtrs {
+				return "", 0, errors.New("too many compression pointers")
+			}
+			if end < 0 {
+				end = off + 2
+			}
+			off = int(binary.BigEndian.Uint16(msg[off:]) & 0x3fff)
+		default:
+			return "", 0, errors.New("invalid label length")
+		}
+	}
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v>>8), byte(v))
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
@@ -0,0 +1,355 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package rfc2136 provides a DNS backend that keeps records up to date
+// by sending dynamic updates, as described in RFC 2136, to a DNS
+// server. Records are listed with a zone transfer, so the server must
+// allow the zone to be transferred as well as updated.
+//
+// Requests are sent over TCP and, if a key is configured, signed with
+// hmac-sha256 transaction signatures. The signatures on responses are
+// not verified.
+package rfc2136
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"math/rand"
+	"net"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/dns"
+)
+
+const (
+	defaultPort    = "53"
+	defaultTimeout = 30 * time.Second
+)
+
+// Config holds the configuration for a Zone.
+type Config struct {
+	// Server is the address of the DNS server, as host:port. If no
+	// port is given, the DNS port is used.
+	Server string
+
+	// Zone is the name of the zone to update.
+	Zone string
+
+	// TSIGKeyName is the name of the key used to sign requests. If it
+	// is empty, requests are not signed.
+	TSIGKeyName string
+
+	// TSIGSecret is the base64-encoded secret of the key used to sign
+	// requests.
+	TSIGSecret string
+
+	// Clock is used to timestamp signed requests.
+	Clock clock.Clock
+
+	// Timeout is the maximum time allowed for each exchange with the
+	// server. If it is zero, a default is used.
+	Timeout time.Duration
+}
+
+// Validate returns an error if the config cannot be used to create a
+// Zone.
+func (config Config) Validate() error {
+	if config.Server == "" {
+		return errors.NotValidf("empty Server")
+	}
+	if config.Zone == "" {
+		return errors.NotValidf("empty Zone")
+	}
+	if config.TSIGKeyName != "" {
+		if _, err := base64.StdEncoding.DecodeString(config.TSIGSecret); err != nil {
+			return errors.NotValidf("TSIGSecret")
+		}
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Timeout < 0 {
+		return errors.NotValidf("negative Timeout")
+	}
+	return nil
+}
+
+// Zone is a DNS zone whose records are managed by sending dynamic
+// updates to its server.
+type Zone struct {
+	config Config
+	server string
+	key    *tsigKey
+}
+
+var _ dns.Zone = (*Zone)(nil)
+
+// NewZone returns a Zone that sends dynamic updates as configured.
+func NewZone(config Config) (*Zone, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if config.Timeout == 0 {
+		config.Timeout = defaultTimeout
+	}
+	server := config.Server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, defaultPort)
+	}
+	z := &Zone{
+		config: config,
+		server: server,
+	}
+	if config.TSIGKeyName != "" {
+		secret, _ := base64.StdEncoding.DecodeString(config.TSIGSecret)
+		z.key = &tsigKey{name: config.TSIGKeyName, secret: secret}
+	}
+	return z, nil
+}
+
+var recordTypes = map[uint16]string{
+	typeA:    dns.TypeA,
+	typeAAAA: dns.TypeAAAA,
+}
+
+type recordKey struct {
+	name  string
+	rtype string
+}
+
+// Records is part of the dns.Zone interface.
+func (z *Zone) Records(domain string) ([]dns.Record, error) {
+	conn, err := z.dial()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer conn.Close()
+
+	query := newMessage(z.newID(), opcodeQuery)
+	query.questions = []question{{dns.Fqdn(z.config.Zone), typeAXFR, classIN}}
+	if err := z.send(conn, query); err != nil {
+		return nil, errors.Annotate(err, "requesting zone transfer")
+	}
+
+	// The transferred records are bracketed by the zone's SOA record,
+	// and may be spread over several messages.
+	records := make(map[recordKey]*dns.Record)
+	for soas := 0; soas < 2; {
+		reply, err := z.receive(conn, query.id)
+		if err != nil {
+			return nil, errors.Annotate(err, "reading zone transfer")
+		}
+		if err := checkReply(reply); err != nil {
+			return nil, errors.Annotate(err, "reading zone transfer")
+		}
+		if len(reply.answers) == 0 {
+			return nil, errors.New("reading zone transfer: no records")
+		}
+		for _, rr := range reply.answers {
+			if rr.rtype == typeSOA {
+				soas++
+				continue
+			}
+			rtype, ok := recordTypes[rr.rtype]
+			if !ok || rr.class != classIN || !dns.InDomain(rr.name, domain) {
+				continue
+			}
+			if len(rr.data) != net.IPv4len && len(rr.data) != net.IPv6len {
+				return nil, errors.Errorf("reading zone transfer: invalid %s record for %q", rtype, rr.name)
+			}
+			key := recordKey{dns.Fqdn(rr.name), rtype}
+			record, ok := records[key]
+			if !ok {
+				record = &dns.Record{Name: key.name, Type: rtype, TTL: int(rr.ttl)}
+				records[key] = record
+			}
+			record.Values = append(record.Values, net.IP(rr.data).String())
+		}
+	}
+
+	result := make([]dns.Record, 0, len(records))
+	for _, record := range records {
+		result = append(result, *record)
+	}
+	dns.SortRecords(result)
+	return result, nil
+}
+
+// SetRecord is part of the dns.Zone interface.
+func (z *Zone) SetRecord(record dns.Record) error {
+	rtype, err := wireType(record.Type)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	name := dns.Fqdn(record.Name)
+	update := z.newUpdate()
+	update.authorities = append(update.authorities, resource{
+		name:  name,
+		rtype: rtype,
+		class: classANY,
+	})
+	for _, value := range record.Values {
+		ip := net.ParseIP(value)
+		if ip4 := ip.To4(); rtype == typeA {
+			ip = ip4
+		} else if ip4 != nil {
+			ip = nil
+		}
+		if ip == nil {
+			return errors.NotValidf("%s record value %q", record.Type, value)
+		}
+		update.authorities = append(update.authorities, resource{
+			name:  name,
+			rtype: rtype,
+			class: classIN,
+			ttl:   uint32(record.TTL),
+			data:  ip,
+		})
+	}
+
+	reply, err := z.exchange(update)
+	if err == nil {
+		err = checkReply(reply)
+	}
+	return errors.Annotatef(err, "setting %s records for %q", record.Type, name)
+}
+
+// RemoveRecord is part of the dns.Zone interface.
+func (z *Zone) RemoveRecord(record dns.Record) error {
+	rtype, err := wireType(record.Type)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	name := dns.Fqdn(record.Name)
+	update := z.newUpdate()
+	// The record set must exist, so that we can report when it does
+	// not.
+	update.answers = append(update.answers, resource{
+		name:  name,
+		rtype: rtype,
+		class: classANY,
+	})
+	update.authorities = append(update.authorities, resource{
+		name:  name,
+		rtype: rtype,
+		class: classANY,
+	})
+
+	reply, err := z.exchange(update)
+	if err != nil {
+		return errors.Annotatef(err, "removing %s records for %q", record.Type, name)
+	}
+	if reply.rcode() == rcodeNXRRSet {
+		return errors.NotFoundf("%s records for %q", record.Type, name)
+	}
+	return errors.Annotatef(checkReply(reply), "removing %s records for %q", record.Type, name)
+}
+
+func wireType(recordType string) (uint16, error) {
+	for rtype, name := range recordTypes {
+		if name == recordType {
+			return rtype, nil
+		}
+	}
+	return 0, errors.NotSupportedf("record type %q", recordType)
+}
+
+func (z *Zone) newID() uint16 {
+	return uint16(rand.Intn(1 << 16))
+}
+
+func (z *Zone) newUpdate() *message {
+	update := newMessage(z.newID(), opcodeUpdate)
+	update.questions = []question{{dns.Fqdn(z.config.Zone), typeSOA, classIN}}
+	return update
+}
+
+// exchange sends the request to the server and returns its reply.
+func (z *Zone) exchange(request *message) (*message, error) {
+	conn, err := z.dial()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer conn.Close()
+	if err := z.send(conn, request); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return z.receive(conn, request.id)
+}
+
+func (z *Zone) dial() (net.Conn, error) {
+	conn, err := net.DialTimeout("tcp", z.server, z.config.Timeout)
+	if err != nil {
+		return nil, errors.Annotatef(err, "connecting to DNS server %q", z.server)
+	}
+	if err := conn.SetDeadline(time.Now().Add(z.config.Timeout)); err != nil {
+		conn.Close()
+		return nil, errors.Trace(err)
+	}
+	return conn, nil
+}
+
+// send writes the message, signed if there is a key, to the connection.
+func (z *Zone) send(conn net.Conn, m *message) error {
+	if z.key != nil {
+		if err := z.key.sign(m, z.config.Clock.Now()); err != nil {
+			return errors.Annotate(err, "signing request")
+		}
+	}
+	packed, err := m.pack()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(writeMessage(conn, packed))
+}
+
+// receive reads the server's response to the request with the given
+// ID from the connection.
+func (z *Zone) receive(conn net.Conn, id uint16) (*message, error) {
+	packed, err := readMessage(conn)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	reply, err := unpackMessage(packed)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing response")
+	}
+	if reply.id != id || reply.flags&flagResponse == 0 {
+		return nil, errors.New("unexpected response")
+	}
+	return reply, nil
+}
+
+func checkReply(reply *message) error {
+	if rcode := reply.rcode(); rcode != rcodeSuccess {
+		return errors.Errorf("server responded %s", rcodeName(rcode))
+	}
+	return nil
+}
+
+// writeMessage writes the packed message, preceded by its length as
+// DNS over TCP requires.
+func writeMessage(w io.Writer, packed []byte) error {
+	if len(packed) > 0xffff {
+		return errors.New("message too long")
+	}
+	_, err := w.Write(append(appendUint16(nil, uint16(len(packed))), packed...))
+	return err
+}
+
+// readMessage reads a packed message written by writeMessage.
+func readMessage(r io.Reader) ([]byte, error) {
+	var length [2]byte
+	if _, err := io.ReadFull(r, length[:]); err != nil {
+		return nil, err
+	}
+	packed := make([]byte, binary.BigEndian.Uint16(length[:]))
+	if _, err := io.ReadFull(r, packed); err != nil {
+		return nil, err
+	}
+	return packed, nil
+}
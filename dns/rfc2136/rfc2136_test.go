@@ -0,0 +1,264 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rfc2136
+
+import (
+	"net"
+	stdtesting "testing"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/dns"
+)
+
+func TestPackage(t *stdtesting.T) {
+	gc.TestingT(t)
+}
+
+type zoneSuite struct {
+	testing.IsolationSuite
+
+	server *standInServer
+	zone   *Zone
+}
+
+var _ = gc.Suite(&zoneSuite{})
+
+const testSecret = "c2VjcmV0LWtleS1mb3ItdGVzdGluZw=="
+
+func (s *zoneSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.server = newStandInServer(c, "example.com", &tsigKey{
+		name:   "juju-key",
+		secret: []byte("secret-key-for-testing"),
+	})
+	s.AddCleanup(func(*gc.C) { s.server.close() })
+	s.zone = s.newZone(c, "juju-key", testSecret)
+}
+
+func (s *zoneSuite) config(keyName, secret string) Config {
+	return Config{
+		Server:      s.server.addr(),
+		Zone:        "example.com",
+		TSIGKeyName: keyName,
+		TSIGSecret:  secret,
+		Clock:       testclock.NewClock(time.Now()),
+		Timeout:     time.Second,
+	}
+}
+
+func (s *zoneSuite) newZone(c *gc.C, keyName, secret string) *Zone {
+	zone, err := NewZone(s.config(keyName, secret))
+	c.Assert(err, jc.ErrorIsNil)
+	return zone
+}
+
+func (s *zoneSuite) TestValidate(c *gc.C) {
+	config := s.config("juju-key", testSecret)
+	config.Server = ""
+	c.Check(config.Validate(), gc.ErrorMatches, "empty Server not valid")
+
+	config = s.config("juju-key", testSecret)
+	config.Zone = ""
+	c.Check(config.Validate(), gc.ErrorMatches, "empty Zone not valid")
+
+	config = s.config("juju-key", "!!")
+	c.Check(config.Validate(), gc.ErrorMatches, "TSIGSecret not valid")
+
+	config = s.config("juju-key", testSecret)
+	config.Clock = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Clock not valid")
+
+	config = s.config("juju-key", testSecret)
+	config.Timeout = -1
+	c.Check(config.Validate(), gc.ErrorMatches, "negative Timeout not valid")
+}
+
+func (s *zoneSuite) TestParseTSIGKey(c *gc.C) {
+	name, secret, err := ParseTSIGKey("juju-key:c2VjcmV0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(name, gc.Equals, "juju-key")
+	c.Assert(secret, gc.Equals, "c2VjcmV0")
+
+	_, _, err = ParseTSIGKey("juju-key")
+	c.Assert(err, gc.ErrorMatches, "TSIG key without a name and secret not valid")
+	_, _, err = ParseTSIGKey(":c2VjcmV0")
+	c.Assert(err, gc.ErrorMatches, "TSIG key without a name and secret not valid")
+	_, _, err = ParseTSIGKey("juju-key:!!")
+	c.Assert(err, gc.ErrorMatches, "invalid TSIG key secret: .*")
+}
+
+func (s *zoneSuite) TestDefaultPort(c *gc.C) {
+	config := s.config("", "")
+	config.Server = "10.0.0.53"
+	zone, err := NewZone(config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zone.server, gc.Equals, "10.0.0.53:53")
+
+	config.Server = "2001:db8::53"
+	zone, err = NewZone(config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zone.server, gc.Equals, "[2001:db8::53]:53")
+}
+
+func (s *zoneSuite) TestSetRecordAndRecords(c *gc.C) {
+	s.server.add("www.example.com", typeA, net.ParseIP("192.0.2.1").To4())
+	s.server.add("db.m.example.com", 16, []byte("\x05hello"))
+
+	err := s.zone.SetRecord(dns.Record{
+		Name:   "wordpress.m.example.com",
+		Type:   dns.TypeA,
+		TTL:    60,
+		Values: []string{"10.0.0.1", "10.0.0.2"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.zone.SetRecord(dns.Record{
+		Name:   "wordpress.m.example.com",
+		Type:   dns.TypeAAAA,
+		TTL:    60,
+		Values: []string{"2001:db8::1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	records, err := s.zone.Records("m.example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, jc.DeepEquals, []dns.Record{{
+		Name:   "wordpress.m.example.com.",
+		Type:   dns.TypeA,
+		TTL:    60,
+		Values: []string{"10.0.0.1", "10.0.0.2"},
+	}, {
+		Name:   "wordpress.m.example.com.",
+		Type:   dns.TypeAAAA,
+		TTL:    60,
+		Values: []string{"2001:db8::1"},
+	}})
+}
+
+func (s *zoneSuite) TestSetRecordReplaces(c *gc.C) {
+	record := dns.Record{
+		Name:   "wordpress.m.example.com.",
+		Type:   dns.TypeA,
+		TTL:    60,
+		Values: []string{"10.0.0.1"},
+	}
+	c.Assert(s.zone.SetRecord(record), jc.ErrorIsNil)
+	record.Values = []string{"10.0.0.3"}
+	c.Assert(s.zone.SetRecord(record), jc.ErrorIsNil)
+
+	records, err := s.zone.Records("example.com.")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, jc.DeepEquals, []dns.Record{record})
+}
+
+func (s *zoneSuite) TestSetRecordInvalidValue(c *gc.C) {
+	err := s.zone.SetRecord(dns.Record{
+		Name:   "wordpress.m.example.com.",
+		Type:   dns.TypeAAAA,
+		Values: []string{"10.0.0.1"},
+	})
+	c.Assert(err, gc.ErrorMatches, `AAAA record value "10.0.0.1" not valid`)
+	c.Assert(s.server.updateCount(), gc.Equals, 0)
+}
+
+func (s *zoneSuite) TestSetRecordUnsupportedType(c *gc.C) {
+	err := s.zone.SetRecord(dns.Record{Name: "wordpress.m.example.com.", Type: "MX"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *zoneSuite) TestSetRecordOutsideZone(c *gc.C) {
+	err := s.zone.SetRecord(dns.Record{
+		Name:   "wordpress.example.org.",
+		Type:   dns.TypeA,
+		Values: []string{"10.0.0.1"},
+	})
+	c.Assert(err, gc.ErrorMatches, `setting A records for "wordpress.example.org.": server responded NOTZONE`)
+}
+
+func (s *zoneSuite) TestRemoveRecord(c *gc.C) {
+	s.server.add("wordpress.m.example.com", typeA, net.ParseIP("10.0.0.1").To4())
+	s.server.add("wordpress.m.example.com", typeAAAA, net.ParseIP("2001:db8::1"))
+
+	err := s.zone.RemoveRecord(dns.Record{Name: "wordpress.m.example.com.", Type: dns.TypeA})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.server.recordNames(), jc.DeepEquals, []string{"wordpress.m.example.com"})
+
+	err = s.zone.RemoveRecord(dns.Record{Name: "wordpress.m.example.com.", Type: dns.TypeA})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `A records for "wordpress.m.example.com." not found`)
+}
+
+func (s *zoneSuite) TestBadKey(c *gc.C) {
+	zone := s.newZone(c, "juju-key", "b3RoZXItc2VjcmV0")
+	err := zone.SetRecord(dns.Record{
+		Name:   "wordpress.m.example.com.",
+		Type:   dns.TypeA,
+		Values: []string{"10.0.0.1"},
+	})
+	c.Assert(err, gc.ErrorMatches, `setting A records for "wordpress.m.example.com.": server responded NOTAUTH`)
+
+	_, err = zone.Records("example.com.")
+	c.Assert(err, gc.ErrorMatches, "reading zone transfer: server responded NOTAUTH")
+}
+
+func (s *zoneSuite) TestUnsigned(c *gc.C) {
+	s.server.key = nil
+	zone := s.newZone(c, "", "")
+	err := zone.SetRecord(dns.Record{
+		Name:   "wordpress.m.example.com.",
+		Type:   dns.TypeA,
+		Values: []string{"10.0.0.1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.server.updateCount(), gc.Equals, 1)
+}
+
+func (s *zoneSuite) TestServerUnavailable(c *gc.C) {
+	s.server.close()
+	_, err := s.zone.Records("example.com.")
+	c.Assert(err, gc.ErrorMatches, `connecting to DNS server ".*": .*`)
+}
+
+type messageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&messageSuite{})
+
+func (s *messageSuite) TestPackUnpack(c *gc.C) {
+	m := newMessage(1234, opcodeUpdate)
+	m.questions = []question{{"example.com.", typeSOA, classIN}}
+	m.authorities = []resource{{"www.example.com.", typeA, classIN, 60, []byte{10, 0, 0, 1}}}
+	packed, err := m.pack()
+	c.Assert(err, jc.ErrorIsNil)
+
+	unpacked, err := unpackMessage(packed)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unpacked, jc.DeepEquals, m)
+	c.Assert(unpacked.opcode(), gc.Equals, opcodeUpdate)
+}
+
+func (s *messageSuite) TestUnpackCompressedName(c *gc.C) {
+	// "example.com." at offset 0, followed by "www" and a pointer to it.
+	msg := []byte("\x07example\x03com\x00\x03www\xc0\x00")
+	name, off, err := unpackName(msg, 13)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(name, gc.Equals, "www.example.com.")
+	c.Assert(off, gc.Equals, len(msg))
+}
+
+func (s *messageSuite) TestUnpackNamePointerLoop(c *gc.C) {
+	_, _, err := unpackName([]byte("\xc0\x00"), 0)
+	c.Assert(err, gc.ErrorMatches, "too many compression pointers")
+}
+
+func (s *messageSuite) TestPackNameInvalid(c *gc.C) {
+	_, err := packName(nil, "www..example.com.")
+	c.Assert(err, gc.ErrorMatches, `name "www..example.com" not valid`)
+}
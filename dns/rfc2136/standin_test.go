@@ -0,0 +1,257 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rfc2136
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/dns"
+)
+
+const (
+	rcodeRefused = 5
+	rcodeNotAuth = 9
+	rcodeNotZone = 10
+)
+
+// standInServer is a minimal DNS server for a single zone. It accepts
+// dynamic updates and zone transfers over TCP, so that Zone can be
+// tested without a real DNS server.
+type standInServer struct {
+	zone     string
+	key      *tsigKey
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu      sync.Mutex
+	records []resource
+	updates int
+}
+
+func newStandInServer(c *gc.C, zone string, key *tsigKey) *standInServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, gc.IsNil)
+	s := &standInServer{
+		zone:     dns.Fqdn(zone),
+		key:      key,
+		listener: listener,
+	}
+	s.wg.Add(1)
+	go s.serve()
+	return s
+}
+
+func (s *standInServer) addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *standInServer) close() {
+	s.listener.Close()
+	s.wg.Wait()
+}
+
+// add adds a record to the zone without an update.
+func (s *standInServer) add(name string, rtype uint16, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, resource{dns.Fqdn(name), rtype, classIN, 300, data})
+}
+
+func (s *standInServer) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+			s.handle(conn)
+		}()
+	}
+}
+
+func (s *standInServer) handle(conn net.Conn) {
+	for {
+		packed, err := readMessage(conn)
+		if err != nil {
+			return
+		}
+		request, err := unpackMessage(packed)
+		if err != nil {
+			return
+		}
+		var replies []*message
+		if rcode := s.verify(request); rcode != rcodeSuccess {
+			replies = []*message{s.reply(request, rcode)}
+		} else if request.opcode() == opcodeUpdate {
+			replies = []*message{s.reply(request, s.update(request))}
+		} else if len(request.questions) == 1 && request.questions[0].qtype == typeAXFR {
+			replies = s.transfer(request)
+		} else {
+			replies = []*message{s.reply(request, rcodeRefused)}
+		}
+		for _, reply := range replies {
+			packed, err := reply.pack()
+			if err != nil {
+				return
+			}
+			if err := writeMessage(conn, packed); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// verify checks the request's transaction signature, if the server
+// has a key, and removes it from the request.
+func (s *standInServer) verify(request *message) int {
+	if s.key == nil {
+		return rcodeSuccess
+	}
+	n := len(request.additionals)
+	if n == 0 || request.additionals[n-1].rtype != typeTSIG {
+		return rcodeRefused
+	}
+	tsig := request.additionals[n-1]
+	request.additionals = request.additionals[:n-1]
+	if dns.Fqdn(tsig.name) != dns.Fqdn(s.key.name) {
+		return rcodeNotAuth
+	}
+
+	// Skip the algorithm name, which is all that the server supports.
+	_, off, err := unpackName(tsig.data, 0)
+	if err != nil || off+10 > len(tsig.data) {
+		return rcodeNotAuth
+	}
+	signed := uint64(binary.BigEndian.Uint16(tsig.data[off:]))<<32 | uint64(binary.BigEndian.Uint32(tsig.data[off+2:]))
+	fudge := binary.BigEndian.Uint16(tsig.data[off+6:])
+	macLen := int(binary.BigEndian.Uint16(tsig.data[off+8:]))
+	if off+10+macLen+2 > len(tsig.data) {
+		return rcodeNotAuth
+	}
+	mac := tsig.data[off+10 : off+10+macLen]
+
+	// The signature covers the request as it was before the TSIG
+	// record was added.
+	original := *request
+	original.id = binary.BigEndian.Uint16(tsig.data[off+10+macLen:])
+	packed, err := original.pack()
+	if err != nil {
+		return rcodeNotAuth
+	}
+	expected, err := s.key.mac(packed, signed, fudge)
+	if err != nil || !bytes.Equal(mac, expected) {
+		return rcodeNotAuth
+	}
+	if now := time.Now().Unix(); now > int64(signed)+int64(fudge) || now < int64(signed)-int64(fudge) {
+		return rcodeNotAuth
+	}
+	return rcodeSuccess
+}
+
+func (s *standInServer) reply(request *message, rcode int) *message {
+	reply := newMessage(request.id, request.opcode())
+	reply.flags |= flagResponse | uint16(rcode)
+	reply.questions = request.questions
+	return reply
+}
+
+func (s *standInServer) update(request *message) int {
+	if len(request.questions) != 1 || dns.Fqdn(request.questions[0].name) != s.zone {
+		return rcodeNotZone
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, prereq := range request.answers {
+		if !s.exists(prereq.name, prereq.rtype) {
+			return rcodeNXRRSet
+		}
+	}
+	for _, rr := range request.authorities {
+		if !dns.InDomain(rr.name, s.zone) {
+			return rcodeNotZone
+		}
+		switch rr.class {
+		case classANY:
+			s.remove(rr.name, rr.rtype)
+		case classIN:
+			rr.name = dns.Fqdn(rr.name)
+			s.records = append(s.records, rr)
+		default:
+			return rcodeRefused
+		}
+	}
+	s.updates++
+	return rcodeSuccess
+}
+
+func (s *standInServer) exists(name string, rtype uint16) bool {
+	for _, rr := range s.records {
+		if rr.name == dns.Fqdn(name) && rr.rtype == rtype {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *standInServer) remove(name string, rtype uint16) {
+	var kept []resource
+	for _, rr := range s.records {
+		if rr.name != dns.Fqdn(name) || rr.rtype != rtype {
+			kept = append(kept, rr)
+		}
+	}
+	s.records = kept
+}
+
+// transfer returns the zone's records, bracketed by its SOA record, in
+// two messages.
+func (s *standInServer) transfer(request *message) []*message {
+	if dns.Fqdn(request.questions[0].name) != s.zone {
+		return []*message{s.reply(request, rcodeNotAuth)}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	soaData, _ := packName(nil, "ns."+s.zone)
+	soaData, _ = packName(soaData, "hostmaster."+s.zone)
+	for i := 0; i < 5; i++ {
+		soaData = appendUint32(soaData, 1)
+	}
+	soa := resource{s.zone, typeSOA, classIN, 300, soaData}
+
+	half := len(s.records) / 2
+	first := s.reply(request, rcodeSuccess)
+	first.answers = append([]resource{soa}, s.records[:half]...)
+	second := s.reply(request, rcodeSuccess)
+	second.answers = append(append([]resource(nil), s.records[half:]...), soa)
+	return []*message{first, second}
+}
+
+func (s *standInServer) updateCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.updates
+}
+
+func (s *standInServer) recordNames() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var names []string
+	for _, rr := range s.records {
+		names = append(names, strings.TrimSuffix(rr.name, "."))
+	}
+	return names
+}
@@ -0,0 +1,107 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rfc2136
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/dns"
+)
+
+const (
+	tsigAlgorithm = "hmac-sha256."
+
+	// tsigFudge is the number of seconds by which the server's clock
+	// may differ from ours.
+	tsigFudge = 300
+)
+
+// ParseTSIGKey returns the name and base64-encoded secret of a TSIG
+// key given in the form "<name>:<base64 secret>".
+func ParseTSIGKey(key string) (name, secret string, err error) {
+	parts := strings.SplitN(key, ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", "", errors.NotValidf("TSIG key without a name and secret")
+	}
+	if _, err := base64.StdEncoding.DecodeString(parts[1]); err != nil {
+		return "", "", errors.Annotate(err, "invalid TSIG key secret")
+	}
+	return parts[0], parts[1], nil
+}
+
+// tsigKey is a key used to sign messages with a transaction signature,
+// as described in RFC 2845.
+type tsigKey struct {
+	name   string
+	secret []byte
+}
+
+// sign appends a TSIG record, signing the message as of the given time,
+// to the message's additional section. It must be the last change made
+// to the message before it is sent.
+func (k *tsigKey) sign(m *message, now time.Time) error {
+	packed, err := m.pack()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	signed := uint64(now.Unix())
+	mac, err := k.mac(packed, signed, tsigFudge)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	data, err := packName(nil, tsigAlgorithm)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data = appendUint48(data, signed)
+	data = appendUint16(data, tsigFudge)
+	data = appendUint16(data, uint16(len(mac)))
+	data = append(data, mac...)
+	data = appendUint16(data, m.id)
+	// The error and other data are empty in requests.
+	data = appendUint16(data, 0)
+	data = appendUint16(data, 0)
+
+	m.additionals = append(m.additionals, resource{
+		name:  dns.Fqdn(k.name),
+		rtype: typeTSIG,
+		class: classANY,
+		data:  data,
+	})
+	return nil
+}
+
+// mac returns the message authentication code for the packed message,
+// which must not include the TSIG record.
+func (k *tsigKey) mac(packed []byte, signed uint64, fudge uint16) ([]byte, error) {
+	vars, err := packName(nil, dns.Fqdn(k.name))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	vars = appendUint16(vars, classANY)
+	vars = appendUint32(vars, 0)
+	if vars, err = packName(vars, tsigAlgorithm); err != nil {
+		return nil, errors.Trace(err)
+	}
+	vars = appendUint48(vars, signed)
+	vars = appendUint16(vars, fudge)
+	vars = appendUint16(vars, 0)
+	vars = appendUint16(vars, 0)
+
+	h := hmac.New(sha256.New, k.secret)
+	h.Write(packed)
+	h.Write(vars)
+	return h.Sum(nil), nil
+}
+
+func appendUint48(b []byte, v uint64) []byte {
+	return append(b, byte(v>>40), byte(v>>32), byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
@@ -192,6 +192,54 @@ const (
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
 
+	// ReplaceInterruptedMachinesKey determines whether machines whose
+	// spot or preemptible instances are reclaimed by the cloud are
+	// automatically replaced, along with their units.
+	ReplaceInterruptedMachinesKey = "replace-interrupted-machines"
+
+	// ExposeLoadBalancersKey determines whether exposed applications
+	// are put behind a cloud load balancer, on clouds that support it.
+	// Currently only GCE does.
+	ExposeLoadBalancersKey = "expose-load-balancers"
+
+	// DNSZoneKey is the DNS zone in which records are kept for exposed
+	// applications and their units. Records are not managed when it
+	// is empty.
+	DNSZoneKey = "dns-zone"
+
+	// DNSBackendKey determines how the DNS zone is updated: "cloud"
+	// uses the cloud's DNS service, and "rfc2136" sends dynamic
+	// updates to a DNS server.
+	DNSBackendKey = "dns-backend"
+
+	// DNSRecordTTLKey is the TTL, in seconds, of managed DNS records.
+	DNSRecordTTLKey = "dns-record-ttl"
+
+	// DNSRFC2136ServerKey is the address of the DNS server that
+	// accepts dynamic updates, for the rfc2136 DNS backend.
+	DNSRFC2136ServerKey = "dns-rfc2136-server"
+
+	// DNSRFC2136TSIGKeyKey is the TSIG key, in the form
+	// "<name>:<base64 secret>", used to sign dynamic updates for the
+	// rfc2136 DNS backend. It is a secret, so it is not kept in the
+	// model config: the ModelConfig facade stores it on the model
+	// when it is set, where only the controller can read it.
+	DNSRFC2136TSIGKeyKey = "dns-rfc2136-tsig-key"
+
+	// PreferredIPv6Key determines whether IPv6 addresses are preferred
+	// over IPv4 addresses of the same scope when selecting the
+	// addresses of the model's machines and units.
+	PreferredIPv6Key = "preferred-ipv6"
+
+	// EgressPolicyKey determines which destinations the model's
+	// machines may send outbound traffic to.
+	EgressPolicyKey = "egress-policy"
+
+	// EgressAllowedCIDRsKey holds the destinations, besides those
+	// allowed by the egress policy, that the model's machines may
+	// send outbound traffic to when egress is restricted.
+	EgressAllowedCIDRsKey = "egress-allowed-cidrs"
+
 	// TransmitVendorMetricsKey is the key for whether the controller sends
 	// metrics collected in this model for anonymized aggregate analytics.
 	TransmitVendorMetricsKey = "transmit-vendor-metrics"
@@ -595,6 +643,10 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if err := cfg.validateDNS(); err != nil {
+		return errors.Trace(err)
+	}
+
 	if uuid := cfg.UUID(); !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
 	}
@@ -647,12 +699,28 @@ func Validate(cfg, old *Config) error {
 			if _, _, err := net.ParseCIDR(strings.TrimSpace(cidr)); err != nil {
 				return errors.Annotatef(err, "invalid egress subnet: %v", cidr)
 			}
-			if cidr == "0.0.0.0/0" {
+			if cidr == "0.0.0.0/0" || cidr == "::/0" {
 				return errors.Errorf("CIDR %q not allowed", cidr)
 			}
 		}
 	}
 
+	if v, ok := cfg.defined[EgressPolicyKey].(string); ok {
+		switch v {
+		case EgressPolicyOpen, EgressPolicyRelations:
+		default:
+			return errors.NotValidf("%s %q", EgressPolicyKey, v)
+		}
+	}
+
+	if v, ok := cfg.defined[EgressAllowedCIDRsKey].(string); ok && v != "" {
+		for _, cidr := range strings.Split(v, ",") {
+			if _, _, err := net.ParseCIDR(strings.TrimSpace(cidr)); err != nil {
+				return errors.Annotatef(err, "invalid egress allowed CIDR: %v", cidr)
+			}
+		}
+	}
+
 	if v, ok := cfg.defined[FanConfig].(string); ok && v != "" {
 		_, err := network.ParseFanConfig(v)
 		if err != nil {
@@ -668,6 +736,7 @@ func Validate(cfg, old *Config) error {
 			}
 		case "provider": // TODO(wpk) FIXME we should check that the provider supports this setting!
 		case "local":
+		case "macvlan", "ipvlan", "sriov":
 		case "": // We'll try to autoconfigure it
 		default:
 			return fmt.Errorf("Invalid value for container-networking-method - %v", v)
@@ -1169,6 +1238,132 @@ func (c *Config) AutomaticallyRetryHooks() bool {
 	}
 }
 
+// ReplaceInterruptedMachines returns whether machines whose instances
+// are interrupted by the cloud should be replaced. By default they are not.
+func (c *Config) ReplaceInterruptedMachines() bool {
+	val, _ := c.defined[ReplaceInterruptedMachinesKey].(bool)
+	return val
+}
+
+// ExposeLoadBalancers returns whether exposed applications should be
+// put behind a cloud load balancer. By default they are not.
+func (c *Config) ExposeLoadBalancers() bool {
+	val, _ := c.defined[ExposeLoadBalancersKey].(bool)
+	return val
+}
+
+const (
+	// DNSBackendCloud is the DNS backend that uses the cloud's DNS
+	// service.
+	DNSBackendCloud = "cloud"
+
+	// DNSBackendRFC2136 is the DNS backend that sends RFC2136 dynamic
+	// updates to a DNS server.
+	DNSBackendRFC2136 = "rfc2136"
+
+	// DefaultDNSRecordTTL is the TTL of managed DNS records, in
+	// seconds, if dns-record-ttl is not set.
+	DefaultDNSRecordTTL = 300
+)
+
+// DNSZone returns the fully qualified name of the DNS zone in which
+// records are kept for exposed applications, or "" if records are not
+// managed.
+func (c *Config) DNSZone() string {
+	zone := c.asString(DNSZoneKey)
+	if zone != "" && !strings.HasSuffix(zone, ".") {
+		zone += "."
+	}
+	return strings.ToLower(zone)
+}
+
+// DNSBackend returns how the DNS zone is updated. By default the
+// cloud's DNS service is used.
+func (c *Config) DNSBackend() string {
+	if backend := c.asString(DNSBackendKey); backend != "" {
+		return backend
+	}
+	return DNSBackendCloud
+}
+
+// DNSRecordTTL returns the TTL, in seconds, of managed DNS records.
+func (c *Config) DNSRecordTTL() int {
+	if ttl, ok := c.defined[DNSRecordTTLKey].(int); ok && ttl > 0 {
+		return ttl
+	}
+	return DefaultDNSRecordTTL
+}
+
+// DNSRFC2136Server returns the address of the DNS server that accepts
+// dynamic updates for the rfc2136 DNS backend.
+func (c *Config) DNSRFC2136Server() string {
+	return c.asString(DNSRFC2136ServerKey)
+}
+
+func (c *Config) validateDNS() error {
+	if ttl, ok := c.defined[DNSRecordTTLKey].(int); ok && ttl < 0 {
+		return errors.NotValidf("negative %s", DNSRecordTTLKey)
+	}
+	switch backend := c.asString(DNSBackendKey); backend {
+	case "", DNSBackendCloud, DNSBackendRFC2136:
+	default:
+		return errors.NotValidf("%s %q", DNSBackendKey, backend)
+	}
+	if _, ok := c.unknown[DNSRFC2136TSIGKeyKey]; ok {
+		return errors.Errorf("%s cannot be kept in model config, set it on an existing model with model-config", DNSRFC2136TSIGKeyKey)
+	}
+	if c.DNSZone() != "" && c.DNSBackend() == DNSBackendRFC2136 && c.DNSRFC2136Server() == "" {
+		return errors.Errorf("%s must be set to use the %s DNS backend", DNSRFC2136ServerKey, DNSBackendRFC2136)
+	}
+	return nil
+}
+
+// PreferredIPv6 returns whether IPv6 addresses are preferred over IPv4
+// addresses of the same scope when selecting the addresses of the
+// model's machines and units. By default IPv4 addresses are preferred.
+func (c *Config) PreferredIPv6() bool {
+	val, _ := c.defined[PreferredIPv6Key].(bool)
+	return val
+}
+
+const (
+	// EgressPolicyOpen leaves the outbound traffic of the model's
+	// machines unrestricted.
+	EgressPolicyOpen = "open"
+
+	// EgressPolicyRelations restricts the outbound traffic of each of
+	// the model's machines to the controllers, the machines of the
+	// applications related to its units, and the egress allowed
+	// CIDRs.
+	EgressPolicyRelations = "relations"
+)
+
+// EgressPolicy returns the policy that determines which destinations
+// the model's machines may send outbound traffic to. By default the
+// traffic is unrestricted.
+func (c *Config) EgressPolicy() string {
+	if val, _ := c.defined[EgressPolicyKey].(string); val != "" {
+		return val
+	}
+	return EgressPolicyOpen
+}
+
+// EgressAllowedCIDRs returns the destinations, besides those allowed by
+// the egress policy, that the model's machines may send outbound
+// traffic to when egress is restricted.
+func (c *Config) EgressAllowedCIDRs() []string {
+	raw := c.asString(EgressAllowedCIDRsKey)
+	if raw == "" {
+		return nil
+	}
+	// Value has already been validated.
+	var result []string
+	for _, cidr := range strings.Split(raw, ",") {
+		result = append(result, strings.TrimSpace(cidr))
+	}
+	return result
+}
+
 // TransmitVendorMetrics returns whether the controller sends charm-collected metrics
 // in this model for anonymized aggregate analytics. By default this should be true.
 func (c *Config) TransmitVendorMetrics() bool {
@@ -1448,57 +1643,66 @@ var alwaysOptional = schema.Defaults{
 	StorageDefaultBlockSourceKey:      schema.Omit,
 	StorageDefaultFilesystemSourceKey: schema.Omit,
 
-	"firewall-mode":              schema.Omit,
-	"logging-config":             schema.Omit,
-	ProvisionerHarvestModeKey:    schema.Omit,
-	HTTPProxyKey:                 schema.Omit,
-	HTTPSProxyKey:                schema.Omit,
-	FTPProxyKey:                  schema.Omit,
-	NoProxyKey:                   schema.Omit,
-	JujuHTTPProxyKey:             schema.Omit,
-	JujuHTTPSProxyKey:            schema.Omit,
-	JujuFTPProxyKey:              schema.Omit,
-	JujuNoProxyKey:               schema.Omit,
-	AptHTTPProxyKey:              schema.Omit,
-	AptHTTPSProxyKey:             schema.Omit,
-	AptFTPProxyKey:               schema.Omit,
-	AptNoProxyKey:                schema.Omit,
-	SnapHTTPProxyKey:             schema.Omit,
-	SnapHTTPSProxyKey:            schema.Omit,
-	SnapStoreProxyKey:            schema.Omit,
-	SnapStoreAssertionsKey:       schema.Omit,
-	"apt-mirror":                 schema.Omit,
-	AgentStreamKey:               schema.Omit,
-	ResourceTagsKey:              schema.Omit,
-	"cloudimg-base-url":          schema.Omit,
-	"enable-os-refresh-update":   schema.Omit,
-	"enable-os-upgrade":          schema.Omit,
-	"image-stream":               schema.Omit,
-	"image-metadata-url":         schema.Omit,
-	AgentMetadataURLKey:          schema.Omit,
-	ContainerImageStreamKey:      schema.Omit,
-	ContainerImageMetadataURLKey: schema.Omit,
-	"default-series":             schema.Omit,
-	"development":                schema.Omit,
-	"ssl-hostname-verification":  schema.Omit,
-	"proxy-ssh":                  schema.Omit,
-	"disable-network-management": schema.Omit,
-	IgnoreMachineAddresses:       schema.Omit,
-	AutomaticallyRetryHooks:      schema.Omit,
-	"test-mode":                  schema.Omit,
-	TransmitVendorMetricsKey:     schema.Omit,
-	NetBondReconfigureDelayKey:   schema.Omit,
-	ContainerNetworkingMethod:    schema.Omit,
-	MaxStatusHistoryAge:          schema.Omit,
-	MaxStatusHistorySize:         schema.Omit,
-	MaxActionResultsAge:          schema.Omit,
-	MaxActionResultsSize:         schema.Omit,
-	UpdateStatusHookInterval:     schema.Omit,
-	EgressSubnets:                schema.Omit,
-	FanConfig:                    schema.Omit,
-	CloudInitUserDataKey:         schema.Omit,
-	ContainerInheritProperiesKey: schema.Omit,
-	BackupDirKey:                 schema.Omit,
+	"firewall-mode":               schema.Omit,
+	"logging-config":              schema.Omit,
+	ProvisionerHarvestModeKey:     schema.Omit,
+	HTTPProxyKey:                  schema.Omit,
+	HTTPSProxyKey:                 schema.Omit,
+	FTPProxyKey:                   schema.Omit,
+	NoProxyKey:                    schema.Omit,
+	JujuHTTPProxyKey:              schema.Omit,
+	JujuHTTPSProxyKey:             schema.Omit,
+	JujuFTPProxyKey:               schema.Omit,
+	JujuNoProxyKey:                schema.Omit,
+	AptHTTPProxyKey:               schema.Omit,
+	AptHTTPSProxyKey:              schema.Omit,
+	AptFTPProxyKey:                schema.Omit,
+	AptNoProxyKey:                 schema.Omit,
+	SnapHTTPProxyKey:              schema.Omit,
+	SnapHTTPSProxyKey:             schema.Omit,
+	SnapStoreProxyKey:             schema.Omit,
+	SnapStoreAssertionsKey:        schema.Omit,
+	"apt-mirror":                  schema.Omit,
+	AgentStreamKey:                schema.Omit,
+	ResourceTagsKey:               schema.Omit,
+	"cloudimg-base-url":           schema.Omit,
+	"enable-os-refresh-update":    schema.Omit,
+	"enable-os-upgrade":           schema.Omit,
+	"image-stream":                schema.Omit,
+	"image-metadata-url":          schema.Omit,
+	AgentMetadataURLKey:           schema.Omit,
+	ContainerImageStreamKey:       schema.Omit,
+	ContainerImageMetadataURLKey:  schema.Omit,
+	"default-series":              schema.Omit,
+	"development":                 schema.Omit,
+	"ssl-hostname-verification":   schema.Omit,
+	"proxy-ssh":                   schema.Omit,
+	"disable-network-management":  schema.Omit,
+	IgnoreMachineAddresses:        schema.Omit,
+	AutomaticallyRetryHooks:       schema.Omit,
+	ReplaceInterruptedMachinesKey: schema.Omit,
+	ExposeLoadBalancersKey:        schema.Omit,
+	PreferredIPv6Key:              schema.Omit,
+	EgressPolicyKey:               schema.Omit,
+	EgressAllowedCIDRsKey:         schema.Omit,
+	DNSZoneKey:                    schema.Omit,
+	DNSBackendKey:                 schema.Omit,
+	DNSRecordTTLKey:               schema.Omit,
+	DNSRFC2136ServerKey:           schema.Omit,
+	"test-mode":                   schema.Omit,
+	TransmitVendorMetricsKey:      schema.Omit,
+	NetBondReconfigureDelayKey:    schema.Omit,
+	ContainerNetworkingMethod:     schema.Omit,
+	MaxStatusHistoryAge:           schema.Omit,
+	MaxStatusHistorySize:          schema.Omit,
+	MaxActionResultsAge:           schema.Omit,
+	MaxActionResultsSize:          schema.Omit,
+	UpdateStatusHookInterval:      schema.Omit,
+	EgressSubnets:                 schema.Omit,
+	FanConfig:                     schema.Omit,
+	CloudInitUserDataKey:          schema.Omit,
+	ContainerInheritProperiesKey:  schema.Omit,
+	BackupDirKey:                  schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -1938,6 +2142,52 @@ data of the store. (default false)`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
+	ReplaceInterruptedMachinesKey: {
+		Description: "Determines whether machines whose spot or preemptible instances are reclaimed by the cloud are replaced, re-adding their units on new machines",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	ExposeLoadBalancersKey: {
+		Description: "Determines whether exposed applications are put behind a cloud load balancer, whose address is used as the application's public address (GCE only)",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	DNSZoneKey: {
+		Description: "The DNS zone in which address records are kept for exposed applications and their units, under a subdomain named after the model",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	DNSBackendKey: {
+		Description: `How the DNS zone is updated - "cloud" to use the cloud's DNS service, or "rfc2136" to send dynamic updates to dns-rfc2136-server`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	DNSRecordTTLKey: {
+		Description: "The TTL of managed DNS records, in seconds",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	DNSRFC2136ServerKey: {
+		Description: "The host:port of the DNS server that accepts dynamic updates for the rfc2136 DNS backend",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	PreferredIPv6Key: {
+		Description: "Determines whether IPv6 addresses are preferred over IPv4 addresses when selecting the public and private addresses of dual-stack machines and units. On the controller model it also applies to the controller addresses",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	EgressPolicyKey: {
+		Description: `Determines which destinations the model's machines may send outbound traffic to: "open" (the default) allows all destinations; "relations" allows only the controllers, the machines of related applications and the egress-allowed-cidrs, besides DHCP, DNS and the cloud metadata service`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{EgressPolicyOpen, EgressPolicyRelations},
+		Group:       environschema.EnvironGroup,
+	},
+	EgressAllowedCIDRsKey: {
+		Description: "Comma-separated CIDRs that the model's machines may always send outbound traffic to when egress-policy restricts it, such as those of package mirrors",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	TransmitVendorMetricsKey: {
 		Description: "Determines whether metrics declared by charms deployed into this model are sent for anonymized aggregate analytics",
 		Type:        environschema.Tbool,
@@ -1949,7 +2199,7 @@ data of the store. (default false)`,
 		Group:       environschema.EnvironGroup,
 	},
 	ContainerNetworkingMethod: {
-		Description: "Method of container networking setup - one of fan, provider, local",
+		Description: "Method of container networking setup - one of fan, provider, local, macvlan, ipvlan, sriov. Containers attached with macvlan cannot reach their host machine, ipvlan needs a provider that allocates container addresses, and KVM guests only support macvlan of the last three",
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
@@ -1325,6 +1325,127 @@ func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	c.Assert(cfg.EgressSubnets(), gc.DeepEquals, []string{"10.0.0.1/32", "192.168.1.1/16"})
 }
 
+func (s *ConfigSuite) TestEgressSubnetsIPv6(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"egress-subnets": "10.0.0.1/32, 2001:db8::/64",
+	})
+	c.Assert(cfg.EgressSubnets(), gc.DeepEquals, []string{"10.0.0.1/32", "2001:db8::/64"})
+}
+
+func (s *ConfigSuite) TestEgressSubnetsAllNotAllowed(c *gc.C) {
+	for _, cidr := range []string{"0.0.0.0/0", "::/0"} {
+		attrs := testing.Attrs{
+			"type": "my-type", "name": "my-name",
+			"uuid":           testing.ModelTag.Id(),
+			"egress-subnets": cidr,
+		}
+		_, err := config.New(config.UseDefaults, attrs)
+		c.Check(err, gc.ErrorMatches, fmt.Sprintf("CIDR %q not allowed", cidr))
+	}
+}
+
+func (s *ConfigSuite) TestPreferredIPv6(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.PreferredIPv6(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{"preferred-ipv6": true})
+	c.Assert(cfg.PreferredIPv6(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestContainerNetworkingMethodParentDevice(c *gc.C) {
+	for _, method := range []string{"macvlan", "ipvlan", "sriov"} {
+		cfg := newTestConfig(c, testing.Attrs{"container-networking-method": method})
+		c.Check(cfg.ContainerNetworkingMethod(), gc.Equals, method)
+	}
+}
+
+func (s *ConfigSuite) TestContainerNetworkingMethodInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, sampleConfig.Merge(testing.Attrs{
+		"container-networking-method": "veth",
+	}))
+	c.Assert(err, gc.ErrorMatches, "Invalid value for container-networking-method - veth")
+}
+
+func (s *ConfigSuite) TestEgressPolicyDefaults(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.EgressPolicy(), gc.Equals, config.EgressPolicyOpen)
+	c.Assert(cfg.EgressAllowedCIDRs(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestEgressPolicyValues(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"egress-policy":        "relations",
+		"egress-allowed-cidrs": "10.0.0.0/24, 192.168.1.10/32",
+	})
+	c.Assert(cfg.EgressPolicy(), gc.Equals, config.EgressPolicyRelations)
+	c.Assert(cfg.EgressAllowedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/24", "192.168.1.10/32"})
+}
+
+func (s *ConfigSuite) TestEgressPolicyInvalid(c *gc.C) {
+	for i, test := range []struct {
+		attrs testing.Attrs
+		err   string
+	}{{
+		attrs: testing.Attrs{"egress-policy": "closed"},
+		err:   `.*egress-policy.*`,
+	}, {
+		attrs: testing.Attrs{"egress-allowed-cidrs": "10.0.0.0/24,mirror"},
+		err:   `invalid egress allowed CIDR: mirror: .*`,
+	}} {
+		c.Logf("test %d", i)
+		attrs := testing.Attrs{
+			"type": "my-type", "name": "my-name",
+			"uuid": testing.ModelTag.Id(),
+		}.Merge(test.attrs)
+		_, err := config.New(config.UseDefaults, attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConfigSuite) TestDNSDefaults(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.DNSZone(), gc.Equals, "")
+	c.Assert(cfg.DNSBackend(), gc.Equals, config.DNSBackendCloud)
+	c.Assert(cfg.DNSRecordTTL(), gc.Equals, config.DefaultDNSRecordTTL)
+}
+
+func (s *ConfigSuite) TestDNSValues(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"dns-zone":           "Example.COM",
+		"dns-backend":        "rfc2136",
+		"dns-record-ttl":     60,
+		"dns-rfc2136-server": "10.0.0.53:53",
+	})
+	c.Assert(cfg.DNSZone(), gc.Equals, "example.com.")
+	c.Assert(cfg.DNSBackend(), gc.Equals, config.DNSBackendRFC2136)
+	c.Assert(cfg.DNSRecordTTL(), gc.Equals, 60)
+	c.Assert(cfg.DNSRFC2136Server(), gc.Equals, "10.0.0.53:53")
+}
+
+func (s *ConfigSuite) TestDNSInvalid(c *gc.C) {
+	for i, test := range []struct {
+		attrs testing.Attrs
+		err   string
+	}{{
+		attrs: testing.Attrs{"dns-backend": "carrier-pigeon"},
+		err:   `dns-backend "carrier-pigeon" not valid`,
+	}, {
+		attrs: testing.Attrs{"dns-rfc2136-tsig-key": "juju-key:c2VjcmV0"},
+		err:   "dns-rfc2136-tsig-key cannot be kept in model config, set it on an existing model with model-config",
+	}, {
+		attrs: testing.Attrs{"dns-zone": "example.com", "dns-backend": "rfc2136"},
+		err:   "dns-rfc2136-server must be set to use the rfc2136 DNS backend",
+	}} {
+		c.Logf("test %d", i)
+		attrs := testing.Attrs{
+			"type": "my-type", "name": "my-name",
+			"uuid": testing.ModelTag.Id(),
+		}.Merge(test.attrs)
+		_, err := config.New(config.UseDefaults, attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *ConfigSuite) TestCloudInitUserDataFromEnvironment(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		config.CloudInitUserDataKey: validCloudInitUserData,
@@ -0,0 +1,27 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"github.com/juju/juju/dns"
+	"github.com/juju/juju/environs/context"
+)
+
+// DNSZones is an interface that an Environ may implement to give
+// access to the zones of its cloud's DNS service. When the dns-zone
+// model config is set and dns-backend is "cloud", Juju keeps address
+// records for exposed applications and their units in the zone.
+type DNSZones interface {
+	// DNSZone returns the cloud's DNS zone with the given fully
+	// qualified name. If there is no such zone, an error satisfying
+	// errors.IsNotFound is returned.
+	DNSZone(ctx context.ProviderCallContext, name string) (dns.Zone, error)
+}
+
+// SupportsDNS is a convenience helper to check if an environment can
+// manage DNS records. It returns the DNSZones if so.
+func SupportsDNS(env Environ) (DNSZones, bool) {
+	zones, ok := env.(DNSZones)
+	return zones, ok
+}
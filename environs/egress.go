@@ -0,0 +1,52 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
+)
+
+// EgressFirewaller is an interface that an Environ may implement to
+// restrict the outbound traffic of the model's instances through the
+// cloud's firewall. When the model's egress-policy restricts outbound
+// traffic and the cloud does not support it, the machines enforce the
+// policy themselves.
+type EgressFirewaller interface {
+	// SupportsEgressRules reports whether the cloud can restrict the
+	// outbound traffic of the model's instances, given the model's
+	// configuration.
+	SupportsEgressRules(ctx context.ProviderCallContext) (bool, error)
+
+	// SetEgressRules restricts the outbound traffic of the instance,
+	// which should have been started with the given machine id, to
+	// the destinations of the rules. Rules that are not restricted
+	// remove any restriction.
+	SetEgressRules(ctx context.ProviderCallContext, id instance.Id, machineId string, rules EgressRules) error
+
+	// EgressRules returns the rules that are currently applied to
+	// the instance, which should have been started with the given
+	// machine id.
+	EgressRules(ctx context.ProviderCallContext, id instance.Id, machineId string) (EgressRules, error)
+}
+
+// EgressRules describes the outbound traffic that an instance may send.
+type EgressRules struct {
+	// Restricted is true if outbound traffic is only allowed to the
+	// destination CIDRs.
+	Restricted bool
+
+	// DestinationCIDRs are the CIDRs that outbound traffic is allowed
+	// to, when restricted. They are sorted.
+	DestinationCIDRs []string
+}
+
+// SupportsEgressFirewall is a convenience helper to check if an
+// environment can restrict the outbound traffic of its instances. It
+// returns the EgressFirewaller if so. Whether the restriction applies
+// to the model's configuration is reported by SupportsEgressRules.
+func SupportsEgressFirewall(env Environ) (EgressFirewaller, bool) {
+	ef, ok := env.(EgressFirewaller)
+	return ef, ok
+}
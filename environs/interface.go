@@ -449,6 +449,22 @@ type Firewaller interface {
 	IngressRules(ctx context.ProviderCallContext) ([]network.IngressRule, error)
 }
 
+// IPv6IngressChecker is an interface that a Firewaller may implement
+// to report whether its ingress rules may have IPv6 source CIDRs.
+type IPv6IngressChecker interface {
+	// SupportsIPv6Ingress reports whether ports can be opened to
+	// IPv6 source CIDRs.
+	SupportsIPv6Ingress() bool
+}
+
+// SupportsIPv6Ingress reports whether the Firewaller's ingress rules
+// may have IPv6 source CIDRs. Firewallers that don't implement
+// IPv6IngressChecker are assumed to support them.
+func SupportsIPv6Ingress(fw Firewaller) bool {
+	checker, ok := fw.(IPv6IngressChecker)
+	return !ok || checker.SupportsIPv6Ingress()
+}
+
 // InstanceTagger is an interface that can be used for tagging instances.
 type InstanceTagger interface {
 	// TagInstance tags the given instance with the specified tags.
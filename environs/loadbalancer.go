@@ -0,0 +1,67 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+// LoadBalancers is an interface that an Environ may implement to
+// manage cloud load balancers in front of exposed applications. When
+// the expose-load-balancers model config is set, Juju keeps one load
+// balancer per exposed application, forwarding the application's open
+// ports to the instances of its healthy units.
+//
+// Only the GCE provider implements LoadBalancers; on other clouds,
+// exposed applications are reached through their units' machines.
+type LoadBalancers interface {
+	// EnsureLoadBalancer creates the load balancer for the application
+	// if it does not exist, and otherwise updates it to forward the
+	// given ports to the given instances only. The load balancer is
+	// returned with its public addresses.
+	EnsureLoadBalancer(ctx context.ProviderCallContext, args LoadBalancerParams) (LoadBalancer, error)
+
+	// RemoveLoadBalancer removes the application's load balancer. If
+	// there is no load balancer for the application, an error
+	// satisfying errors.IsNotFound is returned.
+	RemoveLoadBalancer(ctx context.ProviderCallContext, applicationName string) error
+
+	// LoadBalancerApplications returns the names of the applications
+	// that have a load balancer in the model.
+	LoadBalancerApplications(ctx context.ProviderCallContext) ([]string, error)
+}
+
+// LoadBalancerParams holds the parameters for EnsureLoadBalancer.
+type LoadBalancerParams struct {
+	// ApplicationName is the name of the application that the load
+	// balancer is for.
+	ApplicationName string
+
+	// Ports are the port ranges to forward to the instances.
+	Ports []network.PortRange
+
+	// Instances are the instances that traffic should be sent to. An
+	// instance is omitted if the unit on it is not healthy.
+	Instances []instance.Id
+}
+
+// LoadBalancer describes a load balancer created by LoadBalancers.
+type LoadBalancer struct {
+	// Id is the provider's identifier for the load balancer.
+	Id string
+
+	// Addresses are the addresses that the load balancer accepts
+	// traffic on.
+	Addresses []network.Address
+}
+
+// SupportsLoadBalancers is a convenience helper to check if an
+// environment can manage load balancers. It returns the LoadBalancers
+// if so.
+func SupportsLoadBalancers(env Environ) (LoadBalancers, bool) {
+	lbs, ok := env.(LoadBalancers)
+	return lbs, ok
+}
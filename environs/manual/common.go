@@ -15,6 +15,16 @@ var netLookupHost = net.LookupHost
 
 const ManualInstancePrefix = "manual:"
 
+// UserAnnotation is the machine annotation recording the user that
+// add-machine connected to a manually provisioned machine as, so that
+// the same user can be used to deprovision it.
+const UserAnnotation = "juju-manual-user"
+
+// JumpHostAnnotation is the machine annotation recording the jump host
+// that add-machine reached a manually provisioned machine through, so
+// that it can be reached the same way to deprovision it.
+const JumpHostAnnotation = "juju-manual-jump-host"
+
 // RecordMachineInState records and saves into the state machine the provisioned machine
 func RecordMachineInState(client ProvisioningClientAPI, machineParams params.AddMachineParams) (machineId string, err error) {
 	results, err := client.AddMachines([]params.AddMachineParams{machineParams})
@@ -0,0 +1,142 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package manual
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	jujuos "github.com/juju/os"
+	jujuseries "github.com/juju/os/series"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/juju/paths"
+)
+
+// DeprovisionMachineFunc is the type of a function that removes the
+// Juju agents and their data from a manually provisioned machine.
+type DeprovisionMachineFunc func(DeprovisionMachineArgs) error
+
+// DeprovisionMachineArgs holds the arguments for deprovisioning a
+// manually provisioned machine.
+type DeprovisionMachineArgs struct {
+	// User is the user to connect to the machine as. If empty,
+	// the user set up when the machine was provisioned is used.
+	User string
+
+	// Host is the address of the machine.
+	Host string
+
+	// JumpHost, if set, is the [user@]host through which SSH
+	// connections to the machine are made.
+	JumpHost string
+
+	// Series is the OS series of the machine, used to determine where
+	// Juju's files were installed.
+	Series string
+
+	// Stderr is used to present progress to the user.
+	Stderr io.Writer
+}
+
+// deprovisionFailedPrefix prefixes the lines written by the
+// deprovisioning script to report a step that failed.
+const deprovisionFailedPrefix = "FAILED: "
+
+const deprovisionScript = `
+failed=0
+fail() {
+    echo "FAILED: $*"
+    failed=1
+}
+
+# Stop and remove all of the Juju services, so that the machine
+# is no longer considered to be provisioned.
+if [ -d /run/systemd/system ]; then
+    for unit in $(systemctl list-unit-files --no-legend 'juju*' | awk '{print $1}'); do
+        systemctl stop "$unit" || fail "stopping $unit"
+        systemctl disable "$unit" || fail "disabling $unit"
+    done
+    rm -f /etc/systemd/system/juju* /etc/systemd/system/multi-user.target.wants/juju* || fail "removing systemd units"
+    rm -rf /lib/systemd/system/juju* || fail "removing systemd units"
+    systemctl daemon-reload || fail "reloading systemd"
+fi
+for conf in /etc/init/juju*.conf; do
+    [ -e "$conf" ] || continue
+    job=$(basename "$conf" .conf)
+    stop "$job" >/dev/null 2>&1
+    rm -f "$conf" || fail "removing upstart job $job"
+done
+
+# Any agents still running no longer have a service to restart them.
+pkill -SIGKILL jujud
+for i in $(seq 1 10); do
+    pgrep jujud >/dev/null || break
+    sleep 1
+done
+pgrep jujud >/dev/null && fail "killing jujud"
+
+for path in %s; do
+    rm -rf "$path" || fail "removing $path"
+done
+exit $failed
+`
+
+// DeprovisionScript returns a bash script that, when run as root on a
+// manually provisioned machine of the given series, stops and removes
+// all of the Juju agents and their services, and removes Juju's data,
+// logs and configuration. A step that fails does not prevent the
+// remaining steps from running; it is reported by a line on stdout
+// that can be interpreted by DeprovisionFailures.
+func DeprovisionScript(series string) (string, error) {
+	os, err := jujuseries.GetOSFromSeries(series)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if os == jujuos.Windows {
+		return "", errors.NotSupportedf("deprovisioning %s machines", series)
+	}
+	logDir, err := paths.LogDir(series)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	targets := []string{utils.ShQuote(path.Join(logDir, "juju"))}
+	for _, f := range []func(string) (string, error){
+		paths.DataDir,
+		paths.ConfDir,
+		paths.JujuRun,
+		paths.JujuDumpLogs,
+		paths.JujuIntrospect,
+		paths.JujuUpdateSeries,
+	} {
+		target, err := f(series)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		targets = append(targets, utils.ShQuote(target))
+	}
+	targets = append(targets, utils.ShQuote("/etc/profile.d/juju-introspection.sh"))
+	return fmt.Sprintf(deprovisionScript, strings.Join(targets, " ")), nil
+}
+
+// DeprovisionFailures returns the steps reported as having failed in
+// the output of a script returned by DeprovisionScript. If any steps
+// failed, an error describing them is returned.
+func DeprovisionFailures(stdout []byte) error {
+	var failed []string
+	for _, line := range bytes.Split(stdout, []byte("\n")) {
+		line := string(bytes.TrimSpace(line))
+		if strings.HasPrefix(line, deprovisionFailedPrefix) {
+			failed = append(failed, strings.TrimPrefix(line, deprovisionFailedPrefix))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return errors.Errorf("deprovisioning failed: %s", strings.Join(failed, "; "))
+}
@@ -0,0 +1,46 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package manual_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/testing"
+)
+
+type deprovisionSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&deprovisionSuite{})
+
+func (s *deprovisionSuite) TestDeprovisionScript(c *gc.C) {
+	script, err := manual.DeprovisionScript("bionic")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(script, jc.Contains, "systemctl list-unit-files --no-legend 'juju*'")
+	c.Assert(script, jc.Contains, "pkill -SIGKILL jujud")
+	c.Assert(script, jc.Contains, "for path in '/var/log/juju' '/var/lib/juju' '/etc/juju' '/usr/bin/juju-run' ")
+}
+
+func (s *deprovisionSuite) TestDeprovisionScriptUnknownSeries(c *gc.C) {
+	_, err := manual.DeprovisionScript("spock")
+	c.Assert(err, gc.ErrorMatches, ".*spock.*")
+}
+
+func (s *deprovisionSuite) TestDeprovisionScriptWindows(c *gc.C) {
+	_, err := manual.DeprovisionScript("win2012r2")
+	c.Assert(err, gc.ErrorMatches, "deprovisioning win2012r2 machines not supported")
+}
+
+func (s *deprovisionSuite) TestDeprovisionFailures(c *gc.C) {
+	err := manual.DeprovisionFailures([]byte("stopping\nFAILED: stopping jujud-machine-3.service\n\nFAILED: killing jujud\n"))
+	c.Assert(err, gc.ErrorMatches, "deprovisioning failed: stopping jujud-machine-3.service; killing jujud")
+}
+
+func (s *deprovisionSuite) TestDeprovisionNoFailures(c *gc.C) {
+	err := manual.DeprovisionFailures([]byte("Removed /etc/systemd/system/multi-user.target.wants/jujud-machine-3.service.\n"))
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,51 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package manualtest
+
+import (
+	"io"
+
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/environs/manual"
+)
+
+// Transport is an in-process manual.Transport for testing. Each script
+// run is recorded as a call on the stub. The n'th script run writes the
+// n'th entries of Stdout and Stderr, if any, and returns the stub's
+// next error.
+type Transport struct {
+	testing.Stub
+
+	Stdout []string
+	Stderr []string
+
+	runs int
+}
+
+var _ manual.Transport = (*Transport)(nil)
+
+// Run is part of the manual.Transport interface.
+func (t *Transport) Run(script string, stdout, stderr io.Writer) error {
+	t.MethodCall(t, "Run", script)
+	return t.respond(stdout, stderr)
+}
+
+// RunPrivileged is part of the manual.Transport interface.
+func (t *Transport) RunPrivileged(script string, stdout, stderr io.Writer) error {
+	t.MethodCall(t, "RunPrivileged", script)
+	return t.respond(stdout, stderr)
+}
+
+func (t *Transport) respond(stdout, stderr io.Writer) error {
+	n := t.runs
+	t.runs++
+	if n < len(t.Stdout) && stdout != nil {
+		io.WriteString(stdout, t.Stdout[n])
+	}
+	if n < len(t.Stderr) && stderr != nil {
+		io.WriteString(stderr, t.Stderr[n])
+	}
+	return t.NextErr()
+}
@@ -30,6 +30,11 @@ type ProvisionMachineArgs struct {
 	Host string
 	User string
 
+	// JumpHost, if set, is the [user@]host through which SSH
+	// connections to the machine are made. It is not supported
+	// for WinRM.
+	JumpHost string
+
 	// DataDir is the root directory for juju data.
 	// If left blank, the default location "/var/lib/juju" will be used.
 	DataDir string
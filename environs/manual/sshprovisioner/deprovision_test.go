@@ -0,0 +1,72 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sshprovisioner_test
+
+import (
+	"bytes"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/environs/manual/sshprovisioner"
+	"github.com/juju/juju/testing"
+)
+
+type deprovisionSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&deprovisionSuite{})
+
+func (s *deprovisionSuite) deprovisionScript(c *gc.C) string {
+	script, err := manual.DeprovisionScript("bionic")
+	c.Assert(err, jc.ErrorIsNil)
+	return script
+}
+
+func (s *deprovisionSuite) TestDeprovisionMachine(c *gc.C) {
+	defer installFakeSSH(c, s.deprovisionScript(c), []string{"", "Removed jujud-machine-3.service."}, 0)()
+	var stderr bytes.Buffer
+	err := sshprovisioner.DeprovisionMachine(manual.DeprovisionMachineArgs{
+		Host:   "10.0.0.1",
+		Series: "bionic",
+		Stderr: &stderr,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stderr.String(), gc.Equals, "Removed jujud-machine-3.service.\n")
+}
+
+func (s *deprovisionSuite) TestDeprovisionMachineJumpHost(c *gc.C) {
+	// The fake ssh writes its arguments to stderr.
+	defer installFakeSSH(c, s.deprovisionScript(c), []string{"", "$*"}, 0)()
+	var stderr bytes.Buffer
+	err := sshprovisioner.DeprovisionMachine(manual.DeprovisionMachineArgs{
+		User:     "admin",
+		Host:     "10.0.0.1",
+		JumpHost: "jump@bastion",
+		Series:   "bionic",
+		Stderr:   &stderr,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stderr.String(), gc.Matches, `.*ProxyCommand ssh -W .*%h:%p.* jump@bastion.* admin@10\.0\.0\.1 .*\n`)
+}
+
+func (s *deprovisionSuite) TestDeprovisionMachineFailedSteps(c *gc.C) {
+	defer installFakeSSH(c, s.deprovisionScript(c), "FAILED: killing jujud\nFAILED: removing '/var/lib/juju'", 1)()
+	err := sshprovisioner.DeprovisionMachine(manual.DeprovisionMachineArgs{
+		Host:   "10.0.0.1",
+		Series: "bionic",
+	})
+	c.Assert(err, gc.ErrorMatches, "deprovisioning failed: killing jujud; removing '/var/lib/juju'")
+}
+
+func (s *deprovisionSuite) TestDeprovisionMachineSSHError(c *gc.C) {
+	defer installFakeSSH(c, s.deprovisionScript(c), nil, 255)()
+	err := sshprovisioner.DeprovisionMachine(manual.DeprovisionMachineArgs{
+		Host:   "10.0.0.1",
+		Series: "bionic",
+	})
+	c.Assert(err, gc.ErrorMatches, "deprovisioning 10.0.0.1: .*")
+}
@@ -0,0 +1,69 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sshprovisioner_test
+
+import (
+	"bytes"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/manual/manualtest"
+	"github.com/juju/juju/environs/manual/sshprovisioner"
+	"github.com/juju/juju/service"
+	"github.com/juju/juju/testing"
+)
+
+type linuxPlatformSuite struct {
+	testing.BaseSuite
+	transport *manualtest.Transport
+}
+
+var _ = gc.Suite(&linuxPlatformSuite{})
+
+func (s *linuxPlatformSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.transport = &manualtest.Transport{}
+}
+
+func (s *linuxPlatformSuite) TestCheckProvisioned(c *gc.C) {
+	s.transport.Stdout = []string{"jujud-machine-0\n"}
+	provisioned, err := sshprovisioner.LinuxPlatform{}.CheckProvisioned(s.transport)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provisioned, jc.IsTrue)
+	s.transport.CheckCalls(c, []jujutesting.StubCall{
+		{"Run", []interface{}{service.ListServicesScript()}},
+	})
+}
+
+func (s *linuxPlatformSuite) TestCheckProvisionedError(c *gc.C) {
+	s.transport.Stderr = []string{"no route to host\n"}
+	s.transport.SetErrors(errors.New("exit status 255"))
+	_, err := sshprovisioner.LinuxPlatform{}.CheckProvisioned(s.transport)
+	c.Assert(err, gc.ErrorMatches, `exit status 255 \(no route to host\)`)
+}
+
+func (s *linuxPlatformSuite) TestDetectSeriesAndHardwareCharacteristics(c *gc.C) {
+	s.transport.Stdout = []string{"bionic\nx86_64\nMemTotal: 4194304 kB\nprocessor: 0\nprocessor: 1\n"}
+	hc, series, err := sshprovisioner.LinuxPlatform{}.DetectSeriesAndHardwareCharacteristics(s.transport)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(series, gc.Equals, "bionic")
+	c.Assert(hc.String(), gc.Equals, "arch=amd64 cores=2 mem=4096M")
+	s.transport.CheckCalls(c, []jujutesting.StubCall{
+		{"Run", []interface{}{sshprovisioner.DetectionScript}},
+	})
+}
+
+func (s *linuxPlatformSuite) TestRunProvisioningScript(c *gc.C) {
+	s.transport.Stderr = []string{"installing agent\n"}
+	var stderr bytes.Buffer
+	err := sshprovisioner.LinuxPlatform{}.RunProvisioningScript(s.transport, "echo hi", nil, &stderr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stderr.String(), gc.Equals, "installing agent\n")
+	s.transport.CheckCalls(c, []jujutesting.StubCall{
+		{"RunPrivileged", []interface{}{"echo hi"}},
+	})
+}
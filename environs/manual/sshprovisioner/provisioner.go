@@ -6,8 +6,8 @@ package sshprovisioner
 
 import (
 	"github.com/juju/loggo"
+	"github.com/juju/utils/ssh"
 
-	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs/manual"
 )
 
@@ -18,53 +18,38 @@ var (
 // Provision returns a new machineId and nil if the provision process is done successfully
 // The func will manual provision a linux machine using as it's default protocol SSH
 func ProvisionMachine(args manual.ProvisionMachineArgs) (machineId string, err error) {
-	defer func() {
-		if machineId != "" && err != nil {
-			logger.Errorf("provisioning failed, removing machine %v: %v", machineId, err)
-			if cleanupErr := args.Client.ForceDestroyMachines(machineId); cleanupErr != nil {
-				logger.Errorf("error cleaning up machine: %s", cleanupErr)
-			}
-			machineId = ""
-		}
-	}()
-
 	// Create the "ubuntu" user and initialise passwordless sudo. We populate
 	// the ubuntu user's authorized_keys file with the public keys in the current
 	// user's ~/.ssh directory. The authenticationworker will later update the
 	// ubuntu user's authorized_keys.
-	if err = InitUbuntuUser(args.Host, args.User,
-		args.AuthorizedKeys, args.Stdin, args.Stdout); err != nil {
-		return "", err
-	}
-
-	machineParams, err := gatherMachineParams(args.Host)
-	if err != nil {
-		return "", err
-	}
-
-	// Inform Juju that the machine exists.
-	machineId, err = manual.RecordMachineInState(args.Client, *machineParams)
-	if err != nil {
+	options := jumpHostOptions(args.JumpHost)
+	if err = initUbuntuUser(args.Host, args.User,
+		args.AuthorizedKeys, args.Stdin, args.Stdout, options); err != nil {
 		return "", err
 	}
+	return manual.ProvisionMachineWithTransport(args, LinuxPlatform{}, NewTransport(args.Host, options))
+}
 
-	provisioningScript, err := args.Client.ProvisioningScript(params.ProvisioningScriptParams{
-		MachineId: machineId,
-		Nonce:     machineParams.Nonce,
-		DisablePackageCommands: !args.EnableOSRefreshUpdate && !args.EnableOSUpgrade,
-	})
-
-	if err != nil {
-		logger.Errorf("cannot obtain provisioning script")
-		return "", err
+// DeprovisionMachine removes the Juju agents, their services and Juju's
+// data from a machine previously provisioned by ProvisionMachine, so
+// that the machine may be provisioned again. It connects to the machine
+// as args.User, who must be able to use sudo without a password, or as
+// the ubuntu user, which ProvisionMachine set up for passwordless sudo.
+func DeprovisionMachine(args manual.DeprovisionMachineArgs) error {
+	host := args.Host
+	if args.User != "" {
+		host = args.User + "@" + host
 	}
+	return manual.DeprovisionMachineWithTransport(args, NewTransport(host, jumpHostOptions(args.JumpHost)))
+}
 
-	// Finally, provision the machine agent.
-	err = runProvisionScript(provisioningScript, args.Host, args.Stderr)
-	if err != nil {
-		return machineId, err
+// jumpHostOptions returns the SSH options for reaching a machine
+// through the given jump host, or nil if there is none.
+func jumpHostOptions(jumpHost string) *ssh.Options {
+	if jumpHost == "" {
+		return nil
 	}
-
-	logger.Infof("Provisioned machine %v", machineId)
-	return machineId, nil
+	var options ssh.Options
+	options.SetProxyCommand("ssh", "-W", "%h:%p", jumpHost)
+	return &options
 }
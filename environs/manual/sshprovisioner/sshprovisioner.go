@@ -17,15 +17,12 @@ import (
 	"github.com/juju/utils/shell"
 	"github.com/juju/utils/ssh"
 
-	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig"
 	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/cloudconfig/instancecfg"
-	"github.com/juju/juju/cloudconfig/sshinit"
 	"github.com/juju/juju/environs/manual"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/service"
-	"github.com/juju/juju/state/multiwatcher"
 )
 
 // InitUbuntuUser adds the ubuntu user if it doesn't
@@ -40,6 +37,12 @@ import (
 // authorizedKeys may be empty, in which case the file
 // will be created and left empty.
 func InitUbuntuUser(host, login, authorizedKeys string, read io.Reader, write io.Writer) error {
+	return initUbuntuUser(host, login, authorizedKeys, read, write, nil)
+}
+
+// initUbuntuUser is InitUbuntuUser, connecting to the host
+// with the given SSH options, which may be nil.
+func initUbuntuUser(host, login, authorizedKeys string, read io.Reader, write io.Writer, baseOptions *ssh.Options) error {
 	logger.Infof("initialising %q, user %q", host, login)
 
 	// To avoid unnecessary prompting for the specified login,
@@ -49,7 +52,7 @@ func InitUbuntuUser(host, login, authorizedKeys string, read io.Reader, write io
 	//
 	// Note that we explicitly do not allocate a PTY, so we
 	// get a failure if sudo prompts.
-	cmd := ssh.Command("ubuntu@"+host, []string{"sudo", "-n", "true"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"sudo", "-n", "true"}, baseOptions)
 	if cmd.Run() == nil {
 		logger.Infof("ubuntu user is already initialised")
 		return nil
@@ -62,6 +65,9 @@ func InitUbuntuUser(host, login, authorizedKeys string, read io.Reader, write io
 	}
 	script := fmt.Sprintf(initUbuntuScript, utils.ShQuote(authorizedKeys))
 	var options ssh.Options
+	if baseOptions != nil {
+		options = *baseOptions
+	}
 	options.AllowPasswordAuthentication()
 	options.EnablePTY()
 	cmd = ssh.Command(host, []string{"sudo", "/bin/bash -c " + utils.ShQuote(script)}, &options)
@@ -93,6 +99,12 @@ if [ ! -z "$authorized_keys" ]; then
     su ubuntu -c 'printf "%%s\n" "$authorized_keys" >> ~/.ssh/authorized_keys'
 fi`
 
+// LinuxPlatform is a manual.Platform for provisioning Linux machines.
+// The scripts it runs are written in bash.
+type LinuxPlatform struct{}
+
+var _ manual.Platform = LinuxPlatform{}
+
 // DetectSeriesAndHardwareCharacteristics detects the OS
 // series and hardware characteristics of the remote machine
 // by connecting to the machine and executing a bash script.
@@ -100,12 +112,14 @@ var DetectSeriesAndHardwareCharacteristics = detectSeriesAndHardwareCharacterist
 
 func detectSeriesAndHardwareCharacteristics(host string) (hc instance.HardwareCharacteristics, series string, err error) {
 	logger.Infof("Detecting series and characteristics on %s", host)
-	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, nil)
+	return LinuxPlatform{}.DetectSeriesAndHardwareCharacteristics(NewTransport(host, nil))
+}
+
+// DetectSeriesAndHardwareCharacteristics is part of the
+// manual.Platform interface.
+func (LinuxPlatform) DetectSeriesAndHardwareCharacteristics(t manual.Transport) (hc instance.HardwareCharacteristics, series string, err error) {
 	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-	cmd.Stdin = bytes.NewBufferString(detectionScript)
-	if err := cmd.Run(); err != nil {
+	if err := t.Run(detectionScript, &stdout, &stderr); err != nil {
 		if stderr.Len() != 0 {
 			err = fmt.Errorf("%v (%v)", err, strings.TrimSpace(stderr.String()))
 		}
@@ -165,15 +179,14 @@ var CheckProvisioned = checkProvisioned
 
 func checkProvisioned(host string) (bool, error) {
 	logger.Infof("Checking if %s is already provisioned", host)
+	return LinuxPlatform{}.CheckProvisioned(NewTransport(host, nil))
+}
 
+// CheckProvisioned is part of the manual.Platform interface.
+func (LinuxPlatform) CheckProvisioned(t manual.Transport) (bool, error) {
 	script := service.ListServicesScript()
-
-	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, nil)
 	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-	cmd.Stdin = strings.NewReader(script)
-	if err := cmd.Run(); err != nil {
+	if err := t.Run(script, &stdout, &stderr); err != nil {
 		if stderr.Len() != 0 {
 			err = fmt.Errorf("%v (%v)", err, strings.TrimSpace(stderr.String()))
 		}
@@ -185,6 +198,12 @@ func checkProvisioned(host string) (bool, error) {
 	return provisioned, nil
 }
 
+// RunProvisioningScript is part of the manual.Platform interface.
+// The script reports its progress on stderr; stdout is ignored.
+func (LinuxPlatform) RunProvisioningScript(t manual.Transport, script string, stdout, stderr io.Writer) error {
+	return t.RunPrivileged(script, nil, stderr)
+}
+
 // detectionScript is the script to run on the remote machine to
 // detect the OS series and hardware characteristics.
 const detectionScript = `#!/bin/bash
@@ -205,63 +224,6 @@ uname -m
 grep MemTotal /proc/meminfo
 cat /proc/cpuinfo`
 
-// gatherMachineParams collects all the information we know about the machine
-// we are about to provision. It will SSH into that machine as the ubuntu user.
-// The hostname supplied should not include a username.
-// If we can, we will reverse lookup the hostname by its IP address, and use
-// the DNS resolved name, rather than the name that was supplied
-func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
-
-	// Generate a unique nonce for the machine.
-	uuid, err := utils.NewUUID()
-	if err != nil {
-		return nil, err
-	}
-
-	addr, err := manual.HostAddress(hostname)
-	if err != nil {
-		return nil, errors.Annotatef(err, "failed to compute public address for %q", hostname)
-	}
-
-	provisioned, err := checkProvisioned(hostname)
-	if err != nil {
-		return nil, errors.Annotatef(err, "error checking if provisioned")
-	}
-	if provisioned {
-		return nil, manual.ErrProvisioned
-	}
-
-	hc, series, err := DetectSeriesAndHardwareCharacteristics(hostname)
-	if err != nil {
-		return nil, errors.Annotatef(err, "error detecting linux hardware characteristics")
-	}
-
-	// There will never be a corresponding "instance" that any provider
-	// knows about. This is fine, and works well with the provisioner
-	// task. The provisioner task will happily remove any and all dead
-	// machines from state, but will ignore the associated instance ID
-	// if it isn't one that the environment provider knows about.
-	instanceId := instance.Id(manual.ManualInstancePrefix + hostname)
-	nonce := fmt.Sprintf("%s:%s", instanceId, uuid.String())
-	machineParams := &params.AddMachineParams{
-		Series:                  series,
-		HardwareCharacteristics: hc,
-		InstanceId:              instanceId,
-		Nonce:                   nonce,
-		Addrs:                   params.FromNetworkAddresses(addr),
-		Jobs:                    []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
-	}
-	return machineParams, nil
-}
-
-func runProvisionScript(script, host string, progressWriter io.Writer) error {
-	params := sshinit.ConfigureParams{
-		Host:           "ubuntu@" + host,
-		ProgressWriter: progressWriter,
-	}
-	return sshinit.RunConfigureScript(script, params)
-}
-
 // ProvisioningScript generates a bash script that can be
 // executed on a remote host to carry out the cloud-init
 // configuration.
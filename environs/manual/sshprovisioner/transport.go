@@ -0,0 +1,64 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sshprovisioner
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/utils"
+	"github.com/juju/utils/ssh"
+
+	"github.com/juju/juju/environs/manual"
+)
+
+// runStdinScript is run by the remote shell to run the script fed to
+// it on stdin with bash. bash will read a byte at a time when consuming
+// commands from stdin, so to avoid doing so for large scripts we write
+// the script to disk first, and then execute it from there.
+const runStdinScript = `tmpfile=$(mktemp) && trap "rm -f $tmpfile" EXIT && cat > $tmpfile && /bin/bash $tmpfile`
+
+// Transport is a manual.Transport that runs scripts on a machine over
+// SSH, as the "ubuntu" user set up by InitUbuntuUser unless another
+// user is given.
+type Transport struct {
+	host    string
+	options *ssh.Options
+}
+
+var _ manual.Transport = (*Transport)(nil)
+
+// NewTransport returns a Transport that runs scripts on the given host,
+// which may be of the form user@host to connect as a user other than
+// "ubuntu".
+// The options may be nil; they may be used, for example, to reach the
+// host through a jump host by setting a proxy command.
+func NewTransport(host string, options *ssh.Options) *Transport {
+	return &Transport{host: host, options: options}
+}
+
+// Run is part of the manual.Transport interface.
+func (t *Transport) Run(script string, stdout, stderr io.Writer) error {
+	return t.run([]string{"/bin/bash"}, script, stdout, stderr)
+}
+
+// RunPrivileged is part of the manual.Transport interface. It relies
+// on the user being able to use sudo without a password.
+func (t *Transport) RunPrivileged(script string, stdout, stderr io.Writer) error {
+	return t.run([]string{
+		"sudo", "-n", "/bin/bash", "-c", utils.ShQuote(runStdinScript),
+	}, script, stdout, stderr)
+}
+
+func (t *Transport) run(command []string, script string, stdout, stderr io.Writer) error {
+	target := t.host
+	if !strings.Contains(target, "@") {
+		target = "ubuntu@" + target
+	}
+	cmd := ssh.Command(target, command, t.options)
+	cmd.Stdin = strings.NewReader(script)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	return cmd.Run()
+}
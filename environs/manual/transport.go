@@ -0,0 +1,169 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package manual
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// Transport runs scripts on a machine that is being manually
+// provisioned. The SSH and WinRM provisioners each provide one; a new
+// means of reaching machines, such as a configuration management
+// agent, need only implement Transport to reuse the rest of the
+// provisioning logic.
+type Transport interface {
+	// Run runs the script on the machine, writing its standard
+	// output and error to stdout and stderr. The script is
+	// interpreted by the machine's native shell: bash on Linux,
+	// and PowerShell on Windows.
+	Run(script string, stdout, stderr io.Writer) error
+
+	// RunPrivileged is like Run, but runs the script with
+	// administrative privileges.
+	RunPrivileged(script string, stdout, stderr io.Writer) error
+}
+
+// Platform provides the operating system specific steps of manually
+// provisioning a machine, which are carried out over a Transport.
+type Platform interface {
+	// CheckProvisioned reports whether a Juju agent has already
+	// been installed on the machine.
+	CheckProvisioned(Transport) (bool, error)
+
+	// DetectSeriesAndHardwareCharacteristics returns the hardware
+	// characteristics and OS series of the machine.
+	DetectSeriesAndHardwareCharacteristics(Transport) (instance.HardwareCharacteristics, string, error)
+
+	// RunProvisioningScript runs the script, obtained from the
+	// API server, that installs the machine agent. Progress is
+	// written to stdout and stderr.
+	RunProvisioningScript(t Transport, script string, stdout, stderr io.Writer) error
+}
+
+// ProvisionMachineWithTransport manually provisions the machine with
+// the given arguments, using the transport to run the platform's
+// provisioning steps on it. The transport must already be able to
+// reach the machine; any user initialisation must have been done.
+// On success, it returns the id of the new machine.
+func ProvisionMachineWithTransport(args ProvisionMachineArgs, platform Platform, transport Transport) (machineId string, err error) {
+	defer func() {
+		if machineId != "" && err != nil {
+			logger.Errorf("provisioning failed, removing machine %v: %v", machineId, err)
+			if cleanupErr := args.Client.ForceDestroyMachines(machineId); cleanupErr != nil {
+				logger.Errorf("error cleaning up machine: %s", cleanupErr)
+			}
+			machineId = ""
+		}
+	}()
+
+	machineParams, err := gatherMachineParams(args.Host, platform, transport)
+	if err != nil {
+		return "", err
+	}
+
+	// Inform Juju that the machine exists.
+	machineId, err = RecordMachineInState(args.Client, *machineParams)
+	if err != nil {
+		return "", err
+	}
+
+	provisioningScript, err := args.Client.ProvisioningScript(params.ProvisioningScriptParams{
+		MachineId:              machineId,
+		Nonce:                  machineParams.Nonce,
+		DisablePackageCommands: !args.EnableOSRefreshUpdate && !args.EnableOSUpgrade,
+	})
+	if err != nil {
+		logger.Errorf("cannot obtain provisioning script")
+		return "", err
+	}
+
+	// Finally, provision the machine agent.
+	if err := platform.RunProvisioningScript(transport, provisioningScript, args.Stdout, args.Stderr); err != nil {
+		return machineId, errors.Trace(err)
+	}
+
+	logger.Infof("Provisioned machine %v", machineId)
+	return machineId, nil
+}
+
+// gatherMachineParams collects all the information we know about the
+// machine we are about to provision. The hostname supplied should not
+// include a username.
+func gatherMachineParams(hostname string, platform Platform, transport Transport) (*params.AddMachineParams, error) {
+	// Generate a unique nonce for the machine.
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	addr, err := HostAddress(hostname)
+	if err != nil {
+		return nil, errors.Annotatef(err, "failed to compute public address for %q", hostname)
+	}
+
+	provisioned, err := platform.CheckProvisioned(transport)
+	if err != nil {
+		return nil, errors.Annotatef(err, "error checking if provisioned")
+	}
+	if provisioned {
+		return nil, ErrProvisioned
+	}
+
+	hc, series, err := platform.DetectSeriesAndHardwareCharacteristics(transport)
+	if err != nil {
+		return nil, errors.Annotatef(err, "error detecting hardware characteristics")
+	}
+
+	// There will never be a corresponding "instance" that any provider
+	// knows about. This is fine, and works well with the provisioner
+	// task. The provisioner task will happily remove any and all dead
+	// machines from state, but will ignore the associated instance ID
+	// if it isn't one that the environment provider knows about.
+	instanceId := instance.Id(ManualInstancePrefix + hostname)
+	nonce := fmt.Sprintf("%s:%s", instanceId, uuid.String())
+	machineParams := &params.AddMachineParams{
+		Series:                  series,
+		HardwareCharacteristics: hc,
+		InstanceId:              instanceId,
+		Nonce:                   nonce,
+		Addrs:                   params.FromNetworkAddresses(addr),
+		Jobs:                    []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+	}
+	return machineParams, nil
+}
+
+// DeprovisionMachineWithTransport removes the Juju agents, their
+// services and Juju's data from the machine with the given arguments,
+// using the transport to run the script returned by DeprovisionScript.
+func DeprovisionMachineWithTransport(args DeprovisionMachineArgs, transport Transport) error {
+	script, err := DeprovisionScript(args.Series)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	stderr := args.Stderr
+	if stderr == nil {
+		stderr = ioutil.Discard
+	}
+	logger.Infof("deprovisioning %s", args.Host)
+	var stdout bytes.Buffer
+	runErr := transport.RunPrivileged(script, &stdout, stderr)
+	if err := DeprovisionFailures(stdout.Bytes()); err != nil {
+		return errors.Trace(err)
+	}
+	if runErr != nil {
+		return errors.Annotatef(runErr, "deprovisioning %s", args.Host)
+	}
+	logger.Infof("deprovisioned %s", args.Host)
+	return nil
+}
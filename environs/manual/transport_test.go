@@ -0,0 +1,163 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package manual_test
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/environs/manual/manualtest"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/testing"
+)
+
+type transportSuite struct {
+	testing.BaseSuite
+	client    *fakeProvisioningClient
+	platform  *fakePlatform
+	transport *manualtest.Transport
+}
+
+var _ = gc.Suite(&transportSuite{})
+
+func (s *transportSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.client = &fakeProvisioningClient{machineId: "42"}
+	s.platform = &fakePlatform{series: "bionic"}
+	s.transport = &manualtest.Transport{}
+}
+
+func (s *transportSuite) args() manual.ProvisionMachineArgs {
+	return manual.ProvisionMachineArgs{
+		Host:           "10.0.0.1",
+		Client:         s.client,
+		UpdateBehavior: &params.UpdateBehavior{},
+	}
+}
+
+func (s *transportSuite) TestProvisionMachine(c *gc.C) {
+	machineId, err := manual.ProvisionMachineWithTransport(s.args(), s.platform, s.transport)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, "42")
+
+	c.Assert(s.client.added, gc.HasLen, 1)
+	added := s.client.added[0]
+	c.Assert(added.InstanceId, gc.Equals, instance.Id("manual:10.0.0.1"))
+	c.Assert(added.Series, gc.Equals, "bionic")
+	c.Assert(added.Nonce, jc.HasPrefix, "manual:10.0.0.1:")
+	c.Assert(s.client.scriptParams, jc.DeepEquals, params.ProvisioningScriptParams{
+		MachineId:              "42",
+		Nonce:                  added.Nonce,
+		DisablePackageCommands: true,
+	})
+	c.Assert(s.platform.provisioningScript, gc.Equals, "provisioning script")
+	s.transport.CheckCallNames(c, "Run", "Run", "RunPrivileged")
+}
+
+func (s *transportSuite) TestProvisionMachineAlreadyProvisioned(c *gc.C) {
+	s.platform.provisioned = true
+	machineId, err := manual.ProvisionMachineWithTransport(s.args(), s.platform, s.transport)
+	c.Assert(err, gc.Equals, manual.ErrProvisioned)
+	c.Assert(machineId, gc.Equals, "")
+	c.Assert(s.client.added, gc.HasLen, 0)
+}
+
+func (s *transportSuite) TestProvisionMachineFailureRemovesMachine(c *gc.C) {
+	s.transport.SetErrors(nil, nil, errors.New("out of cheese"))
+	machineId, err := manual.ProvisionMachineWithTransport(s.args(), s.platform, s.transport)
+	c.Assert(err, gc.ErrorMatches, "out of cheese")
+	c.Assert(machineId, gc.Equals, "")
+	c.Assert(s.client.destroyed, jc.DeepEquals, []string{"42"})
+}
+
+func (s *transportSuite) TestDeprovisionMachine(c *gc.C) {
+	s.transport.Stderr = []string{"Removed jujud-machine-42.service.\n"}
+	var stderr bytes.Buffer
+	err := manual.DeprovisionMachineWithTransport(manual.DeprovisionMachineArgs{
+		Host:   "10.0.0.1",
+		Series: "bionic",
+		Stderr: &stderr,
+	}, s.transport)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stderr.String(), gc.Equals, "Removed jujud-machine-42.service.\n")
+
+	script, err := manual.DeprovisionScript("bionic")
+	c.Assert(err, jc.ErrorIsNil)
+	s.transport.CheckCalls(c, []jujutesting.StubCall{{"RunPrivileged", []interface{}{script}}})
+}
+
+func (s *transportSuite) TestDeprovisionMachineFailures(c *gc.C) {
+	s.transport.Stdout = []string{"FAILED: killing jujud\n"}
+	s.transport.SetErrors(errors.New("exit status 1"))
+	err := manual.DeprovisionMachineWithTransport(manual.DeprovisionMachineArgs{
+		Host:   "10.0.0.1",
+		Series: "bionic",
+	}, s.transport)
+	c.Assert(err, gc.ErrorMatches, "deprovisioning failed: killing jujud")
+}
+
+func (s *transportSuite) TestDeprovisionMachineRunError(c *gc.C) {
+	s.transport.SetErrors(errors.New("connection refused"))
+	err := manual.DeprovisionMachineWithTransport(manual.DeprovisionMachineArgs{
+		Host:   "10.0.0.1",
+		Series: "bionic",
+	}, s.transport)
+	c.Assert(err, gc.ErrorMatches, "deprovisioning 10.0.0.1: connection refused")
+}
+
+// fakePlatform is a manual.Platform that runs a placeholder script
+// on the transport for each step, and returns canned results.
+type fakePlatform struct {
+	provisioned        bool
+	series             string
+	provisioningScript string
+}
+
+func (p *fakePlatform) CheckProvisioned(t manual.Transport) (bool, error) {
+	if err := t.Run("check", nil, nil); err != nil {
+		return false, err
+	}
+	return p.provisioned, nil
+}
+
+func (p *fakePlatform) DetectSeriesAndHardwareCharacteristics(t manual.Transport) (instance.HardwareCharacteristics, string, error) {
+	if err := t.Run("detect", nil, nil); err != nil {
+		return instance.HardwareCharacteristics{}, "", err
+	}
+	return instance.MustParseHardware("arch=amd64"), p.series, nil
+}
+
+func (p *fakePlatform) RunProvisioningScript(t manual.Transport, script string, stdout, stderr io.Writer) error {
+	p.provisioningScript = script
+	return t.RunPrivileged(script, stdout, stderr)
+}
+
+type fakeProvisioningClient struct {
+	machineId    string
+	added        []params.AddMachineParams
+	destroyed    []string
+	scriptParams params.ProvisioningScriptParams
+}
+
+func (f *fakeProvisioningClient) AddMachines(args []params.AddMachineParams) ([]params.AddMachinesResult, error) {
+	f.added = append(f.added, args...)
+	return []params.AddMachinesResult{{Machine: f.machineId}}, nil
+}
+
+func (f *fakeProvisioningClient) ForceDestroyMachines(machines ...string) error {
+	f.destroyed = append(f.destroyed, machines...)
+	return nil
+}
+
+func (f *fakeProvisioningClient) ProvisioningScript(args params.ProvisioningScriptParams) (string, error) {
+	f.scriptParams = args
+	return "provisioning script", nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package winrmprovisioner_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/manual/manualtest"
+	"github.com/juju/juju/environs/manual/winrmprovisioner"
+)
+
+type windowsPlatformSuite struct{}
+
+var _ = gc.Suite(&windowsPlatformSuite{})
+
+func (s *windowsPlatformSuite) TestCheckProvisioned(c *gc.C) {
+	transport := &manualtest.Transport{Stdout: []string{"Yes\r\n"}}
+	provisioned, err := winrmprovisioner.WindowsPlatform{}.CheckProvisioned(transport)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provisioned, jc.IsTrue)
+	transport.CheckCallNames(c, "Run")
+}
+
+func (s *windowsPlatformSuite) TestCheckProvisionedError(c *gc.C) {
+	transport := &manualtest.Transport{Stderr: []string{"Access is denied.\r\n"}}
+	transport.SetErrors(errors.New("exit status 1"))
+	_, err := winrmprovisioner.WindowsPlatform{}.CheckProvisioned(transport)
+	c.Assert(err, gc.ErrorMatches, "Access is denied.: exit status 1")
+}
+
+func (s *windowsPlatformSuite) TestDetectSeriesAndHardwareCharacteristics(c *gc.C) {
+	transport := &manualtest.Transport{Stdout: []string{"amd64\r\n16\r\nwin2012r2\r\n4\r\n"}}
+	hc, series, err := winrmprovisioner.WindowsPlatform{}.DetectSeriesAndHardwareCharacteristics(transport)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(series, gc.Equals, "win2012r2")
+	c.Assert(hc.String(), gc.Equals, "arch=amd64 cores=4 mem=16M")
+}
+
+func (s *windowsPlatformSuite) TestRunProvisioningScriptInChunks(c *gc.C) {
+	transport := &manualtest.Transport{}
+	script := strings.Repeat("x", 2000)
+	err := winrmprovisioner.WindowsPlatform{}.RunProvisioningScript(transport, script, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	// The base64 encoded script is 2668 bytes long, which is sent in
+	// three chunks between creating the file and running it.
+	transport.CheckCallNames(c, "Run", "Run", "Run", "Run", "Run")
+}
@@ -0,0 +1,43 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package winrmprovisioner
+
+import (
+	"io"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/shell"
+
+	"github.com/juju/juju/environs/manual"
+)
+
+// Transport is a manual.Transport that runs PowerShell scripts on a
+// machine over WinRM.
+type Transport struct {
+	client manual.WinrmClientAPI
+}
+
+var _ manual.Transport = (*Transport)(nil)
+
+// NewTransport returns a Transport that runs scripts with the given
+// WinRM client.
+func NewTransport(client manual.WinrmClientAPI) *Transport {
+	return &Transport{client: client}
+}
+
+// Run is part of the manual.Transport interface. The script is
+// encoded so that it may be passed safely to powershell.
+func (t *Transport) Run(script string, stdout, stderr io.Writer) error {
+	encoded, err := shell.NewPSEncodedCommand(script)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return t.client.Run(encoded, stdout, stderr)
+}
+
+// RunPrivileged is part of the manual.Transport interface. WinRM
+// connections are made as an administrator, so it is the same as Run.
+func (t *Transport) RunPrivileged(script string, stdout, stderr io.Writer) error {
+	return t.Run(script, stdout, stderr)
+}
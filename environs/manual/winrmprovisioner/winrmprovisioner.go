@@ -16,18 +16,15 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/os/series"
-	"github.com/juju/utils"
 	"github.com/juju/utils/arch"
 	"github.com/juju/utils/shell"
 	"github.com/juju/utils/winrm"
 
-	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig"
 	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/environs/manual"
 	"github.com/juju/juju/instance"
-	"github.com/juju/juju/state/multiwatcher"
 )
 
 // detectJujudProcess powershell script to determine
@@ -106,7 +103,6 @@ Get-NCores
 // newDetectHardwareScript will parse the detectHardware script and add
 // into the powershell hastable the key,val of the map returned from the
 // WindowsVersions func from the series pkg.
-// it will return the script wrapped into a safe powershell base64
 func newDetectHardwareScript() (string, error) {
 	tmpl := template.Must(template.New("hc").Parse(detectHardware))
 	var in bytes.Buffer
@@ -114,7 +110,7 @@ func newDetectHardwareScript() (string, error) {
 	if err := tmpl.Execute(&in, seriesMap); err != nil {
 		return "", err
 	}
-	return shell.NewPSEncodedCommand(in.String())
+	return in.String(), nil
 }
 
 // InitAdministratorUser will initially attempt to login as
@@ -296,86 +292,23 @@ $cred = New-Object System.Management.Automation.PSCredential "$ENV:COMPUTERNAME\
 New-Item -Path WSMan:\localhost\ClientCertificate -Issuer $clientcert.Thumbprint -Subject $subject -Uri * -Credential $cred -Force
 `
 
-// gatherMachineParams collects all the information we know about the machine
-// we are about to provision. It will winrm into that machine as the provision user
-// The hostname supplied should not include a username.
-// If we can, we will reverse lookup the hostname by its IP address, and use
-// the DNS resolved name, rather than the name that was supplied
-func gatherMachineParams(hostname string, cli manual.WinrmClientAPI) (*params.AddMachineParams, error) {
-	// Generate a unique nonce for the machine.
-	uuid, err := utils.NewUUID()
-	if err != nil {
-		return nil, err
-	}
-
-	addr, err := manual.HostAddress(hostname)
-	if err != nil {
-		return nil, errors.Annotatef(err, "failed to compute public address for %q", hostname)
-	}
-
-	provisioned, err := checkProvisioned(hostname, cli)
-	if err != nil {
-		return nil, errors.Annotatef(err, "cannot decide if the machine is provisioned")
-	}
-	if provisioned {
-		return nil, manual.ErrProvisioned
-	}
+// WindowsPlatform is a manual.Platform for provisioning Windows
+// machines. The scripts it runs are written in PowerShell.
+type WindowsPlatform struct{}
 
-	hc, series, err := DetectSeriesAndHardwareCharacteristics(hostname, cli)
-	if err != nil {
-		err = fmt.Errorf("error detecting windows characteristics: %v", err)
-		return nil, err
-	}
-	// There will never be a corresponding "instance" that any provider
-	// knows about. This is fine, and works well with the provisioner
-	// task. The provisioner task will happily remove any and all dead
-	// machines from state, but will ignore the associated instance ID
-	// if it isn't one that the environment provider knows about.
-
-	instanceId := instance.Id(manual.ManualInstancePrefix + hostname)
-	nonce := fmt.Sprintf("%s:%s", instanceId, uuid)
-	machineParams := &params.AddMachineParams{
-		Series:                  series,
-		HardwareCharacteristics: hc,
-		InstanceId:              instanceId,
-		Nonce:                   nonce,
-		Addrs:                   params.FromNetworkAddresses(addr),
-		Jobs:                    []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
-	}
-	return machineParams, nil
-}
+var _ manual.Platform = WindowsPlatform{}
 
-// checkProvisioned checks if the machine is already provisioned or not.
-// if it's already provisioned it will return true
-func checkProvisioned(host string, cli manual.WinrmClientAPI) (bool, error) {
-	logger.Tracef("Checking if %s windows machine is already provisioned", host)
+// CheckProvisioned is part of the manual.Platform interface. It
+// reports whether the jujud service is up and running on the machine.
+func (WindowsPlatform) CheckProvisioned(t manual.Transport) (bool, error) {
 	var stdout, stderr bytes.Buffer
-
-	// run the command trough winrm
-	// this script detects if the jujud process, service is up and running
-	script, err := shell.NewPSEncodedCommand(detectJujudProcess)
-	if err != nil {
-		return false, errors.Trace(err)
-	}
-
-	// send the script to the windows machine
-	if err = cli.Run(script, &stdout, &stderr); err != nil {
-		return false, errors.Trace(err)
-	}
-
-	provisioned := strings.Contains(stdout.String(), "Yes")
-	if stderr.Len() != 0 {
-		err = errors.Annotate(err, strings.TrimSpace(stderr.String()))
-	}
-
-	// if the script said yes
-	if provisioned {
-		logger.Infof("%s is already provisioned", host)
-	} else {
-		logger.Infof("%s is not provisioned", host)
+	if err := t.Run(detectJujudProcess, &stdout, &stderr); err != nil {
+		if stderr.Len() != 0 {
+			err = errors.Annotate(err, strings.TrimSpace(stderr.String()))
+		}
+		return false, err
 	}
-
-	return provisioned, err
+	return strings.Contains(stdout.String(), "Yes"), nil
 }
 
 // DetectSeriesAndHardwareCharacteristics detects the windows OS
@@ -383,6 +316,12 @@ func checkProvisioned(host string, cli manual.WinrmClientAPI) (bool, error) {
 // by connecting to the machine and executing a bash script.
 func DetectSeriesAndHardwareCharacteristics(host string, cli manual.WinrmClientAPI) (hc instance.HardwareCharacteristics, series string, err error) {
 	logger.Infof("Detecting series and characteristics on %s windows machine", host)
+	return WindowsPlatform{}.DetectSeriesAndHardwareCharacteristics(NewTransport(cli))
+}
+
+// DetectSeriesAndHardwareCharacteristics is part of the
+// manual.Platform interface.
+func (WindowsPlatform) DetectSeriesAndHardwareCharacteristics(t manual.Transport) (hc instance.HardwareCharacteristics, series string, err error) {
 	var stdout, stderr bytes.Buffer
 
 	script, err := newDetectHardwareScript()
@@ -391,7 +330,7 @@ func DetectSeriesAndHardwareCharacteristics(host string, cli manual.WinrmClientA
 	}
 
 	// send the script to the windows machine
-	if err = cli.Run(script, &stdout, &stderr); err != nil {
+	if err = t.Run(script, &stdout, &stderr); err != nil {
 		return hc, "", errors.Trace(err)
 	}
 
@@ -463,10 +402,15 @@ func splitHardWareScript(script string) ([]string, error) {
 // RunProvisionScript exported for testing purposes
 var RunProvisionScript = runProvisionScript
 
-// runProvisionScript runs the script generated by the provisioner
-// the script can be big and the underlying protocol dosen't support long messages
-// we need to send it into little chunks saving first into a file and then execute it.
-func runProvisionScript(script string, cli manual.WinrmClientAPI, stdin, stderr io.Writer) (err error) {
+func runProvisionScript(script string, cli manual.WinrmClientAPI, stdout, stderr io.Writer) error {
+	return WindowsPlatform{}.RunProvisioningScript(NewTransport(cli), script, stdout, stderr)
+}
+
+// RunProvisioningScript is part of the manual.Platform interface.
+// The script can be big and the underlying protocol dosen't support
+// long messages, so we send it in little chunks, saving them first
+// into a file and then executing it.
+func (WindowsPlatform) RunProvisioningScript(t manual.Transport, script string, stdout, stderr io.Writer) error {
 	script64 := base64.StdEncoding.EncodeToString([]byte(script))
 	input := bytes.NewBufferString(script64) // make new buffer out of script
 	// we must make sure to buffer the entire script
@@ -477,11 +421,7 @@ func runProvisionScript(script string, cli manual.WinrmClientAPI, stdin, stderr
 
 	// if the file dosen't exist ,create it
 	// if the file exists just clear/reset it
-	script, err = shell.NewPSEncodedCommand(initChunk)
-	if err != nil {
-		return err
-	}
-	if err = cli.Run(script, stdin, stderr); err != nil {
+	if err := t.Run(initChunk, stdout, stderr); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -491,34 +431,23 @@ func runProvisionScript(script string, cli manual.WinrmClientAPI, stdin, stderr
 		if err != nil && err != io.EOF {
 			return errors.Trace(err)
 		}
-		script, err = shell.NewPSEncodedCommand(
-			fmt.Sprintf(saveChunk, string(buf[:n])),
-		)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		if err = cli.Run(script, stdin, stderr); err != nil {
+		if err = t.Run(fmt.Sprintf(saveChunk, string(buf[:n])), stdout, stderr); err != nil {
 			return errors.Trace(err)
 		}
 	}
 
 	// after the sendAndSave script is successfully done
 	// we must execute the newly writed script
-	script, err = shell.NewPSEncodedCommand(runCmdProv)
-	if err != nil {
-		return err
-	}
 	logger.Debugf("Running the provisioningScript")
 	var outerr bytes.Buffer
-	if err = cli.Run(script, stdin, &outerr); err != nil {
+	if err := t.Run(runCmdProv, stdout, &outerr); err != nil {
 		return errors.Trace(err)
 	}
 
 	if outerr.Len() != 0 {
 		return fmt.Errorf("%v ", strings.TrimSpace(outerr.String()))
 	}
-
-	return err
+	return nil
 }
 
 // initChunk creates or clears the file that the userdata will be appendend.
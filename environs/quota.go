@@ -0,0 +1,136 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/context"
+)
+
+// QuotaReporter is an interface that an Environ may implement to
+// report the cloud's remaining resource quota, and the estimated cost
+// of its instance types. Juju uses this to refuse requests that the
+// cloud would otherwise reject when provisioning.
+type QuotaReporter interface {
+	// Quota returns the resource quota for the model's cloud region and
+	// credential. Resources for which the cloud does not report a quota
+	// are left nil.
+	Quota(ctx context.ProviderCallContext) (Quota, error)
+
+	// InstanceCosts returns the estimated hourly cost of each of the
+	// cloud's instance types, for those whose cost is known.
+	InstanceCosts(ctx context.ProviderCallContext) ([]InstanceCost, error)
+}
+
+// SupportsQuota is a convenience helper to check if an environment
+// reports its quota. It returns the QuotaReporter if so.
+func SupportsQuota(env Environ) (QuotaReporter, bool) {
+	qr, ok := env.(QuotaReporter)
+	return qr, ok
+}
+
+// QuotaLimit describes the limit and current usage of a single
+// quota-limited resource.
+type QuotaLimit struct {
+	// Limit is the maximum amount of the resource that may be used.
+	Limit int
+
+	// Used is the amount of the resource currently in use.
+	Used int
+}
+
+// Remaining returns the amount of the resource that may still be used.
+func (l QuotaLimit) Remaining() int {
+	if l.Used >= l.Limit {
+		return 0
+	}
+	return l.Limit - l.Used
+}
+
+// Quota describes the resource quota of a cloud region.
+type Quota struct {
+	// Cores is the quota of CPU cores.
+	Cores *QuotaLimit
+
+	// Instances is the quota of instances.
+	Instances *QuotaLimit
+
+	// Volumes is the quota of block storage volumes.
+	Volumes *QuotaLimit
+
+	// IPAddresses is the quota of public IP addresses.
+	IPAddresses *QuotaLimit
+}
+
+// QuotaRequest describes the resources required to satisfy a request
+// to add machines or units.
+type QuotaRequest struct {
+	// Instances is the number of new instances required.
+	Instances int
+
+	// Cores is the number of CPU cores required across all of the new
+	// instances, or zero if not known.
+	Cores int
+
+	// Volumes is the number of new volumes required.
+	Volumes int
+}
+
+// Check returns an error satisfying IsQuotaExceeded if any of the
+// resources in the request exceed the remaining quota. Resources
+// without a reported quota are not checked.
+func (q Quota) Check(req QuotaRequest) error {
+	var exceeded []string
+	check := func(name string, limit *QuotaLimit, required int) {
+		if limit == nil || required <= 0 {
+			return
+		}
+		if remaining := limit.Remaining(); required > remaining {
+			exceeded = append(exceeded, fmt.Sprintf(
+				"%s (%d required, %d of %d remaining)",
+				name, required, remaining, limit.Limit,
+			))
+		}
+	}
+	check("instances", q.Instances, req.Instances)
+	check("cores", q.Cores, req.Cores)
+	check("volumes", q.Volumes, req.Volumes)
+	if len(exceeded) == 0 {
+		return nil
+	}
+	return &quotaExceededError{strings.Join(exceeded, ", ")}
+}
+
+// InstanceCost describes the estimated hourly cost of an instance type.
+type InstanceCost struct {
+	// InstanceType is the name of the instance type.
+	InstanceType string
+
+	// HourlyCost is the estimated cost of running an instance of the
+	// type for one hour, expressed in Currency.
+	HourlyCost float64
+
+	// Currency is the currency in which HourlyCost is expressed.
+	Currency string
+}
+
+type quotaExceededError struct {
+	resources string
+}
+
+// Error is part of the error interface.
+func (e *quotaExceededError) Error() string {
+	return "cloud quota exceeded: " + e.resources
+}
+
+// IsQuotaExceeded reports whether or not the given error, or its
+// cause, was caused by a request exceeding the cloud's quota.
+func IsQuotaExceeded(err error) bool {
+	_, ok := errors.Cause(err).(*quotaExceededError)
+	return ok
+}
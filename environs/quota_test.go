@@ -0,0 +1,51 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+)
+
+type quotaSuite struct{}
+
+var _ = gc.Suite(&quotaSuite{})
+
+func (s *quotaSuite) TestRemaining(c *gc.C) {
+	c.Assert(environs.QuotaLimit{Limit: 10, Used: 4}.Remaining(), gc.Equals, 6)
+	c.Assert(environs.QuotaLimit{Limit: 10, Used: 10}.Remaining(), gc.Equals, 0)
+	c.Assert(environs.QuotaLimit{Limit: 10, Used: 12}.Remaining(), gc.Equals, 0)
+}
+
+func (s *quotaSuite) TestCheckWithinQuota(c *gc.C) {
+	quota := environs.Quota{
+		Instances: &environs.QuotaLimit{Limit: 20, Used: 18},
+		Cores:     &environs.QuotaLimit{Limit: 64, Used: 32},
+	}
+	err := quota.Check(environs.QuotaRequest{Instances: 2, Cores: 32, Volumes: 100})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *quotaSuite) TestCheckNoQuota(c *gc.C) {
+	err := environs.Quota{}.Check(environs.QuotaRequest{Instances: 1000, Cores: 1000})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *quotaSuite) TestCheckExceeded(c *gc.C) {
+	quota := environs.Quota{
+		Instances: &environs.QuotaLimit{Limit: 20, Used: 18},
+		Cores:     &environs.QuotaLimit{Limit: 64, Used: 32},
+		Volumes:   &environs.QuotaLimit{Limit: 10, Used: 0},
+	}
+	err := quota.Check(environs.QuotaRequest{Instances: 3, Cores: 48, Volumes: 1})
+	c.Assert(err, gc.ErrorMatches, `cloud quota exceeded: `+
+		`instances \(3 required, 2 of 20 remaining\), `+
+		`cores \(48 required, 32 of 64 remaining\)`)
+	c.Assert(err, jc.Satisfies, environs.IsQuotaExceeded)
+	c.Assert(errors.Annotate(err, "adding machine"), jc.Satisfies, environs.IsQuotaExceeded)
+	c.Assert(errors.New("foo"), gc.Not(jc.Satisfies), environs.IsQuotaExceeded)
+}
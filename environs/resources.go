@@ -0,0 +1,73 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"github.com/juju/juju/environs/context"
+)
+
+// TaggedResourceKind identifies the kind of a cloud resource that
+// Juju tags with the model and controller that own it.
+type TaggedResourceKind string
+
+const (
+	// InstanceResource identifies a machine instance.
+	InstanceResource TaggedResourceKind = "instance"
+
+	// VolumeResource identifies a block storage volume.
+	VolumeResource TaggedResourceKind = "volume"
+
+	// SecurityGroupResource identifies a security group, or
+	// whatever the cloud uses to hold firewall rules.
+	SecurityGroupResource TaggedResourceKind = "security-group"
+)
+
+// TaggedResource describes a cloud resource created by Juju.
+type TaggedResource struct {
+	// Kind is the kind of the resource.
+	Kind TaggedResourceKind
+
+	// Id is the provider-specific ID of the resource.
+	Id string
+
+	// ModelUUID is the UUID of the model that the resource
+	// belongs to.
+	ModelUUID string
+
+	// MachineId, if not empty, is the id of the machine that the
+	// resource belongs to, for resources such as per-machine
+	// security groups whose owner is identified by name rather
+	// than by tags.
+	MachineId string
+
+	// Tags holds the tags set on the resource, including those
+	// defined in the environs/tags package.
+	Tags map[string]string
+}
+
+// TaggedResourceLister is an interface that an Environ may implement
+// to list, and destroy, all of the resources in its cloud region that
+// are tagged as being managed by a controller. Juju uses this to find
+// resources that have been leaked, for example by a model whose
+// destruction failed part way through.
+type TaggedResourceLister interface {
+	// ControllerResources returns all of the live resources in the
+	// environ's cloud region tagged with the given controller UUID,
+	// regardless of the model they belong to. Root disks, which are
+	// destroyed along with their instances, are not included.
+	ControllerResources(ctx context.ProviderCallContext, controllerUUID string) ([]TaggedResource, error)
+
+	// DestroyResources destroys the given resources, which must have
+	// been returned by ControllerResources. Resources that no longer
+	// exist are ignored.
+	DestroyResources(ctx context.ProviderCallContext, resources []TaggedResource) error
+}
+
+// SupportsTaggedResources is a convenience helper to check if an
+// environment can list the resources tagged with its controller.
+// It returns the TaggedResourceLister if so.
+func SupportsTaggedResources(env Environ) (TaggedResourceLister, bool) {
+	lister, ok := env.(TaggedResourceLister)
+	return lister, ok
+}
@@ -0,0 +1,211 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package featuretests
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/application"
+	apicaasunitprovisioner "github.com/juju/juju/api/caasunitprovisioner"
+	"github.com/juju/juju/caas"
+	k8stesting "github.com/juju/juju/caas/kubernetes/provider/testing"
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/core/status"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+	"github.com/juju/juju/worker/caasunitprovisioner"
+)
+
+// CAASBrokerSuite deploys to a CAAS model backed by an in-process
+// fake kubernetes cluster, opening the broker the way the controller
+// does for the model.
+type CAASBrokerSuite struct {
+	jujutesting.JujuConnSuite
+
+	cluster *k8stesting.FakeCluster
+	st      *state.State
+}
+
+func (s *CAASBrokerSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+
+	s.cluster = k8stesting.NewFakeCluster()
+	restore := s.cluster.RegisterProvider()
+	s.AddCleanup(func(*gc.C) { restore() })
+
+	s.st = s.Factory.MakeCAASModel(c, &factory.ModelParams{Name: "caas-model"})
+	s.AddCleanup(func(*gc.C) { s.st.Close() })
+}
+
+const gitlabK8sSpec = `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    ports:
+    - containerPort: 80
+      protocol: TCP
+    config:
+      attr: foo=bar; fred=blogs
+`
+
+func (s *CAASBrokerSuite) TestDeployApplication(c *gc.C) {
+	f := factory.NewFactory(s.st)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "gitlab", Series: "kubernetes"})
+	app := f.MakeApplication(c, &factory.ApplicationParams{Name: "gitlab", Charm: ch})
+
+	broker, err := stateenvirons.GetNewCAASBrokerFunc(caas.New)(s.st)
+	c.Assert(err, jc.ErrorIsNil)
+	spec, err := broker.Provider().ParsePodSpec(gitlabK8sSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = broker.EnsureNamespace()
+	c.Assert(err, jc.ErrorIsNil)
+	err = broker.EnsureService(app.Name(), &caas.ServiceParams{PodSpec: spec}, 2, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := broker.Units(app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+	for _, u := range units {
+		c.Check(u.Status.Status, gc.Equals, status.Running)
+	}
+	_, err = broker.Service(app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = broker.DeleteService(app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	units, err = broker.Units(app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 0)
+}
+
+// openCAASModelAPIAsController opens an API connection to the CAAS
+// model as a controller machine agent, the way the controller's
+// model workers connect to hosted models.
+func (s *CAASBrokerSuite) openCAASModelAPIAsController(c *gc.C) api.Connection {
+	machine, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	password, err := utils.RandomPassword()
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetPassword(password)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProvisioned("foo", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info := s.APIInfo(c)
+	info.Tag = machine.Tag()
+	info.Password = password
+	info.Nonce = "fake_nonce"
+	info.ModelTag = names.NewModelTag(s.st.ModelUUID())
+	conn, err := api.Open(info, api.DialOpts{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { conn.Close() })
+	return conn
+}
+
+// openCAASModelAPI opens an API connection to the CAAS model as the
+// admin user.
+func (s *CAASBrokerSuite) openCAASModelAPI(c *gc.C) api.Connection {
+	info := s.APIInfo(c)
+	info.ModelTag = names.NewModelTag(s.st.ModelUUID())
+	conn, err := api.Open(info, api.DialOpts{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { conn.Close() })
+	return conn
+}
+
+func (s *CAASBrokerSuite) TestDeployThroughAPI(c *gc.C) {
+	// Add the charm to the CAAS model, as "juju deploy" does
+	// before asking the controller to deploy it.
+	f := factory.NewFactory(s.st)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "gitlab", Series: "kubernetes"})
+
+	client := application.NewClient(s.openCAASModelAPI(c))
+	err := client.Deploy(application.DeployArgs{
+		CharmID:         charmstore.CharmID{URL: ch.URL()},
+		ApplicationName: "gitlab",
+		Series:          "kubernetes",
+		NumUnits:        2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Start the unit provisioner the controller runs for the
+	// model; its broker is connected to the fake cluster.
+	broker, err := stateenvirons.GetNewCAASBrokerFunc(caas.New)(s.st)
+	c.Assert(err, jc.ErrorIsNil)
+	provisionerClient := apicaasunitprovisioner.NewClient(s.openCAASModelAPIAsController(c))
+	w, err := caasunitprovisioner.NewWorker(caasunitprovisioner.Config{
+		ApplicationGetter:      provisionerClient,
+		ApplicationUpdater:     provisionerClient,
+		ServiceBroker:          broker,
+		ContainerBroker:        broker,
+		ProvisioningInfoGetter: provisionerClient,
+		LifeGetter:             provisionerClient,
+		UnitUpdater:            provisionerClient,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	}()
+
+	// The operator sets the pod spec once the charm has run;
+	// that is what triggers the workload to be created.
+	app, err := s.st.Application("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := s.st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	caasModel, err := model.CAASModel()
+	c.Assert(err, jc.ErrorIsNil)
+	err = caasModel.SetPodSpec(app.ApplicationTag(), gitlabK8sSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Wait for the pods to be started in the cluster and
+	// for the units in state to be associated with them.
+	for a := coretesting.LongAttempt.Start(); ; {
+		pods, err := s.cluster.Pods(model.Name())
+		c.Assert(err, jc.ErrorIsNil)
+		if len(pods) == 2 && s.unitsHaveContainers(c, app) {
+			break
+		}
+		if !a.Next() {
+			c.Fatalf("timed out waiting for units to be provisioned; %d pods started", len(pods))
+		}
+	}
+
+	service, err := broker.Service(app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.Id, gc.Not(gc.Equals), "")
+}
+
+// unitsHaveContainers reports whether all of the application's
+// units have been associated with a running pod.
+func (s *CAASBrokerSuite) unitsHaveContainers(c *gc.C, app *state.Application) bool {
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, u := range units {
+		info, err := u.ContainerInfo()
+		if errors.IsNotFound(err) {
+			return false
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		if info.ProviderId() == "" {
+			return false
+		}
+		agentStatus, err := u.Status()
+		c.Assert(err, jc.ErrorIsNil)
+		if agentStatus.Status == status.Error {
+			c.Fatalf("unit %s: %s", u.Name(), agentStatus.Message)
+		}
+	}
+	return len(units) > 0
+}
@@ -66,6 +66,7 @@ func init() {
 	gc.Suite(&cmdMetricsCommandSuite{})
 	gc.Suite(&meterStatusIntegrationSuite{})
 	gc.Suite(&CAASOperatorSuite{})
+	gc.Suite(&CAASBrokerSuite{})
 	gc.Suite(&StatusSuite{})
 	gc.Suite(&cmdSetSeriesSuite{})
 	gc.Suite(&CmdExportBundleSuite{})
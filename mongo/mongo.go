@@ -260,16 +260,17 @@ func IsMaster(session *mgo.Session, obj WithAddresses) (bool, error) {
 }
 
 // SelectPeerAddress returns the address to use as the mongo replica set peer
-// address by selecting it from the given addresses. If no addresses are
-// available an empty string is returned.
-func SelectPeerAddress(addrs []network.Address) string {
+// address by selecting it from the given addresses, preferring IPv6
+// addresses if preferIPv6 is true. If no addresses are available an empty
+// string is returned.
+func SelectPeerAddress(addrs []network.Address, preferIPv6 bool) string {
 	// ScopeMachineLocal addresses are never suitable for mongo peers,
 	// as each controller runs on a separate machine.
 	const allowMachineLocal = false
 
 	// The second bool result is ignored intentionally (we return an empty
 	// string if no suitable address is available.)
-	addr, _ := network.SelectControllerAddress(addrs, allowMachineLocal)
+	addr, _ := network.SelectPreferredControllerAddress(addrs, allowMachineLocal, preferIPv6)
 	return addr.Value
 }
 
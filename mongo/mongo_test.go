@@ -696,10 +696,25 @@ func (s *MongoSuite) TestSelectPeerAddress(c *gc.C) {
 		Scope: network.ScopePublic,
 	}}
 
-	address := mongo.SelectPeerAddress(addresses)
+	address := mongo.SelectPeerAddress(addresses, false)
 	c.Assert(address, gc.Equals, "10.0.0.1")
 }
 
+func (s *MongoSuite) TestSelectPeerAddressPreferIPv6(c *gc.C) {
+	addresses := []network.Address{{
+		Value: "10.0.0.1",
+		Type:  network.IPv4Address,
+		Scope: network.ScopeCloudLocal,
+	}, {
+		Value: "fc00::1",
+		Type:  network.IPv6Address,
+		Scope: network.ScopeCloudLocal,
+	}}
+
+	c.Assert(mongo.SelectPeerAddress(addresses, false), gc.Equals, "10.0.0.1")
+	c.Assert(mongo.SelectPeerAddress(addresses, true), gc.Equals, "fc00::1")
+}
+
 func (s *MongoSuite) TestGenerateSharedSecret(c *gc.C) {
 	secret, err := mongo.GenerateSharedSecret()
 	c.Assert(err, jc.ErrorIsNil)
@@ -329,7 +329,14 @@ func SelectHostPortsBySpaceNames(hps []HostPort, spaceNames ...SpaceName) ([]Hos
 // When machineLocal is true both ScopeCloudLocal and ScopeMachineLocal
 // addresses are considered during the selection, otherwise just ScopeCloudLocal are.
 func SelectControllerAddress(addresses []Address, machineLocal bool) (Address, bool) {
-	internalAddress, ok := SelectInternalAddress(addresses, machineLocal)
+	return SelectPreferredControllerAddress(addresses, machineLocal, false)
+}
+
+// SelectPreferredControllerAddress is like SelectControllerAddress, but
+// when preferIPv6 is true IPv6 addresses are picked over IPv4 addresses
+// with the same scope.
+func SelectPreferredControllerAddress(addresses []Address, machineLocal, preferIPv6 bool) (Address, bool) {
+	internalAddress, ok := SelectPreferredInternalAddress(addresses, machineLocal, preferIPv6)
 	logger.Debugf(
 		"selected %q as controller address, using scope %q",
 		internalAddress.Value, internalAddress.Scope,
@@ -351,6 +358,19 @@ func SelectPublicAddress(addresses []Address) (Address, bool) {
 	return addresses[index], true
 }
 
+// SelectPreferredPublicAddress is like SelectPublicAddress, but when
+// preferIPv6 is true IPv6 addresses are picked over IPv4 addresses with
+// the same scope.
+func SelectPreferredPublicAddress(addresses []Address, preferIPv6 bool) (Address, bool) {
+	index := bestAddressIndex(len(addresses), func(i int) Address {
+		return addresses[i]
+	}, preferredTypeMatcher(publicMatch, preferIPv6))
+	if index < 0 {
+		return Address{}, false
+	}
+	return addresses[index], true
+}
+
 // SelectPublicHostPort picks one HostPort from a slice that would be
 // appropriate to display as a publicly accessible endpoint. If there
 // are no suitable candidates, the empty string is returned.
@@ -378,6 +398,19 @@ func SelectInternalAddress(addresses []Address, machineLocal bool) (Address, boo
 	return addresses[index], true
 }
 
+// SelectPreferredInternalAddress is like SelectInternalAddress, but
+// when preferIPv6 is true IPv6 addresses are picked over IPv4 addresses
+// with the same scope.
+func SelectPreferredInternalAddress(addresses []Address, machineLocal, preferIPv6 bool) (Address, bool) {
+	index := bestAddressIndex(len(addresses), func(i int) Address {
+		return addresses[i]
+	}, preferredTypeMatcher(internalAddressMatcher(machineLocal), preferIPv6))
+	if index < 0 {
+		return Address{}, false
+	}
+	return addresses[index], true
+}
+
 // SelectInternalAddresses picks the best addresses from a slice that can be
 // used as an endpoint for juju internal communication.
 // I nil slice is returned if there are no suitable addresses identified.
@@ -501,6 +534,43 @@ func cloudOrMachineLocalMatch(addr Address) scopeMatch {
 	return cloudLocalMatch(addr)
 }
 
+// preferredTypeMatcher returns matchFunc if preferIPv6 is false.
+// Otherwise it returns a scopeMatchFunc ranking IPv6 addresses the way
+// matchFunc ranks IPv4 addresses of the same scope, and vice versa.
+func preferredTypeMatcher(matchFunc scopeMatchFunc, preferIPv6 bool) scopeMatchFunc {
+	if !preferIPv6 {
+		return matchFunc
+	}
+	return func(addr Address) scopeMatch {
+		match := matchFunc(addr)
+		switch addr.Type {
+		case IPv4Address:
+			return ipv4Fallbacks[match]
+		case IPv6Address:
+			return ipv6Preferred[match]
+		}
+		return match
+	}
+}
+
+var (
+	// ipv4Fallbacks demotes IPv4 matches below IPv6 ones of the same
+	// scope.
+	ipv4Fallbacks = map[scopeMatch]scopeMatch{
+		exactScopeIPv4:          exactScope,
+		firstFallbackScopeIPv4:  firstFallbackScope,
+		secondFallbackScopeIPv4: secondFallbackScope,
+	}
+
+	// ipv6Preferred promotes IPv6 matches above IPv4 ones of the same
+	// scope.
+	ipv6Preferred = map[scopeMatch]scopeMatch{
+		exactScope:          exactScopeIPv4,
+		firstFallbackScope:  firstFallbackScopeIPv4,
+		secondFallbackScope: secondFallbackScopeIPv4,
+	}
+)
+
 type scopeMatch int
 
 const (
@@ -450,6 +450,76 @@ func (s *AddressSuite) TestSelectInternalAddress(c *gc.C) {
 	}
 }
 
+var selectPreferIPv6Tests = []selectTest{{
+	"a public IPv6 address is preferred to a public IPv4 address",
+	[]network.Address{
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+		network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+	},
+	1,
+}, {
+	"a public IPv4 address is preferred to a cloud local IPv6 address",
+	[]network.Address{
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+	},
+	1,
+}, {
+	"an IPv4 address is selected without IPv6 addresses",
+	[]network.Address{
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+	},
+	1,
+}}
+
+func (s *AddressSuite) TestSelectPreferredPublicAddress(c *gc.C) {
+	for i, t := range selectPreferIPv6Tests {
+		c.Logf("test %d: %s", i, t.about)
+		expectAddr, expectOK := t.expected()
+		actualAddr, actualOK := network.SelectPreferredPublicAddress(t.addresses, true)
+		c.Check(actualOK, gc.Equals, expectOK)
+		c.Check(actualAddr, gc.Equals, expectAddr)
+	}
+	for i, t := range selectPublicTests {
+		c.Logf("test %d: %s", i, t.about)
+		expectAddr, expectOK := t.expected()
+		actualAddr, actualOK := network.SelectPreferredPublicAddress(t.addresses, false)
+		c.Check(actualOK, gc.Equals, expectOK)
+		c.Check(actualAddr, gc.Equals, expectAddr)
+	}
+}
+
+func (s *AddressSuite) TestSelectPreferredInternalAddress(c *gc.C) {
+	addresses := []network.Address{
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+	}
+	addr, ok := network.SelectPreferredInternalAddress(addresses, false, true)
+	c.Check(ok, jc.IsTrue)
+	c.Check(addr, gc.Equals, addresses[2])
+
+	addr, ok = network.SelectPreferredInternalAddress(addresses, false, false)
+	c.Check(ok, jc.IsTrue)
+	c.Check(addr, gc.Equals, addresses[1])
+}
+
+func (s *AddressSuite) TestSelectPreferredControllerAddress(c *gc.C) {
+	addresses := []network.Address{
+		network.NewScopedAddress("127.0.0.1", network.ScopeMachineLocal),
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+	}
+	addr, ok := network.SelectPreferredControllerAddress(addresses, false, true)
+	c.Check(ok, jc.IsTrue)
+	c.Check(addr, gc.Equals, addresses[2])
+
+	addr, ok = network.SelectPreferredControllerAddress(addresses, false, false)
+	c.Check(ok, jc.IsTrue)
+	c.Check(addr, gc.Equals, addresses[1])
+}
+
 var selectInternalMachineTests = []selectTest{{
 	"first cloud local IPv4 address is selected",
 	[]network.Address{
@@ -36,9 +36,39 @@ type BridgePolicy struct {
 	//  - fan
 	//  - provider
 	//  - local
+	//  - macvlan
+	//  - ipvlan
+	//  - sriov
+	// The last three attach containers directly to a host device, so that
+	// the host machine's network configuration is left untouched. Note
+	// that macvlan containers cannot talk to their host machine, and
+	// that KVM guests only support macvlan.
 	ContainerNetworkingMethod string
 }
 
+// parentDeviceNICTypes maps the container networking methods that attach
+// containers directly to a host device to the NIC type used for them.
+var parentDeviceNICTypes = map[string]network.NICType{
+	"macvlan": network.MACVLANNIC,
+	"ipvlan":  network.IPVLANNIC,
+	"sriov":   network.SRIOVNIC,
+}
+
+// NICType returns how container devices are attached to their parent device
+// on the host machine, according to the ContainerNetworkingMethod.
+func (p *BridgePolicy) NICType() network.NICType {
+	if nicType, ok := parentDeviceNICTypes[p.ContainerNetworkingMethod]; ok {
+		return nicType
+	}
+	return network.BridgedNIC
+}
+
+// usesParentDevices returns true if containers are attached directly to a
+// host device instead of to a bridge.
+func (p *BridgePolicy) usesParentDevices() bool {
+	return p.NICType() != network.BridgedNIC
+}
+
 // Machine describes either a host machine, or a container machine. Either way
 // *state.Machine should fulfill the interface in non-test code.
 type Machine interface {
@@ -164,6 +194,64 @@ func possibleBridgeTarget(dev *state.LinkLayerDevice) (bool, error) {
 	return false, nil
 }
 
+// findParentDevicesForContainer returns, for each of the spaces the
+// container wants to be in, the host devices the container's devices should
+// be attached to directly. As when bridging, the first suitable device is
+// used for a known space and all suitable devices are used when the space
+// is unknown.
+// This will return an Error if the container wants a space that the host
+// machine cannot provide.
+func (p *BridgePolicy) findParentDevicesForContainer(m Machine, containerMachine Container) ([]*state.LinkLayerDevice, error) {
+	containerSpaces, devicesPerSpace, err := p.findSpacesAndDevicesForContainer(m, containerMachine)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Debugf("for container %q, found host devices spaces: %s",
+		containerMachine.Id(), formatDeviceMap(devicesPerSpace))
+
+	devicesByName := make(map[string]*state.LinkLayerDevice)
+	chosen := set.NewStrings()
+	notFound := set.NewStrings()
+	for _, spaceName := range containerSpaces.SortedValues() {
+		var deviceNames []string
+		for _, hostDevice := range devicesPerSpace[spaceName] {
+			if skippedDeviceNames.Contains(hostDevice.Name()) {
+				continue
+			}
+			possible, err := possibleBridgeTarget(hostDevice)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if !possible {
+				continue
+			}
+			deviceNames = append(deviceNames, hostDevice.Name())
+			devicesByName[hostDevice.Name()] = hostDevice
+		}
+		if len(deviceNames) == 0 {
+			notFound.Add(spaceName)
+		} else if spaceName == "" {
+			chosen = chosen.Union(set.NewStrings(deviceNames...))
+		} else {
+			// Sort to be sure we stably pick the host device.
+			chosen.Add(network.NaturallySortDeviceNames(deviceNames...)[0])
+		}
+	}
+	if !notFound.IsEmpty() {
+		logger.Warningf("container %q wants spaces %s, but host machine %q has no %s devices for %s",
+			containerMachine.Id(), network.QuoteSpaceSet(containerSpaces),
+			m.Id(), p.ContainerNetworkingMethod, network.QuoteSpaceSet(notFound))
+		return nil, errors.Errorf("host machine %q has no available device in space(s) %s",
+			m.Id(), network.QuoteSpaceSet(notFound))
+	}
+
+	devices := make([]*state.LinkLayerDevice, len(chosen))
+	for i, name := range network.NaturallySortDeviceNames(chosen.Values()...) {
+		devices[i] = devicesByName[name]
+	}
+	return devices, nil
+}
+
 func formatDeviceMap(spacesToDevices map[string][]*state.LinkLayerDevice) string {
 	spaceNames := make([]string, len(spacesToDevices))
 	i := 0
@@ -221,6 +309,14 @@ func BridgeNameForDevice(device string) string {
 // This will return an Error if the container wants a space that the host
 // machine cannot provide.
 func (b *BridgePolicy) FindMissingBridgesForContainer(m Machine, containerMachine Container) ([]network.DeviceToBridge, int, error) {
+	if b.usesParentDevices() {
+		// Containers are attached directly to host devices, so nothing
+		// needs bridging; just check that the devices are there.
+		if _, err := b.findParentDevicesForContainer(m, containerMachine); err != nil {
+			return nil, 0, errors.Trace(err)
+		}
+		return nil, 0, nil
+	}
 	reconfigureDelay := 0
 	containerSpaces, devicesPerSpace, err := b.findSpacesAndDevicesForContainer(m, containerMachine)
 	hostDeviceByName := make(map[string]*state.LinkLayerDevice, 0)
@@ -340,6 +436,9 @@ func (p *BridgePolicy) PopulateContainerLinkLayerDevices(m Machine, containerMac
 	// defining devices that 'will' exist in the container, but don't exist
 	// yet. If anything, this feels more like "Provider" level devices, because
 	// it is defining the devices from the outside, not the inside.
+	if p.usesParentDevices() {
+		return p.populateContainerParentDevices(m, containerMachine)
+	}
 	containerSpaces, devicesPerSpace, err := p.findSpacesAndDevicesForContainer(m, containerMachine)
 	if err != nil {
 		return errors.Trace(err)
@@ -412,3 +511,35 @@ func (p *BridgePolicy) PopulateContainerLinkLayerDevices(m Machine, containerMac
 	logger.Debugf("container %q network config set", containerMachine.Id())
 	return nil
 }
+
+// populateContainerParentDevices sets the link-layer devices of the given
+// containerMachine, attaching each device directly to a host machine
+// device rather than to a bridge.
+func (p *BridgePolicy) populateContainerParentDevices(m Machine, containerMachine Container) error {
+	// KVM guests can only be attached to a host device with macvtap.
+	if containerMachine.ContainerType() == instance.KVM && p.NICType() != network.MACVLANNIC {
+		return errors.NotSupportedf("container networking method %q for KVM container %q",
+			p.ContainerNetworkingMethod, containerMachine.Id())
+	}
+	hostDevices, err := p.findParentDevicesForContainer(m, containerMachine)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	containerDevicesArgs := make([]state.LinkLayerDeviceArgs, len(hostDevices))
+	for i, hostDevice := range hostDevices {
+		newLLD, err := state.DefineEthernetDeviceOnParent(fmt.Sprintf("eth%d", i), hostDevice)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		containerDevicesArgs[i] = newLLD
+	}
+	logger.Debugf("prepared container %q %s network config: %+v",
+		containerMachine.Id(), p.ContainerNetworkingMethod, containerDevicesArgs)
+
+	if err := containerMachine.SetLinkLayerDevices(containerDevicesArgs...); err != nil {
+		return errors.Trace(err)
+	}
+
+	logger.Debugf("container %q network config set", containerMachine.Id())
+	return nil
+}
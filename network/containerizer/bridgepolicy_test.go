@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
@@ -1136,6 +1137,109 @@ func (s *bridgePolicyStateSuite) TestFindMissingBridgesForContainerNetworkingMet
 	c.Assert(err, gc.ErrorMatches, `host machine "0" has no available FAN devices in space\(s\) "default"`)
 }
 
+func (s *bridgePolicyStateSuite) TestFindMissingBridgesForContainerNetworkingMethodMACVLAN(c *gc.C) {
+	s.setupTwoSpaces(c)
+	s.createNICWithIP(c, s.machine, "eth0", "10.0.0.20/24")
+	s.addContainerMachine(c)
+	err := s.containerMachine.SetConstraints(constraints.Value{
+		Spaces: &[]string{"default"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	bridgePolicy := &containerizer.BridgePolicy{
+		NetBondReconfigureDelay:   13,
+		ContainerNetworkingMethod: "macvlan",
+	}
+	missing, reconfigureDelay, err := bridgePolicy.FindMissingBridgesForContainer(s.machine, s.containerMachine)
+	c.Assert(err, jc.ErrorIsNil)
+	// Nothing gets bridged, the container uses eth0 directly.
+	c.Check(missing, gc.HasLen, 0)
+	c.Check(reconfigureDelay, gc.Equals, 0)
+}
+
+func (s *bridgePolicyStateSuite) TestFindMissingBridgesForContainerNetworkingMethodMACVLANMissingSpace(c *gc.C) {
+	s.setupTwoSpaces(c)
+	s.createNICWithIP(c, s.machine, "eth0", "10.0.0.20/24")
+	s.addContainerMachine(c)
+	err := s.containerMachine.SetConstraints(constraints.Value{
+		Spaces: &[]string{"default", "dmz"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	bridgePolicy := &containerizer.BridgePolicy{
+		ContainerNetworkingMethod: "macvlan",
+	}
+	_, _, err = bridgePolicy.FindMissingBridgesForContainer(s.machine, s.containerMachine)
+	c.Assert(err, gc.ErrorMatches, `host machine "0" has no available device in space\(s\) "dmz"`)
+}
+
+func (s *bridgePolicyStateSuite) TestPopulateContainerLinkLayerDevicesNetworkingMethodIPVLAN(c *gc.C) {
+	s.setupTwoSpaces(c)
+	s.createNICWithIP(c, s.machine, "eth0", "10.0.0.20/24")
+	s.createNICWithIP(c, s.machine, "eth1", "10.10.0.20/24")
+	s.addContainerMachine(c)
+	err := s.containerMachine.SetConstraints(constraints.Value{
+		Spaces: &[]string{"default", "dmz"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	bridgePolicy := &containerizer.BridgePolicy{
+		ContainerNetworkingMethod: "ipvlan",
+	}
+	c.Check(bridgePolicy.NICType(), gc.Equals, network.IPVLANNIC)
+
+	err = bridgePolicy.PopulateContainerLinkLayerDevices(s.machine, s.containerMachine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	containerDevices, err := s.containerMachine.AllLinkLayerDevices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(containerDevices, gc.HasLen, 2)
+	parents := make(map[string]string)
+	for _, device := range containerDevices {
+		c.Check(device.Type(), gc.Equals, state.EthernetDevice)
+		parents[device.Name()] = device.ParentName()
+	}
+	c.Check(parents, jc.DeepEquals, map[string]string{
+		"eth0": "m#0#d#eth0",
+		"eth1": "m#0#d#eth1",
+	})
+}
+
+func (s *bridgePolicyStateSuite) TestPopulateContainerLinkLayerDevicesKVMOnlyMACVLAN(c *gc.C) {
+	s.setupTwoSpaces(c)
+	s.createNICWithIP(c, s.machine, "eth0", "10.0.0.20/24")
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.machine.Id(), instance.KVM)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, method := range []string{"ipvlan", "sriov"} {
+		bridgePolicy := &containerizer.BridgePolicy{ContainerNetworkingMethod: method}
+		err = bridgePolicy.PopulateContainerLinkLayerDevices(s.machine, container)
+		c.Check(err, jc.Satisfies, errors.IsNotSupported)
+		c.Check(err, gc.ErrorMatches, `container networking method "`+method+`" for KVM container "0/kvm/0" not supported`)
+	}
+	s.assertNoDevicesOnMachine(c, container)
+
+	bridgePolicy := &containerizer.BridgePolicy{ContainerNetworkingMethod: "macvlan"}
+	err = bridgePolicy.PopulateContainerLinkLayerDevices(s.machine, container)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAllLinkLayerDevicesOnMachineMatchCount(c, container, 1)
+}
+
+func (s *bridgePolicyStateSuite) TestNICType(c *gc.C) {
+	for method, nicType := range map[string]network.NICType{
+		"":         network.BridgedNIC,
+		"local":    network.BridgedNIC,
+		"provider": network.BridgedNIC,
+		"fan":      network.BridgedNIC,
+		"macvlan":  network.MACVLANNIC,
+		"ipvlan":   network.IPVLANNIC,
+		"sriov":    network.SRIOVNIC,
+	} {
+		bridgePolicy := &containerizer.BridgePolicy{ContainerNetworkingMethod: method}
+		c.Check(bridgePolicy.NICType(), gc.Equals, nicType, gc.Commentf("method %q", method))
+	}
+}
+
 var bridgeNames = map[string]string{
 	"eno0":            "br-eno0",
 	"twelvechars0":    "br-twelvechars0",
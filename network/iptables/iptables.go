@@ -145,6 +145,95 @@ func (c IngressRuleCommand) render(commandFlag string) string {
 	return strings.Join(args, " ")
 }
 
+// egressChain is the iptables chain holding the rules that restrict
+// outbound traffic.
+const egressChain = "juju-egress"
+
+// EgressRulesCommand represents the iptables commands that restrict
+// outbound traffic to the given destination CIDRs. Loopback traffic,
+// replies to established connections, DHCP, DNS queries to the given
+// nameservers and requests to the cloud metadata service are always
+// allowed. IPv6 CIDRs and nameservers are applied with ip6tables.
+type EgressRulesCommand struct {
+	DestinationCIDRs []string
+	Nameservers      []string
+	Delete           bool
+}
+
+// metadataServiceCIDR is the link-local address of the metadata
+// service of most clouds.
+const metadataServiceCIDR = "169.254.169.254/32"
+
+// Render renders the commands to a string which can be executed via
+// bash in order to install the iptables rules, or remove them if
+// Delete is true.
+func (c EgressRulesCommand) Render() string {
+	v4CIDRs, v6CIDRs := splitIPv6(c.DestinationCIDRs)
+	v4Nameservers, v6Nameservers := splitIPv6(c.Nameservers)
+	cmds := c.render("iptables", v4CIDRs, v4Nameservers)
+	cmds = append(cmds, c.render("ip6tables", v6CIDRs, v6Nameservers)...)
+	return strings.Join(cmds, "\n")
+}
+
+// splitIPv6 splits the given addresses or CIDRs into those for IPv4
+// and those for IPv6.
+func splitIPv6(values []string) (v4, v6 []string) {
+	for _, value := range values {
+		if strings.Contains(value, ":") {
+			v6 = append(v6, value)
+		} else {
+			v4 = append(v4, value)
+		}
+	}
+	return v4, v6
+}
+
+func (c EgressRulesCommand) render(command string, cidrs, nameservers []string) []string {
+	iptables := func(args ...string) string {
+		return strings.Join(append([]string{"sudo", command}, args...), " ")
+	}
+	jump := []string{"OUTPUT", "-j", egressChain}
+	checkJump := iptables(append([]string{"-C"}, jump...)...)
+	if c.Delete {
+		return []string{
+			fmt.Sprintf("(%s) && (%s)", checkJump, iptables(append([]string{"-D"}, jump...)...)),
+			fmt.Sprintf("(%s && %s) || true", iptables("-F", egressChain), iptables("-X", egressChain)),
+		}
+	}
+	cmds := []string{
+		fmt.Sprintf("(%s) || (%s)", iptables("-N", egressChain), iptables("-F", egressChain)),
+		iptables("-A", egressChain, "-o lo", "-j ACCEPT"),
+		iptables("-A", egressChain, "-m state --state ESTABLISHED,RELATED", "-j ACCEPT"),
+	}
+	if command == "ip6tables" {
+		// Neighbour discovery and router advertisements are needed
+		// for IPv6 to work at all.
+		cmds = append(cmds,
+			iptables("-A", egressChain, "-p ipv6-icmp", "-j ACCEPT"),
+			iptables("-A", egressChain, "-p udp --dport 547", "-j ACCEPT"),
+		)
+	} else {
+		cmds = append(cmds,
+			iptables("-A", egressChain, "-p udp --dport 67", "-j ACCEPT"),
+			iptables("-A", egressChain, "-d", metadataServiceCIDR, "-j ACCEPT"),
+		)
+	}
+	for _, nameserver := range nameservers {
+		cmds = append(cmds,
+			iptables("-A", egressChain, "-d", nameserver, "-p udp --dport 53", "-j ACCEPT"),
+			iptables("-A", egressChain, "-d", nameserver, "-p tcp --dport 53", "-j ACCEPT"),
+		)
+	}
+	for _, cidr := range cidrs {
+		cmds = append(cmds, iptables("-A", egressChain, "-d", cidr, "-j ACCEPT"))
+	}
+	cmds = append(cmds,
+		iptables("-A", egressChain, "-j REJECT"),
+		fmt.Sprintf("(%s) || (%s)", checkJump, iptables(append([]string{"-I"}, jump...)...)),
+	)
+	return cmds
+}
+
 // ParseIngressRules parses the output of "iptables -L INPUT -n",
 // extracting previously added ingress rules, as rendered by
 // IngressRuleCommand.
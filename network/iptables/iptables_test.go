@@ -119,6 +119,48 @@ func (*IptablesSuite) TestIngressRuleCommand(c *gc.C) {
 	)
 }
 
+func (*IptablesSuite) TestEgressRulesCommand(c *gc.C) {
+	assertRender(c,
+		iptables.EgressRulesCommand{
+			DestinationCIDRs: []string{"10.0.0.1/32", "2001:db8::1/128", "192.168.0.0/24"},
+			Nameservers:      []string{"10.0.0.2", "2001:db8::53"},
+		},
+		strings.Join([]string{
+			"(sudo iptables -N juju-egress) || (sudo iptables -F juju-egress)",
+			"sudo iptables -A juju-egress -o lo -j ACCEPT",
+			"sudo iptables -A juju-egress -m state --state ESTABLISHED,RELATED -j ACCEPT",
+			"sudo iptables -A juju-egress -p udp --dport 67 -j ACCEPT",
+			"sudo iptables -A juju-egress -d 169.254.169.254/32 -j ACCEPT",
+			"sudo iptables -A juju-egress -d 10.0.0.2 -p udp --dport 53 -j ACCEPT",
+			"sudo iptables -A juju-egress -d 10.0.0.2 -p tcp --dport 53 -j ACCEPT",
+			"sudo iptables -A juju-egress -d 10.0.0.1/32 -j ACCEPT",
+			"sudo iptables -A juju-egress -d 192.168.0.0/24 -j ACCEPT",
+			"sudo iptables -A juju-egress -j REJECT",
+			"(sudo iptables -C OUTPUT -j juju-egress) || (sudo iptables -I OUTPUT -j juju-egress)",
+			"(sudo ip6tables -N juju-egress) || (sudo ip6tables -F juju-egress)",
+			"sudo ip6tables -A juju-egress -o lo -j ACCEPT",
+			"sudo ip6tables -A juju-egress -m state --state ESTABLISHED,RELATED -j ACCEPT",
+			"sudo ip6tables -A juju-egress -p ipv6-icmp -j ACCEPT",
+			"sudo ip6tables -A juju-egress -p udp --dport 547 -j ACCEPT",
+			"sudo ip6tables -A juju-egress -d 2001:db8::53 -p udp --dport 53 -j ACCEPT",
+			"sudo ip6tables -A juju-egress -d 2001:db8::53 -p tcp --dport 53 -j ACCEPT",
+			"sudo ip6tables -A juju-egress -d 2001:db8::1/128 -j ACCEPT",
+			"sudo ip6tables -A juju-egress -j REJECT",
+			"(sudo ip6tables -C OUTPUT -j juju-egress) || (sudo ip6tables -I OUTPUT -j juju-egress)",
+		}, "\n"),
+	)
+
+	assertRender(c,
+		iptables.EgressRulesCommand{Delete: true},
+		strings.Join([]string{
+			"(sudo iptables -C OUTPUT -j juju-egress) && (sudo iptables -D OUTPUT -j juju-egress)",
+			"(sudo iptables -F juju-egress && sudo iptables -X juju-egress) || true",
+			"(sudo ip6tables -C OUTPUT -j juju-egress) && (sudo ip6tables -D OUTPUT -j juju-egress)",
+			"(sudo ip6tables -F juju-egress && sudo ip6tables -X juju-egress) || true",
+		}, "\n"),
+	)
+}
+
 func (*IptablesSuite) TestParseIngressRulesEmpty(c *gc.C) {
 	assertParseIngressRules(c, ``, []network.IngressRule{})
 }
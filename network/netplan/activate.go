@@ -14,10 +14,14 @@ import (
 
 var logger = loggo.GetLogger("juju.network.netplan")
 
-// ActivationParams contains options to use when bridging interfaces
+// ActivationParams contains options to use when bridging interfaces,
+// or adding bonds, VLANs and routes.
 type ActivationParams struct {
 	Clock     clock.Clock
 	Devices   []DeviceToBridge
+	Bonds     []DeviceToBond
+	VLANs     []DeviceToVLAN
+	Routes    []DeviceRoutes
 	RunPrefix string
 	Directory string
 	Timeout   time.Duration
@@ -39,13 +43,51 @@ func BridgeAndActivate(params ActivationParams) (*ActivationResult, error) {
 	if len(params.Devices) == 0 {
 		return nil, errors.Errorf("no devices specified")
 	}
+	return configureAndActivate(params, "bridge")
+}
+
+// ConfigureAndActivate will parse a set of netplan yaml files in a
+// directory, create a new netplan config with the provided bonds, VLANs,
+// bridges and routes added, in that order, then apply it. If applying
+// the new config fails, the original config is restored.
+func ConfigureAndActivate(params ActivationParams) (*ActivationResult, error) {
+	if len(params.Devices) == 0 && len(params.Bonds) == 0 &&
+		len(params.VLANs) == 0 && len(params.Routes) == 0 {
+		return nil, errors.Errorf("no changes specified")
+	}
+	return configureAndActivate(params, "network")
+}
 
+func configureAndActivate(params ActivationParams, kind string) (*ActivationResult, error) {
 	netplan, err := ReadDirectory(params.Directory)
 
 	if err != nil {
 		return nil, err
 	}
 
+	for _, bond := range params.Bonds {
+		deviceIds := make([]string, len(bond.DeviceNames))
+		for i, name := range bond.DeviceNames {
+			deviceIds[i], err = netplan.FindEthernetByName(name)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := netplan.AddBond(bond.BondName, deviceIds, bond.Parameters); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	for _, vlan := range params.VLANs {
+		linkId, err := netplan.findVLANParentByNameOrMAC(vlan.ParentName, vlan.ParentMACAddress)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := netplan.AddVLAN(vlan.VLANName, linkId, vlan.VLANTag); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	for _, device := range params.Devices {
 		var deviceId string
 		deviceId, deviceType, err := netplan.FindDeviceByNameOrMAC(device.DeviceName, device.MACAddress)
@@ -72,6 +114,16 @@ func BridgeAndActivate(params ActivationParams) (*ActivationResult, error) {
 			return nil, errors.Errorf("unable to create bridge for %q, unknown device type %q", deviceId, deviceType)
 		}
 	}
+	for _, routes := range params.Routes {
+		deviceId, err := netplan.findLinkByNameOrMAC(routes.DeviceName, routes.MACAddress)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := netplan.AddRoutes(deviceId, routes.Routes); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	_, err = netplan.Write("")
 	if err != nil {
 		return nil, err
@@ -100,11 +152,11 @@ func BridgeAndActivate(params ActivationParams) (*ActivationResult, error) {
 
 	if err != nil {
 		netplan.Rollback()
-		return &activationResult, errors.Errorf("bridge activation error: %s", err)
+		return &activationResult, errors.Errorf("%s activation error: %s", kind, err)
 	}
 	if result.Code != 0 {
 		netplan.Rollback()
-		return &activationResult, errors.Errorf("bridge activation error code %d", result.Code)
+		return &activationResult, errors.Errorf("%s activation error code %d", kind, result.Code)
 	}
 	return nil, nil
 }
@@ -105,6 +105,99 @@ func (s *ActivateSuite) TestActivateDeviceAndVLAN(c *gc.C) {
 	c.Check(err, jc.ErrorIsNil)
 }
 
+func (s *ActivateSuite) TestConfigureNoChanges(c *gc.C) {
+	params := netplan.ActivationParams{}
+	result, err := netplan.ConfigureAndActivate(params)
+	c.Check(result, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "no changes specified")
+}
+
+func (s *ActivateSuite) TestConfigureVLANAndRoutes(c *gc.C) {
+	coretesting.SkipIfWindowsBug(c, "lp:1771077")
+	tempDir := c.MkDir()
+	params := netplan.ActivationParams{
+		VLANs: []netplan.DeviceToVLAN{{
+			VLANName:         "eno1.42",
+			ParentMACAddress: "00:11:22:33:44:55",
+			VLANTag:          42,
+		}},
+		Devices: []netplan.DeviceToBridge{{
+			DeviceName: "eno1.42",
+			BridgeName: "br-eno1.42",
+		}},
+		Routes: []netplan.DeviceRoutes{{
+			DeviceName: "eno1.42",
+			Routes:     []netplan.Route{{To: "10.10.0.0/16", Via: "10.0.42.1"}},
+		}},
+		Directory: tempDir,
+		RunPrefix: "exit 0 &&",
+	}
+	content, err := ioutil.ReadFile(path.Join("testdata/TestReadWriteBackup", "00.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path.Join(tempDir, "00.yaml"), content, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := netplan.ConfigureAndActivate(params)
+	c.Check(result, gc.IsNil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	np, err := netplan.ReadDirectory(tempDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(np.Network.VLANs["eno1.42"].Link, gc.Equals, "eno1")
+	bridge, ok := np.Network.Bridges["br-eno1.42"]
+	c.Assert(ok, jc.IsTrue)
+	c.Check(bridge.Interfaces, jc.DeepEquals, []string{"eno1.42"})
+	c.Check(bridge.Routes, jc.DeepEquals, []netplan.Route{{To: "10.10.0.0/16", Via: "10.0.42.1"}})
+}
+
+func (s *ActivateSuite) TestConfigureUnknownParent(c *gc.C) {
+	tempDir := c.MkDir()
+	params := netplan.ActivationParams{
+		VLANs: []netplan.DeviceToVLAN{{
+			VLANName:   "eno9.42",
+			ParentName: "eno9",
+			VLANTag:    42,
+		}},
+		Directory: tempDir,
+	}
+	content, err := ioutil.ReadFile(path.Join("testdata/TestReadWriteBackup", "00.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path.Join(tempDir, "00.yaml"), content, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := netplan.ConfigureAndActivate(params)
+	c.Check(result, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, `VLAN parent device - name "eno9" MAC "" not found`)
+}
+
+func (s *ActivateSuite) TestConfigureFailure(c *gc.C) {
+	coretesting.SkipIfWindowsBug(c, "lp:1771077")
+	tempDir := c.MkDir()
+	params := netplan.ActivationParams{
+		VLANs: []netplan.DeviceToVLAN{{
+			VLANName:   "eno1.42",
+			ParentName: "eno1",
+			VLANTag:    42,
+		}},
+		Directory: tempDir,
+		RunPrefix: "exit 1 &&",
+	}
+	content, err := ioutil.ReadFile(path.Join("testdata/TestReadWriteBackup", "00.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path.Join(tempDir, "00.yaml"), content, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := netplan.ConfigureAndActivate(params)
+	c.Assert(result, gc.NotNil)
+	c.Check(result.Code, gc.Equals, 1)
+	c.Check(err, gc.ErrorMatches, "network activation error code 1")
+
+	// The old file is in place and unchanged.
+	restored, err := ioutil.ReadFile(path.Join(tempDir, "00.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(restored), gc.Equals, string(content))
+}
+
 func (s *ActivateSuite) TestActivateFailure(c *gc.C) {
 	coretesting.SkipIfWindowsBug(c, "lp:1771077")
 	tempDir := c.MkDir()
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"strings"
 	"time"
@@ -283,6 +284,178 @@ func (np *Netplan) createBridgeFromInterface(bridgeName, deviceId string, intf *
 	*intf = Interface{MTU: intf.MTU}
 }
 
+// AddBond creates a bond with the given name aggregating the ethernet
+// devices with the given ids. Like createBridgeFromInterface, the bond
+// takes over the devices' address details, which are wiped from the
+// devices except for MTU so that they are only configured on the bond.
+// Devices with differing address details can't be bonded. Adding a bond
+// which already exists with the same devices is a no-op.
+func (np *Netplan) AddBond(bondName string, deviceIds []string, parameters BondParameters) error {
+	if len(deviceIds) == 0 {
+		return errors.NotValidf("bond %q with no devices", bondName)
+	}
+	if bond, ok := np.Network.Bonds[bondName]; ok {
+		if strings.Join(bond.Interfaces, ",") == strings.Join(deviceIds, ",") {
+			return nil
+		}
+		return errors.AlreadyExistsf(
+			"cannot create bond %q with devices %q - bond %q w/ interfaces %q",
+			bondName, strings.Join(deviceIds, ", "), bondName, strings.Join(bond.Interfaces, ", "))
+	}
+	var bondIntf Interface
+	var configuredId string
+	for _, deviceId := range deviceIds {
+		ethernet, ok := np.Network.Ethernets[deviceId]
+		if !ok {
+			return errors.NotFoundf("ethernet device with id %q for bond %q", deviceId, bondName)
+		}
+		if owner := np.deviceOwner(deviceId); owner != "" {
+			return errors.AlreadyExistsf("cannot create bond %q, device %q in %q", bondName, deviceId, owner)
+		}
+		if !hasAddressDetails(ethernet.Interface) {
+			continue
+		}
+		if configuredId == "" {
+			bondIntf, configuredId = ethernet.Interface, deviceId
+		} else if !reflect.DeepEqual(addressDetails(ethernet.Interface), addressDetails(bondIntf)) {
+			return errors.NotValidf(
+				"bond %q of devices %q and %q with different address details",
+				bondName, configuredId, deviceId)
+		}
+	}
+	for _, deviceId := range deviceIds {
+		ethernet := np.Network.Ethernets[deviceId]
+		if bondIntf.MTU == 0 {
+			bondIntf.MTU = ethernet.MTU
+		}
+		ethernet.Interface = Interface{MTU: ethernet.MTU}
+		np.Network.Ethernets[deviceId] = ethernet
+	}
+	if np.Network.Bonds == nil {
+		np.Network.Bonds = make(map[string]Bond)
+	}
+	np.Network.Bonds[bondName] = Bond{
+		Interfaces: deviceIds,
+		Interface:  bondIntf,
+		Parameters: parameters,
+	}
+	return nil
+}
+
+// addressDetails returns the interface without the details that stay
+// with a device when its addresses move to a bond or bridge.
+func addressDetails(intf Interface) Interface {
+	intf.MTU = 0
+	return intf
+}
+
+// hasAddressDetails returns whether the interface has any details that
+// would move to a bond or bridge.
+func hasAddressDetails(intf Interface) bool {
+	return !reflect.DeepEqual(addressDetails(intf), Interface{})
+}
+
+// AddVLAN creates a VLAN device with the given name and tag on top of the
+// ethernet, bond or bridge with the given link id. Adding a VLAN which
+// already exists with the same tag and link is a no-op.
+func (np *Netplan) AddVLAN(vlanName, linkId string, tag int) error {
+	if tag < 1 || tag > 4094 {
+		return errors.NotValidf("VLAN tag %d for %q", tag, vlanName)
+	}
+	if vlan, ok := np.Network.VLANs[vlanName]; ok {
+		if vlan.Id != nil && *vlan.Id == tag && vlan.Link == linkId {
+			return nil
+		}
+		return errors.AlreadyExistsf("VLAN %q with a different tag or link", vlanName)
+	}
+	_, isEthernet := np.Network.Ethernets[linkId]
+	_, isBond := np.Network.Bonds[linkId]
+	_, isBridge := np.Network.Bridges[linkId]
+	if !isEthernet && !isBond && !isBridge {
+		return errors.NotFoundf("device with id %q for VLAN %q", linkId, vlanName)
+	}
+	if np.Network.VLANs == nil {
+		np.Network.VLANs = make(map[string]VLAN)
+	}
+	np.Network.VLANs[vlanName] = VLAN{
+		Id:   &tag,
+		Link: linkId,
+	}
+	return nil
+}
+
+// AddRoutes adds the given routes to the device with the given id, skipping
+// any the device already has. If the device is a member of a bridge, the
+// routes are added to the bridge, which holds its address details.
+func (np *Netplan) AddRoutes(deviceId string, routes []Route) error {
+	if bridgeName, ok := np.bridgeOf(deviceId); ok {
+		deviceId = bridgeName
+	}
+	if ethernet, ok := np.Network.Ethernets[deviceId]; ok {
+		ethernet.Routes = mergeRoutes(ethernet.Routes, routes)
+		np.Network.Ethernets[deviceId] = ethernet
+	} else if vlan, ok := np.Network.VLANs[deviceId]; ok {
+		vlan.Routes = mergeRoutes(vlan.Routes, routes)
+		np.Network.VLANs[deviceId] = vlan
+	} else if bond, ok := np.Network.Bonds[deviceId]; ok {
+		bond.Routes = mergeRoutes(bond.Routes, routes)
+		np.Network.Bonds[deviceId] = bond
+	} else if bridge, ok := np.Network.Bridges[deviceId]; ok {
+		bridge.Routes = mergeRoutes(bridge.Routes, routes)
+		np.Network.Bridges[deviceId] = bridge
+	} else {
+		return errors.NotFoundf("device with id %q for routes", deviceId)
+	}
+	return nil
+}
+
+// mergeRoutes returns existing with the routes in extra it doesn't
+// already have appended.
+func mergeRoutes(existing, extra []Route) []Route {
+	for _, route := range extra {
+		found := false
+		for _, other := range existing {
+			if route.To == other.To && route.Via == other.Via {
+				found = true
+				break
+			}
+		}
+		if !found {
+			existing = append(existing, route)
+		}
+	}
+	return existing
+}
+
+// bridgeOf returns the name of the bridge the device with the given id
+// is a member of, if any.
+func (np *Netplan) bridgeOf(deviceId string) (string, bool) {
+	for bridgeName, bridge := range np.Network.Bridges {
+		for _, i := range bridge.Interfaces {
+			if i == deviceId {
+				return bridgeName, true
+			}
+		}
+	}
+	return "", false
+}
+
+// deviceOwner returns the name of the bond or bridge the device with the
+// given id is a member of, or an empty string if it is not a member of one.
+func (np *Netplan) deviceOwner(deviceId string) string {
+	if bridgeName, ok := np.bridgeOf(deviceId); ok {
+		return bridgeName
+	}
+	for bondName, bond := range np.Network.Bonds {
+		for _, i := range bond.Interfaces {
+			if i == deviceId {
+				return bondName
+			}
+		}
+	}
+	return ""
+}
+
 func (np *Netplan) merge(other *Netplan) {
 	// Only copy attributes that would be unmarshalled from yaml.
 	// This blithely replaces keys in the maps (eg. Ethernets or
@@ -588,3 +761,41 @@ func (np *Netplan) FindDeviceByNameOrMAC(name, mac string) (string, DeviceType,
 	}
 	return "", "", errors.NotFoundf("device - name %q MAC %q", name, mac)
 }
+
+// findLinkByNameOrMAC will look for a bridge matching the name of the
+// device, falling back to an ethernet, VLAN or bond matching its name or
+// MAC address.
+func (np *Netplan) findLinkByNameOrMAC(name, mac string) (string, error) {
+	if _, ok := np.Network.Bridges[name]; ok && name != "" {
+		return name, nil
+	}
+	deviceId, _, err := np.FindDeviceByNameOrMAC(name, mac)
+	return deviceId, errors.Trace(err)
+}
+
+// findVLANParentByNameOrMAC will look for a bridge, bond or ethernet
+// matching the name of the device, or a bond or ethernet matching its MAC
+// address. VLANs are never considered, since they share the MAC address
+// of their parent device.
+func (np *Netplan) findVLANParentByNameOrMAC(name, mac string) (string, error) {
+	if name != "" {
+		if _, ok := np.Network.Bridges[name]; ok {
+			return name, nil
+		}
+		if bond, err := np.FindBondByName(name); err == nil {
+			return bond, nil
+		}
+		if ethernet, err := np.FindEthernetByName(name); err == nil {
+			return ethernet, nil
+		}
+	}
+	if mac != "" {
+		if bond, err := np.FindBondByMAC(mac); err == nil {
+			return bond, nil
+		}
+		if ethernet, err := np.FindEthernetByMAC(mac); err == nil {
+			return ethernet, nil
+		}
+	}
+	return "", errors.NotFoundf("VLAN parent device - name %q MAC %q", name, mac)
+}
@@ -916,6 +916,246 @@ network:
 	c.Check(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *NetplanSuite) TestAddBond(c *gc.C) {
+	np := MustNetplanFromYaml(c, `
+network:
+  version: 2
+  renderer: networkd
+  ethernets:
+    eno1:
+      match:
+        macaddress: "00:11:22:33:44:55"
+      dhcp4: true
+      mtu: 9000
+    eno2:
+      match:
+        macaddress: "00:11:22:33:44:56"
+`)
+	expected := `
+network:
+  version: 2
+  renderer: networkd
+  ethernets:
+    eno1:
+      match:
+        macaddress: "00:11:22:33:44:55"
+      mtu: 9000
+    eno2:
+      match:
+        macaddress: "00:11:22:33:44:56"
+  bonds:
+    bond0:
+      interfaces: [eno1, eno2]
+      dhcp4: true
+      mtu: 9000
+      parameters:
+        mode: 802.3ad
+`[1:]
+	mode := "802.3ad"
+	parameters := netplan.BondParameters{Mode: netplan.IntString{String: &mode}}
+	err := np.AddBond("bond0", []string{"eno1", "eno2"}, parameters)
+	c.Assert(err, jc.ErrorIsNil)
+	// Adding the same bond again is a no-op.
+	err = np.AddBond("bond0", []string{"eno1", "eno2"}, parameters)
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := netplan.Marshal(np)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), gc.Equals, expected)
+
+	err = np.AddBond("bond0", []string{"eno1"}, parameters)
+	c.Check(err, gc.ErrorMatches, `cannot create bond "bond0" with devices "eno1" - bond "bond0" w/ interfaces "eno1, eno2" already exists`)
+	err = np.AddBond("bond1", []string{"eno2"}, parameters)
+	c.Check(err, gc.ErrorMatches, `cannot create bond "bond1", device "eno2" in "bond0" already exists`)
+	err = np.AddBond("bond1", []string{"eno3"}, parameters)
+	c.Check(err, gc.ErrorMatches, `ethernet device with id "eno3" for bond "bond1" not found`)
+}
+
+func (s *NetplanSuite) TestAddBondMovesAddressDetails(c *gc.C) {
+	np := MustNetplanFromYaml(c, `
+network:
+  version: 2
+  renderer: networkd
+  ethernets:
+    eno1:
+      match:
+        macaddress: "00:11:22:33:44:55"
+    eno2:
+      match:
+        macaddress: "00:11:22:33:44:56"
+      addresses:
+      - 10.0.0.5/24
+      gateway4: 10.0.0.1
+      nameservers:
+        search: [maas]
+        addresses: [8.8.8.8]
+      mtu: 1500
+`)
+	expected := `
+network:
+  version: 2
+  renderer: networkd
+  ethernets:
+    eno1:
+      match:
+        macaddress: "00:11:22:33:44:55"
+    eno2:
+      match:
+        macaddress: "00:11:22:33:44:56"
+      mtu: 1500
+  bonds:
+    bond0:
+      interfaces: [eno1, eno2]
+      addresses:
+      - 10.0.0.5/24
+      gateway4: 10.0.0.1
+      nameservers:
+        search: [maas]
+        addresses: [8.8.8.8]
+      mtu: 1500
+`[1:]
+	err := np.AddBond("bond0", []string{"eno1", "eno2"}, netplan.BondParameters{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := netplan.Marshal(np)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), gc.Equals, expected)
+}
+
+func (s *NetplanSuite) TestAddBondDifferentAddressDetails(c *gc.C) {
+	np := MustNetplanFromYaml(c, `
+network:
+  version: 2
+  renderer: networkd
+  ethernets:
+    eno1:
+      match:
+        macaddress: "00:11:22:33:44:55"
+      addresses:
+      - 10.0.0.5/24
+    eno2:
+      match:
+        macaddress: "00:11:22:33:44:56"
+      dhcp4: true
+`)
+	err := np.AddBond("bond0", []string{"eno1", "eno2"}, netplan.BondParameters{})
+	c.Assert(err, gc.ErrorMatches, `bond "bond0" of devices "eno1" and "eno2" with different address details not valid`)
+	c.Assert(np.Network.Bonds, gc.HasLen, 0)
+	c.Assert(np.Network.Ethernets["eno1"].Addresses, jc.DeepEquals, []string{"10.0.0.5/24"})
+}
+
+func (s *NetplanSuite) TestAddVLAN(c *gc.C) {
+	np := MustNetplanFromYaml(c, `
+network:
+  version: 2
+  renderer: networkd
+  ethernets:
+    eno1:
+      match:
+        macaddress: "00:11:22:33:44:55"
+      dhcp4: true
+`)
+	expected := `
+network:
+  version: 2
+  renderer: networkd
+  ethernets:
+    eno1:
+      match:
+        macaddress: "00:11:22:33:44:55"
+      dhcp4: true
+  vlans:
+    eno1.42:
+      id: 42
+      link: eno1
+`[1:]
+	err := np.AddVLAN("eno1.42", "eno1", 42)
+	c.Assert(err, jc.ErrorIsNil)
+	// Adding the same VLAN again is a no-op.
+	err = np.AddVLAN("eno1.42", "eno1", 42)
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := netplan.Marshal(np)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), gc.Equals, expected)
+
+	err = np.AddVLAN("eno1.42", "eno1", 43)
+	c.Check(err, gc.ErrorMatches, `VLAN "eno1.42" with a different tag or link already exists`)
+	err = np.AddVLAN("eno1.4095", "eno1", 4095)
+	c.Check(err, gc.ErrorMatches, `VLAN tag 4095 for "eno1.4095" not valid`)
+	err = np.AddVLAN("eno2.42", "eno2", 42)
+	c.Check(err, gc.ErrorMatches, `device with id "eno2" for VLAN "eno2.42" not found`)
+}
+
+func (s *NetplanSuite) TestAddRoutes(c *gc.C) {
+	np := MustNetplanFromYaml(c, `
+network:
+  version: 2
+  renderer: networkd
+  ethernets:
+    eno1:
+      match:
+        macaddress: "00:11:22:33:44:55"
+    eno2:
+      match:
+        macaddress: "00:11:22:33:44:56"
+      addresses:
+      - 10.0.0.2/24
+      routes:
+      - to: 10.10.0.0/16
+        via: 10.0.0.1
+  bridges:
+    br-eno1:
+      interfaces: [eno1]
+      addresses:
+      - 1.2.3.4/24
+`)
+	expected := `
+network:
+  version: 2
+  renderer: networkd
+  ethernets:
+    eno1:
+      match:
+        macaddress: "00:11:22:33:44:55"
+    eno2:
+      match:
+        macaddress: "00:11:22:33:44:56"
+      addresses:
+      - 10.0.0.2/24
+      routes:
+      - to: 10.10.0.0/16
+        via: 10.0.0.1
+      - to: 10.20.0.0/16
+        via: 10.0.0.1
+        metric: 100
+  bridges:
+    br-eno1:
+      interfaces: [eno1]
+      addresses:
+      - 1.2.3.4/24
+      routes:
+      - to: 100.0.0.0/8
+        via: 1.2.3.10
+`[1:]
+	metric := 100
+	err := np.AddRoutes("eno2", []netplan.Route{
+		{To: "10.10.0.0/16", Via: "10.0.0.1"},
+		{To: "10.20.0.0/16", Via: "10.0.0.1", Metric: &metric},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	// Routes for a bridged device are added to the bridge.
+	err = np.AddRoutes("eno1", []netplan.Route{{To: "100.0.0.0/8", Via: "1.2.3.10"}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := netplan.Marshal(np)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), gc.Equals, expected)
+
+	err = np.AddRoutes("eno3", []netplan.Route{{To: "100.0.0.0/8", Via: "1.2.3.10"}})
+	c.Check(err, gc.ErrorMatches, `device with id "eno3" for routes not found`)
+}
+
 func (s *NetplanSuite) TestFindEthernetByName(c *gc.C) {
 	np := MustNetplanFromYaml(c, `
 network:
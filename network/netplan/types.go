@@ -13,3 +13,46 @@ type DeviceToBridge struct {
 	// MACAddress is the MAC address of the device to be bridged
 	MACAddress string
 }
+
+// DeviceToBond gives the information about a bond that should be created
+// from devices on the machine.
+type DeviceToBond struct {
+	// BondName is the name of the bond that we want created.
+	BondName string
+
+	// DeviceNames are the names of the devices to be aggregated.
+	DeviceNames []string
+
+	// Parameters holds the bonding parameters, such as the mode.
+	Parameters BondParameters
+}
+
+// DeviceToVLAN gives the information about a VLAN device that should be
+// created on top of a device on the machine.
+type DeviceToVLAN struct {
+	// VLANName is the name of the VLAN device that we want created.
+	VLANName string
+
+	// ParentName is the name of the device the VLAN is created on.
+	ParentName string
+
+	// ParentMACAddress is the MAC address of the device the VLAN is
+	// created on.
+	ParentMACAddress string
+
+	// VLANTag is the 802.1Q tag of the VLAN, between 1 and 4094.
+	VLANTag int
+}
+
+// DeviceRoutes gives the static routes that should be configured on a
+// particular device.
+type DeviceRoutes struct {
+	// DeviceName is the name of the device on the machine.
+	DeviceName string
+
+	// MACAddress is the MAC address of the device.
+	MACAddress string
+
+	// Routes are the routes to configure.
+	Routes []Route
+}
@@ -165,6 +165,25 @@ const (
 	BridgeInterface     InterfaceType = "bridge"
 )
 
+// NICType defines how a container network interface is attached to its
+// parent device on the host machine.
+type NICType string
+
+const (
+	// BridgedNIC is attached to a bridge on the host. It is the empty
+	// value for compatibility with agents that do not know about NIC
+	// types.
+	BridgedNIC NICType = ""
+	// MACVLANNIC is a macvlan interface on the parent device. Traffic
+	// between a macvlan interface and its parent device's host is not
+	// delivered, so such containers cannot talk to their host machine.
+	MACVLANNIC NICType = "macvlan"
+	// IPVLANNIC is an ipvlan interface on the parent device.
+	IPVLANNIC NICType = "ipvlan"
+	// SRIOVNIC is an SR-IOV virtual function of the parent device.
+	SRIOVNIC NICType = "sriov"
+)
+
 // InterfaceInfo describes a single network interface available on an
 // instance. For providers that support networks, this will be
 // available at StartInstance() time.
@@ -221,6 +240,10 @@ type InterfaceInfo struct {
 	// InterfaceType is the type of the interface.
 	InterfaceType InterfaceType
 
+	// NICType defines how the interface is attached to its parent
+	// device, when the interface belongs to a container.
+	NICType NICType
+
 	// Disabled is true when the interface needs to be disabled on the
 	// machine, e.g. not to configure it.
 	Disabled bool
@@ -635,6 +658,28 @@ func FormatAsCIDR(addresses []string) ([]string, error) {
 	return result, nil
 }
 
+// FirstAddressPerFamily returns the first of the specified addresses,
+// followed by the first IP address of the other family, if any. It is
+// used to default the egress subnets of a dual-stack unit to one
+// address of each family.
+func FirstAddressPerFamily(addresses []string) []string {
+	if len(addresses) == 0 {
+		return nil
+	}
+	result := []string{addresses[0]}
+	firstType := DeriveAddressType(addresses[0])
+	if firstType != IPv4Address && firstType != IPv6Address {
+		return result
+	}
+	for _, addr := range addresses[1:] {
+		addrType := DeriveAddressType(addr)
+		if addrType != firstType && (addrType == IPv4Address || addrType == IPv6Address) {
+			return append(result, addr)
+		}
+	}
+	return result
+}
+
 // macAddressTemplate is suitable for generating virtual MAC addresses,
 // particularly for use by container devices.
 // The last 3 segments are randomised.
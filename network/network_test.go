@@ -416,6 +416,37 @@ func (s *CIDRSuite) TestParseCIDR(c *gc.C) {
 	}
 }
 
+func (s *CIDRSuite) TestFormatAsCIDRIPv6(c *gc.C) {
+	cidrs, err := network.FormatAsCIDR([]string{"10.10.10.10", "2001:db8::1", "2001:db8::/64"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.10.10.10/32", "2001:db8::1/128", "2001:db8::/64"})
+}
+
+func (s *NetworkSuite) TestFirstAddressPerFamily(c *gc.C) {
+	for i, test := range []struct {
+		addresses []string
+		expected  []string
+	}{{
+		addresses: nil,
+		expected:  nil,
+	}, {
+		addresses: []string{"10.0.0.1", "10.0.0.2"},
+		expected:  []string{"10.0.0.1"},
+	}, {
+		addresses: []string{"10.0.0.1", "10.0.0.2", "2001:db8::1", "2001:db8::2"},
+		expected:  []string{"10.0.0.1", "2001:db8::1"},
+	}, {
+		addresses: []string{"2001:db8::1", "10.0.0.1"},
+		expected:  []string{"2001:db8::1", "10.0.0.1"},
+	}, {
+		addresses: []string{"example.com", "10.0.0.1"},
+		expected:  []string{"example.com"},
+	}} {
+		c.Logf("test %d: %v", i, test.addresses)
+		c.Check(network.FirstAddressPerFamily(test.addresses), jc.DeepEquals, test.expected)
+	}
+}
+
 func (s *NetworkSuite) TestGenerateVirtualMACAddress(c *gc.C) {
 	mac := network.GenerateVirtualMACAddress()
 	c.Check(mac, gc.Matches, "^([0-9A-Fa-f]{2}[:-]){5}([0-9A-Fa-f]{2})$")
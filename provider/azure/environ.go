@@ -375,6 +375,11 @@ func (env *azureEnviron) ConstraintsValidator(ctx context.ProviderCallContext) (
 		constraints.CpuPower,
 		constraints.Tags,
 		constraints.VirtType,
+		constraints.Zones,
+		constraints.Affinity,
+		constraints.AntiAffinity,
+		constraints.Spot,
+		constraints.MaxPrice,
 	})
 	validator.RegisterVocabulary(
 		constraints.Arch,
@@ -1528,10 +1528,12 @@ func (s *environSuite) TestStopInstancesStorageAccountKeysError(c *gc.C) {
 func (s *environSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	validator := s.constraintsValidator(c)
 	unsupported, err := validator.Validate(constraints.MustParse(
-		"arch=amd64 tags=foo cpu-power=100 virt-type=kvm",
+		"arch=amd64 tags=foo cpu-power=100 virt-type=kvm zones=z1 affinity=foo anti-affinity=bar spot=true max-price=0.05",
 	))
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(unsupported, jc.SameContents, []string{"tags", "cpu-power", "virt-type"})
+	c.Assert(unsupported, jc.SameContents, []string{
+		"tags", "cpu-power", "virt-type", "zones", "affinity", "anti-affinity", "spot", "max-price",
+	})
 }
 
 func (s *environSuite) TestConstraintsValidatorVocabulary(c *gc.C) {
@@ -13,6 +13,11 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.Zones,
+	constraints.Affinity,
+	constraints.AntiAffinity,
+	constraints.Spot,
+	constraints.MaxPrice,
 }
 
 // ConstraintsValidator returns a Validator instance which
@@ -6,8 +6,10 @@ package common
 import (
 	"sort"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/instance"
@@ -197,3 +199,42 @@ func ValidateAvailabilityZone(env ZonedEnviron, ctx context.ProviderCallContext,
 	}
 	return errors.NotValidf("availability zone %q", zone)
 }
+
+// ValidateZonesConstraint returns nil iff every zone named in the zones
+// constraint exists, at least one of the required zones (if any) is
+// available, and the placement zone (if any) is allowed by the
+// constraint. Otherwise it returns a NotValid error.
+func ValidateZonesConstraint(env ZonedEnviron, ctx context.ProviderCallContext, cons constraints.Value, placementZone string) error {
+	if !cons.HasZones() {
+		return nil
+	}
+	zones, err := env.AvailabilityZones(ctx)
+	if err != nil {
+		return err
+	}
+	known := make(set.Strings)
+	available := make(set.Strings)
+	for _, z := range zones {
+		known.Add(z.Name())
+		if z.Available() {
+			available.Add(z.Name())
+		}
+	}
+	include := set.NewStrings(cons.IncludeZones()...)
+	exclude := set.NewStrings(cons.ExcludeZones()...)
+	for _, zone := range include.Union(exclude).SortedValues() {
+		if !known.Contains(zone) {
+			return errors.NotValidf("availability zone %q", zone)
+		}
+	}
+	if !include.IsEmpty() && include.Difference(exclude).Intersection(available).IsEmpty() {
+		return errors.NotValidf("zones constraint %q with no available zones", cons.IncludeZones())
+	}
+	if placementZone == "" {
+		return nil
+	}
+	if exclude.Contains(placementZone) || (!include.IsEmpty() && !include.Contains(placementZone)) {
+		return errors.NotValidf("placement in zone %q with zones constraint %q", placementZone, *cons.Zones)
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/instance"
@@ -186,6 +187,50 @@ func (s *AvailabilityZoneSuite) TestValidateAvailabilityZone(c *gc.C) {
 	}
 }
 
+func (s *AvailabilityZoneSuite) TestValidateZonesConstraint(c *gc.C) {
+	for i, t := range []struct {
+		cons      string
+		placement string
+		err       string
+	}{{
+		cons: "mem=4G",
+	}, {
+		cons: "zones=az1,az2",
+	}, {
+		cons: "zones=^az0",
+	}, {
+		cons:      "zones=az1,az2",
+		placement: "az2",
+	}, {
+		cons: "zones=az1,az3",
+		err:  `availability zone "az3" not valid`,
+	}, {
+		cons: "zones=az0",
+		err:  `zones constraint \["az0"\] with no available zones not valid`,
+	}, {
+		cons: "zones=az1,^az1",
+		err:  `zones constraint \["az1"\] with no available zones not valid`,
+	}, {
+		cons:      "zones=az1",
+		placement: "az2",
+		err:       `placement in zone "az2" with zones constraint \["az1"\] not valid`,
+	}, {
+		cons:      "zones=^az2",
+		placement: "az2",
+		err:       `placement in zone "az2" with zones constraint \["\^az2"\] not valid`,
+	}} {
+		c.Logf("test %d: %s %s", i, t.cons, t.placement)
+		cons := constraints.MustParse(t.cons)
+		err := common.ValidateZonesConstraint(&s.env, s.callCtx, cons, t.placement)
+		if t.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.err)
+			c.Check(err, jc.Satisfies, errors.IsNotValid)
+		}
+	}
+}
+
 func (s *AvailabilityZoneSuite) TestDistributeInstancesGroup(c *gc.C) {
 	expectedGroup := []instance.Id{"0", "1", "2"}
 	var called bool
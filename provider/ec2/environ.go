@@ -81,6 +81,7 @@ type environ struct {
 
 var _ environs.Environ = (*environ)(nil)
 var _ environs.Networking = (*environ)(nil)
+var _ environs.IPv6IngressChecker = (*environ)(nil)
 
 func (e *environ) Config() *config.Config {
 	return e.ecfg().Config
@@ -166,6 +167,11 @@ var unsupportedConstraints = []string{
 	// TODO(anastasiamac 2016-03-16) LP#1557874
 	// use virt-type in StartInstances
 	constraints.VirtType,
+	// The EC2 client we use does not support requesting spot
+	// instances, so the spot and max-price constraints are reported
+	// as unsupported rather than silently starting on-demand instances.
+	constraints.Spot,
+	constraints.MaxPrice,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -323,12 +329,16 @@ func (e *environ) parsePlacement(ctx context.ProviderCallContext, placement stri
 
 // PrecheckInstance is defined on the environs.InstancePrechecker interface.
 func (e *environ) PrecheckInstance(ctx context.ProviderCallContext, args environs.PrecheckInstanceParams) error {
-	if _, _, err := e.deriveAvailabilityZoneAndSubnetID(ctx,
+	zone, _, err := e.deriveAvailabilityZoneAndSubnetID(ctx,
 		environs.StartInstanceParams{
 			Placement:         args.Placement,
 			VolumeAttachments: args.VolumeAttachments,
 		},
-	); err != nil {
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := common.ValidateZonesConstraint(e, ctx, args.Constraints, zone); err != nil {
 		return errors.Trace(err)
 	}
 	if !args.Constraints.HasInstanceType() {
@@ -1473,6 +1483,13 @@ func (e *environ) IngressRules(ctx context.ProviderCallContext) ([]network.Ingre
 	return e.ingressRulesInGroup(ctx, e.globalGroupName())
 }
 
+// SupportsIPv6Ingress implements environs.IPv6IngressChecker. The EC2
+// API version used to authorize security group ingress has no IPv6
+// ranges, so ports are only ever opened to IPv4 sources.
+func (*environ) SupportsIPv6Ingress() bool {
+	return false
+}
+
 func (*environ) Provider() environs.EnvironProvider {
 	return &providerInstance
 }
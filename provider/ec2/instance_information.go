@@ -27,6 +27,6 @@ func (e *environ) InstanceTypes(ctx context.ProviderCallContext, c constraints.V
 	return instances.InstanceTypesWithCostMetadata{
 		InstanceTypes: iTypes,
 		CostUnit:      "$USD/hour",
-		CostDivisor:   1000,
+		CostDivisor:   instanceCostDivisor,
 		CostCurrency:  "USD"}, nil
 }
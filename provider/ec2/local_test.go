@@ -1371,10 +1371,10 @@ func (t *localServerSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	env := t.Prepare(c)
 	validator, err := env.ConstraintsValidator(t.callCtx)
 	c.Assert(err, jc.ErrorIsNil)
-	cons := constraints.MustParse("arch=amd64 tags=foo virt-type=kvm")
+	cons := constraints.MustParse("arch=amd64 tags=foo virt-type=kvm spot=true max-price=0.05")
 	unsupported, err := validator.Validate(cons)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(unsupported, jc.SameContents, []string{"tags", "virt-type"})
+	c.Assert(unsupported, jc.SameContents, []string{"tags", "virt-type", "spot", "max-price"})
 }
 
 func (t *localServerSuite) TestConstraintsValidatorVocab(c *gc.C) {
@@ -1497,6 +1497,38 @@ func (t *localServerSuite) TestPrecheckInstanceAvailZoneUnknown(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `invalid availability zone "test-unknown"`)
 }
 
+func (t *localServerSuite) TestPrecheckInstanceZonesConstraint(c *gc.C) {
+	env := t.Prepare(c)
+	cons := constraints.MustParse("zones=test-available")
+	err := env.PrecheckInstance(t.callCtx, environs.PrecheckInstanceParams{
+		Series:      supportedversion.SupportedLTS(),
+		Constraints: cons,
+		Placement:   "zone=test-available",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (t *localServerSuite) TestPrecheckInstanceZonesConstraintUnknown(c *gc.C) {
+	env := t.Prepare(c)
+	cons := constraints.MustParse("zones=test-available,test-unknown")
+	err := env.PrecheckInstance(t.callCtx, environs.PrecheckInstanceParams{
+		Series:      supportedversion.SupportedLTS(),
+		Constraints: cons,
+	})
+	c.Assert(err, gc.ErrorMatches, `availability zone "test-unknown" not valid`)
+}
+
+func (t *localServerSuite) TestPrecheckInstanceZonesConstraintPlacementConflict(c *gc.C) {
+	env := t.Prepare(c)
+	cons := constraints.MustParse("zones=^test-available")
+	err := env.PrecheckInstance(t.callCtx, environs.PrecheckInstanceParams{
+		Series:      supportedversion.SupportedLTS(),
+		Constraints: cons,
+		Placement:   "zone=test-available",
+	})
+	c.Assert(err, gc.ErrorMatches, `placement in zone "test-available" with zones constraint .* not valid`)
+}
+
 func (t *localServerSuite) TestPrecheckInstanceVolumeAvailZoneNoPlacement(c *gc.C) {
 	t.testPrecheckInstanceVolumeAvailZone(c, "")
 }
@@ -1584,6 +1616,13 @@ func (t *localServerSuite) TestSupportsNetworking(c *gc.C) {
 	c.Assert(supported, jc.IsTrue)
 }
 
+func (t *localServerSuite) TestSupportsIPv6Ingress(c *gc.C) {
+	env := t.Prepare(c)
+	fw, ok := env.(environs.Firewaller)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(environs.SupportsIPv6Ingress(fw), jc.IsFalse)
+}
+
 func (t *localServerSuite) setUpInstanceWithDefaultVpc(c *gc.C) (environs.NetworkingEnviron, instance.Id) {
 	env := t.prepareEnviron(c)
 	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env,
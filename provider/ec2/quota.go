@@ -0,0 +1,84 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"strconv"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+var _ environs.QuotaReporter = (*environ)(nil)
+
+const (
+	// maxInstancesAttribute is the account attribute holding the
+	// maximum number of on-demand instances in the region.
+	maxInstancesAttribute = "max-instances"
+
+	// instanceCostDivisor is applied to the cost of EC2 instance types
+	// to obtain the cost in USD per hour.
+	instanceCostDivisor = 1000
+)
+
+// Quota is part of the environs.QuotaReporter interface. EC2 only
+// reports the account's instance limit for the region; the number of
+// instances used includes those not managed by Juju.
+func (e *environ) Quota(ctx context.ProviderCallContext) (environs.Quota, error) {
+	resp, err := e.ec2.AccountAttributes(maxInstancesAttribute)
+	if err != nil {
+		return environs.Quota{}, errors.Annotate(maybeConvertCredentialError(err, ctx), "getting account attributes")
+	}
+	filter := ec2.NewFilter()
+	filter.Add("instance-state-name", aliveInstanceStates...)
+	instancesResp, err := e.ec2.Instances(nil, filter)
+	if err != nil {
+		return environs.Quota{}, errors.Annotate(maybeConvertCredentialError(err, ctx), "listing instances")
+	}
+	var used int
+	for _, r := range instancesResp.Reservations {
+		used += len(r.Instances)
+	}
+	return instancesQuota(resp.Attributes, used)
+}
+
+// instancesQuota returns the quota described by the given account
+// attributes, with the given number of instances in use.
+func instancesQuota(attributes []ec2.AccountAttribute, used int) (environs.Quota, error) {
+	var quota environs.Quota
+	for _, attr := range attributes {
+		if attr.Name != maxInstancesAttribute || len(attr.Values) == 0 {
+			continue
+		}
+		limit, err := strconv.Atoi(attr.Values[0])
+		if err != nil {
+			return environs.Quota{}, errors.Annotatef(err, "parsing %s account attribute", maxInstancesAttribute)
+		}
+		quota.Instances = &environs.QuotaLimit{Limit: limit, Used: used}
+	}
+	return quota, nil
+}
+
+// InstanceCosts is part of the environs.QuotaReporter interface.
+func (e *environ) InstanceCosts(ctx context.ProviderCallContext) ([]environs.InstanceCost, error) {
+	instanceTypes, err := e.supportedInstanceTypes(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	costs := make([]environs.InstanceCost, 0, len(instanceTypes))
+	for _, instanceType := range instanceTypes {
+		if instanceType.Deprecated {
+			continue
+		}
+		costs = append(costs, environs.InstanceCost{
+			InstanceType: instanceType.Name,
+			HourlyCost:   float64(instanceType.Cost) / instanceCostDivisor,
+			Currency:     "USD",
+		})
+	}
+	return costs, nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"gopkg.in/amz.v3/ec2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+)
+
+type quotaSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&quotaSuite{})
+
+func (s *quotaSuite) TestInstancesQuota(c *gc.C) {
+	quota, err := instancesQuota([]ec2.AccountAttribute{
+		{Name: "default-vpc", Values: []string{"vpc-0123"}},
+		{Name: "max-instances", Values: []string{"20"}},
+	}, 7)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quota, jc.DeepEquals, environs.Quota{
+		Instances: &environs.QuotaLimit{Limit: 20, Used: 7},
+	})
+}
+
+func (s *quotaSuite) TestInstancesQuotaNoAttribute(c *gc.C) {
+	quota, err := instancesQuota(nil, 7)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quota, jc.DeepEquals, environs.Quota{})
+}
+
+func (s *quotaSuite) TestInstancesQuotaInvalidAttribute(c *gc.C) {
+	_, err := instancesQuota([]ec2.AccountAttribute{
+		{Name: "max-instances", Values: []string{"lots"}},
+	}, 7)
+	c.Assert(err, gc.ErrorMatches, `parsing max-instances account attribute: .*`)
+}
@@ -0,0 +1,199 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"strings"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/amz.v3/ec2"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+)
+
+var _ environs.TaggedResourceLister = (*environ)(nil)
+
+// uuidLength is the length of a UUID in its canonical string form.
+const uuidLength = 36
+
+// ControllerResources is part of the environs.TaggedResourceLister
+// interface.
+func (e *environ) ControllerResources(ctx context.ProviderCallContext, controllerUUID string) ([]environs.TaggedResource, error) {
+	instFilter := ec2.NewFilter()
+	instFilter.Add("instance-state-name", aliveInstanceStates...)
+	e.addControllerFilter(instFilter, controllerUUID)
+	instResp, err := e.ec2.Instances(nil, instFilter)
+	if err != nil {
+		return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "listing instances")
+	}
+	resources := taggedInstances(instResp.Reservations)
+
+	volFilter := ec2.NewFilter()
+	e.addControllerFilter(volFilter, controllerUUID)
+	volResp, err := e.ec2.Volumes(nil, volFilter)
+	if err != nil {
+		return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "listing volumes")
+	}
+	resources = append(resources, taggedVolumes(volResp.Volumes)...)
+
+	groupFilter := ec2.NewFilter()
+	e.addControllerFilter(groupFilter, controllerUUID)
+	groupResp, err := e.ec2.SecurityGroups(nil, groupFilter)
+	if err != nil {
+		return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "listing security groups")
+	}
+	resources = append(resources, taggedSecurityGroups(groupResp.Groups)...)
+	return resources, nil
+}
+
+// DestroyResources is part of the environs.TaggedResourceLister
+// interface. Instances are terminated first, so that their volumes
+// and security groups are no longer in use when they are destroyed.
+func (e *environ) DestroyResources(ctx context.ProviderCallContext, resources []environs.TaggedResource) error {
+	var instIds []instance.Id
+	var volIds []string
+	var groups []ec2.SecurityGroup
+	for _, r := range resources {
+		switch r.Kind {
+		case environs.InstanceResource:
+			instIds = append(instIds, instance.Id(r.Id))
+		case environs.VolumeResource:
+			volIds = append(volIds, r.Id)
+		case environs.SecurityGroupResource:
+			groups = append(groups, ec2.SecurityGroup{Id: r.Id})
+		default:
+			return errors.NotSupportedf("destroying %s resources", r.Kind)
+		}
+	}
+
+	if err := e.terminateInstances(ctx, instIds); err != nil {
+		return errors.Annotate(err, "terminating instances")
+	}
+	for i, err := range foreachVolume(e.ec2, ctx, volIds, destroyVolume) {
+		if err != nil {
+			return errors.Annotatef(err, "destroying volume %q", volIds[i])
+		}
+	}
+	for _, g := range groups {
+		if err := deleteSecurityGroupInsistently(e.ec2, ctx, g, clock.WallClock); err != nil {
+			return errors.Annotatef(err, "deleting security group %q", g.Id)
+		}
+	}
+	return nil
+}
+
+// taggedInstances returns the tagged resources describing the
+// instances in the given reservations.
+func taggedInstances(reservations []ec2.Reservation) []environs.TaggedResource {
+	var resources []environs.TaggedResource
+	for _, r := range reservations {
+		for _, inst := range r.Instances {
+			resourceTags := tagsMap(inst.Tags)
+			resources = append(resources, environs.TaggedResource{
+				Kind:      environs.InstanceResource,
+				Id:        inst.InstanceId,
+				ModelUUID: resourceTags[tags.JujuModel],
+				Tags:      resourceTags,
+			})
+		}
+	}
+	return resources
+}
+
+// taggedVolumes returns the tagged resources describing the given
+// volumes. Root disks are destroyed along with their instances, so
+// they are not included, and neither are volumes already being
+// destroyed.
+func taggedVolumes(volumes []ec2.Volume) []environs.TaggedResource {
+	var resources []environs.TaggedResource
+	for _, vol := range volumes {
+		if vol.Status == "deleting" || vol.Status == "deleted" {
+			continue
+		}
+		var isRootDisk bool
+		for _, att := range vol.Attachments {
+			if att.Device == rootDiskDeviceName {
+				isRootDisk = true
+				break
+			}
+		}
+		if isRootDisk {
+			continue
+		}
+		resourceTags := tagsMap(vol.Tags)
+		resources = append(resources, environs.TaggedResource{
+			Kind:      environs.VolumeResource,
+			Id:        vol.Id,
+			ModelUUID: resourceTags[tags.JujuModel],
+			Tags:      resourceTags,
+		})
+	}
+	return resources
+}
+
+// taggedSecurityGroups returns the tagged resources describing the
+// given security groups. Security groups are named after the model,
+// and machine, they belong to, which is where the model UUID and
+// machine id are taken from.
+func taggedSecurityGroups(groups []ec2.SecurityGroupInfo) []environs.TaggedResource {
+	var resources []environs.TaggedResource
+	for _, g := range groups {
+		modelUUID, machineId, ok := parseSecurityGroupName(g.Name)
+		if !ok {
+			logger.Debugf("ignoring security group %q (%q) with unexpected name", g.Name, g.Id)
+			continue
+		}
+		resources = append(resources, environs.TaggedResource{
+			Kind:      environs.SecurityGroupResource,
+			Id:        g.Id,
+			ModelUUID: modelUUID,
+			MachineId: machineId,
+		})
+	}
+	return resources
+}
+
+// parseSecurityGroupName returns the UUID of the model, and the id of
+// the machine if any, that own the security group with the given name.
+// Juju names its groups "juju-<model-uuid>", optionally followed by
+// "-global" or "-<machine-id>".
+func parseSecurityGroupName(name string) (modelUUID, machineId string, ok bool) {
+	if !strings.HasPrefix(name, "juju-") {
+		return "", "", false
+	}
+	name = strings.TrimPrefix(name, "juju-")
+	if len(name) < uuidLength {
+		return "", "", false
+	}
+	modelUUID = name[:uuidLength]
+	if !utils.IsValidUUIDString(modelUUID) {
+		return "", "", false
+	}
+	rest := name[uuidLength:]
+	if rest == "" {
+		return modelUUID, "", true
+	}
+	if !strings.HasPrefix(rest, "-") {
+		return "", "", false
+	}
+	if rest = rest[1:]; names.IsValidMachine(rest) {
+		machineId = rest
+	}
+	return modelUUID, machineId, true
+}
+
+// tagsMap converts EC2 tags to a map of tag names to values.
+func tagsMap(ec2Tags []ec2.Tag) map[string]string {
+	m := make(map[string]string, len(ec2Tags))
+	for _, tag := range ec2Tags {
+		m[tag.Key] = tag.Value
+	}
+	return m
+}
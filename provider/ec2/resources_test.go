@@ -0,0 +1,93 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"gopkg.in/amz.v3/ec2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+)
+
+type resourcesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&resourcesSuite{})
+
+const testModelUUID = "7e386e08-cba7-44a4-a76e-7c1633584210"
+
+func (s *resourcesSuite) TestTaggedInstances(c *gc.C) {
+	resources := taggedInstances([]ec2.Reservation{{
+		Instances: []ec2.Instance{{
+			InstanceId: "i-0",
+			Tags: []ec2.Tag{
+				{Key: "juju-model-uuid", Value: testModelUUID},
+				{Key: "juju-machine-id", Value: "foo-machine-0"},
+			},
+		}, {
+			InstanceId: "i-1",
+		}},
+	}})
+	c.Assert(resources, jc.DeepEquals, []environs.TaggedResource{{
+		Kind:      environs.InstanceResource,
+		Id:        "i-0",
+		ModelUUID: testModelUUID,
+		Tags: map[string]string{
+			"juju-model-uuid": testModelUUID,
+			"juju-machine-id": "foo-machine-0",
+		},
+	}, {
+		Kind: environs.InstanceResource,
+		Id:   "i-1",
+		Tags: map[string]string{},
+	}})
+}
+
+func (s *resourcesSuite) TestTaggedVolumes(c *gc.C) {
+	resources := taggedVolumes([]ec2.Volume{{
+		Id:     "vol-0",
+		Status: "available",
+		Tags:   []ec2.Tag{{Key: "juju-model-uuid", Value: testModelUUID}},
+	}, {
+		Id:          "vol-1",
+		Status:      "in-use",
+		Attachments: []ec2.VolumeAttachment{{Device: rootDiskDeviceName}},
+	}, {
+		Id:     "vol-2",
+		Status: "deleting",
+	}})
+	c.Assert(resources, jc.DeepEquals, []environs.TaggedResource{{
+		Kind:      environs.VolumeResource,
+		Id:        "vol-0",
+		ModelUUID: testModelUUID,
+		Tags:      map[string]string{"juju-model-uuid": testModelUUID},
+	}})
+}
+
+func (s *resourcesSuite) TestTaggedSecurityGroups(c *gc.C) {
+	resources := taggedSecurityGroups([]ec2.SecurityGroupInfo{
+		{SecurityGroup: ec2.SecurityGroup{Id: "sg-0", Name: "juju-" + testModelUUID}},
+		{SecurityGroup: ec2.SecurityGroup{Id: "sg-1", Name: "juju-" + testModelUUID + "-global"}},
+		{SecurityGroup: ec2.SecurityGroup{Id: "sg-2", Name: "juju-" + testModelUUID + "-42"}},
+		{SecurityGroup: ec2.SecurityGroup{Id: "sg-3", Name: "default"}},
+		{SecurityGroup: ec2.SecurityGroup{Id: "sg-4", Name: "juju-" + testModelUUID + "x"}},
+	})
+	c.Assert(resources, jc.DeepEquals, []environs.TaggedResource{{
+		Kind:      environs.SecurityGroupResource,
+		Id:        "sg-0",
+		ModelUUID: testModelUUID,
+	}, {
+		Kind:      environs.SecurityGroupResource,
+		Id:        "sg-1",
+		ModelUUID: testModelUUID,
+	}, {
+		Kind:      environs.SecurityGroupResource,
+		Id:        "sg-2",
+		ModelUUID: testModelUUID,
+		MachineId: "42",
+	}})
+}
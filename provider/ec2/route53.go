@@ -0,0 +1,263 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/dns"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+var _ environs.DNSZones = (*environ)(nil)
+
+const (
+	route53Endpoint  = "https://route53.amazonaws.com/2013-04-01"
+	route53Namespace = "https://route53.amazonaws.com/doc/2013-04-01/"
+
+	// route53SigningRegion is the region that requests to the global
+	// Route 53 endpoint are signed for.
+	route53SigningRegion = "us-east-1"
+)
+
+// DNSZone is part of the environs.DNSZones interface. Records are kept
+// in the Route 53 hosted zone with the given name.
+func (e *environ) DNSZone(ctx context.ProviderCallContext, name string) (dns.Zone, error) {
+	attrs := e.cloud.Credential.Attributes()
+	auth := aws.Auth{
+		AccessKey: attrs["access-key"],
+		SecretKey: attrs["secret-key"],
+	}
+	zone, err := newRoute53Zone(ctx, route53Endpoint, auth, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return zone, nil
+}
+
+// route53Zone is a Route 53 hosted zone. There is no Route 53 client
+// in the AWS library used by the provider, so requests are made
+// directly against the REST API.
+type route53Zone struct {
+	endpoint string
+	auth     aws.Auth
+	sign     aws.Signer
+	ctx      context.ProviderCallContext
+
+	// id is the hosted zone's ID, e.g. "/hostedzone/Z1D633PJN98FT9".
+	id string
+}
+
+func newRoute53Zone(ctx context.ProviderCallContext, endpoint string, auth aws.Auth, name string) (*route53Zone, error) {
+	z := &route53Zone{
+		endpoint: endpoint,
+		auth:     auth,
+		sign:     aws.SignV4Factory(route53SigningRegion, "route53"),
+		ctx:      ctx,
+	}
+	name = dns.Fqdn(name)
+	query := url.Values{"dnsname": {name}, "maxitems": {"1"}}
+	var resp struct {
+		HostedZones []struct {
+			Id   string
+			Name string
+		} `xml:"HostedZones>HostedZone"`
+	}
+	if err := z.do("GET", "/hostedzonesbyname", query, nil, &resp); err != nil {
+		return nil, errors.Annotatef(err, "finding hosted zone %q", name)
+	}
+	// Zones are listed from the one with the given name, if it exists.
+	if len(resp.HostedZones) == 0 || dns.Fqdn(resp.HostedZones[0].Name) != name {
+		return nil, errors.NotFoundf("hosted zone %q", name)
+	}
+	z.id = resp.HostedZones[0].Id
+	return z, nil
+}
+
+type route53RecordSet struct {
+	Name   string   `xml:"Name"`
+	Type   string   `xml:"Type"`
+	TTL    int      `xml:"TTL"`
+	Values []string `xml:"ResourceRecords>ResourceRecord>Value"`
+}
+
+func (rs route53RecordSet) record() dns.Record {
+	return dns.Record{
+		Name:   dns.Fqdn(rs.Name),
+		Type:   rs.Type,
+		TTL:    rs.TTL,
+		Values: rs.Values,
+	}
+}
+
+// listRecordSets returns the record sets in the zone, starting with the
+// given name and type, until filter returns false.
+func (z *route53Zone) listRecordSets(name, recordType string, filter func(route53RecordSet) bool) ([]route53RecordSet, error) {
+	var result []route53RecordSet
+	query := url.Values{"name": {name}}
+	if recordType != "" {
+		query.Set("type", recordType)
+	}
+	for {
+		var resp struct {
+			RecordSets     []route53RecordSet `xml:"ResourceRecordSets>ResourceRecordSet"`
+			IsTruncated    bool
+			NextRecordName string
+			NextRecordType string
+		}
+		if err := z.do("GET", z.id+"/rrset", query, nil, &resp); err != nil {
+			return nil, errors.Annotate(err, "listing record sets")
+		}
+		for _, rs := range resp.RecordSets {
+			if !filter(rs) {
+				return result, nil
+			}
+			result = append(result, rs)
+		}
+		if !resp.IsTruncated {
+			return result, nil
+		}
+		query.Set("name", resp.NextRecordName)
+		query.Set("type", resp.NextRecordType)
+	}
+}
+
+// Records is part of the dns.Zone interface.
+func (z *route53Zone) Records(domain string) ([]dns.Record, error) {
+	// Record sets are listed in order of their names' reversed labels,
+	// so the domain's records follow it, but may be interleaved with
+	// others that share a prefix.
+	recordSets, err := z.listRecordSets(dns.Fqdn(domain), "", func(route53RecordSet) bool {
+		return true
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var records []dns.Record
+	for _, rs := range recordSets {
+		if rs.Type != dns.TypeA && rs.Type != dns.TypeAAAA {
+			continue
+		}
+		if !dns.InDomain(rs.Name, domain) {
+			continue
+		}
+		records = append(records, rs.record())
+	}
+	dns.SortRecords(records)
+	return records, nil
+}
+
+// SetRecord is part of the dns.Zone interface.
+func (z *route53Zone) SetRecord(record dns.Record) error {
+	record.Name = dns.Fqdn(record.Name)
+	err := z.change("UPSERT", record)
+	return errors.Annotatef(err, "setting %s records for %q", record.Type, record.Name)
+}
+
+// RemoveRecord is part of the dns.Zone interface.
+func (z *route53Zone) RemoveRecord(record dns.Record) error {
+	// Route 53 only deletes a record set given its exact values.
+	name := dns.Fqdn(record.Name)
+	recordSets, err := z.listRecordSets(name, record.Type, func(rs route53RecordSet) bool {
+		return dns.Fqdn(rs.Name) == name && rs.Type == record.Type
+	})
+	if err != nil {
+		return errors.Annotatef(err, "removing %s records for %q", record.Type, name)
+	}
+	if len(recordSets) == 0 {
+		return errors.NotFoundf("%s records for %q", record.Type, name)
+	}
+	err = z.change("DELETE", recordSets[0].record())
+	return errors.Annotatef(err, "removing %s records for %q", record.Type, name)
+}
+
+func (z *route53Zone) change(action string, record dns.Record) error {
+	type change struct {
+		Action    string
+		RecordSet route53RecordSet `xml:"ResourceRecordSet"`
+	}
+	req := struct {
+		XMLName xml.Name `xml:"ChangeResourceRecordSetsRequest"`
+		Xmlns   string   `xml:"xmlns,attr"`
+		Changes []change `xml:"ChangeBatch>Changes>Change"`
+	}{
+		Xmlns: route53Namespace,
+		Changes: []change{{
+			Action: action,
+			RecordSet: route53RecordSet{
+				Name:   record.Name,
+				Type:   record.Type,
+				TTL:    record.TTL,
+				Values: record.Values,
+			},
+		}},
+	}
+	body, err := xml.Marshal(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return z.do("POST", z.id+"/rrset/", nil, body, nil)
+}
+
+// do sends a signed request to the Route 53 API, and decodes the
+// response into resp if it is not nil. Errors returned by the API are
+// converted to *ec2.Error, so that credential errors are recognised.
+func (z *route53Zone) do(method, path string, query url.Values, body []byte, resp interface{}) error {
+	u := z.endpoint + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "text/xml")
+	}
+	req.Header.Set("X-Amz-Date", time.Now().UTC().Format("20060102T150405Z"))
+	if err := z.sign(req, z.auth); err != nil {
+		return errors.Annotate(err, "signing request")
+	}
+	httpResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer httpResp.Body.Close()
+	data, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if httpResp.StatusCode >= 300 {
+		var errResp struct {
+			Code      string `xml:"Error>Code"`
+			Message   string `xml:"Error>Message"`
+			RequestId string
+		}
+		if err := xml.Unmarshal(data, &errResp); err != nil || errResp.Code == "" {
+			return errors.Errorf("%s %s: %s", method, path, strings.TrimSpace(httpResp.Status))
+		}
+		return maybeConvertCredentialError(&ec2.Error{
+			StatusCode: httpResp.StatusCode,
+			Code:       errResp.Code,
+			Message:    errResp.Message,
+			RequestId:  errResp.RequestId,
+		}, z.ctx)
+	}
+	if resp == nil {
+		return nil
+	}
+	return errors.Trace(xml.Unmarshal(data, resp))
+}
@@ -0,0 +1,219 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"gopkg.in/amz.v3/aws"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/dns"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
+)
+
+type route53Suite struct {
+	testing.IsolationSuite
+
+	api    *fakeRoute53
+	server *httptest.Server
+}
+
+var _ = gc.Suite(&route53Suite{})
+
+func (s *route53Suite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &fakeRoute53{
+		zoneName: "example.com.",
+		recordSets: []route53RecordSet{
+			{Name: "example.com.", Type: "SOA", TTL: 900, Values: []string{"ns.example.com. hostmaster.example.com. 1 7200 900 1209600 86400"}},
+			{Name: "m.example.com.", Type: "TXT", TTL: 300, Values: []string{`"hello"`}},
+			{Name: "mysql-0.m.example.com.", Type: "A", TTL: 300, Values: []string{"10.0.0.3"}},
+			{Name: "wordpress.m.example.com.", Type: "A", TTL: 300, Values: []string{"10.0.0.1", "10.0.0.2"}},
+			{Name: "wordpress.m.example.com.", Type: "AAAA", TTL: 300, Values: []string{"2001:db8::1"}},
+			{Name: "www.example.com.", Type: "A", TTL: 300, Values: []string{"192.0.2.1"}},
+		},
+	}
+	s.server = httptest.NewServer(s.api)
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *route53Suite) newZone(c *gc.C) *route53Zone {
+	zone, err := newRoute53Zone(context.NewCloudCallContext(), s.server.URL, aws.Auth{AccessKey: "key", SecretKey: "secret"}, "Example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	return zone
+}
+
+func (s *route53Suite) TestZoneNotFound(c *gc.C) {
+	_, err := newRoute53Zone(context.NewCloudCallContext(), s.server.URL, aws.Auth{}, "example.org")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `hosted zone "example.org." not found`)
+}
+
+func (s *route53Suite) TestRecords(c *gc.C) {
+	records, err := s.newZone(c).Records("m.example.com.")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, jc.DeepEquals, []dns.Record{
+		{"mysql-0.m.example.com.", dns.TypeA, 300, []string{"10.0.0.3"}},
+		{"wordpress.m.example.com.", dns.TypeA, 300, []string{"10.0.0.1", "10.0.0.2"}},
+		{"wordpress.m.example.com.", dns.TypeAAAA, 300, []string{"2001:db8::1"}},
+	})
+	c.Assert(s.api.requests, jc.DeepEquals, []string{
+		"GET /hostedzonesbyname",
+		"GET /hostedzone/Z1/rrset",
+		"GET /hostedzone/Z1/rrset",
+		"GET /hostedzone/Z1/rrset",
+	})
+}
+
+func (s *route53Suite) TestSetRecord(c *gc.C) {
+	err := s.newZone(c).SetRecord(dns.Record{
+		Name:   "mysql-0.m.example.com",
+		Type:   dns.TypeA,
+		TTL:    60,
+		Values: []string{"10.0.0.4"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.changes, jc.DeepEquals, []string{"UPSERT mysql-0.m.example.com. A 60 [10.0.0.4]"})
+}
+
+func (s *route53Suite) TestRemoveRecord(c *gc.C) {
+	err := s.newZone(c).RemoveRecord(dns.Record{Name: "wordpress.m.example.com.", Type: dns.TypeA})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.changes, jc.DeepEquals, []string{"DELETE wordpress.m.example.com. A 300 [10.0.0.1 10.0.0.2]"})
+}
+
+func (s *route53Suite) TestRemoveRecordNotFound(c *gc.C) {
+	err := s.newZone(c).RemoveRecord(dns.Record{Name: "mysql-0.m.example.com.", Type: dns.TypeAAAA})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(s.api.changes, gc.HasLen, 0)
+}
+
+func (s *route53Suite) TestInvalidCredential(c *gc.C) {
+	zone := s.newZone(c)
+	ctx := context.NewCloudCallContext()
+	var invalidated bool
+	ctx.InvalidateCredentialFunc = func(string) error {
+		invalidated = true
+		return nil
+	}
+	zone.ctx = ctx
+	s.api.errorCode = "InvalidClientTokenId"
+
+	_, err := zone.Records("m.example.com.")
+	c.Assert(err, jc.Satisfies, common.IsCredentialNotValid)
+	c.Assert(invalidated, jc.IsTrue)
+}
+
+// fakeRoute53 implements enough of the Route 53 API to test
+// route53Zone. Record sets are listed two at a time.
+type fakeRoute53 struct {
+	zoneName   string
+	recordSets []route53RecordSet
+	errorCode  string
+
+	requests []string
+	changes  []string
+}
+
+func (f *fakeRoute53) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.requests = append(f.requests, req.Method+" "+req.URL.Path)
+	if !strings.HasPrefix(req.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+		http.Error(w, "unsigned request", http.StatusBadRequest)
+		return
+	}
+	if f.errorCode != "" {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprintf(w, "<ErrorResponse><Error><Type>Sender</Type><Code>%s</Code><Message>bad</Message></Error><RequestId>1</RequestId></ErrorResponse>", f.errorCode)
+		return
+	}
+	query := req.URL.Query()
+	switch {
+	case req.URL.Path == "/hostedzonesbyname":
+		fmt.Fprint(w, "<ListHostedZonesByNameResponse><HostedZones>")
+		if query.Get("dnsname") <= f.zoneName {
+			fmt.Fprintf(w, "<HostedZone><Id>/hostedzone/Z1</Id><Name>%s</Name></HostedZone>", f.zoneName)
+		}
+		fmt.Fprint(w, "</HostedZones></ListHostedZonesByNameResponse>")
+	case req.URL.Path == "/hostedzone/Z1/rrset" && req.Method == "GET":
+		f.list(w, query.Get("name"), query.Get("type"))
+	case req.URL.Path == "/hostedzone/Z1/rrset/" && req.Method == "POST":
+		f.change(w, req)
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+func reversedName(name string) string {
+	labels := strings.Split(strings.TrimSuffix(name, "."), ".")
+	for i, j := 0, len(labels)-1; i < j; i, j = i+1, j-1 {
+		labels[i], labels[j] = labels[j], labels[i]
+	}
+	return strings.Join(labels, ".")
+}
+
+func (f *fakeRoute53) list(w http.ResponseWriter, name, recordType string) {
+	sort.Slice(f.recordSets, func(i, j int) bool {
+		a, b := f.recordSets[i], f.recordSets[j]
+		if a.Name != b.Name {
+			return reversedName(a.Name) < reversedName(b.Name)
+		}
+		return a.Type < b.Type
+	})
+	var page []route53RecordSet
+	var next *route53RecordSet
+	for i, rs := range f.recordSets {
+		if reversedName(rs.Name) < reversedName(name) || rs.Name == name && rs.Type < recordType {
+			continue
+		}
+		if len(page) == 2 {
+			next = &f.recordSets[i]
+			break
+		}
+		page = append(page, rs)
+	}
+	resp := struct {
+		XMLName        xml.Name           `xml:"ListResourceRecordSetsResponse"`
+		RecordSets     []route53RecordSet `xml:"ResourceRecordSets>ResourceRecordSet"`
+		IsTruncated    bool
+		NextRecordName string `xml:",omitempty"`
+		NextRecordType string `xml:",omitempty"`
+	}{RecordSets: page}
+	if next != nil {
+		resp.IsTruncated = true
+		resp.NextRecordName = next.Name
+		resp.NextRecordType = next.Type
+	}
+	out, _ := xml.Marshal(resp)
+	w.Write(out)
+}
+
+func (f *fakeRoute53) change(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+	var changeReq struct {
+		Changes []struct {
+			Action    string
+			RecordSet route53RecordSet `xml:"ResourceRecordSet"`
+		} `xml:"ChangeBatch>Changes>Change"`
+	}
+	if err := xml.Unmarshal(body, &changeReq); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, change := range changeReq.Changes {
+		rs := change.RecordSet
+		f.changes = append(f.changes, fmt.Sprintf("%s %s %s %d %v", change.Action, rs.Name, rs.Type, rs.TTL, rs.Values))
+	}
+	fmt.Fprint(w, "<ChangeResourceRecordSetsResponse><ChangeInfo><Status>PENDING</Status></ChangeInfo></ChangeResourceRecordSetsResponse>")
+}
@@ -11,6 +11,7 @@ import (
 	"google.golang.org/api/compute/v1"
 
 	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/dns"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
@@ -69,6 +70,30 @@ type gceConnection interface {
 	InstanceDisks(zone, instanceId string) ([]*google.AttachedDisk, error)
 	// ListMachineTypes returns a list of machines available in the project and zone provided.
 	ListMachineTypes(zone string) ([]google.MachineType, error)
+
+	// Load balancer related methods.
+
+	// EnsureLoadBalancer creates or updates the network load balancer
+	// described by spec, and returns its IP addresses.
+	EnsureLoadBalancer(spec google.LoadBalancerSpec) ([]string, error)
+	// RemoveLoadBalancer removes the named network load balancer.
+	RemoveLoadBalancer(name string) error
+	// LoadBalancers returns the names of the network load balancers
+	// whose names start with prefix.
+	LoadBalancers(prefix string) ([]string, error)
+
+	// DNS related methods.
+
+	// ManagedZone returns the name of the Cloud DNS managed zone for
+	// the fully qualified DNS name.
+	ManagedZone(dnsName string) (string, error)
+	// RecordSets returns the A and AAAA record sets in the managed
+	// zone that are within the domain.
+	RecordSets(managedZone, domain string) ([]dns.Record, error)
+	// SetRecordSet creates or replaces a record set in the managed zone.
+	SetRecordSet(managedZone string, record dns.Record) error
+	// RemoveRecordSet removes a record set from the managed zone.
+	RemoveRecordSet(managedZone string, record dns.Record) error
 }
 
 type environ struct {
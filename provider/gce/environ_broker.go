@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/provider/gce/google"
 	"github.com/juju/juju/tools"
@@ -165,6 +166,17 @@ func (env *environ) newRawInstance(ctx context.ProviderCallContext, args environ
 		return nil, common.ZoneIndependentError(err)
 	}
 
+	// In GCE all the subnets are in all AZs, so any of the subnets
+	// of the positive spaces constraints will do.
+	var subnetIds []network.Id
+	for subnetId := range args.SubnetsToZones {
+		subnetIds = append(subnetIds, subnetId)
+	}
+	networkSpec, err := env.subnetNetworkSpec(ctx, subnetIds)
+	if err != nil {
+		return nil, common.ZoneIndependentError(err)
+	}
+
 	// TODO(ericsnow) Use the env ID for the network name (instead of default)?
 	// TODO(ericsnow) Make the network name configurable?
 	// TODO(ericsnow) Support multiple networks?
@@ -173,11 +185,12 @@ func (env *environ) newRawInstance(ctx context.ProviderCallContext, args environ
 		ID:                hostname,
 		Type:              spec.InstanceType.Name,
 		Disks:             disks,
+		Network:           networkSpec,
 		NetworkInterfaces: []string{"ExternalNAT"},
 		Metadata:          metadata,
 		Tags:              tags,
 		AvailabilityZone:  args.AvailabilityZone,
-		// Network is omitted (left empty).
+		Preemptible:       args.Constraints.HasSpot(),
 	})
 	if err != nil {
 		// We currently treat all AddInstance failures
@@ -0,0 +1,57 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/dns"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/gce/google"
+)
+
+var _ environs.DNSZones = (*environ)(nil)
+
+// DNSZone is part of the environs.DNSZones interface. Records are kept
+// in the Cloud DNS managed zone for the given name.
+func (env *environ) DNSZone(ctx context.ProviderCallContext, name string) (dns.Zone, error) {
+	managedZone, err := env.gce.ManagedZone(dns.Fqdn(name))
+	if err != nil {
+		return nil, google.HandleCredentialError(errors.Trace(err), ctx)
+	}
+	return &gceZone{
+		conn:        env.gce,
+		ctx:         ctx,
+		managedZone: managedZone,
+	}, nil
+}
+
+// gceZone is a dns.Zone backed by a Cloud DNS managed zone.
+type gceZone struct {
+	conn        gceConnection
+	ctx         context.ProviderCallContext
+	managedZone string
+}
+
+// Records is part of the dns.Zone interface.
+func (z *gceZone) Records(domain string) ([]dns.Record, error) {
+	records, err := z.conn.RecordSets(z.managedZone, domain)
+	if err != nil {
+		return nil, google.HandleCredentialError(errors.Trace(err), z.ctx)
+	}
+	return records, nil
+}
+
+// SetRecord is part of the dns.Zone interface.
+func (z *gceZone) SetRecord(record dns.Record) error {
+	err := z.conn.SetRecordSet(z.managedZone, record)
+	return google.HandleCredentialError(errors.Trace(err), z.ctx)
+}
+
+// RemoveRecord is part of the dns.Zone interface.
+func (z *gceZone) RemoveRecord(record dns.Record) error {
+	err := z.conn.RemoveRecordSet(z.managedZone, record)
+	return google.HandleCredentialError(errors.Trace(err), z.ctx)
+}
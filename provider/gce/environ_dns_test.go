@@ -0,0 +1,90 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/dns"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/gce"
+)
+
+type environDNSSuite struct {
+	gce.BaseSuite
+}
+
+var _ = gc.Suite(&environDNSSuite{})
+
+func (s *environDNSSuite) zone(c *gc.C) dns.Zone {
+	zones, ok := environs.SupportsDNS(s.Env)
+	c.Assert(ok, jc.IsTrue)
+	zone, err := zones.DNSZone(s.CallCtx, "example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	return zone
+}
+
+func (s *environDNSSuite) TestDNSZone(c *gc.C) {
+	s.FakeConn.ManagedZoneName = "example-zone"
+	s.zone(c)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ManagedZone")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, "example.com.")
+}
+
+func (s *environDNSSuite) TestRecords(c *gc.C) {
+	s.FakeConn.ManagedZoneName = "example-zone"
+	s.FakeConn.Records = []dns.Record{{
+		Name:   "wordpress.m.example.com.",
+		Type:   dns.TypeA,
+		TTL:    300,
+		Values: []string{"10.0.0.1"},
+	}}
+	records, err := s.zone(c).Records("m.example.com.")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(records, jc.DeepEquals, s.FakeConn.Records)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RecordSets")
+	c.Check(s.FakeConn.Calls[1].ManagedZone, gc.Equals, "example-zone")
+	c.Check(s.FakeConn.Calls[1].ID, gc.Equals, "m.example.com.")
+}
+
+func (s *environDNSSuite) TestSetRecord(c *gc.C) {
+	s.FakeConn.ManagedZoneName = "example-zone"
+	record := dns.Record{
+		Name:   "wordpress.m.example.com.",
+		Type:   dns.TypeA,
+		TTL:    300,
+		Values: []string{"10.0.0.1"},
+	}
+	err := s.zone(c).SetRecord(record)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "SetRecordSet")
+	c.Check(s.FakeConn.Calls[1].ManagedZone, gc.Equals, "example-zone")
+	c.Check(s.FakeConn.Calls[1].Record, jc.DeepEquals, record)
+}
+
+func (s *environDNSSuite) TestRemoveRecord(c *gc.C) {
+	s.FakeConn.ManagedZoneName = "example-zone"
+	record := dns.Record{Name: "wordpress.m.example.com.", Type: dns.TypeA}
+	err := s.zone(c).RemoveRecord(record)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveRecordSet")
+	c.Check(s.FakeConn.Calls[1].Record, jc.DeepEquals, record)
+}
+
+func (s *environDNSSuite) TestDNSZoneInvalidCredentialError(c *gc.C) {
+	s.FakeConn.Err = gce.InvalidCredentialError
+	c.Assert(s.InvalidatedCredentials, jc.IsFalse)
+	_, err := s.Env.DNSZone(s.CallCtx, "example.com")
+	c.Check(err, gc.NotNil)
+	c.Assert(s.InvalidatedCredentials, jc.IsTrue)
+}
@@ -0,0 +1,80 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce
+
+import (
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/gce/google"
+)
+
+var _ environs.LoadBalancers = (*environ)(nil)
+
+// loadBalancerPrefix returns the prefix of the names of the model's
+// load balancers, which are followed by the application name.
+func (env *environ) loadBalancerPrefix() string {
+	return env.namespace.Prefix() + "lb-"
+}
+
+// EnsureLoadBalancer is part of the environs.LoadBalancers interface.
+// Each application gets a network load balancer: a target pool of its
+// instances, and a forwarding rule for each protocol it uses.
+func (env *environ) EnsureLoadBalancer(ctx context.ProviderCallContext, args environs.LoadBalancerParams) (environs.LoadBalancer, error) {
+	spec := google.LoadBalancerSpec{
+		Name:       env.loadBalancerPrefix() + args.ApplicationName,
+		PortRanges: args.Ports,
+	}
+	if len(args.Instances) > 0 {
+		ids := set.NewStrings()
+		for _, id := range args.Instances {
+			ids.Add(string(id))
+		}
+		instances, err := env.gceInstances(ctx)
+		if err != nil {
+			return environs.LoadBalancer{}, errors.Trace(err)
+		}
+		for _, inst := range instances {
+			if ids.Contains(inst.ID) {
+				spec.Instances = append(spec.Instances, inst.InstanceSummary)
+			}
+		}
+	}
+
+	ips, err := env.gce.EnsureLoadBalancer(spec)
+	if err != nil {
+		return environs.LoadBalancer{}, google.HandleCredentialError(errors.Trace(err), ctx)
+	}
+	lb := environs.LoadBalancer{Id: spec.Name}
+	for _, ip := range ips {
+		lb.Addresses = append(lb.Addresses, network.NewScopedAddress(ip, network.ScopePublic))
+	}
+	return lb, nil
+}
+
+// RemoveLoadBalancer is part of the environs.LoadBalancers interface.
+func (env *environ) RemoveLoadBalancer(ctx context.ProviderCallContext, applicationName string) error {
+	err := env.gce.RemoveLoadBalancer(env.loadBalancerPrefix() + applicationName)
+	return google.HandleCredentialError(errors.Trace(err), ctx)
+}
+
+// LoadBalancerApplications is part of the environs.LoadBalancers
+// interface.
+func (env *environ) LoadBalancerApplications(ctx context.ProviderCallContext) ([]string, error) {
+	prefix := env.loadBalancerPrefix()
+	names, err := env.gce.LoadBalancers(prefix)
+	if err != nil {
+		return nil, google.HandleCredentialError(errors.Trace(err), ctx)
+	}
+	apps := make([]string, len(names))
+	for i, name := range names {
+		apps[i] = strings.TrimPrefix(name, prefix)
+	}
+	return apps, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/gce"
+	"github.com/juju/juju/provider/gce/google"
+)
+
+type environLoadBalancerSuite struct {
+	gce.BaseSuite
+}
+
+var _ = gc.Suite(&environLoadBalancerSuite{})
+
+func (s *environLoadBalancerSuite) TestEnsureLoadBalancer(c *gc.C) {
+	s.FakeConn.Insts = []google.Instance{*s.BaseInstance}
+	s.FakeConn.LoadBalancerIPs = []string{"203.0.113.10"}
+	ports := []network.PortRange{network.MustParsePortRange("80/tcp")}
+
+	lb, err := s.Env.EnsureLoadBalancer(s.CallCtx, environs.LoadBalancerParams{
+		ApplicationName: "wordpress",
+		Ports:           ports,
+		Instances:       []instance.Id{instance.Id(s.BaseInstance.ID), "gone"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(lb, jc.DeepEquals, environs.LoadBalancer{
+		Id:        s.Prefix() + "lb-wordpress",
+		Addresses: []network.Address{network.NewScopedAddress("203.0.113.10", network.ScopePublic)},
+	})
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Instances")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "EnsureLoadBalancer")
+	c.Check(s.FakeConn.Calls[1].LoadBalancerSpec, jc.DeepEquals, google.LoadBalancerSpec{
+		Name:       s.Prefix() + "lb-wordpress",
+		Instances:  []google.InstanceSummary{s.BaseInstance.InstanceSummary},
+		PortRanges: ports,
+	})
+}
+
+func (s *environLoadBalancerSuite) TestEnsureLoadBalancerInvalidCredentialError(c *gc.C) {
+	s.FakeConn.Err = gce.InvalidCredentialError
+	c.Assert(s.InvalidatedCredentials, jc.IsFalse)
+	_, err := s.Env.EnsureLoadBalancer(s.CallCtx, environs.LoadBalancerParams{ApplicationName: "wordpress"})
+	c.Check(err, gc.NotNil)
+	c.Assert(s.InvalidatedCredentials, jc.IsTrue)
+}
+
+func (s *environLoadBalancerSuite) TestRemoveLoadBalancer(c *gc.C) {
+	err := s.Env.RemoveLoadBalancer(s.CallCtx, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "RemoveLoadBalancer")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, s.Prefix()+"lb-wordpress")
+}
+
+func (s *environLoadBalancerSuite) TestLoadBalancerApplications(c *gc.C) {
+	s.FakeConn.LoadBalancerNames = []string{s.Prefix() + "lb-mysql", s.Prefix() + "lb-wordpress"}
+
+	apps, err := s.Env.LoadBalancerApplications(s.CallCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(apps, jc.DeepEquals, []string{"mysql", "wordpress"})
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "LoadBalancers")
+	c.Check(s.FakeConn.Calls[0].Prefix, gc.Equals, s.Prefix()+"lb-")
+}
@@ -255,17 +255,85 @@ func (e *environ) subnetsByURL(ctx context.ProviderCallContext, urls []string, n
 
 // SupportsSpaces implements environs.NetworkingEnviron.
 func (e *environ) SupportsSpaces(ctx context.ProviderCallContext) (bool, error) {
-	return false, nil
+	return true, nil
 }
 
 // SupportsSpaceDiscovery implements environs.NetworkingEnviron.
 func (e *environ) SupportsSpaceDiscovery(ctx context.ProviderCallContext) (bool, error) {
-	return false, nil
+	return true, nil
 }
 
-// Spaces implements environs.NetworkingEnviron.
+// Spaces implements environs.NetworkingEnviron. Each VPC network with
+// subnets in the model's region is a space. The space is named after
+// the network's name, converted to a valid and unique space name,
+// while its provider id is the network's name itself.
 func (e *environ) Spaces(ctx context.ProviderCallContext) ([]network.SpaceInfo, error) {
-	return nil, errors.NotSupportedf("spaces")
+	subnets, err := e.Subnets(ctx, instance.UnknownId, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	networkSubnets := make(map[network.Id][]network.SubnetInfo)
+	for _, subnet := range subnets {
+		subnet.SpaceProviderId = subnet.ProviderNetworkId
+		networkSubnets[subnet.ProviderNetworkId] = append(networkSubnets[subnet.ProviderNetworkId], subnet)
+	}
+	networkNames := set.NewStrings()
+	for name := range networkSubnets {
+		networkNames.Add(string(name))
+	}
+	spaceNames := set.NewStrings()
+	var results []network.SpaceInfo
+	for _, name := range networkNames.SortedValues() {
+		spaceName := network.ConvertSpaceName(name, spaceNames)
+		spaceNames.Add(spaceName)
+		results = append(results, network.SpaceInfo{
+			Name:       spaceName,
+			ProviderId: network.Id(name),
+			Subnets:    networkSubnets[network.Id(name)],
+		})
+	}
+	return results, nil
+}
+
+// subnetNetworkSpec returns the network spec for an instance that
+// must be connected to one of the given subnets. A zero spec, for the
+// default network, is returned if there are no subnets.
+func (e *environ) subnetNetworkSpec(ctx context.ProviderCallContext, subnetIds []network.Id) (google.NetworkSpec, error) {
+	if len(subnetIds) == 0 {
+		return google.NetworkSpec{}, nil
+	}
+	ids := set.NewStrings()
+	for _, id := range subnetIds {
+		ids.Add(string(id))
+	}
+	allSubnets, err := e.gce.Subnetworks(e.cloud.Region)
+	if err != nil {
+		return google.NetworkSpec{}, google.HandleCredentialError(errors.Trace(err), ctx)
+	}
+	networks, err := e.networksByURL(ctx)
+	if err != nil {
+		return google.NetworkSpec{}, errors.Trace(err)
+	}
+	for _, id := range ids.SortedValues() {
+		for _, subnet := range allSubnets {
+			if subnet.Name != id {
+				continue
+			}
+			netwk, ok := networks[subnet.Network]
+			if !ok {
+				return google.NetworkSpec{}, errors.NotFoundf("network %q for subnet %q", subnet.Network, subnet.Name)
+			}
+			return google.NetworkSpec{Name: netwk.Name, Subnetwork: subnet.SelfLink}, nil
+		}
+		// Legacy networks have no subnetworks; they are their
+		// own subnet.
+		for _, netwk := range networks {
+			if netwk.IPv4Range != "" && netwk.Name == id {
+				return google.NetworkSpec{Name: netwk.Name}, nil
+			}
+		}
+	}
+	return google.NetworkSpec{}, errors.NotFoundf("subnets %v", formatMissing(ids.SortedValues()))
 }
 
 // SupportsContainerAddresses implements environs.NetworkingEnviron.
@@ -385,3 +385,64 @@ func (s *environNetSuite) TestInterfacesSameSubnetwork(c *gc.C) {
 		Address:           network.NewScopedAddress("10.0.10.4", network.ScopeCloudLocal),
 	}})
 }
+
+func (s *environNetSuite) TestSpaces(c *gc.C) {
+	s.cannedData()
+
+	spaces, err := s.NetEnv.Spaces(s.CallCtx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(spaces, gc.DeepEquals, []network.SpaceInfo{{
+		Name:       "albini",
+		ProviderId: "albini",
+		Subnets: []network.SubnetInfo{{
+			ProviderId:        "shellac",
+			ProviderNetworkId: "albini",
+			CIDR:              "10.0.20.0/24",
+			AvailabilityZones: []string{"a-zone", "b-zone"},
+			SpaceProviderId:   "albini",
+		}},
+	}, {
+		Name:       "go-team1",
+		ProviderId: "go-team1",
+		Subnets: []network.SubnetInfo{{
+			ProviderId:        "go-team",
+			ProviderNetworkId: "go-team1",
+			CIDR:              "10.0.10.0/24",
+			AvailabilityZones: []string{"a-zone", "b-zone"},
+			SpaceProviderId:   "go-team1",
+		}},
+	}, {
+		Name:       "legacy",
+		ProviderId: "legacy",
+		Subnets: []network.SubnetInfo{{
+			ProviderId:        "legacy",
+			ProviderNetworkId: "legacy",
+			CIDR:              "10.240.0.0/16",
+			AvailabilityZones: []string{"a-zone", "b-zone"},
+			SpaceProviderId:   "legacy",
+		}},
+	}})
+}
+
+func (s *environNetSuite) TestSubnetNetworkSpec(c *gc.C) {
+	s.cannedData()
+
+	spec, err := gce.SubnetNetworkSpec(s.Env, s.CallCtx, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, gc.Equals, google.NetworkSpec{})
+
+	spec, err = gce.SubnetNetworkSpec(s.Env, s.CallCtx, []network.Id{"shellac"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, gc.Equals, google.NetworkSpec{
+		Name:       "albini",
+		Subnetwork: "https://www.googleapis.com/compute/v1/projects/sonic-youth/regions/asia-east1/subnetworks/shellac",
+	})
+
+	spec, err = gce.SubnetNetworkSpec(s.Env, s.CallCtx, []network.Id{"legacy"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, gc.Equals, google.NetworkSpec{Name: "legacy"})
+
+	_, err = gce.SubnetNetworkSpec(s.Env, s.CallCtx, []network.Id{"brunettes"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
 )
 
 // PrecheckInstance verifies that the provided series and constraints
@@ -18,7 +19,11 @@ func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args envir
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if _, err := env.instancePlacementZone(ctx, args.Placement, volumeAttachmentsZone); err != nil {
+	zone, err := env.instancePlacementZone(ctx, args.Placement, volumeAttachmentsZone)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := common.ValidateZonesConstraint(env, ctx, args.Constraints, zone); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -34,6 +39,8 @@ func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args envir
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
+	// Preemptible instances have a fixed price.
+	constraints.MaxPrice,
 }
 
 // instanceTypeConstraints defines the fields defined on each of the
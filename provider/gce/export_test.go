@@ -9,6 +9,7 @@ import (
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/gce/google"
 )
 
@@ -67,6 +68,10 @@ func NewRawInstance(env *environ, ctx context.ProviderCallContext, args environs
 	return env.newRawInstance(ctx, args, spec)
 }
 
+func SubnetNetworkSpec(env *environ, ctx context.ProviderCallContext, subnetIds []network.Id) (google.NetworkSpec, error) {
+	return env.subnetNetworkSpec(ctx, subnetIds)
+}
+
 func GetHardwareCharacteristics(env *environ, spec *instances.InstanceSpec, inst *environInstance) *instance.HardwareCharacteristics {
 	return env.getHardwareCharacteristics(spec, inst)
 }
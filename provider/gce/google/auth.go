@@ -4,10 +4,13 @@
 package google
 
 import (
+	"net/http"
+
 	"github.com/juju/errors"
 	"golang.org/x/oauth2"
 	goauth2 "golang.org/x/oauth2/google"
 	"google.golang.org/api/compute/v1"
+	clouddns "google.golang.org/api/dns/v1"
 )
 
 var (
@@ -15,12 +18,37 @@ var (
 		"https://www.googleapis.com/auth/compute",
 		"https://www.googleapis.com/auth/devstorage.full_control",
 	}
+	dnsScopes = []string{
+		"https://www.googleapis.com/auth/ndev.clouddns.readwrite",
+	}
 )
 
 // newConnection opens a new low-level connection to the GCE API using
 // the Auth's data and returns it. This includes building the
 // OAuth-wrapping network transport.
 func newConnection(creds *Credentials) (*compute.Service, error) {
+	client, err := newClient(creds, driverScopes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	service, err := compute.New(client)
+	return service, errors.Trace(err)
+}
+
+// newDNSConnection opens a new low-level connection to the Cloud DNS
+// API using the Auth's data and returns it.
+func newDNSConnection(creds *Credentials) (*clouddns.Service, error) {
+	client, err := newClient(creds, dnsScopes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	service, err := clouddns.New(client)
+	return service, errors.Trace(err)
+}
+
+// newClient returns an HTTP client that authenticates its requests
+// using the credentials, with the given scopes.
+func newClient(creds *Credentials, scopes []string) (*http.Client, error) {
 	jsonKey := creds.JSONKey
 	if jsonKey == nil {
 		built, err := creds.buildJSONKey()
@@ -29,11 +57,9 @@ func newConnection(creds *Credentials) (*compute.Service, error) {
 		}
 		jsonKey = built
 	}
-	cfg, err := goauth2.JWTConfigFromJSON(jsonKey, driverScopes...)
+	cfg, err := goauth2.JWTConfigFromJSON(jsonKey, scopes...)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	client := cfg.Client(oauth2.NoContext)
-	service, err := compute.New(client)
-	return service, errors.Trace(err)
+	return cfg.Client(oauth2.NoContext), nil
 }
@@ -18,3 +18,8 @@ func (s *authSuite) TestNewConnection(c *gc.C) {
 	_, err := newConnection(s.Credentials)
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *authSuite) TestNewDNSConnection(c *gc.C) {
+	_, err := newDNSConnection(s.Credentials)
+	c.Assert(err, jc.ErrorIsNil)
+}
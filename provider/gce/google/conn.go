@@ -6,6 +6,7 @@ package google
 import (
 	"github.com/juju/errors"
 	"google.golang.org/api/compute/v1"
+	clouddns "google.golang.org/api/dns/v1"
 )
 
 // rawConnectionWrapper facilitates mocking out the GCE API during tests.
@@ -104,6 +105,76 @@ type rawConnectionWrapper interface {
 
 	// ListNetworks returns a list of Networks available in the given project.
 	ListNetworks(projectID string) ([]*compute.Network, error)
+
+	// GetTargetPool returns the named target pool in the given project
+	// and region. If it does not exist, an error satisfying
+	// errors.IsNotFound is returned.
+	GetTargetPool(projectID, region, name string) (*compute.TargetPool, error)
+
+	// ListTargetPools returns the target pools in the given project and
+	// region whose names start with the prefix.
+	ListTargetPools(projectID, region, prefix string) ([]*compute.TargetPool, error)
+
+	// AddTargetPool creates a target pool with the provided info. The
+	// call blocks until the pool is created or the request fails.
+	AddTargetPool(projectID, region string, pool *compute.TargetPool) error
+
+	// RemoveTargetPool removes the named target pool. If it does not
+	// exist, an error satisfying errors.IsNotFound is returned. The
+	// call blocks until the pool is removed or the request fails.
+	RemoveTargetPool(projectID, region, name string) error
+
+	// AddTargetPoolInstances adds the instances, identified by their
+	// URLs, to the named target pool.
+	AddTargetPoolInstances(projectID, region, name string, instanceURLs []string) error
+
+	// RemoveTargetPoolInstances removes the instances, identified by
+	// their URLs, from the named target pool.
+	RemoveTargetPoolInstances(projectID, region, name string, instanceURLs []string) error
+
+	// ListForwardingRules returns the forwarding rules in the given
+	// project and region whose names start with the prefix.
+	ListForwardingRules(projectID, region, prefix string) ([]*compute.ForwardingRule, error)
+
+	// AddForwardingRule creates a forwarding rule with the provided
+	// info. The call blocks until the rule is created or the request
+	// fails.
+	AddForwardingRule(projectID, region string, rule *compute.ForwardingRule) error
+
+	// RemoveForwardingRule removes the named forwarding rule. If it
+	// does not exist, an error satisfying errors.IsNotFound is
+	// returned. The call blocks until the rule is removed or the
+	// request fails.
+	RemoveForwardingRule(projectID, region, name string) error
+
+	// GetAddress returns the named address in the given project and
+	// region. If it does not exist, an error satisfying
+	// errors.IsNotFound is returned.
+	GetAddress(projectID, region, name string) (*compute.Address, error)
+
+	// AddAddress reserves an address with the provided info. The call
+	// blocks until the address is reserved or the request fails.
+	AddAddress(projectID, region string, address *compute.Address) error
+
+	// RemoveAddress releases the named address. If it does not exist,
+	// an error satisfying errors.IsNotFound is returned. The call
+	// blocks until the address is released or the request fails.
+	RemoveAddress(projectID, region, name string) error
+
+	// ListManagedZones returns the Cloud DNS managed zones in the given
+	// project for the fully qualified DNS name.
+	ListManagedZones(projectID, dnsName string) ([]*clouddns.ManagedZone, error)
+
+	// ListResourceRecordSets returns the record sets in the managed
+	// zone. If name or recordType are not empty, only the record sets
+	// with that name or type are returned.
+	ListResourceRecordSets(projectID, managedZone, name, recordType string) ([]*clouddns.ResourceRecordSet, error)
+
+	// ChangeResourceRecordSets requests that the change's deletions
+	// and additions are made to the managed zone's record sets. The
+	// change is applied atomically, but the call does not wait for it
+	// to be applied.
+	ChangeResourceRecordSets(projectID, managedZone string, change *clouddns.Change) error
 }
 
 // TODO(ericsnow) Add specific error types for common failures
@@ -132,9 +203,13 @@ func Connect(connCfg ConnectionConfig, creds *Credentials) (*Connection, error)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	dnsService, err := newRawDNSConnection(creds)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	conn := &Connection{
-		raw:       &rawConn{raw},
+		raw:       &rawConn{Service: raw, dnsService: dnsService},
 		region:    connCfg.Region,
 		projectID: connCfg.ProjectID,
 	}
@@ -145,6 +220,10 @@ var newRawConnection = func(creds *Credentials) (*compute.Service, error) {
 	return newConnection(creds)
 }
 
+var newRawDNSConnection = func(creds *Credentials) (*clouddns.Service, error) {
+	return newDNSConnection(creds)
+}
+
 // TODO(ericsnow) Verify in each method that Connection.raw is set?
 
 // VerifyCredentials ensures that the authentication credentials used
@@ -0,0 +1,112 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package google
+
+import (
+	"github.com/juju/errors"
+	clouddns "google.golang.org/api/dns/v1"
+
+	"github.com/juju/juju/dns"
+)
+
+// ManagedZone returns the name of the Cloud DNS managed zone for the
+// fully qualified DNS name. If there is no such zone, an error
+// satisfying errors.IsNotFound is returned.
+func (gce Connection) ManagedZone(dnsName string) (string, error) {
+	zones, err := gce.raw.ListManagedZones(gce.projectID, dnsName)
+	if err != nil {
+		return "", errors.Annotate(err, "listing managed zones")
+	}
+	for _, zone := range zones {
+		if dns.Fqdn(zone.DnsName) == dns.Fqdn(dnsName) {
+			return zone.Name, nil
+		}
+	}
+	return "", errors.NotFoundf("managed zone for %q", dnsName)
+}
+
+func newRecord(rrset *clouddns.ResourceRecordSet) dns.Record {
+	return dns.Record{
+		Name:   dns.Fqdn(rrset.Name),
+		Type:   rrset.Type,
+		TTL:    int(rrset.Ttl),
+		Values: rrset.Rrdatas,
+	}
+}
+
+// RecordSets returns the A and AAAA record sets in the managed zone
+// whose names are the fully qualified domain, or are within it.
+func (gce Connection) RecordSets(managedZone, domain string) ([]dns.Record, error) {
+	rrsets, err := gce.raw.ListResourceRecordSets(gce.projectID, managedZone, "", "")
+	if err != nil {
+		return nil, errors.Annotate(err, "listing record sets")
+	}
+	var records []dns.Record
+	for _, rrset := range rrsets {
+		if rrset.Type != dns.TypeA && rrset.Type != dns.TypeAAAA {
+			continue
+		}
+		if !dns.InDomain(rrset.Name, domain) {
+			continue
+		}
+		records = append(records, newRecord(rrset))
+	}
+	dns.SortRecords(records)
+	return records, nil
+}
+
+// recordSet returns the record set in the managed zone with the
+// record's name and type, or nil if there is none.
+func (gce Connection) recordSet(managedZone string, record dns.Record) (*clouddns.ResourceRecordSet, error) {
+	rrsets, err := gce.raw.ListResourceRecordSets(gce.projectID, managedZone, dns.Fqdn(record.Name), record.Type)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing record sets")
+	}
+	if len(rrsets) == 0 {
+		return nil, nil
+	}
+	return rrsets[0], nil
+}
+
+// SetRecordSet creates the record set in the managed zone, replacing
+// any existing record set with the same name and type.
+func (gce Connection) SetRecordSet(managedZone string, record dns.Record) error {
+	existing, err := gce.recordSet(managedZone, record)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// A record set is replaced by deleting the existing one, with
+	// its exact values, in the same change as the addition.
+	change := &clouddns.Change{
+		Additions: []*clouddns.ResourceRecordSet{{
+			Kind:    "dns#resourceRecordSet",
+			Name:    dns.Fqdn(record.Name),
+			Type:    record.Type,
+			Ttl:     int64(record.TTL),
+			Rrdatas: record.Values,
+		}},
+	}
+	if existing != nil {
+		change.Deletions = []*clouddns.ResourceRecordSet{existing}
+	}
+	err = gce.raw.ChangeResourceRecordSets(gce.projectID, managedZone, change)
+	return errors.Annotatef(err, "setting %s records for %q", record.Type, record.Name)
+}
+
+// RemoveRecordSet removes the record set in the managed zone with the
+// record's name and type. If there is no such record set, an error
+// satisfying errors.IsNotFound is returned.
+func (gce Connection) RemoveRecordSet(managedZone string, record dns.Record) error {
+	existing, err := gce.recordSet(managedZone, record)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if existing == nil {
+		return errors.NotFoundf("%s records for %q", record.Type, dns.Fqdn(record.Name))
+	}
+	err = gce.raw.ChangeResourceRecordSets(gce.projectID, managedZone, &clouddns.Change{
+		Deletions: []*clouddns.ResourceRecordSet{existing},
+	})
+	return errors.Annotatef(err, "removing %s records for %q", record.Type, record.Name)
+}
@@ -0,0 +1,126 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package google_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	clouddns "google.golang.org/api/dns/v1"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/dns"
+)
+
+func (s *connSuite) TestManagedZone(c *gc.C) {
+	s.FakeConn.ManagedZones = []*clouddns.ManagedZone{
+		{Name: "example-com", DnsName: "example.com."},
+	}
+
+	name, err := s.Conn.ManagedZone("example.com.")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(name, gc.Equals, "example-com")
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListManagedZones")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, "example.com.")
+}
+
+func (s *connSuite) TestManagedZoneNotFound(c *gc.C) {
+	_, err := s.Conn.ManagedZone("example.com.")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `managed zone for "example.com." not found`)
+}
+
+func (s *connSuite) TestRecordSets(c *gc.C) {
+	s.FakeConn.RecordSets = []*clouddns.ResourceRecordSet{
+		{Name: "example.com.", Type: "SOA", Ttl: 900, Rrdatas: []string{"ns.example.com. hostmaster.example.com. 1 7200 900 1209600 86400"}},
+		{Name: "wordpress.m.example.com.", Type: "AAAA", Ttl: 300, Rrdatas: []string{"2001:db8::1"}},
+		{Name: "wordpress.m.example.com.", Type: "A", Ttl: 300, Rrdatas: []string{"10.0.0.1"}},
+		{Name: "m.example.com.", Type: "TXT", Ttl: 300, Rrdatas: []string{`"hello"`}},
+		{Name: "www.example.com.", Type: "A", Ttl: 300, Rrdatas: []string{"192.0.2.1"}},
+	}
+
+	records, err := s.Conn.RecordSets("example-com", "m.example.com.")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(records, jc.DeepEquals, []dns.Record{
+		{"wordpress.m.example.com.", dns.TypeA, 300, []string{"10.0.0.1"}},
+		{"wordpress.m.example.com.", dns.TypeAAAA, 300, []string{"2001:db8::1"}},
+	})
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].ManagedZone, gc.Equals, "example-com")
+}
+
+func (s *connSuite) TestSetRecordSetCreates(c *gc.C) {
+	err := s.Conn.SetRecordSet("example-com", dns.Record{
+		Name:   "wordpress.m.example.com",
+		Type:   dns.TypeA,
+		TTL:    60,
+		Values: []string{"10.0.0.1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	calls := s.FakeConn.Calls
+	c.Assert(calls, gc.HasLen, 2)
+	c.Check(calls[0].FuncName, gc.Equals, "ListResourceRecordSets")
+	c.Check(calls[0].Name, gc.Equals, "wordpress.m.example.com.")
+	c.Check(calls[0].RecordType, gc.Equals, "A")
+	c.Check(calls[1].FuncName, gc.Equals, "ChangeResourceRecordSets")
+	c.Check(calls[1].DNSChange, jc.DeepEquals, &clouddns.Change{
+		Additions: []*clouddns.ResourceRecordSet{{
+			Kind:    "dns#resourceRecordSet",
+			Name:    "wordpress.m.example.com.",
+			Type:    "A",
+			Ttl:     60,
+			Rrdatas: []string{"10.0.0.1"},
+		}},
+	})
+}
+
+func (s *connSuite) TestSetRecordSetReplaces(c *gc.C) {
+	existing := &clouddns.ResourceRecordSet{
+		Name:    "wordpress.m.example.com.",
+		Type:    "A",
+		Ttl:     300,
+		Rrdatas: []string{"10.0.0.2"},
+	}
+	s.FakeConn.RecordSets = []*clouddns.ResourceRecordSet{existing}
+
+	err := s.Conn.SetRecordSet("example-com", dns.Record{
+		Name:   "wordpress.m.example.com.",
+		Type:   dns.TypeA,
+		TTL:    60,
+		Values: []string{"10.0.0.1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	calls := s.FakeConn.Calls
+	c.Assert(calls, gc.HasLen, 2)
+	c.Check(calls[1].DNSChange.Deletions, jc.DeepEquals, []*clouddns.ResourceRecordSet{existing})
+	c.Check(calls[1].DNSChange.Additions, gc.HasLen, 1)
+}
+
+func (s *connSuite) TestRemoveRecordSet(c *gc.C) {
+	existing := &clouddns.ResourceRecordSet{
+		Name:    "wordpress.m.example.com.",
+		Type:    "A",
+		Ttl:     300,
+		Rrdatas: []string{"10.0.0.2"},
+	}
+	s.FakeConn.RecordSets = []*clouddns.ResourceRecordSet{existing}
+
+	err := s.Conn.RemoveRecordSet("example-com", dns.Record{Name: "wordpress.m.example.com.", Type: dns.TypeA})
+	c.Assert(err, jc.ErrorIsNil)
+
+	calls := s.FakeConn.Calls
+	c.Assert(calls, gc.HasLen, 2)
+	c.Check(calls[1].DNSChange, jc.DeepEquals, &clouddns.Change{
+		Deletions: []*clouddns.ResourceRecordSet{existing},
+	})
+}
+
+func (s *connSuite) TestRemoveRecordSetNotFound(c *gc.C) {
+	err := s.Conn.RemoveRecordSet("example-com", dns.Record{Name: "wordpress.m.example.com.", Type: dns.TypeA})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+}
@@ -0,0 +1,266 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package google
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/juju/juju/network"
+)
+
+const instanceURLBase = "https://www.googleapis.com/compute/v1/projects/%s/zones/%s/instances/%s"
+
+// loadBalancerProtocols are the protocols that a network load balancer
+// can forward.
+var loadBalancerProtocols = []string{"tcp", "udp"}
+
+// LoadBalancerSpec describes a network load balancer. It is made up of
+// a target pool of instances, a reserved address, and a forwarding rule
+// from the address to the pool for each range of ports. All of the
+// load balancer's resources are named after it.
+type LoadBalancerSpec struct {
+	// Name is the name of the load balancer.
+	Name string
+
+	// Instances are the instances to send traffic to.
+	Instances []InstanceSummary
+
+	// PortRanges are the port ranges to forward. Overlapping and
+	// adjacent ranges are forwarded by a single rule.
+	PortRanges []network.PortRange
+}
+
+func (gce Connection) instanceURL(inst InstanceSummary) string {
+	return fmt.Sprintf(instanceURLBase, gce.projectID, inst.ZoneName, inst.ID)
+}
+
+func forwardingRuleName(lbName string, pr network.PortRange) string {
+	return fmt.Sprintf("%s-%s-%d-%d", lbName, strings.ToLower(pr.Protocol), pr.FromPort, pr.ToPort)
+}
+
+// forwardingRuleNameRE returns a regular expression matching the names
+// of the named load balancer's forwarding rules only. Application names
+// cannot have a part made of digits alone, so the rules of another load
+// balancer whose name starts with this one's are not matched.
+func forwardingRuleNameRE(lbName string) *regexp.Regexp {
+	return regexp.MustCompile("^" + regexp.QuoteMeta(lbName) + "-(" +
+		strings.Join(loadBalancerProtocols, "|") + `)-\d+-\d+$`)
+}
+
+// mergePortRanges returns the port ranges of the given protocol,
+// sorted, with overlapping and adjacent ranges merged. Forwarding rules
+// that share an address must not overlap.
+func mergePortRanges(protocol string, portRanges []network.PortRange) []network.PortRange {
+	var ranges []network.PortRange
+	for _, pr := range portRanges {
+		if strings.ToLower(pr.Protocol) == protocol {
+			ranges = append(ranges, pr)
+		}
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].FromPort < ranges[j].FromPort
+	})
+	var merged []network.PortRange
+	for _, pr := range ranges {
+		if n := len(merged); n > 0 && pr.FromPort <= merged[n-1].ToPort+1 {
+			if pr.ToPort > merged[n-1].ToPort {
+				merged[n-1].ToPort = pr.ToPort
+			}
+			continue
+		}
+		merged = append(merged, network.PortRange{
+			FromPort: pr.FromPort,
+			ToPort:   pr.ToPort,
+			Protocol: protocol,
+		})
+	}
+	return merged
+}
+
+// forwardingRules returns the forwarding rules required by the spec,
+// keyed by name, forwarding traffic sent to the given IP address. The
+// rules' targets are left for the caller to set.
+func (spec LoadBalancerSpec) forwardingRules(ipAddress string) map[string]*compute.ForwardingRule {
+	rules := make(map[string]*compute.ForwardingRule)
+	for _, protocol := range loadBalancerProtocols {
+		for _, pr := range mergePortRanges(protocol, spec.PortRanges) {
+			name := forwardingRuleName(spec.Name, pr)
+			rules[name] = &compute.ForwardingRule{
+				Name:       name,
+				IPAddress:  ipAddress,
+				IPProtocol: strings.ToUpper(protocol),
+				PortRange:  fmt.Sprintf("%d-%d", pr.FromPort, pr.ToPort),
+			}
+		}
+	}
+	return rules
+}
+
+// EnsureLoadBalancer creates or updates the network load balancer
+// described by the spec, and returns its IP address. The address is
+// reserved when the load balancer is created, and so stays the same
+// as its ports change.
+func (gce Connection) EnsureLoadBalancer(spec LoadBalancerSpec) ([]string, error) {
+	pool, err := gce.ensureTargetPool(spec)
+	if err != nil {
+		return nil, errors.Annotatef(err, "ensuring target pool %q", spec.Name)
+	}
+	ipAddress, err := gce.ensureAddress(spec.Name)
+	if err != nil {
+		return nil, errors.Annotatef(err, "ensuring address %q", spec.Name)
+	}
+
+	wanted := spec.forwardingRules(ipAddress)
+	existing, err := gce.loadBalancerForwardingRules(spec.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Rules are removed before any are added, so that the ranges of
+	// the rules on the address never overlap.
+	for _, name := range sortedRuleNames(existing) {
+		rule := existing[name]
+		if _, ok := wanted[name]; ok && rule.Target == pool.SelfLink && rule.IPAddress == ipAddress {
+			continue
+		}
+		if err := gce.raw.RemoveForwardingRule(gce.projectID, gce.region, name); err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Annotatef(err, "removing forwarding rule %q", name)
+		}
+		delete(existing, name)
+	}
+	for _, name := range sortedRuleNames(wanted) {
+		if _, ok := existing[name]; ok {
+			continue
+		}
+		rule := wanted[name]
+		rule.Target = pool.SelfLink
+		if err := gce.raw.AddForwardingRule(gce.projectID, gce.region, rule); err != nil {
+			return nil, errors.Annotatef(err, "adding forwarding rule %q", name)
+		}
+	}
+	return []string{ipAddress}, nil
+}
+
+func sortedRuleNames(rules map[string]*compute.ForwardingRule) []string {
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ensureAddress reserves the named regional address if it is not
+// already reserved, and returns its IP address.
+func (gce Connection) ensureAddress(name string) (string, error) {
+	address, err := gce.raw.GetAddress(gce.projectID, gce.region, name)
+	if errors.IsNotFound(err) {
+		if err := gce.raw.AddAddress(gce.projectID, gce.region, &compute.Address{Name: name}); err != nil {
+			return "", errors.Trace(err)
+		}
+		address, err = gce.raw.GetAddress(gce.projectID, gce.region, name)
+	}
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return address.Address, nil
+}
+
+// ensureTargetPool creates the spec's target pool if it does not exist,
+// and otherwise updates its instances to be exactly those in the spec.
+func (gce Connection) ensureTargetPool(spec LoadBalancerSpec) (*compute.TargetPool, error) {
+	wanted := set.NewStrings()
+	for _, inst := range spec.Instances {
+		wanted.Add(gce.instanceURL(inst))
+	}
+	pool, err := gce.raw.GetTargetPool(gce.projectID, gce.region, spec.Name)
+	if errors.IsNotFound(err) {
+		err := gce.raw.AddTargetPool(gce.projectID, gce.region, &compute.TargetPool{
+			Name:      spec.Name,
+			Instances: wanted.SortedValues(),
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		pool, err = gce.raw.GetTargetPool(gce.projectID, gce.region, spec.Name)
+		return pool, errors.Trace(err)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	current := set.NewStrings(pool.Instances...)
+	if missing := wanted.Difference(current); !missing.IsEmpty() {
+		if err := gce.raw.AddTargetPoolInstances(gce.projectID, gce.region, spec.Name, missing.SortedValues()); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if extra := current.Difference(wanted); !extra.IsEmpty() {
+		if err := gce.raw.RemoveTargetPoolInstances(gce.projectID, gce.region, spec.Name, extra.SortedValues()); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return pool, nil
+}
+
+// loadBalancerForwardingRules returns the named load balancer's
+// forwarding rules, keyed by name.
+func (gce Connection) loadBalancerForwardingRules(lbName string) (map[string]*compute.ForwardingRule, error) {
+	rules, err := gce.raw.ListForwardingRules(gce.projectID, gce.region, lbName)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing forwarding rules")
+	}
+	nameRE := forwardingRuleNameRE(lbName)
+	result := make(map[string]*compute.ForwardingRule)
+	for _, rule := range rules {
+		// Other load balancers' names may start with this one's.
+		if nameRE.MatchString(rule.Name) {
+			result[rule.Name] = rule
+		}
+	}
+	return result, nil
+}
+
+// RemoveLoadBalancer removes the named network load balancer. If there
+// is no such load balancer, an error satisfying errors.IsNotFound is
+// returned.
+func (gce Connection) RemoveLoadBalancer(name string) error {
+	rules, err := gce.loadBalancerForwardingRules(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, ruleName := range sortedRuleNames(rules) {
+		if err := gce.raw.RemoveForwardingRule(gce.projectID, gce.region, ruleName); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing forwarding rule %q", ruleName)
+		}
+	}
+	if err := gce.raw.RemoveAddress(gce.projectID, gce.region, name); err != nil && !errors.IsNotFound(err) {
+		return errors.Annotatef(err, "removing address %q", name)
+	}
+	err = gce.raw.RemoveTargetPool(gce.projectID, gce.region, name)
+	if errors.IsNotFound(err) {
+		return errors.NotFoundf("load balancer %q", name)
+	}
+	return errors.Annotatef(err, "removing target pool %q", name)
+}
+
+// LoadBalancers returns the names of the network load balancers in the
+// Connection's region whose names start with the prefix.
+func (gce Connection) LoadBalancers(prefix string) ([]string, error) {
+	pools, err := gce.raw.ListTargetPools(gce.projectID, gce.region, prefix)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing target pools")
+	}
+	var names []string
+	for _, pool := range pools {
+		names = append(names, pool.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
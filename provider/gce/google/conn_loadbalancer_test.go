@@ -0,0 +1,239 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package google_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"google.golang.org/api/compute/v1"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/gce/google"
+)
+
+const instanceURLPrefix = "https://www.googleapis.com/compute/v1/projects/spam/zones/"
+
+func (s *connSuite) loadBalancerSpec() google.LoadBalancerSpec {
+	return google.LoadBalancerSpec{
+		Name: "lb",
+		Instances: []google.InstanceSummary{
+			{ID: "inst-b", ZoneName: "a-zone"},
+			{ID: "inst-c", ZoneName: "b-zone"},
+		},
+		PortRanges: []network.PortRange{
+			network.MustParsePortRange("80/tcp"),
+			network.MustParsePortRange("443/tcp"),
+			network.MustParsePortRange("53/udp"),
+		},
+	}
+}
+
+func (s *connSuite) TestEnsureLoadBalancerCreates(c *gc.C) {
+	s.FakeConn.Err = errors.NotFoundf("target pool")
+	s.FakeConn.FailOnCall = 0
+	s.FakeConn.TargetPool = &compute.TargetPool{Name: "lb", SelfLink: "pool-link"}
+	s.FakeConn.Address = &compute.Address{Name: "lb", Address: "203.0.113.10"}
+
+	addrs, err := s.Conn.EnsureLoadBalancer(s.loadBalancerSpec())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addrs, jc.DeepEquals, []string{"203.0.113.10"})
+
+	calls := s.FakeConn.Calls
+	c.Assert(calls, gc.HasLen, 8)
+	c.Check(calls[0].FuncName, gc.Equals, "GetTargetPool")
+	c.Check(calls[0].Region, gc.Equals, "a")
+	c.Check(calls[0].Name, gc.Equals, "lb")
+	c.Check(calls[1].FuncName, gc.Equals, "AddTargetPool")
+	c.Check(calls[1].TargetPool, jc.DeepEquals, &compute.TargetPool{
+		Name: "lb",
+		Instances: []string{
+			instanceURLPrefix + "a-zone/instances/inst-b",
+			instanceURLPrefix + "b-zone/instances/inst-c",
+		},
+	})
+	c.Check(calls[2].FuncName, gc.Equals, "GetTargetPool")
+	c.Check(calls[3].FuncName, gc.Equals, "GetAddress")
+	c.Check(calls[3].Name, gc.Equals, "lb")
+	c.Check(calls[4].FuncName, gc.Equals, "ListForwardingRules")
+	c.Check(calls[4].Prefix, gc.Equals, "lb")
+	// Each port range gets its own rule on the load balancer's address.
+	c.Check(calls[5].FuncName, gc.Equals, "AddForwardingRule")
+	c.Check(calls[5].ForwardingRule, jc.DeepEquals, &compute.ForwardingRule{
+		Name:       "lb-tcp-443-443",
+		IPAddress:  "203.0.113.10",
+		IPProtocol: "TCP",
+		PortRange:  "443-443",
+		Target:     "pool-link",
+	})
+	c.Check(calls[6].FuncName, gc.Equals, "AddForwardingRule")
+	c.Check(calls[6].ForwardingRule, jc.DeepEquals, &compute.ForwardingRule{
+		Name:       "lb-tcp-80-80",
+		IPAddress:  "203.0.113.10",
+		IPProtocol: "TCP",
+		PortRange:  "80-80",
+		Target:     "pool-link",
+	})
+	c.Check(calls[7].FuncName, gc.Equals, "AddForwardingRule")
+	c.Check(calls[7].ForwardingRule, jc.DeepEquals, &compute.ForwardingRule{
+		Name:       "lb-udp-53-53",
+		IPAddress:  "203.0.113.10",
+		IPProtocol: "UDP",
+		PortRange:  "53-53",
+		Target:     "pool-link",
+	})
+}
+
+func (s *connSuite) TestEnsureLoadBalancerReservesAddress(c *gc.C) {
+	s.FakeConn.Err = errors.NotFoundf("address")
+	s.FakeConn.FailOnCall = 1
+	s.FakeConn.TargetPool = &compute.TargetPool{Name: "lb", SelfLink: "pool-link"}
+	s.FakeConn.Address = &compute.Address{Name: "lb", Address: "203.0.113.10"}
+	spec := s.loadBalancerSpec()
+	spec.Instances = nil
+	spec.PortRanges = nil
+
+	addrs, err := s.Conn.EnsureLoadBalancer(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addrs, jc.DeepEquals, []string{"203.0.113.10"})
+
+	calls := s.FakeConn.Calls
+	c.Assert(calls, gc.HasLen, 5)
+	c.Check(calls[0].FuncName, gc.Equals, "GetTargetPool")
+	c.Check(calls[1].FuncName, gc.Equals, "GetAddress")
+	c.Check(calls[2].FuncName, gc.Equals, "AddAddress")
+	c.Check(calls[2].Region, gc.Equals, "a")
+	c.Check(calls[2].Address, jc.DeepEquals, &compute.Address{Name: "lb"})
+	c.Check(calls[3].FuncName, gc.Equals, "GetAddress")
+	c.Check(calls[4].FuncName, gc.Equals, "ListForwardingRules")
+}
+
+func (s *connSuite) TestEnsureLoadBalancerMergesPortRanges(c *gc.C) {
+	s.FakeConn.TargetPool = &compute.TargetPool{Name: "lb", SelfLink: "pool-link"}
+	s.FakeConn.Address = &compute.Address{Name: "lb", Address: "203.0.113.10"}
+	spec := s.loadBalancerSpec()
+	spec.Instances = nil
+	spec.PortRanges = []network.PortRange{
+		network.MustParsePortRange("8000-8080/tcp"),
+		network.MustParsePortRange("80/tcp"),
+		network.MustParsePortRange("8081/tcp"),
+		network.MustParsePortRange("8050-8060/tcp"),
+		network.MustParsePortRange("80/udp"),
+	}
+
+	_, err := s.Conn.EnsureLoadBalancer(spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var added []string
+	for _, call := range s.FakeConn.Calls {
+		if call.FuncName == "AddForwardingRule" {
+			added = append(added, call.ForwardingRule.Name+" "+call.ForwardingRule.PortRange)
+		}
+	}
+	c.Check(added, jc.DeepEquals, []string{
+		"lb-tcp-80-80 80-80",
+		"lb-tcp-8000-8081 8000-8081",
+		"lb-udp-80-80 80-80",
+	})
+}
+
+func (s *connSuite) TestEnsureLoadBalancerUpdates(c *gc.C) {
+	s.FakeConn.TargetPool = &compute.TargetPool{
+		Name:     "lb",
+		SelfLink: "pool-link",
+		Instances: []string{
+			instanceURLPrefix + "a-zone/instances/inst-a",
+			instanceURLPrefix + "a-zone/instances/inst-b",
+		},
+	}
+	s.FakeConn.Address = &compute.Address{Name: "lb", Address: "203.0.113.10"}
+	s.FakeConn.ForwardingRules = []*compute.ForwardingRule{{
+		Name:      "lb-tcp-80-80",
+		PortRange: "80-80",
+		Target:    "pool-link",
+		IPAddress: "203.0.113.10",
+	}, {
+		// No longer wanted.
+		Name:      "lb-tcp-8080-8080",
+		PortRange: "8080-8080",
+		Target:    "pool-link",
+		IPAddress: "203.0.113.10",
+	}, {
+		// Not on the load balancer's address.
+		Name:      "lb-udp-53-53",
+		PortRange: "53-53",
+		Target:    "pool-link",
+		IPAddress: "203.0.113.11",
+	}, {
+		// Belongs to the load balancer for another application.
+		Name:      "lb-other-tcp-22-22",
+		PortRange: "22-22",
+		Target:    "other-pool-link",
+		IPAddress: "203.0.113.12",
+	}}
+
+	addrs, err := s.Conn.EnsureLoadBalancer(s.loadBalancerSpec())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addrs, jc.DeepEquals, []string{"203.0.113.10"})
+
+	calls := s.FakeConn.Calls
+	c.Assert(calls, gc.HasLen, 9)
+	c.Check(calls[0].FuncName, gc.Equals, "GetTargetPool")
+	c.Check(calls[1].FuncName, gc.Equals, "AddTargetPoolInstances")
+	c.Check(calls[1].InstanceURLs, jc.DeepEquals, []string{instanceURLPrefix + "b-zone/instances/inst-c"})
+	c.Check(calls[2].FuncName, gc.Equals, "RemoveTargetPoolInstances")
+	c.Check(calls[2].InstanceURLs, jc.DeepEquals, []string{instanceURLPrefix + "a-zone/instances/inst-a"})
+	c.Check(calls[3].FuncName, gc.Equals, "GetAddress")
+	c.Check(calls[4].FuncName, gc.Equals, "ListForwardingRules")
+	c.Check(calls[5].FuncName, gc.Equals, "RemoveForwardingRule")
+	c.Check(calls[5].Name, gc.Equals, "lb-tcp-8080-8080")
+	c.Check(calls[6].FuncName, gc.Equals, "RemoveForwardingRule")
+	c.Check(calls[6].Name, gc.Equals, "lb-udp-53-53")
+	c.Check(calls[7].FuncName, gc.Equals, "AddForwardingRule")
+	c.Check(calls[7].ForwardingRule.Name, gc.Equals, "lb-tcp-443-443")
+	c.Check(calls[8].FuncName, gc.Equals, "AddForwardingRule")
+	c.Check(calls[8].ForwardingRule.Name, gc.Equals, "lb-udp-53-53")
+	c.Check(calls[8].ForwardingRule.IPAddress, gc.Equals, "203.0.113.10")
+}
+
+func (s *connSuite) TestRemoveLoadBalancer(c *gc.C) {
+	s.FakeConn.ForwardingRules = []*compute.ForwardingRule{
+		{Name: "lb-tcp-80-80"},
+		{Name: "lb-other-tcp-80-80"},
+	}
+
+	err := s.Conn.RemoveLoadBalancer("lb")
+	c.Assert(err, jc.ErrorIsNil)
+
+	calls := s.FakeConn.Calls
+	c.Assert(calls, gc.HasLen, 4)
+	c.Check(calls[0].FuncName, gc.Equals, "ListForwardingRules")
+	c.Check(calls[1].FuncName, gc.Equals, "RemoveForwardingRule")
+	c.Check(calls[1].Name, gc.Equals, "lb-tcp-80-80")
+	c.Check(calls[2].FuncName, gc.Equals, "RemoveAddress")
+	c.Check(calls[2].Name, gc.Equals, "lb")
+	c.Check(calls[3].FuncName, gc.Equals, "RemoveTargetPool")
+	c.Check(calls[3].Name, gc.Equals, "lb")
+}
+
+func (s *connSuite) TestRemoveLoadBalancerNotFound(c *gc.C) {
+	s.FakeConn.Err = errors.NotFoundf("target pool")
+	s.FakeConn.FailOnCall = 2
+
+	err := s.Conn.RemoveLoadBalancer("lb")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `load balancer "lb" not found`)
+}
+
+func (s *connSuite) TestLoadBalancers(c *gc.C) {
+	s.FakeConn.TargetPools = []*compute.TargetPool{{Name: "lb-b"}, {Name: "lb-a"}}
+
+	names, err := s.Conn.LoadBalancers("lb-")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(names, jc.DeepEquals, []string{"lb-a", "lb-b"})
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListTargetPools")
+	c.Check(s.FakeConn.Calls[0].Region, gc.Equals, "a")
+	c.Check(s.FakeConn.Calls[0].Prefix, gc.Equals, "lb-")
+}
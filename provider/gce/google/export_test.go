@@ -40,6 +40,10 @@ func NewAvailabilityZone(zone *compute.Zone) AvailabilityZone {
 	return AvailabilityZone{zone: zone}
 }
 
+func InstanceSpecRaw(spec InstanceSpec) *compute.Instance {
+	return spec.raw()
+}
+
 func GetInstanceSpec(inst *Instance) *InstanceSpec {
 	return inst.spec
 }
@@ -62,6 +62,25 @@ func (s *instanceSuite) TestInstanceStatusDown(c *gc.C) {
 	c.Check(status, gc.Equals, google.StatusDown)
 }
 
+func (s *instanceSuite) TestNewInstancePreemptible(c *gc.C) {
+	raw := s.RawInstanceFull
+	raw.Scheduling = &compute.Scheduling{Preemptible: true}
+	inst := google.NewInstanceRaw(&raw, &s.InstanceSpec)
+
+	c.Check(inst.Preemptible, jc.IsTrue)
+}
+
+func (s *instanceSuite) TestInstanceSpecPreemptible(c *gc.C) {
+	spec := s.InstanceSpec
+	c.Check(google.InstanceSpecRaw(spec).Scheduling, gc.IsNil)
+
+	spec.Preemptible = true
+	c.Check(google.InstanceSpecRaw(spec).Scheduling, jc.DeepEquals, &compute.Scheduling{
+		Preemptible:       true,
+		OnHostMaintenance: "TERMINATE",
+	})
+}
+
 func (s *instanceSuite) TestInstanceAddresses(c *gc.C) {
 	addresses := s.Instance.Addresses()
 
@@ -26,6 +26,10 @@ const (
 type NetworkSpec struct {
 	// Name is the unqualified name of the network.
 	Name string
+	// Subnetwork is the URL of the subnetwork of the network that
+	// the interface is connected to. If it is empty then GCE picks
+	// the network's subnetwork in the instance's region.
+	Subnetwork string
 	// TODO(ericsnow) support a CIDR for internal IP addr range?
 }
 
@@ -53,6 +57,7 @@ func (ns *NetworkSpec) newInterface(name string) *compute.NetworkInterface {
 	}
 	return &compute.NetworkInterface{
 		Network:       ns.Path(),
+		Subnetwork:    ns.Subnetwork,
 		AccessConfigs: access,
 	}
 }
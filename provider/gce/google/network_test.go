@@ -44,6 +44,19 @@ func (s *networkSuite) TestNetworkSpecNewInterface(c *gc.C) {
 	})
 }
 
+func (s *networkSuite) TestNetworkSpecNewInterfaceSubnetwork(c *gc.C) {
+	spec := google.NetworkSpec{
+		Name:       "spam",
+		Subnetwork: "https://www.googleapis.com/compute/v1/projects/sonic-youth/regions/us-central1/subnetworks/ham",
+	}
+	netIF := google.NewNetInterface(spec, "")
+
+	c.Check(netIF, gc.DeepEquals, &compute.NetworkInterface{
+		Network:    "global/networks/spam",
+		Subnetwork: "https://www.googleapis.com/compute/v1/projects/sonic-youth/regions/us-central1/subnetworks/ham",
+	})
+}
+
 type ByIPProtocol []*compute.FirewallAllowed
 
 func (s ByIPProtocol) Len() int {
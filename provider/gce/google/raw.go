@@ -14,6 +14,7 @@ import (
 	"github.com/juju/utils"
 	"golang.org/x/net/context"
 	"google.golang.org/api/compute/v1"
+	clouddns "google.golang.org/api/dns/v1"
 	"google.golang.org/api/googleapi"
 )
 
@@ -40,6 +41,8 @@ func convertRawAPIError(err error) error {
 
 type rawConn struct {
 	*compute.Service
+
+	dnsService *clouddns.Service
 }
 
 func (rc *rawConn) GetProject(projectID string) (*compute.Project, error) {
@@ -421,3 +424,177 @@ func (rc *rawConn) ListNetworks(projectID string) ([]*compute.Network, error) {
 	}
 	return results, nil
 }
+
+func (rc *rawConn) GetTargetPool(projectID, region, name string) (*compute.TargetPool, error) {
+	call := rc.TargetPools.Get(projectID, region, name)
+	pool, err := call.Do()
+	return pool, errors.Trace(convertRawAPIError(err))
+}
+
+func (rc *rawConn) ListTargetPools(projectID, region, prefix string) ([]*compute.TargetPool, error) {
+	ctx := context.Background()
+	call := rc.TargetPools.List(projectID, region)
+	call = call.Filter("name eq " + prefix + ".*")
+	var results []*compute.TargetPool
+	err := call.Pages(ctx, func(page *compute.TargetPoolList) error {
+		results = append(results, page.Items...)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+func (rc *rawConn) AddTargetPool(projectID, region string, pool *compute.TargetPool) error {
+	call := rc.TargetPools.Insert(projectID, region, pool)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) RemoveTargetPool(projectID, region, name string) error {
+	call := rc.TargetPools.Delete(projectID, region, name)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(convertRawAPIError(err))
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(convertRawAPIError(err))
+}
+
+func instanceReferences(instanceURLs []string) []*compute.InstanceReference {
+	refs := make([]*compute.InstanceReference, len(instanceURLs))
+	for i, url := range instanceURLs {
+		refs[i] = &compute.InstanceReference{Instance: url}
+	}
+	return refs
+}
+
+func (rc *rawConn) AddTargetPoolInstances(projectID, region, name string, instanceURLs []string) error {
+	call := rc.TargetPools.AddInstance(projectID, region, name, &compute.TargetPoolsAddInstanceRequest{
+		Instances: instanceReferences(instanceURLs),
+	})
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) RemoveTargetPoolInstances(projectID, region, name string, instanceURLs []string) error {
+	call := rc.TargetPools.RemoveInstance(projectID, region, name, &compute.TargetPoolsRemoveInstanceRequest{
+		Instances: instanceReferences(instanceURLs),
+	})
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) ListForwardingRules(projectID, region, prefix string) ([]*compute.ForwardingRule, error) {
+	ctx := context.Background()
+	call := rc.ForwardingRules.List(projectID, region)
+	call = call.Filter("name eq " + prefix + ".*")
+	var results []*compute.ForwardingRule
+	err := call.Pages(ctx, func(page *compute.ForwardingRuleList) error {
+		results = append(results, page.Items...)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+func (rc *rawConn) AddForwardingRule(projectID, region string, rule *compute.ForwardingRule) error {
+	call := rc.ForwardingRules.Insert(projectID, region, rule)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) RemoveForwardingRule(projectID, region, name string) error {
+	call := rc.ForwardingRules.Delete(projectID, region, name)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(convertRawAPIError(err))
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(convertRawAPIError(err))
+}
+
+func (rc *rawConn) GetAddress(projectID, region, name string) (*compute.Address, error) {
+	call := rc.Addresses.Get(projectID, region, name)
+	address, err := call.Do()
+	return address, errors.Trace(convertRawAPIError(err))
+}
+
+func (rc *rawConn) AddAddress(projectID, region string, address *compute.Address) error {
+	call := rc.Addresses.Insert(projectID, region, address)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) RemoveAddress(projectID, region, name string) error {
+	call := rc.Addresses.Delete(projectID, region, name)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(convertRawAPIError(err))
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(convertRawAPIError(err))
+}
+
+func (rc *rawConn) ListManagedZones(projectID, dnsName string) ([]*clouddns.ManagedZone, error) {
+	ctx := context.Background()
+	call := rc.dnsService.ManagedZones.List(projectID).DnsName(dnsName)
+	var results []*clouddns.ManagedZone
+	err := call.Pages(ctx, func(page *clouddns.ManagedZonesListResponse) error {
+		results = append(results, page.ManagedZones...)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+func (rc *rawConn) ListResourceRecordSets(projectID, managedZone, name, recordType string) ([]*clouddns.ResourceRecordSet, error) {
+	ctx := context.Background()
+	call := rc.dnsService.ResourceRecordSets.List(projectID, managedZone)
+	if name != "" {
+		call = call.Name(name)
+	}
+	if recordType != "" {
+		call = call.Type(recordType)
+	}
+	var results []*clouddns.ResourceRecordSet
+	err := call.Pages(ctx, func(page *clouddns.ResourceRecordSetsListResponse) error {
+		results = append(results, page.Rrsets...)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(convertRawAPIError(err))
+	}
+	return results, nil
+}
+
+func (rc *rawConn) ChangeResourceRecordSets(projectID, managedZone string, change *clouddns.Change) error {
+	call := rc.dnsService.Changes.Create(projectID, managedZone, change)
+	_, err := call.Do()
+	return errors.Trace(err)
+}
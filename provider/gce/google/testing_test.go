@@ -5,6 +5,7 @@ package google
 
 import (
 	"google.golang.org/api/compute/v1"
+	clouddns "google.golang.org/api/dns/v1"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/network"
@@ -170,6 +171,13 @@ type fakeCall struct {
 	Metadata         *compute.Metadata
 	LabelFingerprint string
 	Labels           map[string]string
+	TargetPool       *compute.TargetPool
+	ForwardingRule   *compute.ForwardingRule
+	Address          *compute.Address
+	InstanceURLs     []string
+	ManagedZone      string
+	RecordType       string
+	DNSChange        *clouddns.Change
 }
 
 type fakeConn struct {
@@ -187,6 +195,14 @@ type fakeConn struct {
 	AttachedDisks []*compute.AttachedDisk
 	Networks      []*compute.Network
 	Subnetworks   []*compute.Subnetwork
+
+	TargetPool      *compute.TargetPool
+	TargetPools     []*compute.TargetPool
+	ForwardingRules []*compute.ForwardingRule
+	Address         *compute.Address
+
+	ManagedZones []*clouddns.ManagedZone
+	RecordSets   []*clouddns.ResourceRecordSet
 }
 
 func (rc *fakeConn) GetProject(projectID string) (*compute.Project, error) {
@@ -542,3 +558,252 @@ func (rc *fakeConn) ListSubnetworks(projectID, region string) ([]*compute.Subnet
 	}
 	return rc.Subnetworks, nil
 }
+
+func (rc *fakeConn) GetTargetPool(projectID, region, name string) (*compute.TargetPool, error) {
+	call := fakeCall{
+		FuncName:  "GetTargetPool",
+		ProjectID: projectID,
+		Region:    region,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.TargetPool, err
+}
+
+func (rc *fakeConn) ListTargetPools(projectID, region, prefix string) ([]*compute.TargetPool, error) {
+	call := fakeCall{
+		FuncName:  "ListTargetPools",
+		ProjectID: projectID,
+		Region:    region,
+		Prefix:    prefix,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.TargetPools, err
+}
+
+func (rc *fakeConn) AddTargetPool(projectID, region string, pool *compute.TargetPool) error {
+	call := fakeCall{
+		FuncName:   "AddTargetPool",
+		ProjectID:  projectID,
+		Region:     region,
+		TargetPool: pool,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) RemoveTargetPool(projectID, region, name string) error {
+	call := fakeCall{
+		FuncName:  "RemoveTargetPool",
+		ProjectID: projectID,
+		Region:    region,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) AddTargetPoolInstances(projectID, region, name string, instanceURLs []string) error {
+	call := fakeCall{
+		FuncName:     "AddTargetPoolInstances",
+		ProjectID:    projectID,
+		Region:       region,
+		Name:         name,
+		InstanceURLs: instanceURLs,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) RemoveTargetPoolInstances(projectID, region, name string, instanceURLs []string) error {
+	call := fakeCall{
+		FuncName:     "RemoveTargetPoolInstances",
+		ProjectID:    projectID,
+		Region:       region,
+		Name:         name,
+		InstanceURLs: instanceURLs,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) ListForwardingRules(projectID, region, prefix string) ([]*compute.ForwardingRule, error) {
+	call := fakeCall{
+		FuncName:  "ListForwardingRules",
+		ProjectID: projectID,
+		Region:    region,
+		Prefix:    prefix,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.ForwardingRules, err
+}
+
+func (rc *fakeConn) AddForwardingRule(projectID, region string, rule *compute.ForwardingRule) error {
+	call := fakeCall{
+		FuncName:       "AddForwardingRule",
+		ProjectID:      projectID,
+		Region:         region,
+		ForwardingRule: rule,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) RemoveForwardingRule(projectID, region, name string) error {
+	call := fakeCall{
+		FuncName:  "RemoveForwardingRule",
+		ProjectID: projectID,
+		Region:    region,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) GetAddress(projectID, region, name string) (*compute.Address, error) {
+	call := fakeCall{
+		FuncName:  "GetAddress",
+		ProjectID: projectID,
+		Region:    region,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.Address, err
+}
+
+func (rc *fakeConn) AddAddress(projectID, region string, address *compute.Address) error {
+	call := fakeCall{
+		FuncName:  "AddAddress",
+		ProjectID: projectID,
+		Region:    region,
+		Address:   address,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) RemoveAddress(projectID, region, name string) error {
+	call := fakeCall{
+		FuncName:  "RemoveAddress",
+		ProjectID: projectID,
+		Region:    region,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) ListManagedZones(projectID, dnsName string) ([]*clouddns.ManagedZone, error) {
+	call := fakeCall{
+		FuncName:  "ListManagedZones",
+		ProjectID: projectID,
+		Name:      dnsName,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.ManagedZones, err
+}
+
+func (rc *fakeConn) ListResourceRecordSets(projectID, managedZone, name, recordType string) ([]*clouddns.ResourceRecordSet, error) {
+	call := fakeCall{
+		FuncName:    "ListResourceRecordSets",
+		ProjectID:   projectID,
+		ManagedZone: managedZone,
+		Name:        name,
+		RecordType:  recordType,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	var results []*clouddns.ResourceRecordSet
+	for _, rrset := range rc.RecordSets {
+		if name != "" && rrset.Name != name || recordType != "" && rrset.Type != recordType {
+			continue
+		}
+		results = append(results, rrset)
+	}
+	return results, err
+}
+
+func (rc *fakeConn) ChangeResourceRecordSets(projectID, managedZone string, change *clouddns.Change) error {
+	call := fakeCall{
+		FuncName:    "ChangeResourceRecordSets",
+		ProjectID:   projectID,
+		ManagedZone: managedZone,
+		DNSChange:   change,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
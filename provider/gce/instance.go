@@ -43,6 +43,15 @@ func (inst *environInstance) Status(ctx context.ProviderCallContext) instance.In
 		jujuStatus = status.Running
 	case "STOPPING", "TERMINATED":
 		jujuStatus = status.Empty
+		if inst.base.Preemptible {
+			// Juju never stops instances, it deletes them,
+			// so a stopped preemptible instance has been
+			// preempted by GCE.
+			return instance.InstanceStatus{
+				Status:  status.Interrupted,
+				Message: "preempted",
+			}
+		}
 	default:
 		jujuStatus = status.Empty
 	}
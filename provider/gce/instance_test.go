@@ -7,6 +7,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/provider/gce"
 	"github.com/juju/juju/provider/gce/google"
@@ -40,6 +41,24 @@ func (s *instanceSuite) TestStatus(c *gc.C) {
 	s.CheckNoAPI(c)
 }
 
+func (s *instanceSuite) TestStatusPreempted(c *gc.C) {
+	s.BaseInstance.InstanceSummary.Status = google.StatusTerminated
+	s.BaseInstance.InstanceSummary.Preemptible = true
+	instStatus := s.Instance.Status(s.CallCtx)
+
+	c.Check(instStatus.Status, gc.Equals, status.Interrupted)
+	c.Check(instStatus.Message, gc.Equals, "preempted")
+	s.CheckNoAPI(c)
+}
+
+func (s *instanceSuite) TestStatusTerminatedNotPreemptible(c *gc.C) {
+	s.BaseInstance.InstanceSummary.Status = google.StatusTerminated
+	instStatus := s.Instance.Status(s.CallCtx)
+
+	c.Check(instStatus.Status, gc.Equals, status.Empty)
+	c.Check(instStatus.Message, gc.Equals, google.StatusTerminated)
+}
+
 func (s *instanceSuite) TestAddresses(c *gc.C) {
 	addresses, err := s.Instance.Addresses(s.CallCtx)
 	c.Assert(err, jc.ErrorIsNil)
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/cloudconfig/providerinit"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/dns"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
@@ -503,6 +504,9 @@ type fakeConnCall struct {
 	Value            string
 	LabelFingerprint string
 	Labels           map[string]string
+	LoadBalancerSpec google.LoadBalancerSpec
+	ManagedZone      string
+	Record           dns.Record
 }
 
 type fakeConn struct {
@@ -520,6 +524,12 @@ type fakeConn struct {
 	AttachedDisk  *google.AttachedDisk
 	AttachedDisks []*google.AttachedDisk
 
+	LoadBalancerIPs   []string
+	LoadBalancerNames []string
+
+	ManagedZoneName string
+	Records         []dns.Record
+
 	Err        error
 	FailOnCall int
 }
@@ -732,4 +742,63 @@ func (fc *fakeConn) ListMachineTypes(zone string) ([]google.MachineType, error)
 	}, nil
 }
 
+func (fc *fakeConn) EnsureLoadBalancer(spec google.LoadBalancerSpec) ([]string, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:         "EnsureLoadBalancer",
+		LoadBalancerSpec: spec,
+	})
+	return fc.LoadBalancerIPs, fc.err()
+}
+
+func (fc *fakeConn) RemoveLoadBalancer(name string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "RemoveLoadBalancer",
+		ID:       name,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) LoadBalancers(prefix string) ([]string, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "LoadBalancers",
+		Prefix:   prefix,
+	})
+	return fc.LoadBalancerNames, fc.err()
+}
+
+func (fc *fakeConn) ManagedZone(dnsName string) (string, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "ManagedZone",
+		ID:       dnsName,
+	})
+	return fc.ManagedZoneName, fc.err()
+}
+
+func (fc *fakeConn) RecordSets(managedZone, domain string) ([]dns.Record, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:    "RecordSets",
+		ManagedZone: managedZone,
+		ID:          domain,
+	})
+	return fc.Records, fc.err()
+}
+
+func (fc *fakeConn) SetRecordSet(managedZone string, record dns.Record) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:    "SetRecordSet",
+		ManagedZone: managedZone,
+		Record:      record,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) RemoveRecordSet(managedZone string, record dns.Record) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:    "RemoveRecordSet",
+		ManagedZone: managedZone,
+		Record:      record,
+	})
+	return fc.err()
+}
+
 var InvalidCredentialError = &url.Error{"Get", "testbad.com", errors.New("400 Bad Request")}
@@ -49,6 +49,11 @@ var unsupportedConstraints = []string{
 	constraints.CpuPower,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.Zones,
+	constraints.Affinity,
+	constraints.AntiAffinity,
+	constraints.Spot,
+	constraints.MaxPrice,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -0,0 +1,113 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/environs/config"
+)
+
+// The libvirt-specific config keys.
+const (
+	cfgPool   = "libvirt-pool"
+	cfgBridge = "libvirt-bridge"
+)
+
+const (
+	defaultPool   = "default"
+	defaultBridge = "virbr0"
+)
+
+var configSchema = environschema.Fields{
+	cfgPool: {
+		Description: "The libvirt storage pool in which instance disks and images are created.",
+		Type:        environschema.Tstring,
+		Immutable:   true,
+	},
+	cfgBridge: {
+		Description: "The bridge on the libvirt host to which instances are attached. " +
+			"Instances must be reachable from the Juju client and controller on this bridge, " +
+			"so a bridge onto the host's network is required when connecting to a remote host.",
+		Type: environschema.Tstring,
+	},
+}
+
+// configFields is the spec for each libvirt config value's type.
+var configFields = func() schema.Fields {
+	fs, _, err := configSchema.ValidationSchema()
+	if err != nil {
+		panic(err)
+	}
+	return fs
+}()
+
+var configImmutableFields = []string{
+	cfgPool,
+}
+
+var configDefaults = schema.Defaults{
+	cfgPool:   defaultPool,
+	cfgBridge: defaultBridge,
+}
+
+type environConfig struct {
+	*config.Config
+	attrs map[string]interface{}
+}
+
+// newValidConfig builds a new environConfig from the provided Config,
+// filling in default values. It returns an error if the resulting
+// configuration is not valid, or if any immutable attributes differ
+// from those in old.
+func newValidConfig(cfg, old *config.Config) (*environConfig, error) {
+	if err := config.Validate(cfg, old); err != nil {
+		return nil, errors.Trace(err)
+	}
+	attrs, err := cfg.ValidateUnknownAttrs(configFields, configDefaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ecfg := &environConfig{Config: cfg, attrs: attrs}
+	if err := ecfg.validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if old != nil {
+		oldEcfg, err := newValidConfig(old, nil)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid base config")
+		}
+		for _, attr := range configImmutableFields {
+			oldv, newv := oldEcfg.attrs[attr], attrs[attr]
+			if oldv != newv {
+				return nil, errors.Errorf(
+					"%s: cannot change from %v to %v",
+					attr, oldv, newv,
+				)
+			}
+		}
+	}
+	return ecfg, nil
+}
+
+func (c *environConfig) pool() string {
+	return c.attrs[cfgPool].(string)
+}
+
+func (c *environConfig) bridge() string {
+	return c.attrs[cfgBridge].(string)
+}
+
+// validate checks libvirt-specific config values.
+func (c *environConfig) validate() error {
+	for _, field := range []string{cfgPool, cfgBridge} {
+		if c.attrs[field].(string) == "" {
+			return errors.Errorf("%s: must not be empty", field)
+		}
+	}
+	return nil
+}
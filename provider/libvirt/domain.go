@@ -0,0 +1,126 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/container/kvm/libvirt"
+)
+
+// nvramCode is the path, on the libvirt host, to the UEFI firmware
+// used to boot ARM64 instances.
+const nvramCode = "/usr/share/AAVMF/AAVMF_CODE.fd"
+
+// domainParams implements the parameters required by libvirt.NewDomain
+// to generate the domain XML for an instance.
+type domainParams struct {
+	name       string
+	arch       string
+	cpus       uint64
+	ram        uint64
+	disks      []libvirt.DiskInfo
+	interfaces []libvirt.InterfaceInfo
+}
+
+// Arch implements libvirt.domainParams.
+func (p domainParams) Arch() string {
+	return p.arch
+}
+
+// CPUs implements libvirt.domainParams.
+func (p domainParams) CPUs() uint64 {
+	return p.cpus
+}
+
+// DiskInfo implements libvirt.domainParams.
+func (p domainParams) DiskInfo() []libvirt.DiskInfo {
+	return p.disks
+}
+
+// Host implements libvirt.domainParams.
+func (p domainParams) Host() string {
+	return p.name
+}
+
+// Loader implements libvirt.domainParams.
+func (p domainParams) Loader() string {
+	return nvramCode
+}
+
+// NetworkInfo implements libvirt.domainParams.
+func (p domainParams) NetworkInfo() []libvirt.InterfaceInfo {
+	return p.interfaces
+}
+
+// RAM implements libvirt.domainParams.
+func (p domainParams) RAM() uint64 {
+	return p.ram
+}
+
+// ValidateDomainParams implements libvirt.domainParams.
+func (p domainParams) ValidateDomainParams() error {
+	if p.name == "" {
+		return errors.Errorf("missing required hostname")
+	}
+	if len(p.disks) < 2 {
+		// We need at least the root disk and the data source disk.
+		return errors.Errorf("got %d disks, need at least 2", len(p.disks))
+	}
+	return nil
+}
+
+// diskInfo implements libvirt.DiskInfo.
+type diskInfo struct {
+	driver, source string
+}
+
+// Driver implements libvirt.DiskInfo.
+func (d diskInfo) Driver() string {
+	return d.driver
+}
+
+// Source implements libvirt.DiskInfo.
+func (d diskInfo) Source() string {
+	return d.source
+}
+
+// interfaceInfo implements libvirt.InterfaceInfo for an interface
+// attached to a bridge on the libvirt host.
+type interfaceInfo struct {
+	mac, bridge, name string
+}
+
+// MACAddress implements libvirt.InterfaceInfo.
+func (i interfaceInfo) MACAddress() string {
+	return i.mac
+}
+
+// ParentInterfaceName implements libvirt.InterfaceInfo.
+func (i interfaceInfo) ParentInterfaceName() string {
+	return i.bridge
+}
+
+// InterfaceName implements libvirt.InterfaceInfo.
+func (i interfaceInfo) InterfaceName() string {
+	return i.name
+}
+
+// NICType implements libvirt.InterfaceInfo.
+func (i interfaceInfo) NICType() string {
+	return ""
+}
+
+// generateMAC returns a random MAC address with the QEMU/KVM
+// organisationally unique identifier, 52:54:00.
+func generateMAC() (string, error) {
+	var b [3]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", errors.Trace(err)
+	}
+	return fmt.Sprintf("52:54:00:%02x:%02x:%02x", b[0], b[1], b[2]), nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+)
+
+type environ struct {
+	cloud    environs.CloudSpec
+	provider *environProvider
+	virsh    virsh
+
+	// namespace is used to create the machine and device hostnames.
+	namespace instance.Namespace
+
+	lock sync.Mutex
+	ecfg *environConfig
+
+	// imageMutex serialises fetching base images into the pool.
+	imageMutex sync.Mutex
+}
+
+var _ environs.Environ = (*environ)(nil)
+
+func newEnviron(provider *environProvider, spec environs.CloudSpec, cfg *config.Config) (*environ, error) {
+	ecfg, err := newValidConfig(cfg, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+	namespace, err := instance.NewNamespace(cfg.UUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &environ{
+		cloud:     spec,
+		provider:  provider,
+		virsh:     virsh{uri: spec.Endpoint, run: provider.run},
+		namespace: namespace,
+		ecfg:      ecfg,
+	}, nil
+}
+
+// Provider returns the provider that created this environ.
+func (env *environ) Provider() environs.EnvironProvider {
+	return env.provider
+}
+
+// SetConfig updates the environ's configuration.
+func (env *environ) SetConfig(cfg *config.Config) error {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	ecfg, err := newValidConfig(cfg, env.ecfg.Config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	env.ecfg = ecfg
+	return nil
+}
+
+// Config returns the configuration data with which the env was created.
+func (env *environ) Config() *config.Config {
+	return env.envConfig().Config
+}
+
+func (env *environ) envConfig() *environConfig {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	return env.ecfg
+}
+
+// PrepareForBootstrap implements environs.Environ.
+func (env *environ) PrepareForBootstrap(ctx environs.BootstrapContext) error {
+	if _, err := env.virsh.version(); err != nil {
+		return errors.Annotatef(err, "connecting to %q", env.cloud.Endpoint)
+	}
+	return nil
+}
+
+// Create implements environs.Environ.
+func (env *environ) Create(context.ProviderCallContext, environs.CreateParams) error {
+	return nil
+}
+
+// Bootstrap implements environs.Environ.
+func (env *environ) Bootstrap(ctx environs.BootstrapContext, callCtx context.ProviderCallContext, params environs.BootstrapParams) (*environs.BootstrapResult, error) {
+	return common.Bootstrap(ctx, env, callCtx, params)
+}
+
+// Destroy shuts down all known machines and destroys the rest of the
+// known environment.
+func (env *environ) Destroy(ctx context.ProviderCallContext) error {
+	return common.Destroy(env, ctx)
+}
+
+// DestroyController implements the Environ interface.
+func (env *environ) DestroyController(ctx context.ProviderCallContext, controllerUUID string) error {
+	if err := env.Destroy(ctx); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(env.destroyHostedModelResources(controllerUUID))
+}
+
+func (env *environ) destroyHostedModelResources(controllerUUID string) error {
+	// Destroy all domains tagged with juju-controller-uuid
+	// matching the specified UUID.
+	domains, err := env.virsh.listDomains("juju-")
+	if err != nil {
+		return errors.Annotate(err, "listing domains")
+	}
+	var names []string
+	for name := range domains {
+		domainTags, err := env.virsh.domainTags(name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if domainTags[tags.JujuController] != controllerUUID {
+			continue
+		}
+		names = append(names, name)
+	}
+	logger.Debugf("removing domains: %v", names)
+	return errors.Trace(env.removeDomains(names))
+}
+
+// AdoptResources updates the controller tags on all instances to have the
+// new controller id. It's part of the Environ interface.
+func (env *environ) AdoptResources(ctx context.ProviderCallContext, controllerUUID string, fromVersion version.Number) error {
+	domains, err := env.virsh.listDomains(env.namespace.Prefix())
+	if err != nil {
+		return errors.Annotate(err, "listing domains")
+	}
+	var failed []string
+	for name := range domains {
+		domainTags, err := env.virsh.domainTags(name)
+		if err == nil {
+			domainTags[tags.JujuController] = controllerUUID
+			err = env.virsh.setDomainTags(name, domainTags)
+		}
+		if err != nil {
+			logger.Errorf("error setting controller uuid tag for %q: %v", name, err)
+			failed = append(failed, name)
+		}
+	}
+	if len(failed) != 0 {
+		return errors.Errorf("failed to update controller for some instances: %v", failed)
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/cloudconfig/providerinit"
+	"github.com/juju/juju/container/kvm"
+	"github.com/juju/juju/container/kvm/libvirt"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/tools"
+)
+
+// MaintainInstance is specified in the InstanceBroker interface.
+func (*environ) MaintainInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams) error {
+	return nil
+}
+
+// StartInstance implements environs.InstanceBroker.
+func (env *environ) StartInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	logger.Debugf("StartInstance: %q, %s", args.InstanceConfig.MachineId, args.InstanceConfig.Series)
+
+	instArch, err := env.finishInstanceConfig(args)
+	if err != nil {
+		return nil, common.ZoneIndependentError(err)
+	}
+
+	name, hwc, err := env.newDomain(args, instArch)
+	if err != nil {
+		if args.StatusCallback != nil {
+			args.StatusCallback(status.ProvisioningError, err.Error(), nil)
+		}
+		return nil, errors.Trace(err)
+	}
+	logger.Infof("started instance %q", name)
+	return &environs.StartInstanceResult{
+		Instance: newInstance(name, domainRunning, env),
+		Hardware: hwc,
+	}, nil
+}
+
+func (env *environ) finishInstanceConfig(args environs.StartInstanceParams) (string, error) {
+	instArch := arch.AMD64
+	if args.Constraints.Arch != nil {
+		instArch = *args.Constraints.Arch
+	}
+	tools, err := args.Tools.Match(tools.Filter{Arch: instArch})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := args.InstanceConfig.SetTools(tools); err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := instancecfg.FinishInstanceConfig(args.InstanceConfig, env.Config()); err != nil {
+		return "", errors.Trace(err)
+	}
+	return instArch, nil
+}
+
+// newDomain creates the disks for a new instance in the storage pool,
+// then defines and starts a domain using them. The domain's name and
+// hardware characteristics are returned.
+func (env *environ) newDomain(
+	args environs.StartInstanceParams, instArch string,
+) (_ string, _ *instance.HardwareCharacteristics, err error) {
+	name, err := env.namespace.Hostname(args.InstanceConfig.MachineId)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	series := args.InstanceConfig.Series
+	statusCallback := func(msg string) {
+		if args.StatusCallback != nil {
+			args.StatusCallback(status.Provisioning, msg, nil)
+		}
+	}
+
+	cloudcfg, err := cloudinit.New(series)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	// Make sure the hostname is resolvable by adding it to /etc/hosts.
+	cloudcfg.ManageEtcHosts(true)
+	userData, err := providerinit.ComposeUserData(args.InstanceConfig, cloudcfg, libvirtRenderer{})
+	if err != nil {
+		return "", nil, errors.Annotate(err, "cannot make user data")
+	}
+
+	base, err := env.ensureBaseVolume(series, instArch, statusCallback)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+
+	// From here on, clean up anything we have created if we fail.
+	defer func() {
+		if err != nil {
+			if err := env.removeDomains([]string{name}); err != nil {
+				logger.Errorf("cleaning up instance %q: %v", name, err)
+			}
+		}
+	}()
+
+	statusCallback("Creating instance disks")
+	startParams := kvm.ParseConstraintsToStartParams(args.Constraints)
+	pool := env.envConfig().pool()
+	rootDisk := rootVolumeName(name)
+	rootSize := startParams.RootDisk * 1024 * 1024 * 1024
+	if err := env.virsh.createVolume(pool, rootDisk, rootSize, "qcow2", base); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	dataSource := dataSourceVolumeName(name)
+	if err := env.writeDataSourceVolume(pool, dataSource, userData); err != nil {
+		return "", nil, errors.Annotate(err, "writing data source volume")
+	}
+
+	params := domainParams{
+		name: name,
+		arch: instArch,
+		cpus: startParams.CpuCores,
+		ram:  startParams.Memory,
+	}
+	for _, disk := range []diskInfo{
+		{driver: "qcow2", source: rootDisk},
+		{driver: "raw", source: dataSource},
+	} {
+		path, err := env.virsh.volumePath(pool, disk.source)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+		disk.source = path
+		params.disks = append(params.disks, disk)
+	}
+	mac, err := generateMAC()
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	params.interfaces = []libvirt.InterfaceInfo{
+		interfaceInfo{mac: mac, bridge: env.envConfig().bridge(), name: "eth0"},
+	}
+
+	statusCallback("Starting instance")
+	if err := env.defineDomain(params, args.InstanceConfig.Tags); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	if err := env.virsh.startDomain(name); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+
+	rootDiskMiB := startParams.RootDisk * 1024
+	hwc := &instance.HardwareCharacteristics{
+		Arch:     &instArch,
+		Mem:      &startParams.Memory,
+		CpuCores: &startParams.CpuCores,
+		RootDisk: &rootDiskMiB,
+	}
+	return name, hwc, nil
+}
+
+// defineDomain defines a domain with the given parameters, recording
+// the tags in its description.
+func (env *environ) defineDomain(params domainParams, tags map[string]string) error {
+	dom, err := libvirt.NewDomain(params)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	dom.Description = formatTags(tags)
+	data, err := xml.MarshalIndent(&dom, "", "    ")
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	dir, err := ioutil.TempDir("", "juju-libvirt-")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
+	domainPath := filepath.Join(dir, params.name+".xml")
+	if err := ioutil.WriteFile(domainPath, data, 0644); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(env.virsh.defineDomain(domainPath))
+}
+
+// writeDataSourceVolume creates a cloud-init NoCloud data source image
+// holding the user data, and uploads it to the named pool volume.
+// See: http://cloudinit.readthedocs.io/en/latest/topics/datasources/nocloud.html
+func (env *environ) writeDataSourceVolume(pool, name string, userData []byte) error {
+	dir, err := ioutil.TempDir("", "juju-libvirt-")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// instance-id is required in meta-data. It is what cloud-init uses
+	// to determine if this is the first boot.
+	metaData := fmt.Sprintf(`{"instance-id": "%s"}`, utils.MustNewUUID())
+	userDataPath := filepath.Join(dir, "user-data")
+	metaDataPath := filepath.Join(dir, "meta-data")
+	if err := ioutil.WriteFile(userDataPath, userData, 0600); err != nil {
+		return errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(metaDataPath, []byte(metaData), 0600); err != nil {
+		return errors.Trace(err)
+	}
+
+	// The files must be named exactly user-data and meta-data at the
+	// root of the image, so we use graft points to place them.
+	isoPath := filepath.Join(dir, name)
+	if out, err := env.provider.run(
+		"genisoimage",
+		"-output", isoPath,
+		"-volid", "cidata",
+		"-joliet", "-rock",
+		"-graft-points",
+		"user-data="+userDataPath,
+		"meta-data="+metaDataPath,
+	); err != nil {
+		return errors.Annotatef(err, "genisoimage: %s", out)
+	}
+
+	info, err := os.Stat(isoPath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := env.virsh.createVolume(pool, name, uint64(info.Size()), "raw", ""); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(env.virsh.uploadVolume(pool, name, isoPath))
+}
+
+// StopInstances implements environs.InstanceBroker.
+func (env *environ) StopInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	prefix := env.namespace.Prefix()
+	var names []string
+	for _, id := range ids {
+		name := string(id)
+		if !strings.HasPrefix(name, prefix) {
+			logger.Warningf("ignoring request to stop instance %q from another model", name)
+			continue
+		}
+		names = append(names, name)
+	}
+	return errors.Trace(env.removeDomains(names))
+}
+
+// removeDomains removes the named domains, along with their disks.
+// Domains and disks that do not exist are ignored.
+func (env *environ) removeDomains(names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	domains, err := env.virsh.listDomains("")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	pool := env.envConfig().pool()
+	var failed []string
+	for _, name := range names {
+		if _, ok := domains[name]; ok {
+			if err := env.virsh.removeDomain(name); err != nil {
+				logger.Errorf("removing domain %q: %v", name, err)
+				failed = append(failed, name)
+				continue
+			}
+		}
+		for _, volume := range []string{rootVolumeName(name), dataSourceVolumeName(name)} {
+			if err := env.virsh.deleteVolume(pool, volume); err != nil {
+				logger.Errorf("removing volume %q: %v", volume, err)
+				failed = append(failed, name)
+				break
+			}
+		}
+	}
+	if len(failed) != 0 {
+		return errors.Errorf("failed to remove some instances: %v", failed)
+	}
+	return nil
+}
+
+// rootVolumeName returns the name of the pool volume holding the named
+// domain's root disk.
+func rootVolumeName(name string) string {
+	return name + ".qcow2"
+}
+
+// dataSourceVolumeName returns the name of the pool volume holding the
+// named domain's cloud-init data source.
+func dataSourceVolumeName(name string) string {
+	return name + "-ds.iso"
+}
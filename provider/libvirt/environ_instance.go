@@ -0,0 +1,92 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+)
+
+// Instances returns the available instances in the environment that
+// match the provided instance IDs. For IDs that did not match any
+// instances, the result at the corresponding index will be nil. In that
+// case the error will be environs.ErrPartialInstances (or
+// ErrNoInstances if none of the IDs match an instance).
+func (env *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instance.Instance, error) {
+	if len(ids) == 0 {
+		return nil, environs.ErrNoInstances
+	}
+	domains, err := env.virsh.listDomains(env.namespace.Prefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	numFound := 0
+	results := make([]instance.Instance, len(ids))
+	for i, id := range ids {
+		state, ok := domains[string(id)]
+		if !ok {
+			continue
+		}
+		results[i] = newInstance(string(id), state, env)
+		numFound++
+	}
+	if numFound == 0 {
+		return nil, environs.ErrNoInstances
+	} else if numFound != len(ids) {
+		return results, environs.ErrPartialInstances
+	}
+	return results, nil
+}
+
+// AllInstances implements environs.InstanceBroker.
+func (env *environ) AllInstances(ctx context.ProviderCallContext) ([]instance.Instance, error) {
+	domains, err := env.virsh.listDomains(env.namespace.Prefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, 0, len(domains))
+	for name := range domains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := make([]instance.Instance, len(names))
+	for i, name := range names {
+		results[i] = newInstance(name, domains[name], env)
+	}
+	return results, nil
+}
+
+// ControllerInstances returns the IDs of the instances corresponding
+// to juju controllers.
+func (env *environ) ControllerInstances(ctx context.ProviderCallContext, controllerUUID string) ([]instance.Id, error) {
+	domains, err := env.virsh.listDomains(env.namespace.Prefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results []instance.Id
+	for name := range domains {
+		domainTags, err := env.virsh.domainTags(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if domainTags[tags.JujuController] != controllerUUID {
+			continue
+		}
+		if domainTags[tags.JujuIsController] == "true" {
+			results = append(results, instance.Id(name))
+		}
+	}
+	if len(results) == 0 {
+		return nil, environs.ErrNotBootstrapped
+	}
+	return results, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/storage"
+)
+
+// PrecheckInstance verifies that the provided series and constraints
+// are valid for use in creating an instance in this environment.
+func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args environs.PrecheckInstanceParams) error {
+	if args.Placement != "" {
+		return errors.NotSupportedf("placement directive %q", args.Placement)
+	}
+	return nil
+}
+
+var unsupportedConstraints = []string{
+	constraints.CpuPower,
+	constraints.InstanceType,
+	constraints.Tags,
+	constraints.VirtType,
+	constraints.Container,
+	constraints.Spaces,
+	constraints.Zones,
+	constraints.Affinity,
+	constraints.AntiAffinity,
+	constraints.Spot,
+	constraints.MaxPrice,
+}
+
+// ConstraintsValidator returns a Validator value which is used to
+// validate and merge constraints.
+func (env *environ) ConstraintsValidator(ctx context.ProviderCallContext) (constraints.Validator, error) {
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported(unsupportedConstraints)
+	validator.RegisterVocabulary(constraints.Arch, []string{arch.AMD64, arch.ARM64})
+	return validator, nil
+}
+
+// InstanceTypes implements environs.InstanceTypesFetcher.
+func (env *environ) InstanceTypes(ctx context.ProviderCallContext, c constraints.Value) (instances.InstanceTypesWithCostMetadata, error) {
+	result := instances.InstanceTypesWithCostMetadata{}
+	return result, errors.NotSupportedf("InstanceTypes")
+}
+
+// StorageProviderTypes implements storage.ProviderRegistry.
+func (*environ) StorageProviderTypes() ([]storage.ProviderType, error) {
+	return nil, nil
+}
+
+// StorageProvider implements storage.ProviderRegistry.
+func (*environ) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	return nil, errors.NotFoundf("storage provider %q", t)
+}
@@ -0,0 +1,154 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"io/ioutil"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/container/kvm/libvirt"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type environSuite struct {
+	coretesting.BaseSuite
+
+	runner  *fakeRunner
+	env     *environ
+	callCtx context.ProviderCallContext
+	prefix  string
+}
+
+var _ = gc.Suite(&environSuite{})
+
+func (s *environSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.runner = newFakeRunner()
+	provider := &environProvider{run: s.runner.run}
+	env, err := provider.Open(environs.OpenParams{
+		Cloud:  fakeCloudSpec(),
+		Config: coretesting.ModelConfig(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.env = env.(*environ)
+	s.callCtx = context.NewCloudCallContext()
+	s.prefix = s.env.namespace.Prefix()
+
+	s.runner.outputs["virsh -q list --all"] = "" +
+		" 1     " + s.prefix + "0    running\n" +
+		" -     " + s.prefix + "1    shut off\n" +
+		" 3     juju-f00f00-0    running\n"
+	s.runner.outputs["virsh desc --config "+s.prefix+"0"] = "" +
+		"juju-controller-uuid=" + coretesting.ControllerTag.Id() + "\n" +
+		"juju-is-controller=true\n"
+	s.runner.outputs["virsh desc --config "+s.prefix+"1"] = "" +
+		"juju-controller-uuid=" + coretesting.ControllerTag.Id() + "\n"
+	s.runner.outputs["virsh desc --config juju-f00f00-0"] = "" +
+		"juju-controller-uuid=" + coretesting.ControllerTag.Id() + "\n"
+}
+
+func (s *environSuite) TestAllInstances(c *gc.C) {
+	insts, err := s.env.AllInstances(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(insts, gc.HasLen, 2)
+	c.Assert(insts[0].Id(), gc.Equals, instance.Id(s.prefix+"0"))
+	c.Assert(insts[0].Status(s.callCtx).Status, gc.Equals, status.Running)
+	c.Assert(insts[1].Id(), gc.Equals, instance.Id(s.prefix+"1"))
+	c.Assert(insts[1].Status(s.callCtx).Message, gc.Equals, "shut off")
+}
+
+func (s *environSuite) TestInstancesPartial(c *gc.C) {
+	insts, err := s.env.Instances(s.callCtx, []instance.Id{
+		instance.Id(s.prefix + "0"), "juju-f00f00-0",
+	})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(insts[0].Id(), gc.Equals, instance.Id(s.prefix+"0"))
+	c.Assert(insts[1], gc.IsNil)
+}
+
+func (s *environSuite) TestInstancesNone(c *gc.C) {
+	_, err := s.env.Instances(s.callCtx, []instance.Id{"juju-f00f00-0"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+}
+
+func (s *environSuite) TestControllerInstances(c *gc.C) {
+	ids, err := s.env.ControllerInstances(s.callCtx, coretesting.ControllerTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []instance.Id{instance.Id(s.prefix + "0")})
+}
+
+func (s *environSuite) TestControllerInstancesNotBootstrapped(c *gc.C) {
+	_, err := s.env.ControllerInstances(s.callCtx, "other-uuid")
+	c.Assert(err, gc.Equals, environs.ErrNotBootstrapped)
+}
+
+func (s *environSuite) TestStopInstances(c *gc.C) {
+	s.runner.calls = nil
+	err := s.env.StopInstances(s.callCtx, instance.Id(s.prefix+"1"), "juju-f00f00-0")
+	c.Assert(err, jc.ErrorIsNil)
+	name := s.prefix + "1"
+	c.Assert(s.runner.calls, jc.DeepEquals, []string{
+		"virsh -q list --all",
+		"virsh destroy " + name,
+		"virsh undefine --nvram " + name,
+		"virsh vol-info --pool default " + name + ".qcow2",
+		"virsh vol-delete --pool default " + name + ".qcow2",
+		"virsh vol-info --pool default " + name + "-ds.iso",
+		"virsh vol-delete --pool default " + name + "-ds.iso",
+	})
+}
+
+func (s *environSuite) TestAdoptResources(c *gc.C) {
+	s.runner.calls = nil
+	err := s.env.AdoptResources(s.callCtx, "new-uuid", version.MustParse("2.5.0"))
+	c.Assert(err, jc.ErrorIsNil)
+	var updated []string
+	for _, call := range s.runner.calls {
+		if strings.Contains(call, "--new-desc") {
+			updated = append(updated, call)
+		}
+	}
+	c.Assert(updated, jc.SameContents, []string{
+		"virsh desc --config " + s.prefix + "0 --new-desc juju-controller-uuid=new-uuid\njuju-is-controller=true",
+		"virsh desc --config " + s.prefix + "1 --new-desc juju-controller-uuid=new-uuid",
+	})
+}
+
+func (s *environSuite) TestDefineDomain(c *gc.C) {
+	var domainXML string
+	s.env.virsh.run = func(command string, args ...string) (string, error) {
+		if args[2] == "define" {
+			data, err := ioutil.ReadFile(args[3])
+			c.Assert(err, jc.ErrorIsNil)
+			domainXML = string(data)
+		}
+		return "", nil
+	}
+	err := s.env.defineDomain(domainParams{
+		name: s.prefix + "0",
+		arch: "amd64",
+		cpus: 2,
+		ram:  2048,
+		disks: []libvirt.DiskInfo{
+			diskInfo{driver: "qcow2", source: "/var/lib/libvirt/images/root.qcow2"},
+			diskInfo{driver: "raw", source: "/var/lib/libvirt/images/ds.iso"},
+		},
+		interfaces: []libvirt.InterfaceInfo{
+			interfaceInfo{mac: "52:54:00:01:02:03", bridge: "virbr0", name: "eth0"},
+		},
+	}, map[string]string{"juju-is-controller": "true"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(domainXML, jc.Contains, "<name>"+s.prefix+"0</name>")
+	c.Assert(domainXML, jc.Contains, "<description>juju-is-controller=true</description>")
+	c.Assert(domainXML, jc.Contains, `<source file="/var/lib/libvirt/images/root.qcow2"></source>`)
+	c.Assert(domainXML, jc.Contains, `<source bridge="virbr0"></source>`)
+}
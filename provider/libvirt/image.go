@@ -0,0 +1,139 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/container/kvm"
+	"github.com/juju/juju/environs/imagedownloads"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/simplestreams"
+)
+
+// baseVolumeName returns the name of the pool volume holding the cloud
+// image for the series and architecture. Instance root disks are
+// created as copy on write overlays of this volume.
+func baseVolumeName(series, arch string) string {
+	return fmt.Sprintf("juju-%s-%s-base.qcow2", series, arch)
+}
+
+// ensureBaseVolume ensures that the storage pool holds the cloud image
+// for the series and architecture, fetching it from simplestreams and
+// uploading it to the pool if it does not. The name of the volume is
+// returned.
+func (env *environ) ensureBaseVolume(series, arch string, progress func(string)) (string, error) {
+	env.imageMutex.Lock()
+	defer env.imageMutex.Unlock()
+
+	ecfg := env.envConfig()
+	pool := ecfg.pool()
+	name := baseVolumeName(series, arch)
+	if env.virsh.volumeExists(pool, name) {
+		return name, nil
+	}
+
+	metadataURL, _ := ecfg.ImageMetadataURL()
+	md, err := findImage(series, arch, ecfg.ImageStream(), metadataURL)
+	if err != nil {
+		return "", errors.Annotatef(err, "finding %s %s image", series, arch)
+	}
+	progress(fmt.Sprintf("Fetching %s %s image", series, arch))
+	path, err := downloadImage(md)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer os.Remove(path)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	progress(fmt.Sprintf("Uploading %s %s image", series, arch))
+	if err := env.virsh.createVolume(pool, name, uint64(info.Size()), "qcow2", ""); err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := env.virsh.uploadVolume(pool, name, path); err != nil {
+		if err := env.virsh.deleteVolume(pool, name); err != nil {
+			logger.Errorf("removing incomplete image volume %q: %v", name, err)
+		}
+		return "", errors.Trace(err)
+	}
+	return name, nil
+}
+
+// findImage returns the simplestreams metadata for the cloud image
+// matching the series and architecture. Ubuntu cloud images are used
+// unless an image metadata URL is configured.
+func findImage(series, arch, stream, metadataURL string) (*imagedownloads.Metadata, error) {
+	if metadataURL == "" && stream != imagemetadata.ReleasedStream {
+		var err error
+		metadataURL, err = imagemetadata.ImageMetadataURL(imagemetadata.UbuntuCloudImagesURL, stream)
+		if err != nil {
+			return nil, errors.Annotate(err, "generating image metadata source")
+		}
+	}
+	var srcFunc func() simplestreams.DataSource
+	if metadataURL != "" {
+		srcFunc = func() simplestreams.DataSource {
+			return imagedownloads.NewDataSource(metadataURL)
+		}
+	}
+	return imagedownloads.One(arch, series, stream, imageFileType(arch), srcFunc)
+}
+
+// imageFileType returns the type of image file to use for the
+// architecture.
+func imageFileType(a string) string {
+	if a == arch.ARM64 {
+		return kvm.UEFIFType
+	}
+	return kvm.BIOSFType
+}
+
+// downloadImage downloads the image described by the metadata to a
+// temporary file, verifying its checksum, and returns the file's path.
+// The caller is responsible for removing the file.
+var downloadImage = func(md *imagedownloads.Metadata) (_ string, err error) {
+	dlURL, err := md.DownloadURL()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	resp, err := http.Get(dlURL.String())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.NotFoundf("got %d fetching image %q", resp.StatusCode, dlURL)
+	}
+
+	f, err := ioutil.TempFile("", "juju-libvirt-image-")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer func() {
+		f.Close()
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(f, hash), resp.Body); err != nil {
+		return "", errors.Trace(err)
+	}
+	if sum := fmt.Sprintf("%x", hash.Sum(nil)); sum != md.SHA256 {
+		return "", errors.Errorf("hash sum mismatch for %s: %s != %s", dlURL, sum, md.SHA256)
+	}
+	return f.Name(), nil
+}
@@ -0,0 +1,16 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/juju/environs"
+)
+
+const (
+	providerType = "libvirt"
+)
+
+func init() {
+	environs.RegisterProvider(providerType, NewProvider())
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+type environInstance struct {
+	name  string
+	state string
+	env   *environ
+}
+
+var _ instance.Instance = (*environInstance)(nil)
+
+func newInstance(name, state string, env *environ) *environInstance {
+	return &environInstance{
+		name:  name,
+		state: state,
+		env:   env,
+	}
+}
+
+// Id implements instance.Instance.
+func (i *environInstance) Id() instance.Id {
+	return instance.Id(i.name)
+}
+
+// Status implements instance.Instance.
+func (i *environInstance) Status(ctx context.ProviderCallContext) instance.InstanceStatus {
+	jujuStatus := status.Empty
+	switch i.state {
+	case domainRunning:
+		jujuStatus = status.Running
+	case domainCrashed:
+		jujuStatus = status.ProvisioningError
+	}
+	return instance.InstanceStatus{
+		Status:  jujuStatus,
+		Message: i.state,
+	}
+}
+
+// Addresses implements instance.Instance.
+func (i *environInstance) Addresses(ctx context.ProviderCallContext) ([]network.Address, error) {
+	if i.state != domainRunning {
+		return nil, nil
+	}
+	ips, err := i.env.virsh.domainAddresses(i.name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	addrs := make([]network.Address, len(ips))
+	for j, ip := range ips {
+		addrs[j] = network.NewAddress(ip)
+	}
+	return addrs, nil
+}
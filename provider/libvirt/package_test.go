@@ -0,0 +1,44 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	gc "gopkg.in/check.v1"
+)
+
+func TestPackage(t *testing.T) {
+	gc.TestingT(t)
+}
+
+// fakeRunner records the commands it is asked to run, and returns
+// canned output for them. The "-c <uri>" arguments are stripped from
+// virsh commands.
+type fakeRunner struct {
+	calls   []string
+	outputs map[string]string
+	errors  map[string]string
+}
+
+func newFakeRunner() *fakeRunner {
+	return &fakeRunner{
+		outputs: make(map[string]string),
+		errors:  make(map[string]string),
+	}
+}
+
+func (r *fakeRunner) run(command string, args ...string) (string, error) {
+	if command == "virsh" && len(args) >= 2 && args[0] == "-c" {
+		args = args[2:]
+	}
+	call := strings.Join(append([]string{command}, args...), " ")
+	r.calls = append(r.calls, call)
+	if msg, ok := r.errors[call]; ok {
+		return msg, errors.New("exit status 1")
+	}
+	return r.outputs[call], nil
+}
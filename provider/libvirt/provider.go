@@ -0,0 +1,157 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/jsonschema"
+	"github.com/juju/loggo"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+)
+
+var logger = loggo.GetLogger("juju.provider.libvirt")
+
+type environProvider struct {
+	environProviderCredentials
+
+	// run is used to run virsh and the other external commands
+	// needed to manage the libvirt host.
+	run runFunc
+}
+
+var _ environs.CloudEnvironProvider = (*environProvider)(nil)
+
+// NewProvider returns a new libvirt EnvironProvider.
+func NewProvider() environs.CloudEnvironProvider {
+	return &environProvider{run: run}
+}
+
+// Version is part of the EnvironProvider interface.
+func (*environProvider) Version() int {
+	return 0
+}
+
+var cloudSchema = &jsonschema.Schema{
+	Type:     []jsonschema.Type{jsonschema.ObjectType},
+	Required: []string{cloud.EndpointKey, cloud.AuthTypesKey},
+	Order:    []string{cloud.EndpointKey, cloud.AuthTypesKey},
+	Properties: map[string]*jsonschema.Schema{
+		cloud.EndpointKey: {
+			Singular: "the libvirt connection URI (e.g. qemu:///system or qemu+ssh://user@host/system)",
+			Type:     []jsonschema.Type{jsonschema.StringType},
+			Format:   jsonschema.FormatURI,
+		},
+		cloud.AuthTypesKey: {
+			// don't need a prompt, since there's only one choice.
+			Type: []jsonschema.Type{jsonschema.ArrayType},
+			Enum: []interface{}{[]string{string(cloud.EmptyAuthType)}},
+		},
+	},
+}
+
+// CloudSchema returns the schema for adding new clouds of this type.
+func (*environProvider) CloudSchema() *jsonschema.Schema {
+	return cloudSchema
+}
+
+// Ping tests the connection to the cloud, to verify the endpoint is valid.
+func (p *environProvider) Ping(ctx context.ProviderCallContext, endpoint string) error {
+	if err := validateEndpoint(endpoint); err != nil {
+		return errors.Trace(err)
+	}
+	version, err := virsh{uri: endpoint, run: p.run}.version()
+	if err != nil {
+		return errors.Annotatef(err, "no libvirt daemon at %q", endpoint)
+	}
+	logger.Debugf("connected to %q: %s", endpoint, version)
+	return nil
+}
+
+// DetectRegions is specified in the environs.CloudRegionDetector interface.
+func (*environProvider) DetectRegions() ([]cloud.Region, error) {
+	return nil, errors.NotFoundf("regions")
+}
+
+// PrepareConfig is specified in the EnvironProvider interface.
+func (p *environProvider) PrepareConfig(args environs.PrepareConfigParams) (*config.Config, error) {
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	return p.Validate(args.Config, nil)
+}
+
+// Open is specified in the EnvironProvider interface.
+func (p *environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	env, err := newEnviron(p, args.Cloud, args.Config)
+	return env, errors.Trace(err)
+}
+
+// Validate is specified in the config.Validator interface.
+func (p *environProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
+	ecfg, err := newValidConfig(cfg, old)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+	return cfg.Apply(ecfg.attrs)
+}
+
+// Schema returns the configuration schema for an environment.
+func (*environProvider) Schema() environschema.Fields {
+	fields, err := config.Schema(configSchema)
+	if err != nil {
+		panic(err)
+	}
+	return fields
+}
+
+// ConfigSchema returns extra config attributes specific
+// to this provider only.
+func (*environProvider) ConfigSchema() schema.Fields {
+	return configFields
+}
+
+// ConfigDefaults returns the default values for the
+// provider specific config attributes.
+func (*environProvider) ConfigDefaults() schema.Defaults {
+	return configDefaults
+}
+
+func validateCloudSpec(spec environs.CloudSpec) error {
+	if err := spec.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := validateEndpoint(spec.Endpoint); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Credential != nil {
+		if authType := spec.Credential.AuthType(); authType != cloud.EmptyAuthType {
+			return errors.NotSupportedf("%q auth-type", authType)
+		}
+	}
+	return nil
+}
+
+// validateEndpoint checks that the endpoint is a libvirt connection
+// URI, such as qemu:///system or qemu+ssh://user@host/system.
+func validateEndpoint(endpoint string) error {
+	if endpoint == "" {
+		return errors.NotValidf("missing libvirt connection URI")
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" {
+		return errors.NotValidf("libvirt connection URI %q", endpoint)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type providerSuite struct {
+	coretesting.BaseSuite
+
+	runner   *fakeRunner
+	provider *environProvider
+}
+
+var _ = gc.Suite(&providerSuite{})
+
+func (s *providerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.runner = newFakeRunner()
+	s.provider = &environProvider{run: s.runner.run}
+}
+
+func fakeCloudSpec() environs.CloudSpec {
+	cred := cloud.NewEmptyCredential()
+	return environs.CloudSpec{
+		Type:       "libvirt",
+		Name:       "lab",
+		Endpoint:   "qemu+ssh://ubuntu@host/system",
+		Credential: &cred,
+	}
+}
+
+func (s *providerSuite) TestRegistered(c *gc.C) {
+	provider, err := environs.Provider("libvirt")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider, gc.FitsTypeOf, &environProvider{})
+}
+
+func (s *providerSuite) TestValidateDefaults(c *gc.C) {
+	cfg, err := s.provider.Validate(coretesting.ModelConfig(c), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	attrs := cfg.UnknownAttrs()
+	c.Assert(attrs[cfgPool], gc.Equals, "default")
+	c.Assert(attrs[cfgBridge], gc.Equals, "virbr0")
+}
+
+func (s *providerSuite) TestValidatePoolImmutable(c *gc.C) {
+	old, err := coretesting.ModelConfig(c).Apply(map[string]interface{}{cfgPool: "juju"})
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := old.Apply(map[string]interface{}{cfgPool: "default"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.provider.Validate(cfg, old)
+	c.Assert(err, gc.ErrorMatches, "invalid config: libvirt-pool: cannot change from juju to default")
+}
+
+func (s *providerSuite) TestValidateBridgeNotEmpty(c *gc.C) {
+	cfg, err := coretesting.ModelConfig(c).Apply(map[string]interface{}{cfgBridge: ""})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.provider.Validate(cfg, nil)
+	c.Assert(err, gc.ErrorMatches, "invalid config: libvirt-bridge: must not be empty")
+}
+
+func (s *providerSuite) TestPing(c *gc.C) {
+	s.runner.outputs["virsh version --daemon"] = "Running against daemon: 4.0.0\n"
+	err := s.provider.Ping(context.NewCloudCallContext(), "qemu+ssh://ubuntu@host/system")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.runner.calls, jc.DeepEquals, []string{"virsh version --daemon"})
+}
+
+func (s *providerSuite) TestPingFailed(c *gc.C) {
+	s.runner.errors["virsh version --daemon"] = "error: failed to connect to the hypervisor"
+	err := s.provider.Ping(context.NewCloudCallContext(), "qemu+ssh://ubuntu@host/system")
+	c.Assert(err, gc.ErrorMatches, `no libvirt daemon at "qemu\+ssh://ubuntu@host/system": .*`)
+}
+
+func (s *providerSuite) TestPingInvalidEndpoint(c *gc.C) {
+	err := s.provider.Ping(context.NewCloudCallContext(), "host")
+	c.Assert(err, gc.ErrorMatches, `libvirt connection URI "host" not valid`)
+	c.Assert(s.runner.calls, gc.HasLen, 0)
+}
+
+func (s *providerSuite) TestOpenRejectsCredentials(c *gc.C) {
+	spec := fakeCloudSpec()
+	cred := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{"username": "u", "password": "p"})
+	spec.Credential = &cred
+	_, err := s.provider.Open(environs.OpenParams{
+		Cloud:  spec,
+		Config: coretesting.ModelConfig(c),
+	})
+	c.Assert(err, gc.ErrorMatches, `validating cloud spec: "userpass" auth-type not supported`)
+}
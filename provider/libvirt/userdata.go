@@ -0,0 +1,26 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+	jujuos "github.com/juju/os"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/cloudconfig/providerinit/renderers"
+)
+
+type libvirtRenderer struct{}
+
+// Render implements renderers.ProviderRenderer. The user data is
+// written unencoded to the NoCloud data source image.
+func (libvirtRenderer) Render(cfg cloudinit.CloudConfig, os jujuos.OSType) ([]byte, error) {
+	switch os {
+	case jujuos.Ubuntu, jujuos.CentOS:
+		bytes, err := renderers.RenderYAML(cfg)
+		return bytes, errors.Trace(err)
+	default:
+		return nil, errors.Errorf("cannot encode userdata for OS %q", os)
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+// runFunc provides the signature for running an external command and
+// returning the combined output.
+type runFunc func(string, ...string) (string, error)
+
+// run the command and return the combined output.
+func run(command string, args ...string) (string, error) {
+	logger.Debugf("%s %v", command, args)
+	output, err := utils.RunCommand(command, args...)
+	logger.Tracef("output: %v", output)
+	return output, err
+}
+
+// Domain states, as reported by "virsh list".
+const (
+	domainRunning = "running"
+	domainCrashed = "crashed"
+)
+
+// domainListPattern matches the lines output by "virsh -q list --all",
+// which hold the domain's id, name and state separated by whitespace.
+// The id is "-" for inactive domains.
+var domainListPattern = regexp.MustCompile(`(?m)^\s*(?:\d+|-)\s+(?P<name>\S+)\s+(?P<state>.+?)\s*$`)
+
+// virsh drives the libvirt daemon identified by uri, which may be
+// local (qemu:///system) or remote (qemu+ssh://user@host/system),
+// with the virsh command. All files passed to virsh are read on the
+// client side, so the same commands work for either.
+type virsh struct {
+	uri string
+	run runFunc
+}
+
+func (v virsh) exec(args ...string) (string, error) {
+	out, err := v.run("virsh", append([]string{"-c", v.uri}, args...)...)
+	if err != nil {
+		command := args[0]
+		for _, arg := range args {
+			if !strings.HasPrefix(arg, "-") {
+				command = arg
+				break
+			}
+		}
+		return "", errors.Annotatef(err, "virsh %s: %s", command, strings.TrimSpace(out))
+	}
+	return out, nil
+}
+
+// version returns the version of the libvirt daemon, which also
+// verifies that it can be connected to.
+func (v virsh) version() (string, error) {
+	out, err := v.exec("version", "--daemon")
+	return strings.TrimSpace(out), errors.Trace(err)
+}
+
+// listDomains returns the state of each domain, active or not, whose
+// name has the given prefix, keyed by domain name.
+func (v virsh) listDomains(prefix string) (map[string]string, error) {
+	out, err := v.exec("-q", "list", "--all")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string)
+	for _, m := range domainListPattern.FindAllStringSubmatch(out, -1) {
+		name, state := m[1], m[2]
+		if strings.HasPrefix(name, prefix) {
+			result[name] = state
+		}
+	}
+	return result, nil
+}
+
+// defineDomain defines a persistent domain from the XML in the file
+// at domainPath.
+func (v virsh) defineDomain(domainPath string) error {
+	_, err := v.exec("define", domainPath)
+	return errors.Trace(err)
+}
+
+// startDomain starts the named domain, and marks it to be started
+// when the host boots.
+func (v virsh) startDomain(name string) error {
+	if _, err := v.exec("start", name); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := v.exec("autostart", name)
+	return errors.Trace(err)
+}
+
+// removeDomain stops and undefines the named domain. Errors are
+// ignored if the domain is already stopped.
+func (v virsh) removeDomain(name string) error {
+	if _, err := v.exec("destroy", name); err != nil {
+		logger.Debugf("stopping domain %q: %v", name, err)
+	}
+	_, err := v.exec("undefine", "--nvram", name)
+	return errors.Trace(err)
+}
+
+// domainTags returns the tags recorded in the named domain's
+// description.
+func (v virsh) domainTags(name string) (map[string]string, error) {
+	out, err := v.exec("desc", "--config", name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return parseTags(out), nil
+}
+
+// setDomainTags replaces the named domain's description with the
+// given tags.
+func (v virsh) setDomainTags(name string, tags map[string]string) error {
+	_, err := v.exec("desc", "--config", name, "--new-desc", formatTags(tags))
+	return errors.Trace(err)
+}
+
+// domainAddresses returns the IP addresses of the named domain's
+// network interfaces. Addresses are taken from the DHCP leases of
+// libvirt-managed networks, falling back to the host's ARP table for
+// domains attached to other bridges.
+func (v virsh) domainAddresses(name string) ([]string, error) {
+	var addrs []string
+	for _, source := range []string{"lease", "arp"} {
+		out, err := v.exec("domifaddr", name, "--source", source)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		addrs = parseDomainAddresses(out)
+		if len(addrs) > 0 {
+			break
+		}
+	}
+	return addrs, nil
+}
+
+// parseDomainAddresses parses the output of "virsh domifaddr", which
+// has a header followed by a line for each address, holding the
+// interface name, MAC address, protocol and address in CIDR form.
+func parseDomainAddresses(out string) []string {
+	var addrs []string
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		if fields[2] != "ipv4" && fields[2] != "ipv6" {
+			continue
+		}
+		ip, _, err := net.ParseCIDR(fields[3])
+		if err != nil {
+			continue
+		}
+		addrs = append(addrs, ip.String())
+	}
+	return addrs
+}
+
+// volumeExists reports whether the named volume exists in the pool.
+func (v virsh) volumeExists(pool, name string) bool {
+	_, err := v.exec("vol-info", "--pool", pool, name)
+	return err == nil
+}
+
+// createVolume creates a volume in the pool with the given size and
+// format. If backing is not empty, the volume is created as a copy on
+// write overlay of the named qcow2 volume.
+func (v virsh) createVolume(pool, name string, size uint64, format, backing string) error {
+	args := []string{"vol-create-as", pool, name, fmt.Sprint(size), "--format", format}
+	if backing != "" {
+		args = append(args, "--backing-vol", backing, "--backing-vol-format", "qcow2")
+	}
+	_, err := v.exec(args...)
+	return errors.Trace(err)
+}
+
+// uploadVolume uploads the contents of the local file at path to the
+// named volume.
+func (v virsh) uploadVolume(pool, name, path string) error {
+	_, err := v.exec("vol-upload", "--pool", pool, name, path)
+	return errors.Trace(err)
+}
+
+// deleteVolume deletes the named volume. Volumes that do not exist
+// are ignored.
+func (v virsh) deleteVolume(pool, name string) error {
+	if !v.volumeExists(pool, name) {
+		return nil
+	}
+	_, err := v.exec("vol-delete", "--pool", pool, name)
+	return errors.Trace(err)
+}
+
+// volumePath returns the path to the named volume on the libvirt host.
+func (v virsh) volumePath(pool, name string) (string, error) {
+	out, err := v.exec("vol-path", "--pool", pool, name)
+	return strings.TrimSpace(out), errors.Trace(err)
+}
+
+// formatTags renders tags as sorted key=value lines, for recording in
+// a domain's description.
+func formatTags(tags map[string]string) string {
+	lines := make([]string, 0, len(tags))
+	for k, v := range tags {
+		lines = append(lines, k+"="+v)
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+// parseTags parses tags rendered by formatTags. Lines that are not
+// key=value pairs are ignored.
+func parseTags(desc string) map[string]string {
+	tags := make(map[string]string)
+	for _, line := range strings.Split(desc, "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			continue
+		}
+		tags[parts[0]] = parts[1]
+	}
+	return tags
+}
@@ -0,0 +1,113 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type virshSuite struct {
+	testing.IsolationSuite
+
+	runner *fakeRunner
+	virsh  virsh
+}
+
+var _ = gc.Suite(&virshSuite{})
+
+func (s *virshSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.runner = newFakeRunner()
+	s.virsh = virsh{uri: "qemu+ssh://ubuntu@host/system", run: s.runner.run}
+}
+
+func (s *virshSuite) TestListDomains(c *gc.C) {
+	s.runner.outputs["virsh -q list --all"] = "" +
+		" 1     juju-abc123-0    running\n" +
+		" -     juju-abc123-1    shut off\n" +
+		" 3     other            running\n"
+	domains, err := s.virsh.listDomains("juju-abc123-")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(domains, jc.DeepEquals, map[string]string{
+		"juju-abc123-0": "running",
+		"juju-abc123-1": "shut off",
+	})
+}
+
+func (s *virshSuite) TestListDomainsError(c *gc.C) {
+	s.runner.errors["virsh -q list --all"] = "error: failed to connect to the hypervisor\n"
+	_, err := s.virsh.listDomains("")
+	c.Assert(err, gc.ErrorMatches, "virsh list: error: failed to connect to the hypervisor: exit status 1")
+}
+
+func (s *virshSuite) TestDomainAddresses(c *gc.C) {
+	s.runner.outputs["virsh domifaddr juju-abc123-0 --source lease"] = "" +
+		" Name       MAC address          Protocol     Address\n" +
+		"-------------------------------------------------------------------------------\n" +
+		" vnet0      52:54:00:8e:d7:2f    ipv4         192.168.122.54/24\n"
+	addrs, err := s.virsh.domainAddresses("juju-abc123-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, jc.DeepEquals, []string{"192.168.122.54"})
+	c.Assert(s.runner.calls, gc.HasLen, 1)
+}
+
+func (s *virshSuite) TestDomainAddressesFallsBackToARP(c *gc.C) {
+	s.runner.outputs["virsh domifaddr juju-abc123-0 --source arp"] = "" +
+		" Name       MAC address          Protocol     Address\n" +
+		"-------------------------------------------------------------------------------\n" +
+		" vnet0      52:54:00:8e:d7:2f    ipv4         10.0.0.12/0\n"
+	addrs, err := s.virsh.domainAddresses("juju-abc123-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, jc.DeepEquals, []string{"10.0.0.12"})
+	c.Assert(s.runner.calls, jc.DeepEquals, []string{
+		"virsh domifaddr juju-abc123-0 --source lease",
+		"virsh domifaddr juju-abc123-0 --source arp",
+	})
+}
+
+func (s *virshSuite) TestDomainTags(c *gc.C) {
+	s.runner.outputs["virsh desc --config juju-abc123-0"] = "juju-controller-uuid=deadbeef\njuju-is-controller=true\n"
+	tags, err := s.virsh.domainTags("juju-abc123-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, jc.DeepEquals, map[string]string{
+		"juju-controller-uuid": "deadbeef",
+		"juju-is-controller":   "true",
+	})
+}
+
+func (s *virshSuite) TestDomainTagsNoDescription(c *gc.C) {
+	s.runner.outputs["virsh desc --config juju-abc123-0"] = "No description for domain: juju-abc123-0\n"
+	tags, err := s.virsh.domainTags("juju-abc123-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, gc.HasLen, 0)
+}
+
+func (s *virshSuite) TestSetDomainTags(c *gc.C) {
+	err := s.virsh.setDomainTags("juju-abc123-0", map[string]string{
+		"juju-model-uuid":      "cafe",
+		"juju-controller-uuid": "deadbeef",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.runner.calls, jc.DeepEquals, []string{
+		"virsh desc --config juju-abc123-0 --new-desc juju-controller-uuid=deadbeef\njuju-model-uuid=cafe",
+	})
+}
+
+func (s *virshSuite) TestCreateVolumeWithBacking(c *gc.C) {
+	err := s.virsh.createVolume("default", "juju-abc123-0.qcow2", 8589934592, "qcow2", "juju-bionic-amd64-base.qcow2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.runner.calls, jc.DeepEquals, []string{
+		"virsh vol-create-as default juju-abc123-0.qcow2 8589934592 --format qcow2 " +
+			"--backing-vol juju-bionic-amd64-base.qcow2 --backing-vol-format qcow2",
+	})
+}
+
+func (s *virshSuite) TestDeleteVolumeMissing(c *gc.C) {
+	s.runner.errors["virsh vol-info --pool default juju-abc123-0.qcow2"] = "error: failed to get vol"
+	err := s.virsh.deleteVolume("default", "juju-abc123-0.qcow2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.runner.calls, gc.HasLen, 1)
+}
@@ -119,7 +119,7 @@ func (env *environ) newContainer(
 	}
 	cleanupCallback() // Clean out any long line of completed download status
 
-	cSpec, err := env.getContainerSpec(image, args)
+	cSpec, err := env.getContainerSpec(ctx, image, args)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -171,7 +171,7 @@ func (env *environ) getImageSources() ([]lxd.ServerSpec, error) {
 // Cloud-init config is generated based on the network devices in the default
 // profile and included in the spec config.
 func (env *environ) getContainerSpec(
-	image lxd.SourcedImage, args environs.StartInstanceParams,
+	ctx context.ProviderCallContext, image lxd.SourcedImage, args environs.StartInstanceParams,
 ) (lxd.ContainerSpec, error) {
 	hostname, err := env.namespace.Hostname(args.InstanceConfig.MachineId)
 	if err != nil {
@@ -197,6 +197,16 @@ func (env *environ) getContainerSpec(
 	if err != nil {
 		return cSpec, errors.Trace(err)
 	}
+	// If the container is constrained to spaces, eth0 is attached to
+	// the LXD network of one of the matching subnets.
+	if len(args.SubnetsToZones) > 0 {
+		netName, err := env.subnetsNetwork(ctx, args.SubnetsToZones)
+		if err != nil {
+			return cSpec, errors.Trace(err)
+		}
+		nics = nicsWithParent(nics, netName)
+		cSpec.Devices = nics
+	}
 	if !(len(nics) == 1 && nics["eth0"] != nil) {
 		logger.Debugf("generating custom cloud-init networking")
 
@@ -240,6 +250,26 @@ func (env *environ) getContainerSpec(
 	return cSpec, nil
 }
 
+// nicsWithParent returns a copy of the input NIC devices, with eth0
+// bridged to the named network.
+func nicsWithParent(nics map[string]map[string]string, parent string) map[string]map[string]string {
+	result := make(map[string]map[string]string, len(nics)+1)
+	for name, device := range nics {
+		result[name] = device
+	}
+	eth0 := map[string]string{
+		"type":    "nic",
+		"nictype": "bridged",
+		"name":    "eth0",
+	}
+	for k, v := range nics["eth0"] {
+		eth0[k] = v
+	}
+	eth0["parent"] = parent
+	result["eth0"] = eth0
+	return result
+}
+
 // getTargetServer checks to see if a valid zone was passed as a placement
 // directive in the start-up start-up arguments. If so, a server for the
 // specific node is returned.
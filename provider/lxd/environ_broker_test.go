@@ -17,6 +17,7 @@ import (
 	containerlxd "github.com/juju/juju/container/lxd"
 	lxdtesting "github.com/juju/juju/container/lxd/testing"
 	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/lxd"
 )
 
@@ -121,6 +122,51 @@ func (s *environBrokerSuite) TestStartInstanceNonDefaultNIC(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *environBrokerSuite) TestStartInstanceSubnetsToZones(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	networks := []api.Network{{
+		Name:    "lxdbr1",
+		Managed: true,
+		NetworkPut: api.NetworkPut{
+			Config: map[string]string{"ipv4.address": "10.10.0.1/24"},
+		},
+	}}
+
+	// Check that eth0 is explicitly bridged to the network of the
+	// matching subnet.
+	check := func(spec containerlxd.ContainerSpec) bool {
+		return reflect.DeepEqual(spec.Devices, map[string]map[string]string{
+			"eth0": {
+				"type":    "nic",
+				"nictype": "bridged",
+				"name":    "eth0",
+				"parent":  "lxdbr1",
+			},
+		})
+	}
+
+	exp := svr.EXPECT()
+	gomock.InOrder(
+		exp.HostArch().Return(arch.AMD64),
+		exp.FindImage("bionic", arch.AMD64, gomock.Any(), true, gomock.Any()).Return(containerlxd.SourcedImage{}, nil),
+		exp.GetNICsFromProfile("default").Return(map[string]map[string]string{
+			"eth0": {"type": "nic", "nictype": "bridged", "parent": "lxdbr0"},
+		}, nil),
+		exp.GetNetworks().Return(networks, nil),
+		exp.CreateContainerFromSpec(matchesContainerSpec(check)).Return(&containerlxd.Container{}, nil),
+		exp.HostArch().Return(arch.AMD64),
+	)
+
+	args := s.GetStartInstanceArgs(c, "bionic")
+	args.SubnetsToZones = map[network.Id][]string{"lxdbr1": nil}
+	env := s.NewEnviron(c, svr, nil)
+	_, err := env.StartInstance(s.callCtx, args)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *environBrokerSuite) TestStartInstanceWithPlacementAvailable(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -0,0 +1,194 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd
+
+import (
+	"net"
+	"sort"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	lxdapi "github.com/lxc/lxd/shared/api"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+var _ environs.Networking = (*environ)(nil)
+
+// Subnets is part of the environs.Networking interface.
+// The subnets of each LXD managed network are returned; the provider ID
+// of a subnet is the network name, suffixed with "-ipv6" for an IPv6
+// subnet.
+func (env *environ) Subnets(
+	ctx context.ProviderCallContext, inst instance.Id, subnetIds []network.Id,
+) ([]network.SubnetInfo, error) {
+	if inst != instance.UnknownId {
+		return nil, errors.NotSupportedf("subnets with instance Id")
+	}
+	spaces, err := env.Spaces(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	wanted := set.NewStrings()
+	for _, id := range subnetIds {
+		wanted.Add(string(id))
+	}
+	var results []network.SubnetInfo
+	for _, space := range spaces {
+		for _, subnet := range space.Subnets {
+			if len(subnetIds) > 0 && !wanted.Contains(string(subnet.ProviderId)) {
+				continue
+			}
+			wanted.Remove(string(subnet.ProviderId))
+			results = append(results, subnet)
+		}
+	}
+	if len(subnetIds) > 0 && !wanted.IsEmpty() {
+		return nil, errors.NotFoundf("subnets %v", wanted.SortedValues())
+	}
+	return results, nil
+}
+
+// SuperSubnets is part of the environs.Networking interface.
+func (env *environ) SuperSubnets(ctx context.ProviderCallContext) ([]string, error) {
+	subnets, err := env.Subnets(ctx, instance.UnknownId, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cidrs := make([]string, len(subnets))
+	for i, subnet := range subnets {
+		cidrs[i] = subnet.CIDR
+	}
+	return cidrs, nil
+}
+
+// NetworkInterfaces is part of the environs.Networking interface.
+func (*environ) NetworkInterfaces(ctx context.ProviderCallContext, instId instance.Id) ([]network.InterfaceInfo, error) {
+	return nil, errors.NotSupportedf("network interfaces")
+}
+
+// SupportsSpaces is part of the environs.Networking interface.
+func (*environ) SupportsSpaces(ctx context.ProviderCallContext) (bool, error) {
+	return true, nil
+}
+
+// SupportsSpaceDiscovery is part of the environs.Networking interface.
+func (*environ) SupportsSpaceDiscovery(ctx context.ProviderCallContext) (bool, error) {
+	return true, nil
+}
+
+// Spaces is part of the environs.Networking interface.
+// Each LXD managed network with a subnet is returned as a space named
+// after the network.
+func (env *environ) Spaces(ctx context.ProviderCallContext) ([]network.SpaceInfo, error) {
+	networks, err := env.server.GetNetworks()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting LXD networks")
+	}
+	var spaces []network.SpaceInfo
+	for _, lxdNet := range networks {
+		subnets := managedNetworkSubnets(lxdNet)
+		if len(subnets) == 0 {
+			continue
+		}
+		spaces = append(spaces, network.SpaceInfo{
+			Name:       lxdNet.Name,
+			ProviderId: network.Id(lxdNet.Name),
+			Subnets:    subnets,
+		})
+	}
+	sort.Slice(spaces, func(i, j int) bool {
+		return spaces[i].Name < spaces[j].Name
+	})
+	return spaces, nil
+}
+
+// managedNetworkSubnets returns the IPv4 and IPv6 subnets of the given
+// network, if it is managed by LXD.
+func managedNetworkSubnets(lxdNet lxdapi.Network) []network.SubnetInfo {
+	if !lxdNet.Managed {
+		return nil
+	}
+	networkId := network.Id(lxdNet.Name)
+	var subnets []network.SubnetInfo
+	for _, family := range []struct {
+		key, suffix string
+	}{{"ipv4.address", ""}, {"ipv6.address", "-ipv6"}} {
+		_, ipNet, err := net.ParseCIDR(lxdNet.Config[family.key])
+		if err != nil {
+			logger.Tracef("ignoring %s %q of network %q", family.key, lxdNet.Config[family.key], lxdNet.Name)
+			continue
+		}
+		subnets = append(subnets, network.SubnetInfo{
+			CIDR:              ipNet.String(),
+			ProviderId:        network.Id(lxdNet.Name + family.suffix),
+			ProviderNetworkId: networkId,
+			SpaceProviderId:   networkId,
+		})
+	}
+	return subnets
+}
+
+// subnetsNetwork returns the name of the LXD network to use for a
+// container constrained to the given subnets.
+func (env *environ) subnetsNetwork(ctx context.ProviderCallContext, subnetsToZones map[network.Id][]string) (string, error) {
+	subnetIds := make([]string, 0, len(subnetsToZones))
+	for id := range subnetsToZones {
+		subnetIds = append(subnetIds, string(id))
+	}
+	sort.Strings(subnetIds)
+	subnets, err := env.Subnets(ctx, instance.UnknownId, nil)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	known := make(map[string]network.Id)
+	for _, subnet := range subnets {
+		known[string(subnet.ProviderId)] = subnet.ProviderNetworkId
+	}
+	for _, id := range subnetIds {
+		if networkId, ok := known[id]; ok {
+			return string(networkId), nil
+		}
+	}
+	return "", errors.NotFoundf("LXD network for subnets %v", subnetIds)
+}
+
+// SupportsContainerAddresses is part of the environs.Networking interface.
+func (*environ) SupportsContainerAddresses(ctx context.ProviderCallContext) (bool, error) {
+	return false, nil
+}
+
+// AllocateContainerAddresses is part of the environs.Networking interface.
+func (*environ) AllocateContainerAddresses(
+	ctx context.ProviderCallContext,
+	hostInstanceID instance.Id,
+	containerTag names.MachineTag,
+	preparedInfo []network.InterfaceInfo,
+) ([]network.InterfaceInfo, error) {
+	return nil, errors.NotSupportedf("container addresses")
+}
+
+// ReleaseContainerAddresses is part of the environs.Networking interface.
+func (*environ) ReleaseContainerAddresses(ctx context.ProviderCallContext, interfaces []network.ProviderInterfaceInfo) error {
+	return errors.NotSupportedf("container addresses")
+}
+
+// ProviderSpaceInfo is part of the environs.Networking interface.
+func (*environ) ProviderSpaceInfo(ctx context.ProviderCallContext, space *network.SpaceInfo) (*environs.ProviderSpaceInfo, error) {
+	return nil, errors.NotSupportedf("provider space info")
+}
+
+// AreSpacesRoutable is part of the environs.Networking interface.
+func (*environ) AreSpacesRoutable(ctx context.ProviderCallContext, space1, space2 *environs.ProviderSpaceInfo) (bool, error) {
+	return false, nil
+}
+
+// SSHAddresses is part of the environs.Networking interface.
+func (*environ) SSHAddresses(ctx context.ProviderCallContext, addresses []network.Address) ([]network.Address, error) {
+	return addresses, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd/shared/api"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/lxd"
+)
+
+type environNetworkSuite struct {
+	lxd.EnvironSuite
+
+	callCtx context.ProviderCallContext
+}
+
+var _ = gc.Suite(&environNetworkSuite{})
+
+func (s *environNetworkSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.callCtx = context.NewCloudCallContext()
+}
+
+var lxdNetworks = []api.Network{{
+	Name:    "lxdbr1",
+	Managed: true,
+	NetworkPut: api.NetworkPut{
+		Config: map[string]string{"ipv4.address": "10.10.0.1/24"},
+	},
+}, {
+	Name:    "eth0",
+	Managed: false,
+}, {
+	Name:    "lxdbr0",
+	Managed: true,
+	NetworkPut: api.NetworkPut{
+		Config: map[string]string{
+			"ipv4.address": "10.0.8.1/24",
+			"ipv6.address": "fd42:8::1/64",
+		},
+	},
+}, {
+	Name:    "ipv6only",
+	Managed: true,
+	NetworkPut: api.NetworkPut{
+		Config: map[string]string{
+			"ipv4.address": "none",
+			"ipv6.address": "fd42:9::1/64",
+		},
+	},
+}}
+
+func (s *environNetworkSuite) networkingEnviron(c *gc.C, svr lxd.Server) environs.Networking {
+	netEnv, ok := environs.SupportsNetworking(s.NewEnviron(c, svr, nil))
+	c.Assert(ok, jc.IsTrue)
+	return netEnv
+}
+
+func (s *environNetworkSuite) TestSupportsSpaces(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	env := s.networkingEnviron(c, lxd.NewMockServer(ctrl))
+
+	supported, err := env.SupportsSpaces(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(supported, jc.IsTrue)
+	supported, err = env.SupportsSpaceDiscovery(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(supported, jc.IsTrue)
+}
+
+func (s *environNetworkSuite) TestSpaces(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+	svr.EXPECT().GetNetworks().Return(lxdNetworks, nil)
+
+	spaces, err := s.networkingEnviron(c, svr).Spaces(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spaces, jc.DeepEquals, []network.SpaceInfo{{
+		Name:       "ipv6only",
+		ProviderId: "ipv6only",
+		Subnets: []network.SubnetInfo{{
+			CIDR:              "fd42:9::/64",
+			ProviderId:        "ipv6only-ipv6",
+			ProviderNetworkId: "ipv6only",
+			SpaceProviderId:   "ipv6only",
+		}},
+	}, {
+		Name:       "lxdbr0",
+		ProviderId: "lxdbr0",
+		Subnets: []network.SubnetInfo{{
+			CIDR:              "10.0.8.0/24",
+			ProviderId:        "lxdbr0",
+			ProviderNetworkId: "lxdbr0",
+			SpaceProviderId:   "lxdbr0",
+		}, {
+			CIDR:              "fd42:8::/64",
+			ProviderId:        "lxdbr0-ipv6",
+			ProviderNetworkId: "lxdbr0",
+			SpaceProviderId:   "lxdbr0",
+		}},
+	}, {
+		Name:       "lxdbr1",
+		ProviderId: "lxdbr1",
+		Subnets: []network.SubnetInfo{{
+			CIDR:              "10.10.0.0/24",
+			ProviderId:        "lxdbr1",
+			ProviderNetworkId: "lxdbr1",
+			SpaceProviderId:   "lxdbr1",
+		}},
+	}})
+}
+
+func (s *environNetworkSuite) TestSpacesError(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+	svr.EXPECT().GetNetworks().Return(nil, errors.New("boom"))
+
+	_, err := s.networkingEnviron(c, svr).Spaces(s.callCtx)
+	c.Assert(err, gc.ErrorMatches, "getting LXD networks: boom")
+}
+
+func (s *environNetworkSuite) TestSubnets(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+	svr.EXPECT().GetNetworks().Return(lxdNetworks, nil)
+
+	subnets, err := s.networkingEnviron(c, svr).Subnets(s.callCtx, instance.UnknownId, []network.Id{"lxdbr1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, gc.HasLen, 1)
+	c.Check(subnets[0].CIDR, gc.Equals, "10.10.0.0/24")
+}
+
+func (s *environNetworkSuite) TestSubnetsMissing(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+	svr.EXPECT().GetNetworks().Return(lxdNetworks, nil)
+
+	_, err := s.networkingEnviron(c, svr).Subnets(s.callCtx, instance.UnknownId, []network.Id{"lxdbr0", "eth0"})
+	c.Assert(err, gc.ErrorMatches, `subnets \[eth0\] not found`)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *environNetworkSuite) TestSubnetsWithInstanceNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	_, err := s.networkingEnviron(c, lxd.NewMockServer(ctrl)).Subnets(s.callCtx, "juju-0", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
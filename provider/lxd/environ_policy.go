@@ -23,6 +23,8 @@ var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
 	constraints.Container,
+	constraints.Spot,
+	constraints.MaxPrice,
 }
 
 // ConstraintsValidator returns a Validator value which is used to
@@ -171,6 +171,8 @@ func (s *environPolicySuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 		"cores=2",
 		"cpu-power=250",
 		"virt-type=kvm",
+		"spot=true",
+		"max-price=0.05",
 	}, " "))
 	unsupported, err := validator.Validate(cons)
 	c.Assert(err, jc.ErrorIsNil)
@@ -179,6 +181,8 @@ func (s *environPolicySuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 		"tags",
 		"cpu-power",
 		"virt-type",
+		"spot",
+		"max-price",
 	}
 	c.Check(unsupported, jc.SameContents, expected)
 }
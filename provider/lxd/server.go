@@ -67,6 +67,7 @@ type Server interface {
 	HostArch() string
 	EnableHTTPSListener() error
 	GetNICsFromProfile(profName string) (map[string]map[string]string, error)
+	GetNetworks() (networks []lxdapi.Network, err error)
 	IsClustered() bool
 	UseTargetServer(name string) (*lxd.Server, error)
 	GetClusterMembers() (members []lxdapi.ClusterMember, err error)
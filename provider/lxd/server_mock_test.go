@@ -268,6 +268,19 @@ func (mr *MockServerMockRecorder) GetNICsFromProfile(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNICsFromProfile", reflect.TypeOf((*MockServer)(nil).GetNICsFromProfile), arg0)
 }
 
+// GetNetworks mocks base method
+func (m *MockServer) GetNetworks() ([]api.Network, error) {
+	ret := m.ctrl.Call(m, "GetNetworks")
+	ret0, _ := ret[0].([]api.Network)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworks indicates an expected call of GetNetworks
+func (mr *MockServerMockRecorder) GetNetworks() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworks", reflect.TypeOf((*MockServer)(nil).GetNetworks))
+}
+
 // GetProfile mocks base method
 func (m *MockServer) GetProfile(arg0 string) (*api.Profile, string, error) {
 	ret := m.ctrl.Call(m, "GetProfile", arg0)
@@ -431,6 +431,7 @@ type StubClient struct {
 	Container          *lxd.Container
 	Server             *api.Server
 	Profile            *api.Profile
+	Networks           []api.Network
 	StorageIsSupported bool
 	Volumes            map[string][]api.StorageVolume
 	ServerCert         string
@@ -670,6 +671,11 @@ func (conn *StubClient) GetNICsFromProfile(profName string) (map[string]map[stri
 	return conn.Profile.Devices, conn.NextErr()
 }
 
+func (conn *StubClient) GetNetworks() ([]api.Network, error) {
+	conn.AddCall("GetNetworks")
+	return conn.Networks, conn.NextErr()
+}
+
 func (conn *StubClient) IsClustered() bool {
 	conn.AddCall("IsClustered")
 	return true
@@ -21,6 +21,8 @@ var unsupportedConstraints = []string{
 	constraints.CpuPower,
 	constraints.InstanceType,
 	constraints.VirtType,
+	constraints.Spot,
+	constraints.MaxPrice,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -623,11 +623,15 @@ func (e *maasEnviron) parsePlacement(ctx context.ProviderCallContext, placement
 }
 
 func (env *maasEnviron) PrecheckInstance(ctx context.ProviderCallContext, args environs.PrecheckInstanceParams) error {
-	if args.Placement == "" {
-		return nil
+	var zone string
+	if args.Placement != "" {
+		placement, err := env.parsePlacement(ctx, args.Placement)
+		if err != nil {
+			return err
+		}
+		zone = placement.zoneName
 	}
-	_, err := env.parsePlacement(ctx, args.Placement)
-	return err
+	return common.ValidateZonesConstraint(env, ctx, args.Constraints, zone)
 }
 
 const (
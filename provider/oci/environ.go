@@ -321,6 +321,8 @@ func (e *Environ) ConstraintsValidator(ctx envcontext.ProviderCallContext) (cons
 		constraints.Container,
 		constraints.VirtType,
 		constraints.Tags,
+		constraints.Spot,
+		constraints.MaxPrice,
 	}
 
 	validator := constraints.NewValidator()
@@ -0,0 +1,265 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/dns"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+var _ environs.DNSZones = (*Environ)(nil)
+
+// DNSZone is part of the environs.DNSZones interface. Records are kept
+// in the Designate zone with the given name.
+func (e *Environ) DNSZone(ctx context.ProviderCallContext, name string) (dns.Zone, error) {
+	client := e.client()
+	if !client.IsAuthenticated() {
+		if err := authenticateClient(client); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	endpoint, err := makeServiceURL(client, "dns", "", nil)
+	if err != nil {
+		return nil, errors.NotSupportedf("DNS without a Designate endpoint")
+	}
+
+	httpClient := utils.GetHTTPClient(utils.VerifySSLHostnames)
+	if len(e.cloud.CACertificates) > 0 {
+		httpClient = &http.Client{
+			Transport: utils.NewHttpTLSTransport(tlsConfig(e.cloud.CACertificates)),
+		}
+	} else if !e.ecfg().SSLHostnameVerification() {
+		httpClient = utils.GetNonValidatingHTTPClient()
+	}
+	zone, err := newDesignateZone(httpClient, endpoint, client.Token, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return zone, nil
+}
+
+// designateZone is a Designate zone. There is no Designate client in
+// the OpenStack library used by the provider, so requests are made
+// directly against the v2 REST API.
+type designateZone struct {
+	client   *http.Client
+	endpoint string
+	token    func() string
+
+	id string
+}
+
+func newDesignateZone(client *http.Client, endpoint string, token func() string, name string) (*designateZone, error) {
+	z := &designateZone{
+		client:   client,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		token:    token,
+	}
+	name = dns.Fqdn(name)
+	var resp struct {
+		Zones []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"zones"`
+	}
+	if err := z.do("GET", "/v2/zones?"+url.Values{"name": {name}}.Encode(), nil, &resp); err != nil {
+		return nil, errors.Annotatef(err, "finding zone %q", name)
+	}
+	for _, zone := range resp.Zones {
+		if dns.Fqdn(zone.Name) == name {
+			z.id = zone.ID
+			return z, nil
+		}
+	}
+	return nil, errors.NotFoundf("zone %q", name)
+}
+
+type designateRecordSet struct {
+	ID      string   `json:"id,omitempty"`
+	Name    string   `json:"name,omitempty"`
+	Type    string   `json:"type,omitempty"`
+	TTL     int      `json:"ttl,omitempty"`
+	Records []string `json:"records"`
+}
+
+func (rs designateRecordSet) record() dns.Record {
+	return dns.Record{
+		Name:   dns.Fqdn(rs.Name),
+		Type:   rs.Type,
+		TTL:    rs.TTL,
+		Values: rs.Records,
+	}
+}
+
+// listRecordSets returns the record sets in the zone that match the
+// query, following the links to later pages.
+func (z *designateZone) listRecordSets(query url.Values) ([]designateRecordSet, error) {
+	var result []designateRecordSet
+	path := "/v2/zones/" + z.id + "/recordsets"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	for path != "" {
+		var resp struct {
+			RecordSets []designateRecordSet `json:"recordsets"`
+			Links      struct {
+				Next string `json:"next"`
+			} `json:"links"`
+		}
+		if err := z.do("GET", path, nil, &resp); err != nil {
+			return nil, errors.Annotate(err, "listing record sets")
+		}
+		result = append(result, resp.RecordSets...)
+		path = ""
+		if resp.Links.Next != "" {
+			next, err := url.Parse(resp.Links.Next)
+			if err != nil {
+				return nil, errors.Annotate(err, "parsing next page link")
+			}
+			path = strings.TrimPrefix(next.RequestURI(), z.basePath())
+		}
+	}
+	return result, nil
+}
+
+// basePath returns the path component of the endpoint, which prefixes
+// the paths of the links in responses.
+func (z *designateZone) basePath() string {
+	u, err := url.Parse(z.endpoint)
+	if err != nil {
+		return ""
+	}
+	return u.Path
+}
+
+// recordSet returns the record set with the record's name and type, or
+// nil if there is none.
+func (z *designateZone) recordSet(record dns.Record) (*designateRecordSet, error) {
+	recordSets, err := z.listRecordSets(url.Values{
+		"name": {dns.Fqdn(record.Name)},
+		"type": {record.Type},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rs := range recordSets {
+		if dns.Fqdn(rs.Name) == dns.Fqdn(record.Name) && rs.Type == record.Type {
+			return &rs, nil
+		}
+	}
+	return nil, nil
+}
+
+// Records is part of the dns.Zone interface.
+func (z *designateZone) Records(domain string) ([]dns.Record, error) {
+	recordSets, err := z.listRecordSets(nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var records []dns.Record
+	for _, rs := range recordSets {
+		if rs.Type != dns.TypeA && rs.Type != dns.TypeAAAA {
+			continue
+		}
+		if !dns.InDomain(rs.Name, domain) {
+			continue
+		}
+		records = append(records, rs.record())
+	}
+	dns.SortRecords(records)
+	return records, nil
+}
+
+// SetRecord is part of the dns.Zone interface.
+func (z *designateZone) SetRecord(record dns.Record) error {
+	name := dns.Fqdn(record.Name)
+	existing, err := z.recordSet(record)
+	if err != nil {
+		return errors.Annotatef(err, "setting %s records for %q", record.Type, name)
+	}
+	if existing == nil {
+		err = z.do("POST", "/v2/zones/"+z.id+"/recordsets", designateRecordSet{
+			Name:    name,
+			Type:    record.Type,
+			TTL:     record.TTL,
+			Records: record.Values,
+		}, nil)
+	} else {
+		err = z.do("PUT", "/v2/zones/"+z.id+"/recordsets/"+existing.ID, designateRecordSet{
+			TTL:     record.TTL,
+			Records: record.Values,
+		}, nil)
+	}
+	return errors.Annotatef(err, "setting %s records for %q", record.Type, name)
+}
+
+// RemoveRecord is part of the dns.Zone interface.
+func (z *designateZone) RemoveRecord(record dns.Record) error {
+	name := dns.Fqdn(record.Name)
+	existing, err := z.recordSet(record)
+	if err != nil {
+		return errors.Annotatef(err, "removing %s records for %q", record.Type, name)
+	}
+	if existing == nil {
+		return errors.NotFoundf("%s records for %q", record.Type, name)
+	}
+	err = z.do("DELETE", "/v2/zones/"+z.id+"/recordsets/"+existing.ID, nil, nil)
+	return errors.Annotatef(err, "removing %s records for %q", record.Type, name)
+}
+
+// do sends an authenticated request to the Designate API, encoding body
+// and decoding the response into resp if they are not nil.
+func (z *designateZone) do(method, path string, body, resp interface{}) error {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	req, err := http.NewRequest(method, z.endpoint+path, bytes.NewReader(data))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Auth-Token", z.token())
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	httpResp, err := z.client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer httpResp.Body.Close()
+	data, err = ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if httpResp.StatusCode >= 300 {
+		var errResp struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(data, &errResp); err != nil || errResp.Message == "" {
+			return errors.Errorf("%s %s: %s", method, path, strings.TrimSpace(httpResp.Status))
+		}
+		return errors.Errorf("%s %s: %s", method, path, errResp.Message)
+	}
+	if resp == nil || len(data) == 0 {
+		return nil
+	}
+	return errors.Trace(json.Unmarshal(data, resp))
+}
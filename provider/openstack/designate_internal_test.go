@@ -0,0 +1,190 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/dns"
+)
+
+type designateSuite struct {
+	testing.IsolationSuite
+
+	api    *fakeDesignate
+	server *httptest.Server
+}
+
+var _ = gc.Suite(&designateSuite{})
+
+func (s *designateSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &fakeDesignate{
+		zoneName: "example.com.",
+		recordSets: []designateRecordSet{
+			{ID: "1", Name: "example.com.", Type: "SOA", TTL: 900, Records: []string{"ns.example.com. hostmaster.example.com. 1 7200 900 1209600 86400"}},
+			{ID: "2", Name: "wordpress.m.example.com.", Type: "A", TTL: 300, Records: []string{"10.0.0.1", "10.0.0.2"}},
+			{ID: "3", Name: "m.example.com.", Type: "TXT", TTL: 300, Records: []string{`"hello"`}},
+			{ID: "4", Name: "mysql-0.m.example.com.", Type: "A", TTL: 300, Records: []string{"10.0.0.3"}},
+			{ID: "5", Name: "www.example.com.", Type: "A", TTL: 300, Records: []string{"192.0.2.1"}},
+		},
+	}
+	s.server = httptest.NewServer(s.api)
+	s.api.url = s.server.URL + "/dns"
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *designateSuite) newZone(c *gc.C) *designateZone {
+	zone, err := newDesignateZone(http.DefaultClient, s.api.url+"/", func() string { return "token" }, "Example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	return zone
+}
+
+func (s *designateSuite) TestZoneNotFound(c *gc.C) {
+	_, err := newDesignateZone(http.DefaultClient, s.api.url, func() string { return "token" }, "example.org")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `zone "example.org." not found`)
+}
+
+func (s *designateSuite) TestRecords(c *gc.C) {
+	records, err := s.newZone(c).Records("m.example.com.")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, jc.DeepEquals, []dns.Record{
+		{"mysql-0.m.example.com.", dns.TypeA, 300, []string{"10.0.0.3"}},
+		{"wordpress.m.example.com.", dns.TypeA, 300, []string{"10.0.0.1", "10.0.0.2"}},
+	})
+	c.Assert(s.api.requests, jc.DeepEquals, []string{
+		"GET /dns/v2/zones",
+		"GET /dns/v2/zones/z1/recordsets",
+		"GET /dns/v2/zones/z1/recordsets",
+		"GET /dns/v2/zones/z1/recordsets",
+	})
+}
+
+func (s *designateSuite) TestSetRecordCreates(c *gc.C) {
+	err := s.newZone(c).SetRecord(dns.Record{
+		Name:   "mysql-0.m.example.com",
+		Type:   dns.TypeAAAA,
+		TTL:    60,
+		Values: []string{"2001:db8::1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.changes, jc.DeepEquals, []string{"POST mysql-0.m.example.com. AAAA 60 [2001:db8::1]"})
+}
+
+func (s *designateSuite) TestSetRecordUpdates(c *gc.C) {
+	err := s.newZone(c).SetRecord(dns.Record{
+		Name:   "mysql-0.m.example.com",
+		Type:   dns.TypeA,
+		TTL:    60,
+		Values: []string{"10.0.0.4"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.changes, jc.DeepEquals, []string{"PUT 4 60 [10.0.0.4]"})
+}
+
+func (s *designateSuite) TestRemoveRecord(c *gc.C) {
+	err := s.newZone(c).RemoveRecord(dns.Record{Name: "wordpress.m.example.com.", Type: dns.TypeA})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.changes, jc.DeepEquals, []string{"DELETE 2"})
+}
+
+func (s *designateSuite) TestRemoveRecordNotFound(c *gc.C) {
+	err := s.newZone(c).RemoveRecord(dns.Record{Name: "mysql-0.m.example.com.", Type: dns.TypeAAAA})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(s.api.changes, gc.HasLen, 0)
+}
+
+func (s *designateSuite) TestError(c *gc.C) {
+	zone := s.newZone(c)
+	s.api.fail = true
+	_, err := zone.Records("m.example.com.")
+	c.Assert(err, gc.ErrorMatches, "listing record sets: GET /v2/zones/z1/recordsets: not authorized")
+}
+
+// fakeDesignate implements enough of the Designate v2 API to test
+// designateZone. Unfiltered record sets are listed two at a time.
+type fakeDesignate struct {
+	url        string
+	zoneName   string
+	recordSets []designateRecordSet
+	fail       bool
+
+	requests []string
+	changes  []string
+}
+
+func (f *fakeDesignate) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.requests = append(f.requests, req.Method+" "+req.URL.Path)
+	if req.Header.Get("X-Auth-Token") != "token" || f.fail {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"code": 401, "type": "unauthorized", "message": "not authorized"}`)
+		return
+	}
+	recordSetsPath := "/dns/v2/zones/z1/recordsets"
+	switch {
+	case req.URL.Path == "/dns/v2/zones":
+		zones := []map[string]string{}
+		if req.URL.Query().Get("name") == f.zoneName {
+			zones = append(zones, map[string]string{"id": "z1", "name": f.zoneName})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"zones": zones})
+	case req.URL.Path == recordSetsPath && req.Method == "GET":
+		f.list(w, req)
+	case req.URL.Path == recordSetsPath && req.Method == "POST":
+		var rs designateRecordSet
+		json.NewDecoder(req.Body).Decode(&rs)
+		f.changes = append(f.changes, fmt.Sprintf("POST %s %s %d %v", rs.Name, rs.Type, rs.TTL, rs.Records))
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(rs)
+	case strings.HasPrefix(req.URL.Path, recordSetsPath+"/") && req.Method == "PUT":
+		var rs designateRecordSet
+		json.NewDecoder(req.Body).Decode(&rs)
+		id := strings.TrimPrefix(req.URL.Path, recordSetsPath+"/")
+		f.changes = append(f.changes, fmt.Sprintf("PUT %s %d %v", id, rs.TTL, rs.Records))
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(rs)
+	case strings.HasPrefix(req.URL.Path, recordSetsPath+"/") && req.Method == "DELETE":
+		id := strings.TrimPrefix(req.URL.Path, recordSetsPath+"/")
+		f.changes = append(f.changes, "DELETE "+id)
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+func (f *fakeDesignate) list(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	name, recordType := query.Get("name"), query.Get("type")
+	var matched []designateRecordSet
+	for _, rs := range f.recordSets {
+		if (name == "" || rs.Name == name) && (recordType == "" || rs.Type == recordType) {
+			matched = append(matched, rs)
+		}
+	}
+	var start int
+	fmt.Sscan(query.Get("marker"), &start)
+	resp := map[string]interface{}{"recordsets": []designateRecordSet{}}
+	links := map[string]string{"self": f.url + req.URL.RequestURI()}
+	if start < len(matched) {
+		end := start + 2
+		if end < len(matched) {
+			links["next"] = fmt.Sprintf("%s/v2/zones/z1/recordsets?marker=%d", f.url, end)
+		} else {
+			end = len(matched)
+		}
+		resp["recordsets"] = matched[start:end]
+	}
+	resp["links"] = links
+	json.NewEncoder(w).Encode(resp)
+}
@@ -5,12 +5,14 @@ package openstack
 
 import (
 	"fmt"
+	"net"
 	"regexp"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/juju/clock"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/retry"
 	"gopkg.in/goose.v2/neutron"
@@ -205,6 +207,59 @@ func (f *switchingFirewaller) InstanceIngressRules(ctx context.ProviderCallConte
 	return f.fw.InstanceIngressRules(ctx, inst, machineId)
 }
 
+// egressFirewaller is implemented by Firewallers that can restrict the
+// outbound traffic of instances.
+type egressFirewaller interface {
+	// supportsEgressRules reports whether outbound traffic can be
+	// restricted with the model's configuration.
+	supportsEgressRules() (bool, error)
+
+	// setInstanceEgressRules restricts the outbound traffic of the
+	// instance started for the given machine.
+	setInstanceEgressRules(machineId string, rules environs.EgressRules) error
+
+	// instanceEgressRules returns the egress rules applied to the
+	// instance started for the given machine.
+	instanceEgressRules(machineId string) (environs.EgressRules, error)
+}
+
+func (f *switchingFirewaller) egressFirewaller() (egressFirewaller, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	fw, ok := f.fw.(egressFirewaller)
+	if !ok {
+		return nil, errors.NotSupportedf("egress rules without neutron")
+	}
+	return fw, nil
+}
+
+func (f *switchingFirewaller) supportsEgressRules() (bool, error) {
+	fw, err := f.egressFirewaller()
+	if errors.IsNotSupported(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return fw.supportsEgressRules()
+}
+
+func (f *switchingFirewaller) setInstanceEgressRules(machineId string, rules environs.EgressRules) error {
+	fw, err := f.egressFirewaller()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return fw.setInstanceEgressRules(machineId, rules)
+}
+
+func (f *switchingFirewaller) instanceEgressRules(machineId string) (environs.EgressRules, error) {
+	fw, err := f.egressFirewaller()
+	if err != nil {
+		return environs.EgressRules{}, errors.Trace(err)
+	}
+	return fw.instanceEgressRules(machineId)
+}
+
 type firewallerBase struct {
 	environ          *Environ
 	ensureGroupMutex sync.Mutex
@@ -954,6 +1009,137 @@ func (c *neutronFirewaller) ingressRulesInGroup(nameRegexp string) (rules []netw
 	return rules, nil
 }
 
+// supportsEgressRules implements egressFirewaller. Security groups
+// only ever allow traffic, so an instance's outbound traffic can only
+// be restricted when the instance has a group of its own, and is not
+// also in the default group, which allows all outbound traffic.
+func (c *neutronFirewaller) supportsEgressRules() (bool, error) {
+	supported := c.environ.Config().FirewallMode() == config.FwInstance &&
+		!c.environ.ecfg().useDefaultSecurityGroup()
+	return supported, nil
+}
+
+// setInstanceEgressRules implements egressFirewaller. The egress rules
+// that neutron adds to every new group are removed from the model's
+// group, which all of its instances are in, and the machine's group
+// holds the egress rules of its instance instead.
+func (c *neutronFirewaller) setInstanceEgressRules(machineId string, rules environs.EgressRules) error {
+	if supported, _ := c.supportsEgressRules(); !supported {
+		return errors.NotSupportedf("egress rules in firewall mode %q", c.environ.Config().FirewallMode())
+	}
+	neutronClient := c.environ.neutron()
+	modelGroup, err := c.matchingGroup(c.modelGroupRegexp())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, rule := range modelGroup.Rules {
+		if rule.Direction != "egress" {
+			continue
+		}
+		if err := neutronClient.DeleteSecurityGroupRuleV2(rule.Id); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	group, err := c.matchingGroup(c.machineGroupRegexp(machineId))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	have := newRuleInfoSetFromRules(egressGroupRules(group.Rules))
+	want := newRuleInfoSetFromRuleInfo(egressRulesToRuleInfo(rules))
+	for k, ruleId := range have {
+		if _, ok := want[k]; ok {
+			continue
+		}
+		if err := neutronClient.DeleteSecurityGroupRuleV2(ruleId); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for k := range want {
+		if _, ok := have[k]; ok {
+			continue
+		}
+		k.ParentGroupId = group.Id
+		if _, err := neutronClient.CreateSecurityGroupRuleV2(k); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	logger.Infof("set egress rules in security group %s-%s: %+v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+// instanceEgressRules implements egressFirewaller. Outbound traffic is
+// unrestricted while the model's group, or the machine's group, has
+// an egress rule without a remote prefix.
+func (c *neutronFirewaller) instanceEgressRules(machineId string) (environs.EgressRules, error) {
+	modelGroup, err := c.matchingGroup(c.modelGroupRegexp())
+	if err != nil {
+		return environs.EgressRules{}, errors.Trace(err)
+	}
+	group, err := c.matchingGroup(c.machineGroupRegexp(machineId))
+	if err != nil {
+		return environs.EgressRules{}, errors.Trace(err)
+	}
+	cidrs := set.NewStrings()
+	for _, rule := range egressGroupRules(append(modelGroup.Rules, group.Rules...)) {
+		if rule.RemoteIPPrefix == "" {
+			return environs.EgressRules{}, nil
+		}
+		cidrs.Add(rule.RemoteIPPrefix)
+	}
+	return environs.EgressRules{
+		Restricted:       true,
+		DestinationCIDRs: cidrs.SortedValues(),
+	}, nil
+}
+
+// modelGroupRegexp matches the name of the model's group, which all of
+// its instances are in, but not the names of its machine groups.
+func (c *neutronFirewaller) modelGroupRegexp() string {
+	return fmt.Sprintf("^%s$", c.jujuGroupRegexp())
+}
+
+// cidrEthernetType returns the neutron ethertype of the addresses in
+// the CIDR.
+func cidrEthernetType(cidr string) string {
+	if ip, _, err := net.ParseCIDR(cidr); err == nil && ip.To4() == nil {
+		return "IPv6"
+	}
+	return "IPv4"
+}
+
+// egressGroupRules returns the egress rules of a security group.
+func egressGroupRules(rules []neutron.SecurityGroupRuleV2) []neutron.SecurityGroupRuleV2 {
+	var result []neutron.SecurityGroupRuleV2
+	for _, rule := range rules {
+		if rule.Direction == "egress" {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
+
+// egressRulesToRuleInfo returns the security group rules that allow the
+// outbound traffic described by rules. Unrestricted traffic is allowed
+// with the rules that neutron adds to new groups.
+func egressRulesToRuleInfo(rules environs.EgressRules) []neutron.RuleInfoV2 {
+	if !rules.Restricted {
+		return []neutron.RuleInfoV2{
+			{Direction: "egress", EthernetType: "IPv4"},
+			{Direction: "egress", EthernetType: "IPv6"},
+		}
+	}
+	var ruleInfo []neutron.RuleInfoV2
+	for _, cidr := range rules.DestinationCIDRs {
+		ruleInfo = append(ruleInfo, neutron.RuleInfoV2{
+			Direction:      "egress",
+			EthernetType:   cidrEthernetType(cidr),
+			RemoteIPPrefix: cidr,
+		})
+	}
+	return ruleInfo
+}
+
 func replaceControllerUUID(oldName, controllerUUID string) (string, error) {
 	if !extractControllerRe.MatchString(oldName) {
 		return "", errors.Errorf("unexpected security group name format for %q", oldName)
@@ -88,3 +88,8 @@ func (n *LegacyNovaNetworking) Subnets(instId instance.Id, subnetIds []network.I
 func (n *LegacyNovaNetworking) NetworkInterfaces(instId instance.Id) ([]network.InterfaceInfo, error) {
 	return nil, errors.NotSupportedf("nova network interfaces")
 }
+
+// Spaces is part of the Networking interface.
+func (n *LegacyNovaNetworking) Spaces() ([]network.SpaceInfo, error) {
+	return nil, errors.NotSupportedf("nova spaces")
+}
@@ -840,6 +840,74 @@ func (s *localServerSuite) TestDestroyEnvironmentDeletesSecurityGroupsFWModeGlob
 	assertSecurityGroups(c, env, []string{"default"})
 }
 
+func (s *localServerSuite) TestEgressRulesFWModeInstance(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-mode": config.FwInstance})
+	instanceName := "100"
+	inst, _ := testing.AssertStartInstance(c, env, s.callCtx, s.ControllerUUID, instanceName)
+	ef, ok := environs.SupportsEgressFirewall(env)
+	c.Assert(ok, jc.IsTrue)
+	supported, err := ef.SupportsEgressRules(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(supported, jc.IsTrue)
+
+	// New security groups allow all outbound traffic.
+	rules, err := ef.EgressRules(s.callCtx, inst.Id(), instanceName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, environs.EgressRules{})
+
+	restricted := environs.EgressRules{
+		Restricted:       true,
+		DestinationCIDRs: []string{"10.0.0.1/32", "2001:db8::1/128"},
+	}
+	err = ef.SetEgressRules(s.callCtx, inst.Id(), instanceName, restricted)
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = ef.EgressRules(s.callCtx, inst.Id(), instanceName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, restricted)
+
+	modelUUID := env.Config().UUID()
+	c.Check(egressRuleInfo(c, env, fmt.Sprintf("juju-%v-%v", s.ControllerUUID, modelUUID)), gc.HasLen, 0)
+	c.Check(egressRuleInfo(c, env, fmt.Sprintf("juju-%v-%v-%v", s.ControllerUUID, modelUUID, instanceName)), jc.SameContents, []neutron.RuleInfoV2{
+		{Direction: "egress", EthernetType: "IPv4", RemoteIPPrefix: "10.0.0.1/32"},
+		{Direction: "egress", EthernetType: "IPv6", RemoteIPPrefix: "2001:db8::1/128"},
+	})
+
+	// Lifting the restriction allows all outbound traffic again.
+	err = ef.SetEgressRules(s.callCtx, inst.Id(), instanceName, environs.EgressRules{})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = ef.EgressRules(s.callCtx, inst.Id(), instanceName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, environs.EgressRules{})
+}
+
+func (s *localServerSuite) TestEgressRulesFWModeGlobal(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-mode": config.FwGlobal})
+	instanceName := "100"
+	inst, _ := testing.AssertStartInstance(c, env, s.callCtx, s.ControllerUUID, instanceName)
+	ef, ok := environs.SupportsEgressFirewall(env)
+	c.Assert(ok, jc.IsTrue)
+	supported, err := ef.SupportsEgressRules(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(supported, jc.IsFalse)
+
+	err = ef.SetEgressRules(s.callCtx, inst.Id(), instanceName, environs.EgressRules{Restricted: true})
+	c.Assert(err, gc.ErrorMatches, `egress rules in firewall mode "global" not supported`)
+}
+
+// egressRuleInfo returns the egress rules of the named security group.
+func egressRuleInfo(c *gc.C, env environs.Environ, groupName string) []neutron.RuleInfoV2 {
+	groups, err := openstack.GetNeutronClient(env).SecurityGroupByNameV2(groupName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 1)
+	var rules []neutron.RuleInfoV2
+	for _, rule := range ruleToRuleInfo(groups[0].Rules) {
+		if rule.Direction == "egress" {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
 func (s *localServerSuite) TestDestroyController(c *gc.C) {
 	env := s.openEnviron(c, coretesting.Attrs{"uuid": utils.MustNewUUID().String()})
 	controllerEnv := s.env
@@ -1273,6 +1341,85 @@ func (s *localServerSuite) TestSubnetsWithMissingSubnet(c *gc.C) {
 	c.Assert(subnets, gc.HasLen, 0)
 }
 
+func (s *localServerSuite) TestSupportsSpaces(c *gc.C) {
+	env := s.prepareNetworkingEnviron(c, s.env.Config())
+	supported, err := env.SupportsSpaces(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(supported, jc.IsTrue)
+	supported, err = env.SupportsSpaceDiscovery(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(supported, jc.IsTrue)
+}
+
+func (s *localServerSuite) TestSpaces(c *gc.C) {
+	env := s.prepareNetworkingEnviron(c, s.env.Config())
+	spaces, err := env.Spaces(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	neutronClient := openstack.GetNeutronClient(s.env)
+	openstackSubnets, err := neutronClient.ListSubnetsV2()
+	c.Assert(err, jc.ErrorIsNil)
+
+	var private *network.SpaceInfo
+	for i, space := range spaces {
+		// The external network is never a space.
+		c.Check(space.ProviderId, gc.Not(gc.Equals), network.Id("998"))
+		if space.ProviderId == "999" {
+			private = &spaces[i]
+		}
+	}
+	c.Assert(private, gc.NotNil)
+	c.Check(private.Name, gc.Equals, "private_999")
+
+	net, err := neutronClient.GetNetworkV2("999")
+	c.Assert(err, jc.ErrorIsNil)
+	var expectedSubnets []network.SubnetInfo
+	for _, os := range openstackSubnets {
+		if os.NetworkId != "999" {
+			continue
+		}
+		expectedSubnets = append(expectedSubnets, network.SubnetInfo{
+			CIDR:              os.Cidr,
+			ProviderId:        network.Id(os.Id),
+			ProviderNetworkId: "999",
+			AvailabilityZones: net.AvailabilityZones,
+			SpaceProviderId:   "999",
+		})
+	}
+	c.Check(private.Subnets, jc.DeepEquals, expectedSubnets)
+}
+
+func (s *localServerSuite) TestStartInstanceSubnetsToZones(c *gc.C) {
+	neutronClient := openstack.GetNeutronClient(s.env)
+	openstackSubnets, err := neutronClient.ListSubnetsV2()
+	c.Assert(err, jc.ErrorIsNil)
+	var subnetId network.Id
+	for _, os := range openstackSubnets {
+		if os.NetworkId == "999" {
+			subnetId = network.Id(os.Id)
+			break
+		}
+	}
+	c.Assert(subnetId, gc.Not(gc.Equals), network.Id(""))
+
+	params := environs.StartInstanceParams{
+		ControllerUUID: s.ControllerUUID,
+		SubnetsToZones: map[network.Id][]string{subnetId: nil},
+	}
+	result, err := testing.StartInstanceWithParams(s.env, s.callCtx, "1", params)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.env.StopInstances(s.callCtx, result.Instance.Id())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *localServerSuite) TestStartInstanceSubnetsToZonesUnknownSubnet(c *gc.C) {
+	params := environs.StartInstanceParams{
+		ControllerUUID: s.ControllerUUID,
+		SubnetsToZones: map[network.Id][]string{"missing": nil},
+	}
+	_, err := testing.StartInstanceWithParams(s.env, s.callCtx, "1", params)
+	c.Assert(err, gc.ErrorMatches, `network for subnets \[missing\] in zone "" not found`)
+}
+
 func (s *localServerSuite) TestSuperSubnets(c *gc.C) {
 	env := s.prepareNetworkingEnviron(c, s.env.Config())
 	obtainedSubnets, err := env.SuperSubnets(s.callCtx)
@@ -1306,10 +1453,10 @@ func (s *localServerSuite) TestConstraintsValidator(c *gc.C) {
 	env := s.Open(c, s.env.Config())
 	validator, err := env.ConstraintsValidator(s.callCtx)
 	c.Assert(err, jc.ErrorIsNil)
-	cons := constraints.MustParse("arch=amd64 cpu-power=10 virt-type=lxd")
+	cons := constraints.MustParse("arch=amd64 cpu-power=10 virt-type=lxd spot=true")
 	unsupported, err := validator.Validate(cons)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(unsupported, jc.SameContents, []string{"cpu-power"})
+	c.Assert(unsupported, jc.SameContents, []string{"cpu-power", "spot"})
 }
 
 func (s *localServerSuite) TestConstraintsValidatorVocab(c *gc.C) {
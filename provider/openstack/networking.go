@@ -6,6 +6,7 @@ package openstack
 import (
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 	"sync"
 
@@ -44,6 +45,11 @@ type Networking interface {
 	// interfaces on the given instance.
 	// Needed for Environ.Networking
 	NetworkInterfaces(instId instance.Id) ([]network.InterfaceInfo, error)
+
+	// Spaces returns the internal networks known by OpenStack for
+	// the environment, each with its subnets, as spaces.
+	// Needed for Environ.Networking
+	Spaces() ([]network.SpaceInfo, error)
 }
 
 // NetworkingDecorator is an interface that provides a means of overriding
@@ -128,6 +134,14 @@ func (n *switchingNetworking) NetworkInterfaces(instId instance.Id) ([]network.I
 	return n.networking.NetworkInterfaces(instId)
 }
 
+// Spaces is part of the Networking interface.
+func (n *switchingNetworking) Spaces() ([]network.SpaceInfo, error) {
+	if err := n.initNetworking(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return n.networking.Spaces()
+}
+
 type networkingBase struct {
 	env *Environ
 }
@@ -407,6 +421,66 @@ func (n *NeutronNetworking) Subnets(instId instance.Id, subnetIds []network.Id)
 	return results, nil
 }
 
+// internalNetworkFilter returns a neutron.Filter to match Neutron Networks
+// with router:external = false.
+func internalNetworkFilter() *neutron.Filter {
+	filter := neutron.NewFilter()
+	filter.Set(neutron.FilterRouterExternal, "false")
+	return filter
+}
+
+// Spaces is part of the Networking interface. Each internal Neutron
+// network with at least one valid subnet is returned as a space
+// named after the network.
+func (n *NeutronNetworking) Spaces() ([]network.SpaceInfo, error) {
+	client := n.env.neutron()
+	networks, err := client.ListNetworksV2(internalNetworkFilter())
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to retrieve networks")
+	}
+	subnets, err := client.ListSubnetsV2()
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to retrieve subnets")
+	}
+	byNetwork := make(map[string][]neutron.SubnetV2)
+	for _, subnet := range subnets {
+		byNetwork[subnet.NetworkId] = append(byNetwork[subnet.NetworkId], subnet)
+	}
+
+	var spaces []network.SpaceInfo
+	for _, netV2 := range networks {
+		space := network.SpaceInfo{
+			Name:       netV2.Name,
+			ProviderId: network.Id(netV2.Id),
+		}
+		if space.Name == "" {
+			space.Name = netV2.Id
+		}
+		for _, subnet := range byNetwork[netV2.Id] {
+			if _, _, err := net.ParseCIDR(subnet.Cidr); err != nil {
+				logger.Debugf("skipping subnet %q, invalid CIDR %q", subnet.Id, subnet.Cidr)
+				continue
+			}
+			space.Subnets = append(space.Subnets, network.SubnetInfo{
+				CIDR:              subnet.Cidr,
+				ProviderId:        network.Id(subnet.Id),
+				ProviderNetworkId: network.Id(netV2.Id),
+				AvailabilityZones: netV2.AvailabilityZones,
+				SpaceProviderId:   network.Id(netV2.Id),
+			})
+		}
+		if len(space.Subnets) == 0 {
+			logger.Tracef("ignoring network %q with no subnets", netV2.Id)
+			continue
+		}
+		spaces = append(spaces, space)
+	}
+	sort.Slice(spaces, func(i, j int) bool {
+		return spaces[i].Name < spaces[j].Name
+	})
+	return spaces, nil
+}
+
 // noNetConfigMsg is used to present resolution options when an error is
 // encountered due to missing "network" configuration.
 // Any error from attempting to resolve a network without network
@@ -11,12 +11,14 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/juju/clock"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/jsonschema"
 	"github.com/juju/loggo"
@@ -324,6 +326,7 @@ var _ environs.NetworkingEnviron = (*Environ)(nil)
 var _ simplestreams.HasRegion = (*Environ)(nil)
 var _ instance.Distributor = (*Environ)(nil)
 var _ environs.InstanceTagger = (*Environ)(nil)
+var _ environs.EgressFirewaller = (*Environ)(nil)
 
 type openstackInstance struct {
 	e        *Environ
@@ -518,6 +521,8 @@ func (e *Environ) neutron() *neutron.Client {
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.CpuPower,
+	constraints.Spot,
+	constraints.MaxPrice,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -627,7 +632,11 @@ func (e *Environ) parsePlacement(ctx context.ProviderCallContext, placement stri
 
 // PrecheckInstance is defined on the environs.InstancePrechecker interface.
 func (e *Environ) PrecheckInstance(ctx context.ProviderCallContext, args environs.PrecheckInstanceParams) error {
-	if _, err := e.deriveAvailabilityZone(ctx, args.Placement, args.VolumeAttachments); err != nil {
+	zone, err := e.deriveAvailabilityZone(ctx, args.Placement, args.VolumeAttachments)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := common.ValidateZonesConstraint(e, ctx, args.Constraints, zone); err != nil {
 		return errors.Trace(err)
 	}
 	if !args.Constraints.HasInstanceType() {
@@ -1052,7 +1061,17 @@ func (e *Environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 		return nil, common.ZoneIndependentError(errors.Annotate(err, "getting initial networks"))
 	}
 	usingNetwork := e.ecfg().network()
-	networkId, err := e.networking.ResolveNetwork(usingNetwork, false)
+	var networkId string
+	if len(args.SubnetsToZones) > 0 {
+		// Spaces constraints take precedence over the configured
+		// network; use the network containing a matching subnet.
+		networkId, err = e.subnetsNetworkId(args.SubnetsToZones, args.AvailabilityZone)
+		if err != nil {
+			return nil, common.ZoneIndependentError(err)
+		}
+	} else {
+		networkId, err = e.networking.ResolveNetwork(usingNetwork, false)
+	}
 	if err != nil {
 		if usingNetwork == "" {
 			// If there is no network configured, we only throw out when the
@@ -1718,6 +1737,33 @@ func (e *Environ) IngressRules(ctx context.ProviderCallContext) ([]network.Ingre
 	return e.firewaller.IngressRules(ctx)
 }
 
+// SupportsEgressRules implements environs.EgressFirewaller.
+func (e *Environ) SupportsEgressRules(ctx context.ProviderCallContext) (bool, error) {
+	fw, ok := e.firewaller.(egressFirewaller)
+	if !ok {
+		return false, nil
+	}
+	return fw.supportsEgressRules()
+}
+
+// SetEgressRules implements environs.EgressFirewaller.
+func (e *Environ) SetEgressRules(ctx context.ProviderCallContext, id instance.Id, machineId string, rules environs.EgressRules) error {
+	fw, ok := e.firewaller.(egressFirewaller)
+	if !ok {
+		return errors.NotSupportedf("egress rules")
+	}
+	return fw.setInstanceEgressRules(machineId, rules)
+}
+
+// EgressRules implements environs.EgressFirewaller.
+func (e *Environ) EgressRules(ctx context.ProviderCallContext, id instance.Id, machineId string) (environs.EgressRules, error) {
+	fw, ok := e.firewaller.(egressFirewaller)
+	if !ok {
+		return environs.EgressRules{}, errors.NotSupportedf("egress rules")
+	}
+	return fw.instanceEgressRules(machineId)
+}
+
 func (e *Environ) Provider() environs.EnvironProvider {
 	return providerInstance
 }
@@ -1820,17 +1866,61 @@ func (e *Environ) NetworkInterfaces(ctx context.ProviderCallContext, instId inst
 
 // SupportsSpaces is specified on environs.Networking.
 func (e *Environ) SupportsSpaces(ctx context.ProviderCallContext) (bool, error) {
-	return false, nil
+	return e.supportsSpaces()
 }
 
 // SupportsSpaceDiscovery is specified on environs.Networking.
 func (e *Environ) SupportsSpaceDiscovery(ctx context.ProviderCallContext) (bool, error) {
-	return false, nil
+	return e.supportsSpaces()
+}
+
+// subnetsNetworkId returns the id of the Neutron network containing
+// the first of the given subnets, in id order, that is available in
+// the given zone.
+func (e *Environ) subnetsNetworkId(subnetsToZones map[network.Id][]string, zone string) (string, error) {
+	spaces, err := e.networking.Spaces()
+	if err != nil {
+		return "", errors.Annotate(err, "getting networks for subnets")
+	}
+	networkIds := make(map[network.Id]network.Id)
+	for _, space := range spaces {
+		for _, subnet := range space.Subnets {
+			networkIds[subnet.ProviderId] = subnet.ProviderNetworkId
+		}
+	}
+	subnetIds := make([]string, 0, len(subnetsToZones))
+	for id := range subnetsToZones {
+		subnetIds = append(subnetIds, string(id))
+	}
+	sort.Strings(subnetIds)
+	for _, id := range subnetIds {
+		zones := subnetsToZones[network.Id(id)]
+		if zone != "" && len(zones) > 0 && !set.NewStrings(zones...).Contains(zone) {
+			continue
+		}
+		if networkId, ok := networkIds[network.Id(id)]; ok {
+			logger.Debugf("using network id %q for subnet %q", networkId, id)
+			return string(networkId), nil
+		}
+	}
+	return "", errors.NotFoundf("network for subnets %v in zone %q", subnetIds, zone)
+}
+
+// supportsSpaces reports whether spaces can be discovered from the
+// cloud's networks, which requires Neutron.
+func (e *Environ) supportsSpaces() (bool, error) {
+	client := e.client()
+	if !client.IsAuthenticated() {
+		if err := authenticateClient(client); err != nil {
+			return false, errors.Trace(err)
+		}
+	}
+	return e.supportsNeutron(), nil
 }
 
 // Spaces is specified on environs.Networking.
 func (e *Environ) Spaces(ctx context.ProviderCallContext) ([]network.SpaceInfo, error) {
-	return nil, errors.NotSupportedf("spaces")
+	return e.networking.Spaces()
 }
 
 // SupportsContainerAddresses is specified on environs.Networking.
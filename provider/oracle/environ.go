@@ -652,6 +652,11 @@ func (o *OracleEnviron) ConstraintsValidator(ctx context.ProviderCallContext) (c
 		constraints.CpuPower,
 		constraints.RootDisk,
 		constraints.VirtType,
+		constraints.Zones,
+		constraints.Affinity,
+		constraints.AntiAffinity,
+		constraints.Spot,
+		constraints.MaxPrice,
 	}
 
 	// we choose to use the default validator implementation
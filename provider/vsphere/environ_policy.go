@@ -30,6 +30,8 @@ func (env *sessionEnviron) PrecheckInstance(ctx context.ProviderCallContext, arg
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.Spot,
+	constraints.MaxPrice,
 }
 
 // ConstraintsValidator returns a Validator value which is used to
@@ -16,6 +16,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/network"
 )
@@ -53,10 +54,14 @@ func (st *State) controllerAddresses() ([]string, error) {
 	if len(allAddresses) == 0 {
 		return nil, errors.New("no controller machines found")
 	}
+	preferIPv6, err := st.controllerPreferIPv6(cinfo.ModelTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	apiAddrs := make([]string, 0, len(allAddresses))
 	for _, addrs := range allAddresses {
 		naddrs := networkAddresses(addrs.Addresses)
-		addr, ok := network.SelectControllerAddress(naddrs, false)
+		addr, ok := network.SelectPreferredControllerAddress(naddrs, false, preferIPv6)
 		if ok {
 			apiAddrs = append(apiAddrs, addr.Value)
 		}
@@ -67,6 +72,20 @@ func (st *State) controllerAddresses() ([]string, error) {
 	return apiAddrs, nil
 }
 
+// controllerPreferIPv6 returns whether the controller model prefers IPv6
+// addresses, which then also applies to the addresses of the controllers.
+func (st *State) controllerPreferIPv6(controllerModelUUID string) (bool, error) {
+	db, closer := st.db().CopyForModel(controllerModelUUID)
+	defer closer()
+	settings, err := readSettings(db, settingsC, modelGlobalKey)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	value, _ := settings.Get(config.PreferredIPv6Key)
+	preferIPv6, _ := value.(bool)
+	return preferIPv6, nil
+}
+
 func appendPort(addrs []string, port int) []string {
 	newAddrs := make([]string, len(addrs))
 	for i, addr := range addrs {
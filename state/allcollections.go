@@ -379,6 +379,7 @@ func allCollections() collectionSchema {
 		subnetsC:              {},
 		linkLayerDevicesC:     {},
 		linkLayerDevicesRefsC: {},
+		machineBondsC:         {},
 		ipAddressesC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "machine-id", "device-name"},
@@ -469,6 +470,13 @@ func allCollections() collectionSchema {
 				{Key: []string{"model-uuid", "offer-uuid"}},
 			},
 		},
+		// offerShareTokensC holds the time-limited tokens granting
+		// consume access to application offers.
+		offerShareTokensC: {
+			indexes: []mgo.Index{
+				{Key: []string{"model-uuid", "offer-uuid"}},
+			},
+		},
 		remoteApplicationsC: {},
 		// remoteEntitiesC holds information about entities involved in
 		// cross-model relations.
@@ -482,12 +490,28 @@ func allCollections() collectionSchema {
 		externalControllersC: {
 			global: true,
 		},
+		// offerRedirectsC records where offers hosted in models which have
+		// been migrated to other controllers have moved to, so that their
+		// consumers can follow them. It is global as it outlives the models.
+		offerRedirectsC: {
+			global: true,
+		},
 		// relationNetworksC holds required ingress or egress cidrs for remote relations.
 		relationNetworksC: {},
+		// remoteRelationHealthC holds the health of the exchange of
+		// settings for cross-model relations. It's written frequently
+		// and is informational only.
+		remoteRelationHealthC: {
+			rawAccess: true,
+		},
 
 		// firewallRulesC holds firewall rules for defined service types.
 		firewallRulesC: {},
 
+		// imagePoliciesC holds the policies that constrain the images
+		// used to provision a model's machines.
+		imagePoliciesC: {},
+
 		// podSpecsC holds the CAAS pod specifications,
 		// for applications.
 		podSpecsC: {},
@@ -544,6 +568,7 @@ const (
 	globalSettingsC            = "globalSettings"
 	guimetadataC               = "guimetadata"
 	guisettingsC               = "guisettings"
+	imagePoliciesC             = "imagePolicies"
 	instanceDataC              = "instanceData"
 	leasesC                    = "leases"
 	leaseHoldersC              = "leaseholders"
@@ -587,6 +612,7 @@ const (
 	subnetsC                   = "subnets"
 	linkLayerDevicesC          = "linklayerdevices"
 	linkLayerDevicesRefsC      = "linklayerdevicesrefs"
+	machineBondsC              = "machinebonds"
 	ipAddressesC               = "ip.addresses"
 	toolsmetadataC             = "toolsmetadata"
 	txnLogC                    = "txns.log"
@@ -601,11 +627,14 @@ const (
 	// "resources" (see resource/persistence/mongo.go)
 
 	// Cross model relations
-	applicationOffersC   = "applicationOffers"
-	remoteApplicationsC  = "remoteApplications"
-	offerConnectionsC    = "applicationOfferConnections"
-	remoteEntitiesC      = "remoteEntities"
-	externalControllersC = "externalControllers"
-	relationNetworksC    = "relationNetworks"
-	firewallRulesC       = "firewallRules"
+	applicationOffersC    = "applicationOffers"
+	remoteApplicationsC   = "remoteApplications"
+	offerConnectionsC     = "applicationOfferConnections"
+	offerShareTokensC     = "applicationOfferShareTokens"
+	remoteEntitiesC       = "remoteEntities"
+	externalControllersC  = "externalControllers"
+	offerRedirectsC       = "offerRedirects"
+	relationNetworksC     = "relationNetworks"
+	remoteRelationHealthC = "remoteRelationHealth"
+	firewallRulesC        = "firewallRules"
 )
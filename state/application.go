@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -44,25 +45,26 @@ type Application struct {
 // applicationDoc represents the internal state of an application in MongoDB.
 // Note the correspondence with ApplicationInfo in apiserver.
 type applicationDoc struct {
-	DocID                string       `bson:"_id"`
-	Name                 string       `bson:"name"`
-	ModelUUID            string       `bson:"model-uuid"`
-	Series               string       `bson:"series"`
-	Subordinate          bool         `bson:"subordinate"`
-	CharmURL             *charm.URL   `bson:"charmurl"`
-	Channel              string       `bson:"cs-channel"`
-	CharmModifiedVersion int          `bson:"charmmodifiedversion"`
-	ForceCharm           bool         `bson:"forcecharm"`
-	Life                 Life         `bson:"life"`
-	UnitCount            int          `bson:"unitcount"`
-	RelationCount        int          `bson:"relationcount"`
-	Exposed              bool         `bson:"exposed"`
-	MinUnits             int          `bson:"minunits"`
-	DesiredScale         int          `bson:"scale"`
-	Tools                *tools.Tools `bson:",omitempty"`
-	TxnRevno             int64        `bson:"txn-revno"`
-	MetricCredentials    []byte       `bson:"metric-credentials"`
-	PasswordHash         string       `bson:"passwordhash"`
+	DocID                string                     `bson:"_id"`
+	Name                 string                     `bson:"name"`
+	ModelUUID            string                     `bson:"model-uuid"`
+	Series               string                     `bson:"series"`
+	Subordinate          bool                       `bson:"subordinate"`
+	CharmURL             *charm.URL                 `bson:"charmurl"`
+	Channel              string                     `bson:"cs-channel"`
+	CharmModifiedVersion int                        `bson:"charmmodifiedversion"`
+	ForceCharm           bool                       `bson:"forcecharm"`
+	Life                 Life                       `bson:"life"`
+	UnitCount            int                        `bson:"unitcount"`
+	RelationCount        int                        `bson:"relationcount"`
+	Exposed              bool                       `bson:"exposed"`
+	ExposedEndpoints     map[string]ExposedEndpoint `bson:"exposed-endpoints,omitempty"`
+	MinUnits             int                        `bson:"minunits"`
+	DesiredScale         int                        `bson:"scale"`
+	Tools                *tools.Tools               `bson:",omitempty"`
+	TxnRevno             int64                      `bson:"txn-revno"`
+	MetricCredentials    []byte                     `bson:"metric-credentials"`
+	PasswordHash         string                     `bson:"passwordhash"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -426,8 +428,9 @@ func (a *Application) IsExposed() bool {
 	return a.doc.Exposed
 }
 
-// SetExposed marks the application as exposed.
-// See ClearExposed and IsExposed.
+// SetExposed marks the application as exposed, reachable from
+// anywhere; any expose settings restricting where it may be reached
+// from are removed. See ClearExposed and IsExposed.
 func (a *Application) SetExposed() error {
 	return a.setExposed(true)
 }
@@ -439,16 +442,164 @@ func (a *Application) ClearExposed() error {
 }
 
 func (a *Application) setExposed(exposed bool) (err error) {
+	update := bson.D{
+		{"$set", bson.D{{"exposed", exposed}}},
+		{"$unset", bson.D{{"exposed-endpoints", nil}}},
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := a.st.db().RunTransaction(ops); err != nil {
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, applicationNotAliveErr))
 	}
 	a.doc.Exposed = exposed
+	a.doc.ExposedEndpoints = nil
+	return nil
+}
+
+// ExposedEndpoint holds the sources that the ports of an exposed
+// application may be reached from, when exposed through an endpoint.
+// If neither spaces nor CIDRs are given, the ports may be reached from
+// anywhere.
+type ExposedEndpoint struct {
+	// ExposeToSpaces holds the names of the spaces whose subnets
+	// may reach the ports.
+	ExposeToSpaces []string `bson:"to-spaces,omitempty"`
+
+	// ExposeToCIDRs holds the CIDRs that may reach the ports.
+	ExposeToCIDRs []string `bson:"to-cidrs,omitempty"`
+}
+
+// AllowsAll returns true if the exposed endpoint does not restrict
+// where its ports may be reached from.
+func (e ExposedEndpoint) AllowsAll() bool {
+	return len(e.ExposeToSpaces) == 0 && len(e.ExposeToCIDRs) == 0
+}
+
+// ExposedEndpoints returns the expose settings of the application,
+// keyed by endpoint name. The settings keyed by the empty endpoint
+// name apply to all of the application's endpoints. An application
+// that is exposed without any settings may be reached from anywhere.
+func (a *Application) ExposedEndpoints() map[string]ExposedEndpoint {
+	if len(a.doc.ExposedEndpoints) == 0 {
+		return nil
+	}
+	result := make(map[string]ExposedEndpoint, len(a.doc.ExposedEndpoints))
+	for key, ep := range a.doc.ExposedEndpoints {
+		if key == allEndpointsKey {
+			key = ""
+		}
+		result[key] = ep
+	}
+	return result
+}
+
+// MergeExposeSettings marks the application as exposed and replaces
+// the expose settings of the given endpoints, keeping the settings of
+// other endpoints. The empty endpoint name stands for all of the
+// application's endpoints.
+func (a *Application) MergeExposeSettings(exposed map[string]ExposedEndpoint) error {
+	if err := a.validateExposeSettings(exposed); err != nil {
+		return errors.Annotatef(err, "cannot expose application %q", a)
+	}
+	fields := bson.D{{"exposed", true}}
+	for name, ep := range exposed {
+		fields = append(fields, bson.DocElem{"exposed-endpoints." + exposedEndpointKey(name), ep})
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", fields}},
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Errorf("cannot expose application %q: %v", a, onAbort(err, applicationNotAliveErr))
+	}
+	a.doc.Exposed = true
+	if a.doc.ExposedEndpoints == nil {
+		a.doc.ExposedEndpoints = make(map[string]ExposedEndpoint)
+	}
+	for name, ep := range exposed {
+		a.doc.ExposedEndpoints[exposedEndpointKey(name)] = ep
+	}
+	return nil
+}
+
+// UnsetExposeSettings removes the expose settings of the given
+// endpoints. Once no endpoint remains exposed, the application is no
+// longer exposed.
+func (a *Application) UnsetExposeSettings(endpoints []string) error {
+	remaining := a.ExposedEndpoints()
+	unset := bson.D{}
+	for _, name := range endpoints {
+		if _, ok := remaining[name]; !ok {
+			return errors.NotFoundf("expose settings for endpoint %q of application %q", name, a)
+		}
+		delete(remaining, name)
+		unset = append(unset, bson.DocElem{"exposed-endpoints." + exposedEndpointKey(name), nil})
+	}
+	if len(remaining) == 0 {
+		return a.ClearExposed()
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$unset", unset}},
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Errorf("cannot unexpose endpoints of application %q: %v", a, onAbort(err, applicationNotAliveErr))
+	}
+	for _, name := range endpoints {
+		delete(a.doc.ExposedEndpoints, exposedEndpointKey(name))
+	}
+	return nil
+}
+
+// allEndpointsKey is the key that the expose settings for all of an
+// application's endpoints are stored under, since mongo does not allow
+// empty keys.
+const allEndpointsKey = "*"
+
+// exposedEndpointKey returns the key of the expose settings for the
+// endpoint.
+func exposedEndpointKey(name string) string {
+	if name == "" {
+		return allEndpointsKey
+	}
+	return name
+}
+
+func (a *Application) validateExposeSettings(exposed map[string]ExposedEndpoint) error {
+	if len(exposed) == 0 {
+		return errors.NotValidf("empty expose settings")
+	}
+	eps, err := a.Endpoints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	endpointNames := set.NewStrings()
+	for _, ep := range eps {
+		endpointNames.Add(ep.Name)
+	}
+	for name, ep := range exposed {
+		if name != "" && !endpointNames.Contains(name) {
+			return errors.NotFoundf("endpoint %q", name)
+		}
+		for _, cidr := range ep.ExposeToCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.NotValidf("CIDR %q", cidr)
+			}
+		}
+		for _, spaceName := range ep.ExposeToSpaces {
+			if _, err := a.st.Space(spaceName); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
 	return nil
 }
 
@@ -2585,10 +2736,15 @@ func (a *Application) SetAgentPresence() (*presence.Pinger, error) {
 
 // UpdateCloudService updates the cloud service details for the application.
 func (a *Application) UpdateCloudService(providerId string, addreses []network.Address) error {
+	preferIPv6, err := a.st.preferIPv6()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	doc := cloudServiceDoc{
 		Id:         a.globalKey(),
 		ProviderId: providerId,
 		Addresses:  fromNetworkAddresses(addreses, OriginProvider),
+		PreferIPv6: preferIPv6,
 	}
 	ops, err := a.saveServiceOps(doc)
 	if err != nil {
@@ -2598,7 +2754,8 @@ func (a *Application) UpdateCloudService(providerId string, addreses []network.A
 }
 
 // ServiceInfo returns information about this application's cloud service.
-// This is only used for CAAS models.
+// This is used for CAAS models, and for IAAS applications exposed through
+// a load balancer.
 func (a *Application) ServiceInfo() (CloudService, error) {
 	doc, err := a.cloudService()
 	if err != nil {
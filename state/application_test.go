@@ -1815,6 +1815,113 @@ func (s *ApplicationSuite) TestApplicationExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestMergeExposeSettings(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"":       {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"server": {ExposeToSpaces: []string{"db"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+
+	// Settings for other endpoints are kept.
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"192.168.0.0/16"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := map[string]state.ExposedEndpoint{
+		"":       {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"server": {ExposeToCIDRs: []string{"192.168.0.0/16"}},
+	}
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, expected)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, expected)
+}
+
+func (s *ApplicationSuite) TestMergeExposeSettingsInvalid(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"bogus": {},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": endpoint "bogus" not found`)
+
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"10.0.0.0"}},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": CIDR "10.0.0.0" not valid`)
+
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToSpaces: []string{"missing"}},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
+func (s *ApplicationSuite) TestUnsetExposeSettings(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"":       {},
+		"server": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.UnsetExposeSettings([]string{"juju-info"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.mysql.UnsetExposeSettings([]string{"server"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{"": {}})
+
+	// Unsetting the last settings unexposes the application.
+	err = s.mysql.UnsetExposeSettings([]string{""})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestClearExposedRemovesSettings(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestSetExposedRemovesSettings(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"":       {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"server": {ExposeToCIDRs: []string{"10.1.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit(state.AddUnitParams{})
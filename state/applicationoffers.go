@@ -228,6 +228,11 @@ func (s *applicationOffers) Remove(offerName string, force bool) (err error) {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		shareTokenOps, err := removeOfferShareTokensOps(s.st, offer.OfferUUID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, shareTokenOps...)
 		ops = append(ops, txn.Op{
 			C:      applicationOffersC,
 			Id:     offer.OfferName,
@@ -268,6 +273,11 @@ func removeApplicationOffersOps(st *State, application string) ([]txn.Op, error)
 
 	var ops []txn.Op
 	for _, doc := range docs {
+		shareTokenOps, err := removeOfferShareTokensOps(st, doc.OfferUUID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, shareTokenOps...)
 		ops = append(ops, txn.Op{
 			C:      applicationOffersC,
 			Id:     doc.OfferName,
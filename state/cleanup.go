@@ -40,6 +40,7 @@ const (
 
 	cleanupResourceBlob         cleanupKind = "resourceBlob"
 	cleanupStorageForDyingModel cleanupKind = "modelStorage"
+	cleanupRelationRemoteHealth cleanupKind = "relationRemoteHealth"
 )
 
 // cleanupDoc originally represented a set of documents that should be
@@ -154,6 +155,8 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupResourceBlob(doc.Prefix)
 		case cleanupStorageForDyingModel:
 			err = st.cleanupStorageForDyingModel(args)
+		case cleanupRelationRemoteHealth:
+			err = st.cleanupRelationRemoteHealth(doc.Prefix)
 		default:
 			err = errors.Errorf("unknown cleanup kind %q", doc.Kind)
 		}
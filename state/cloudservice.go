@@ -34,6 +34,11 @@ type cloudServiceDoc struct {
 
 	ProviderId string    `bson:"provider-id"`
 	Addresses  []address `bson:"addresses"`
+
+	// PreferIPv6 records the model's preferred-ipv6 setting, so the
+	// addresses of the application's units can be picked without
+	// reading the model config each time.
+	PreferIPv6 bool `bson:"prefer-ipv6,omitempty"`
 }
 
 // Id implements CloudService.
@@ -94,7 +99,8 @@ func (a *Application) saveServiceOps(doc cloudServiceDoc) ([]txn.Op, error) {
 		Update: bson.D{
 			{"$set",
 				bson.D{{"provider-id", doc.ProviderId},
-					{"addresses", doc.Addresses}},
+					{"addresses", doc.Addresses},
+					{"prefer-ipv6", doc.PreferIPv6}},
 			},
 		},
 	}}, nil
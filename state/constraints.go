@@ -28,6 +28,11 @@ type constraintsDoc struct {
 	Tags         *[]string
 	Spaces       *[]string
 	VirtType     *string
+	Zones        *[]string
+	Affinity     *[]string
+	AntiAffinity *[]string
+	Spot         *bool
+	MaxPrice     *string
 }
 
 func (doc constraintsDoc) value() constraints.Value {
@@ -42,6 +47,11 @@ func (doc constraintsDoc) value() constraints.Value {
 		Tags:         doc.Tags,
 		Spaces:       doc.Spaces,
 		VirtType:     doc.VirtType,
+		Zones:        doc.Zones,
+		Affinity:     doc.Affinity,
+		AntiAffinity: doc.AntiAffinity,
+		Spot:         doc.Spot,
+		MaxPrice:     doc.MaxPrice,
 	}
 	return result
 }
@@ -58,6 +68,11 @@ func newConstraintsDoc(cons constraints.Value) constraintsDoc {
 		Tags:         cons.Tags,
 		Spaces:       cons.Spaces,
 		VirtType:     cons.VirtType,
+		Zones:        cons.Zones,
+		Affinity:     cons.Affinity,
+		AntiAffinity: cons.AntiAffinity,
+		Spot:         cons.Spot,
+		MaxPrice:     cons.MaxPrice,
 	}
 	return result
 }
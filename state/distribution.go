@@ -8,7 +8,9 @@ import (
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
 )
 
@@ -16,7 +18,17 @@ import (
 // and asks the InstanceDistributor policy (if any) which ones are suitable
 // for assigning the unit to. If there is no InstanceDistributor, or the
 // distribution group is empty, then all of the candidates will be returned.
-func distributeUnit(u *Unit, candidates []instance.Id) ([]instance.Id, error) {
+// Candidates in availability zones ruled out by the unit's zones, affinity
+// or anti-affinity constraints are never returned, and instances of any
+// anti-affinity applications are included in the distribution group.
+func distributeUnit(u *Unit, candidates []instance.Id, cons constraints.Value) ([]instance.Id, error) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	candidates, err := filterInstancesByZone(u.st, cons, candidates)
+	if err != nil {
+		return nil, err
+	}
 	if len(candidates) == 0 {
 		return nil, nil
 	}
@@ -36,6 +48,15 @@ func distributeUnit(u *Unit, candidates []instance.Id) ([]instance.Id, error) {
 	if err != nil {
 		return nil, err
 	}
+	if cons.AntiAffinity != nil {
+		for _, app := range *cons.AntiAffinity {
+			instances, err := ApplicationInstances(u.st, app)
+			if err != nil {
+				return nil, err
+			}
+			distributionGroup = append(distributionGroup, instances...)
+		}
+	}
 	if len(distributionGroup) == 0 {
 		return candidates, nil
 	}
@@ -93,3 +114,137 @@ func ApplicationMachines(st *State, application string) ([]string, error) {
 	}
 	return machineIds, nil
 }
+
+// ResolveZoneConstraints returns a copy of cons with any affinity and
+// anti-affinity application policies resolved into the zones constraint.
+// A machine with affinity for an application is limited to the zones
+// that application's provisioned machines are in (if there are any yet),
+// and one with anti-affinity for an application excludes the zones that
+// application's machines are in. An error satisfying errors.IsNotValid
+// is returned if no zone is left to choose from.
+func ResolveZoneConstraints(st *State, cons constraints.Value) (constraints.Value, error) {
+	if !cons.HasAffinity() {
+		return cons, nil
+	}
+	include := set.NewStrings(cons.IncludeZones()...)
+	exclude := set.NewStrings(cons.ExcludeZones()...)
+	if cons.Affinity != nil && len(*cons.Affinity) > 0 {
+		zones, err := applicationZones(st, *cons.Affinity)
+		if err != nil {
+			return constraints.Value{}, errors.Trace(err)
+		}
+		switch {
+		case zones.IsEmpty():
+			// None of the applications have been provisioned yet,
+			// so there's nowhere to be near.
+		case include.IsEmpty():
+			include = zones
+		default:
+			include = include.Intersection(zones)
+			if include.IsEmpty() {
+				return constraints.Value{}, errors.NotValidf(
+					"zones %q with affinity for %q in zones %q",
+					cons.IncludeZones(), *cons.Affinity, zones.SortedValues(),
+				)
+			}
+		}
+	}
+	if cons.AntiAffinity != nil && len(*cons.AntiAffinity) > 0 {
+		zones, err := applicationZones(st, *cons.AntiAffinity)
+		if err != nil {
+			return constraints.Value{}, errors.Trace(err)
+		}
+		exclude = exclude.Union(zones)
+	}
+	if !include.IsEmpty() && include.Difference(exclude).IsEmpty() {
+		return constraints.Value{}, errors.NotValidf(
+			"zones %q all excluded by zones %q", include.SortedValues(), exclude.SortedValues(),
+		)
+	}
+
+	zones := include.Difference(exclude).SortedValues()
+	for _, zone := range exclude.SortedValues() {
+		zones = append(zones, "^"+zone)
+	}
+	cons.Zones = &zones
+	return cons, nil
+}
+
+// filterInstancesByZone returns the candidate instances which are in an
+// availability zone allowed by the zones, affinity and anti-affinity
+// constraints. Instances with no recorded zone are kept unless specific
+// zones are required.
+func filterInstancesByZone(st *State, cons constraints.Value, candidates []instance.Id) ([]instance.Id, error) {
+	if !cons.HasZones() && !cons.HasAffinity() {
+		return candidates, nil
+	}
+	cons, err := ResolveZoneConstraints(st, cons)
+	if errors.IsNotValid(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	include := set.NewStrings(cons.IncludeZones()...)
+	exclude := set.NewStrings(cons.ExcludeZones()...)
+
+	instanceDataCollection, closer := st.db().GetCollection(instanceDataC)
+	defer closer()
+	var docs []instanceData
+	query := bson.D{{"instanceid", bson.D{{"$in", candidates}}}}
+	if err := instanceDataCollection.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get instance data")
+	}
+	zones := make(map[instance.Id]string)
+	for _, doc := range docs {
+		if doc.AvailZone != nil {
+			zones[doc.InstanceId] = *doc.AvailZone
+		}
+	}
+
+	var result []instance.Id
+	for _, id := range candidates {
+		zone := zones[id]
+		if exclude.Contains(zone) {
+			continue
+		}
+		if !include.IsEmpty() && !include.Contains(zone) {
+			continue
+		}
+		result = append(result, id)
+	}
+	return result, nil
+}
+
+// applicationZones returns the availability zones of the provisioned
+// machines which are assigned units of the specified applications.
+func applicationZones(st *State, applications []string) (set.Strings, error) {
+	zones := set.NewStrings()
+	for _, application := range applications {
+		units, err := allUnits(st, application)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			machineId, err := unit.AssignedMachineId()
+			if errors.IsNotAssigned(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			machine, err := st.Machine(machineId)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			zone, err := machine.AvailabilityZone()
+			if errors.IsNotProvisioned(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if zone != "" {
+				zones.Add(zone)
+			}
+		}
+	}
+	return zones, nil
+}
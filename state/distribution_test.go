@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
@@ -182,6 +183,102 @@ func (s *InstanceDistributorSuite) TestDistributeInstancesNoPolicy(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+// provisionInZones provisions the suite's machines in the given zones.
+func (s *InstanceDistributorSuite) provisionInZones(c *gc.C, zones ...string) {
+	for i, m := range s.machines {
+		instId := instance.Id(fmt.Sprintf("i-blah-%d", i))
+		hc := &instance.HardwareCharacteristics{AvailabilityZone: &zones[i]}
+		err := m.SetProvisioned(instId, "fake-nonce", hc)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *InstanceDistributorSuite) TestDistributeInstancesZonesConstraint(c *gc.C) {
+	s.provisionInZones(c, "az0", "az1", "az2")
+	err := s.wordpress.SetConstraints(constraints.MustParse("zones=az1,az2"))
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machines[2])
+	c.Assert(err, jc.ErrorIsNil)
+
+	unit, err = s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := unit.AssignToCleanMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Id(), gc.Equals, s.machines[1].Id())
+	c.Assert(s.distributor.candidates, jc.SameContents, []instance.Id{"i-blah-1"})
+}
+
+func (s *InstanceDistributorSuite) TestDistributeInstancesAntiAffinity(c *gc.C) {
+	s.provisionInZones(c, "az0", "az0", "az1")
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	unit, err := mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machines[0])
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.wordpress.SetConstraints(constraints.MustParse("anti-affinity=mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err = s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := unit.AssignToCleanMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Id(), gc.Equals, s.machines[2].Id())
+	c.Assert(s.distributor.candidates, jc.SameContents, []instance.Id{"i-blah-2"})
+	c.Assert(s.distributor.distributionGroup, jc.SameContents, []instance.Id{"i-blah-0"})
+}
+
+func (s *InstanceDistributorSuite) TestResolveZoneConstraints(c *gc.C) {
+	s.provisionInZones(c, "az0", "az1", "az2")
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	unit, err := mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machines[0])
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err = s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machines[1])
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, t := range []struct {
+		cons   string
+		result string
+		err    string
+	}{{
+		cons:   "zones=az0,az1",
+		result: "zones=az0,az1",
+	}, {
+		cons:   "affinity=mysql",
+		result: "zones=az0 affinity=mysql",
+	}, {
+		cons:   "anti-affinity=mysql,wordpress",
+		result: "zones=^az0,^az1 anti-affinity=mysql,wordpress",
+	}, {
+		cons:   "zones=az0,az2 anti-affinity=mysql",
+		result: "zones=az2,^az0 anti-affinity=mysql",
+	}, {
+		cons:   "affinity=varnish",
+		result: "zones= affinity=varnish",
+	}, {
+		cons: "zones=az2 affinity=mysql",
+		err:  `zones \["az2"\] with affinity for \["mysql"\] in zones \["az0"\] not valid`,
+	}, {
+		cons: "affinity=mysql anti-affinity=wordpress,mysql",
+		err:  `zones \["az0"\] all excluded by zones \["az0" "az1"\] not valid`,
+	}} {
+		c.Logf("test %d: %s", i, t.cons)
+		cons, err := state.ResolveZoneConstraints(s.State, constraints.MustParse(t.cons))
+		if t.err != "" {
+			c.Check(err, gc.ErrorMatches, t.err)
+			c.Check(err, jc.Satisfies, errors.IsNotValid)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(cons.String(), gc.Equals, t.result)
+	}
+}
+
 type ApplicationMachinesSuite struct {
 	ConnSuite
 	wordpress *state.Application
@@ -0,0 +1,84 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/network"
+)
+
+// RelatedAddresses returns the addresses of the other machines hosting
+// units of the applications that are related to the units on the
+// machine. Remote applications have no machines in the model, so
+// relations to them are not taken into account.
+func (m *Machine) RelatedAddresses() ([]network.Address, error) {
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	appNames := set.NewStrings()
+	for _, unit := range units {
+		appNames.Add(unit.ApplicationName())
+	}
+	relatedAppNames := set.NewStrings()
+	for _, appName := range appNames.SortedValues() {
+		app, err := m.st.Application(appName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		relations, err := app.Relations()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, rel := range relations {
+			for _, ep := range rel.Endpoints() {
+				if ep.ApplicationName != appName {
+					relatedAppNames.Add(ep.ApplicationName)
+				}
+			}
+		}
+	}
+
+	machineIds := set.NewStrings()
+	for _, appName := range relatedAppNames.SortedValues() {
+		app, err := m.st.Application(appName)
+		if errors.IsNotFound(err) {
+			// The application is remote or has been removed.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		appUnits, err := app.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range appUnits {
+			machineId, err := unit.AssignedMachineId()
+			if errors.IsNotAssigned(err) || errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if machineId != m.Id() {
+				machineIds.Add(machineId)
+			}
+		}
+	}
+
+	var addresses []network.Address
+	for _, machineId := range machineIds.SortedValues() {
+		machine, err := m.st.Machine(machineId)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		addresses = append(addresses, machine.Addresses()...)
+	}
+	return addresses, nil
+}
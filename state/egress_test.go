@@ -0,0 +1,61 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type egressSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&egressSuite{})
+
+func (s *egressSuite) addUnitWithAddress(c *gc.C, app *state.Application, address string) (*state.Unit, *state.Machine) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Addresses: network.NewAddresses(address),
+	})
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: app,
+		Machine:     machine,
+	})
+	return unit, machine
+}
+
+func (s *egressSuite) TestRelatedAddresses(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	other := s.AddTestingApplication(c, "other", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, m0 := s.addUnitWithAddress(c, wordpress, "10.0.0.1")
+	_, m1 := s.addUnitWithAddress(c, mysql, "10.0.0.2")
+	_, m2 := s.addUnitWithAddress(c, mysql, "10.0.0.3")
+	_, m3 := s.addUnitWithAddress(c, other, "10.0.0.4")
+
+	addresses, err := m0.RelatedAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addresses, jc.DeepEquals, network.NewAddresses("10.0.0.2", "10.0.0.3"))
+
+	addresses, err = m1.RelatedAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addresses, jc.DeepEquals, network.NewAddresses("10.0.0.1"))
+
+	addresses, err = m2.RelatedAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addresses, jc.DeepEquals, network.NewAddresses("10.0.0.1"))
+
+	addresses, err = m3.RelatedAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addresses, gc.HasLen, 0)
+}
@@ -69,6 +69,7 @@ func (rc *externalController) ControllerInfo() crossmodel.ControllerInfo {
 // ExternalControllers instances provide access to external controllers in state.
 type ExternalControllers interface {
 	Save(_ crossmodel.ControllerInfo, modelUUIDs ...string) (ExternalController, error)
+	SaveAndMoveModels(_ crossmodel.ControllerInfo, modelUUIDs ...string) error
 	Controller(controllerUUID string) (ExternalController, error)
 	ControllerForModel(modelUUID string) (ExternalController, error)
 	Remove(controllerUUID string) error
@@ -87,6 +88,18 @@ func NewExternalControllers(st *State) *externalControllers {
 
 // Add creates or updates an external controller record.
 func (ec *externalControllers) Save(controller crossmodel.ControllerInfo, modelUUIDs ...string) (ExternalController, error) {
+	return ec.save(controller, false, modelUUIDs...)
+}
+
+// SaveAndMoveModels creates or updates an external controller record,
+// and removes the specified models from the records of any other external
+// controllers, as when the models have been migrated between them.
+func (ec *externalControllers) SaveAndMoveModels(controller crossmodel.ControllerInfo, modelUUIDs ...string) error {
+	_, err := ec.save(controller, true, modelUUIDs...)
+	return errors.Trace(err)
+}
+
+func (ec *externalControllers) save(controller crossmodel.ControllerInfo, moveModels bool, modelUUIDs ...string) (ExternalController, error) {
 	if err := controller.Validate(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -134,6 +147,13 @@ func (ec *externalControllers) Save(controller crossmodel.ControllerInfo, modelU
 				Insert: doc,
 			}, model.assertActiveOp()}
 		}
+		if moveModels && len(modelUUIDs) > 0 {
+			moveOps, err := ec.removeModelsFromOtherControllersOps(doc.Id, modelUUIDs)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, moveOps...)
+		}
 		return ops, nil
 	}
 	if err := ec.st.db().Run(buildTxn); err != nil {
@@ -145,6 +165,77 @@ func (ec *externalControllers) Save(controller crossmodel.ControllerInfo, modelU
 	}, nil
 }
 
+// modelMovedToControllerOps returns the operations which record that the
+// model with the specified UUID is now hosted by the specified controller.
+// Unlike Save, the life of the model whose state is used is not checked,
+// so these can be run as the model is migrated away.
+func (ec *externalControllers) modelMovedToControllerOps(controller crossmodel.ControllerInfo, modelUUID string) ([]txn.Op, error) {
+	existing, err := ec.controller(controller.ControllerTag.Id())
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	if err == nil {
+		ops = []txn.Op{{
+			C:      externalControllersC,
+			Id:     existing.Id,
+			Assert: txn.DocExists,
+			Update: bson.D{
+				{"$set", bson.D{
+					{"addresses", controller.Addrs},
+					{"cacert", controller.CACert},
+				}},
+				{"$addToSet", bson.D{{"models", modelUUID}}},
+			},
+		}}
+	} else {
+		ops = []txn.Op{{
+			C:      externalControllersC,
+			Id:     controller.ControllerTag.Id(),
+			Assert: txn.DocMissing,
+			Insert: externalControllerDoc{
+				Id:     controller.ControllerTag.Id(),
+				Alias:  controller.Alias,
+				Addrs:  controller.Addrs,
+				CACert: controller.CACert,
+				Models: []string{modelUUID},
+			},
+		}}
+	}
+	moveOps, err := ec.removeModelsFromOtherControllersOps(controller.ControllerTag.Id(), []string{modelUUID})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, moveOps...), nil
+}
+
+// removeModelsFromOtherControllersOps returns the operations which remove
+// the specified models from the records of external controllers other than
+// the one with the specified UUID.
+func (ec *externalControllers) removeModelsFromOtherControllersOps(controllerUUID string, modelUUIDs []string) ([]txn.Op, error) {
+	coll, closer := ec.st.db().GetCollection(externalControllersC)
+	defer closer()
+
+	var docs []externalControllerDoc
+	err := coll.Find(bson.D{
+		{"_id", bson.D{{"$ne", controllerUUID}}},
+		{"models", bson.D{{"$in", modelUUIDs}}},
+	}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      externalControllersC,
+			Id:     doc.Id,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$pullAll", bson.D{{"models", modelUUIDs}}}},
+		}
+	}
+	return ops, nil
+}
+
 // Remove removes an external controller record with the given controller UUID.
 func (ec *externalControllers) Remove(controllerUUID string) error {
 	ops := []txn.Op{{
@@ -0,0 +1,235 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// Image attributes that an ImagePolicy can prefer.
+const (
+	ImageTagVirtType        = "virt-type"
+	ImageTagRootStorageType = "root-storage-type"
+	ImageTagSource          = "source"
+)
+
+// ImagePolicy constrains the images used to provision the model's
+// machines of a series. A policy may apply to a single cloud region,
+// in which case it takes the place of any policy for the series that
+// applies to all regions.
+type ImagePolicy struct {
+	// Series is the OS series of the machines the policy applies to.
+	Series string
+
+	// Region is the cloud region the policy applies to. If it is
+	// empty, the policy applies to all regions.
+	Region string
+
+	// ImageId, if set, is the image that is always used, without
+	// looking up image metadata. A pinned image requires an Arch.
+	ImageId string
+
+	// Arch is the architecture of the pinned image.
+	Arch string
+
+	// Stream, if set, is the image stream that images are taken
+	// from, in place of the model's image-stream.
+	Stream string
+
+	// RequireSigned records that only images found in signed image
+	// metadata may be used.
+	RequireSigned bool
+
+	// PreferTags holds image attributes, keyed by ImageTagVirtType,
+	// ImageTagRootStorageType or ImageTagSource. When any of the
+	// images found has all of the attributes, only those images are
+	// used.
+	PreferTags map[string]string
+}
+
+// Validate returns an error if the policy is not valid.
+func (p ImagePolicy) Validate() error {
+	if p.Series == "" {
+		return errors.NotValidf("image policy without a series")
+	}
+	if p.ImageId != "" {
+		if !arch.IsSupportedArch(p.Arch) {
+			return errors.NotValidf("pinned image %q architecture %q", p.ImageId, p.Arch)
+		}
+		if p.RequireSigned || len(p.PreferTags) > 0 {
+			return errors.NotValidf("pinned image %q with image preferences", p.ImageId)
+		}
+	} else if p.Arch != "" {
+		return errors.NotValidf("architecture %q without a pinned image", p.Arch)
+	}
+	for tag := range p.PreferTags {
+		switch tag {
+		case ImageTagVirtType, ImageTagRootStorageType, ImageTagSource:
+		default:
+			return errors.NotValidf("image tag %q", tag)
+		}
+	}
+	return nil
+}
+
+type imagePolicyDoc struct {
+	DocID         string            `bson:"_id"`
+	Series        string            `bson:"series"`
+	Region        string            `bson:"region"`
+	ImageId       string            `bson:"image-id,omitempty"`
+	Arch          string            `bson:"arch,omitempty"`
+	Stream        string            `bson:"stream,omitempty"`
+	RequireSigned bool              `bson:"require-signed,omitempty"`
+	PreferTags    map[string]string `bson:"prefer-tags,omitempty"`
+}
+
+func (doc *imagePolicyDoc) policy() ImagePolicy {
+	return ImagePolicy{
+		Series:        doc.Series,
+		Region:        doc.Region,
+		ImageId:       doc.ImageId,
+		Arch:          doc.Arch,
+		Stream:        doc.Stream,
+		RequireSigned: doc.RequireSigned,
+		PreferTags:    doc.PreferTags,
+	}
+}
+
+func imagePolicyId(series, region string) string {
+	return series + "#" + region
+}
+
+// SetImagePolicy adds the image policy to the model, replacing any
+// existing policy for the same series and region.
+func (st *State) SetImagePolicy(policy ImagePolicy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	id := imagePolicyId(policy.Series, policy.Region)
+	doc := imagePolicyDoc{
+		DocID:         id,
+		Series:        policy.Series,
+		Region:        policy.Region,
+		ImageId:       policy.ImageId,
+		Arch:          policy.Arch,
+		Stream:        policy.Stream,
+		RequireSigned: policy.RequireSigned,
+		PreferTags:    policy.PreferTags,
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		model, err := st.Model()
+		if err != nil {
+			return nil, errors.Annotate(err, "failed to load model")
+		}
+		if err := checkModelActive(st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		_, err = st.imagePolicy(id)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if err == nil {
+			return []txn.Op{{
+				C:      imagePoliciesC,
+				Id:     id,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{
+					{"image-id", doc.ImageId},
+					{"arch", doc.Arch},
+					{"stream", doc.Stream},
+					{"require-signed", doc.RequireSigned},
+					{"prefer-tags", doc.PreferTags},
+				}}},
+			}, model.assertActiveOp()}, nil
+		}
+		return []txn.Op{{
+			C:      imagePoliciesC,
+			Id:     id,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		}, model.assertActiveOp()}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot set image policy")
+	}
+	return nil
+}
+
+// RemoveImagePolicy removes the model's image policy for the series
+// and region.
+func (st *State) RemoveImagePolicy(series, region string) error {
+	id := imagePolicyId(series, region)
+	buildTxn := func(int) ([]txn.Op, error) {
+		if _, err := st.imagePolicy(id); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      imagePoliciesC,
+			Id:     id,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot remove image policy")
+	}
+	return nil
+}
+
+// ImagePolicy returns the image policy that applies to machines of the
+// series in the region: the policy for the region if there is one,
+// and otherwise the policy for the series in all regions.
+func (st *State) ImagePolicy(series, region string) (ImagePolicy, error) {
+	if region != "" {
+		policy, err := st.imagePolicy(imagePolicyId(series, region))
+		if err == nil || !errors.IsNotFound(err) {
+			return policy, errors.Trace(err)
+		}
+	}
+	policy, err := st.imagePolicy(imagePolicyId(series, ""))
+	return policy, errors.Trace(err)
+}
+
+func (st *State) imagePolicy(id string) (ImagePolicy, error) {
+	coll, closer := st.db().GetCollection(imagePoliciesC)
+	defer closer()
+
+	var doc imagePolicyDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return ImagePolicy{}, errors.NotFoundf("image policy %q", id)
+	} else if err != nil {
+		return ImagePolicy{}, errors.Trace(err)
+	}
+	return doc.policy(), nil
+}
+
+// AllImagePolicies returns the model's image policies, ordered by
+// series and region.
+func (st *State) AllImagePolicies() ([]ImagePolicy, error) {
+	coll, closer := st.db().GetCollection(imagePoliciesC)
+	defer closer()
+
+	var docs []imagePolicyDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	policies := make([]ImagePolicy, len(docs))
+	for i, doc := range docs {
+		policies[i] = doc.policy()
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		if policies[i].Series != policies[j].Series {
+			return policies[i].Series < policies[j].Series
+		}
+		return policies[i].Region < policies[j].Region
+	})
+	return policies, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ImagePoliciesSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ImagePoliciesSuite{})
+
+func (s *ImagePoliciesSuite) TestSetAndGet(c *gc.C) {
+	pinned := state.ImagePolicy{
+		Series:  "bionic",
+		Region:  "us-east-1",
+		ImageId: "ami-1234",
+		Arch:    "amd64",
+	}
+	preferred := state.ImagePolicy{
+		Series:        "bionic",
+		Stream:        "released",
+		RequireSigned: true,
+		PreferTags:    map[string]string{state.ImageTagVirtType: "hvm"},
+	}
+	c.Assert(s.State.SetImagePolicy(pinned), jc.ErrorIsNil)
+	c.Assert(s.State.SetImagePolicy(preferred), jc.ErrorIsNil)
+
+	policy, err := s.State.ImagePolicy("bionic", "us-east-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(policy, jc.DeepEquals, pinned)
+
+	// Other regions fall back to the policy for all regions.
+	policy, err = s.State.ImagePolicy("bionic", "us-west-2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(policy, jc.DeepEquals, preferred)
+
+	_, err = s.State.ImagePolicy("xenial", "us-east-1")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	all, err := s.State.AllImagePolicies()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(all, jc.DeepEquals, []state.ImagePolicy{preferred, pinned})
+}
+
+func (s *ImagePoliciesSuite) TestSetReplaces(c *gc.C) {
+	err := s.State.SetImagePolicy(state.ImagePolicy{
+		Series:     "bionic",
+		PreferTags: map[string]string{state.ImageTagVirtType: "hvm"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	replacement := state.ImagePolicy{Series: "bionic", Stream: "daily"}
+	c.Assert(s.State.SetImagePolicy(replacement), jc.ErrorIsNil)
+
+	policy, err := s.State.ImagePolicy("bionic", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(policy, jc.DeepEquals, replacement)
+}
+
+func (s *ImagePoliciesSuite) TestRemove(c *gc.C) {
+	err := s.State.SetImagePolicy(state.ImagePolicy{Series: "bionic", Stream: "daily"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.State.RemoveImagePolicy("bionic", ""), jc.ErrorIsNil)
+
+	_, err = s.State.ImagePolicy("bionic", "")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveImagePolicy("bionic", "")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(err, gc.ErrorMatches, `cannot remove image policy: image policy "bionic#" not found`)
+}
+
+func (s *ImagePoliciesSuite) TestSetInvalid(c *gc.C) {
+	for i, t := range []struct {
+		policy state.ImagePolicy
+		err    string
+	}{{
+		policy: state.ImagePolicy{Stream: "daily"},
+		err:    "image policy without a series not valid",
+	}, {
+		policy: state.ImagePolicy{Series: "bionic", Region: "us-east-1", ImageId: "ami-1234"},
+		err:    `pinned image "ami-1234" architecture "" not valid`,
+	}, {
+		policy: state.ImagePolicy{Series: "bionic", Region: "us-east-1", ImageId: "ami-1234", Arch: "amd64", RequireSigned: true},
+		err:    `pinned image "ami-1234" with image preferences not valid`,
+	}, {
+		policy: state.ImagePolicy{Series: "bionic", Arch: "amd64"},
+		err:    `architecture "amd64" without a pinned image not valid`,
+	}, {
+		policy: state.ImagePolicy{Series: "bionic", PreferTags: map[string]string{"colour": "blue"}},
+		err:    `image tag "colour" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.State.SetImagePolicy(t.policy)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
@@ -1157,12 +1157,31 @@ func (s *linkLayerDevicesStateSuite) TestSetLinkLayerDevicesWithTooMuchStateChur
 	s.assertAllLinkLayerDevicesOnMachineMatchCount(c, s.machine, 1) // only the parent remains
 }
 
-func (s *linkLayerDevicesStateSuite) TestSetLinkLayerDevicesRefusesToAddContainerChildDeviceWithNonBridgeParent(c *gc.C) {
-	// Add one device of every type to the host machine, except a BridgeDevice.
-	hostDevicesArgs := []state.LinkLayerDeviceArgs{{
+func (s *linkLayerDevicesStateSuite) TestSetLinkLayerDevicesRefusesToAddContainerChildDeviceWithLoopbackParent(c *gc.C) {
+	s.setMultipleDevicesSucceedsAndCheckAllAdded(c, []state.LinkLayerDeviceArgs{{
 		Name: "loopback",
 		Type: state.LoopbackDevice,
-	}, {
+	}})
+	s.addContainerMachine(c)
+
+	containerDeviceArgs := state.LinkLayerDeviceArgs{
+		Name:       "eth0",
+		Type:       state.EthernetDevice,
+		ParentName: "m#0#d#loopback",
+	}
+	err := s.containerMachine.SetLinkLayerDevices(containerDeviceArgs)
+	expectedError := `cannot set .* to machine "0/lxd/0": ` +
+		`invalid device "eth0": ` +
+		`parent device "loopback" on host machine "0" must be of type "bridge", "ethernet", "802.1q" or "bond", not type "loopback"`
+	c.Check(err, gc.ErrorMatches, expectedError)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	s.assertNoDevicesOnMachine(c, s.containerMachine)
+}
+
+func (s *linkLayerDevicesStateSuite) TestSetLinkLayerDevicesAllowsNonBridgeParentForContainerDevice(c *gc.C) {
+	// Containers using macvlan, ipvlan or SR-IOV have their devices
+	// attached directly to a host device, rather than to a bridge.
+	hostDevices := s.setMultipleDevicesSucceedsAndCheckAllAdded(c, []state.LinkLayerDeviceArgs{{
 		Name: "ethernet",
 		Type: state.EthernetDevice,
 	}, {
@@ -1171,29 +1190,16 @@ func (s *linkLayerDevicesStateSuite) TestSetLinkLayerDevicesRefusesToAddContaine
 	}, {
 		Name: "bond",
 		Type: state.BondDevice,
-	}}
-	hostDevices := s.setMultipleDevicesSucceedsAndCheckAllAdded(c, hostDevicesArgs)
-	hostMachineParentDeviceGlobalKeyPrefix := "m#0#d#"
+	}})
 	s.addContainerMachine(c)
 
-	// Now try setting an EthernetDevice on the container specifying each of the
-	// hostDevices as parent and expect none of them to succeed, as none of the
-	// hostDevices is a BridgeDevice.
-	for _, hostDevice := range hostDevices {
-		parentDeviceGlobalKey := hostMachineParentDeviceGlobalKeyPrefix + hostDevice.Name()
-		containerDeviceArgs := state.LinkLayerDeviceArgs{
-			Name:       "eth0",
-			Type:       state.EthernetDevice,
-			ParentName: parentDeviceGlobalKey,
-		}
-		err := s.containerMachine.SetLinkLayerDevices(containerDeviceArgs)
-		expectedError := `cannot set .* to machine "0/lxd/0": ` +
-			`invalid device "eth0": ` +
-			`parent device ".*" on host machine "0" must be of type "bridge", not type ".*"`
-		c.Check(err, gc.ErrorMatches, expectedError)
-		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	for i, hostDevice := range hostDevices {
+		containerDeviceArgs, err := state.DefineEthernetDeviceOnParent(fmt.Sprintf("eth%d", i), hostDevice)
+		c.Assert(err, jc.ErrorIsNil)
+		err = s.containerMachine.SetLinkLayerDevices(containerDeviceArgs)
+		c.Check(err, jc.ErrorIsNil)
 	}
-	s.assertNoDevicesOnMachine(c, s.containerMachine)
+	s.assertAllLinkLayerDevicesOnMachineMatchCount(c, s.containerMachine, len(hostDevices))
 }
 
 func (s *linkLayerDevicesStateSuite) addContainerMachine(c *gc.C) {
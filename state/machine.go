@@ -563,6 +563,72 @@ func (m *Machine) forceDestroyOps() ([]txn.Op, error) {
 	}
 }
 
+// Replace queues the machine for forced removal after adding a
+// replacement for each principal unit assigned to it. The replacement
+// units are staged for assignment by the unit assigner, which puts them
+// on new (or clean and empty) machines. It is intended for machines
+// whose instances have been reclaimed by the cloud. The units are added
+// and the machine made Dying in a single transaction, and nothing is
+// done if the machine is not Alive, so Replace can safely be retried.
+func (m *Machine) Replace() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot replace machine %v", m)
+	if m.IsManager() {
+		return errors.NotSupportedf("replacing controller machine")
+	}
+	if m.ContainerType() != "" {
+		return errors.NotSupportedf("replacing container")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.Life() != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		// The machine is made Dying, so that it can't be replaced
+		// twice, nor have units assigned to it while the forced
+		// destroy cleanup is pending.
+		ops := []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"principals", m.doc.Principals}},
+			Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+		}}
+		units, err := m.Units()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, u := range units {
+			if !u.IsPrincipal() || u.Life() != Alive {
+				continue
+			}
+			app, err := u.Application()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			name, addOps, err := app.addUnitOps("", AddUnitParams{}, nil)
+			if err != nil {
+				return nil, errors.Annotatef(err, "adding replacement for unit %q", u.Name())
+			}
+			ops = append(ops, txn.Op{
+				C:      unitsC,
+				Id:     u.doc.DocID,
+				Assert: isAliveDoc,
+			})
+			ops = append(ops, addOps...)
+			ops = append(ops, assignUnitOps(name, instance.Placement{})...)
+		}
+		destroyOps, err := m.forceDestroyOps()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, destroyOps...), nil
+	}
+	return m.st.db().Run(buildTxn)
+}
+
 // EnsureDead sets the machine lifecycle to Dead if it is Alive or Dying.
 // It does nothing otherwise. EnsureDead will fail if the machine has
 // principal units assigned, or if the machine has JobManageModel.
@@ -947,6 +1013,7 @@ func (m *Machine) removeOps() ([]txn.Op, error) {
 		annotationRemoveOp(m.st, m.globalKey()),
 		removeRebootDocOp(m.st, m.globalKey()),
 		removeMachineBlockDevicesOp(m.Id()),
+		removeMachineBondsOp(m.Id()),
 		removeModelMachineRefOp(m.st, m.Id()),
 		removeSSHHostKeyOp(m.globalKey()),
 	}
@@ -1515,7 +1582,7 @@ func (m *Machine) setPreferredAddressOps(addr address, isPublic bool) []txn.Op {
 	return ops
 }
 
-func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, *address) {
+func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address, preferIPv6 bool) ([]txn.Op, *address) {
 	publicAddress := m.doc.PreferredPublicAddress
 	logger.Tracef(
 		"machine %v: current public address: %#v \nprovider addresses: %#v \nmachine addresses: %#v",
@@ -1523,11 +1590,12 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 
 	// Always prefer an exact match if available.
 	checkScope := func(addr address) bool {
-		return network.ExactScopeMatch(addr.networkAddress(), network.ScopePublic)
+		return network.ExactScopeMatch(addr.networkAddress(), network.ScopePublic) &&
+			preferredAddressType(addr, preferIPv6)
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
-		addr, _ := network.SelectPublicAddress(networkAddresses(addresses))
+		addr, _ := network.SelectPreferredPublicAddress(networkAddresses(addresses), preferIPv6)
 		return addr
 	}
 
@@ -1541,16 +1609,17 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 	return ops, &newAddr
 }
 
-func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, *address) {
+func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address, preferIPv6 bool) ([]txn.Op, *address) {
 	privateAddress := m.doc.PreferredPrivateAddress
 	// Always prefer an exact match if available.
 	checkScope := func(addr address) bool {
 		return network.ExactScopeMatch(
-			addr.networkAddress(), network.ScopeMachineLocal, network.ScopeCloudLocal, network.ScopeFanLocal)
+			addr.networkAddress(), network.ScopeMachineLocal, network.ScopeCloudLocal, network.ScopeFanLocal) &&
+			preferredAddressType(addr, preferIPv6)
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
-		addr, _ := network.SelectInternalAddress(networkAddresses(addresses), false)
+		addr, _ := network.SelectPreferredInternalAddress(networkAddresses(addresses), false, preferIPv6)
 		return addr
 	}
 
@@ -1563,6 +1632,99 @@ func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddre
 	return ops, &newAddr
 }
 
+// preferredAddressType returns false if addr is an IPv4 address and
+// IPv6 addresses are preferred, so a better match is looked for.
+func preferredAddressType(addr address, preferIPv6 bool) bool {
+	return !preferIPv6 || network.AddressType(addr.AddressType) != network.IPv4Address
+}
+
+// preferIPv6 returns whether the model prefers IPv6 addresses over IPv4
+// addresses when selecting the preferred addresses of its machines and
+// units.
+func (st *State) preferIPv6() (bool, error) {
+	model, err := st.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return cfg.PreferredIPv6(), nil
+}
+
+// reselectPreferredAddressesOps returns the operations needed to pick the
+// machine's preferred addresses afresh from its current addresses. It is
+// used when the model's address family preference changes, as the
+// preferred addresses are otherwise kept while they remain suitable.
+func (m *Machine) reselectPreferredAddressesOps(preferIPv6 bool) []txn.Op {
+	var ops []txn.Op
+	current := *m
+	current.doc.PreferredPrivateAddress = address{}
+	current.doc.PreferredPublicAddress = address{}
+	_, newPrivate := current.setPrivateAddressOps(m.doc.Addresses, m.doc.MachineAddresses, preferIPv6)
+	if newPrivate != nil && *newPrivate != m.doc.PreferredPrivateAddress {
+		ops = append(ops, m.setPreferredAddressOps(*newPrivate, false)...)
+	}
+	_, newPublic := current.setPublicAddressOps(m.doc.Addresses, m.doc.MachineAddresses, preferIPv6)
+	if newPublic != nil && *newPublic != m.doc.PreferredPublicAddress {
+		ops = append(ops, m.setPreferredAddressOps(*newPublic, true)...)
+	}
+	return ops
+}
+
+// updatePreferredAddresses picks the preferred addresses of all machines
+// and the address family preference of all cloud services in the model
+// again, after the preferred-ipv6 model config has changed.
+func (st *State) updatePreferredAddresses(preferIPv6 bool) error {
+	machines, err := st.AllMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, m := range machines {
+		buildTxn := func(attempt int) ([]txn.Op, error) {
+			if attempt > 0 {
+				if err := m.Refresh(); errors.IsNotFound(err) {
+					return nil, jujutxn.ErrNoOperations
+				} else if err != nil {
+					return nil, errors.Trace(err)
+				}
+			}
+			ops := m.reselectPreferredAddressesOps(preferIPv6)
+			if len(ops) == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return ops, nil
+		}
+		if err := st.db().Run(buildTxn); err != nil {
+			return errors.Annotatef(err, "cannot update preferred addresses of machine %v", m)
+		}
+	}
+
+	cloudServices, closer := st.db().GetCollection(cloudServicesC)
+	defer closer()
+	var docs []cloudServiceDoc
+	if err := cloudServices.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all cloud services")
+	}
+	var ops []txn.Op
+	for _, doc := range docs {
+		if doc.PreferIPv6 == preferIPv6 {
+			continue
+		}
+		ops = append(ops, txn.Op{
+			C:      cloudServicesC,
+			Id:     doc.Id,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"prefer-ipv6", preferIPv6}}}},
+		})
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	return errors.Trace(st.db().RunTransaction(ops))
+}
+
 // SetProviderAddresses records any addresses related to the machine, sourced
 // by asking the provider.
 func (m *Machine) SetProviderAddresses(addresses ...network.Address) error {
@@ -1680,8 +1842,12 @@ func (m *Machine) setAddressesOps(
 		Update: bson.D{{"$set", set}},
 	}}
 
-	setPrivateAddressOps, newPrivate := m.setPrivateAddressOps(providerStateAddresses, machineStateAddresses)
-	setPublicAddressOps, newPublic := m.setPublicAddressOps(providerStateAddresses, machineStateAddresses)
+	preferIPv6, err := m.st.preferIPv6()
+	if err != nil {
+		return nil, nil, nil, nil, nil, errors.Trace(err)
+	}
+	setPrivateAddressOps, newPrivate := m.setPrivateAddressOps(providerStateAddresses, machineStateAddresses, preferIPv6)
+	setPublicAddressOps, newPublic := m.setPublicAddressOps(providerStateAddresses, machineStateAddresses, preferIPv6)
 	ops = append(ops, setPrivateAddressOps...)
 	ops = append(ops, setPublicAddressOps...)
 	return ops, machineStateAddresses, providerStateAddresses, newPrivate, newPublic, nil
@@ -0,0 +1,208 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// validBondModes holds the bonding modes supported by the Linux kernel,
+// by their names as used in netplan.
+var validBondModes = set.NewStrings(
+	"balance-rr",
+	"active-backup",
+	"balance-xor",
+	"broadcast",
+	"802.3ad",
+	"balance-tlb",
+	"balance-alb",
+)
+
+// BondInfo describes a bond which the machine agent should create by
+// aggregating some of the machine's ethernet devices.
+type BondInfo struct {
+	// Name is the name of the bond device, e.g. "bond0".
+	Name string
+
+	// DeviceNames holds the names of the ethernet devices which are
+	// aggregated by the bond.
+	DeviceNames []string
+
+	// Mode is the bonding mode, e.g. "802.3ad" or "active-backup".
+	// If empty, the kernel default (balance-rr) is used.
+	Mode string
+
+	// LACPRate is the rate at which LACPDUs are transmitted in
+	// 802.3ad mode, either "slow" or "fast".
+	LACPRate string
+
+	// MIIMonitorInterval is the link monitoring interval, in
+	// milliseconds. Zero leaves it unset.
+	MIIMonitorInterval int
+
+	// TransmitHashPolicy selects the transmit hash policy used to
+	// pick a device in balance-xor and 802.3ad modes.
+	TransmitHashPolicy string
+}
+
+// machineBondsDoc holds the bonds defined for a single machine.
+type machineBondsDoc struct {
+	DocID     string        `bson:"_id"`
+	ModelUUID string        `bson:"model-uuid"`
+	Machine   string        `bson:"machineid"`
+	Bonds     []bondInfoDoc `bson:"bonds"`
+}
+
+type bondInfoDoc struct {
+	Name               string   `bson:"name"`
+	DeviceNames        []string `bson:"device-names"`
+	Mode               string   `bson:"mode,omitempty"`
+	LACPRate           string   `bson:"lacp-rate,omitempty"`
+	MIIMonitorInterval int      `bson:"mii-monitor-interval,omitempty"`
+	TransmitHashPolicy string   `bson:"transmit-hash-policy,omitempty"`
+}
+
+// Bonds returns the bonds defined for the machine.
+func (m *Machine) Bonds() ([]BondInfo, error) {
+	docs, err := getMachineBonds(m.st.db(), m.Id())
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get bonds of machine %q", m.Id())
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+	bonds := make([]BondInfo, len(docs))
+	for i, doc := range docs {
+		bonds[i] = BondInfo{
+			Name:               doc.Name,
+			DeviceNames:        doc.DeviceNames,
+			Mode:               doc.Mode,
+			LACPRate:           doc.LACPRate,
+			MIIMonitorInterval: doc.MIIMonitorInterval,
+			TransmitHashPolicy: doc.TransmitHashPolicy,
+		}
+	}
+	return bonds, nil
+}
+
+// SetBonds replaces the bonds defined for the machine. Passing no bonds
+// removes them all. The machine must be alive.
+func (m *Machine) SetBonds(bonds ...BondInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set bonds of machine %q", m.Id())
+
+	docs := make([]bondInfoDoc, len(bonds))
+	for i, bond := range bonds {
+		docs[i] = bondInfoDoc{
+			Name:               bond.Name,
+			DeviceNames:        bond.DeviceNames,
+			Mode:               bond.Mode,
+			LACPRate:           bond.LACPRate,
+			MIIMonitorInterval: bond.MIIMonitorInterval,
+			TransmitHashPolicy: bond.TransmitHashPolicy,
+		}
+	}
+	if err := validateBonds(docs); err != nil {
+		return errors.Trace(err)
+	}
+
+	coll, closer := m.st.db().GetCollection(machineBondsC)
+	defer closer()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.isStillAlive(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		ops := []txn.Op{m.assertAliveOp()}
+		count, err := coll.FindId(m.Id()).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if count == 0 {
+			if len(docs) == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return append(ops, txn.Op{
+				C:      machineBondsC,
+				Id:     m.Id(),
+				Assert: txn.DocMissing,
+				Insert: &machineBondsDoc{Machine: m.Id(), Bonds: docs},
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      machineBondsC,
+			Id:     m.Id(),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"bonds", docs}}}},
+		}), nil
+	}
+	return m.st.db().Run(buildTxn)
+}
+
+func getMachineBonds(db Database, machineId string) ([]bondInfoDoc, error) {
+	coll, closer := db.GetCollection(machineBondsC)
+	defer closer()
+
+	var doc machineBondsDoc
+	err := coll.FindId(machineId).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.Bonds, nil
+}
+
+// validateBonds checks that the bonds have valid and distinct names,
+// valid modes, and that no device is aggregated by more than one bond.
+func validateBonds(bonds []bondInfoDoc) error {
+	names := set.NewStrings()
+	for _, bond := range bonds {
+		if !IsValidLinkLayerDeviceName(bond.Name) {
+			return errors.NotValidf("bond name %q", bond.Name)
+		}
+		if names.Contains(bond.Name) {
+			return errors.NotValidf("duplicate bond %q", bond.Name)
+		}
+		names.Add(bond.Name)
+		if bond.Mode != "" && !validBondModes.Contains(bond.Mode) {
+			return errors.NotValidf("bond %q mode %q", bond.Name, bond.Mode)
+		}
+		if bond.MIIMonitorInterval < 0 {
+			return errors.NotValidf("bond %q MII monitor interval %d", bond.Name, bond.MIIMonitorInterval)
+		}
+		if len(bond.DeviceNames) == 0 {
+			return errors.NotValidf("bond %q with no devices", bond.Name)
+		}
+	}
+	devices := set.NewStrings()
+	for _, bond := range bonds {
+		for _, device := range bond.DeviceNames {
+			if !IsValidLinkLayerDeviceName(device) {
+				return errors.NotValidf("bond %q device name %q", bond.Name, device)
+			}
+			if names.Contains(device) {
+				return errors.NotValidf("bond %q containing bond %q", bond.Name, device)
+			}
+			if devices.Contains(device) {
+				return errors.NotValidf("bond %q reusing device %q", bond.Name, device)
+			}
+			devices.Add(device)
+		}
+	}
+	return nil
+}
+
+func removeMachineBondsOp(machineId string) txn.Op {
+	return txn.Op{
+		C:      machineBondsC,
+		Id:     machineId,
+		Remove: true,
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type MachineBondsSuite struct {
+	ConnSuite
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&MachineBondsSuite{})
+
+func (s *MachineBondsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	var err error
+	s.machine, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MachineBondsSuite) TestBondsNoneSet(c *gc.C) {
+	bonds, err := s.machine.Bonds()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bonds, gc.HasLen, 0)
+}
+
+func (s *MachineBondsSuite) TestSetBondsReplacesAndClears(c *gc.C) {
+	bond0 := state.BondInfo{
+		Name:               "bond0",
+		DeviceNames:        []string{"eth0", "eth1"},
+		Mode:               "802.3ad",
+		LACPRate:           "fast",
+		MIIMonitorInterval: 100,
+		TransmitHashPolicy: "layer3+4",
+	}
+	bond1 := state.BondInfo{
+		Name:        "bond1",
+		DeviceNames: []string{"eth2", "eth3"},
+		Mode:        "active-backup",
+	}
+	err := s.machine.SetBonds(bond0, bond1)
+	c.Assert(err, jc.ErrorIsNil)
+	bonds, err := s.machine.Bonds()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bonds, jc.DeepEquals, []state.BondInfo{bond0, bond1})
+
+	err = s.machine.SetBonds(bond1)
+	c.Assert(err, jc.ErrorIsNil)
+	bonds, err = s.machine.Bonds()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bonds, jc.DeepEquals, []state.BondInfo{bond1})
+
+	err = s.machine.SetBonds()
+	c.Assert(err, jc.ErrorIsNil)
+	bonds, err = s.machine.Bonds()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bonds, gc.HasLen, 0)
+}
+
+func (s *MachineBondsSuite) TestSetBondsValidation(c *gc.C) {
+	for i, test := range []struct {
+		about  string
+		bonds  []state.BondInfo
+		expect string
+	}{{
+		about:  "invalid bond name",
+		bonds:  []state.BondInfo{{Name: "", DeviceNames: []string{"eth0"}}},
+		expect: `bond name "" not valid`,
+	}, {
+		about: "duplicate bond",
+		bonds: []state.BondInfo{
+			{Name: "bond0", DeviceNames: []string{"eth0"}},
+			{Name: "bond0", DeviceNames: []string{"eth1"}},
+		},
+		expect: `duplicate bond "bond0" not valid`,
+	}, {
+		about:  "unknown mode",
+		bonds:  []state.BondInfo{{Name: "bond0", DeviceNames: []string{"eth0"}, Mode: "fast"}},
+		expect: `bond "bond0" mode "fast" not valid`,
+	}, {
+		about:  "negative MII monitor interval",
+		bonds:  []state.BondInfo{{Name: "bond0", DeviceNames: []string{"eth0"}, MIIMonitorInterval: -1}},
+		expect: `bond "bond0" MII monitor interval -1 not valid`,
+	}, {
+		about:  "no devices",
+		bonds:  []state.BondInfo{{Name: "bond0"}},
+		expect: `bond "bond0" with no devices not valid`,
+	}, {
+		about:  "invalid device name",
+		bonds:  []state.BondInfo{{Name: "bond0", DeviceNames: []string{"eth 0"}}},
+		expect: `bond "bond0" device name "eth 0" not valid`,
+	}, {
+		about: "bond of bonds",
+		bonds: []state.BondInfo{
+			{Name: "bond0", DeviceNames: []string{"eth0"}},
+			{Name: "bond1", DeviceNames: []string{"bond0"}},
+		},
+		expect: `bond "bond1" containing bond "bond0" not valid`,
+	}, {
+		about: "device in two bonds",
+		bonds: []state.BondInfo{
+			{Name: "bond0", DeviceNames: []string{"eth0", "eth1"}},
+			{Name: "bond1", DeviceNames: []string{"eth1"}},
+		},
+		expect: `bond "bond1" reusing device "eth1" not valid`,
+	}} {
+		c.Logf("test #%d: %s", i, test.about)
+		err := s.machine.SetBonds(test.bonds...)
+		c.Check(err, gc.ErrorMatches, `cannot set bonds of machine "`+s.machine.Id()+`": `+test.expect)
+		c.Check(errors.Cause(err), jc.Satisfies, errors.IsNotValid)
+	}
+	bonds, err := s.machine.Bonds()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bonds, gc.HasLen, 0)
+}
+
+func (s *MachineBondsSuite) TestSetBondsDeadMachine(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.SetBonds(state.BondInfo{Name: "bond0", DeviceNames: []string{"eth0"}})
+	c.Assert(err, gc.ErrorMatches, `cannot set bonds of machine "`+s.machine.Id()+`": machine not found or not alive`)
+}
+
+func (s *MachineBondsSuite) TestRemoveMachineRemovesBonds(c *gc.C) {
+	err := s.machine.SetBonds(state.BondInfo{Name: "bond0", DeviceNames: []string{"eth0"}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	bonds, err := s.machine.Bonds()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bonds, gc.HasLen, 0)
+}
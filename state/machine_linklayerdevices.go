@@ -158,7 +158,9 @@ type LinkLayerDeviceArgs struct {
 	// ParentName is the name of the parent device, which may be empty. If set,
 	// it needs to be an existing device on the same machine, unless the current
 	// device is inside a container, in which case ParentName can be a global
-	// key of a BridgeDevice on the host machine of the container. Traffic
+	// key of a BridgeDevice on the host machine of the container, or of an
+	// EthernetDevice, VLAN_8021QDevice or BondDevice when the container's
+	// device is attached directly to it (e.g. with macvlan). Traffic
 	// originating from a device egresses from its parent device.
 	ParentName string
 }
@@ -343,7 +345,7 @@ func (m *Machine) validateLinkLayerDeviceParent(args *LinkLayerDeviceArgs) error
 		return errors.NotValidf("ParentName %q on non-host machine %q", args.ParentName, hostMachineID)
 	}
 
-	err = m.verifyHostMachineParentDeviceExistsAndIsABridgeDevice(hostMachineID, parentDeviceName)
+	err = m.verifyHostMachineParentDeviceExistsAndCanBeAContainerParent(hostMachineID, parentDeviceName)
 	return errors.Trace(err)
 }
 
@@ -357,7 +359,25 @@ func parseLinkLayerDeviceParentNameAsGlobalKey(parentName string) (hostMachineID
 	return hostMachineID, parentDeviceName, nil
 }
 
-func (m *Machine) verifyHostMachineParentDeviceExistsAndIsABridgeDevice(hostMachineID, parentDeviceName string) error {
+// containerParentDeviceTypes holds the types of host machine devices that a
+// container device can have as its parent.
+var containerParentDeviceTypes = []LinkLayerDeviceType{
+	BridgeDevice,
+	EthernetDevice,
+	VLAN_8021QDevice,
+	BondDevice,
+}
+
+func isContainerParentDeviceType(deviceType LinkLayerDeviceType) bool {
+	for _, t := range containerParentDeviceTypes {
+		if t == deviceType {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Machine) verifyHostMachineParentDeviceExistsAndCanBeAContainerParent(hostMachineID, parentDeviceName string) error {
 	hostMachine, err := m.st.Machine(hostMachineID)
 	if errors.IsNotFound(err) || err == nil && hostMachine.Life() != Alive {
 		return errors.Errorf("host machine %q of parent device %q not found or not alive", hostMachineID, parentDeviceName)
@@ -372,10 +392,11 @@ func (m *Machine) verifyHostMachineParentDeviceExistsAndIsABridgeDevice(hostMach
 		return errors.Trace(err)
 	}
 
-	if parentDevice.Type() != BridgeDevice {
+	if !isContainerParentDeviceType(parentDevice.Type()) {
 		errorMessage := fmt.Sprintf(
-			"parent device %q on host machine %q must be of type %q, not type %q",
-			parentDeviceName, hostMachineID, BridgeDevice, parentDevice.Type(),
+			"parent device %q on host machine %q must be of type %q, %q, %q or %q, not type %q",
+			parentDeviceName, hostMachineID,
+			BridgeDevice, EthernetDevice, VLAN_8021QDevice, BondDevice, parentDevice.Type(),
 		)
 		return errors.NewNotValid(nil, errorMessage)
 	}
@@ -1102,6 +1123,27 @@ func DefineEthernetDeviceOnBridge(name string, hostBridge *LinkLayerDevice) (Lin
 	}, nil
 }
 
+// DefineEthernetDeviceOnParent returns the arguments for an EthernetDevice in
+// a container that is attached directly to the given host device, rather
+// than to a bridge on the host (e.g. with macvlan, ipvlan or SR-IOV).
+func DefineEthernetDeviceOnParent(name string, hostDevice *LinkLayerDevice) (LinkLayerDeviceArgs, error) {
+	switch hostDevice.Type() {
+	case EthernetDevice, VLAN_8021QDevice, BondDevice:
+	default:
+		return LinkLayerDeviceArgs{}, errors.Errorf(
+			"hostDevice must be an Ethernet, VLAN or Bond Device not %q", hostDevice.Type())
+	}
+	return LinkLayerDeviceArgs{
+		Name:        name,
+		Type:        EthernetDevice,
+		MACAddress:  network.GenerateVirtualMACAddress(),
+		MTU:         hostDevice.MTU(),
+		IsUp:        true,
+		IsAutoStart: true,
+		ParentName:  hostDevice.globalKey(),
+	}, nil
+}
+
 // MachineNetworkInfoResult contains an error or a list of NetworkInfo structures for a specific space.
 type MachineNetworkInfoResult struct {
 	NetworkInfos []network.NetworkInfo
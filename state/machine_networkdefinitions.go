@@ -0,0 +1,170 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/network"
+)
+
+// NetworkDefinitions holds the bonds, VLANs and routes which the machine
+// agent should configure on a machine, derived from the machine's bonds
+// and the subnets of the spaces it needs.
+type NetworkDefinitions struct {
+	// Bonds are the bonds defined for the machine.
+	Bonds []BondInfo
+
+	// VLANs are the VLAN devices needed to reach tagged subnets in the
+	// machine's desired spaces, which the machine has no address in.
+	VLANs []VLANInfo
+
+	// Routes are the static routes of the subnets the machine has
+	// addresses in, or will have a VLAN device in, per device.
+	Routes []DeviceRoutes
+}
+
+// VLANInfo describes a VLAN device which should be created on top of an
+// existing device of a machine.
+type VLANInfo struct {
+	// Name is the name of the VLAN device, e.g. "eth0.42".
+	Name string
+
+	// ParentName is the name of the device the VLAN is created on.
+	ParentName string
+
+	// ParentMACAddress is the MAC address of the parent device.
+	ParentMACAddress string
+
+	// VLANTag is the 802.1Q tag of the VLAN.
+	VLANTag int
+}
+
+// DeviceRoutes holds the static routes which should be configured on a
+// single device of a machine.
+type DeviceRoutes struct {
+	// DeviceName is the name of the device.
+	DeviceName string
+
+	// MACAddress is the MAC address of the device, if known.
+	MACAddress string
+
+	// Routes are the routes to configure.
+	Routes []network.Route
+}
+
+// NetworkDefinitions returns the bonds, VLANs and routes which should be
+// configured on the machine.
+//
+// A VLAN is defined for each tagged subnet in one of the machine's
+// desired spaces which the machine has no address in. It is created on
+// the device holding the machine's address in an untagged subnet of the
+// same space; if there is no such device the subnet is skipped.
+func (m *Machine) NetworkDefinitions() (_ NetworkDefinitions, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot get network definitions of machine %q", m.Id())
+
+	var result NetworkDefinitions
+	if result.Bonds, err = m.Bonds(); err != nil {
+		return NetworkDefinitions{}, errors.Trace(err)
+	}
+
+	subnets, err := m.st.AllSubnets()
+	if err != nil {
+		return NetworkDefinitions{}, errors.Trace(err)
+	}
+	sort.Slice(subnets, func(i, j int) bool {
+		return subnets[i].CIDR() < subnets[j].CIDR()
+	})
+	subnetsByCIDR := make(map[string]*Subnet)
+	for _, subnet := range subnets {
+		subnetsByCIDR[subnet.CIDR()] = subnet
+	}
+
+	devices, err := m.AllLinkLayerDevices()
+	if err != nil {
+		return NetworkDefinitions{}, errors.Trace(err)
+	}
+	devicesByName := make(map[string]*LinkLayerDevice)
+	for _, device := range devices {
+		devicesByName[device.Name()] = device
+	}
+
+	addresses, err := m.AllAddresses()
+	if err != nil {
+		return NetworkDefinitions{}, errors.Trace(err)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].DeviceName() < addresses[j].DeviceName()
+	})
+
+	// Routes for the subnets the machine already has addresses in, and
+	// the devices which can carry VLANs for each space.
+	addressSubnets := set.NewStrings()
+	routedDevices := set.NewStrings()
+	parentsBySpace := make(map[string]*LinkLayerDevice)
+	for _, addr := range addresses {
+		subnet, ok := subnetsByCIDR[addr.SubnetCIDR()]
+		if !ok {
+			continue
+		}
+		addressSubnets.Add(subnet.CIDR())
+		device, ok := devicesByName[addr.DeviceName()]
+		if !ok || device.IsLoopbackDevice() {
+			continue
+		}
+		if routes := subnet.Routes(); len(routes) > 0 && !routedDevices.Contains(device.Name()) {
+			routedDevices.Add(device.Name())
+			result.Routes = append(result.Routes, DeviceRoutes{
+				DeviceName: device.Name(),
+				MACAddress: device.MACAddress(),
+				Routes:     routes,
+			})
+		}
+		space := subnet.SpaceName()
+		if _, ok := parentsBySpace[space]; !ok && space != "" && subnet.VLANTag() == 0 {
+			parentsBySpace[space] = device
+		}
+	}
+
+	spaces, err := m.DesiredSpaces()
+	if err != nil {
+		return NetworkDefinitions{}, errors.Trace(err)
+	}
+	vlanNames := set.NewStrings()
+	for _, subnet := range subnets {
+		tag := subnet.VLANTag()
+		if tag == 0 || !spaces.Contains(subnet.SpaceName()) || addressSubnets.Contains(subnet.CIDR()) {
+			continue
+		}
+		parent, ok := parentsBySpace[subnet.SpaceName()]
+		if !ok {
+			logger.Debugf(
+				"machine %q has no device in space %q for VLAN %d of subnet %q",
+				m.Id(), subnet.SpaceName(), tag, subnet.CIDR(),
+			)
+			continue
+		}
+		name := fmt.Sprintf("%s.%d", parent.Name(), tag)
+		if !vlanNames.Contains(name) {
+			vlanNames.Add(name)
+			result.VLANs = append(result.VLANs, VLANInfo{
+				Name:             name,
+				ParentName:       parent.Name(),
+				ParentMACAddress: parent.MACAddress(),
+				VLANTag:          tag,
+			})
+		}
+		if routes := subnet.Routes(); len(routes) > 0 {
+			result.Routes = append(result.Routes, DeviceRoutes{
+				DeviceName: name,
+				Routes:     routes,
+			})
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type MachineNetworkDefinitionsSuite struct {
+	ConnSuite
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&MachineNetworkDefinitionsSuite{})
+
+func (s *MachineNetworkDefinitionsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("admin", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.machine, err = s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.MustParse("spaces=db"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.SetLinkLayerDevices(
+		state.LinkLayerDeviceArgs{Name: "lo", Type: state.LoopbackDevice},
+		state.LinkLayerDeviceArgs{Name: "eth0", Type: state.EthernetDevice, MACAddress: "aa:bb:cc:dd:ee:f0"},
+		state.LinkLayerDeviceArgs{Name: "eth1", Type: state.EthernetDevice, MACAddress: "aa:bb:cc:dd:ee:f1"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MachineNetworkDefinitionsSuite) addSubnet(c *gc.C, info state.SubnetInfo) {
+	_, err := s.State.AddSubnet(info)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MachineNetworkDefinitionsSuite) setAddresses(c *gc.C, addresses ...state.LinkLayerDeviceAddress) {
+	for i := range addresses {
+		addresses[i].ConfigMethod = state.StaticAddress
+	}
+	err := s.machine.SetDevicesAddresses(addresses...)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MachineNetworkDefinitionsSuite) TestNetworkDefinitionsNone(c *gc.C) {
+	defs, err := s.machine.NetworkDefinitions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(defs, jc.DeepEquals, state.NetworkDefinitions{})
+}
+
+func (s *MachineNetworkDefinitionsSuite) TestNetworkDefinitions(c *gc.C) {
+	dbRoutes := []network.Route{{DestinationCIDR: "10.100.0.0/16", GatewayIP: "10.0.0.254", Metric: 10}}
+	vlanRoutes := []network.Route{{DestinationCIDR: "10.200.0.0/16", GatewayIP: "10.0.42.254"}}
+	adminRoutes := []network.Route{{DestinationCIDR: "10.50.0.0/16", GatewayIP: "10.1.0.254"}}
+	s.addSubnet(c, state.SubnetInfo{CIDR: "10.0.0.0/24", SpaceName: "db", Routes: dbRoutes})
+	s.addSubnet(c, state.SubnetInfo{CIDR: "10.0.42.0/24", SpaceName: "db", VLANTag: 42, Routes: vlanRoutes})
+	s.addSubnet(c, state.SubnetInfo{CIDR: "10.0.43.0/24", SpaceName: "db", VLANTag: 43})
+	s.addSubnet(c, state.SubnetInfo{CIDR: "10.1.0.0/24", SpaceName: "admin", Routes: adminRoutes})
+	s.addSubnet(c, state.SubnetInfo{CIDR: "10.1.44.0/24", SpaceName: "admin", VLANTag: 44})
+	s.addSubnet(c, state.SubnetInfo{CIDR: "127.0.0.0/8", Routes: dbRoutes})
+	s.setAddresses(c,
+		state.LinkLayerDeviceAddress{DeviceName: "lo", CIDRAddress: "127.0.0.1/8"},
+		state.LinkLayerDeviceAddress{DeviceName: "eth0", CIDRAddress: "10.0.0.5/24"},
+		state.LinkLayerDeviceAddress{DeviceName: "eth1", CIDRAddress: "10.1.0.5/24"},
+	)
+	bond := state.BondInfo{Name: "bond0", DeviceNames: []string{"eth2", "eth3"}, Mode: "active-backup"}
+	err := s.machine.SetBonds(bond)
+	c.Assert(err, jc.ErrorIsNil)
+
+	defs, err := s.machine.NetworkDefinitions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(defs, jc.DeepEquals, state.NetworkDefinitions{
+		Bonds: []state.BondInfo{bond},
+		VLANs: []state.VLANInfo{{
+			Name:             "eth0.42",
+			ParentName:       "eth0",
+			ParentMACAddress: "aa:bb:cc:dd:ee:f0",
+			VLANTag:          42,
+		}, {
+			Name:             "eth0.43",
+			ParentName:       "eth0",
+			ParentMACAddress: "aa:bb:cc:dd:ee:f0",
+			VLANTag:          43,
+		}},
+		Routes: []state.DeviceRoutes{{
+			DeviceName: "eth0",
+			MACAddress: "aa:bb:cc:dd:ee:f0",
+			Routes:     dbRoutes,
+		}, {
+			DeviceName: "eth1",
+			MACAddress: "aa:bb:cc:dd:ee:f1",
+			Routes:     adminRoutes,
+		}, {
+			DeviceName: "eth0.42",
+			Routes:     vlanRoutes,
+		}},
+	})
+}
+
+func (s *MachineNetworkDefinitionsSuite) TestNetworkDefinitionsSkipsVLANsWithoutParent(c *gc.C) {
+	s.addSubnet(c, state.SubnetInfo{CIDR: "10.0.42.0/24", SpaceName: "db", VLANTag: 42})
+
+	defs, err := s.machine.NetworkDefinitions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(defs.VLANs, gc.HasLen, 0)
+}
+
+func (s *MachineNetworkDefinitionsSuite) TestWatchBonds(c *gc.C) {
+	w := s.machine.WatchBonds()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.machine.SetBonds(state.BondInfo{Name: "bond0", DeviceNames: []string{"eth0"}})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.machine.SetBonds()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	testing.AssertStop(c, w)
+	wc.AssertClosed()
+}
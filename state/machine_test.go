@@ -349,6 +349,68 @@ func (s *MachineSuite) TestLifeJobHostUnits(c *gc.C) {
 	c.Assert(m.Life(), gc.Equals, state.Dead)
 }
 
+func (s *MachineSuite) TestReplace(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.Replace()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Life(), gc.Equals, state.Dying)
+
+	// Replacing the machine again does nothing.
+	err = s.machine.Replace()
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+
+	// The replacement is staged for assignment by the unit assigner.
+	results, err := s.State.AssignStagedUnits([]string{"wordpress/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []state.UnitAssignmentResult{{Unit: "wordpress/1"}})
+	replacement, err := s.State.Unit("wordpress/1")
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := replacement.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Not(gc.Equals), s.machine.Id())
+	c.Assert(machineId, gc.Not(gc.Equals), s.machine0.Id())
+
+	// The force-destroy cleanup removes the original unit and leaves
+	// the machine dead, for the provisioner to remove.
+	assertCleanupRuns(c, s.State)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Life(), gc.Equals, state.Dead)
+	err = unit.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MachineSuite) TestReplaceNotAlive(c *gc.C) {
+	err := s.machine.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Replace()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Life(), gc.Equals, state.Dying)
+}
+
+func (s *MachineSuite) TestReplaceNotSupported(c *gc.C) {
+	err := s.machine0.Replace()
+	c.Assert(err, gc.ErrorMatches, "cannot replace machine 0: replacing controller machine not supported")
+
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.machine.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	err = container.Replace()
+	c.Assert(err, gc.ErrorMatches, "cannot replace machine 1/lxd/0: replacing container not supported")
+}
+
 func (s *MachineSuite) TestDestroyRemovePorts(c *gc.C) {
 	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
 	unit, err := app.AddUnit(state.AddUnitParams{})
@@ -1882,6 +1944,73 @@ func (s *MachineSuite) TestPrivateAddressEmptyAddresses(c *gc.C) {
 	c.Assert(addr.Value, gc.Equals, "")
 }
 
+func (s *MachineSuite) TestPreferredAddressesPreferIPv6(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	addresses := network.NewAddresses("10.0.0.1", "fc00::1", "8.8.8.8", "2001:db8::1")
+	err = machine.SetProviderAddresses(addresses...)
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err := machine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addr.Value, gc.Equals, "8.8.8.8")
+
+	err = s.Model.UpdateModelConfig(map[string]interface{}{"preferred-ipv6": true}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProviderAddresses(addresses...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr, err = machine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addr.Value, gc.Equals, "2001:db8::1")
+	addr, err = machine.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addr.Value, gc.Equals, "fc00::1")
+}
+
+func (s *MachineSuite) TestPreferredAddressesFollowPreferIPv6Changes(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProviderAddresses(network.NewAddresses("10.0.0.1", "fc00::1", "8.8.8.8", "2001:db8::1")...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	assertPreferred := func(public, private string) {
+		err := machine.Refresh()
+		c.Assert(err, jc.ErrorIsNil)
+		addr, err := machine.PublicAddress()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(addr.Value, gc.Equals, public)
+		addr, err = machine.PrivateAddress()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(addr.Value, gc.Equals, private)
+	}
+	assertPreferred("8.8.8.8", "10.0.0.1")
+
+	err = s.Model.UpdateModelConfig(map[string]interface{}{"preferred-ipv6": true}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	assertPreferred("2001:db8::1", "fc00::1")
+
+	err = s.Model.UpdateModelConfig(map[string]interface{}{"preferred-ipv6": false}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	assertPreferred("8.8.8.8", "10.0.0.1")
+}
+
+func (s *MachineSuite) TestPreferredAddressesPreferIPv6IPv4Only(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"preferred-ipv6": true}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetProviderAddresses(network.NewAddresses("10.0.0.1", "8.8.8.8")...)
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err := machine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addr.Value, gc.Equals, "8.8.8.8")
+	addr, err = machine.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addr.Value, gc.Equals, "10.0.0.1")
+}
+
 func (s *MachineSuite) TestPublicAddress(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
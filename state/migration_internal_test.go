@@ -136,6 +136,9 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		migrationsStatusC,
 		migrationsActiveC,
 		migrationsMinionSyncC,
+		// Offer redirects are recorded by the controller which a model
+		// has been migrated away from, for offers it no longer hosts.
+		offerRedirectsC,
 
 		// The container ref document is primarily there to keep track
 		// of a particular machine's containers. The migration format
@@ -199,11 +202,16 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		remoteApplicationsC,
 		applicationOffersC,
 		offerConnectionsC,
+		offerShareTokensC,
 		remoteEntitiesC,
 		externalControllersC,
 		relationNetworksC,
+		remoteRelationHealthC,
 		firewallRulesC,
 		dockerResourcesC,
+		imagePoliciesC,
+		// TODO(network) the description package has no bonds yet.
+		machineBondsC,
 		// TODO(raftlease)
 		// This collection shouldn't be migrated, but we need to make
 		// sure the leader units' leases are claimed in the target
@@ -250,6 +258,10 @@ func (s *MigrationSuite) TestModelDocFields(c *gc.C) {
 		"SLA",
 		"MeterStatus",
 		"EnvironVersion",
+		// TODO(dns) the DNS TSIG key is not yet migrated, as the
+		// description package has nowhere to hold it; it must be set
+		// again on the migrated model.
+		"DNSTSIGKey",
 	)
 	s.AssertExportedFields(c, modelDoc{}, fields)
 }
@@ -379,6 +391,9 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		"RelationCount",
 		// TODO(caas)
 		"DesiredScale",
+		// TODO(expose) not yet supported by the description package;
+		// exported applications are exposed to everywhere.
+		"ExposedEndpoints",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -545,10 +560,21 @@ func (s *MigrationSuite) TestSequenceDocFields(c *gc.C) {
 }
 
 func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
-	fields := set.NewStrings(
+	ignored := set.NewStrings(
 		// ModelUUID shouldn't be exported, and is inherited
 		// from the model definition.
 		"ModelUUID",
+		// TODO(placement) the description package has no
+		// placement constraints yet.
+		"Zones",
+		"Affinity",
+		"AntiAffinity",
+		// TODO(spot) the description package has no
+		// spot constraints yet.
+		"Spot",
+		"MaxPrice",
+	)
+	migrated := set.NewStrings(
 		"Arch",
 		"CpuCores",
 		"CpuPower",
@@ -560,7 +586,7 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"Spaces",
 		"VirtType",
 	)
-	s.AssertExportedFields(c, constraintsDoc{}, fields)
+	s.AssertExportedFields(c, constraintsDoc{}, migrated.Union(ignored))
 }
 
 func (s *MigrationSuite) TestHistoricalStatusDocFields(c *gc.C) {
@@ -630,6 +656,10 @@ func (s *MigrationSuite) TestSubnetDocFields(c *gc.C) {
 
 		// Currently unused (never set or exposed).
 		"IsPublic",
+
+		// TODO(network) the description package has no
+		// subnet routes yet.
+		"Routes",
 	)
 	migrated := set.NewStrings(
 		"CIDR",
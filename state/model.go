@@ -112,6 +112,11 @@ type modelDoc struct {
 
 	// MeterStatus is the current meter status of the model.
 	MeterStatus modelMeterStatusdoc `bson:"meter-status"`
+
+	// DNSTSIGKey is the TSIG key used to sign dynamic DNS updates for
+	// the rfc2136 DNS backend. It is kept out of the model config, so
+	// that it is only seen by the controller.
+	DNSTSIGKey string `bson:"dns-tsig-key,omitempty"`
 }
 
 // slaLevel enumerates the support levels available to a model.
@@ -753,6 +758,33 @@ func (m *Model) SetSLA(level, owner string, credentials []byte) error {
 	return m.Refresh()
 }
 
+// DNSTSIGKey returns the TSIG key, in the form "<name>:<base64 secret>",
+// used to sign dynamic DNS updates for the model's records, or "" if
+// there is none.
+func (m *Model) DNSTSIGKey() string {
+	return m.doc.DNSTSIGKey
+}
+
+// SetDNSTSIGKey sets the TSIG key used to sign dynamic DNS updates for
+// the model's records. An empty key removes it.
+func (m *Model) SetDNSTSIGKey(key string) error {
+	var update bson.D
+	if key == "" {
+		update = bson.D{{"$unset", bson.D{{"dns-tsig-key", 1}}}}
+	} else {
+		update = bson.D{{"$set", bson.D{{"dns-tsig-key", key}}}}
+	}
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     m.doc.UUID,
+		Update: update,
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	return m.Refresh()
+}
+
 // SetMeterStatus sets the current meter status for this model.
 func (m *Model) SetMeterStatus(status, info string) error {
 	if _, err := isValidMeterStatusCode(status); err != nil {
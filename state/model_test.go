@@ -321,6 +321,28 @@ func (s *ModelSuite) TestSLA(c *gc.C) {
 	c.Assert(slaCreds, gc.DeepEquals, []byte("auth advanced"))
 }
 
+func (s *ModelSuite) TestDNSTSIGKey(c *gc.C) {
+	c.Assert(s.Model.DNSTSIGKey(), gc.Equals, "")
+
+	err := s.Model.SetDNSTSIGKey("juju-key:c2VjcmV0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.Model.DNSTSIGKey(), gc.Equals, "juju-key:c2VjcmV0")
+
+	// The key is not part of the model config.
+	cfg, err := s.Model.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := cfg.AllAttrs()["dns-rfc2136-tsig-key"]
+	c.Assert(ok, jc.IsFalse)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.DNSTSIGKey(), gc.Equals, "juju-key:c2VjcmV0")
+
+	err = model.SetDNSTSIGKey("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.DNSTSIGKey(), gc.Equals, "")
+}
+
 func (s *ModelSuite) TestMeterStatus(c *gc.C) {
 	cfg, _ := s.createTestModelConfig(c)
 	owner := names.NewUserTag("test@remote")
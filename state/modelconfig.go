@@ -344,7 +344,13 @@ func (m *Model) UpdateModelConfig(updateAttrs map[string]interface{}, removeAttr
 
 	modelSettings.Update(validAttrs)
 	_, ops := modelSettings.settingsUpdateOps()
-	return modelSettings.write(ops)
+	if err := modelSettings.write(ops); err != nil {
+		return errors.Trace(err)
+	}
+	if validCfg.PreferredIPv6() != oldConfig.PreferredIPv6() {
+		return errors.Trace(st.updatePreferredAddresses(validCfg.PreferredIPv6()))
+	}
+	return nil
 }
 
 type modelConfigSourceFunc func() (attrValues, error)
@@ -307,6 +307,20 @@ func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 		return errors.Trace(err)
 	}
 
+	// Once the target controller has taken over the model, consumers
+	// of the model's offers are redirected to it.
+	if nextPhase == migration.SUCCESS {
+		targetInfo, err := mig.TargetInfo()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		redirectOps, err := offerRedirectOps(mig.st, targetInfo)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ops = append(ops, redirectOps...)
+	}
+
 	// If the migration aborted, make the model active again.
 	if nextPhase == migration.ABORTDONE {
 		ops = append(ops, txn.Op{
@@ -0,0 +1,150 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/migration"
+)
+
+// offerRedirectDoc records the controller an offer has moved to, after
+// the model hosting the offer was migrated away from this controller.
+type offerRedirectDoc struct {
+	// DocID is the UUID of the offer, which is preserved by migration.
+	DocID           string   `bson:"_id"`
+	OfferName       string   `bson:"offer-name"`
+	SourceModelUUID string   `bson:"source-model-uuid"`
+	ControllerUUID  string   `bson:"controller-uuid"`
+	Addrs           []string `bson:"addresses"`
+	CACert          string   `bson:"cacert"`
+}
+
+// OfferRedirect describes where an offer which used to be hosted on
+// this controller has moved to.
+type OfferRedirect struct {
+	// OfferUUID is the UUID of the offer.
+	OfferUUID string
+
+	// OfferName is the name of the offer.
+	OfferName string
+
+	// ModelUUID is the UUID of the model hosting the offer.
+	ModelUUID string
+
+	// Controller holds the details of the controller now hosting
+	// the offer's model.
+	Controller crossmodel.ControllerInfo
+}
+
+// OfferRedirect returns where the offer with the specified UUID has moved
+// to, if the model hosting the offer has been migrated away from this
+// controller. A NotFound error is returned if the offer hasn't moved, or
+// if its model has since been migrated back to this controller.
+func (st *State) OfferRedirect(offerUUID string) (*OfferRedirect, error) {
+	redirects, closer := st.db().GetCollection(offerRedirectsC)
+	defer closer()
+
+	var doc offerRedirectDoc
+	err := redirects.FindId(offerUUID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("redirect for offer %q", offerUUID)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "reading redirect for offer %q", offerUUID)
+	}
+
+	// A model which has come back is no longer redirected.
+	models, closer := st.db().GetCollection(modelsC)
+	defer closer()
+	var model modelDoc
+	err = models.FindId(doc.SourceModelUUID).Select(bson.D{{"migration-mode", 1}}).One(&model)
+	if err == nil && model.MigrationMode == MigrationModeNone {
+		return nil, errors.NotFoundf("redirect for offer %q", offerUUID)
+	} else if err != nil && err != mgo.ErrNotFound {
+		return nil, errors.Annotatef(err, "reading model %q", doc.SourceModelUUID)
+	}
+
+	return &OfferRedirect{
+		OfferUUID: doc.DocID,
+		OfferName: doc.OfferName,
+		ModelUUID: doc.SourceModelUUID,
+		Controller: crossmodel.ControllerInfo{
+			ControllerTag: names.NewControllerTag(doc.ControllerUUID),
+			Addrs:         doc.Addrs,
+			CACert:        doc.CACert,
+		},
+	}, nil
+}
+
+// offerRedirectOps returns the operations which record that the offers
+// hosted in the model being migrated, and the model itself, have moved to
+// the target controller.
+func offerRedirectOps(st *State, target *migration.TargetInfo) ([]txn.Op, error) {
+	offers, closer := st.db().GetCollection(applicationOffersC)
+	defer closer()
+	var offerDocs []applicationOfferDoc
+	if err := offers.Find(nil).All(&offerDocs); err != nil {
+		return nil, errors.Annotate(err, "reading application offers")
+	}
+	if len(offerDocs) == 0 {
+		return nil, nil
+	}
+
+	// Consumers hosted on this controller find the model's new home
+	// the same way as for any other external model.
+	ops, err := NewExternalControllers(st).modelMovedToControllerOps(crossmodel.ControllerInfo{
+		ControllerTag: target.ControllerTag,
+		Addrs:         target.Addrs,
+		CACert:        target.CACert,
+	}, st.ModelUUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	redirects, closer := st.db().GetCollection(offerRedirectsC)
+	defer closer()
+	for _, offer := range offerDocs {
+		doc := offerRedirectDoc{
+			DocID:           offer.OfferUUID,
+			OfferName:       offer.OfferName,
+			SourceModelUUID: st.ModelUUID(),
+			ControllerUUID:  target.ControllerTag.Id(),
+			Addrs:           target.Addrs,
+			CACert:          target.CACert,
+		}
+		// The model may have been migrated away from this controller
+		// before, in which case the redirect is updated.
+		n, err := redirects.FindId(doc.DocID).Count()
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading redirect for offer %q", doc.DocID)
+		}
+		if n == 0 {
+			ops = append(ops, txn.Op{
+				C:      offerRedirectsC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			})
+			continue
+		}
+		ops = append(ops, txn.Op{
+			C:      offerRedirectsC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"offer-name", doc.OfferName},
+				{"source-model-uuid", doc.SourceModelUUID},
+				{"controller-uuid", doc.ControllerUUID},
+				{"addresses", doc.Addrs},
+				{"cacert", doc.CACert},
+			}}},
+		})
+	}
+	return ops, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type offerRedirectsSuite struct {
+	ConnSuite
+	st2       *state.State
+	offerUUID string
+	spec      state.MigrationSpec
+}
+
+var _ = gc.Suite(&offerRedirectsSuite{})
+
+func (s *offerRedirectsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.st2 = s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { s.st2.Close() })
+
+	f := factory.NewFactory(s.st2)
+	f.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: f.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	offer, err := state.NewApplicationOffers(s.st2).AddOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"server": "server"},
+		Owner:           s.Owner.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.offerUUID = offer.OfferUUID
+
+	s.spec = state.MigrationSpec{
+		InitiatedBy: names.NewUserTag("admin"),
+		TargetInfo: migration.TargetInfo{
+			ControllerTag: names.NewControllerTag(utils.MustNewUUID().String()),
+			Addrs:         []string{"1.2.3.4:5555", "4.3.2.1:6666"},
+			CACert:        "cert",
+			AuthTag:       names.NewUserTag("user"),
+			Password:      "password",
+		},
+	}
+}
+
+func (s *offerRedirectsSuite) migrate(c *gc.C, phases ...migration.Phase) {
+	mig, err := s.st2.CreateMigration(s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, phase := range phases {
+		err := mig.SetPhase(phase)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *offerRedirectsSuite) TestNoRedirect(c *gc.C) {
+	_, err := s.State.OfferRedirect(s.offerUUID)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `redirect for offer ".*" not found`)
+}
+
+func (s *offerRedirectsSuite) TestRedirectNotRecordedBeforeSuccess(c *gc.C) {
+	s.migrate(c, migration.IMPORT, migration.VALIDATION)
+	_, err := s.State.OfferRedirect(s.offerUUID)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *offerRedirectsSuite) TestRedirectNotRecordedOnAbort(c *gc.C) {
+	s.migrate(c, migration.IMPORT, migration.ABORT, migration.ABORTDONE)
+	_, err := s.State.OfferRedirect(s.offerUUID)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *offerRedirectsSuite) TestRedirectRecordedOnSuccess(c *gc.C) {
+	s.migrate(c, migration.IMPORT, migration.VALIDATION, migration.SUCCESS)
+	redirect, err := s.State.OfferRedirect(s.offerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(redirect, jc.DeepEquals, &state.OfferRedirect{
+		OfferUUID: s.offerUUID,
+		OfferName: "hosted-mysql",
+		ModelUUID: s.st2.ModelUUID(),
+		Controller: crossmodel.ControllerInfo{
+			ControllerTag: s.spec.TargetInfo.ControllerTag,
+			Addrs:         []string{"1.2.3.4:5555", "4.3.2.1:6666"},
+			CACert:        "cert",
+		},
+	})
+}
+
+func (s *offerRedirectsSuite) TestRedirectRecordsExternalController(c *gc.C) {
+	s.migrate(c, migration.IMPORT, migration.VALIDATION, migration.SUCCESS)
+	ec, err := state.NewExternalControllers(s.State).ControllerForModel(s.st2.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ec.ControllerInfo(), jc.DeepEquals, crossmodel.ControllerInfo{
+		ControllerTag: s.spec.TargetInfo.ControllerTag,
+		Addrs:         []string{"1.2.3.4:5555", "4.3.2.1:6666"},
+		CACert:        "cert",
+	})
+}
+
+func (s *offerRedirectsSuite) TestRedirectOutlivesModel(c *gc.C) {
+	s.migrate(c, migration.IMPORT, migration.VALIDATION, migration.SUCCESS,
+		migration.LOGTRANSFER, migration.REAP)
+	model, err := s.st2.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.st2.RemoveExportingModelDocs()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	redirect, err := s.State.OfferRedirect(s.offerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(redirect.Controller.ControllerTag, gc.Equals, s.spec.TargetInfo.ControllerTag)
+}
@@ -0,0 +1,224 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// OfferShareToken represents a time-limited token granting consume
+// access to an offer hosted in this model.
+type OfferShareToken struct {
+	st  *State
+	doc offerShareTokenDoc
+}
+
+// offerShareTokenDoc represents the internal state of an offer share
+// token in MongoDB.
+type offerShareTokenDoc struct {
+	DocID     string    `bson:"_id"`
+	OfferUUID string    `bson:"offer-uuid"`
+	CreatedBy string    `bson:"created-by"`
+	Created   time.Time `bson:"created"`
+	Expires   time.Time `bson:"expires"`
+}
+
+func newOfferShareToken(st *State, doc *offerShareTokenDoc) *OfferShareToken {
+	return &OfferShareToken{
+		st:  st,
+		doc: *doc,
+	}
+}
+
+// Id returns the id of the token.
+func (t *OfferShareToken) Id() string {
+	return t.st.localID(t.doc.DocID)
+}
+
+// OfferUUID returns the UUID of the offer the token grants access to.
+func (t *OfferShareToken) OfferUUID() string {
+	return t.doc.OfferUUID
+}
+
+// CreatedBy returns the name of the user who created the token.
+func (t *OfferShareToken) CreatedBy() string {
+	return t.doc.CreatedBy
+}
+
+// Created returns the time the token was created.
+func (t *OfferShareToken) Created() time.Time {
+	return t.doc.Created
+}
+
+// Expires returns the time after which the token is no longer valid.
+func (t *OfferShareToken) Expires() time.Time {
+	return t.doc.Expires
+}
+
+// String returns the details of the token.
+func (t *OfferShareToken) String() string {
+	return fmt.Sprintf("share token %q for offer %q", t.Id(), t.doc.OfferUUID)
+}
+
+// AddOfferShareTokenParams contains the parameters for adding an offer
+// share token to the model.
+type AddOfferShareTokenParams struct {
+	// OfferUUID is the UUID of the offer.
+	OfferUUID string
+
+	// CreatedBy is the name of the user creating the token.
+	CreatedBy string
+
+	// Expires is the time after which the token is no longer valid.
+	Expires time.Time
+}
+
+func validateOfferShareTokenParams(args AddOfferShareTokenParams) error {
+	if args.OfferUUID == "" {
+		return errors.NotValidf("empty offer UUID")
+	}
+	if !names.IsValidUser(args.CreatedBy) {
+		return errors.NotValidf("share token user %q", args.CreatedBy)
+	}
+	if args.Expires.IsZero() {
+		return errors.NotValidf("share token without expiry")
+	}
+	return nil
+}
+
+// AddOfferShareToken creates a new share token for an offer in the model.
+func (st *State) AddOfferShareToken(args AddOfferShareTokenParams) (_ *OfferShareToken, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add share token for offer %q", args.OfferUUID)
+
+	if err := validateOfferShareTokenParams(args); err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	} else if model.Life() != Alive {
+		return nil, errors.Errorf("model is no longer alive")
+	}
+	offer, err := NewApplicationOffers(st).ApplicationOfferForUUID(args.OfferUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := offerShareTokenDoc{
+		DocID:     st.docID(id.String()),
+		OfferUUID: args.OfferUUID,
+		CreatedBy: args.CreatedBy,
+		Created:   st.nowToTheSecond(),
+		Expires:   args.Expires.UTC(),
+	}
+	ops := []txn.Op{
+		model.assertActiveOp(),
+		{
+			C:      applicationOffersC,
+			Id:     offer.OfferName,
+			Assert: bson.D{{"offer-uuid", args.OfferUUID}},
+		}, {
+			C:      offerShareTokensC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		},
+	}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		if err := checkModelActive(st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return nil, errors.NotFoundf("application offer %q", args.OfferUUID)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newOfferShareToken(st, &doc), nil
+}
+
+// OfferShareToken returns the share token with the specified id.
+func (st *State) OfferShareToken(id string) (*OfferShareToken, error) {
+	shareTokens, closer := st.db().GetCollection(offerShareTokensC)
+	defer closer()
+
+	var doc offerShareTokenDoc
+	err := shareTokens.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("offer share token %q", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get offer share token %q", id)
+	}
+	return newOfferShareToken(st, &doc), nil
+}
+
+// OfferShareTokens returns the share tokens for an offer, or all the share
+// tokens in the model if offerUUID is empty.
+func (st *State) OfferShareTokens(offerUUID string) ([]*OfferShareToken, error) {
+	shareTokens, closer := st.db().GetCollection(offerShareTokensC)
+	defer closer()
+
+	query := bson.D{}
+	if offerUUID != "" {
+		query = bson.D{{"offer-uuid", offerUUID}}
+	}
+	var docs []offerShareTokenDoc
+	if err := shareTokens.Find(query).Sort("created", "_id").All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get the share tokens for offer %q", offerUUID)
+	}
+	tokens := make([]*OfferShareToken, len(docs))
+	for i := range docs {
+		tokens[i] = newOfferShareToken(st, &docs[i])
+	}
+	return tokens, nil
+}
+
+// RemoveOfferShareToken revokes the share token with the specified id.
+func (st *State) RemoveOfferShareToken(id string) error {
+	ops := []txn.Op{{
+		C:      offerShareTokensC,
+		Id:     st.docID(id),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("offer share token %q", id)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove offer share token %q", id)
+	}
+	return nil
+}
+
+// removeOfferShareTokensOps returns the operations to remove all the share
+// tokens for the specified offer.
+func removeOfferShareTokensOps(st *State, offerUUID string) ([]txn.Op, error) {
+	shareTokens, closer := st.db().GetCollection(offerShareTokensC)
+	defer closer()
+
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	if err := shareTokens.Find(bson.D{{"offer-uuid", offerUUID}}).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "reading share tokens for offer %q", offerUUID)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      offerShareTokensC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/state"
+)
+
+type offerShareTokensSuite struct {
+	ConnSuite
+
+	offer *crossmodel.ApplicationOffer
+}
+
+var _ = gc.Suite(&offerShareTokensSuite{})
+
+func (s *offerShareTokensSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	owner := s.Factory.MakeUser(c, nil)
+	var err error
+	s.offer, err = state.NewApplicationOffers(s.State).AddOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"server": "server"},
+		Owner:           owner.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *offerShareTokensSuite) addToken(c *gc.C, expires time.Time) *state.OfferShareToken {
+	token, err := s.State.AddOfferShareToken(state.AddOfferShareTokenParams{
+		OfferUUID: s.offer.OfferUUID,
+		CreatedBy: "fred",
+		Expires:   expires,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return token
+}
+
+func (s *offerShareTokensSuite) TestAddOfferShareToken(c *gc.C) {
+	expires := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	token := s.addToken(c, expires)
+	c.Assert(token.Id(), gc.Not(gc.Equals), "")
+	c.Assert(token.OfferUUID(), gc.Equals, s.offer.OfferUUID)
+	c.Assert(token.CreatedBy(), gc.Equals, "fred")
+	c.Assert(token.Expires(), gc.Equals, expires)
+
+	got, err := s.State.OfferShareToken(token.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.Id(), gc.Equals, token.Id())
+	c.Assert(got.OfferUUID(), gc.Equals, s.offer.OfferUUID)
+	c.Assert(got.CreatedBy(), gc.Equals, "fred")
+	c.Assert(got.Expires().Equal(expires), jc.IsTrue)
+	c.Assert(got.Created().Equal(token.Created()), jc.IsTrue)
+}
+
+func (s *offerShareTokensSuite) TestAddOfferShareTokenInvalid(c *gc.C) {
+	_, err := s.State.AddOfferShareToken(state.AddOfferShareTokenParams{
+		OfferUUID: s.offer.OfferUUID,
+		CreatedBy: "fred",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add share token for offer ".*": share token without expiry not valid`)
+
+	_, err = s.State.AddOfferShareToken(state.AddOfferShareTokenParams{
+		OfferUUID: s.offer.OfferUUID,
+		CreatedBy: "not/valid",
+		Expires:   time.Now().Add(time.Hour),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add share token for offer ".*": share token user "not/valid" not valid`)
+}
+
+func (s *offerShareTokensSuite) TestAddOfferShareTokenOfferNotFound(c *gc.C) {
+	_, err := s.State.AddOfferShareToken(state.AddOfferShareTokenParams{
+		OfferUUID: "missing-uuid",
+		CreatedBy: "fred",
+		Expires:   time.Now().Add(time.Hour),
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *offerShareTokensSuite) TestOfferShareTokens(c *gc.C) {
+	token1 := s.addToken(c, time.Now().Add(time.Hour))
+	token2 := s.addToken(c, time.Now().Add(2*time.Hour))
+
+	tokens, err := s.State.OfferShareTokens(s.offer.OfferUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	var ids []string
+	for _, token := range tokens {
+		ids = append(ids, token.Id())
+	}
+	c.Assert(ids, jc.SameContents, []string{token1.Id(), token2.Id()})
+
+	all, err := s.State.OfferShareTokens("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+
+	other, err := s.State.OfferShareTokens("other-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(other, gc.HasLen, 0)
+}
+
+func (s *offerShareTokensSuite) TestRemoveOfferShareToken(c *gc.C) {
+	token := s.addToken(c, time.Now().Add(time.Hour))
+	err := s.State.RemoveOfferShareToken(token.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.OfferShareToken(token.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveOfferShareToken(token.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *offerShareTokensSuite) TestRemoveOfferRemovesShareTokens(c *gc.C) {
+	token := s.addToken(c, time.Now().Add(time.Hour))
+	err := state.NewApplicationOffers(s.State).Remove(s.offer.OfferName, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.OfferShareToken(token.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
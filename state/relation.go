@@ -327,6 +327,7 @@ func (r *Relation) removeOps(ignoreApplication string, departingUnitName string)
 		relOp.Assert = bson.D{{"life", Alive}, {"unitcount", 0}}
 	}
 	ops := []txn.Op{relOp}
+	isRemote := false
 	for _, ep := range r.doc.Endpoints {
 		if ep.ApplicationName == ignoreApplication {
 			continue
@@ -336,6 +337,7 @@ func (r *Relation) removeOps(ignoreApplication string, departingUnitName string)
 			return nil, errors.Trace(err)
 		}
 		if app.IsRemote() {
+			isRemote = true
 			epOps, err := r.removeRemoteEndpointOps(ep, departingUnitName != "")
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -356,6 +358,9 @@ func (r *Relation) removeOps(ignoreApplication string, departingUnitName string)
 	ops = append(ops, tokenOps...)
 	offerOps := removeOfferConnectionsForRelationOps(r.Id())
 	ops = append(ops, offerOps...)
+	if isRemote {
+		ops = append(ops, newCleanupOp(cleanupRelationRemoteHealth, r.doc.Key))
+	}
 	cleanupOp := newCleanupOp(cleanupRelationSettings, fmt.Sprintf("r#%d#", r.Id()))
 	return append(ops, cleanupOp), nil
 }
@@ -517,6 +522,32 @@ func (r *Relation) RemoteUnit(unitName string) (*RelationUnit, error) {
 	return r.unit(unitName, principal, isPrincipal, isLocalUnit)
 }
 
+// UnitNamesInScope returns the sorted names of the units of the given
+// application, local or remote, that are in scope in the relation and
+// not departing.
+func (r *Relation) UnitNamesInScope(applicationName string) ([]string, error) {
+	relationScopes, closer := r.st.db().GetCollection(relationScopesC)
+	defer closer()
+
+	var docs []relationScopeDoc
+	sel := bson.D{
+		{"key", bson.D{{"$regex", "^" + r.globalScope() + "#"}}},
+		{"departing", bson.D{{"$ne", true}}},
+	}
+	if err := relationScopes.Find(sel).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot read scope of relation %q", r)
+	}
+	var unitNames []string
+	for _, doc := range docs {
+		unitName := doc.unitName()
+		if appName, err := names.UnitApplication(unitName); err == nil && appName == applicationName {
+			unitNames = append(unitNames, unitName)
+		}
+	}
+	sort.Strings(unitNames)
+	return unitNames, nil
+}
+
 // IsCrossModel returns whether this relation is a cross-model
 // relation.
 func (r *Relation) IsCrossModel() (bool, error) {
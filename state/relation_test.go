@@ -508,6 +508,46 @@ func (s *RelationSuite) TestWatchLifeSuspendedStatus(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *RelationSuite) TestUnitNamesInScope(c *gc.C) {
+	s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	enterScope := func(appName string) *state.RelationUnit {
+		app, err := s.State.Application(appName)
+		c.Assert(err, jc.ErrorIsNil)
+		u, err := app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		m := s.Factory.MakeMachine(c, &factory.MachineParams{})
+		err = u.AssignToMachine(m)
+		c.Assert(err, jc.ErrorIsNil)
+		relUnit, err := rel.Unit(u)
+		c.Assert(err, jc.ErrorIsNil)
+		err = relUnit.EnterScope(nil)
+		c.Assert(err, jc.ErrorIsNil)
+		return relUnit
+	}
+	enterScope("mysql")
+	enterScope("wordpress")
+	leaving := enterScope("wordpress")
+
+	unitNames, err := rel.UnitNamesInScope("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(unitNames, jc.DeepEquals, []string{"wordpress/0", "wordpress/1"})
+	unitNames, err = rel.UnitNamesInScope("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(unitNames, jc.DeepEquals, []string{"mysql/0"})
+
+	err = leaving.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	unitNames, err = rel.UnitNamesInScope("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(unitNames, jc.DeepEquals, []string{"wordpress/0"})
+}
+
 func (s *RelationSuite) TestWatchLifeSuspendedStatusDead(c *gc.C) {
 	// Create a pair of application and a relation between them.
 	s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
@@ -0,0 +1,141 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// remoteRelationHealthDoc records how well the settings of a cross-model
+// relation are being exchanged with the other side of the relation. It
+// is written directly, without transactions, every time an exchange is
+// attempted, and is informational only.
+type remoteRelationHealthDoc struct {
+	DocID               string    `bson:"_id"`
+	ModelUUID           string    `bson:"model-uuid"`
+	RelationKey         string    `bson:"relation-key"`
+	ControllerReachable bool      `bson:"controller-reachable"`
+	LastExchange        time.Time `bson:"last-exchange,omitempty"`
+	LastError           string    `bson:"last-error,omitempty"`
+	LastErrorTime       time.Time `bson:"last-error-time,omitempty"`
+	Updated             time.Time `bson:"updated"`
+}
+
+// RemoteRelationHealth describes the health of the exchange of settings
+// for a cross-model relation.
+type RemoteRelationHealth struct {
+	// ControllerReachable is true if the controller on the other side
+	// of the relation responded to the most recent exchange.
+	ControllerReachable bool
+
+	// LastExchange is when settings were last successfully exchanged.
+	// It is zero if no exchange has succeeded.
+	LastExchange time.Time
+
+	// LastError holds the error from the most recent failed exchange.
+	LastError string
+
+	// LastErrorTime is when the most recent failed exchange occurred.
+	LastErrorTime time.Time
+
+	// Updated is when the health was last recorded.
+	Updated time.Time
+}
+
+// RemoteRelationHealthUpdate holds the outcome of an attempt to exchange
+// settings for a cross-model relation.
+type RemoteRelationHealthUpdate struct {
+	// When is the time of the exchange.
+	When time.Time
+
+	// Error holds the reason the exchange failed, or is empty if
+	// it succeeded.
+	Error string
+
+	// ControllerReachable is true if the controller on the other
+	// side of the relation responded.
+	ControllerReachable bool
+}
+
+// UpdateRemoteHealth records the outcome of an attempt to exchange
+// settings for a cross-model relation. A successful exchange does not
+// clear any previously recorded error; the times of the last exchange
+// and the last error indicate which happened most recently.
+func (r *Relation) UpdateRemoteHealth(update RemoteRelationHealthUpdate) error {
+	if update.When.IsZero() {
+		return errors.NotValidf("zero remote relation health update time")
+	}
+	healthColl, closer := r.st.db().GetCollection(remoteRelationHealthC)
+	defer closer()
+
+	healthW := healthColl.Writeable()
+
+	// Update the safe mode of the underlying session to not require
+	// write majority, nor sync to disk.
+	session := healthW.Underlying().Database.Session
+	session.SetSafe(&mgo.Safe{})
+
+	when := update.When.UTC()
+	set := bson.D{
+		{"model-uuid", r.st.ModelUUID()},
+		{"relation-key", r.doc.Key},
+		{"controller-reachable", update.ControllerReachable},
+		{"updated", when},
+	}
+	if update.Error == "" {
+		set = append(set, bson.DocElem{"last-exchange", when})
+	} else {
+		set = append(set,
+			bson.DocElem{"last-error", update.Error},
+			bson.DocElem{"last-error-time", when},
+		)
+	}
+	_, err := healthW.UpsertId(r.st.docID(r.doc.Key), bson.D{{"$set", set}})
+	return errors.Annotatef(err, "updating remote health of relation %q", r.doc.Key)
+}
+
+// RemoteHealth returns the most recently recorded health of the exchange
+// of settings for a cross-model relation. A NotFound error is returned
+// if no exchange has been attempted.
+func (r *Relation) RemoteHealth() (RemoteRelationHealth, error) {
+	healthColl, closer := r.st.db().GetCollection(remoteRelationHealthC)
+	defer closer()
+
+	var doc remoteRelationHealthDoc
+	err := healthColl.FindId(r.doc.Key).One(&doc)
+	if err == mgo.ErrNotFound {
+		return RemoteRelationHealth{}, errors.NotFoundf("remote health of relation %q", r.doc.Key)
+	} else if err != nil {
+		return RemoteRelationHealth{}, errors.Annotatef(err, "reading remote health of relation %q", r.doc.Key)
+	}
+	health := RemoteRelationHealth{
+		ControllerReachable: doc.ControllerReachable,
+		LastError:           doc.LastError,
+		Updated:             doc.Updated.UTC(),
+	}
+	if !doc.LastExchange.IsZero() {
+		health.LastExchange = doc.LastExchange.UTC()
+	}
+	if !doc.LastErrorTime.IsZero() {
+		health.LastErrorTime = doc.LastErrorTime.UTC()
+	}
+	return health, nil
+}
+
+// cleanupRelationRemoteHealth removes the recorded remote health of
+// the relation with the specified key, once the relation is gone.
+func (st *State) cleanupRelationRemoteHealth(key string) error {
+	healthColl, closer := st.db().GetCollection(remoteRelationHealthC)
+	defer closer()
+
+	err := healthColl.Writeable().RemoveId(key)
+	if err != nil && err != mgo.ErrNotFound {
+		return errors.Annotatef(err, "removing remote health of relation %q", key)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/state"
+)
+
+type remoteRelationHealthSuite struct {
+	ConnSuite
+	application *state.RemoteApplication
+	relation    *state.Relation
+}
+
+var _ = gc.Suite(&remoteRelationHealthSuite{})
+
+func (s *remoteRelationHealthSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	var err error
+	s.application, err = s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "mysql",
+		SourceModel: s.Model.ModelTag(),
+		Token:       "app-token",
+		Endpoints: []charm.Relation{{
+			Interface: "mysql",
+			Name:      "db",
+			Role:      charm.RoleProvider,
+			Scope:     charm.ScopeGlobal,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.relation, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *remoteRelationHealthSuite) TestRemoteHealthNotFound(c *gc.C) {
+	_, err := s.relation.RemoteHealth()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `remote health of relation "wordpress:db mysql:db" not found`)
+}
+
+func (s *remoteRelationHealthSuite) TestUpdateRemoteHealth(c *gc.C) {
+	exchanged := time.Date(2018, 5, 1, 10, 0, 0, 0, time.UTC)
+	err := s.relation.UpdateRemoteHealth(state.RemoteRelationHealthUpdate{
+		When:                exchanged,
+		ControllerReachable: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	health, err := s.relation.RemoteHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(health, jc.DeepEquals, state.RemoteRelationHealth{
+		ControllerReachable: true,
+		LastExchange:        exchanged,
+		Updated:             exchanged,
+	})
+
+	failed := exchanged.Add(time.Minute)
+	err = s.relation.UpdateRemoteHealth(state.RemoteRelationHealthUpdate{
+		When:  failed,
+		Error: "connection refused",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	health, err = s.relation.RemoteHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(health, jc.DeepEquals, state.RemoteRelationHealth{
+		LastExchange:  exchanged,
+		LastError:     "connection refused",
+		LastErrorTime: failed,
+		Updated:       failed,
+	})
+
+	recovered := failed.Add(time.Minute)
+	err = s.relation.UpdateRemoteHealth(state.RemoteRelationHealthUpdate{
+		When:                recovered,
+		ControllerReachable: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	health, err = s.relation.RemoteHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(health, jc.DeepEquals, state.RemoteRelationHealth{
+		ControllerReachable: true,
+		LastExchange:        recovered,
+		LastError:           "connection refused",
+		LastErrorTime:       failed,
+		Updated:             recovered,
+	})
+}
+
+func (s *remoteRelationHealthSuite) TestUpdateRemoteHealthZeroTime(c *gc.C) {
+	err := s.relation.UpdateRemoteHealth(state.RemoteRelationHealthUpdate{})
+	c.Assert(err, gc.ErrorMatches, "zero remote relation health update time not valid")
+}
+
+func (s *remoteRelationHealthSuite) TestRemoteHealthRemovedWithRelation(c *gc.C) {
+	err := s.relation.UpdateRemoteHealth(state.RemoteRelationHealthUpdate{
+		When:                time.Now(),
+		ControllerReachable: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.relation.RemoteHealth()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -584,9 +584,10 @@ func NetworksForRelation(
 		}
 	}
 
-	// If no egress subnets defined, We default to the ingress address.
+	// If no egress subnets defined, We default to the ingress address,
+	// one of each family for dual-stack units.
 	if len(egress) == 0 && len(ingress) > 0 {
-		egress, err = network.FormatAsCIDR([]string{ingress[0]})
+		egress, err = network.FormatAsCIDR(network.FirstAddressPerFamily(ingress))
 		if err != nil {
 			return "", nil, nil, errors.Trace(err)
 		}
@@ -3444,6 +3444,89 @@ func (s *StateSuite) TestWatchSubnetsDiesOnStateClose(c *gc.C) {
 	})
 }
 
+func (s *StateSuite) TestWatchSubnetSpaces(c *gc.C) {
+	w := s.State.WatchSubnetSpaces()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+
+	// Check initial event.
+	wc.AssertOneChange()
+
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Moving the subnet to a space is notified.
+	_, err = s.State.AddSpace("public", "", []string{"10.0.0.0/24"}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *StateSuite) TestWatchExposedAddresses(c *gc.C) {
+	w := s.State.WatchExposedAddresses()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+
+	// Check initial event.
+	wc.AssertOneChange()
+
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	wc.AssertOneChange()
+
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = machine.SetProviderAddresses(network.NewAddress("203.0.113.1"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *StateSuite) TestWatchRelatedAddresses(c *gc.C) {
+	w := s.State.WatchRelatedAddresses()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+
+	// Check initial event.
+	wc.AssertOneChange()
+
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	wc.AssertNoChange()
+
+	_, err := wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	wc.AssertNoChange()
+
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = machine.SetProviderAddresses(network.NewAddress("10.0.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *StateSuite) setupWatchRemoteRelations(c *gc.C, wc statetesting.StringsWatcherC) (*state.RemoteApplication, *state.Application, *state.Relation) {
 	// Check initial event.
 	wc.AssertChange()
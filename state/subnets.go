@@ -45,6 +45,10 @@ type SubnetInfo struct {
 
 	// FanOverlay is the CIDR of the complete FAN setup. Empty if not a FAN subnet.
 	FanOverlay string
+
+	// Routes holds the static routes which machines with addresses in
+	// the subnet should configure. It can be empty.
+	Routes []network.Route
 }
 
 type Subnet struct {
@@ -68,6 +72,30 @@ type subnetDoc struct {
 	SpaceName        string `bson:"space-name,omitempty"`
 	FanLocalUnderlay string `bson:"fan-local-underlay,omitempty"`
 	FanOverlay       string `bson:"fan-overlay,omitempty"`
+
+	Routes []subnetRouteDoc `bson:"routes,omitempty"`
+}
+
+// subnetRouteDoc holds a static route configured for a subnet.
+type subnetRouteDoc struct {
+	DestinationCIDR string `bson:"destination-cidr"`
+	GatewayIP       string `bson:"gateway-ip"`
+	Metric          int    `bson:"metric,omitempty"`
+}
+
+func subnetRouteDocs(routes []network.Route) []subnetRouteDoc {
+	if len(routes) == 0 {
+		return nil
+	}
+	docs := make([]subnetRouteDoc, len(routes))
+	for i, route := range routes {
+		docs[i] = subnetRouteDoc{
+			DestinationCIDR: route.DestinationCIDR,
+			GatewayIP:       route.GatewayIP,
+			Metric:          route.Metric,
+		}
+	}
+	return docs
 }
 
 // Life returns whether the subnet is Alive, Dying or Dead.
@@ -167,6 +195,45 @@ func (s *Subnet) VLANTag() int {
 	return s.doc.VLANTag
 }
 
+// Routes returns the static routes which machines with addresses in the
+// subnet should configure.
+func (s *Subnet) Routes() []network.Route {
+	if len(s.doc.Routes) == 0 {
+		return nil
+	}
+	routes := make([]network.Route, len(s.doc.Routes))
+	for i, doc := range s.doc.Routes {
+		routes[i] = network.Route{
+			DestinationCIDR: doc.DestinationCIDR,
+			GatewayIP:       doc.GatewayIP,
+			Metric:          doc.Metric,
+		}
+	}
+	return routes
+}
+
+// SetRoutes replaces the static routes which machines with addresses in
+// the subnet should configure.
+func (s *Subnet) SetRoutes(routes []network.Route) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set routes for subnet %q", s)
+
+	docs := subnetRouteDocs(routes)
+	if err := validateSubnetRoutes(docs); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      subnetsC,
+		Id:     s.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"routes", docs}}}},
+	}}
+	if err := s.st.db().RunTransaction(ops); err != nil {
+		return onAbort(err, subnetNotAliveErr)
+	}
+	s.doc.Routes = docs
+	return nil
+}
+
 // AvailabilityZone returns the availability zone of the subnet. If the subnet
 // is not associated with an availability zone it will be the empty string.
 func (s *Subnet) AvailabilityZone() string {
@@ -200,6 +267,21 @@ func (s *Subnet) Validate() error {
 		return errors.Errorf("invalid VLAN tag %d: must be between 0 and 4094", s.doc.VLANTag)
 	}
 
+	return validateSubnetRoutes(s.doc.Routes)
+}
+
+func validateSubnetRoutes(routes []subnetRouteDoc) error {
+	for _, route := range routes {
+		if _, _, err := net.ParseCIDR(route.DestinationCIDR); err != nil {
+			return errors.Errorf("invalid route destination %q", route.DestinationCIDR)
+		}
+		if net.ParseIP(route.GatewayIP) == nil {
+			return errors.Errorf("invalid route gateway %q", route.GatewayIP)
+		}
+		if route.Metric < 0 {
+			return errors.Errorf("invalid route metric %d: must not be negative", route.Metric)
+		}
+	}
 	return nil
 }
 
@@ -279,6 +361,7 @@ func (st *State) newSubnetFromArgs(args SubnetInfo) (*Subnet, error) {
 		SpaceName:         args.SpaceName,
 		FanLocalUnderlay:  args.FanLocalUnderlay,
 		FanOverlay:        args.FanOverlay,
+		Routes:            subnetRouteDocs(args.Routes),
 	}
 	subnet := &Subnet{doc: subDoc, st: st, spaceName: args.SpaceName}
 	err := subnet.Validate()
@@ -302,6 +385,7 @@ func (st *State) addSubnetOps(args SubnetInfo) []txn.Op {
 		SpaceName:         args.SpaceName,
 		FanLocalUnderlay:  args.FanLocalUnderlay,
 		FanOverlay:        args.FanOverlay,
+		Routes:            subnetRouteDocs(args.Routes),
 	}
 	ops := []txn.Op{
 		{
@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
 
@@ -85,6 +86,79 @@ func (s *SubnetSuite) TestAddSubnetFailsWithOutOfRangeVLANTag(c *gc.C) {
 	s.assertAddSubnetForInfoFailsWithSuffix(c, subnetInfo, "invalid VLAN tag 4095: must be between 0 and 4094")
 }
 
+func (s *SubnetSuite) TestAddSubnetWithRoutes(c *gc.C) {
+	routes := []network.Route{{
+		DestinationCIDR: "10.10.0.0/16",
+		GatewayIP:       "192.168.0.254",
+		Metric:          100,
+	}, {
+		DestinationCIDR: "fd00::/64",
+		GatewayIP:       "fd01::1",
+	}}
+	subnet, err := s.State.AddSubnet(state.SubnetInfo{
+		CIDR:    "192.168.0.0/24",
+		VLANTag: 42,
+		Routes:  routes,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.Routes(), jc.DeepEquals, routes)
+
+	subnet, err = s.State.Subnet("192.168.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.VLANTag(), gc.Equals, 42)
+	c.Assert(subnet.Routes(), jc.DeepEquals, routes)
+}
+
+func (s *SubnetSuite) TestAddSubnetFailsWithInvalidRoute(c *gc.C) {
+	subnetInfo := state.SubnetInfo{
+		CIDR:   "192.168.0.0/24",
+		Routes: []network.Route{{DestinationCIDR: "10.10.0.0/16", GatewayIP: "foo"}},
+	}
+	s.assertAddSubnetForInfoFailsWithSuffix(c, subnetInfo, `invalid route gateway "foo"`)
+
+	subnetInfo.Routes = []network.Route{{DestinationCIDR: "10.10.0.0", GatewayIP: "192.168.0.254"}}
+	s.assertAddSubnetForInfoFailsWithSuffix(c, subnetInfo, `invalid route destination "10.10.0.0"`)
+}
+
+func (s *SubnetSuite) TestSetRoutes(c *gc.C) {
+	subnet, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "192.168.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.Routes(), gc.HasLen, 0)
+
+	routes := []network.Route{{
+		DestinationCIDR: "10.10.0.0/16",
+		GatewayIP:       "192.168.0.254",
+	}}
+	err = subnet.SetRoutes(routes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.Routes(), jc.DeepEquals, routes)
+	err = subnet.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.Routes(), jc.DeepEquals, routes)
+
+	err = subnet.SetRoutes(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = subnet.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.Routes(), gc.HasLen, 0)
+}
+
+func (s *SubnetSuite) TestSetRoutesInvalid(c *gc.C) {
+	subnet, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "192.168.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = subnet.SetRoutes([]network.Route{{DestinationCIDR: "10.10.0.0/16", GatewayIP: "192.168.0.254", Metric: -1}})
+	c.Assert(err, gc.ErrorMatches, `cannot set routes for subnet "192.168.0.0/24": invalid route metric -1: must not be negative`)
+}
+
+func (s *SubnetSuite) TestSetRoutesNotAlive(c *gc.C) {
+	subnet, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "192.168.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = subnet.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = subnet.SetRoutes(nil)
+	c.Assert(err, gc.ErrorMatches, `cannot set routes for subnet "192.168.0.0/24": subnet is not found or not alive`)
+}
+
 func (s *SubnetSuite) TestAddSubnetFailsWithAlreadyExistsForDuplicateCIDRInSameModel(c *gc.C) {
 	subnetInfo := state.SubnetInfo{CIDR: "192.168.0.1/24"}
 	subnet, err := s.State.AddSubnet(subnetInfo)
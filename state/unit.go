@@ -952,33 +952,40 @@ func (u *Unit) scopedAddress(scope string) (network.Address, error) {
 // Only relevant for CAAS models - will return an empty
 // slice for IAAS models.
 func (u *Unit) AllAddresses() ([]network.Address, error) {
+	addresses, _, err := u.allAddresses()
+	return addresses, errors.Trace(err)
+}
+
+// allAddresses returns the addresses of the unit as for AllAddresses,
+// and whether IPv6 addresses are preferred when picking one of them.
+func (u *Unit) allAddresses() (_ []network.Address, preferIPv6 bool, _ error) {
 	if u.ShouldBeAssigned() {
-		return nil, nil
+		return nil, false, nil
 	}
 	// First the addresses of the service.
 	app, err := u.Application()
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, false, errors.Trace(err)
 	}
-	serviceInfo, err := app.ServiceInfo()
+	serviceDoc, err := app.cloudService()
 	if errors.IsNotFound(err) {
-		return nil, nil
+		return nil, false, nil
 	}
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, false, errors.Trace(err)
 	}
-	addresses := serviceInfo.Addresses()
+	addresses := networkAddresses(serviceDoc.Addresses)
 
 	// Second the address of the container.
 	addr, err := u.containerAddress()
 	if network.IsNoAddressError(err) {
-		return addresses, nil
+		return addresses, serviceDoc.PreferIPv6, nil
 	}
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, false, errors.Trace(err)
 	}
 	addresses = append(addresses, addr)
-	return addresses, nil
+	return addresses, serviceDoc.PreferIPv6, nil
 }
 
 // containerAddress returns the address of the pod's container.
@@ -1000,7 +1007,7 @@ func (u *Unit) containerAddress() (network.Address, error) {
 // serviceAddress returns the address of the service
 // managing the pods in which the unit workload is running.
 func (u *Unit) serviceAddress(scope string) (network.Address, error) {
-	addresses, err := u.AllAddresses()
+	addresses, preferIPv6, err := u.allAddresses()
 	if err != nil {
 		return network.Address{}, errors.Trace(err)
 	}
@@ -1009,13 +1016,13 @@ func (u *Unit) serviceAddress(scope string) (network.Address, error) {
 	}
 
 	getStrictPublicAddr := func(addresses []network.Address) (network.Address, bool) {
-		addr, ok := network.SelectPublicAddress(addresses)
+		addr, ok := network.SelectPreferredPublicAddress(addresses, preferIPv6)
 		ok = ok && addr.Scope == network.ScopePublic
 		return addr, ok
 	}
 
 	getInternalAddr := func(addresses []network.Address) (network.Address, bool) {
-		return network.SelectInternalAddress(addresses, false)
+		return network.SelectPreferredInternalAddress(addresses, false, preferIPv6)
 	}
 
 	var addrMatch func([]network.Address) (network.Address, bool)
@@ -2520,7 +2527,7 @@ func (u *Unit) assignToCleanMaybeEmptyMachineOps(requireEmpty bool) (_ *Machine,
 	// Shuffle machines to reduce likelihood of collisions.
 	// The partition of provisioned/unprovisioned machines
 	// must be maintained.
-	if instances, err = distributeUnit(u, instances); err != nil {
+	if instances, err = distributeUnit(u, instances, *cons); err != nil {
 		assignContextf(&err, u.Name(), context)
 		return failure(err)
 	}
@@ -1974,6 +1974,7 @@ func unitMachine(c *gc.C, st *state.State, u *state.Unit) *state.Machine {
 
 type CAASUnitSuite struct {
 	ConnSuite
+	caasSt      *state.State
 	charm       *state.Charm
 	application *state.Application
 }
@@ -1982,10 +1983,10 @@ var _ = gc.Suite(&CAASUnitSuite{})
 
 func (s *CAASUnitSuite) SetUpTest(c *gc.C) {
 	s.ConnSuite.SetUpTest(c)
-	st := s.Factory.MakeCAASModel(c, nil)
-	s.AddCleanup(func(_ *gc.C) { st.Close() })
+	s.caasSt = s.Factory.MakeCAASModel(c, nil)
+	s.AddCleanup(func(_ *gc.C) { s.caasSt.Close() })
 
-	f := factory.NewFactory(st)
+	f := factory.NewFactory(s.caasSt)
 	ch := f.MakeCharm(c, &factory.CharmParams{Name: "gitlab", Series: "kubernetes"})
 	s.application = f.MakeApplication(c, &factory.ApplicationParams{Name: "gitlab", Charm: ch})
 }
@@ -2161,6 +2162,29 @@ func (s *CAASUnitSuite) TestPublicAddress(c *gc.C) {
 	})
 }
 
+func (s *CAASUnitSuite) TestAddressesFollowPreferIPv6Changes(c *gc.C) {
+	existingUnit, err := s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.UpdateCloudService("", []network.Address{
+		{Value: "54.32.1.2", Scope: network.ScopePublic, Type: network.IPv4Address},
+		{Value: "2001:db8::1", Scope: network.ScopePublic, Type: network.IPv6Address},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr, err := existingUnit.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "54.32.1.2")
+
+	model, err := s.caasSt.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.UpdateModelConfig(map[string]interface{}{"preferred-ipv6": true}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr, err = existingUnit.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "2001:db8::1")
+}
+
 func (s *CAASUnitSuite) TestAllAddresses(c *gc.C) {
 	existingUnit, err := s.application.AddUnit(state.AddUnitParams{})
 	c.Assert(err, jc.ErrorIsNil)
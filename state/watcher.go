@@ -1708,6 +1708,12 @@ func (m *Machine) WatchUpgradeSeriesNotifications() (NotifyWatcher, error) {
 	return nil, watcher.EnsureErr(watch)
 }
 
+// WatchBonds returns a NotifyWatcher that notifies when the bonds
+// defined for the machine change.
+func (m *Machine) WatchBonds() NotifyWatcher {
+	return newEntityWatcher(m.st, machineBondsC, m.st.docID(m.Id()))
+}
+
 func newEntityWatcher(backend modelBackend, collName string, key interface{}) NotifyWatcher {
 	return newDocWatcher(backend, []docKey{{collName, key}})
 }
@@ -2848,20 +2854,40 @@ func (st *State) WatchMachineRemovals() NotifyWatcher {
 	return newNotifyCollWatcher(st, machineRemovalsC, isLocalID(st))
 }
 
+// WatchExposedAddresses returns a NotifyWatcher that notifies when
+// any of the model's machines, applications, units or cloud services
+// is added, removed or changed. Between them, these determine the
+// addresses at which exposed applications and their units are reached.
+func (st *State) WatchExposedAddresses() NotifyWatcher {
+	return newNotifyCollsWatcher(st, []string{machinesC, applicationsC, unitsC, cloudServicesC}, isLocalID(st))
+}
+
+// WatchRelatedAddresses returns a NotifyWatcher that notifies when any
+// of the model's machines, units or relations is added, removed or
+// changed. Between them, these determine the addresses returned by
+// Machine.RelatedAddresses.
+func (st *State) WatchRelatedAddresses() NotifyWatcher {
+	return newNotifyCollsWatcher(st, []string{machinesC, unitsC, relationsC}, isLocalID(st))
+}
+
 // notifyCollWatcher implements NotifyWatcher, triggering when a
-// change is seen in a specific collection matching the provided
-// filter function.
+// change is seen in any of a set of collections matching the
+// provided filter function.
 type notifyCollWatcher struct {
 	commonWatcher
-	collName string
-	filter   func(interface{}) bool
-	sink     chan struct{}
+	collNames []string
+	filter    func(interface{}) bool
+	sink      chan struct{}
 }
 
 func newNotifyCollWatcher(backend modelBackend, collName string, filter func(interface{}) bool) NotifyWatcher {
+	return newNotifyCollsWatcher(backend, []string{collName}, filter)
+}
+
+func newNotifyCollsWatcher(backend modelBackend, collNames []string, filter func(interface{}) bool) NotifyWatcher {
 	w := &notifyCollWatcher{
 		commonWatcher: newCommonWatcher(backend),
-		collName:      collName,
+		collNames:     collNames,
 		filter:        filter,
 		sink:          make(chan struct{}),
 	}
@@ -2880,8 +2906,10 @@ func (w *notifyCollWatcher) Changes() <-chan struct{} {
 func (w *notifyCollWatcher) loop() error {
 	in := make(chan watcher.Change)
 
-	w.watcher.WatchCollectionWithFilter(w.collName, in, w.filter)
-	defer w.watcher.UnwatchCollection(w.collName, in)
+	for _, collName := range w.collNames {
+		w.watcher.WatchCollectionWithFilter(collName, in, w.filter)
+		defer w.watcher.UnwatchCollection(collName, in)
+	}
 
 	out := w.sink // out set so that initial event is sent.
 	for {
@@ -2978,6 +3006,13 @@ func (st *State) WatchSubnets(subnetFilter func(id interface{}) bool) StringsWat
 	return newLifecycleWatcher(st, subnetsC, nil, filter, nil)
 }
 
+// WatchSubnetSpaces returns a NotifyWatcher that notifies when any of
+// the model's subnets is added, removed or changed, including when it
+// is moved to another space.
+func (st *State) WatchSubnetSpaces() NotifyWatcher {
+	return newNotifyCollWatcher(st, subnetsC, isLocalID(st))
+}
+
 // isLocalID returns a watcher filter func that rejects ids not specific
 // to the supplied modelBackend.
 func isLocalID(st modelBackend) func(interface{}) bool {
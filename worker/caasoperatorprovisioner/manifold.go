@@ -4,6 +4,7 @@
 package caasoperatorprovisioner
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
@@ -11,6 +12,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/caasoperatorprovisioner"
+	"github.com/juju/juju/api/modelupgrader"
 	"github.com/juju/juju/caas"
 )
 
@@ -19,6 +21,7 @@ type ManifoldConfig struct {
 	AgentName     string
 	APICallerName string
 	BrokerName    string
+	ClockName     string
 
 	NewWorker func(Config) (worker.Worker, error)
 }
@@ -34,6 +37,9 @@ func (config ManifoldConfig) Validate() error {
 	if config.BrokerName == "" {
 		return errors.NotValidf("empty BrokerName")
 	}
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
 	if config.NewWorker == nil {
 		return errors.NotValidf("nil NewWorker")
 	}
@@ -60,6 +66,11 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		return nil, errors.Trace(err)
 	}
 
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	modelTag, ok := apiCaller.ModelTag()
 	if !ok {
 		return nil, errors.New("API connection is controller-only (should never happen)")
@@ -72,6 +83,8 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		Broker:      broker,
 		ModelTag:    modelTag,
 		AgentConfig: agentConfig,
+		ModelStatus: modelupgrader.NewClient(apiCaller),
+		Clock:       clock,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -87,6 +100,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			config.AgentName,
 			config.APICallerName,
 			config.BrokerName,
+			config.ClockName,
 		},
 		Start: config.start,
 	}
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/caasoperatorprovisioner"
@@ -142,6 +143,63 @@ func (m *mockBroker) DeleteOperator(appName string) error {
 	return m.NextErr()
 }
 
+type mockUpgraderBroker struct {
+	mockBroker
+
+	mu       sync.Mutex
+	operator caas.Operator
+	// statuses are the operator statuses returned by successive Operator calls.
+	statuses []status.Status
+}
+
+func (m *mockUpgraderBroker) Operator(appName string) (*caas.Operator, error) {
+	m.MethodCall(m, "Operator", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	op := m.operator
+	if len(m.statuses) > 0 {
+		op.Status.Status = m.statuses[0]
+		m.statuses = m.statuses[1:]
+	}
+	return &op, nil
+}
+
+func (m *mockUpgraderBroker) UpgradeOperator(appName string, config *caas.OperatorConfig) error {
+	m.MethodCall(m, "UpgradeOperator", appName, config)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.operator.PreviousImage, m.operator.PreviousVersion = m.operator.Image, m.operator.Version
+	m.operator.Image, m.operator.Version = config.OperatorImagePath, config.Version
+	return m.NextErr()
+}
+
+func (m *mockUpgraderBroker) RollbackOperator(appName string) error {
+	m.MethodCall(m, "RollbackOperator", appName)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.operator.FailedVersion = m.operator.Version
+	m.operator.Image, m.operator.Version = m.operator.PreviousImage, m.operator.PreviousVersion
+	return m.NextErr()
+}
+
+type mockModelStatus struct {
+	testing.Stub
+	status status.StatusInfo
+}
+
+func (m *mockModelStatus) ModelStatus(tag names.ModelTag) (status.StatusInfo, error) {
+	m.MethodCall(m, "ModelStatus", tag)
+	return m.status, m.NextErr()
+}
+
+func (m *mockModelStatus) SetModelStatus(tag names.ModelTag, s status.Status, info string, data map[string]interface{}) error {
+	m.MethodCall(m, "SetModelStatus", tag, s, info, data)
+	return m.NextErr()
+}
+
 type mockWatcher struct {
 	testing.Stub
 	tomb.Tomb
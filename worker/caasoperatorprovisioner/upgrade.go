@@ -0,0 +1,280 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasoperatorprovisioner
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/status"
+)
+
+const (
+	// operatorHealthCheckInterval is how often an upgraded
+	// operator is checked while waiting for it to become healthy.
+	operatorHealthCheckInterval = 5 * time.Second
+
+	// operatorUpgradeTimeout is how long an upgraded operator has to
+	// become healthy before the upgrade is rolled back.
+	operatorUpgradeTimeout = 5 * time.Minute
+)
+
+// operatorUpgrade holds the config an application's operator
+// is to be upgraded to.
+type operatorUpgrade struct {
+	app    string
+	config *caas.OperatorConfig
+}
+
+// operatorUpgradeResult holds the outcome of an operator upgrade.
+// If the upgrade failed, err holds the reason it was rolled back.
+type operatorUpgradeResult struct {
+	app string
+	err error
+}
+
+// existingOperator returns the application's operator, or nil if
+// there is none or the broker cannot upgrade operators in place.
+func (p *provisioner) existingOperator(app string) (*caas.Operator, error) {
+	upgrader, ok := p.broker.(caas.OperatorUpgrader)
+	if !ok {
+		return nil, nil
+	}
+	operator, err := upgrader.Operator(app)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting operator for %q", app)
+	}
+	return operator, nil
+}
+
+// applyOperatorConfig starts the application's operator with the
+// config or, if the operator already exists and is running a different
+// version, queues an in place upgrade of it to be started once the
+// caller has applied the config of all the operators. An operator is not
+// upgraded again to a version it has been rolled back from; it is
+// kept on the version it is running until there's a new one to go to.
+func (p *provisioner) applyOperatorConfig(app string, config *caas.OperatorConfig) error {
+	if p.upgradeQueued(app) {
+		// Any upgrade in progress has stale config, so
+		// queue another one to pass on the new config.
+		p.queueUpgrade(operatorUpgrade{app, config})
+		return nil
+	}
+	operator, err := p.existingOperator(app)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if operator != nil && operator.Version != config.Version {
+		if config.Version != operator.FailedVersion {
+			p.queueUpgrade(operatorUpgrade{app, config})
+			return nil
+		}
+		logger.Infof("not upgrading operator for %q to %v, a previous upgrade to it was rolled back", app, config.Version)
+		keep := *config
+		keep.OperatorImagePath = operator.Image
+		keep.Version = operator.Version
+		config = &keep
+	}
+	return p.startOperator(app, config)
+}
+
+// upgradeQueued reports whether the application's
+// operator is being, or is waiting to be, upgraded.
+func (p *provisioner) upgradeQueued(app string) bool {
+	if p.upgrading == app {
+		return true
+	}
+	for _, u := range p.pendingUpgrades {
+		if u.app == app {
+			return true
+		}
+	}
+	return false
+}
+
+// queueUpgrade adds an operator upgrade to those waiting to be run,
+// replacing any already waiting for the same application. Queued
+// upgrades are run by startNextUpgrade.
+func (p *provisioner) queueUpgrade(u operatorUpgrade) {
+	for i, pending := range p.pendingUpgrades {
+		if pending.app == u.app {
+			p.pendingUpgrades[i] = u
+			return
+		}
+	}
+	p.pendingUpgrades = append(p.pendingUpgrades, u)
+}
+
+// startNextUpgrade starts a worker to run the next pending operator
+// upgrade, unless there are none or one is already in progress;
+// operators are upgraded one application at a time. Progress is
+// reported in the model status, which is restored once all the
+// pending upgrades are done.
+func (p *provisioner) startNextUpgrade() error {
+	if p.upgrading != "" || len(p.pendingUpgrades) == 0 {
+		return nil
+	}
+	if p.previousStatus == nil {
+		previous, err := p.modelStatus.ModelStatus(p.modelTag)
+		if err != nil {
+			return errors.Annotate(err, "getting model status")
+		}
+		p.previousStatus = &previous
+	}
+	u := p.pendingUpgrades[0]
+	p.pendingUpgrades = p.pendingUpgrades[1:]
+
+	info := fmt.Sprintf("upgrading operator for %q to %v (%d of %d)",
+		u.app, u.config.Version, p.upgradesDone+1, p.upgradesDone+1+len(p.pendingUpgrades))
+	logger.Infof(info)
+	if err := p.setModelStatus(status.Busy, info); err != nil {
+		return errors.Trace(err)
+	}
+	w, err := newUpgradeWorker(p.broker.(caas.OperatorUpgrader), p.clock, u, p.upgradeResults)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := p.catacomb.Add(w); err != nil {
+		return errors.Trace(err)
+	}
+	p.upgrading = u.app
+	return nil
+}
+
+// upgradeFinished records the outcome of an operator upgrade and starts
+// the next one. Once there are none left, any which failed are logged
+// and the model status is put back to what it was before the upgrades.
+func (p *provisioner) upgradeFinished(result operatorUpgradeResult) error {
+	p.upgrading = ""
+	p.upgradesDone++
+	if result.err != nil {
+		p.failedUpgrades = append(p.failedUpgrades, result.app)
+	}
+	if len(p.pendingUpgrades) > 0 {
+		return errors.Trace(p.startNextUpgrade())
+	}
+
+	failed := p.failedUpgrades
+	previous := p.previousStatus
+	p.upgradesDone = 0
+	p.failedUpgrades = nil
+	p.previousStatus = nil
+	if len(failed) > 0 {
+		logger.Errorf("operator upgrade failed and was rolled back for %s", strings.Join(failed, ", "))
+	}
+	err := p.modelStatus.SetModelStatus(p.modelTag, previous.Status, previous.Message, previous.Data)
+	return errors.Annotate(err, "restoring model status")
+}
+
+func (p *provisioner) setModelStatus(s status.Status, info string) error {
+	err := p.modelStatus.SetModelStatus(p.modelTag, s, info, nil)
+	return errors.Annotate(err, "setting model status")
+}
+
+// upgradeWorker upgrades a single application's operator, waiting
+// for it to become healthy and rolling it back if it does not, so
+// that the provisioner is not blocked while the upgrade happens.
+type upgradeWorker struct {
+	catacomb catacomb.Catacomb
+	upgrader caas.OperatorUpgrader
+	clock    clock.Clock
+	upgrade  operatorUpgrade
+	results  chan<- operatorUpgradeResult
+}
+
+func newUpgradeWorker(
+	upgrader caas.OperatorUpgrader,
+	clock clock.Clock,
+	upgrade operatorUpgrade,
+	results chan<- operatorUpgradeResult,
+) (*upgradeWorker, error) {
+	w := &upgradeWorker{
+		upgrader: upgrader,
+		clock:    clock,
+		upgrade:  upgrade,
+		results:  results,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, err
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *upgradeWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *upgradeWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *upgradeWorker) loop() error {
+	app := w.upgrade.app
+	err := w.upgradeOperator()
+	if err == w.catacomb.ErrDying() {
+		return err
+	}
+	switch {
+	case err == nil:
+		logger.Infof("upgraded operator for application %q to %v", app, w.upgrade.config.Version)
+	case errors.IsNotFound(err):
+		// The application has been removed, there's nothing to roll back.
+		logger.Debugf("operator for %q removed during upgrade", app)
+		err = nil
+	default:
+		logger.Errorf("upgrading operator for %q failed, rolling back: %v", app, err)
+		if err := w.upgrader.RollbackOperator(app); err != nil {
+			return errors.Annotatef(err, "rolling back operator for %q", app)
+		}
+	}
+	select {
+	case <-w.catacomb.Dying():
+		return w.catacomb.ErrDying()
+	case w.results <- operatorUpgradeResult{app: app, err: err}:
+	}
+	return nil
+}
+
+// upgradeOperator upgrades the application's operator and waits
+// for the broker to report that it is running the new image.
+func (w *upgradeWorker) upgradeOperator() error {
+	u := w.upgrade
+	if err := w.upgrader.UpgradeOperator(u.app, u.config); err != nil {
+		return errors.Trace(err)
+	}
+	timeout := w.clock.After(operatorUpgradeTimeout)
+	for {
+		operator, err := w.upgrader.Operator(u.app)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		switch operator.Status.Status {
+		case status.Running:
+			return nil
+		case status.Error:
+			return errors.Errorf("operator failed: %s", operator.Status.Message)
+		}
+
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timeout:
+			return errors.Errorf("operator not healthy after %v: %s %s",
+				operatorUpgradeTimeout, operator.Status.Status, operator.Status.Message)
+		case <-w.clock.After(operatorHealthCheckInterval):
+		}
+	}
+}
@@ -6,6 +6,7 @@ package caasoperatorprovisioner
 import (
 	"strings"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils"
@@ -18,6 +19,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -33,22 +35,62 @@ type CAASProvisionerFacade interface {
 	APIAddresses() ([]string, error)
 }
 
+// ModelStatusFacade is used to report the progress of operator
+// upgrades in the model's status, and to restore the status the
+// model had once they are done.
+type ModelStatusFacade interface {
+	ModelStatus(names.ModelTag) (status.StatusInfo, error)
+	SetModelStatus(names.ModelTag, status.Status, string, map[string]interface{}) error
+}
+
 // Config defines the operation of a Worker.
 type Config struct {
 	Facade      CAASProvisionerFacade
 	Broker      caas.Broker
 	ModelTag    names.ModelTag
 	AgentConfig agent.Config
+
+	// ModelStatus is used to report operator upgrade progress.
+	ModelStatus ModelStatusFacade
+
+	// Clock is used when waiting for upgraded operators to become healthy.
+	Clock clock.Clock
+}
+
+// Validate returns an error if the config cannot be used to start a Worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Broker == nil {
+		return errors.NotValidf("nil Broker")
+	}
+	if config.AgentConfig == nil {
+		return errors.NotValidf("nil AgentConfig")
+	}
+	if config.ModelStatus == nil {
+		return errors.NotValidf("nil ModelStatus")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
 }
 
 // NewProvisionerWorker starts and returns a new CAAS provisioner worker.
 func NewProvisionerWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	p := &provisioner{
 		provisionerFacade: config.Facade,
 		broker:            config.Broker,
 		modelTag:          config.ModelTag,
 		agentConfig:       config.AgentConfig,
+		modelStatus:       config.ModelStatus,
+		clock:             config.Clock,
 		appPasswords:      make(map[string]string),
+		upgradeResults:    make(chan operatorUpgradeResult),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &p.catacomb,
@@ -64,8 +106,23 @@ type provisioner struct {
 
 	modelTag    names.ModelTag
 	agentConfig agent.Config
+	modelStatus ModelStatusFacade
+	clock       clock.Clock
 
 	appPasswords map[string]string
+
+	// upgrading holds the application whose operator is being
+	// upgraded, if any, and pendingUpgrades those waiting for it.
+	// The outcome of each upgrade is sent on upgradeResults.
+	upgrading       string
+	pendingUpgrades []operatorUpgrade
+	upgradesDone    int
+	failedUpgrades  []string
+	upgradeResults  chan operatorUpgradeResult
+
+	// previousStatus holds the model's status from before the
+	// current batch of upgrades started, to be restored after.
+	previousStatus *status.StatusInfo
 }
 
 // Kill is part of the worker.Worker interface.
@@ -117,6 +174,14 @@ func (p *provisioner) loop() error {
 					return errors.Annotatef(err, "updating operator for q with new api addresses", app)
 				}
 			}
+			if err := p.startNextUpgrade(); err != nil {
+				return errors.Trace(err)
+			}
+
+		case result := <-p.upgradeResults:
+			if err := p.upgradeFinished(result); err != nil {
+				return errors.Trace(err)
+			}
 
 		// CAAS applications changed so either create or remove pods as appropriate.
 		case apps, ok := <-appWatcher.Changes():
@@ -174,9 +239,17 @@ func (p *provisioner) ensureOperators(appPasswords []apicaasprovisioner.Applicat
 			errorStrings = append(errorStrings, r.Error.Error())
 			continue
 		}
-		if err := p.ensureOperator(appPasswords[i].Name, appPasswords[i].Password); err != nil {
+		app, password := appPasswords[i].Name, appPasswords[i].Password
+		config, err := p.newOperatorConfig(app, password)
+		if err != nil {
 			return errors.Trace(err)
 		}
+		if err := p.applyOperatorConfig(app, config); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := p.startNextUpgrade(); err != nil {
+		return errors.Trace(err)
 	}
 	if errorStrings != nil {
 		err := errors.New(strings.Join(errorStrings, "\n"))
@@ -190,6 +263,10 @@ func (p *provisioner) ensureOperator(app, password string) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	return p.applyOperatorConfig(app, config)
+}
+
+func (p *provisioner) startOperator(app string, config *caas.OperatorConfig) error {
 	if err := p.broker.EnsureOperator(app, p.agentConfig.DataDir(), config); err != nil {
 		return errors.Annotatef(err, "failed to start operator for %q", app)
 	}
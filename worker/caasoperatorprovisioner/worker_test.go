@@ -7,7 +7,9 @@ import (
 	"io/ioutil"
 	"path/filepath"
 	"reflect"
+	"time"
 
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -20,6 +22,7 @@ import (
 	"github.com/juju/juju/agent"
 	apicaasprovisioner "github.com/juju/juju/api/caasoperatorprovisioner"
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/status"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/caasoperatorprovisioner"
 )
@@ -32,6 +35,9 @@ type CAASProvisionerSuite struct {
 
 	provisionerFacade *mockProvisionerFacade
 	caasClient        *mockBroker
+	broker            caas.Broker
+	modelStatus       *mockModelStatus
+	clock             *testclock.Clock
 	agentConfig       agent.Config
 	modelTag          names.ModelTag
 }
@@ -42,6 +48,11 @@ func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
 	s.stub = new(jujutesting.Stub)
 	s.provisionerFacade = newMockProvisionerFacade(s.stub)
 	s.caasClient = &mockBroker{}
+	s.broker = s.caasClient
+	s.modelStatus = &mockModelStatus{
+		status: status.StatusInfo{Status: status.Available},
+	}
+	s.clock = testclock.NewClock(time.Now())
 	s.agentConfig = &mockAgentConfig{}
 	s.modelTag = coretesting.ModelTag
 }
@@ -64,9 +75,11 @@ func waitForStubCalls(c *gc.C, stub *jujutesting.Stub, expected []jujutesting.St
 func (s *CAASProvisionerSuite) assertWorker(c *gc.C) worker.Worker {
 	w, err := caasoperatorprovisioner.NewProvisionerWorker(caasoperatorprovisioner.Config{
 		Facade:      s.provisionerFacade,
-		Broker:      s.caasClient,
+		Broker:      s.broker,
 		ModelTag:    s.modelTag,
 		AgentConfig: s.agentConfig,
+		ModelStatus: s.modelStatus,
+		Clock:       s.clock,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	expected := []jujutesting.StubCall{
@@ -170,3 +183,186 @@ func (s *CAASProvisionerSuite) TestAddressChanges(c *gc.C) {
 	s.stub.CheckCallNames(c, "APIAddresses", "OperatorProvisioningInfo")
 	s.caasClient.CheckCallNames(c, "EnsureOperator")
 }
+
+func (s *CAASProvisionerSuite) TestConfigValidate(c *gc.C) {
+	config := caasoperatorprovisioner.Config{
+		Facade:      s.provisionerFacade,
+		Broker:      s.broker,
+		ModelTag:    s.modelTag,
+		AgentConfig: s.agentConfig,
+		ModelStatus: s.modelStatus,
+		Clock:       s.clock,
+	}
+	c.Assert(config.Validate(), jc.ErrorIsNil)
+
+	config.ModelStatus = nil
+	c.Assert(config.Validate(), gc.ErrorMatches, "nil ModelStatus not valid")
+	config.ModelStatus = s.modelStatus
+	config.Clock = nil
+	c.Assert(config.Validate(), gc.ErrorMatches, "nil Clock not valid")
+}
+
+func (s *CAASProvisionerSuite) setupUpgrader(statuses ...status.Status) *mockUpgraderBroker {
+	upgrader := &mockUpgraderBroker{
+		operator: caas.Operator{
+			Image:   "juju-operator-image-old",
+			Version: version.MustParse("2.98.0"),
+		},
+		statuses: statuses,
+	}
+	s.broker = upgrader
+	return upgrader
+}
+
+// modelStatusSets returns the calls made to set the model status.
+func (s *CAASProvisionerSuite) modelStatusSets() []jujutesting.StubCall {
+	var calls []jujutesting.StubCall
+	for _, call := range s.modelStatus.Calls() {
+		if call.FuncName == "SetModelStatus" {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func (s *CAASProvisionerSuite) waitForModelStatus(c *gc.C, expected ...status.Status) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.modelStatusSets()) >= len(expected) {
+			break
+		}
+	}
+	calls := s.modelStatusSets()
+	c.Assert(calls, gc.HasLen, len(expected))
+	for i, call := range calls {
+		c.Assert(call.Args[1], gc.Equals, expected[i])
+	}
+}
+
+func (s *CAASProvisionerSuite) TestUpgradesOperatorInPlace(c *gc.C) {
+	upgrader := s.setupUpgrader(status.Running, status.Running)
+	w := s.assertWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.provisionerFacade.life = "alive"
+	s.provisionerFacade.applicationsWatcher.changes <- []string{"myapp"}
+
+	s.waitForModelStatus(c, status.Busy, status.Available)
+	c.Assert(s.modelStatusSets()[0].Args[2], gc.Equals, `upgrading operator for "myapp" to 2.99.0 (1 of 1)`)
+	upgrader.CheckCallNames(c, "Operator", "UpgradeOperator", "Operator")
+	config := upgrader.Calls()[1].Args[1].(*caas.OperatorConfig)
+	c.Assert(config.OperatorImagePath, gc.Equals, "juju-operator-image")
+	c.Assert(config.Version, gc.Equals, version.MustParse("2.99.0"))
+}
+
+func (s *CAASProvisionerSuite) TestUpgradeRestoresModelStatus(c *gc.C) {
+	s.setupUpgrader(status.Running, status.Running)
+	s.modelStatus.status = status.StatusInfo{
+		Status:  status.Error,
+		Message: "cloud credential is not valid",
+		Data:    map[string]interface{}{"reason": "expired"},
+	}
+	w := s.assertWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.provisionerFacade.life = "alive"
+	s.provisionerFacade.applicationsWatcher.changes <- []string{"myapp"}
+
+	s.waitForModelStatus(c, status.Busy, status.Error)
+	s.modelStatus.CheckCallNames(c, "ModelStatus", "SetModelStatus", "SetModelStatus")
+	c.Assert(s.modelStatusSets()[1].Args[2:], jc.DeepEquals, []interface{}{
+		"cloud credential is not valid",
+		map[string]interface{}{"reason": "expired"},
+	})
+}
+
+func (s *CAASProvisionerSuite) TestUpgradeOperatorWaitsForHealthy(c *gc.C) {
+	upgrader := s.setupUpgrader(status.Running, status.Allocating, status.Running)
+	w := s.assertWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.provisionerFacade.life = "alive"
+	s.provisionerFacade.applicationsWatcher.changes <- []string{"myapp"}
+
+	// Wait for the health check timer and upgrade timeout.
+	err := s.clock.WaitAdvance(5*time.Second, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.waitForModelStatus(c, status.Busy, status.Available)
+	upgrader.CheckCallNames(c, "Operator", "UpgradeOperator", "Operator", "Operator")
+}
+
+func (s *CAASProvisionerSuite) TestUpgradeOperatorRollsBack(c *gc.C) {
+	upgrader := s.setupUpgrader(status.Running, status.Error)
+	w := s.assertWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.provisionerFacade.life = "alive"
+	s.provisionerFacade.applicationsWatcher.changes <- []string{"myapp"}
+
+	// The failure is logged, and the model status restored.
+	s.waitForModelStatus(c, status.Busy, status.Available)
+	upgrader.CheckCallNames(c, "Operator", "UpgradeOperator", "Operator", "RollbackOperator")
+	upgrader.mu.Lock()
+	defer upgrader.mu.Unlock()
+	c.Assert(upgrader.operator.Version, gc.Equals, version.MustParse("2.98.0"))
+	c.Assert(upgrader.operator.FailedVersion, gc.Equals, version.MustParse("2.99.0"))
+}
+
+func (s *CAASProvisionerSuite) TestFailedUpgradeNotRetried(c *gc.C) {
+	upgrader := s.setupUpgrader(status.Running)
+	upgrader.operator.FailedVersion = version.MustParse("2.99.0")
+	w := s.assertWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.provisionerFacade.life = "alive"
+	s.provisionerFacade.applicationsWatcher.changes <- []string{"myapp"}
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(upgrader.Calls()) >= 2 {
+			break
+		}
+	}
+	// The operator is given its new config, but
+	// kept on the version it was rolled back to.
+	upgrader.CheckCallNames(c, "Operator", "EnsureOperator")
+	config := upgrader.Calls()[1].Args[2].(*caas.OperatorConfig)
+	c.Assert(config.OperatorImagePath, gc.Equals, "juju-operator-image-old")
+	c.Assert(config.Version, gc.Equals, version.MustParse("2.98.0"))
+	c.Assert(s.modelStatus.Calls(), gc.HasLen, 0)
+}
+
+func (s *CAASProvisionerSuite) TestUpgradesOperatorsOneAtATime(c *gc.C) {
+	upgrader := s.setupUpgrader(status.Running, status.Running, status.Running, status.Running)
+	w := s.assertWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.provisionerFacade.life = "alive"
+	s.provisionerFacade.applicationsWatcher.changes <- []string{"myapp", "another"}
+
+	s.waitForModelStatus(c, status.Busy, status.Busy, status.Available)
+	c.Assert(s.modelStatusSets()[0].Args[2], gc.Equals, `upgrading operator for "myapp" to 2.99.0 (1 of 2)`)
+	c.Assert(s.modelStatusSets()[1].Args[2], gc.Equals, `upgrading operator for "another" to 2.99.0 (2 of 2)`)
+	upgrader.CheckCallNames(c,
+		"Operator", "Operator",
+		"UpgradeOperator", "Operator",
+		"UpgradeOperator", "Operator",
+	)
+}
+
+func (s *CAASProvisionerSuite) TestNewOperatorNotUpgraded(c *gc.C) {
+	upgrader := s.setupUpgrader()
+	upgrader.SetErrors(errors.NotFoundf("operator"))
+	w := s.assertWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.provisionerFacade.life = "alive"
+	s.provisionerFacade.applicationsWatcher.changes <- []string{"myapp"}
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(upgrader.Calls()) >= 2 {
+			break
+		}
+	}
+	upgrader.CheckCallNames(c, "Operator", "EnsureOperator")
+	c.Assert(s.modelStatus.Calls(), gc.HasLen, 0)
+}
@@ -0,0 +1,86 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnsrecords
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/dnsrecords"
+	"github.com/juju/juju/dns"
+	"github.com/juju/juju/dns/rfc2136"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/worker/common"
+)
+
+// ManifoldConfig describes the resources used by the DNS records
+// worker.
+type ManifoldConfig struct {
+	APICallerName string
+	EnvironName   string
+	ClockName     string
+	RetryDelay    time.Duration
+
+	NewCredentialValidatorFacade func(base.APICaller) (common.CredentialAPI, error)
+}
+
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	// The worker runs whatever the provider, as records can be kept
+	// by a DNS server that accepts dynamic updates.
+	var environ environs.Environ
+	if err := context.Get(config.EnvironName, &environ); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+	credentialAPI, err := config.NewCredentialValidatorFacade(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	w, err := NewWorker(Config{
+		Facade:         dnsrecords.NewAPI(apiCaller),
+		Environ:        environ,
+		Clock:          clock,
+		RetryDelay:     config.RetryDelay,
+		CredentialAPI:  credentialAPI,
+		NewRFC2136Zone: newRFC2136Zone,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+func newRFC2136Zone(config rfc2136.Config) (dns.Zone, error) {
+	zone, err := rfc2136.NewZone(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return zone, nil
+}
+
+// Manifold returns a Manifold that encapsulates the DNS records
+// worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.APICallerName,
+			config.EnvironName,
+			config.ClockName,
+		},
+		Start: config.start,
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package dnsrecords provides a worker that keeps A and AAAA records
+// for the model's exposed applications and their units, when the
+// model's dns-zone config is set.
+package dnsrecords
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/api/dnsrecords"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/dns"
+	"github.com/juju/juju/dns/rfc2136"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/worker/common"
+)
+
+var logger = loggo.GetLogger("juju.worker.dnsrecords")
+
+// Facade exposes the controller functionality needed by the worker.
+type Facade interface {
+	// Records returns the names that the model's exposed
+	// applications and their units should have, with the addresses
+	// they should resolve to.
+	Records() ([]dnsrecords.Record, error)
+
+	// DNSConfig returns the model's DNS settings.
+	DNSConfig() (dnsrecords.DNSConfig, error)
+
+	// WatchRecords returns a watcher that notifies when the results
+	// of Records or DNSConfig may have changed.
+	WatchRecords() (watcher.NotifyWatcher, error)
+}
+
+// Config holds the dependencies and configuration for the worker.
+type Config struct {
+	Facade Facade
+
+	// Environ is the model's environ. If it implements
+	// environs.DNSZones, the cloud's DNS service can be used.
+	Environ       environs.Environ
+	Clock         clock.Clock
+	RetryDelay    time.Duration
+	CredentialAPI common.CredentialAPI
+
+	// NewRFC2136Zone returns the zone used by the rfc2136 DNS
+	// backend.
+	NewRFC2136Zone func(rfc2136.Config) (dns.Zone, error)
+}
+
+// Validate returns an error if the config cannot be used to start a
+// worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Environ == nil {
+		return errors.NotValidf("nil Environ")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	if config.CredentialAPI == nil {
+		return errors.NotValidf("nil CredentialAPI")
+	}
+	if config.NewRFC2136Zone == nil {
+		return errors.NotValidf("nil NewRFC2136Zone")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that brings the records in the model's
+// DNS domain, <model>.<dns-zone>, in line with the addresses of the
+// exposed applications and their units whenever they change. Records in
+// the domain that no longer belong to an exposed application or unit
+// are removed; nothing outside the domain is touched.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &dnsWorker{
+		config:      config,
+		callContext: common.NewCloudCallContext(config.CredentialAPI),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type dnsWorker struct {
+	catacomb    catacomb.Catacomb
+	config      Config
+	callContext context.ProviderCallContext
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *dnsWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *dnsWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *dnsWorker) loop() error {
+	watch, err := w.config.Facade.WatchRecords()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watch); err != nil {
+		return errors.Trace(err)
+	}
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watch.Changes():
+			if !ok {
+				return errors.New("DNS records watcher closed")
+			}
+		case <-retry:
+		}
+		synced, err := w.update()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		retry = nil
+		if !synced {
+			retry = w.config.Clock.After(w.config.RetryDelay)
+		}
+	}
+}
+
+// update brings the zone's records in line with the exposed
+// applications, and reports whether it succeeded. Failures to reach
+// the zone, or to update an individual record, are logged and retried
+// after the RetryDelay.
+func (w *dnsWorker) update() (bool, error) {
+	cfg, err := w.config.Facade.DNSConfig()
+	if err != nil {
+		return false, errors.Annotate(err, "getting DNS config")
+	}
+	if cfg.Zone == "" {
+		return true, nil
+	}
+	records, err := w.config.Facade.Records()
+	if err != nil {
+		return false, errors.Annotate(err, "getting DNS records")
+	}
+	zone, err := w.openZone(cfg)
+	if err != nil {
+		logger.Errorf("opening DNS zone %q: %v", cfg.Zone, err)
+		return false, nil
+	}
+
+	domain := cfg.ModelName + "." + cfg.Zone
+	var wanted []dns.Record
+	for _, record := range records {
+		wanted = append(wanted, dns.AddressRecords(record.Name+"."+domain, cfg.TTL, record.Addresses)...)
+	}
+	existing, err := zone.Records(domain)
+	if err != nil {
+		logger.Errorf("listing DNS records in %q: %v", domain, err)
+		return false, nil
+	}
+
+	key := func(record dns.Record) string {
+		return dns.Fqdn(record.Name) + " " + record.Type
+	}
+	synced := true
+	current := make(map[string]dns.Record)
+	for _, record := range existing {
+		current[key(record)] = record
+	}
+	for _, record := range wanted {
+		if last, ok := current[key(record)]; ok {
+			delete(current, key(record))
+			if last.Equal(record) {
+				continue
+			}
+		}
+		logger.Debugf("setting %s records for %q to %v", record.Type, record.Name, record.Values)
+		if err := zone.SetRecord(record); err != nil {
+			logger.Errorf("%v", err)
+			synced = false
+		}
+	}
+	for _, record := range existing {
+		if _, ok := current[key(record)]; !ok {
+			continue
+		}
+		logger.Debugf("removing %s records for %q", record.Type, record.Name)
+		if err := zone.RemoveRecord(record); err != nil && !errors.IsNotFound(err) {
+			logger.Errorf("%v", err)
+			synced = false
+		}
+	}
+	return synced, nil
+}
+
+// openZone returns the zone configured by the model's dns-backend.
+func (w *dnsWorker) openZone(cfg dnsrecords.DNSConfig) (dns.Zone, error) {
+	switch cfg.Backend {
+	case config.DNSBackendCloud:
+		zones, ok := w.config.Environ.(environs.DNSZones)
+		if !ok {
+			return nil, errors.NotSupportedf("%s DNS backend on this cloud", cfg.Backend)
+		}
+		return zones.DNSZone(w.callContext, cfg.Zone)
+	case config.DNSBackendRFC2136:
+		var keyName, secret string
+		if cfg.TSIGKey != "" {
+			var err error
+			keyName, secret, err = rfc2136.ParseTSIGKey(cfg.TSIGKey)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		return w.config.NewRFC2136Zone(rfc2136.Config{
+			Server:      cfg.RFC2136Server,
+			Zone:        cfg.Zone,
+			TSIGKeyName: keyName,
+			TSIGSecret:  secret,
+			Clock:       w.config.Clock,
+		})
+	default:
+		return nil, errors.NotSupportedf("%s DNS backend", cfg.Backend)
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnsrecords_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	apidnsrecords "github.com/juju/juju/api/dnsrecords"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/dns"
+	"github.com/juju/juju/dns/rfc2136"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/dnsrecords"
+)
+
+const retryDelay = time.Minute
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	facade  *fakeFacade
+	environ *fakeEnviron
+	zone    *fakeZone
+
+	rfc2136Config rfc2136.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.facade = &fakeFacade{
+		changes: make(chan struct{}),
+		config: apidnsrecords.DNSConfig{
+			ModelName: "testmodel",
+			Zone:      "example.com.",
+			TTL:       60,
+			Backend:   config.DNSBackendCloud,
+		},
+		records: []apidnsrecords.Record{{
+			Name:      "wordpress",
+			Addresses: []string{"203.0.113.1", "203.0.113.2", "2001:db8::1"},
+		}, {
+			Name:      "wordpress-0",
+			Addresses: []string{"203.0.113.1", "2001:db8::1"},
+		}, {
+			Name:      "wordpress-1",
+			Addresses: []string{"203.0.113.2"},
+		}},
+	}
+	s.zone = &fakeZone{
+		records: []dns.Record{
+			{"wordpress.testmodel.example.com.", dns.TypeA, 60, []string{"203.0.113.1", "203.0.113.2"}},
+			{"wordpress-0.testmodel.example.com.", dns.TypeA, 60, []string{"203.0.113.9"}},
+			{"wordpress-2.testmodel.example.com.", dns.TypeA, 60, []string{"203.0.113.3"}},
+		},
+	}
+	s.environ = &fakeEnviron{zone: s.zone}
+}
+
+func (s *workerSuite) config() dnsrecords.Config {
+	return dnsrecords.Config{
+		Facade:        s.facade,
+		Environ:       s.environ,
+		Clock:         s.clock,
+		RetryDelay:    retryDelay,
+		CredentialAPI: &fakeCredentialAPI{},
+		NewRFC2136Zone: func(config rfc2136.Config) (dns.Zone, error) {
+			s.rfc2136Config = config
+			return s.zone, nil
+		},
+	}
+}
+
+func (s *workerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := dnsrecords.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+// sendChange sends a change to the worker's watcher. Once the worker
+// has received it, the worker finishes updating the zone before it
+// looks at anything else, so it is safe to kill the worker and check
+// the calls it made.
+func (s *workerSuite) sendChange(c *gc.C) {
+	select {
+	case s.facade.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending change")
+	}
+}
+
+// waitRetry waits for the worker to schedule a retry after a failed
+// update.
+func (s *workerSuite) waitRetry(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for retry")
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.Environ = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Environ not valid")
+
+	config = s.config()
+	config.Clock = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Clock not valid")
+
+	config = s.config()
+	config.RetryDelay = 0
+	c.Check(config.Validate(), gc.ErrorMatches, "non-positive RetryDelay not valid")
+
+	config = s.config()
+	config.CredentialAPI = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil CredentialAPI not valid")
+
+	config = s.config()
+	config.NewRFC2136Zone = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil NewRFC2136Zone not valid")
+}
+
+func (s *workerSuite) TestSyncsRecords(c *gc.C) {
+	w := s.startWorker(c)
+	s.sendChange(c)
+	s.sendChange(c)
+	workertest.CleanKill(c, w)
+
+	s.facade.CheckCallNames(c, "WatchRecords", "DNSConfig", "Records", "DNSConfig", "Records")
+	s.environ.CheckCalls(c, []testing.StubCall{
+		{"DNSZone", []interface{}{"example.com."}},
+		{"DNSZone", []interface{}{"example.com."}},
+	})
+	// Records that are already in the zone are not set again.
+	s.zone.CheckCalls(c, []testing.StubCall{
+		{"Records", []interface{}{"testmodel.example.com."}},
+		{"SetRecord", []interface{}{dns.Record{"wordpress.testmodel.example.com.", dns.TypeAAAA, 60, []string{"2001:db8::1"}}}},
+		{"SetRecord", []interface{}{dns.Record{"wordpress-0.testmodel.example.com.", dns.TypeA, 60, []string{"203.0.113.1"}}}},
+		{"SetRecord", []interface{}{dns.Record{"wordpress-0.testmodel.example.com.", dns.TypeAAAA, 60, []string{"2001:db8::1"}}}},
+		{"SetRecord", []interface{}{dns.Record{"wordpress-1.testmodel.example.com.", dns.TypeA, 60, []string{"203.0.113.2"}}}},
+		{"RemoveRecord", []interface{}{dns.Record{"wordpress-2.testmodel.example.com.", dns.TypeA, 60, []string{"203.0.113.3"}}}},
+		{"Records", []interface{}{"testmodel.example.com."}},
+	})
+}
+
+func (s *workerSuite) TestNoZone(c *gc.C) {
+	s.facade.config.Zone = ""
+	w := s.startWorker(c)
+	s.sendChange(c)
+	workertest.CleanKill(c, w)
+
+	s.facade.CheckCallNames(c, "WatchRecords", "DNSConfig")
+	s.environ.CheckNoCalls(c)
+}
+
+func (s *workerSuite) TestRFC2136Backend(c *gc.C) {
+	s.facade.config.Backend = config.DNSBackendRFC2136
+	s.facade.config.RFC2136Server = "10.0.0.53"
+	s.facade.config.TSIGKey = "juju-key:c2VjcmV0"
+	w := s.startWorker(c)
+	s.sendChange(c)
+	workertest.CleanKill(c, w)
+
+	s.environ.CheckNoCalls(c)
+	c.Check(s.rfc2136Config, jc.DeepEquals, rfc2136.Config{
+		Server:      "10.0.0.53",
+		Zone:        "example.com.",
+		TSIGKeyName: "juju-key",
+		TSIGSecret:  "c2VjcmV0",
+		Clock:       s.clock,
+	})
+	c.Check(s.zone.Calls(), gc.Not(gc.HasLen), 0)
+}
+
+func (s *workerSuite) TestZoneErrorRetried(c *gc.C) {
+	s.environ.SetErrors(errors.NotFoundf("hosted zone %q", "example.com."))
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.waitRetry(c)
+	s.zone.CheckNoCalls(c)
+
+	s.clock.Advance(retryDelay)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.zone.Calls()) > 0 {
+			break
+		}
+	}
+	s.environ.CheckCallNames(c, "DNSZone", "DNSZone")
+	s.zone.CheckCall(c, 0, "Records", "testmodel.example.com.")
+}
+
+func (s *workerSuite) TestRecordErrorDoesNotBlockOthers(c *gc.C) {
+	s.zone.SetErrors(nil, errors.New("throttled"))
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.waitRetry(c)
+	s.zone.CheckCallNames(c, "Records", "SetRecord", "SetRecord", "SetRecord", "SetRecord", "RemoveRecord")
+	workertest.CheckAlive(c, w)
+}
+
+func (s *workerSuite) TestRecordsError(c *gc.C) {
+	s.facade.SetErrors(nil, nil, errors.New("boom"))
+	w := s.startWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	s.sendChange(c)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting DNS records: boom")
+}
+
+type fakeFacade struct {
+	testing.Stub
+	changes chan struct{}
+	config  apidnsrecords.DNSConfig
+	records []apidnsrecords.Record
+}
+
+func (f *fakeFacade) WatchRecords() (watcher.NotifyWatcher, error) {
+	f.MethodCall(f, "WatchRecords")
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
+
+func (f *fakeFacade) DNSConfig() (apidnsrecords.DNSConfig, error) {
+	f.MethodCall(f, "DNSConfig")
+	return f.config, f.NextErr()
+}
+
+func (f *fakeFacade) Records() ([]apidnsrecords.Record, error) {
+	f.MethodCall(f, "Records")
+	return f.records, f.NextErr()
+}
+
+type fakeEnviron struct {
+	environs.Environ
+	testing.Stub
+	zone *fakeZone
+}
+
+func (e *fakeEnviron) DNSZone(ctx context.ProviderCallContext, name string) (dns.Zone, error) {
+	e.MethodCall(e, "DNSZone", name)
+	if err := e.NextErr(); err != nil {
+		return nil, err
+	}
+	return e.zone, nil
+}
+
+// fakeZone is a dns.Zone whose records follow successful calls to
+// SetRecord and RemoveRecord.
+type fakeZone struct {
+	testing.Stub
+	records []dns.Record
+}
+
+func (z *fakeZone) Records(domain string) ([]dns.Record, error) {
+	z.MethodCall(z, "Records", domain)
+	return append([]dns.Record(nil), z.records...), z.NextErr()
+}
+
+func (z *fakeZone) SetRecord(record dns.Record) error {
+	z.MethodCall(z, "SetRecord", record)
+	if err := z.NextErr(); err != nil {
+		return err
+	}
+	z.remove(record)
+	z.records = append(z.records, record)
+	return nil
+}
+
+func (z *fakeZone) RemoveRecord(record dns.Record) error {
+	z.MethodCall(z, "RemoveRecord", record)
+	if err := z.NextErr(); err != nil {
+		return err
+	}
+	z.remove(record)
+	return nil
+}
+
+func (z *fakeZone) remove(record dns.Record) {
+	var records []dns.Record
+	for _, r := range z.records {
+		if r.Name != record.Name || r.Type != record.Type {
+			records = append(records, r)
+		}
+	}
+	z.records = records
+}
+
+type fakeCredentialAPI struct{}
+
+func (*fakeCredentialAPI) InvalidateModelCredential(reason string) error {
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egressfirewall
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/exec"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/base"
+	apiegressfirewall "github.com/juju/juju/api/egressfirewall"
+	"github.com/juju/juju/network"
+)
+
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return apiegressfirewall.NewFacade(apiCaller), nil
+}
+
+func NewWorker(config Config) (worker.Worker, error) {
+	worker, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
+
+// resolvConfPath is the path of the file listing the nameservers that
+// the machine resolves names with.
+const resolvConfPath = "/etc/resolv.conf"
+
+func readNameservers() ([]string, error) {
+	dnsConfig, err := network.ParseResolvConf(resolvConfPath)
+	if err != nil || dnsConfig == nil {
+		return nil, errors.Trace(err)
+	}
+	nameservers := make([]string, len(dnsConfig.Nameservers))
+	for i, address := range dnsConfig.Nameservers {
+		nameservers[i] = address.Value
+	}
+	return nameservers, nil
+}
+
+func runCommands(commands string) error {
+	result, err := exec.RunCommands(exec.RunParams{Commands: commands})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Code != 0 {
+		return errors.Errorf("exit code %d: %s", result.Code, result.Stderr)
+	}
+	return nil
+}
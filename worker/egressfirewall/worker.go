@@ -0,0 +1,162 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package egressfirewall provides a worker that enforces the model's
+// egress-policy with iptables rules on machines whose outbound traffic
+// the cloud can't restrict, such as containers and manually provisioned
+// machines.
+package egressfirewall
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/network/iptables"
+)
+
+var logger = loggo.GetLogger("juju.worker.egressfirewall")
+
+// Facade exposes the controller functionality needed by the worker.
+type Facade interface {
+	// EgressRules returns the egress rules of the machine, and
+	// whether the machine must enforce them itself.
+	EgressRules(machineId string) (params.EgressRules, bool, error)
+
+	// WatchEgressRules returns a watcher that notifies when the
+	// results of EgressRules may have changed.
+	WatchEgressRules(machineId string) (watcher.NotifyWatcher, error)
+}
+
+// Config holds the dependencies and configuration for the worker.
+type Config struct {
+	Facade    Facade
+	MachineId string
+
+	// RunCommands runs the given bash commands, returning an error if
+	// they fail.
+	RunCommands func(commands string) error
+
+	// ReadNameservers returns the addresses of the nameservers that
+	// the machine resolves names with, which it may always reach.
+	ReadNameservers func() ([]string, error)
+}
+
+// Validate returns an error if the config cannot be used to start a
+// worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.MachineId == "" {
+		return errors.NotValidf("empty MachineId")
+	}
+	if config.RunCommands == nil {
+		return errors.NotValidf("nil RunCommands")
+	}
+	if config.ReadNameservers == nil {
+		return errors.NotValidf("nil ReadNameservers")
+	}
+	return nil
+}
+
+// New returns a worker that watches the machine's egress rules, and
+// installs iptables rules allowing outbound traffic only to their
+// destinations when the machine must enforce them. Otherwise any such
+// iptables rules are removed.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &egressWorker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type egressWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// applied holds the commands that were most recently run, so
+	// that unchanged rules are not reinstalled on every change.
+	applied string
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *egressWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *egressWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *egressWorker) loop() error {
+	watch, err := w.config.Facade.WatchEgressRules(w.config.MachineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watch); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watch.Changes():
+			if !ok {
+				return errors.New("egress rules watcher closed")
+			}
+			if err := w.update(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// update installs or removes the iptables rules. Failures to run the
+// commands are logged, and the same commands are not run again until
+// the rules change; running them again would only flush the chain
+// without installing its rules.
+func (w *egressWorker) update() error {
+	rules, enforce, err := w.config.Facade.EgressRules(w.config.MachineId)
+	if err != nil {
+		return errors.Annotate(err, "getting egress rules")
+	}
+	cmd := iptables.EgressRulesCommand{Delete: true}
+	if enforce && rules.Restricted {
+		nameservers, err := w.config.ReadNameservers()
+		if err != nil {
+			return errors.Annotate(err, "reading nameservers")
+		}
+		cmd = iptables.EgressRulesCommand{
+			DestinationCIDRs: rules.DestinationCIDRs,
+			Nameservers:      nameservers,
+		}
+	}
+	commands := cmd.Render()
+	if commands == w.applied {
+		return nil
+	}
+	w.applied = commands
+	if err := w.config.RunCommands(commands); err != nil {
+		logger.Errorf("updating egress rules: %v", err)
+		return nil
+	}
+	if cmd.Delete {
+		logger.Debugf("removed egress rules")
+	} else {
+		logger.Infof("restricted egress to %v", rules.DestinationCIDRs)
+	}
+	return nil
+}
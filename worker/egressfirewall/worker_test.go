@@ -0,0 +1,191 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egressfirewall_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/network/iptables"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/egressfirewall"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	facade *fakeFacade
+	runner *testing.Stub
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.facade = &fakeFacade{
+		changes: make(chan struct{}),
+		rules: params.EgressRules{
+			Restricted:       true,
+			DestinationCIDRs: []string{"10.0.0.1/32"},
+		},
+		enforce: true,
+	}
+	s.runner = &testing.Stub{}
+}
+
+func (s *workerSuite) config() egressfirewall.Config {
+	return egressfirewall.Config{
+		Facade:    s.facade,
+		MachineId: "0",
+		RunCommands: func(commands string) error {
+			s.runner.AddCall("RunCommands", commands)
+			return s.runner.NextErr()
+		},
+		ReadNameservers: func() ([]string, error) {
+			return []string{"10.0.0.2"}, nil
+		},
+	}
+}
+
+func (s *workerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := egressfirewall.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+// sendChange sends a change to the worker's watcher. Once the worker
+// has received it, the worker finishes updating the rules before it
+// looks at anything else, so it is safe to kill the worker and check
+// the calls it made.
+func (s *workerSuite) sendChange(c *gc.C) {
+	select {
+	case s.facade.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending change")
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.MachineId = ""
+	c.Check(config.Validate(), gc.ErrorMatches, "empty MachineId not valid")
+
+	config = s.config()
+	config.RunCommands = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil RunCommands not valid")
+
+	config = s.config()
+	config.ReadNameservers = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil ReadNameservers not valid")
+}
+
+func (s *workerSuite) TestEnforces(c *gc.C) {
+	w := s.startWorker(c)
+	s.sendChange(c)
+	workertest.CleanKill(c, w)
+
+	s.facade.CheckCalls(c, []testing.StubCall{
+		{"WatchEgressRules", []interface{}{"0"}},
+		{"EgressRules", []interface{}{"0"}},
+	})
+	s.runner.CheckCalls(c, []testing.StubCall{{"RunCommands", []interface{}{
+		iptables.EgressRulesCommand{
+			DestinationCIDRs: []string{"10.0.0.1/32"},
+			Nameservers:      []string{"10.0.0.2"},
+		}.Render(),
+	}}})
+}
+
+func (s *workerSuite) TestUnchangedRulesNotReinstalled(c *gc.C) {
+	w := s.startWorker(c)
+	s.sendChange(c)
+	s.sendChange(c)
+	workertest.CleanKill(c, w)
+
+	s.facade.CheckCallNames(c, "WatchEgressRules", "EgressRules", "EgressRules")
+	s.runner.CheckCallNames(c, "RunCommands")
+}
+
+func (s *workerSuite) TestNotEnforcedRemoves(c *gc.C) {
+	s.facade.enforce = false
+	w := s.startWorker(c)
+	s.sendChange(c)
+	workertest.CleanKill(c, w)
+
+	s.runner.CheckCalls(c, []testing.StubCall{{"RunCommands", []interface{}{
+		iptables.EgressRulesCommand{Delete: true}.Render(),
+	}}})
+}
+
+func (s *workerSuite) TestNotRestrictedRemoves(c *gc.C) {
+	s.facade.rules = params.EgressRules{}
+	w := s.startWorker(c)
+	s.sendChange(c)
+	workertest.CleanKill(c, w)
+
+	s.runner.CheckCalls(c, []testing.StubCall{{"RunCommands", []interface{}{
+		iptables.EgressRulesCommand{Delete: true}.Render(),
+	}}})
+}
+
+func (s *workerSuite) TestRunErrorNotRepeated(c *gc.C) {
+	s.runner.SetErrors(errors.New("iptables: command not found"))
+	w := s.startWorker(c)
+	s.sendChange(c)
+	s.sendChange(c)
+	workertest.CheckAlive(c, w)
+	workertest.CleanKill(c, w)
+
+	// Running the same commands again would only flush the chain, so
+	// they are not run again until the rules change.
+	s.runner.CheckCallNames(c, "RunCommands")
+}
+
+func (s *workerSuite) TestFacadeError(c *gc.C) {
+	s.facade.SetErrors(nil, errors.New("boom"))
+	w := s.startWorker(c)
+	s.sendChange(c)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting egress rules: boom")
+}
+
+func (s *workerSuite) TestWatcherClosed(c *gc.C) {
+	w := s.startWorker(c)
+	close(s.facade.changes)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "egress rules watcher closed")
+}
+
+type fakeFacade struct {
+	testing.Stub
+	changes chan struct{}
+	rules   params.EgressRules
+	enforce bool
+}
+
+func (f *fakeFacade) EgressRules(machineId string) (params.EgressRules, bool, error) {
+	f.MethodCall(f, "EgressRules", machineId)
+	return f.rules, f.enforce, f.NextErr()
+}
+
+func (f *fakeFacade) WatchEgressRules(machineId string) (watcher.NotifyWatcher, error) {
+	f.MethodCall(f, "WatchEgressRules", machineId)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
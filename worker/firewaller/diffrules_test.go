@@ -129,3 +129,17 @@ func (s *DiffRulesSuite) TestDiffRangesClosesPortsIfRulesAreDisjoint(c *gc.C) {
 	c.Assert(toOpen, gc.DeepEquals, wanted)
 	c.Assert(toClose, gc.DeepEquals, current)
 }
+
+func (s *DiffRulesSuite) TestNoSourceCIDRsIsIPv4Only(c *gc.C) {
+	current := []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 90),
+	}
+	wanted := []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0", "::/0"),
+	}
+	toOpen, toClose := diffRanges(current, wanted)
+	c.Assert(toClose, gc.HasLen, 0)
+	c.Assert(toOpen, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 90, "::/0"),
+	})
+}
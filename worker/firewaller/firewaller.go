@@ -5,6 +5,7 @@ package firewaller
 
 import (
 	"io"
+	"net"
 	"strings"
 	"time"
 
@@ -36,6 +37,8 @@ import (
 type FirewallerAPI interface {
 	WatchModelMachines() (watcher.StringsWatcher, error)
 	WatchOpenedPorts() (watcher.StringsWatcher, error)
+	WatchSubnetSpaces() (watcher.NotifyWatcher, error)
+	WatchEgressRules() (watcher.NotifyWatcher, error)
 	Machine(tag names.MachineTag) (*firewaller.Machine, error)
 	Unit(tag names.UnitTag) (*firewaller.Unit, error)
 	Relation(tag names.RelationTag) (*firewaller.Relation, error)
@@ -84,6 +87,10 @@ type Config struct {
 	EnvironFirewaller  EnvironFirewaller
 	EnvironInstances   EnvironInstances
 
+	// EnvironEgressFirewaller, if set, is used to apply the model's
+	// egress-policy to the instances of the model's machines.
+	EnvironEgressFirewaller environs.EgressFirewaller
+
 	NewCrossModelFacadeFunc newCrossModelFacadeFunc
 
 	Clock clock.Clock
@@ -129,8 +136,16 @@ type Firewaller struct {
 	environFirewaller  EnvironFirewaller
 	environInstances   EnvironInstances
 
+	environEgressFirewaller environs.EgressFirewaller
+
+	// ipv6Ingress records whether ports may be opened to IPv6 source
+	// CIDRs; IPv6 CIDRs are left out of ingress rules otherwise.
+	ipv6Ingress bool
+
 	machinesWatcher      watcher.StringsWatcher
 	portsWatcher         watcher.StringsWatcher
+	subnetSpacesWatcher  watcher.NotifyWatcher
+	egressRulesWatcher   watcher.NotifyWatcher
 	machineds            map[names.MachineTag]*machineData
 	unitsChange          chan *unitsChange
 	unitds               map[names.UnitTag]*unitData
@@ -165,6 +180,8 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 		remoteRelationsApi:         cfg.RemoteRelationsApi,
 		environFirewaller:          cfg.EnvironFirewaller,
 		environInstances:           cfg.EnvironInstances,
+		environEgressFirewaller:    cfg.EnvironEgressFirewaller,
+		ipv6Ingress:                environs.SupportsIPv6Ingress(cfg.EnvironFirewaller),
 		newRemoteFirewallerAPIFunc: cfg.NewCrossModelFacadeFunc,
 		modelUUID:                  cfg.ModelUUID,
 		machineds:                  make(map[names.MachineTag]*machineData),
@@ -226,6 +243,37 @@ func (fw *Firewaller) setUp() error {
 		return errors.Trace(err)
 	}
 
+	// The CIDRs of the spaces that applications are exposed to change
+	// with the model's subnets.
+	fw.subnetSpacesWatcher, err = fw.firewallerApi.WatchSubnetSpaces()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not watching subnet spaces: %v", err)
+	} else if err != nil {
+		return errors.Annotatef(err, "failed to start subnet spaces watcher")
+	} else if err := fw.catacomb.Add(fw.subnetSpacesWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	// The machines' egress rules are applied through the cloud when
+	// it can restrict outbound traffic; otherwise the machines
+	// enforce them themselves.
+	if fw.environEgressFirewaller != nil {
+		supported, err := fw.environEgressFirewaller.SupportsEgressRules(fw.cloudCallContext)
+		if err != nil {
+			return errors.Annotate(err, "checking for egress rules support")
+		}
+		if supported {
+			fw.egressRulesWatcher, err = fw.firewallerApi.WatchEgressRules()
+			if errors.IsNotSupported(err) {
+				logger.Debugf("not applying egress rules: %v", err)
+			} else if err != nil {
+				return errors.Annotatef(err, "failed to start egress rules watcher")
+			} else if err := fw.catacomb.Add(fw.egressRulesWatcher); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+
 	fw.remoteRelationsWatcher, err = fw.remoteRelationsApi.WatchRemoteRelations()
 	if err != nil {
 		return errors.Trace(err)
@@ -244,6 +292,14 @@ func (fw *Firewaller) loop() error {
 	}
 	var reconciled bool
 	portsChange := fw.portsWatcher.Changes()
+	var subnetSpacesChange watcher.NotifyChannel
+	if fw.subnetSpacesWatcher != nil {
+		subnetSpacesChange = fw.subnetSpacesWatcher.Changes()
+	}
+	var egressRulesChange watcher.NotifyChannel
+	if fw.egressRulesWatcher != nil {
+		egressRulesChange = fw.egressRulesWatcher.Changes()
+	}
 	for {
 		select {
 		case <-fw.catacomb.Dying():
@@ -282,6 +338,13 @@ func (fw *Firewaller) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case _, ok := <-subnetSpacesChange:
+			if !ok {
+				return errors.New("subnet spaces watcher closed")
+			}
+			for _, applicationd := range fw.applicationids {
+				applicationd.notifySubnetsChanged()
+			}
 		case change, ok := <-fw.remoteRelationsWatcher.Changes():
 			if !ok {
 				return errors.New("remote relations watcher closed")
@@ -304,6 +367,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.applicationd.exposed = change.exposed
+			change.applicationd.exposedCIDRs = change.cidrs
 			unitds := []*unitData{}
 			for _, unitd := range change.applicationd.unitds {
 				unitds = append(unitds, unitd)
@@ -311,10 +375,82 @@ func (fw *Firewaller) loop() error {
 			if err := fw.flushUnits(unitds); err != nil {
 				return errors.Annotate(err, "cannot change firewall ports")
 			}
+		case _, ok := <-egressRulesChange:
+			if !ok {
+				return errors.New("egress rules watcher closed")
+			}
+			if err := fw.flushEgressRules(); err != nil {
+				return errors.Annotate(err, "cannot change egress rules")
+			}
 		}
 	}
 }
 
+// flushEgressRules applies the egress rules of the tracked machines to
+// their instances. Machines without an instance yet are left alone;
+// provisioning them changes the rules, which triggers another flush.
+func (fw *Firewaller) flushEgressRules() error {
+	for _, machined := range fw.machineds {
+		if err := fw.flushMachineEgressRules(machined); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (fw *Firewaller) flushMachineEgressRules(machined *machineData) error {
+	m, err := machined.machine()
+	if params.IsCodeNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	rules, err := m.EgressRules()
+	if params.IsCodeNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	want := environs.EgressRules{
+		Restricted:       rules.Restricted,
+		DestinationCIDRs: rules.DestinationCIDRs,
+	}
+	instanceId, err := m.InstanceId()
+	if params.IsCodeNotProvisioned(err) || params.IsCodeNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	machineId := machined.tag.Id()
+	if machined.egressRules == nil {
+		current, err := fw.environEgressFirewaller.EgressRules(fw.cloudCallContext, instanceId, machineId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		machined.egressRules = &current
+	}
+	if egressRulesEqual(*machined.egressRules, want) {
+		return nil
+	}
+	if err := fw.environEgressFirewaller.SetEgressRules(fw.cloudCallContext, instanceId, machineId, want); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("set egress rules %+v on %q", want, machined.tag)
+	machined.egressRules = &want
+	return nil
+}
+
+func egressRulesEqual(a, b environs.EgressRules) bool {
+	if a.Restricted != b.Restricted {
+		return false
+	}
+	if !a.Restricted {
+		return true
+	}
+	return set.NewStrings(a.DestinationCIDRs...).Difference(set.NewStrings(b.DestinationCIDRs...)).IsEmpty() &&
+		set.NewStrings(b.DestinationCIDRs...).Difference(set.NewStrings(a.DestinationCIDRs...)).IsEmpty()
+}
+
 func (fw *Firewaller) relationIngressChanged(change *remoteRelationNetworkChange) error {
 	logger.Debugf("process remote relation ingress change for %v", change.relationTag)
 	relData, ok := fw.relationIngress[change.relationTag]
@@ -468,22 +604,24 @@ func (fw *Firewaller) startUnit(unit *firewaller.Unit, machineTag names.MachineT
 // startApplication creates a new data value for tracking details of the
 // application and starts watching the application for exposure changes.
 func (fw *Firewaller) startApplication(app *firewaller.Application) error {
-	exposed, err := app.IsExposed()
+	exposed, cidrs, err := app.ExposeInfo()
 	if err != nil {
 		return err
 	}
 	applicationd := &applicationData{
-		fw:          fw,
-		application: app,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:            fw,
+		application:   app,
+		exposed:       exposed,
+		exposedCIDRs:  set.NewStrings(cidrs...),
+		unitds:        make(map[names.UnitTag]*unitData),
+		subnetsChange: make(chan struct{}, 1),
 	}
 	fw.applicationids[app.Tag()] = applicationd
 
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed)
+			return applicationd.watchLoop(exposed, applicationd.exposedCIDRs)
 		},
 	})
 	if err != nil {
@@ -752,16 +890,22 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 			}
 
 			cidrs := set.NewStrings()
-			// If the unit is exposed, allow access from everywhere.
+			// If the unit is exposed, allow access from the sources
+			// the application is exposed to.
 			if unitd.applicationd.exposed {
-				cidrs.Add("0.0.0.0/0")
-			} else {
-				// Not exposed, so add any ingress rules required by remote relations.
+				cidrs = cidrs.Union(unitd.applicationd.exposedCIDRs)
+			}
+			if !cidrs.Contains("0.0.0.0/0") && !cidrs.Contains("::/0") {
+				// Not exposed to everywhere, so add any ingress rules
+				// required by remote relations.
 				if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
 					return nil, errors.Trace(err)
 				}
 				logger.Debugf("CIDRS for %v: %v", unitTag, cidrs.Values())
 			}
+			if !fw.ipv6Ingress {
+				cidrs = ipv4CIDRs(cidrs)
+			}
 			if cidrs.Size() > 0 {
 				for portRange := range portRanges {
 					sourceCidrs := cidrs.SortedValues()
@@ -777,6 +921,20 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 	return want, nil
 }
 
+// ipv4CIDRs returns the IPv4 CIDRs of the given set, for clouds whose
+// ingress rules can't have IPv6 sources.
+func ipv4CIDRs(cidrs set.Strings) set.Strings {
+	result := set.NewStrings()
+	for _, cidr := range cidrs.Values() {
+		if ip, _, err := net.ParseCIDR(cidr); err == nil && ip.To4() == nil {
+			logger.Debugf("not opening ports to IPv6 source %v", cidr)
+			continue
+		}
+		result.Add(cidr)
+	}
+	return result
+}
+
 // TODO(wallyworld) - consider making this configurable.
 const maxAllowedCIDRS = 20
 
@@ -785,6 +943,7 @@ func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag
 	// Now create the rules for any remote relations of which the
 	// unit's application is a part.
 	newCidrs := make(set.Strings)
+	var ipv6 bool
 	for _, data := range fw.relationIngress {
 		if data.localApplicationTag != appTag {
 			continue
@@ -794,6 +953,7 @@ func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag
 		}
 		for _, cidr := range data.networks.Values() {
 			newCidrs.Add(cidr)
+			ipv6 = ipv6 || strings.Contains(cidr, ":")
 		}
 	}
 	// If we have too many CIDRs to create a rule for, consolidate.
@@ -823,9 +983,13 @@ func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag
 				}
 			}
 		}
-		// No relevant firewall rule exists, so go public.
+		// No relevant firewall rule exists, so go public, including
+		// over IPv6 if any of the relations need it.
 		if newCidrs.Size() == 0 {
 			newCidrs.Add("0.0.0.0/0")
+			if ipv6 {
+				newCidrs.Add("::/0")
+			}
 		}
 	}
 	for _, cidr := range newCidrs.Values() {
@@ -1027,6 +1191,9 @@ type machineData struct {
 	ingressRules []network.IngressRule
 	// ports defined by units on this machine
 	definedPorts map[names.UnitTag]portRanges
+	// egressRules are the egress rules last known to be applied to
+	// the machine's instance, or nil if they are not known yet.
+	egressRules *environs.EgressRules
 }
 
 func (md *machineData) machine() (*firewaller.Machine, error) {
@@ -1074,23 +1241,42 @@ type unitData struct {
 	machined     *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific application.
+// exposedChange contains the changed exposed flag and ingress CIDRs
+// for one specific application.
 type exposedChange struct {
 	applicationd *applicationData
 	exposed      bool
+	cidrs        set.Strings
 }
 
 // applicationData holds application details and watches exposure changes.
 type applicationData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	application *firewaller.Application
-	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	catacomb     catacomb.Catacomb
+	fw           *Firewaller
+	application  *firewaller.Application
+	exposed      bool
+	exposedCIDRs set.Strings
+	unitds       map[names.UnitTag]*unitData
+
+	// subnetsChange is signalled when the model's subnets change,
+	// which changes the CIDRs of the spaces that the application may
+	// be exposed to.
+	subnetsChange chan struct{}
+}
+
+// notifySubnetsChanged asks the application to check whether its
+// ingress CIDRs changed with the model's subnets. It does not block;
+// a pending notification covers any further ones.
+func (ad *applicationData) notifySubnetsChanged() {
+	select {
+	case ad.subnetsChange <- struct{}{}:
+	default:
+	}
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(exposed bool) error {
+// watchLoop watches the application's exposed flag and ingress CIDRs
+// for changes, rechecking the CIDRs when the model's subnets change.
+func (ad *applicationData) watchLoop(exposed bool, cidrs set.Strings) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1115,20 +1301,24 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 				}
 				return nil
 			}
-			change, err := ad.application.IsExposed()
-			if err != nil {
-				return errors.Trace(err)
-			}
-			if change == exposed {
-				continue
-			}
+		case <-ad.subnetsChange:
+		}
+		changeExposed, changeCIDRs, err := ad.application.ExposeInfo()
+		if params.IsCodeNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		newCIDRs := set.NewStrings(changeCIDRs...)
+		if changeExposed == exposed && newCIDRs.Difference(cidrs).IsEmpty() && cidrs.Difference(newCIDRs).IsEmpty() {
+			continue
+		}
 
-			exposed = change
-			select {
-			case <-ad.catacomb.Dying():
-				return ad.catacomb.ErrDying()
-			case ad.fw.exposedChange <- &exposedChange{ad, change}:
-			}
+		exposed, cidrs = changeExposed, newCIDRs
+		select {
+		case <-ad.catacomb.Dying():
+			return ad.catacomb.ErrDying()
+		case ad.fw.exposedChange <- &exposedChange{ad, changeExposed, newCIDRs}:
 		}
 	}
 }
@@ -1173,6 +1363,8 @@ func diffRanges(currentRules, wantedRules []network.IngressRule) (toOpen, toClos
 			}
 			ruleCidrs := rule.SourceCIDRs
 			if len(ruleCidrs) == 0 {
+				// Providers open rules without source CIDRs to
+				// IPv4 only, so ::/0 is still opened when wanted.
 				ruleCidrs = []string{"0.0.0.0/0"}
 			}
 			for _, cidr := range ruleCidrs {
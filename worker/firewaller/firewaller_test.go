@@ -6,11 +6,13 @@ package firewaller_test
 import (
 	"fmt"
 	"reflect"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/juju/clock"
 	"github.com/juju/clock/testclock"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -196,12 +198,17 @@ func (s *firewallerBaseSuite) startInstance(c *gc.C, m *state.Machine) instance.
 
 type InstanceModeSuite struct {
 	firewallerBaseSuite
+
+	// noIPv6Ingress, if set, makes the firewaller's environ report
+	// that it can't open ports to IPv6 sources.
+	noIPv6Ingress bool
 }
 
 var _ = gc.Suite(&InstanceModeSuite{})
 
 func (s *InstanceModeSuite) SetUpTest(c *gc.C) {
 	s.firewallerBaseSuite.setUpTest(c, config.FwInstance)
+	s.noIPv6Ingress = false
 }
 
 func (s *InstanceModeSuite) TearDownTest(c *gc.C) {
@@ -232,6 +239,9 @@ func (s *InstanceModeSuite) newFirewallerWithClock(c *gc.C, clock clock.Clock) w
 	s.clock = clock
 	fwEnv, ok := s.Environ.(environs.Firewaller)
 	c.Assert(ok, gc.Equals, true)
+	if s.noIPv6Ingress {
+		fwEnv = ipv4OnlyFirewaller{fwEnv}
+	}
 
 	cfg := firewaller.Config{
 		ModelUUID:          s.State.ModelUUID(),
@@ -294,15 +304,15 @@ func (s *InstanceModeSuite) TestExposedApplication(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0"),
-		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0", "::/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0", "::/0"),
 	})
 
 	err = u.ClosePorts("tcp", 80, 90)
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0", "::/0"),
 	})
 }
 
@@ -332,11 +342,11 @@ func (s *InstanceModeSuite) TestMultipleExposedApplications(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.assertPorts(c, inst1, m1.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
-		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0", "::/0"),
 	})
 	s.assertPorts(c, inst2, m2.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 3306, 3306, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 3306, 3306, "0.0.0.0/0", "::/0"),
 	})
 
 	err = u1.ClosePort("tcp", 80)
@@ -345,7 +355,7 @@ func (s *InstanceModeSuite) TestMultipleExposedApplications(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.assertPorts(c, inst1, m1.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0", "::/0"),
 	})
 	s.assertPorts(c, inst2, m2.Id(), nil)
 }
@@ -367,14 +377,14 @@ func (s *InstanceModeSuite) TestMachineWithoutInstanceId(c *gc.C) {
 	err = u2.OpenPort("tcp", 80)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertPorts(c, inst2, m2.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
 	})
 
 	inst1 := s.startInstance(c, m1)
 	err = u1.OpenPort("tcp", 8080)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertPorts(c, inst1, m1.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0", "::/0"),
 	})
 }
 
@@ -397,10 +407,10 @@ func (s *InstanceModeSuite) TestMultipleUnits(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.assertPorts(c, inst1, m1.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
 	})
 	s.assertPorts(c, inst2, m2.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
 	})
 
 	err = u1.ClosePort("tcp", 80)
@@ -432,8 +442,8 @@ func (s *InstanceModeSuite) TestStartWithState(c *gc.C) {
 	defer statetesting.AssertKillAndWait(c, fw)
 
 	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
-		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0", "::/0"),
 	})
 
 	err = app.SetExposed()
@@ -464,7 +474,7 @@ func (s *InstanceModeSuite) TestStartWithPartialState(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
 	})
 }
 
@@ -491,7 +501,7 @@ func (s *InstanceModeSuite) TestStartWithUnexposedApplication(c *gc.C) {
 	err = app.SetExposed()
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
 	})
 }
 
@@ -547,8 +557,8 @@ func (s *InstanceModeSuite) TestSetClearExposedApplication(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
-		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0", "::/0"),
 	})
 
 	// ClearExposed closes the ports again.
@@ -558,6 +568,147 @@ func (s *InstanceModeSuite) TestSetClearExposedApplication(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestExposeSettingsApplication(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err := u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Expose settings open the ports to their CIDRs only.
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/24", "192.168.1.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24", "192.168.1.0/24"),
+	})
+
+	// Changing the settings changes the ingress CIDRs.
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24"),
+	})
+
+	// ClearExposed closes the ports again.
+	err = app.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), nil)
+}
+
+func (s *InstanceModeSuite) TestExposeToSpaceSubnetsChange(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("public", "", []string{"10.0.0.0/24"}, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToSpaces: []string{"public"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24"),
+	})
+
+	// Adding a subnet to the space opens the ports to it too, without
+	// the application changing.
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.1.0.0/24", SpaceName: "public"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24", "10.1.0.0/24"),
+	})
+}
+
+// mockEgressFirewaller records the egress rules set on instances.
+type mockEgressFirewaller struct {
+	mu    sync.Mutex
+	rules map[string]environs.EgressRules
+}
+
+func (m *mockEgressFirewaller) SupportsEgressRules(ctx context.ProviderCallContext) (bool, error) {
+	return true, nil
+}
+
+func (m *mockEgressFirewaller) SetEgressRules(ctx context.ProviderCallContext, id instance.Id, machineId string, rules environs.EgressRules) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rules[machineId] = rules
+	return nil
+}
+
+func (m *mockEgressFirewaller) EgressRules(ctx context.ProviderCallContext, id instance.Id, machineId string) (environs.EgressRules, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.rules[machineId], nil
+}
+
+func (m *mockEgressFirewaller) machineRules(machineId string) environs.EgressRules {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.rules[machineId]
+}
+
+func (s *InstanceModeSuite) TestEgressRules(c *gc.C) {
+	fwEnv, ok := s.Environ.(environs.Firewaller)
+	c.Assert(ok, gc.Equals, true)
+	egressEnv := &mockEgressFirewaller{rules: make(map[string]environs.EgressRules)}
+	fw, err := firewaller.NewFirewaller(firewaller.Config{
+		ModelUUID:               s.State.ModelUUID(),
+		Mode:                    config.FwInstance,
+		EnvironFirewaller:       fwEnv,
+		EnvironInstances:        s.Environ,
+		EnvironEgressFirewaller: egressEnv,
+		FirewallerAPI:           s.firewaller,
+		RemoteRelationsApi:      s.remoteRelations,
+		NewCrossModelFacadeFunc: func(*api.Info) (firewaller.CrossModelFirewallerFacadeCloser, error) {
+			return s.crossmodelFirewaller, nil
+		},
+		Clock:         &mockClock{c: c},
+		CredentialAPI: s.credentialsFacade,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	_, m := s.addUnit(c, app)
+	s.startInstance(c, m)
+
+	err = s.Model.UpdateModelConfig(map[string]interface{}{
+		"egress-policy":        "relations",
+		"egress-allowed-cidrs": "192.168.10.0/24",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		rules := egressEnv.machineRules(m.Id())
+		if rules.Restricted {
+			c.Assert(set.NewStrings(rules.DestinationCIDRs...).Contains("192.168.10.0/24"), jc.IsTrue)
+			return
+		}
+	}
+	c.Fatalf("timed out waiting for egress rules")
+}
+
 func (s *InstanceModeSuite) TestRemoveUnit(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -577,10 +728,10 @@ func (s *InstanceModeSuite) TestRemoveUnit(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.assertPorts(c, inst1, m1.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
 	})
 	s.assertPorts(c, inst2, m2.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
 	})
 
 	// Remove unit.
@@ -591,7 +742,7 @@ func (s *InstanceModeSuite) TestRemoveUnit(c *gc.C) {
 
 	s.assertPorts(c, inst1, m1.Id(), nil)
 	s.assertPorts(c, inst2, m2.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
 	})
 }
 
@@ -609,7 +760,7 @@ func (s *InstanceModeSuite) TestRemoveApplication(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
 	})
 
 	// Remove application.
@@ -645,10 +796,10 @@ func (s *InstanceModeSuite) TestRemoveMultipleApplications(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.assertPorts(c, inst1, m1.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
 	})
 	s.assertPorts(c, inst2, m2.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 3306, 3306, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 3306, 3306, "0.0.0.0/0", "::/0"),
 	})
 
 	// Remove applications.
@@ -684,7 +835,7 @@ func (s *InstanceModeSuite) TestDeadMachine(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
 	})
 
 	// Remove unit and application, also tested without. Has no effect.
@@ -718,7 +869,7 @@ func (s *InstanceModeSuite) TestRemoveMachine(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
 	})
 
 	// Remove unit.
@@ -1204,6 +1355,33 @@ func (s *InstanceModeSuite) TestRemoteRelationIngressFallbackToPublic(c *gc.C) {
 	s.assertIngressCidrs(c, ingress, []string{"0.0.0.0/0"})
 }
 
+func (s *InstanceModeSuite) TestRemoteRelationIngressIPv6(c *gc.C) {
+	s.assertIngressCidrs(c, []string{"10.0.0.4/16", "2001:db8::/64"}, []string{"10.0.0.4/16", "2001:db8::/64"})
+}
+
+func (s *InstanceModeSuite) TestRemoteRelationIngressIPv6Unsupported(c *gc.C) {
+	s.noIPv6Ingress = true
+	s.assertIngressCidrs(c, []string{"10.0.0.4/16", "2001:db8::/64"}, []string{"10.0.0.4/16"})
+}
+
+// ipv4OnlyFirewaller is an environs.Firewaller that can't open ports
+// to IPv6 sources.
+type ipv4OnlyFirewaller struct {
+	environs.Firewaller
+}
+
+func (ipv4OnlyFirewaller) SupportsIPv6Ingress() bool {
+	return false
+}
+
+func (s *InstanceModeSuite) TestRemoteRelationIngressFallbackToPublicIPv6(c *gc.C) {
+	var ingress []string
+	for i := 1; i < 30; i++ {
+		ingress = append(ingress, fmt.Sprintf("2001:db8:%d::1/128", i))
+	}
+	s.assertIngressCidrs(c, ingress, []string{"0.0.0.0/0", "::/0"})
+}
+
 func (s *InstanceModeSuite) TestRemoteRelationIngressFallbackToWhitelist(c *gc.C) {
 	fwRules := state.NewFirewallRules(s.State)
 	err := fwRules.Save(state.FirewallRule{
@@ -1320,23 +1498,23 @@ func (s *GlobalModeSuite) TestGlobalMode(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.assertEnvironPorts(c, []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0"),
-		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0", "::/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0", "::/0"),
 	})
 
 	// Closing a port opened by a different unit won't touch the environment.
 	err = u1.ClosePorts("tcp", 80, 90)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertEnvironPorts(c, []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0"),
-		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0", "::/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0", "::/0"),
 	})
 
 	// Closing a port used just once changes the environment.
 	err = u1.ClosePort("tcp", 8080)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertEnvironPorts(c, []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0", "::/0"),
 	})
 
 	// Closing the last port also modifies the environment.
@@ -1368,7 +1546,7 @@ func (s *GlobalModeSuite) TestStartWithUnexposedApplication(c *gc.C) {
 	err = app.SetExposed()
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertEnvironPorts(c, []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
 	})
 }
 
@@ -1388,8 +1566,8 @@ func (s *GlobalModeSuite) TestRestart(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.assertEnvironPorts(c, []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0"),
-		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0", "::/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0", "::/0"),
 	})
 
 	// Stop firewaller and close one and open a different port.
@@ -1406,8 +1584,8 @@ func (s *GlobalModeSuite) TestRestart(c *gc.C) {
 	defer statetesting.AssertKillAndWait(c, fw)
 
 	s.assertEnvironPorts(c, []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0"),
-		network.MustNewIngressRule("tcp", 8888, 8888, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0", "::/0"),
+		network.MustNewIngressRule("tcp", 8888, 8888, "0.0.0.0/0", "::/0"),
 	})
 }
 
@@ -1427,8 +1605,8 @@ func (s *GlobalModeSuite) TestRestartUnexposedApplication(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.assertEnvironPorts(c, []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
-		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0", "::/0"),
 	})
 
 	// Stop firewaller and clear exposed flag on application.
@@ -1461,8 +1639,8 @@ func (s *GlobalModeSuite) TestRestartPortCount(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.assertEnvironPorts(c, []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
-		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0", "::/0"),
 	})
 
 	// Stop firewaller and add another application using the port.
@@ -1483,23 +1661,23 @@ func (s *GlobalModeSuite) TestRestartPortCount(c *gc.C) {
 	defer statetesting.AssertKillAndWait(c, fw)
 
 	s.assertEnvironPorts(c, []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
-		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0", "::/0"),
 	})
 
 	// Closing a port opened by a different unit won't touch the environment.
 	err = u1.ClosePort("tcp", 80)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertEnvironPorts(c, []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
-		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0", "::/0"),
 	})
 
 	// Closing a port used just once changes the environment.
 	err = u1.ClosePort("tcp", 8080)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertEnvironPorts(c, []network.IngressRule{
-		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
 	})
 
 	// Closing the last port also modifies the environment.
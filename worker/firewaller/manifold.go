@@ -108,6 +108,10 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 		}
 	}
 
+	// The egress firewaller is optional; machines enforce the
+	// model's egress-policy themselves when the cloud can't.
+	egressEnv, _ := environs.SupportsEgressFirewall(environ)
+
 	firewallerAPI, err := cfg.NewFirewallerFacade(apiConn)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -123,12 +127,13 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 	}
 
 	w, err := cfg.NewFirewallerWorker(Config{
-		ModelUUID:          agent.CurrentConfig().Model().Id(),
-		RemoteRelationsApi: remoteRelationsAPI,
-		FirewallerAPI:      firewallerAPI,
-		EnvironFirewaller:  fwEnv,
-		EnvironInstances:   environ,
-		Mode:               mode,
+		ModelUUID:               agent.CurrentConfig().Model().Id(),
+		RemoteRelationsApi:      remoteRelationsAPI,
+		FirewallerAPI:           firewallerAPI,
+		EnvironFirewaller:       fwEnv,
+		EnvironInstances:        environ,
+		EnvironEgressFirewaller: egressEnv,
+		Mode:                    mode,
 		NewCrossModelFacadeFunc: crossmodelFirewallerFacadeFunc(cfg.NewControllerConnection),
 		CredentialAPI:           credentialAPI,
 	})
@@ -0,0 +1,214 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package loadbalancer provides a worker that keeps a cloud load
+// balancer in front of each exposed application, when the model's
+// expose-load-balancers config is set and the cloud supports it.
+package loadbalancer
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/api/loadbalancer"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/worker/common"
+)
+
+var logger = loggo.GetLogger("juju.worker.loadbalancer")
+
+// Facade exposes the controller functionality needed by the worker.
+type Facade interface {
+	// ApplicationTargets returns the exposed applications and the
+	// traffic their load balancers should forward.
+	ApplicationTargets() ([]loadbalancer.Target, error)
+
+	// SetApplicationAddresses records the load balancer in front of
+	// an application.
+	SetApplicationAddresses(applicationName, providerId string, addresses []network.Address) error
+}
+
+// Environ is the part of an environs.Environ that the worker needs.
+type Environ interface {
+	environs.LoadBalancers
+
+	// Config returns the current model configuration.
+	Config() *config.Config
+}
+
+// Config holds the dependencies and configuration for the worker.
+type Config struct {
+	Facade        Facade
+	Environ       Environ
+	Clock         clock.Clock
+	Interval      time.Duration
+	CredentialAPI common.CredentialAPI
+}
+
+// Validate returns an error if the config cannot be used to start a
+// worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Environ == nil {
+		return errors.NotValidf("nil Environ")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	if config.CredentialAPI == nil {
+		return errors.NotValidf("nil CredentialAPI")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that periodically ensures that each
+// exposed application has a load balancer forwarding its open ports to
+// the instances of its healthy units, and that load balancers for
+// applications that are no longer exposed are removed. The addresses
+// of the load balancers are recorded as the applications' public
+// addresses.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &lbWorker{
+		config:      config,
+		callContext: common.NewCloudCallContext(config.CredentialAPI),
+		recorded:    make(map[string]environs.LoadBalancer),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type lbWorker struct {
+	catacomb    catacomb.Catacomb
+	config      Config
+	callContext context.ProviderCallContext
+
+	// recorded holds the load balancers most recently recorded
+	// against each application, so that unchanged addresses are not
+	// written on every poll.
+	recorded map[string]environs.LoadBalancer
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *lbWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *lbWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *lbWorker) loop() error {
+	var delay time.Duration
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(delay):
+			if err := w.update(); err != nil {
+				return errors.Trace(err)
+			}
+			delay = w.config.Interval
+		}
+	}
+}
+
+// update brings the cloud's load balancers in line with the exposed
+// applications. Failures to manage an individual load balancer are
+// logged and retried on the next poll, so that one application cannot
+// hold up the others.
+func (w *lbWorker) update() error {
+	var targets []loadbalancer.Target
+	if w.config.Environ.Config().ExposeLoadBalancers() {
+		var err error
+		targets, err = w.config.Facade.ApplicationTargets()
+		if err != nil {
+			return errors.Annotate(err, "getting load balancer targets")
+		}
+	}
+
+	wanted := set.NewStrings()
+	for _, target := range targets {
+		if len(target.Ports) == 0 {
+			// There is nothing to balance until the
+			// application opens a port.
+			continue
+		}
+		wanted.Add(target.ApplicationName)
+		lb, err := w.config.Environ.EnsureLoadBalancer(w.callContext, environs.LoadBalancerParams{
+			ApplicationName: target.ApplicationName,
+			Ports:           target.Ports,
+			Instances:       target.InstanceIds,
+		})
+		if err != nil {
+			logger.Errorf("ensuring load balancer for %q: %v", target.ApplicationName, err)
+			continue
+		}
+		if err := w.record(target.ApplicationName, lb); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	existing, err := w.config.Environ.LoadBalancerApplications(w.callContext)
+	if err != nil {
+		return errors.Annotate(err, "listing load balancers")
+	}
+	for _, appName := range existing {
+		if wanted.Contains(appName) {
+			continue
+		}
+		logger.Infof("removing load balancer for %q", appName)
+		err := w.config.Environ.RemoveLoadBalancer(w.callContext, appName)
+		if err != nil && !errors.IsNotFound(err) {
+			logger.Errorf("removing load balancer for %q: %v", appName, err)
+			continue
+		}
+		if err := w.record(appName, environs.LoadBalancer{}); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// record records the load balancer against the application, if it
+// differs from what was last recorded. Applications that have since
+// been removed are ignored.
+func (w *lbWorker) record(appName string, lb environs.LoadBalancer) error {
+	if last, ok := w.recorded[appName]; ok && reflect.DeepEqual(last, lb) {
+		return nil
+	}
+	err := w.config.Facade.SetApplicationAddresses(appName, lb.Id, lb.Addresses)
+	if params.IsCodeNotFound(err) {
+		delete(w.recorded, appName)
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "recording load balancer for %q", appName)
+	}
+	w.recorded[appName] = lb
+	return nil
+}
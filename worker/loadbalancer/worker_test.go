@@ -0,0 +1,249 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package loadbalancer_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	apiloadbalancer "github.com/juju/juju/api/loadbalancer"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/loadbalancer"
+)
+
+const interval = time.Minute
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	facade  *fakeFacade
+	environ *fakeEnviron
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.facade = &fakeFacade{
+		targets: []apiloadbalancer.Target{{
+			ApplicationName: "wordpress",
+			Ports:           []network.PortRange{network.MustParsePortRange("80/tcp")},
+			InstanceIds:     []instance.Id{"inst-0", "inst-1"},
+		}, {
+			ApplicationName: "mysql",
+		}},
+	}
+	s.environ = &fakeEnviron{
+		config: coretesting.CustomModelConfig(c, coretesting.Attrs{
+			config.ExposeLoadBalancersKey: true,
+		}),
+		existing: []string{"wordpress", "old"},
+	}
+}
+
+func (s *workerSuite) config() loadbalancer.Config {
+	return loadbalancer.Config{
+		Facade:        s.facade,
+		Environ:       s.environ,
+		Clock:         s.clock,
+		Interval:      interval,
+		CredentialAPI: &fakeCredentialAPI{},
+	}
+}
+
+func (s *workerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := loadbalancer.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+// waitPolled waits for the worker to finish a poll and start waiting
+// for the next one.
+func (s *workerSuite) waitPolled(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for poll")
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.Environ = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Environ not valid")
+
+	config = s.config()
+	config.Clock = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Clock not valid")
+
+	config = s.config()
+	config.Interval = 0
+	c.Check(config.Validate(), gc.ErrorMatches, "non-positive Interval not valid")
+
+	config = s.config()
+	config.CredentialAPI = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil CredentialAPI not valid")
+}
+
+func (s *workerSuite) TestEnsuresAndRemoves(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	// The first poll happens immediately.
+	s.waitPolled(c)
+	s.waitPolled(c)
+
+	addrs := []network.Address{network.NewScopedAddress("203.0.113.10", network.ScopePublic)}
+	s.environ.CheckCalls(c, []testing.StubCall{
+		{"EnsureLoadBalancer", []interface{}{environs.LoadBalancerParams{
+			ApplicationName: "wordpress",
+			Ports:           []network.PortRange{network.MustParsePortRange("80/tcp")},
+			Instances:       []instance.Id{"inst-0", "inst-1"},
+		}}},
+		{"LoadBalancerApplications", nil},
+		{"RemoveLoadBalancer", []interface{}{"old"}},
+	})
+	s.facade.CheckCalls(c, []testing.StubCall{
+		{"ApplicationTargets", nil},
+		{"SetApplicationAddresses", []interface{}{"wordpress", "lb-wordpress", addrs}},
+		{"SetApplicationAddresses", []interface{}{"old", "", []network.Address(nil)}},
+	})
+
+	// Unchanged load balancers are not recorded again.
+	s.environ.ResetCalls()
+	s.facade.ResetCalls()
+	s.clock.Advance(interval)
+	s.waitPolled(c)
+	s.environ.CheckCallNames(c, "EnsureLoadBalancer", "LoadBalancerApplications")
+	s.facade.CheckCallNames(c, "ApplicationTargets")
+}
+
+func (s *workerSuite) TestDisabledRemovesAll(c *gc.C) {
+	s.environ.config = coretesting.ModelConfig(c)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitPolled(c)
+	s.waitPolled(c)
+
+	s.environ.CheckCalls(c, []testing.StubCall{
+		{"LoadBalancerApplications", nil},
+		{"RemoveLoadBalancer", []interface{}{"wordpress"}},
+		{"RemoveLoadBalancer", []interface{}{"old"}},
+	})
+	s.facade.CheckCalls(c, []testing.StubCall{
+		{"SetApplicationAddresses", []interface{}{"wordpress", "", []network.Address(nil)}},
+		{"SetApplicationAddresses", []interface{}{"old", "", []network.Address(nil)}},
+	})
+}
+
+func (s *workerSuite) TestEnsureErrorDoesNotBlockOthers(c *gc.C) {
+	s.environ.SetErrors(errors.New("quota exceeded"))
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitPolled(c)
+	s.waitPolled(c)
+
+	s.environ.CheckCallNames(c, "EnsureLoadBalancer", "LoadBalancerApplications", "RemoveLoadBalancer")
+	s.facade.CheckCallNames(c, "ApplicationTargets", "SetApplicationAddresses")
+	s.facade.CheckCall(c, 1, "SetApplicationAddresses", "old", "", []network.Address(nil))
+}
+
+func (s *workerSuite) TestIgnoresRemovedApplication(c *gc.C) {
+	s.facade.SetErrors(nil, nil, &params.Error{Code: params.CodeNotFound, Message: "application not found"})
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitPolled(c)
+	s.waitPolled(c)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *workerSuite) TestTargetsError(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting load balancer targets: boom")
+}
+
+type fakeFacade struct {
+	testing.Stub
+	targets []apiloadbalancer.Target
+}
+
+func (f *fakeFacade) ApplicationTargets() ([]apiloadbalancer.Target, error) {
+	f.MethodCall(f, "ApplicationTargets")
+	return f.targets, f.NextErr()
+}
+
+func (f *fakeFacade) SetApplicationAddresses(applicationName, providerId string, addresses []network.Address) error {
+	f.MethodCall(f, "SetApplicationAddresses", applicationName, providerId, addresses)
+	return f.NextErr()
+}
+
+type fakeEnviron struct {
+	testing.Stub
+	config   *config.Config
+	existing []string
+}
+
+func (e *fakeEnviron) Config() *config.Config {
+	return e.config
+}
+
+func (e *fakeEnviron) EnsureLoadBalancer(ctx context.ProviderCallContext, args environs.LoadBalancerParams) (environs.LoadBalancer, error) {
+	e.MethodCall(e, "EnsureLoadBalancer", args)
+	if err := e.NextErr(); err != nil {
+		return environs.LoadBalancer{}, err
+	}
+	return environs.LoadBalancer{
+		Id:        "lb-" + args.ApplicationName,
+		Addresses: []network.Address{network.NewScopedAddress("203.0.113.10", network.ScopePublic)},
+	}, nil
+}
+
+func (e *fakeEnviron) RemoveLoadBalancer(ctx context.ProviderCallContext, applicationName string) error {
+	e.MethodCall(e, "RemoveLoadBalancer", applicationName)
+	if err := e.NextErr(); err != nil {
+		return err
+	}
+	for i, name := range e.existing {
+		if name == applicationName {
+			e.existing = append(e.existing[:i], e.existing[i+1:]...)
+			return nil
+		}
+	}
+	return errors.NotFoundf("load balancer for %q", applicationName)
+}
+
+func (e *fakeEnviron) LoadBalancerApplications(ctx context.ProviderCallContext) ([]string, error) {
+	e.MethodCall(e, "LoadBalancerApplications")
+	return append([]string(nil), e.existing...), e.NextErr()
+}
+
+type fakeCredentialAPI struct{}
+
+func (*fakeCredentialAPI) InvalidateModelCredential(reason string) error {
+	return nil
+}
@@ -0,0 +1,338 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package networkconfigurer implements the machine agent worker which
+// applies the bonds, VLANs and static routes defined for a manually
+// provisioned machine or an LXD container to its netplan configuration.
+//
+// The worker only ever adds to the netplan configuration. Once applied,
+// the entries can't be told apart from the machine's own configuration,
+// so bonds, VLANs and routes that are later removed from, or changed
+// in, the machine's definitions are left in place; the worker logs an
+// error for each of them, and they need removing from the machine's
+// netplan configuration by hand.
+package networkconfigurer
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/machinelock"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/network/netplan"
+)
+
+var logger = loggo.GetLogger("juju.worker.networkconfigurer")
+
+const (
+	// NetplanDirectory is where the system's netplan configuration
+	// is kept.
+	NetplanDirectory = "/etc/netplan"
+
+	// activateTimeout is how long we wait for netplan to apply a new
+	// configuration.
+	activateTimeout = 5 * time.Minute
+)
+
+// Facade exposes controller functionality to a Worker.
+type Facade interface {
+	NetworkDefinitions(names.MachineTag) (params.NetworkDefinitionsResult, error)
+	WatchNetworkDefinitions(names.MachineTag) (watcher.NotifyWatcher, error)
+}
+
+// ActivateFunc applies network changes to the machine's netplan
+// configuration; netplan.ConfigureAndActivate satisfies it.
+type ActivateFunc func(netplan.ActivationParams) (*netplan.ActivationResult, error)
+
+// Config defines the parameters of the networkconfigurer worker.
+type Config struct {
+	Facade           Facade
+	Tag              names.MachineTag
+	MachineLock      machinelock.Lock
+	Clock            clock.Clock
+	NetplanDirectory string
+	Activate         ActivateFunc
+}
+
+// Validate returns an error if Config cannot drive a networkconfigurer.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Tag.Id() == "" {
+		return errors.NotValidf("empty Tag")
+	}
+	if config.MachineLock == nil {
+		return errors.NotValidf("nil MachineLock")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.NetplanDirectory == "" {
+		return errors.NotValidf("empty NetplanDirectory")
+	}
+	if config.Activate == nil {
+		return errors.NotValidf("nil Activate")
+	}
+	return nil
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &networkConfigurer{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// networkConfigurer watches the network definitions of the machine, and
+// applies them whenever they change.
+type networkConfigurer struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// applied holds the definitions last applied, so unrelated
+	// subnet changes don't cause netplan to be reapplied. It includes
+	// every entry applied since the worker started, including those
+	// since removed from the machine's definitions.
+	applied *params.NetworkDefinitionsResult
+}
+
+// Kill implements worker.Worker.
+func (w *networkConfigurer) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (w *networkConfigurer) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *networkConfigurer) loop() error {
+	definitionsWatcher, err := w.config.Facade.WatchNetworkDefinitions(w.config.Tag)
+	if params.IsCodeNotSupported(err) {
+		logger.Debugf("network of machine %q is not configured by its agent", w.config.Tag.Id())
+		return dependency.ErrUninstall
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(definitionsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-definitionsWatcher.Changes():
+			if !ok {
+				return errors.New("network definitions watcher closed")
+			}
+			if err := w.configure(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// configure applies the machine's network definitions, if they changed
+// since they were last applied.
+func (w *networkConfigurer) configure() error {
+	defs, err := w.config.Facade.NetworkDefinitions(w.config.Tag)
+	if params.IsCodeNotSupported(err) {
+		return dependency.ErrUninstall
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if w.applied != nil {
+		defs = w.rejectRemovals(*w.applied, defs)
+	}
+	if w.applied != nil && reflect.DeepEqual(*w.applied, defs) {
+		return nil
+	}
+	activationParams := w.activationParams(defs)
+	if len(activationParams.Bonds) == 0 && len(activationParams.VLANs) == 0 && len(activationParams.Routes) == 0 {
+		logger.Debugf("no network definitions for machine %q", w.config.Tag.Id())
+		w.applied = &defs
+		return nil
+	}
+
+	releaser, err := w.config.MachineLock.Acquire(machinelock.Spec{
+		Cancel:  w.catacomb.Dying(),
+		Worker:  "networkconfigurer",
+		Comment: "applying network definitions",
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer releaser()
+
+	logger.Infof(
+		"applying %d bonds, %d VLANs and routes for %d devices to machine %q",
+		len(activationParams.Bonds), len(activationParams.VLANs), len(activationParams.Routes), w.config.Tag.Id(),
+	)
+	result, err := w.config.Activate(activationParams)
+	if err != nil {
+		if result != nil {
+			logger.Errorf("netplan activation output: %q %q", result.Stdout, result.Stderr)
+		}
+		return errors.Annotate(err, "cannot apply network definitions")
+	}
+	w.applied = &defs
+	return nil
+}
+
+// rejectRemovals returns defs with the bonds, VLANs and routes of
+// applied that are missing from, or changed in, defs put back, and logs
+// an error for each of them; see the package documentation.
+func (w *networkConfigurer) rejectRemovals(applied, defs params.NetworkDefinitionsResult) params.NetworkDefinitionsResult {
+	machineId := w.config.Tag.Id()
+	result := params.NetworkDefinitionsResult{
+		Bonds:  append([]params.NetworkBond(nil), defs.Bonds...),
+		VLANs:  append([]params.NetworkVLAN(nil), defs.VLANs...),
+		Routes: append([]params.NetworkDeviceRoutes(nil), defs.Routes...),
+	}
+
+	bonds := make(map[string]int)
+	for i, bond := range result.Bonds {
+		bonds[bond.Name] = i
+	}
+	for _, bond := range applied.Bonds {
+		i, ok := bonds[bond.Name]
+		if !ok {
+			logger.Errorf("cannot remove bond %q from machine %q; remove it from netplan by hand", bond.Name, machineId)
+			result.Bonds = append(result.Bonds, bond)
+		} else if !reflect.DeepEqual(result.Bonds[i], bond) {
+			logger.Errorf("cannot change bond %q of machine %q; change it in netplan by hand", bond.Name, machineId)
+			result.Bonds[i] = bond
+		}
+	}
+
+	vlans := make(map[string]int)
+	for i, vlan := range result.VLANs {
+		vlans[vlan.Name] = i
+	}
+	for _, vlan := range applied.VLANs {
+		i, ok := vlans[vlan.Name]
+		if !ok {
+			logger.Errorf("cannot remove VLAN %q from machine %q; remove it from netplan by hand", vlan.Name, machineId)
+			result.VLANs = append(result.VLANs, vlan)
+		} else if !reflect.DeepEqual(result.VLANs[i], vlan) {
+			logger.Errorf("cannot change VLAN %q of machine %q; change it in netplan by hand", vlan.Name, machineId)
+			result.VLANs[i] = vlan
+		}
+	}
+
+	for _, device := range applied.Routes {
+		i := 0
+		for ; i < len(result.Routes); i++ {
+			if result.Routes[i].DeviceName == device.DeviceName && result.Routes[i].MACAddress == device.MACAddress {
+				break
+			}
+		}
+		if i == len(result.Routes) {
+			result.Routes = append(result.Routes, params.NetworkDeviceRoutes{
+				DeviceName: device.DeviceName,
+				MACAddress: device.MACAddress,
+			})
+		}
+		routes := append([]params.NetworkRoute(nil), result.Routes[i].Routes...)
+		for _, route := range device.Routes {
+			if !hasRoute(routes, route) {
+				logger.Errorf(
+					"cannot remove route to %s via %s from device %q of machine %q; remove it from netplan by hand",
+					route.DestinationCIDR, route.GatewayIP, device.DeviceName, machineId,
+				)
+				routes = append(routes, route)
+			}
+		}
+		result.Routes[i].Routes = routes
+	}
+	return result
+}
+
+// hasRoute returns whether routes has a route to the same destination
+// through the same gateway as route; netplan.AddRoutes tells routes
+// apart the same way.
+func hasRoute(routes []params.NetworkRoute, route params.NetworkRoute) bool {
+	for _, other := range routes {
+		if other.DestinationCIDR == route.DestinationCIDR && other.GatewayIP == route.GatewayIP {
+			return true
+		}
+	}
+	return false
+}
+
+// activationParams converts the network definitions into the netplan
+// changes which implement them.
+func (w *networkConfigurer) activationParams(defs params.NetworkDefinitionsResult) netplan.ActivationParams {
+	result := netplan.ActivationParams{
+		Clock:     w.config.Clock,
+		Directory: w.config.NetplanDirectory,
+		Timeout:   activateTimeout,
+	}
+	for _, bond := range defs.Bonds {
+		var parameters netplan.BondParameters
+		if bond.Mode != "" {
+			mode := bond.Mode
+			parameters.Mode = netplan.IntString{String: &mode}
+		}
+		if bond.LACPRate != "" {
+			rate := bond.LACPRate
+			parameters.LACPRate = netplan.IntString{String: &rate}
+		}
+		if bond.MIIMonitorInterval > 0 {
+			interval := bond.MIIMonitorInterval
+			parameters.MIIMonitorInterval = &interval
+		}
+		parameters.TransmitHashPolicy = bond.TransmitHashPolicy
+		result.Bonds = append(result.Bonds, netplan.DeviceToBond{
+			BondName:    bond.Name,
+			DeviceNames: bond.DeviceNames,
+			Parameters:  parameters,
+		})
+	}
+	for _, vlan := range defs.VLANs {
+		result.VLANs = append(result.VLANs, netplan.DeviceToVLAN{
+			VLANName:         vlan.Name,
+			ParentName:       vlan.ParentName,
+			ParentMACAddress: vlan.ParentMACAddress,
+			VLANTag:          vlan.VLANTag,
+		})
+	}
+	for _, device := range defs.Routes {
+		routes := make([]netplan.Route, len(device.Routes))
+		for i, route := range device.Routes {
+			routes[i] = netplan.Route{
+				To:  route.DestinationCIDR,
+				Via: route.GatewayIP,
+			}
+			if route.Metric != 0 {
+				metric := route.Metric
+				routes[i].Metric = &metric
+			}
+		}
+		result.Routes = append(result.Routes, netplan.DeviceRoutes{
+			DeviceName: device.DeviceName,
+			MACAddress: device.MACAddress,
+			Routes:     routes,
+		})
+	}
+	return result
+}
@@ -0,0 +1,327 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package networkconfigurer_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1/dependency"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/machinelock"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/network/netplan"
+	"github.com/juju/juju/worker/networkconfigurer"
+)
+
+type Suite struct {
+	jujutesting.IsolationSuite
+
+	stub    *jujutesting.Stub
+	changes chan struct{}
+	facade  *stubFacade
+	clock   *testclock.Clock
+	config  networkconfigurer.Config
+}
+
+var _ = gc.Suite(&Suite{})
+
+func (s *Suite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = new(jujutesting.Stub)
+	s.changes = make(chan struct{})
+	s.facade = &stubFacade{stub: s.stub, changes: s.changes}
+	s.clock = testclock.NewClock(time.Now())
+	s.config = networkconfigurer.Config{
+		Facade:           s.facade,
+		Tag:              names.NewMachineTag("42"),
+		MachineLock:      &fakemachinelock{},
+		Clock:            s.clock,
+		NetplanDirectory: "/etc/netplan",
+		Activate: func(args netplan.ActivationParams) (*netplan.ActivationResult, error) {
+			s.stub.AddCall("Activate", args)
+			return nil, s.stub.NextErr()
+		},
+	}
+}
+
+func (s *Suite) TestInvalidConfig(c *gc.C) {
+	s.config.Activate = nil
+	_, err := networkconfigurer.New(s.config)
+	c.Check(err, gc.ErrorMatches, "nil Activate not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(s.stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *Suite) TestNotSupported(c *gc.C) {
+	s.stub.SetErrors(&params.Error{Code: params.CodeNotSupported, Message: "nope"})
+
+	w, err := networkconfigurer.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrUninstall)
+	s.stub.CheckCallNames(c, "WatchNetworkDefinitions")
+}
+
+func (s *Suite) TestAppliesChangedDefinitions(c *gc.C) {
+	defs := params.NetworkDefinitionsResult{
+		Bonds: []params.NetworkBond{{
+			Name:               "bond0",
+			DeviceNames:        []string{"eth0", "eth1"},
+			Mode:               "802.3ad",
+			LACPRate:           "fast",
+			MIIMonitorInterval: 100,
+			TransmitHashPolicy: "layer3+4",
+		}},
+		VLANs: []params.NetworkVLAN{{
+			Name:             "bond0.42",
+			ParentName:       "bond0",
+			ParentMACAddress: "aa:bb:cc:dd:ee:f0",
+			VLANTag:          42,
+		}},
+		Routes: []params.NetworkDeviceRoutes{{
+			DeviceName: "bond0.42",
+			Routes: []params.NetworkRoute{{
+				DestinationCIDR: "10.100.0.0/16",
+				GatewayIP:       "10.0.42.254",
+				Metric:          10,
+			}, {
+				DestinationCIDR: "10.200.0.0/16",
+				GatewayIP:       "10.0.42.253",
+			}},
+		}},
+	}
+	s.facade.setDefinitions(defs)
+	// Unchanged definitions aren't applied again.
+	s.facade.repeatDefinitions()
+	defs.Routes = append(defs.Routes, params.NetworkDeviceRoutes{
+		DeviceName: "eth2",
+		MACAddress: "aa:bb:cc:dd:ee:f2",
+		Routes: []params.NetworkRoute{{
+			DestinationCIDR: "10.100.0.0/16",
+			GatewayIP:       "10.0.0.254",
+		}},
+	})
+	s.facade.setDefinitions(defs)
+	w, err := networkconfigurer.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.sendChange(c)
+	s.sendChange(c)
+	s.sendChange(c)
+	workertest.CleanKill(c, w)
+
+	mode, rate, interval, metric := "802.3ad", "fast", 100, 10
+	s.stub.CheckCallNames(c,
+		"WatchNetworkDefinitions",
+		"NetworkDefinitions", "Activate",
+		"NetworkDefinitions",
+		"NetworkDefinitions", "Activate",
+	)
+	expected := netplan.ActivationParams{
+		Clock: s.clock,
+		Bonds: []netplan.DeviceToBond{{
+			BondName:    "bond0",
+			DeviceNames: []string{"eth0", "eth1"},
+			Parameters: netplan.BondParameters{
+				Mode:               netplan.IntString{String: &mode},
+				LACPRate:           netplan.IntString{String: &rate},
+				MIIMonitorInterval: &interval,
+				TransmitHashPolicy: "layer3+4",
+			},
+		}},
+		VLANs: []netplan.DeviceToVLAN{{
+			VLANName:         "bond0.42",
+			ParentName:       "bond0",
+			ParentMACAddress: "aa:bb:cc:dd:ee:f0",
+			VLANTag:          42,
+		}},
+		Routes: []netplan.DeviceRoutes{{
+			DeviceName: "bond0.42",
+			Routes: []netplan.Route{{
+				To:     "10.100.0.0/16",
+				Via:    "10.0.42.254",
+				Metric: &metric,
+			}, {
+				To:  "10.200.0.0/16",
+				Via: "10.0.42.253",
+			}},
+		}},
+		Directory: "/etc/netplan",
+		Timeout:   5 * time.Minute,
+	}
+	calls := s.stub.Calls()
+	c.Check(calls[2].Args, jc.DeepEquals, []interface{}{expected})
+	expected.Routes = append(expected.Routes, netplan.DeviceRoutes{
+		DeviceName: "eth2",
+		MACAddress: "aa:bb:cc:dd:ee:f2",
+		Routes: []netplan.Route{{
+			To:  "10.100.0.0/16",
+			Via: "10.0.0.254",
+		}},
+	})
+	c.Check(calls[5].Args, jc.DeepEquals, []interface{}{expected})
+}
+
+func (s *Suite) TestRejectsRemovals(c *gc.C) {
+	s.facade.setDefinitions(params.NetworkDefinitionsResult{
+		Bonds: []params.NetworkBond{{Name: "bond0", DeviceNames: []string{"eth0", "eth1"}}},
+		VLANs: []params.NetworkVLAN{{Name: "eth2.42", ParentName: "eth2", VLANTag: 42}},
+		Routes: []params.NetworkDeviceRoutes{{
+			DeviceName: "eth2",
+			Routes: []params.NetworkRoute{
+				{DestinationCIDR: "10.100.0.0/16", GatewayIP: "10.0.0.254"},
+				{DestinationCIDR: "10.200.0.0/16", GatewayIP: "10.0.0.254"},
+			},
+		}},
+	})
+	// The bond is removed, the VLAN's tag changes, one route is
+	// removed and another added. Only the new route is applied.
+	s.facade.setDefinitions(params.NetworkDefinitionsResult{
+		VLANs: []params.NetworkVLAN{{Name: "eth2.42", ParentName: "eth2", VLANTag: 43}},
+		Routes: []params.NetworkDeviceRoutes{{
+			DeviceName: "eth2",
+			Routes: []params.NetworkRoute{
+				{DestinationCIDR: "10.100.0.0/16", GatewayIP: "10.0.0.254"},
+				{DestinationCIDR: "10.30.0.0/16", GatewayIP: "10.0.0.254"},
+			},
+		}},
+	})
+	// Removing everything changes nothing.
+	s.facade.setDefinitions(params.NetworkDefinitionsResult{})
+	w, err := networkconfigurer.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.sendChange(c)
+	s.sendChange(c)
+	s.sendChange(c)
+	workertest.CleanKill(c, w)
+
+	s.stub.CheckCallNames(c,
+		"WatchNetworkDefinitions",
+		"NetworkDefinitions", "Activate",
+		"NetworkDefinitions", "Activate",
+		"NetworkDefinitions",
+	)
+	c.Check(s.stub.Calls()[4].Args, jc.DeepEquals, []interface{}{netplan.ActivationParams{
+		Clock: s.clock,
+		Bonds: []netplan.DeviceToBond{{
+			BondName:    "bond0",
+			DeviceNames: []string{"eth0", "eth1"},
+		}},
+		VLANs: []netplan.DeviceToVLAN{{
+			VLANName:   "eth2.42",
+			ParentName: "eth2",
+			VLANTag:    42,
+		}},
+		Routes: []netplan.DeviceRoutes{{
+			DeviceName: "eth2",
+			Routes: []netplan.Route{
+				{To: "10.100.0.0/16", Via: "10.0.0.254"},
+				{To: "10.30.0.0/16", Via: "10.0.0.254"},
+				{To: "10.200.0.0/16", Via: "10.0.0.254"},
+			},
+		}},
+		Directory: "/etc/netplan",
+		Timeout:   5 * time.Minute,
+	}})
+}
+
+func (s *Suite) TestNoDefinitions(c *gc.C) {
+	w, err := networkconfigurer.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.sendChange(c)
+	workertest.CleanKill(c, w)
+
+	s.stub.CheckCallNames(c, "WatchNetworkDefinitions", "NetworkDefinitions")
+}
+
+func (s *Suite) TestActivateError(c *gc.C) {
+	s.facade.setDefinitions(params.NetworkDefinitionsResult{
+		VLANs: []params.NetworkVLAN{{Name: "eth0.42", ParentName: "eth0", VLANTag: 42}},
+	})
+	s.stub.SetErrors(nil, nil, errors.New("blam"))
+
+	w, err := networkconfigurer.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.sendChange(c)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "cannot apply network definitions: blam")
+}
+
+func (s *Suite) sendChange(c *gc.C) {
+	select {
+	case s.changes <- struct{}{}:
+	case <-time.After(jujutesting.LongWait):
+		c.Fatalf("timed out sending change")
+	}
+}
+
+type stubFacade struct {
+	stub    *jujutesting.Stub
+	changes chan struct{}
+
+	// defs holds the definitions returned by successive calls to
+	// NetworkDefinitions; once exhausted, nothing is defined.
+	defs []params.NetworkDefinitionsResult
+}
+
+// setDefinitions queues the definitions returned by the next
+// NetworkDefinitions call.
+func (f *stubFacade) setDefinitions(defs params.NetworkDefinitionsResult) {
+	f.defs = append(f.defs, defs)
+}
+
+// repeatDefinitions queues the last definitions again.
+func (f *stubFacade) repeatDefinitions() {
+	f.defs = append(f.defs, f.defs[len(f.defs)-1])
+}
+
+func (f *stubFacade) NetworkDefinitions(tag names.MachineTag) (params.NetworkDefinitionsResult, error) {
+	f.stub.AddCall("NetworkDefinitions", tag)
+	var defs params.NetworkDefinitionsResult
+	if len(f.defs) > 0 {
+		defs, f.defs = f.defs[0], f.defs[1:]
+	}
+	return defs, f.stub.NextErr()
+}
+
+func (f *stubFacade) WatchNetworkDefinitions(tag names.MachineTag) (watcher.NotifyWatcher, error) {
+	f.stub.AddCall("WatchNetworkDefinitions", tag)
+	if err := f.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
+
+type fakemachinelock struct {
+	mu sync.Mutex
+}
+
+func (f *fakemachinelock) Acquire(spec machinelock.Spec) (func(), error) {
+	f.mu.Lock()
+	return func() {
+		f.mu.Unlock()
+	}, nil
+}
+
+func (f *fakemachinelock) Report(opts ...machinelock.ReportOption) (string, error) {
+	return "", nil
+}
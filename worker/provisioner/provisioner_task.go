@@ -1000,8 +1000,8 @@ func (task *provisionerTask) setupToStartMachine(machine *apiprovisioner.Machine
 }
 
 // populateExcludedMachines, translates the results of DeriveAvailabilityZones
-// into availabilityZoneMachines.ExcludedMachineIds for machines not to be used
-// in the given zone.
+// and the zones constraint into availabilityZoneMachines.ExcludedMachineIds
+// for machines not to be used in the given zone.
 func (task *provisionerTask) populateExcludedMachines(machineId string, startInstanceParams environs.StartInstanceParams) error {
 	zonedEnv, ok := task.broker.(providercommon.ZonedEnviron)
 	if !ok {
@@ -1011,14 +1011,20 @@ func (task *provisionerTask) populateExcludedMachines(machineId string, startIns
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if len(derivedZones) == 0 {
+	cons := startInstanceParams.Constraints
+	if len(derivedZones) == 0 && !cons.HasZones() {
 		return nil
 	}
 	task.machinesMutex.Lock()
 	defer task.machinesMutex.Unlock()
 	useZones := set.NewStrings(derivedZones...)
+	consZones := set.NewStrings(cons.IncludeZones()...)
+	avoidZones := set.NewStrings(cons.ExcludeZones()...)
 	for _, zoneMachines := range task.availabilityZoneMachines {
-		if !useZones.Contains(zoneMachines.ZoneName) {
+		zone := zoneMachines.ZoneName
+		if (!useZones.IsEmpty() && !useZones.Contains(zone)) ||
+			(!consZones.IsEmpty() && !consZones.Contains(zone)) ||
+			avoidZones.Contains(zone) {
 			zoneMachines.ExcludedMachineIds.Add(machineId)
 		}
 	}
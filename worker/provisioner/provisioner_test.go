@@ -1948,6 +1948,28 @@ func (s *ProvisionerSuite) TestProvisioningMachinesDerivedAZ(c *gc.C) {
 	}
 }
 
+func (s *ProvisionerSuite) TestProvisioningMachinesZonesConstraint(c *gc.C) {
+	// Per provider dummy, zone1, zone3 and zone4 are available.
+	task := s.newProvisionerTask(c, config.HarvestDestroyed, s.Environ, s.provisioner, &mockDistributionGroupFinder{}, mockToolsFinder{})
+	defer workertest.CleanKill(c, task)
+
+	for _, t := range []struct {
+		zones    string
+		expected string
+	}{
+		{"zones=zone3", "zone3"},
+		{"zones=^zone1,^zone3", "zone4"},
+	} {
+		cons := constraints.MustParse(s.defaultConstraints.String(), t.zones)
+		m, err := s.addMachineWithConstraints(cons)
+		c.Assert(err, jc.ErrorIsNil)
+		s.checkStartInstanceCustom(c, m, "pork", cons, nil, nil, nil, nil, nil, true)
+		machineAZ, err := m.AvailabilityZone()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(machineAZ, gc.Equals, t.expected)
+	}
+}
+
 func (s *ProvisionerSuite) TestProvisioningMachinesNoZonedEnviron(c *gc.C) {
 	// Make sure the provisioner still works for providers which do not
 	// implement the ZonedEnviron interface.
@@ -66,9 +66,10 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	}
 
 	w, err := config.NewWorker(Config{
-		ModelUUID:                agent.CurrentConfig().Model().Id(),
-		RelationsFacade:          facade,
-		NewRemoteModelFacadeFunc: remoteRelationsFacadeForModelFunc(config.NewControllerConnection),
+		ModelUUID:                  agent.CurrentConfig().Model().Id(),
+		RelationsFacade:            facade,
+		NewRemoteModelFacadeFunc:   remoteRelationsFacadeForModelFunc(config.NewControllerConnection),
+		NewOfferRedirectFacadeFunc: offerRedirectFacadeFunc(config.NewControllerConnection),
 		Clock: clock.WallClock,
 	})
 	if err != nil {
@@ -16,6 +16,7 @@ import (
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/worker/remoterelations"
@@ -250,6 +251,56 @@ func (m *mockRelationsFacade) SetRemoteApplicationStatus(applicationName string,
 	return nil
 }
 
+func (m *mockRelationsFacade) SetRemoteRelationHealth(entity names.Tag, exchangeErr error, controllerReachable bool) error {
+	var errMessage string
+	if exchangeErr != nil {
+		errMessage = exchangeErr.Error()
+	}
+	m.stub.MethodCall(m, "SetRemoteRelationHealth", entity, errMessage, controllerReachable)
+	return nil
+}
+
+func (m *mockRelationsFacade) UpdateControllerForModel(controller crossmodel.ControllerInfo, modelUUID string) error {
+	m.stub.MethodCall(m, "UpdateControllerForModel", controller, modelUUID)
+	if err := m.stub.NextErr(); err != nil {
+		return err
+	}
+	m.controllerInfo[modelUUID] = &api.Info{
+		Addrs:  controller.Addrs,
+		CACert: controller.CACert,
+	}
+	return nil
+}
+
+type mockOfferRedirectFacade struct {
+	stub      *testing.Stub
+	redirects map[string]*crossmodel.ControllerInfo
+}
+
+func newMockOfferRedirectFacade(stub *testing.Stub) *mockOfferRedirectFacade {
+	return &mockOfferRedirectFacade{
+		stub:      stub,
+		redirects: make(map[string]*crossmodel.ControllerInfo),
+	}
+}
+
+func (m *mockOfferRedirectFacade) Close() error {
+	m.stub.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockOfferRedirectFacade) OfferRedirect(offerUUID string) (*crossmodel.ControllerInfo, error) {
+	m.stub.MethodCall(m, "OfferRedirect", offerUUID)
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	info, ok := m.redirects[offerUUID]
+	if !ok {
+		return nil, errors.NotFoundf("redirect for offer %q", offerUUID)
+	}
+	return info, nil
+}
+
 type mockRemoteRelationsFacade struct {
 	mu   sync.Mutex
 	stub *testing.Stub
@@ -10,9 +10,11 @@ import (
 	"gopkg.in/juju/worker.v1/catacomb"
 	"gopkg.in/macaroon.v2-unstable"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/rpc"
 )
 
 // remoteApplicationWorker listens for localChanges to relations
@@ -44,6 +46,7 @@ type remoteApplicationWorker struct {
 	remoteModelFacade RemoteModelRelationsFacadeCloser
 
 	newRemoteModelRelationsFacadeFunc newRemoteRelationsFacadeFunc
+	newOfferRedirectFacadeFunc        newOfferRedirectFacadeFunc
 }
 
 // relation holds attributes relevant to a particular
@@ -97,6 +100,69 @@ func (w *remoteApplicationWorker) checkOfferPermissionDenied(err error, appToken
 	}
 }
 
+// relationExchanged records the outcome of exchanging a settings change
+// with the offering model, for the relation the change pertains to.
+func (w *remoteApplicationWorker) relationExchanged(
+	relations map[string]*relation, change params.RemoteRelationChangeEvent,
+	exchangeErr error, controllerReachable bool,
+) {
+	if change.Life != "" && change.Life != params.Alive {
+		// The relation is going away, so its health is of no interest.
+		return
+	}
+	for key, r := range relations {
+		if r.relationToken == change.RelationToken {
+			w.setRemoteRelationHealth(names.NewRelationTag(key), exchangeErr, controllerReachable)
+			return
+		}
+	}
+}
+
+// setRemoteRelationHealth records the outcome of exchanging settings with
+// the offering model for a relation, or for all the relations of a remote
+// application. The health is informational only, so failing to record it
+// is logged rather than returned.
+func (w *remoteApplicationWorker) setRemoteRelationHealth(tag names.Tag, exchangeErr error, controllerReachable bool) {
+	err := w.localModelFacade.SetRemoteRelationHealth(tag, exchangeErr, controllerReachable)
+	if errors.IsNotImplemented(err) {
+		logger.Debugf("cannot record health of %v: %v", tag.Id(), err)
+	} else if err != nil {
+		logger.Warningf("cannot record health of %v: %v", tag.Id(), err)
+	}
+}
+
+// controllerReachable reports whether the offering controller could be
+// reached, given the error returned by a call to it. Errors returned by
+// the controller itself mean it was reachable.
+func controllerReachable(err error) bool {
+	switch errors.Cause(err).(type) {
+	case *params.Error, *rpc.RequestError:
+		return true
+	}
+	return err == nil
+}
+
+// followOfferRedirect asks the controller which used to host the offering
+// model where the offer has moved to, records the new controller for the
+// model and returns the API info for connecting to the model there.
+func (w *remoteApplicationWorker) followOfferRedirect(apiInfo *api.Info) (*api.Info, error) {
+	facade, err := w.newOfferRedirectFacadeFunc(apiInfo)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening facade to remote controller")
+	}
+	defer facade.Close()
+
+	controllerInfo, err := facade.OfferRedirect(w.offerUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Infof("offer %v has moved to controller %v", w.offerUUID, controllerInfo.ControllerTag.Id())
+	if err := w.localModelFacade.UpdateControllerForModel(*controllerInfo, w.remoteModelUUID); err != nil {
+		return nil, errors.Annotate(err, "updating controller for remote model")
+	}
+	return w.localModelFacade.ControllerAPIInfoForModel(w.remoteModelUUID)
+}
+
 func (w *remoteApplicationWorker) loop() (err error) {
 	// On the consuming side, watch for status changes to the offer.
 	var offerStatusChanges watcher.OfferStatusChannel
@@ -108,7 +174,23 @@ func (w *remoteApplicationWorker) loop() (err error) {
 		}
 
 		w.remoteModelFacade, err = w.newRemoteModelRelationsFacadeFunc(apiInfo)
+		if params.IsCodeModelNotFound(err) {
+			// The offering model may have been migrated to another
+			// controller, in which case we follow it there. Until the
+			// old controller has removed the model, calls to it fail
+			// as the migration is in progress and the worker restarts.
+			// Relation macaroons minted by the old controller are
+			// re-minted by the new one when the consumer discharges
+			// the caveats it returns.
+			redirectInfo, redirectErr := w.followOfferRedirect(apiInfo)
+			if redirectErr != nil {
+				logger.Debugf("cannot follow redirect for offer %v: %v", w.offerUUID, redirectErr)
+			} else {
+				w.remoteModelFacade, err = w.newRemoteModelRelationsFacadeFunc(redirectInfo)
+			}
+		}
 		if err != nil {
+			w.setRemoteRelationHealth(names.NewApplicationTag(w.applicationName), err, false)
 			return errors.Annotate(err, "opening facade to remote model")
 		}
 
@@ -126,6 +208,7 @@ func (w *remoteApplicationWorker) loop() (err error) {
 		offerStatusWatcher, err := w.remoteModelFacade.WatchOfferStatus(arg)
 		if err != nil {
 			w.checkOfferPermissionDenied(err, "", "")
+			w.setRemoteRelationHealth(names.NewApplicationTag(w.applicationName), err, controllerReachable(err))
 			return errors.Annotate(err, "watching status for offer")
 		}
 		if err := w.catacomb.Add(offerStatusWatcher); err != nil {
@@ -159,13 +242,19 @@ func (w *remoteApplicationWorker) loop() (err error) {
 			logger.Debugf("local relation units changed -> publishing: %#v", change)
 			if err := w.remoteModelFacade.PublishRelationChange(change); err != nil {
 				w.checkOfferPermissionDenied(err, change.ApplicationToken, change.RelationToken)
+				w.relationExchanged(relations, change, err, controllerReachable(err))
 				return errors.Annotatef(err, "publishing relation change %+v to remote model %v", change, w.remoteModelUUID)
 			}
+			w.relationExchanged(relations, change, nil, true)
 		case change := <-w.remoteRelationChanges:
 			logger.Debugf("remote relation units changed -> consuming: %#v", change)
+			// The change came from the offering controller, so it's
+			// reachable even if consuming the change fails.
 			if err := w.localModelFacade.ConsumeRemoteRelationChange(change); err != nil {
+				w.relationExchanged(relations, change, err, true)
 				return errors.Annotatef(err, "consuming relation change %+v from remote model %v", change, w.remoteModelUUID)
 			}
+			w.relationExchanged(relations, change, nil, true)
 		case changes := <-offerStatusChanges:
 			logger.Debugf("offer status changed: %#v", changes)
 			for _, change := range changes {
@@ -495,11 +584,13 @@ func (w *remoteApplicationWorker) registerRemoteRelation(
 	}
 	remoteRelation, err := w.remoteModelFacade.RegisterRemoteRelations(arg)
 	if err != nil {
+		w.setRemoteRelationHealth(relationTag, err, controllerReachable(err))
 		return fail(errors.Trace(err))
 	}
 	// remoteAppIds is a slice but there's only one item
 	// as we currently only register one remote application
 	if err := remoteRelation[0].Error; err != nil {
+		w.setRemoteRelationHealth(relationTag, err, true)
 		return fail(errors.Trace(err))
 	}
 	if err := results[0].Error; err != nil && !params.IsCodeAlreadyExists(err) {
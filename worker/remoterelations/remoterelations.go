@@ -17,6 +17,7 @@ import (
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 )
@@ -109,16 +110,39 @@ type RemoteRelationsFacade interface {
 
 	// SetRemoteApplicationStatus sets the status for the specified remote application.
 	SetRemoteApplicationStatus(applicationName string, status status.Status, message string) error
+
+	// SetRemoteRelationHealth records the outcome of an attempt to exchange
+	// settings with the offering model for the specified relation, or for
+	// all the relations of the specified remote application.
+	SetRemoteRelationHealth(entity names.Tag, exchangeErr error, controllerReachable bool) error
+
+	// UpdateControllerForModel records that the specified offering model
+	// is now hosted by the specified controller.
+	UpdateControllerForModel(controller crossmodel.ControllerInfo, modelUUID string) error
+}
+
+// OfferRedirectFacadeCloser instances find where offers hosted on a
+// remote controller have moved to, after the models hosting them were
+// migrated to another controller.
+type OfferRedirectFacadeCloser interface {
+	io.Closer
+
+	// OfferRedirect returns the controller now hosting the offer with
+	// the specified UUID.
+	OfferRedirect(offerUUID string) (*crossmodel.ControllerInfo, error)
 }
 
 type newRemoteRelationsFacadeFunc func(*api.Info) (RemoteModelRelationsFacadeCloser, error)
 
+type newOfferRedirectFacadeFunc func(*api.Info) (OfferRedirectFacadeCloser, error)
+
 // Config defines the operation of a Worker.
 type Config struct {
-	ModelUUID                string
-	RelationsFacade          RemoteRelationsFacade
-	NewRemoteModelFacadeFunc newRemoteRelationsFacadeFunc
-	Clock                    clock.Clock
+	ModelUUID                  string
+	RelationsFacade            RemoteRelationsFacade
+	NewRemoteModelFacadeFunc   newRemoteRelationsFacadeFunc
+	NewOfferRedirectFacadeFunc newOfferRedirectFacadeFunc
+	Clock                      clock.Clock
 }
 
 // Validate returns an error if config cannot drive a Worker.
@@ -132,6 +156,9 @@ func (config Config) Validate() error {
 	if config.NewRemoteModelFacadeFunc == nil {
 		return errors.NotValidf("nil Remote Model Facade func")
 	}
+	if config.NewOfferRedirectFacadeFunc == nil {
+		return errors.NotValidf("nil Offer Redirect Facade func")
+	}
 	if config.Clock == nil {
 		return errors.NotValidf("nil Clock")
 	}
@@ -264,6 +291,7 @@ func (w *Worker) handleApplicationChanges(applicationIds []string) error {
 				remoteRelationChanges:             make(chan params.RemoteRelationChangeEvent),
 				localModelFacade:                  w.config.RelationsFacade,
 				newRemoteModelRelationsFacadeFunc: w.config.NewRemoteModelFacadeFunc,
+				newOfferRedirectFacadeFunc:        w.config.NewOfferRedirectFacadeFunc,
 			}
 			if err := catacomb.Invoke(catacomb.Plan{
 				Site: &appWorker.catacomb,
@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
@@ -38,6 +39,7 @@ type remoteRelationsSuite struct {
 	authorizer            *apiservertesting.FakeAuthorizer
 	relationsFacade       *mockRelationsFacade
 	remoteRelationsFacade *mockRemoteRelationsFacade
+	offerRedirectFacade   *mockOfferRedirectFacade
 	config                remoterelations.Config
 	stub                  *jujutesting.Stub
 }
@@ -48,12 +50,16 @@ func (s *remoteRelationsSuite) SetUpTest(c *gc.C) {
 	s.stub = new(jujutesting.Stub)
 	s.relationsFacade = newMockRelationsFacade(s.stub)
 	s.remoteRelationsFacade = newMockRemoteRelationsFacade(s.stub)
+	s.offerRedirectFacade = newMockOfferRedirectFacade(s.stub)
 	s.config = remoterelations.Config{
 		ModelUUID:       "local-model-uuid",
 		RelationsFacade: s.relationsFacade,
 		NewRemoteModelFacadeFunc: func(*api.Info) (remoterelations.RemoteModelRelationsFacadeCloser, error) {
 			return s.remoteRelationsFacade, nil
 		},
+		NewOfferRedirectFacadeFunc: func(*api.Info) (remoterelations.OfferRedirectFacadeCloser, error) {
+			return s.offerRedirectFacade, nil
+		},
 		Clock: testclock.NewClock(time.Time{}),
 	}
 }
@@ -178,6 +184,67 @@ func (s *remoteRelationsSuite) TestOfferStatusChange(c *gc.C) {
 	s.waitForWorkerStubCalls(c, expected)
 }
 
+func (s *remoteRelationsSuite) TestRemoteControllerUnreachable(c *gc.C) {
+	s.config.NewRemoteModelFacadeFunc = func(*api.Info) (remoterelations.RemoteModelRelationsFacadeCloser, error) {
+		return nil, errors.New("connection refused")
+	}
+	s.relationsFacade.remoteApplications["db2"] = newMockRemoteApplication("db2", "db2url")
+	s.relationsFacade.controllerInfo["remote-model-uuid"] = &api.Info{
+		Addrs: []string{"1.2.3.4:1234"}, CACert: coretesting.CACert}
+
+	w, err := remoterelations.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.relationsFacade.remoteApplicationsWatcher.changes <- []string{"db2"}
+	expected := []jujutesting.StubCall{
+		{"WatchRemoteApplications", nil},
+		{"RemoteApplications", []interface{}{[]string{"db2"}}},
+		{"WatchRemoteApplicationRelations", []interface{}{"db2"}},
+		{"ControllerAPIInfoForModel", []interface{}{"remote-model-uuid"}},
+		{"SetRemoteRelationHealth", []interface{}{names.NewApplicationTag("db2"), "connection refused", false}},
+	}
+	s.waitForWorkerStubCalls(c, expected)
+}
+
+func (s *remoteRelationsSuite) TestRemoteModelMigrated(c *gc.C) {
+	s.config.NewRemoteModelFacadeFunc = func(info *api.Info) (remoterelations.RemoteModelRelationsFacadeCloser, error) {
+		if info.Addrs[0] == "1.2.3.4:1234" {
+			return nil, &params.Error{Code: params.CodeModelNotFound, Message: "model not found"}
+		}
+		return s.remoteRelationsFacade, nil
+	}
+	s.relationsFacade.remoteApplications["db2"] = newMockRemoteApplication("db2", "db2url")
+	s.relationsFacade.controllerInfo["remote-model-uuid"] = &api.Info{
+		Addrs: []string{"1.2.3.4:1234"}, CACert: coretesting.CACert}
+	target := crossmodel.ControllerInfo{
+		ControllerTag: coretesting.ControllerTag,
+		Addrs:         []string{"4.3.2.1:1234"},
+		CACert:        coretesting.CACert,
+	}
+	s.offerRedirectFacade.redirects["offer-db2-uuid"] = &target
+
+	w, err := remoterelations.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	mac, err := apitesting.NewMacaroon("test")
+	c.Assert(err, jc.ErrorIsNil)
+	s.relationsFacade.remoteApplicationsWatcher.changes <- []string{"db2"}
+	expected := []jujutesting.StubCall{
+		{"WatchRemoteApplications", nil},
+		{"RemoteApplications", []interface{}{[]string{"db2"}}},
+		{"WatchRemoteApplicationRelations", []interface{}{"db2"}},
+		{"ControllerAPIInfoForModel", []interface{}{"remote-model-uuid"}},
+		{"OfferRedirect", []interface{}{"offer-db2-uuid"}},
+		{"UpdateControllerForModel", []interface{}{target, "remote-model-uuid"}},
+		{"ControllerAPIInfoForModel", []interface{}{"remote-model-uuid"}},
+		{"Close", nil},
+		{"WatchOfferStatus", []interface{}{"offer-db2-uuid", macaroon.Slice{mac}}},
+	}
+	s.waitForWorkerStubCalls(c, expected)
+}
+
 func (s *remoteRelationsSuite) assertRemoteRelationsWorkers(c *gc.C) worker.Worker {
 	s.relationsFacade.relations["db2:db django:db"] = newMockRelation(123)
 	w := s.assertRemoteApplicationWorkers(c)
@@ -304,6 +371,7 @@ func (s *remoteRelationsSuite) TestRemoteRelationsRevoked(c *gc.C) {
 			LocalEndpointName: "data",
 			Macaroons:         macaroon.Slice{mac},
 		}}}},
+		{"SetRemoteRelationHealth", []interface{}{relTag, "message", true}},
 		{"SetRemoteApplicationStatus", []interface{}{"db2", "error", "message"}},
 		{"Close", nil},
 	}
@@ -407,6 +475,7 @@ func (s *remoteRelationsSuite) TestLocalRelationsChangedNotifies(c *gc.C) {
 				Macaroons:     macaroon.Slice{mac},
 			},
 		}},
+		{"SetRemoteRelationHealth", []interface{}{names.NewRelationTag("db2:db django:db"), "", true}},
 	}
 	s.waitForWorkerStubCalls(c, expected)
 }
@@ -442,6 +511,7 @@ func (s *remoteRelationsSuite) TestRemoteRelationsChangedConsumes(c *gc.C) {
 				Macaroons:     macaroon.Slice{mac},
 			},
 		}},
+		{"SetRemoteRelationHealth", []interface{}{names.NewRelationTag("db2:db django:db"), "", true}},
 	}
 	s.waitForWorkerStubCalls(c, expected)
 }
@@ -493,6 +563,7 @@ func (s *remoteRelationsSuite) TestRemoteRelationsChangedError(c *gc.C) {
 				Macaroons:        macaroon.Slice{apiMac},
 			},
 		}},
+		{"SetRemoteRelationHealth", []interface{}{names.NewRelationTag("db2:db django:db"), "failed", false}},
 		{"Close", nil},
 	}
 
@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/crosscontroller"
 	"github.com/juju/juju/api/crossmodelrelations"
 	"github.com/juju/juju/api/remoterelations"
 	"github.com/juju/juju/worker/apicaller"
@@ -58,6 +59,26 @@ func remoteRelationsFacadeForModelFunc(
 	}
 }
 
+// offerRedirectFacadeFunc returns a function that can be used to
+// construct instances which find where offers hosted on a remote
+// controller have moved to.
+func offerRedirectFacadeFunc(
+	connectionFunc apicaller.NewExternalControllerConnectionFunc,
+) newOfferRedirectFacadeFunc {
+	return func(apiInfo *api.Info) (OfferRedirectFacadeCloser, error) {
+		// Redirects are served at the controller level, since the
+		// model hosting the offer is no longer on the controller.
+		controllerInfo := *apiInfo
+		controllerInfo.ModelTag = names.ModelTag{}
+		controllerInfo.Tag = names.NewUserTag(api.AnonymousUsername)
+		conn, err := connectionFunc(&controllerInfo)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return crosscontroller.NewClient(conn), nil
+	}
+}
+
 type remoteModelRelationsFacadeCloser struct {
 	RemoteModelRelationsFacade
 	conn io.Closer
@@ -0,0 +1,134 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/network"
+)
+
+const (
+	// probeReachable is the result of a successful probe.
+	probeReachable = "reachable"
+
+	// probeRefused is the result of a probe whose connection was
+	// refused. The target answered, so the network path to it works,
+	// but nothing accepted the connection.
+	probeRefused = "refused"
+
+	// hostProbeKey is the result key used when a target has no opened
+	// ports, in which case the target host itself is probed.
+	hostProbeKey = "host"
+
+	// hostProbePort is the port a target host is probed on.
+	hostProbePort = 22
+
+	// defaultProbeTimeout is used when the action has no timeout.
+	defaultProbeTimeout = 5 * time.Second
+)
+
+// dialTimeout is used to probe targets. It is a variable so that it can be
+// patched in tests.
+var dialTimeout = net.DialTimeout
+
+// runCheckNetworkAction is the function that executes when a
+// juju-check-network action is ran. It probes the opened ports of each
+// target unit, recording in the action results whether each of them is
+// reachable.
+func (runner *runner) runCheckNetworkAction() error {
+	params, err := runner.context.ActionParams()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	targets, ok := params["targets"].([]interface{})
+	if !ok {
+		return runner.context.Flush("juju-check-network", errors.New("no targets parameter to juju-check-network action"))
+	}
+	// The timeout is passed in in nanoseconds, but due to serialization
+	// it comes out as float64.
+	timeout := defaultProbeTimeout
+	if t, ok := params["timeout"].(float64); ok && t > 0 {
+		timeout = time.Duration(t)
+	}
+
+	for _, t := range targets {
+		target, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		unit, _ := target["unit"].(string)
+		address, _ := target["address"].(string)
+		if unit == "" || address == "" {
+			continue
+		}
+		results := map[string]string{"address": address}
+		ports, _ := target["ports"].([]interface{})
+		if len(ports) == 0 {
+			results[hostProbeKey] = probeHost(address, timeout)
+		}
+		for _, p := range ports {
+			if port, ok := p.(string); ok {
+				results[port] = probePort(address, port, timeout)
+			}
+		}
+		for key, value := range results {
+			if err := runner.context.UpdateActionResults([]string{"probes", unit, key}, value); err != nil {
+				return runner.context.Flush("juju-check-network", errors.Trace(err))
+			}
+		}
+	}
+	return runner.context.Flush("juju-check-network", nil)
+}
+
+// probePort returns whether a connection can be made to the given port
+// range of the address. Only the first port of a range is probed.
+func probePort(address, port string, timeout time.Duration) string {
+	portRange, err := network.ParsePortRange(port)
+	if err != nil {
+		return "invalid port: " + err.Error()
+	}
+	if portRange.Protocol != "tcp" {
+		return "not probed: only tcp ports can be probed"
+	}
+	return probeTCP(address, portRange.FromPort, timeout)
+}
+
+// probeHost returns whether the address answers on the network, by
+// probing its ssh port.
+func probeHost(address string, timeout time.Duration) string {
+	return probeTCP(address, hostProbePort, timeout)
+}
+
+// probeTCP returns whether a TCP connection can be made to the port of
+// the address, reporting refused connections apart from unreachable
+// addresses.
+func probeTCP(address string, port int, timeout time.Duration) string {
+	conn, err := dialTimeout("tcp", net.JoinHostPort(address, strconv.Itoa(port)), timeout)
+	if err == nil {
+		conn.Close()
+		return probeReachable
+	}
+	if isConnectionRefused(err) {
+		return probeRefused
+	}
+	return "unreachable: " + err.Error()
+}
+
+func isConnectionRefused(err error) bool {
+	opErr, ok := err.(*net.OpError)
+	if !ok {
+		return false
+	}
+	if sysErr, ok := opErr.Err.(*os.SyscallError); ok {
+		return sysErr.Err == syscall.ECONNREFUSED
+	}
+	return false
+}
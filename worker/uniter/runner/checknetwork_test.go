@@ -0,0 +1,123 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner_test
+
+import (
+	"net"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/juju/errors"
+	envtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner"
+	"github.com/juju/juju/worker/uniter/runner/context"
+	runnertesting "github.com/juju/juju/worker/uniter/runner/testing"
+)
+
+type CheckNetworkSuite struct {
+	envtesting.IsolationSuite
+	paths runnertesting.RealPaths
+}
+
+var _ = gc.Suite(&CheckNetworkSuite{})
+
+func (s *CheckNetworkSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.paths = runnertesting.NewRealPaths(c)
+}
+
+// checkNetworkContext records the nested action results that a
+// juju-check-network action sets.
+type checkNetworkContext struct {
+	MockContext
+	results map[string]string
+}
+
+func (ctx *checkNetworkContext) UpdateActionResults(keys []string, value string) error {
+	ctx.results[strings.Join(keys, ".")] = value
+	return nil
+}
+
+func (s *CheckNetworkSuite) TestRunCheckNetworkAction(c *gc.C) {
+	var dialed []string
+	s.PatchValue(runner.DialTimeout, func(network, address string, timeout time.Duration) (net.Conn, error) {
+		c.Check(network, gc.Equals, "tcp")
+		c.Check(timeout, gc.Equals, time.Second)
+		dialed = append(dialed, address)
+		switch address {
+		case "10.0.0.1:3306":
+			client, server := net.Pipe()
+			server.Close()
+			return client, nil
+		case "10.0.0.2:22", "10.0.0.4:8080":
+			return nil, &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+		}
+		return nil, errors.New("i/o timeout")
+	})
+	ctx := &checkNetworkContext{
+		MockContext: MockContext{
+			actionData: &context.ActionData{},
+			actionParams: map[string]interface{}{
+				"relation": "mysql:server wordpress:db",
+				"timeout":  float64(time.Second),
+				"targets": []interface{}{
+					map[string]interface{}{
+						"unit":    "mysql/0",
+						"address": "10.0.0.1",
+						"ports":   []interface{}{"3306/tcp", "53/udp"},
+					},
+					map[string]interface{}{
+						"unit":    "wordpress/0",
+						"address": "10.0.0.2",
+					},
+					map[string]interface{}{
+						"unit":    "wordpress/1",
+						"address": "10.0.0.3",
+						"ports":   []interface{}{"80-88/tcp"},
+					},
+					map[string]interface{}{
+						"unit":    "wordpress/2",
+						"address": "10.0.0.4",
+						"ports":   []interface{}{"8080/tcp"},
+					},
+				},
+			},
+		},
+		results: make(map[string]string),
+	}
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-check-network")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ctx.flushBadge, gc.Equals, "juju-check-network")
+	c.Check(ctx.flushFailure, gc.IsNil)
+	c.Check(dialed, jc.SameContents, []string{"10.0.0.1:3306", "10.0.0.2:22", "10.0.0.3:80", "10.0.0.4:8080"})
+	c.Check(ctx.results, jc.DeepEquals, map[string]string{
+		"probes.mysql/0.address":       "10.0.0.1",
+		"probes.mysql/0.3306/tcp":      "reachable",
+		"probes.mysql/0.53/udp":        "not probed: only tcp ports can be probed",
+		"probes.wordpress/0.address":   "10.0.0.2",
+		"probes.wordpress/0.host":      "refused",
+		"probes.wordpress/1.address":   "10.0.0.3",
+		"probes.wordpress/1.80-88/tcp": "unreachable: i/o timeout",
+		"probes.wordpress/2.address":   "10.0.0.4",
+		"probes.wordpress/2.8080/tcp":  "refused",
+	})
+}
+
+func (s *CheckNetworkSuite) TestRunCheckNetworkActionNoTargets(c *gc.C) {
+	ctx := &checkNetworkContext{
+		MockContext: MockContext{
+			actionData:   &context.ActionData{},
+			actionParams: map[string]interface{}{},
+		},
+		results: make(map[string]string),
+	}
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-check-network")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ctx.flushFailure, gc.ErrorMatches, "no targets parameter to juju-check-network action")
+}
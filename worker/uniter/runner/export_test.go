@@ -12,6 +12,7 @@ var (
 	SearchHook              = searchHook
 	HookCommand             = hookCommand
 	LookPath                = lookPath
+	DialTimeout             = &dialTimeout
 )
 
 func RunnerPaths(rnr Runner) context.Paths {
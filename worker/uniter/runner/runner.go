@@ -193,8 +193,11 @@ func (runner *runner) RunAction(actionName string) error {
 	if _, err := runner.context.ActionData(); err != nil {
 		return errors.Trace(err)
 	}
-	if actionName == actions.JujuRunActionName {
+	switch actionName {
+	case actions.JujuRunActionName:
 		return runner.runJujuRunAction()
+	case actions.JujuCheckNetworkActionName:
+		return runner.runCheckNetworkAction()
 	}
 	return runner.runCharmHookWithLocation(actionName, "actions")
 }